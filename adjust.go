@@ -15,9 +15,15 @@ import (
 	"bytes"
 	"encoding/xml"
 	"io"
+	"regexp"
+	"strconv"
 	"strings"
 )
 
+// formulaRefRegexp matches the cell reference or range reference in formulas
+// with an optional worksheet name prefix.
+var formulaRefRegexp = regexp.MustCompile(`(?:('(?:[^']|'')+'|[\w.]+)!)?(\$?[A-Za-z]{1,3}\$?[0-9]+(?::\$?[A-Za-z]{1,3}\$?[0-9]+)?)`)
+
 type adjustDirection bool
 
 const (
@@ -47,7 +53,9 @@ func (f *File) adjustHelper(sheet string, dir adjustDirection, num, offset int)
 		f.adjustColDimensions(ws, num, offset)
 	}
 	f.adjustHyperlinks(ws, sheet, dir, num, offset)
-	f.adjustTable(ws, sheet, dir, num, offset)
+	if err = f.adjustTable(ws, sheet, dir, num, offset); err != nil {
+		return err
+	}
 	if err = f.adjustMergeCells(ws, dir, num, offset); err != nil {
 		return err
 	}
@@ -146,10 +154,13 @@ func (f *File) adjustHyperlinks(ws *xlsxWorksheet, sheet string, dir adjustDirec
 }
 
 // adjustTable provides a function to update the table when inserting or
-// deleting rows or columns.
-func (f *File) adjustTable(ws *xlsxWorksheet, sheet string, dir adjustDirection, num, offset int) {
+// deleting rows or columns. Inserting rows right below the last row of the
+// table which has no totals row appends the rows to the table, and the
+// formulas of the calculated columns will be set on the inserted rows of
+// the table.
+func (f *File) adjustTable(ws *xlsxWorksheet, sheet string, dir adjustDirection, num, offset int) error {
 	if ws.TableParts == nil || len(ws.TableParts.TableParts) == 0 {
-		return
+		return nil
 	}
 	for idx := 0; idx < len(ws.TableParts.TableParts); idx++ {
 		tbl := ws.TableParts.TableParts[idx]
@@ -162,11 +173,11 @@ func (f *File) adjustTable(ws *xlsxWorksheet, sheet string, dir adjustDirection,
 		t := xlsxTable{}
 		if err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(content.([]byte)))).
 			Decode(&t); err != nil && err != io.EOF {
-			return
+			return nil
 		}
 		coordinates, err := areaRefToCoordinates(t.Ref)
 		if err != nil {
-			return
+			return nil
 		}
 		// Remove the table when deleting the header row of the table
 		if dir == rows && num == coordinates[0] {
@@ -175,8 +186,20 @@ func (f *File) adjustTable(ws *xlsxWorksheet, sheet string, dir adjustDirection,
 			idx--
 			continue
 		}
+		// Fill the calculated column formulas when inserting rows inside the
+		// table or appending rows to the table
+		appendRows := dir == rows && offset > 0 && num == coordinates[3]+1 && t.TotalsRowCount == 0
+		fillFormula := dir == rows && offset > 0 && num > coordinates[1] && num <= coordinates[3]
 		coordinates = f.adjustAutoFilterHelper(dir, coordinates, num, offset)
+		if appendRows {
+			coordinates[3] += offset
+		}
 		x1, y1, x2, y2 := coordinates[0], coordinates[1], coordinates[2], coordinates[3]
+		if fillFormula || appendRows {
+			if err = f.fillTableCalculatedColumns(ws, sheet, &t, x1, num, num+offset-1); err != nil {
+				return err
+			}
+		}
 		if y2-y1 < 2 || x2-x1 < 1 {
 			ws.TableParts.TableParts = append(ws.TableParts.TableParts[:idx], ws.TableParts.TableParts[idx+1:]...)
 			ws.TableParts.Count = len(ws.TableParts.TableParts)
@@ -191,6 +214,33 @@ func (f *File) adjustTable(ws *xlsxWorksheet, sheet string, dir adjustDirection,
 		table, _ := xml.Marshal(t)
 		f.saveFileList(tableXML, table)
 	}
+	return nil
+}
+
+// fillTableCalculatedColumns provides a function to set the formulas of the
+// calculated columns of the table on the given rows, the first column of the
+// table is specified by the col.
+func (f *File) fillTableCalculatedColumns(ws *xlsxWorksheet, sheet string, t *xlsxTable, col, startRow, endRow int) error {
+	if t.TableColumns == nil {
+		return nil
+	}
+	// Make the rows contiguous after the row numbers has been adjusted
+	checkSheet(ws)
+	for idx, column := range t.TableColumns.TableColumn {
+		if column.CalculatedColumnFormula == nil || column.CalculatedColumnFormula.Content == "" {
+			continue
+		}
+		for row := startRow; row <= endRow; row++ {
+			cell, err := CoordinatesToCellName(col+idx, row)
+			if err != nil {
+				return err
+			}
+			if err = f.SetCellFormula(sheet, cell, column.CalculatedColumnFormula.Content); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
 }
 
 // adjustAutoFilter provides a function to update the auto filter when
@@ -356,3 +406,358 @@ func (f *File) adjustCalcChain(dir adjustDirection, num, offset, sheetID int) er
 	}
 	return nil
 }
+
+// insertCells provides a function to shift the cells of the worksheet right
+// or down by given coordinates of the inserted blank cells, and update the
+// references of the formulas, hyperlinks, merged cells, calculation chain,
+// auto filter, conditional formats, data validations and tables which
+// pointing to the shifted cells.
+func (f *File) insertCells(ws *xlsxWorksheet, sheet string, coordinates []int, shift ShiftDirection) error {
+	x1, y1, x2, y2 := coordinates[0], coordinates[1], coordinates[2], coordinates[3]
+	area, offset := []int{x1, y1, MaxColumns, y2}, x2-x1+1
+	if shift == ShiftCellsDown {
+		area, offset = []int{x1, y1, x2, TotalRows}, y2-y1+1
+	}
+	adjust := func(rect []int) {
+		if shift == ShiftCellsDown {
+			if rect[0] >= x1 && rect[2] <= x2 {
+				for i := 1; i < 4; i += 2 {
+					if rect[i] >= y1 {
+						rect[i] += offset
+					}
+				}
+			}
+			return
+		}
+		if rect[1] >= y1 && rect[3] <= y2 {
+			for i := 0; i < 4; i += 2 {
+				if rect[i] >= x1 {
+					rect[i] += offset
+				}
+			}
+		}
+	}
+	if err := f.checkInsertCellsMergeCells(ws, area); err != nil {
+		return err
+	}
+	tables, err := f.checkInsertCellsTables(ws, sheet, coordinates, shift)
+	if err != nil {
+		return err
+	}
+	if shift == ShiftCellsDown {
+		if err := f.shiftCellsDown(ws, x1, x2, y1, offset); err != nil {
+			return err
+		}
+	} else {
+		if err := f.shiftCellsRight(ws, x1, y1, y2, offset); err != nil {
+			return err
+		}
+	}
+	for rowIdx := range ws.SheetData.Row {
+		for colIdx := range ws.SheetData.Row[rowIdx].C {
+			if c := &ws.SheetData.Row[rowIdx].C[colIdx]; c.F != nil {
+				c.F.Content = adjustFormulaRef(c.F.Content, sheet, adjust)
+				if c.F.Ref != "" {
+					c.F.Ref = adjustRangeRef(c.F.Ref, adjust)
+				}
+			}
+		}
+	}
+	if ws.Hyperlinks != nil {
+		for i := range ws.Hyperlinks.Hyperlink {
+			ws.Hyperlinks.Hyperlink[i].Ref = adjustRangeRef(ws.Hyperlinks.Hyperlink[i].Ref, adjust)
+		}
+	}
+	if ws.MergeCells != nil {
+		for _, mergeCell := range ws.MergeCells.Cells {
+			mergeCell.Ref, mergeCell.rect = adjustRangeRef(mergeCell.Ref, adjust), nil
+		}
+	}
+	if f.CalcChain != nil {
+		sheetID := f.getSheetID(sheet)
+		for i := range f.CalcChain.C {
+			if f.CalcChain.C[i].I == sheetID {
+				f.CalcChain.C[i].R = adjustRangeRef(f.CalcChain.C[i].R, adjust)
+			}
+		}
+	}
+	if ws.AutoFilter != nil {
+		ws.AutoFilter.Ref = adjustRangeRef(ws.AutoFilter.Ref, adjust)
+	}
+	for _, cf := range ws.ConditionalFormatting {
+		cf.SQRef = adjustSqref(cf.SQRef, adjust)
+		for _, rule := range cf.CfRule {
+			for i := range rule.Formula {
+				rule.Formula[i] = adjustFormulaRef(rule.Formula[i], sheet, adjust)
+			}
+		}
+	}
+	if ws.DataValidations != nil {
+		for _, dv := range ws.DataValidations.DataValidation {
+			dv.Sqref = adjustSqref(dv.Sqref, adjust)
+			dv.Formula1 = adjustFormulaRef(dv.Formula1, sheet, adjust)
+			dv.Formula2 = adjustFormulaRef(dv.Formula2, sheet, adjust)
+		}
+	}
+	for tableXML, t := range tables {
+		rect, _ := areaRefToCoordinates(t.Ref)
+		// Fill the calculated column formulas when inserting rows inside the table
+		if shift == ShiftCellsDown && y1 > rect[1] {
+			if err := f.fillTableCalculatedColumns(ws, sheet, t, rect[0], y1, y2); err != nil {
+				return err
+			}
+		}
+		t.Ref = adjustRangeRef(t.Ref, adjust)
+		if t.AutoFilter != nil {
+			t.AutoFilter.Ref = t.Ref
+		}
+		table, _ := xml.Marshal(t)
+		f.saveFileList(tableXML, table)
+	}
+	f.setInsertCellsStyle(ws, coordinates, shift)
+	return nil
+}
+
+// checkInsertCellsTables provides a function to check if the tables in the
+// worksheet can be shifted by the inserted cells, it returns the tables
+// which need to be updated. When shifting cells down, the inserted cells
+// must cover all columns of the table below them, which will insert rows
+// into the table or move the table down. When shifting cells right, the
+// inserted cells must cover all rows of the table and be on the left of the
+// table, which will move the table right.
+func (f *File) checkInsertCellsTables(ws *xlsxWorksheet, sheet string, coordinates []int, shift ShiftDirection) (map[string]*xlsxTable, error) {
+	tables := make(map[string]*xlsxTable)
+	if ws.TableParts == nil {
+		return tables, nil
+	}
+	x1, y1, x2, y2 := coordinates[0], coordinates[1], coordinates[2], coordinates[3]
+	for _, tbl := range ws.TableParts.TableParts {
+		tableXML := strings.ReplaceAll(f.getSheetRelationshipsTargetByID(sheet, tbl.RID), "..", "xl")
+		content, ok := f.Pkg.Load(tableXML)
+		if !ok {
+			continue
+		}
+		t := xlsxTable{}
+		if err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(content.([]byte)))).
+			Decode(&t); err != nil && err != io.EOF {
+			return tables, err
+		}
+		rect, err := areaRefToCoordinates(t.Ref)
+		if err != nil {
+			return tables, err
+		}
+		_ = sortCoordinates(rect)
+		if shift == ShiftCellsDown {
+			if rect[0] > x2 || x1 > rect[2] || rect[3] < y1 {
+				continue
+			}
+			if rect[0] < x1 || rect[2] > x2 {
+				return tables, ErrInsertCellsTable
+			}
+		} else {
+			if rect[1] > y2 || y1 > rect[3] || rect[2] < x1 {
+				continue
+			}
+			if rect[1] < y1 || rect[3] > y2 || rect[0] < x1 {
+				return tables, ErrInsertCellsTable
+			}
+		}
+		tables[tableXML] = &t
+	}
+	return tables, nil
+}
+
+// setInsertCellsStyle provides a function to set the style of the inserted
+// cells by given coordinates of the inserted cells and the shift direction,
+// the inserted cells inherit the style of the cells above them when shifting
+// cells down, or on the left of them when shifting cells right. The style of
+// the shifted cells will be used for the inserted cells in the first row or
+// column of the worksheet.
+func (f *File) setInsertCellsStyle(ws *xlsxWorksheet, coordinates []int, shift ShiftDirection) {
+	x1, y1, x2, y2 := coordinates[0], coordinates[1], coordinates[2], coordinates[3]
+	getStyle := func(col, row int) int {
+		if row <= len(ws.SheetData.Row) && col <= len(ws.SheetData.Row[row-1].C) {
+			return ws.SheetData.Row[row-1].C[col-1].S
+		}
+		return 0
+	}
+	for row := y1; row <= y2; row++ {
+		for col := x1; col <= x2; col++ {
+			srcCol, srcRow := col, y1-1
+			if shift == ShiftCellsDown && y1 == 1 {
+				srcRow = y2 + 1
+			}
+			if shift == ShiftCellsRight {
+				if srcCol, srcRow = x1-1, row; x1 == 1 {
+					srcCol = x2 + 1
+				}
+			}
+			if styleID := getStyle(srcCol, srcRow); styleID != 0 {
+				prepareSheetXML(ws, col, row)
+				ws.SheetData.Row[row-1].C[col-1].S = styleID
+			}
+		}
+	}
+}
+
+// adjustSqref provides a function to update each cell reference or range
+// reference in the space-separated sequence of references by the adjust
+// function.
+func adjustSqref(sqref string, adjust func(rect []int)) string {
+	refs := strings.Fields(sqref)
+	for i, ref := range refs {
+		refs[i] = adjustRangeRef(ref, adjust)
+	}
+	return strings.Join(refs, " ")
+}
+
+// checkInsertCellsMergeCells provides a function to check if any merged cells
+// is only partly inside the given area of the cells to be shifted.
+func (f *File) checkInsertCellsMergeCells(ws *xlsxWorksheet, area []int) error {
+	if ws.MergeCells == nil {
+		return nil
+	}
+	for _, mergeCell := range ws.MergeCells.Cells {
+		rect, err := areaRefToCoordinates(mergeCell.Ref)
+		if err != nil {
+			return err
+		}
+		_ = sortCoordinates(rect)
+		if rect[0] > area[2] || area[0] > rect[2] || rect[1] > area[3] || area[1] > rect[3] {
+			continue
+		}
+		if !cellInRef([]int{rect[0], rect[1]}, area) || !cellInRef([]int{rect[2], rect[3]}, area) {
+			return ErrInsertCellsMergeCell
+		}
+	}
+	return nil
+}
+
+// shiftCellsRight provides a function to move the cells in the given rows
+// which on the right of the given column to right by given offset, and
+// leave blank cells in the origin places.
+func (f *File) shiftCellsRight(ws *xlsxWorksheet, col, startRow, endRow, offset int) error {
+	for row := startRow; row <= endRow && row <= len(ws.SheetData.Row); row++ {
+		if lastCol := len(ws.SheetData.Row[row-1].C); lastCol >= col && lastCol+offset > MaxColumns {
+			return ErrColumnNumber
+		}
+	}
+	for row := startRow; row <= endRow && row <= len(ws.SheetData.Row); row++ {
+		rowData := &ws.SheetData.Row[row-1]
+		lastCol := len(rowData.C)
+		if lastCol < col {
+			continue
+		}
+		fillColumns(rowData, lastCol+offset, row)
+		for c := lastCol; c >= col; c-- {
+			cell := rowData.C[c-1]
+			cell.R, _ = CoordinatesToCellName(c+offset, row)
+			rowData.C[c+offset-1] = cell
+		}
+		for c := col; c < col+offset; c++ {
+			cellName, _ := CoordinatesToCellName(c, row)
+			rowData.C[c-1] = xlsxC{R: cellName}
+		}
+	}
+	return nil
+}
+
+// shiftCellsDown provides a function to move the cells in the given columns
+// which below the given row to down by given offset, and leave blank cells
+// in the origin places.
+func (f *File) shiftCellsDown(ws *xlsxWorksheet, startCol, endCol, row, offset int) error {
+	lastRow := len(ws.SheetData.Row)
+	if lastRow < row {
+		return nil
+	}
+	if lastRow+offset > TotalRows {
+		return ErrMaxRows
+	}
+	prepareSheetXML(ws, 0, lastRow+offset)
+	for r := lastRow; r >= row; r-- {
+		src, dst := &ws.SheetData.Row[r-1], &ws.SheetData.Row[r+offset-1]
+		for c := startCol; c <= endCol; c++ {
+			var cell xlsxC
+			if c <= len(src.C) {
+				cell = src.C[c-1]
+				src.C[c-1] = xlsxC{R: cell.R}
+			} else if c > len(dst.C) {
+				continue
+			}
+			fillColumns(dst, c, r+offset)
+			cell.R, _ = CoordinatesToCellName(c, r+offset)
+			dst.C[c-1] = cell
+		}
+	}
+	return nil
+}
+
+// adjustFormulaRef provides a function to update the cell references in the
+// given formula which belongs to the given worksheet by the adjust function.
+// The references of the other worksheets and the text in the string literals
+// will be kept.
+func adjustFormulaRef(formula, sheet string, adjust func(rect []int)) string {
+	var (
+		buf   strings.Builder
+		parts = strings.Split(formula, "\"")
+	)
+	isNameChar := func(b byte) bool {
+		return b == '_' || b == '.' || ('0' <= b && b <= '9') || ('A' <= b && b <= 'Z') || ('a' <= b && b <= 'z')
+	}
+	for i, part := range parts {
+		if i > 0 {
+			buf.WriteString("\"")
+		}
+		if i%2 == 1 {
+			buf.WriteString(part)
+			continue
+		}
+		var last int
+		for _, loc := range formulaRefRegexp.FindAllStringSubmatchIndex(part, -1) {
+			if (loc[0] > 0 && (isNameChar(part[loc[0]-1]) || part[loc[0]-1] == '$')) ||
+				(loc[1] < len(part) && (isNameChar(part[loc[1]]) || part[loc[1]] == '(' || part[loc[1]] == '!')) {
+				continue
+			}
+			if loc[2] != -1 && !strings.EqualFold(strings.ReplaceAll(strings.Trim(part[loc[2]:loc[3]], "'"), "''", "'"), sheet) {
+				continue
+			}
+			buf.WriteString(part[last:loc[4]])
+			buf.WriteString(adjustRangeRef(part[loc[4]:loc[5]], adjust))
+			last = loc[5]
+		}
+		buf.WriteString(part[last:])
+	}
+	return buf.String()
+}
+
+// adjustRangeRef provides a function to update the cell reference or range
+// reference with or without the absolute reference dollar sign ($) by the
+// adjust function, which receives the coordinates of the range.
+func adjustRangeRef(ref string, adjust func(rect []int)) string {
+	cells := strings.Split(ref, ":")
+	if len(cells) > 2 {
+		return ref
+	}
+	rect, err := areaRangeToCoordinates(strings.ReplaceAll(cells[0], "$", ""),
+		strings.ReplaceAll(cells[len(cells)-1], "$", ""))
+	if err != nil {
+		return ref
+	}
+	adjust(rect)
+	for i, cell := range cells {
+		col, row := rect[i*2], rect[i*2+1]
+		colName, err := ColumnNumberToName(col)
+		if err != nil || row > TotalRows {
+			return ref
+		}
+		signCol, signRow := "", ""
+		if strings.HasPrefix(cell, "$") {
+			signCol = "$"
+		}
+		if strings.LastIndex(cell, "$") > 0 {
+			signRow = "$"
+		}
+		cells[i] = signCol + colName + signRow + strconv.Itoa(row)
+	}
+	return strings.Join(cells, ":")
+}
@@ -199,6 +199,7 @@ type calcContext struct {
 	sync.Mutex
 	entry      string
 	iterations map[string]uint
+	values     map[string]string
 }
 
 // cellRef defines the structure of a cell reference.
@@ -570,6 +571,7 @@ type formulaFuncs struct {
 //	LEFTB
 //	LEN
 //	LENB
+//	LET
 //	LN
 //	LOG
 //	LOG10
@@ -678,6 +680,7 @@ type formulaFuncs struct {
 //	SEC
 //	SECH
 //	SECOND
+//	SEQUENCE
 //	SERIESSUM
 //	SHEET
 //	SHEETS
@@ -689,6 +692,7 @@ type formulaFuncs struct {
 //	SLN
 //	SLOPE
 //	SMALL
+//	SORTBY
 //	SQRT
 //	SQRTPI
 //	STANDARDIZE
@@ -738,6 +742,7 @@ type formulaFuncs struct {
 //	TYPE
 //	UNICHAR
 //	UNICODE
+//	UNIQUE
 //	UPPER
 //	VALUE
 //	VAR
@@ -773,10 +778,19 @@ func (f *File) CalcCellValue(sheet, cell string) (result string, err error) {
 }
 
 func (f *File) calcCellValue(ctx *calcContext, sheet, cell string) (result string, err error) {
-	var (
-		formula string
-		token   formulaArg
-	)
+	var token formulaArg
+	if token, err = f.calcCellArg(ctx, sheet, cell); err != nil {
+		return
+	}
+	result = roundCalcResult(token.Value())
+	return
+}
+
+// calcCellArg provides a function to calculate the formula of the cell by
+// given worksheet name and cell reference, and returns the formula argument
+// of the calculated result.
+func (f *File) calcCellArg(ctx *calcContext, sheet, cell string) (token formulaArg, err error) {
+	var formula string
 	if formula, err = f.GetCellFormula(sheet, cell); err != nil {
 		return
 	}
@@ -785,16 +799,340 @@ func (f *File) calcCellValue(ctx *calcContext, sheet, cell string) (result strin
 	if tokens == nil {
 		return
 	}
-	if token, err = f.evalInfixExp(ctx, sheet, cell, tokens); err != nil {
+	if tokens, err = f.expandLazyFuncTokens(ctx, sheet, cell, tokens, map[string][]efp.Token{}); err != nil {
 		return
 	}
-	result = token.Value()
+	return f.evalInfixExp(ctx, sheet, cell, tokens)
+}
+
+// roundCalcResult provides a function to round the numeric calculated result
+// to 15 significant digits as Excel does.
+func roundCalcResult(result string) string {
 	isNum, precision := isNumeric(result)
 	if isNum && (precision > 15 || precision == 0) {
 		num := roundPrecision(result, -1)
 		result = strings.ToUpper(num)
 	}
-	return
+	return result
+}
+
+// FlattenFormulas provides a function to replace the formulas of the
+// worksheet by given worksheet name with their calculated values, and remove
+// the formulas from the cells. The formulas of all worksheets in the workbook
+// will be flattened if the given worksheet name is empty. All formulas will
+// be calculated before any of them is replaced, so the cells which depend on
+// the other formula cells will get the correct values regardless of the
+// order of the cells. The calculated values are reused, so the formula cells
+// referenced by others will not be calculated repeatedly. The formulas
+// evaluated to an error will be replaced with the error value, such as
+// #DIV/0!, the data table formulas will be replaced with their cached values,
+// and the workbook will not be modified if any formula can't be calculated.
+// For example, replace the formulas of all worksheets with the calculated
+// values:
+//
+//	err := f.FlattenFormulas("")
+func (f *File) FlattenFormulas(sheet string) error {
+	type formulaCell struct {
+		sheet, cell string
+		dataTable   bool
+		result      formulaArg
+	}
+	sheets := []string{sheet}
+	if sheet == "" {
+		sheets = sheets[:0]
+		for _, name := range f.GetSheetList() {
+			if sheetXMLPath, _ := f.getSheetXMLPath(name); strings.HasPrefix(sheetXMLPath, "xl/worksheets/") {
+				sheets = append(sheets, name)
+			}
+		}
+	}
+	var cells []formulaCell
+	for _, name := range sheets {
+		ws, err := f.workSheetReader(name)
+		if err != nil {
+			return err
+		}
+		ws.Lock()
+		for _, row := range ws.SheetData.Row {
+			for _, c := range row.C {
+				if c.F != nil {
+					cells = append(cells, formulaCell{sheet: name, cell: c.R, dataTable: c.F.T == STCellFormulaTypeDataTable})
+				}
+			}
+		}
+		ws.Unlock()
+	}
+	ctx := &calcContext{values: make(map[string]string)}
+	for idx, c := range cells {
+		ws, _ := f.workSheetReader(c.sheet)
+		cell, err := f.mergeCellsParser(ws, c.cell)
+		if err != nil {
+			return err
+		}
+		if _, _, err = CellNameToCoordinates(cell); err != nil {
+			return err
+		}
+		if c.dataTable {
+			continue
+		}
+		ref := fmt.Sprintf("%s!%s", c.sheet, c.cell)
+		ctx.entry, ctx.iterations = ref, make(map[string]uint)
+		result, err := f.calcCellArg(ctx, c.sheet, c.cell)
+		if err != nil {
+			if inStrSlice([]string{
+				formulaErrorDIV, formulaErrorNAME, formulaErrorNA, formulaErrorNUM,
+				formulaErrorVALUE, formulaErrorREF, formulaErrorNULL, formulaErrorSPILL,
+				formulaErrorCALC, formulaErrorGETTINGDATA,
+			}, err.Error(), true) == -1 {
+				return err
+			}
+			result = newErrorFormulaArg(err.Error(), err.Error())
+			ctx.setValue(ref, "")
+		} else {
+			ctx.setValue(ref, roundCalcResult(result.Value()))
+		}
+		if list := result.ToList(); (result.Type == ArgMatrix || result.Type == ArgList) && len(list) > 0 {
+			result = list[0]
+		}
+		cells[idx].result = result
+	}
+	if err := f.sharedStringsLoader(); err != nil {
+		return err
+	}
+	for _, c := range cells {
+		ws, _ := f.workSheetReader(c.sheet)
+		cellData, _, _, err := f.prepareCell(ws, c.cell)
+		if err != nil {
+			return err
+		}
+		ws.Lock()
+		if !c.dataTable {
+			cellData.IS = nil
+			switch c.result.Type {
+			case ArgNumber:
+				if cellData.T, cellData.V = "", roundCalcResult(c.result.Value()); c.result.Boolean {
+					cellData.T, cellData.V = setCellBool(c.result.Number == 1)
+				}
+			case ArgString:
+				cellData.T, cellData.V, err = f.setCellString(c.result.String)
+			case ArgError:
+				cellData.T, cellData.V = "e", c.result.Error
+			default:
+				cellData.T, cellData.V = "", ""
+			}
+		}
+		cellData.Vm = nil
+		f.removeFormula(cellData, ws, c.sheet)
+		ws.Unlock()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// formulaRef directly maps the cell or range referenced by the formula, the
+// coordinates order: first column, first row, last column, last row.
+type formulaRef struct {
+	Sheet       string
+	Coordinates []int
+}
+
+// String provides a function to get the reference in the form of
+// "Sheet1!A1" or "Sheet1!A1:B2".
+func (r formulaRef) String() string {
+	sheetRef := r.Sheet
+	if strings.ContainsAny(sheetRef, " !\"'#$%&()*+,-/:;<=>?@[]^`{|}~") {
+		sheetRef = "'" + strings.ReplaceAll(sheetRef, "'", "''") + "'"
+	}
+	firstCell, _ := CoordinatesToCellName(r.Coordinates[0], r.Coordinates[1])
+	if r.Coordinates[0] == r.Coordinates[2] && r.Coordinates[1] == r.Coordinates[3] {
+		return sheetRef + "!" + firstCell
+	}
+	lastCell, _ := CoordinatesToCellName(r.Coordinates[2], r.Coordinates[3])
+	return sheetRef + "!" + firstCell + ":" + lastCell
+}
+
+// GetCellPrecedents provides a function to get the cells and ranges
+// referenced by the formula of the cell by given worksheet name and cell
+// reference. The references are returned in the form of "Sheet1!A1" or
+// "Sheet1!A1:B2" in the order of their first appearance in the formula.
+// Defined names will be resolved to the references they refer to, whole
+// column or row references will be expanded to the bounds of the worksheet,
+// and external references will be ignored. This function returns an empty
+// list if the cell doesn't contain a formula. For example, get the
+// precedents of the cell C1 with formula "=SUM(A1:B2)+Sheet2!A1" on Sheet1:
+//
+//	precedents, err := f.GetCellPrecedents("Sheet1", "C1")
+//
+// The result will be:
+//
+//	[Sheet1!A1:B2 Sheet2!A1]
+func (f *File) GetCellPrecedents(sheet, cell string) ([]string, error) {
+	formula, err := f.GetCellFormula(sheet, cell)
+	if err != nil {
+		return nil, err
+	}
+	var precedents []string
+	for _, ref := range f.getFormulaRefs(sheet, formula) {
+		if ref := ref.String(); inStrSlice(precedents, ref, true) == -1 {
+			precedents = append(precedents, ref)
+		}
+	}
+	return precedents, err
+}
+
+// GetCellDependents provides a function to get the formula cells which
+// reference the cell directly by given worksheet name and cell reference.
+// All worksheets in the workbook will be scanned, the chart sheets will be
+// skipped, and the dependents are returned in the form of "Sheet1!A1" in
+// the order of worksheets and cells. The error of reading any worksheet will
+// be returned.
+// For example, get the formula cells referencing the cell A1 on Sheet1:
+//
+//	dependents, err := f.GetCellDependents("Sheet1", "A1")
+func (f *File) GetCellDependents(sheet, cell string) ([]string, error) {
+	col, row, err := CellNameToCoordinates(cell)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = f.workSheetReader(sheet); err != nil {
+		return nil, err
+	}
+	var dependents []string
+	for _, name := range f.GetSheetList() {
+		ws, err := f.workSheetReader(name)
+		if err != nil {
+			if err.Error() == fmt.Sprintf("sheet %s is not a worksheet", trimSheetName(name)) {
+				continue
+			}
+			return dependents, err
+		}
+		for _, formulaCell := range getSheetFormulaCells(ws) {
+			for _, ref := range f.getFormulaRefs(name, formulaCell[1]) {
+				if strings.EqualFold(ref.Sheet, sheet) &&
+					ref.Coordinates[0] <= col && col <= ref.Coordinates[2] &&
+					ref.Coordinates[1] <= row && row <= ref.Coordinates[3] {
+					x, y, _ := CellNameToCoordinates(formulaCell[0])
+					dependents = append(dependents, formulaRef{Sheet: name, Coordinates: []int{x, y, x, y}}.String())
+					break
+				}
+			}
+		}
+	}
+	return dependents, err
+}
+
+// getSheetFormulaCells provides a function to get the cell references and
+// formulas of all formula cells in the worksheet, the shared formulas will
+// be expanded for each cell.
+func getSheetFormulaCells(ws *xlsxWorksheet) [][]string {
+	ws.Lock()
+	defer ws.Unlock()
+	var cells [][]string
+	for _, row := range ws.SheetData.Row {
+		for _, c := range row.C {
+			if c.F == nil {
+				continue
+			}
+			formula := c.F.Content
+			if c.F.T == STCellFormulaTypeShared && c.F.Si != nil {
+				formula = getSharedFormula(ws, *c.F.Si, c.R)
+			}
+			if formula != "" {
+				cells = append(cells, []string{c.R, formula})
+			}
+		}
+	}
+	return cells
+}
+
+// getFormulaRefs provides a function to get the cells and ranges referenced
+// by the formula by given worksheet name of the formula cell.
+func (f *File) getFormulaRefs(sheet, formula string) []formulaRef {
+	var refs []formulaRef
+	for _, reference := range getFormulaRangeOperands(formula) {
+		if refTo := f.getDefinedNameRefTo(reference, sheet); refTo != "" {
+			for _, ref := range getFormulaRangeOperands(refTo) {
+				refs = append(refs, f.parseFormulaRef(sheet, ref)...)
+			}
+			continue
+		}
+		refs = append(refs, f.parseFormulaRef(sheet, reference)...)
+	}
+	return refs
+}
+
+// getFormulaRangeOperands provides a function to get the range operands of
+// the formula by the formula tokenizer, the quoted worksheet names in the
+// operands will be unquoted.
+func getFormulaRangeOperands(formula string) []string {
+	var operands []string
+	ps := efp.ExcelParser()
+	for _, token := range ps.Parse(formula) {
+		if token.TType == efp.TokenTypeOperand && token.TSubType == efp.TokenSubTypeRange {
+			operands = append(operands, token.TValue)
+		}
+	}
+	return operands
+}
+
+// parseFormulaRef provides a function to parse the cell or range reference
+// of the formula, the reference without worksheet name will be qualified by
+// the given worksheet name, and 3-D references will be expanded for each
+// worksheet. The external and invalid references will be ignored.
+func (f *File) parseFormulaRef(sheet, reference string) []formulaRef {
+	sheets := []string{sheet}
+	if idx := strings.LastIndex(reference, "!"); idx != -1 {
+		sheetRef := reference[:idx]
+		if strings.HasPrefix(sheetRef, "'") && strings.HasSuffix(sheetRef, "'") && len(sheetRef) > 1 {
+			sheetRef = strings.ReplaceAll(sheetRef[1:len(sheetRef)-1], "''", "'")
+		}
+		if strings.HasPrefix(sheetRef, "[") {
+			return nil
+		}
+		sheets, reference = strings.Split(sheetRef, ":"), reference[idx+1:]
+		if len(sheets) == 2 {
+			sheetList := f.GetSheetList()
+			from, to := inStrSlice(sheetList, sheets[0], false), inStrSlice(sheetList, sheets[1], false)
+			if from == -1 || to == -1 {
+				return nil
+			}
+			if from > to {
+				from, to = to, from
+			}
+			sheets = sheetList[from : to+1]
+		}
+	}
+	cells := strings.Split(strings.ReplaceAll(reference, "$", ""), ":")
+	if len(cells) > 2 {
+		return nil
+	}
+	var coordinates []int
+	for _, cell := range cells {
+		if col, row, err := CellNameToCoordinates(cell); err == nil {
+			coordinates = append(coordinates, col, row)
+			continue
+		}
+		if col, err := ColumnNameToNumber(cell); err == nil && len(cells) == 2 {
+			coordinates = append(coordinates, col, (len(coordinates)/2)*(TotalRows-1)+1)
+			continue
+		}
+		if row, err := strconv.Atoi(cell); err == nil && row > 0 && row <= TotalRows && len(cells) == 2 {
+			coordinates = append(coordinates, (len(coordinates)/2)*(MaxColumns-1)+1, row)
+			continue
+		}
+		return nil
+	}
+	if len(coordinates) == 2 {
+		coordinates = append(coordinates, coordinates...)
+	}
+	_ = sortCoordinates(coordinates)
+	var refs []formulaRef
+	for _, name := range sheets {
+		refs = append(refs, formulaRef{Sheet: name, Coordinates: coordinates})
+	}
+	return refs
 }
 
 // getPriority calculate arithmetic operator priority.
@@ -1007,6 +1345,276 @@ func (f *File) evalInfixExp(ctx *calcContext, sheet, cell string, tokens []efp.T
 	return opdStack.Peek().(formulaArg), err
 }
 
+// expandLazyFuncTokens evaluates the LET, IFS and SWITCH functions in the
+// tokens of a formula and replaces each of them with the tokens of its
+// result. The arguments of the IFS and SWITCH functions are evaluated in
+// order, and the arguments after the selected branch will not be evaluated.
+// The scope is the symbol table of the names bound by the enclosing LET
+// functions, the references of these names will be replaced with the tokens
+// of the bound values.
+func (f *File) expandLazyFuncTokens(ctx *calcContext, sheet, cell string, tokens []efp.Token, scope map[string][]efp.Token) ([]efp.Token, error) {
+	expanded := make([]efp.Token, 0, len(tokens))
+	for i := 0; i < len(tokens); i++ {
+		token := tokens[i]
+		if isFunctionStartToken(token) {
+			var (
+				result []efp.Token
+				ok     bool
+				err    error
+			)
+			end := getFunctionStopTokenIndex(tokens, i)
+			switch strings.ToUpper(strings.TrimPrefix(token.TValue, "_xlfn.")) {
+			case "LET":
+				if end == -1 {
+					return nil, ErrInvalidFormula
+				}
+				result, err = f.evalLetFunc(ctx, sheet, cell, splitFunctionArgTokens(tokens[i+1:end]), scope)
+				ok = true
+			case "IFS":
+				if end != -1 {
+					result, ok, err = f.evalIFSFunc(ctx, sheet, cell, splitFunctionArgTokens(tokens[i+1:end]), scope)
+				}
+			case "SWITCH":
+				if end != -1 {
+					result, ok, err = f.evalSWITCHFunc(ctx, sheet, cell, splitFunctionArgTokens(tokens[i+1:end]), scope)
+				}
+			}
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				expanded = append(expanded, result...)
+				i = end
+				continue
+			}
+		}
+		if token.TType == efp.TokenTypeOperand && token.TSubType == efp.TokenSubTypeRange {
+			if value, ok := scope[getLetName(token.TValue)]; ok {
+				expanded = append(expanded, value...)
+				continue
+			}
+		}
+		expanded = append(expanded, token)
+	}
+	return expanded, nil
+}
+
+// evalIFSFunc evaluates the IFS function by given tokens of the arguments
+// with short-circuit evaluation, the conditions are evaluated in order until
+// the first condition evaluates to TRUE, and only the value of this
+// condition will be returned as the tokens. It returns false if the IFS
+// function can't be evaluated lazily, such as the condition evaluates to an
+// error or an array, and the function will be evaluated by the formula
+// engine as usual.
+func (f *File) evalIFSFunc(ctx *calcContext, sheet, cell string, args [][]efp.Token, scope map[string][]efp.Token) ([]efp.Token, bool, error) {
+	if len(args) < 2 || len(args)%2 != 0 {
+		return nil, false, nil
+	}
+	for i := 0; i < len(args); i += 2 {
+		arg, ok, err := f.evalLazyFuncArg(ctx, sheet, cell, args[i], scope)
+		if !ok || err != nil {
+			return nil, ok, err
+		}
+		condition := toLogicalCondition(arg)
+		if condition.Type == ArgError {
+			return nil, false, nil
+		}
+		if condition.Number == 1 {
+			return f.getLazyFuncResult(ctx, sheet, cell, args[i+1], scope)
+		}
+	}
+	return getNAFuncTokens(), true, nil
+}
+
+// evalSWITCHFunc evaluates the SWITCH function by given tokens of the
+// arguments with short-circuit evaluation, the values are evaluated in order
+// until the first value matches the expression, and only the result
+// corresponding to this value will be returned as the tokens. It returns
+// false if the SWITCH function can't be evaluated lazily, and the function
+// will be evaluated by the formula engine as usual.
+func (f *File) evalSWITCHFunc(ctx *calcContext, sheet, cell string, args [][]efp.Token, scope map[string][]efp.Token) ([]efp.Token, bool, error) {
+	if len(args) < 3 {
+		return nil, false, nil
+	}
+	target, ok, err := f.evalLazyFuncArg(ctx, sheet, cell, args[0], scope)
+	if !ok || err != nil {
+		return nil, ok, err
+	}
+	for i := 1; i+1 < len(args); i += 2 {
+		value, ok, err := f.evalLazyFuncArg(ctx, sheet, cell, args[i], scope)
+		if !ok || err != nil {
+			return nil, ok, err
+		}
+		if target.Value() == value.Value() {
+			return f.getLazyFuncResult(ctx, sheet, cell, args[i+1], scope)
+		}
+	}
+	if len(args)%2 == 0 {
+		return f.getLazyFuncResult(ctx, sheet, cell, args[len(args)-1], scope)
+	}
+	return getNAFuncTokens(), true, nil
+}
+
+// evalLazyFuncArg evaluates the tokens of the argument for the IFS and SWITCH
+// functions, it returns false if the argument isn't a single value.
+func (f *File) evalLazyFuncArg(ctx *calcContext, sheet, cell string, tokens []efp.Token, scope map[string][]efp.Token) (formulaArg, bool, error) {
+	expanded, err := f.expandLazyFuncTokens(ctx, sheet, cell, tokens, scope)
+	if err != nil || len(expanded) == 0 {
+		return newEmptyFormulaArg(), false, err
+	}
+	if len(expanded) == 1 && expanded[0].TType == efp.TokenTypeOperand && expanded[0].TSubType == efp.TokenSubTypeLogical {
+		return newStringFormulaArg(expanded[0].TValue), true, nil
+	}
+	arg, err := f.evalInfixExp(ctx, sheet, cell, expanded)
+	if err != nil {
+		return newEmptyFormulaArg(), false, nil
+	}
+	switch arg.Type {
+	case ArgNumber, ArgString, ArgEmpty:
+		return arg, true, nil
+	}
+	return newEmptyFormulaArg(), false, nil
+}
+
+// getLazyFuncResult returns the tokens of the selected result of the IFS and
+// SWITCH functions, the tokens will be evaluated by the formula engine.
+func (f *File) getLazyFuncResult(ctx *calcContext, sheet, cell string, tokens []efp.Token, scope map[string][]efp.Token) ([]efp.Token, bool, error) {
+	expanded, err := f.expandLazyFuncTokens(ctx, sheet, cell, tokens, scope)
+	if err != nil || len(expanded) == 0 {
+		return nil, false, err
+	}
+	if len(expanded) == 1 && expanded[0].TType == efp.TokenTypeOperand {
+		return expanded, true, nil
+	}
+	return append(append([]efp.Token{{TType: efp.TokenTypeSubexpression, TSubType: efp.TokenSubTypeStart}}, expanded...),
+		efp.Token{TType: efp.TokenTypeSubexpression, TSubType: efp.TokenSubTypeStop}), true, nil
+}
+
+// getNAFuncTokens returns the tokens of the NA function, which is the result
+// of the IFS and SWITCH functions if no condition or value matches.
+func getNAFuncTokens() []efp.Token {
+	return []efp.Token{
+		{TValue: "NA", TType: efp.TokenTypeFunction, TSubType: efp.TokenSubTypeStart},
+		{TType: efp.TokenTypeFunction, TSubType: efp.TokenSubTypeStop},
+	}
+}
+
+// evalLetFunc evaluates the LET function by given tokens of the arguments,
+// the LET function assigns names to calculation results, and returns the
+// tokens of the calculation result. The syntax of the function is:
+//
+//	LET(name1,name_value1,[name2,name_value2,...],calculation)
+func (f *File) evalLetFunc(ctx *calcContext, sheet, cell string, args [][]efp.Token, scope map[string][]efp.Token) ([]efp.Token, error) {
+	if len(args) < 3 {
+		return nil, errors.New("LET requires at least 3 arguments")
+	}
+	if len(args)%2 == 0 {
+		return nil, errors.New("LET requires an odd number of arguments")
+	}
+	localScope := make(map[string][]efp.Token, len(scope)+len(args)/2)
+	for name, value := range scope {
+		localScope[name] = value
+	}
+	for i := 0; i < len(args)-1; i += 2 {
+		if len(args[i]) != 1 || args[i][0].TType != efp.TokenTypeOperand || args[i][0].TSubType != efp.TokenSubTypeRange {
+			return nil, errors.New(formulaErrorNAME)
+		}
+		name := getLetName(args[i][0].TValue)
+		if _, _, err := CellNameToCoordinates(name); err == nil || strings.ContainsAny(name, ":!") {
+			return nil, errors.New(formulaErrorNAME)
+		}
+		value, err := f.expandLazyFuncTokens(ctx, sheet, cell, args[i+1], localScope)
+		if err != nil {
+			return nil, err
+		}
+		if localScope[name], err = f.evalLetValue(ctx, sheet, cell, value); err != nil {
+			return nil, err
+		}
+	}
+	calculation, err := f.expandLazyFuncTokens(ctx, sheet, cell, args[len(args)-1], localScope)
+	if err != nil {
+		return nil, err
+	}
+	return f.evalLetValue(ctx, sheet, cell, calculation)
+}
+
+// evalLetValue evaluates the tokens of the value for the LET function and
+// returns the result as tokens, the single operand such as the reference will
+// be kept as is to be used by the functions which accept the cell range.
+func (f *File) evalLetValue(ctx *calcContext, sheet, cell string, tokens []efp.Token) ([]efp.Token, error) {
+	if len(tokens) == 0 {
+		return nil, ErrInvalidFormula
+	}
+	if len(tokens) == 1 && tokens[0].TType == efp.TokenTypeOperand {
+		return tokens, nil
+	}
+	arg, err := f.evalInfixExp(ctx, sheet, cell, tokens)
+	if err != nil {
+		return nil, err
+	}
+	switch arg.Type {
+	case ArgError:
+		return nil, errors.New(arg.Value())
+	case ArgEmpty:
+		return []efp.Token{{TValue: "0", TType: efp.TokenTypeOperand, TSubType: efp.TokenSubTypeNumber}}, nil
+	case ArgNumber, ArgString:
+		if isNum, _ := isNumeric(arg.Value()); isNum && !arg.Boolean {
+			return []efp.Token{{TValue: arg.Value(), TType: efp.TokenTypeOperand, TSubType: efp.TokenSubTypeNumber}}, nil
+		}
+		return []efp.Token{{TValue: arg.Value(), TType: efp.TokenTypeOperand, TSubType: efp.TokenSubTypeText}}, nil
+	}
+	return append(append([]efp.Token{{TType: efp.TokenTypeSubexpression, TSubType: efp.TokenSubTypeStart}}, tokens...),
+		efp.Token{TType: efp.TokenTypeSubexpression, TSubType: efp.TokenSubTypeStop}), nil
+}
+
+// getLetName returns the upper case name of the LET function without the
+// prefix for the parameter.
+func getLetName(name string) string {
+	return strings.ToUpper(strings.TrimPrefix(name, "_xlpm."))
+}
+
+// getFunctionStopTokenIndex returns the index of the function stop token
+// which matches the function start token at the given index, and returns -1
+// if not found.
+func getFunctionStopTokenIndex(tokens []efp.Token, start int) int {
+	var depth int
+	for i := start; i < len(tokens); i++ {
+		if isFunctionStartToken(tokens[i]) {
+			depth++
+		}
+		if isFunctionStopToken(tokens[i]) {
+			if depth--; depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// splitFunctionArgTokens splits the tokens between the function start and
+// stop tokens into the tokens of each argument.
+func splitFunctionArgTokens(tokens []efp.Token) [][]efp.Token {
+	var (
+		depth int
+		args  [][]efp.Token
+		arg   = []efp.Token{}
+	)
+	for _, token := range tokens {
+		if isFunctionStartToken(token) || (token.TType == efp.TokenTypeSubexpression && token.TSubType == efp.TokenSubTypeStart) {
+			depth++
+		}
+		if isFunctionStopToken(token) || (token.TType == efp.TokenTypeSubexpression && token.TSubType == efp.TokenSubTypeStop) {
+			depth--
+		}
+		if depth == 0 && token.TType == efp.TokenTypeArgument {
+			args, arg = append(args, arg), []efp.Token{}
+			continue
+		}
+		arg = append(arg, token)
+	}
+	return append(args, arg)
+}
+
 // evalInfixExpFunc evaluate formula function in the infix expression.
 func (f *File) evalInfixExpFunc(ctx *calcContext, sheet, cell string, token, nextToken efp.Token, opfStack, opdStack, opftStack, opfdStack, argsStack *Stack) error {
 	if !isFunctionStopToken(token) {
@@ -1506,10 +2114,15 @@ func (f *File) cellResolver(ctx *calcContext, sheet, cell string) (string, error
 	ref := fmt.Sprintf("%s!%s", sheet, cell)
 	if formula, _ := f.GetCellFormula(sheet, cell); len(formula) != 0 {
 		ctx.Lock()
+		if value, ok := ctx.values[ref]; ok {
+			ctx.Unlock()
+			return value, nil
+		}
 		if ctx.entry != ref && ctx.iterations[ref] <= f.options.MaxCalcIterations {
 			ctx.iterations[ref]++
 			ctx.Unlock()
 			value, _ = f.calcCellValue(ctx, sheet, cell)
+			ctx.setValue(ref, value)
 			return value, nil
 		}
 		ctx.Unlock()
@@ -1517,6 +2130,17 @@ func (f *File) cellResolver(ctx *calcContext, sheet, cell string) (string, error
 	return f.GetCellValue(sheet, cell, Options{RawCellValue: true})
 }
 
+// setValue provides a function to cache the calculated value of the formula
+// cell by given reference, if the calculation context shares the calculated
+// values between the formula cells.
+func (ctx *calcContext) setValue(ref, value string) {
+	ctx.Lock()
+	defer ctx.Unlock()
+	if ctx.values != nil {
+		ctx.values[ref] = value
+	}
+}
+
 // rangeResolver extract value as string from given reference and range list.
 // This function will not ignore the empty cell. For example, A1:A2:A2:B3 will
 // be reference A1:B3.
@@ -5282,6 +5906,49 @@ func (fn *formulaFuncs) SECH(argsList *list.List) formulaArg {
 	return newNumberFormulaArg(1 / math.Cosh(number.Number))
 }
 
+// SEQUENCE function generates an array of sequential numbers, such as 1, 2,
+// 3, 4. The rows and columns specify the size of the array, the start is the
+// first number and the step is the increment of each subsequent number in the
+// array. The #VALUE! error will be returned if the rows exceed the maximum
+// number of rows or the columns exceed the maximum number of columns of the
+// worksheet. The syntax of the function is:
+//
+//	SEQUENCE(rows,[columns],[start],[step])
+func (fn *formulaFuncs) SEQUENCE(argsList *list.List) formulaArg {
+	if argsList.Len() < 1 {
+		return newErrorFormulaArg(formulaErrorVALUE, "SEQUENCE requires at least 1 argument")
+	}
+	if argsList.Len() > 4 {
+		return newErrorFormulaArg(formulaErrorVALUE, "SEQUENCE allows at most 4 arguments")
+	}
+	params, idx := []float64{1, 1, 1, 1}, 0
+	for arg := argsList.Front(); arg != nil; arg = arg.Next() {
+		if token := arg.Value.(formulaArg); token.Type != ArgEmpty && token.Value() != "" {
+			num := token.ToNumber()
+			if num.Type == ArgError {
+				return num
+			}
+			params[idx] = num.Number
+		}
+		idx++
+	}
+	if params[0] < 1 || params[1] < 1 {
+		return newErrorFormulaArg(formulaErrorCALC, formulaErrorCALC)
+	}
+	if params[0] > TotalRows || params[1] > MaxColumns {
+		return newErrorFormulaArg(formulaErrorVALUE, formulaErrorVALUE)
+	}
+	rows, cols, start, step := int(params[0]), int(params[1]), params[2], params[3]
+	matrix := make([][]formulaArg, rows)
+	for r := range matrix {
+		matrix[r] = make([]formulaArg, cols)
+		for c := range matrix[r] {
+			matrix[r][c] = newNumberFormulaArg(start + float64(r*cols+c)*step)
+		}
+	}
+	return newMatrixFormulaArg(matrix)
+}
+
 // SERIESSUM function returns the sum of a power series. The syntax of the
 // function is:
 //
@@ -11557,8 +12224,15 @@ func (fn *formulaFuncs) IFS(argsList *list.List) formulaArg {
 	if argsList.Len() < 2 {
 		return newErrorFormulaArg(formulaErrorVALUE, "IFS requires at least 2 arguments")
 	}
+	if argsList.Len()%2 != 0 {
+		return newErrorFormulaArg(formulaErrorVALUE, "IFS requires an even number of arguments")
+	}
 	for arg := argsList.Front(); arg != nil; arg = arg.Next() {
-		if arg.Value.(formulaArg).ToBool().Number == 1 {
+		condition := toLogicalCondition(arg.Value.(formulaArg))
+		if condition.Type == ArgError {
+			return condition
+		}
+		if condition.Number == 1 {
 			return arg.Next().Value.(formulaArg)
 		}
 		arg = arg.Next()
@@ -11566,6 +12240,25 @@ func (fn *formulaFuncs) IFS(argsList *list.List) formulaArg {
 	return newErrorFormulaArg(formulaErrorNA, formulaErrorNA)
 }
 
+// toLogicalCondition converts the condition of the IFS function to the
+// boolean formula argument. The numbers other than 0 are TRUE, the empty
+// value and the empty cell are FALSE, and the text other than TRUE and FALSE
+// is the #VALUE! error.
+func toLogicalCondition(condition formulaArg) formulaArg {
+	switch condition.Type {
+	case ArgString:
+		if condition.String == "" || strings.EqualFold(condition.String, "TRUE") || strings.EqualFold(condition.String, "FALSE") {
+			return newBoolFormulaArg(strings.EqualFold(condition.String, "TRUE"))
+		}
+		return newErrorFormulaArg(formulaErrorVALUE, formulaErrorVALUE)
+	case ArgNumber:
+		return newBoolFormulaArg(condition.Number != 0)
+	case ArgError:
+		return condition
+	}
+	return newBoolFormulaArg(false)
+}
+
 // NOT function returns the opposite to a supplied logical value. The syntax
 // of the function is:
 //
@@ -11639,6 +12332,9 @@ func (fn *formulaFuncs) SWITCH(argsList *list.List) formulaArg {
 		return newErrorFormulaArg(formulaErrorVALUE, "SWITCH requires at least 3 arguments")
 	}
 	target := argsList.Front().Value.(formulaArg)
+	if target.Type == ArgError {
+		return target
+	}
 	argCount := argsList.Len() - 1
 	switchCount := int(math.Floor(float64(argCount) / 2))
 	hasDefaultClause := argCount%2 != 0
@@ -11731,13 +12427,14 @@ func (fn *formulaFuncs) DATE(argsList *list.List) formulaArg {
 	if year.Type != ArgNumber || month.Type != ArgNumber || day.Type != ArgNumber {
 		return newErrorFormulaArg(formulaErrorVALUE, "DATE requires 3 number arguments")
 	}
-	d := makeDate(int(year.Number), time.Month(month.Number), int(day.Number))
-	return newStringFormulaArg(timeFromExcelTime(daysBetween(excelMinTime1900.Unix(), d)+1, false).String())
+	date1904 := fn.date1904()
+	date := toExcelDate(int(year.Number), time.Month(month.Number), int(day.Number), date1904)
+	return newStringFormulaArg(timeFromExcelTime(date, date1904).String())
 }
 
 // calcDateDif is an implementation of the formula function DATEDIF,
 // calculation difference between two dates.
-func calcDateDif(unit string, diff float64, seq []int, startArg, endArg formulaArg) float64 {
+func calcDateDif(unit string, diff float64, seq []int, startArg, endArg formulaArg, date1904 bool) float64 {
 	ey, sy, em, sm, ed, sd := seq[0], seq[1], seq[2], seq[3], seq[4], seq[5]
 	switch unit {
 	case "d":
@@ -11747,7 +12444,7 @@ func calcDateDif(unit string, diff float64, seq []int, startArg, endArg formulaA
 		if ed < sd {
 			smMD--
 		}
-		diff = endArg.Number - daysBetween(excelMinTime1900.Unix(), makeDate(ey, time.Month(smMD), sd)) - 1
+		diff = endArg.Number - toExcelDate(ey, time.Month(smMD), sd, date1904)
 	case "ym":
 		diff = float64(em - sm)
 		if ed < sd {
@@ -11761,8 +12458,8 @@ func calcDateDif(unit string, diff float64, seq []int, startArg, endArg formulaA
 		if em < sm || (em == sm && ed < sd) {
 			syYD++
 		}
-		s := daysBetween(excelMinTime1900.Unix(), makeDate(syYD, time.Month(em), ed))
-		e := daysBetween(excelMinTime1900.Unix(), makeDate(sy, time.Month(sm), sd))
+		s := toExcelDate(syYD, time.Month(em), ed, date1904)
+		e := toExcelDate(sy, time.Month(sm), sd, date1904)
 		diff = s - e
 	}
 	return diff
@@ -11787,7 +12484,8 @@ func (fn *formulaFuncs) DATEDIF(argsList *list.List) formulaArg {
 		return newNumberFormulaArg(0)
 	}
 	unit := strings.ToLower(argsList.Back().Value.(formulaArg).Value())
-	startDate, endDate := timeFromExcelTime(startArg.Number, false), timeFromExcelTime(endArg.Number, false)
+	date1904 := fn.date1904()
+	startDate, endDate := timeFromExcelTime(startArg.Number, date1904), timeFromExcelTime(endArg.Number, date1904)
 	sy, smm, sd := startDate.Date()
 	ey, emm, ed := endDate.Date()
 	sm, em, diff := int(smm), int(emm), 0.0
@@ -11809,7 +12507,7 @@ func (fn *formulaFuncs) DATEDIF(argsList *list.List) formulaArg {
 		}
 		diff = float64(yDiff*12 + mDiff)
 	case "d", "md", "ym", "yd":
-		diff = calcDateDif(unit, diff, []int{ey, sy, em, sm, ed, sd}, startArg, endArg)
+		diff = calcDateDif(unit, diff, []int{ey, sy, em, sm, ed, sd}, startArg, endArg, date1904)
 	default:
 		return newErrorFormulaArg(formulaErrorVALUE, "DATEDIF has invalid unit")
 	}
@@ -12066,7 +12764,7 @@ func (fn *formulaFuncs) DATEVALUE(argsList *list.List) formulaArg {
 	if err.Type == ArgError {
 		return err
 	}
-	return newNumberFormulaArg(daysBetween(excelMinTime1900.Unix(), makeDate(y, time.Month(m), d)) + 1)
+	return newNumberFormulaArg(toExcelDate(y, time.Month(m), d, fn.date1904()))
 }
 
 // DAY function returns the day of a date, represented by a serial number. The
@@ -12096,10 +12794,11 @@ func (fn *formulaFuncs) DAY(argsList *list.List) formulaArg {
 	if num.Number < 0 {
 		return newErrorFormulaArg(formulaErrorNUM, "DAY only accepts positive argument")
 	}
-	if num.Number <= 60 {
+	date1904 := fn.date1904()
+	if !date1904 && num.Number <= 60 {
 		return newNumberFormulaArg(math.Mod(num.Number, 31.0))
 	}
-	return newNumberFormulaArg(float64(timeFromExcelTime(num.Number, false).Day()))
+	return newNumberFormulaArg(float64(timeFromExcelTime(num.Number, date1904).Day()))
 }
 
 // DAYS function returns the number of days between two supplied dates. The
@@ -12129,15 +12828,15 @@ func (fn *formulaFuncs) DAYS360(argsList *list.List) formulaArg {
 	if argsList.Len() > 3 {
 		return newErrorFormulaArg(formulaErrorVALUE, "DAYS360 requires at most 3 arguments")
 	}
-	startDate := toExcelDateArg(argsList.Front().Value.(formulaArg))
+	startDate := toExcelDateArg(argsList.Front().Value.(formulaArg), fn.date1904())
 	if startDate.Type != ArgNumber {
 		return startDate
 	}
-	endDate := toExcelDateArg(argsList.Front().Next().Value.(formulaArg))
+	endDate := toExcelDateArg(argsList.Front().Next().Value.(formulaArg), fn.date1904())
 	if endDate.Type != ArgNumber {
 		return endDate
 	}
-	start, end := timeFromExcelTime(startDate.Number, false), timeFromExcelTime(endDate.Number, false)
+	start, end := timeFromExcelTime(startDate.Number, fn.date1904()), timeFromExcelTime(endDate.Number, fn.date1904())
 	sy, sm, sd, ey, em, ed := start.Year(), int(start.Month()), start.Day(), end.Year(), int(end.Month()), end.Day()
 	method := newBoolFormulaArg(false)
 	if argsList.Len() > 2 {
@@ -12195,7 +12894,7 @@ func (fn *formulaFuncs) ISOWEEKNUM(argsList *list.List) formulaArg {
 		if num.Number < 0 {
 			return newErrorFormulaArg(formulaErrorNUM, formulaErrorNUM)
 		}
-		_, weekNum = timeFromExcelTime(num.Number, false).ISOWeek()
+		_, weekNum = timeFromExcelTime(num.Number, fn.date1904()).ISOWeek()
 	}
 	return newNumberFormulaArg(float64(weekNum))
 }
@@ -12227,7 +12926,7 @@ func (fn *formulaFuncs) EDATE(argsList *list.List) formulaArg {
 		if num.Number < 0 {
 			return newErrorFormulaArg(formulaErrorNUM, formulaErrorNUM)
 		}
-		dateTime = timeFromExcelTime(num.Number, false)
+		dateTime = timeFromExcelTime(num.Number, fn.date1904())
 	}
 	month := argsList.Back().Value.(formulaArg).ToNumber()
 	if month.Type != ArgNumber {
@@ -12249,7 +12948,7 @@ func (fn *formulaFuncs) EDATE(argsList *list.List) formulaArg {
 			d = days
 		}
 	}
-	result, _ := timeToExcelTime(time.Date(y, time.Month(m), d, 0, 0, 0, 0, time.UTC), false)
+	result := toExcelDate(y, time.Month(m), d, fn.date1904())
 	return newNumberFormulaArg(result)
 }
 
@@ -12281,7 +12980,7 @@ func (fn *formulaFuncs) EOMONTH(argsList *list.List) formulaArg {
 		if num.Number < 0 {
 			return newErrorFormulaArg(formulaErrorNUM, formulaErrorNUM)
 		}
-		dateTime = timeFromExcelTime(num.Number, false)
+		dateTime = timeFromExcelTime(num.Number, fn.date1904())
 	}
 	months := argsList.Back().Value.(formulaArg).ToNumber()
 	if months.Type != ArgNumber {
@@ -12297,7 +12996,7 @@ func (fn *formulaFuncs) EOMONTH(argsList *list.List) formulaArg {
 	if m = m % 12; m < 0 {
 		m += 12
 	}
-	result, _ := timeToExcelTime(time.Date(y, time.Month(m+1), getDaysInMonth(y, m+1), 0, 0, 0, 0, time.UTC), false)
+	result := toExcelDate(y, time.Month(m+1), getDaysInMonth(y, m+1), fn.date1904())
 	return newNumberFormulaArg(result)
 }
 
@@ -12331,7 +13030,7 @@ func (fn *formulaFuncs) HOUR(argsList *list.List) formulaArg {
 	if num.Number < 0 {
 		return newErrorFormulaArg(formulaErrorNUM, "HOUR only accepts positive argument")
 	}
-	return newNumberFormulaArg(float64(timeFromExcelTime(num.Number, false).Hour()))
+	return newNumberFormulaArg(float64(timeFromExcelTime(num.Number, fn.date1904()).Hour()))
 }
 
 // MINUTE function returns an integer representing the minute component of a
@@ -12361,7 +13060,7 @@ func (fn *formulaFuncs) MINUTE(argsList *list.List) formulaArg {
 	if num.Number < 0 {
 		return newErrorFormulaArg(formulaErrorNUM, "MINUTE only accepts positive argument")
 	}
-	return newNumberFormulaArg(float64(timeFromExcelTime(num.Number, false).Minute()))
+	return newNumberFormulaArg(float64(timeFromExcelTime(num.Number, fn.date1904()).Minute()))
 }
 
 // MONTH function returns the month of a date represented by a serial number.
@@ -12391,7 +13090,7 @@ func (fn *formulaFuncs) MONTH(argsList *list.List) formulaArg {
 	if num.Number < 0 {
 		return newErrorFormulaArg(formulaErrorNUM, "MONTH only accepts positive argument")
 	}
-	return newNumberFormulaArg(float64(timeFromExcelTime(num.Number, false).Month()))
+	return newNumberFormulaArg(float64(timeFromExcelTime(num.Number, fn.date1904()).Month()))
 }
 
 // genWeekendMask generate weekend mask of a series of seven 0's and 1's which
@@ -12411,8 +13110,8 @@ func genWeekendMask(weekend int) []byte {
 }
 
 // isWorkday check if the date is workday.
-func isWorkday(weekendMask []byte, date float64) bool {
-	dateTime := timeFromExcelTime(date, false)
+func isWorkday(weekendMask []byte, date float64, date1904 bool) bool {
+	dateTime := timeFromExcelTime(date, date1904)
 	weekday := dateTime.Weekday()
 	if weekday == time.Sunday {
 		weekday = 7
@@ -12448,9 +13147,18 @@ func prepareWorkday(weekend formulaArg) ([]byte, int) {
 	return weekendMask, workdaysPerWeek
 }
 
+// date1904 returns whether the workbook that the formula is evaluated in uses
+// the 1904 date system.
+func (fn *formulaFuncs) date1904() bool {
+	if fn.f == nil {
+		return false
+	}
+	return fn.f.date1904()
+}
+
 // toExcelDateArg function converts a text representation of a time, into an
 // Excel date time number formula argument.
-func toExcelDateArg(arg formulaArg) formulaArg {
+func toExcelDateArg(arg formulaArg, date1904 bool) formulaArg {
 	num := arg.ToNumber()
 	if num.Type != ArgNumber {
 		dateString := strings.ToLower(arg.Value())
@@ -12463,8 +13171,7 @@ func toExcelDateArg(arg formulaArg) formulaArg {
 		if err.Type == ArgError {
 			return err
 		}
-		num.Number, _ = timeToExcelTime(time.Date(y, time.Month(m), d, 0, 0, 0, 0, time.UTC), false)
-		return newNumberFormulaArg(num.Number)
+		return newNumberFormulaArg(toExcelDate(y, time.Month(m), d, date1904))
 	}
 	if arg.Number < 0 {
 		return newErrorFormulaArg(formulaErrorNUM, formulaErrorNUM)
@@ -12474,10 +13181,10 @@ func toExcelDateArg(arg formulaArg) formulaArg {
 
 // prepareHolidays function converts array type formula arguments to into an
 // Excel date time number formula arguments list.
-func prepareHolidays(args formulaArg) []int {
+func prepareHolidays(args formulaArg, date1904 bool) []int {
 	var holidays []int
 	for _, arg := range args.ToList() {
-		num := toExcelDateArg(arg)
+		num := toExcelDateArg(arg, date1904)
 		if num.Type != ArgNumber {
 			continue
 		}
@@ -12487,7 +13194,7 @@ func prepareHolidays(args formulaArg) []int {
 }
 
 // workdayIntl is an implementation of the formula function WORKDAY.INTL.
-func workdayIntl(endDate, sign int, holidays []int, weekendMask []byte, startDate float64) int {
+func workdayIntl(endDate, sign int, holidays []int, weekendMask []byte, startDate float64, date1904 bool) int {
 	for i := 0; i < len(holidays); i++ {
 		holiday := holidays[i]
 		if sign > 0 {
@@ -12501,18 +13208,18 @@ func workdayIntl(endDate, sign int, holidays []int, weekendMask []byte, startDat
 		}
 		if sign > 0 {
 			if holiday > int(math.Ceil(startDate)) {
-				if isWorkday(weekendMask, float64(holiday)) {
+				if isWorkday(weekendMask, float64(holiday), date1904) {
 					endDate += sign
-					for !isWorkday(weekendMask, float64(endDate)) {
+					for !isWorkday(weekendMask, float64(endDate), date1904) {
 						endDate += sign
 					}
 				}
 			}
 		} else {
 			if holiday < int(math.Ceil(startDate)) {
-				if isWorkday(weekendMask, float64(holiday)) {
+				if isWorkday(weekendMask, float64(holiday), date1904) {
 					endDate += sign
-					for !isWorkday(weekendMask, float64(endDate)) {
+					for !isWorkday(weekendMask, float64(endDate), date1904) {
 						endDate += sign
 					}
 				}
@@ -12558,11 +13265,12 @@ func (fn *formulaFuncs) NETWORKDAYSdotINTL(argsList *list.List) formulaArg {
 	if argsList.Len() > 4 {
 		return newErrorFormulaArg(formulaErrorVALUE, "NETWORKDAYS.INTL requires at most 4 arguments")
 	}
-	startDate := toExcelDateArg(argsList.Front().Value.(formulaArg))
+	date1904 := fn.date1904()
+	startDate := toExcelDateArg(argsList.Front().Value.(formulaArg), date1904)
 	if startDate.Type != ArgNumber {
 		return startDate
 	}
-	endDate := toExcelDateArg(argsList.Front().Next().Value.(formulaArg))
+	endDate := toExcelDateArg(argsList.Front().Next().Value.(formulaArg), date1904)
 	if endDate.Type != ArgNumber {
 		return endDate
 	}
@@ -12572,7 +13280,7 @@ func (fn *formulaFuncs) NETWORKDAYSdotINTL(argsList *list.List) formulaArg {
 	}
 	var holidays []int
 	if argsList.Len() == 4 {
-		holidays = prepareHolidays(argsList.Back().Value.(formulaArg))
+		holidays = prepareHolidays(argsList.Back().Value.(formulaArg), date1904)
 		sort.Ints(holidays)
 	}
 	weekendMask, workdaysPerWeek := prepareWorkday(weekend)
@@ -12590,14 +13298,14 @@ func (fn *formulaFuncs) NETWORKDAYSdotINTL(argsList *list.List) formulaArg {
 	count := int(math.Floor(offset/7) * float64(workdaysPerWeek))
 	daysMod := int(offset) % 7
 	for daysMod >= 0 {
-		if isWorkday(weekendMask, endDate.Number-float64(daysMod)) {
+		if isWorkday(weekendMask, endDate.Number-float64(daysMod), date1904) {
 			count++
 		}
 		daysMod--
 	}
 	for i := 0; i < len(holidays); i++ {
 		holiday := float64(holidays[i])
-		if isWorkday(weekendMask, holiday) && holiday >= startDate.Number && holiday <= endDate.Number {
+		if isWorkday(weekendMask, holiday, date1904) && holiday >= startDate.Number && holiday <= endDate.Number {
 			count--
 		}
 	}
@@ -12639,7 +13347,8 @@ func (fn *formulaFuncs) WORKDAYdotINTL(argsList *list.List) formulaArg {
 	if argsList.Len() > 4 {
 		return newErrorFormulaArg(formulaErrorVALUE, "WORKDAY.INTL requires at most 4 arguments")
 	}
-	startDate := toExcelDateArg(argsList.Front().Value.(formulaArg))
+	date1904 := fn.date1904()
+	startDate := toExcelDateArg(argsList.Front().Value.(formulaArg), date1904)
 	if startDate.Type != ArgNumber {
 		return startDate
 	}
@@ -12653,7 +13362,7 @@ func (fn *formulaFuncs) WORKDAYdotINTL(argsList *list.List) formulaArg {
 	}
 	var holidays []int
 	if argsList.Len() == 4 {
-		holidays = prepareHolidays(argsList.Back().Value.(formulaArg))
+		holidays = prepareHolidays(argsList.Back().Value.(formulaArg), date1904)
 		sort.Ints(holidays)
 	}
 	if days.Number == 0 {
@@ -12671,13 +13380,13 @@ func (fn *formulaFuncs) WORKDAYdotINTL(argsList *list.List) formulaArg {
 	daysMod := int(days.Number) % workdaysPerWeek
 	endDate := int(math.Ceil(startDate.Number)) + offset*7
 	if daysMod == 0 {
-		for !isWorkday(weekendMask, float64(endDate)) {
+		for !isWorkday(weekendMask, float64(endDate), date1904) {
 			endDate -= sign
 		}
 	} else {
 		for daysMod != 0 {
 			endDate += sign
-			if isWorkday(weekendMask, float64(endDate)) {
+			if isWorkday(weekendMask, float64(endDate), date1904) {
 				if daysMod < 0 {
 					daysMod++
 					continue
@@ -12686,7 +13395,7 @@ func (fn *formulaFuncs) WORKDAYdotINTL(argsList *list.List) formulaArg {
 			}
 		}
 	}
-	return newNumberFormulaArg(float64(workdayIntl(endDate, sign, holidays, weekendMask, startDate.Number)))
+	return newNumberFormulaArg(float64(workdayIntl(endDate, sign, holidays, weekendMask, startDate.Number, date1904)))
 }
 
 // YEAR function returns an integer representing the year of a supplied date.
@@ -12715,7 +13424,7 @@ func (fn *formulaFuncs) YEAR(argsList *list.List) formulaArg {
 	if num.Number < 0 {
 		return newErrorFormulaArg(formulaErrorNUM, "YEAR only accepts positive argument")
 	}
-	return newNumberFormulaArg(float64(timeFromExcelTime(num.Number, false).Year()))
+	return newNumberFormulaArg(float64(timeFromExcelTime(num.Number, fn.date1904()).Year()))
 }
 
 // yearFracBasisCond is an implementation of the yearFracBasis1.
@@ -12725,8 +13434,8 @@ func yearFracBasisCond(sy, sm, sd, ey, em, ed int) bool {
 
 // yearFracBasis0 function returns the fraction of a year that between two
 // supplied dates in US (NASD) 30/360 type of day.
-func yearFracBasis0(startDate, endDate float64) (dayDiff, daysInYear float64) {
-	startTime, endTime := timeFromExcelTime(startDate, false), timeFromExcelTime(endDate, false)
+func yearFracBasis0(startDate, endDate float64, date1904 bool) (dayDiff, daysInYear float64) {
+	startTime, endTime := timeFromExcelTime(startDate, date1904), timeFromExcelTime(endDate, date1904)
 	sy, smM, sd := startTime.Date()
 	ey, emM, ed := endTime.Date()
 	sm, em := int(smM), int(emM)
@@ -12748,8 +13457,8 @@ func yearFracBasis0(startDate, endDate float64) (dayDiff, daysInYear float64) {
 
 // yearFracBasis1 function returns the fraction of a year that between two
 // supplied dates in actual type of day.
-func yearFracBasis1(startDate, endDate float64) (dayDiff, daysInYear float64) {
-	startTime, endTime := timeFromExcelTime(startDate, false), timeFromExcelTime(endDate, false)
+func yearFracBasis1(startDate, endDate float64, date1904 bool) (dayDiff, daysInYear float64) {
+	startTime, endTime := timeFromExcelTime(startDate, date1904), timeFromExcelTime(endDate, date1904)
 	sy, smM, sd := startTime.Date()
 	ey, emM, ed := endTime.Date()
 	sm, em := int(smM), int(emM)
@@ -12777,8 +13486,8 @@ func yearFracBasis1(startDate, endDate float64) (dayDiff, daysInYear float64) {
 
 // yearFracBasis4 function returns the fraction of a year that between two
 // supplied dates in European 30/360 type of day.
-func yearFracBasis4(startDate, endDate float64) (dayDiff, daysInYear float64) {
-	startTime, endTime := timeFromExcelTime(startDate, false), timeFromExcelTime(endDate, false)
+func yearFracBasis4(startDate, endDate float64, date1904 bool) (dayDiff, daysInYear float64) {
+	startTime, endTime := timeFromExcelTime(startDate, date1904), timeFromExcelTime(endDate, date1904)
 	sy, smM, sd := startTime.Date()
 	ey, emM, ed := endTime.Date()
 	sm, em := int(smM), int(emM)
@@ -12794,17 +13503,17 @@ func yearFracBasis4(startDate, endDate float64) (dayDiff, daysInYear float64) {
 }
 
 // yearFrac is an implementation of the formula function YEARFRAC.
-func yearFrac(startDate, endDate float64, basis int) formulaArg {
-	startTime, endTime := timeFromExcelTime(startDate, false), timeFromExcelTime(endDate, false)
+func yearFrac(startDate, endDate float64, basis int, date1904 bool) formulaArg {
+	startTime, endTime := timeFromExcelTime(startDate, date1904), timeFromExcelTime(endDate, date1904)
 	if startTime == endTime {
 		return newNumberFormulaArg(0)
 	}
 	var dayDiff, daysInYear float64
 	switch basis {
 	case 0:
-		dayDiff, daysInYear = yearFracBasis0(startDate, endDate)
+		dayDiff, daysInYear = yearFracBasis0(startDate, endDate, date1904)
 	case 1:
-		dayDiff, daysInYear = yearFracBasis1(startDate, endDate)
+		dayDiff, daysInYear = yearFracBasis1(startDate, endDate, date1904)
 	case 2:
 		dayDiff = endDate - startDate
 		daysInYear = 360
@@ -12812,7 +13521,7 @@ func yearFrac(startDate, endDate float64, basis int) formulaArg {
 		dayDiff = endDate - startDate
 		daysInYear = 365
 	case 4:
-		dayDiff, daysInYear = yearFracBasis4(startDate, endDate)
+		dayDiff, daysInYear = yearFracBasis4(startDate, endDate, date1904)
 	default:
 		return newErrorFormulaArg(formulaErrorNUM, "invalid basis")
 	}
@@ -12855,7 +13564,7 @@ func (fn *formulaFuncs) YEARFRAC(argsList *list.List) formulaArg {
 			return basis
 		}
 	}
-	return yearFrac(start.Number, end.Number, int(basis.Number))
+	return yearFrac(start.Number, end.Number, int(basis.Number), fn.date1904())
 }
 
 // NOW function returns the current date and time. The function receives no
@@ -12868,7 +13577,7 @@ func (fn *formulaFuncs) NOW(argsList *list.List) formulaArg {
 	}
 	now := time.Now()
 	_, offset := now.Zone()
-	return newNumberFormulaArg(25569.0 + float64(now.Unix()+int64(offset))/86400)
+	return newNumberFormulaArg(toExcelDate(1970, time.January, 1, fn.date1904()) + float64(now.Unix()+int64(offset))/86400)
 }
 
 // SECOND function returns an integer representing the second component of a
@@ -12898,7 +13607,7 @@ func (fn *formulaFuncs) SECOND(argsList *list.List) formulaArg {
 	if num.Number < 0 {
 		return newErrorFormulaArg(formulaErrorNUM, "SECOND only accepts positive argument")
 	}
-	return newNumberFormulaArg(float64(timeFromExcelTime(num.Number, false).Second()))
+	return newNumberFormulaArg(float64(timeFromExcelTime(num.Number, fn.date1904()).Second()))
 }
 
 // TIME function accepts three integer arguments representing hours, minutes
@@ -12964,7 +13673,7 @@ func (fn *formulaFuncs) TODAY(argsList *list.List) formulaArg {
 	}
 	now := time.Now()
 	_, offset := now.Zone()
-	return newNumberFormulaArg(daysBetween(excelMinTime1900.Unix(), now.Unix()+int64(offset)) + 1)
+	return newNumberFormulaArg(toExcelDate(1970, time.January, 1, fn.date1904()) + daysBetween(0, now.Unix()+int64(offset)))
 }
 
 // makeDate return date as a Unix time, the number of seconds elapsed since
@@ -12977,6 +13686,15 @@ func makeDate(y int, m time.Month, d int) int64 {
 	return date.Unix()
 }
 
+// toExcelDate converts the given year, month and day to the serial number of
+// the date in the 1900 or 1904 date system.
+func toExcelDate(y int, m time.Month, d int, date1904 bool) float64 {
+	if date1904 {
+		return daysBetween(excel1904Epoc.Unix(), time.Date(y, m, d, 0, 0, 0, 0, time.UTC).Unix())
+	}
+	return daysBetween(excelMinTime1900.Unix(), makeDate(y, m, d)) + 1
+}
+
 // daysBetween return time interval of the given start timestamp and end
 // timestamp.
 func daysBetween(startDate, endDate int64) float64 {
@@ -13013,7 +13731,7 @@ func (fn *formulaFuncs) WEEKDAY(argsList *list.List) formulaArg {
 		if num.Number < 0 {
 			return newErrorFormulaArg(formulaErrorNUM, formulaErrorNUM)
 		}
-		weekday = int(timeFromExcelTime(num.Number, false).Weekday())
+		weekday = int(timeFromExcelTime(num.Number, fn.date1904()).Weekday())
 	}
 	if argsList.Len() == 2 {
 		returnTypeArg := argsList.Back().Value.(formulaArg).ToNumber()
@@ -13105,7 +13823,7 @@ func (fn *formulaFuncs) WEEKNUM(argsList *list.List) formulaArg {
 		if num.Number < 0 {
 			return newErrorFormulaArg(formulaErrorNUM, formulaErrorNUM)
 		}
-		snTime = timeFromExcelTime(num.Number, false)
+		snTime = timeFromExcelTime(num.Number, fn.date1904())
 	}
 	if argsList.Len() == 2 {
 		returnTypeArg := argsList.Back().Value.(formulaArg).ToNumber()
@@ -13764,7 +14482,7 @@ func (fn *formulaFuncs) VALUE(argsList *list.List) formulaArg {
 	y, m, d, _, err := strToDate(text)
 	errDate = err.Type == ArgError
 	if !errDate {
-		dateValue = daysBetween(excelMinTime1900.Unix(), makeDate(y, time.Month(m), d)) + 1
+		dateValue = toExcelDate(y, time.Month(m), d, fn.date1904())
 	}
 	if errTime && errDate {
 		return newErrorFormulaArg(formulaErrorVALUE, formulaErrorVALUE)
@@ -14221,46 +14939,148 @@ func (fn *formulaFuncs) HYPERLINK(argsList *list.List) formulaArg {
 	return newStringFormulaArg(argsList.Back().Value.(formulaArg).Value())
 }
 
-// calcMatch returns the position of the value by given match type, criteria
-// and lookup array for the formula function MATCH.
-func calcMatch(matchType int, criteria *formulaCriteria, lookupArray []formulaArg) formulaArg {
-	switch matchType {
-	case 0:
-		for i, arg := range lookupArray {
-			if ok, _ := formulaCriteriaEval(arg.Value(), criteria); ok {
-				return newNumberFormulaArg(float64(i + 1))
+// calcMatchLinear returns the position of the closest value by sequentially
+// checking the lookup array with given match type and criteria, for the
+// approximate match modes of the formula function XLOOKUP.
+func calcMatchLinear(matchType int, criteria *formulaCriteria, lookupArray []formulaArg) formulaArg {
+	condition := map[int]byte{-1: criteriaL, 1: criteriaG}[matchType]
+	for i, arg := range lookupArray {
+		if ok, _ := formulaCriteriaEval(arg.Value(), criteria); ok {
+			return newNumberFormulaArg(float64(i + 1))
+		}
+		if ok, _ := formulaCriteriaEval(arg.Value(), &formulaCriteria{
+			Type: condition, Condition: criteria.Condition,
+		}); ok {
+			if i == 0 {
+				return newErrorFormulaArg(formulaErrorNA, formulaErrorNA)
 			}
+			return newNumberFormulaArg(float64(i))
 		}
-	case -1:
-		for i, arg := range lookupArray {
-			if ok, _ := formulaCriteriaEval(arg.Value(), criteria); ok {
-				return newNumberFormulaArg(float64(i + 1))
+	}
+	return newErrorFormulaArg(formulaErrorNA, formulaErrorNA)
+}
+
+// prepareMatchValue converts the lookup value or the value of the lookup
+// array to the formula argument for comparing in the formula function MATCH,
+// the numeric text will be converted to number and the blank cell will be
+// converted to empty.
+func prepareMatchValue(arg formulaArg) formulaArg {
+	if arg.Type != ArgString {
+		return arg
+	}
+	if arg.String == "" {
+		return newEmptyFormulaArg()
+	}
+	if num := arg.ToNumber(); num.Type == ArgNumber {
+		return num
+	}
+	return arg
+}
+
+// prepareMatchCells converts the values of the boolean cells in the given
+// reference to the boolean formula arguments for comparing in the formula
+// function MATCH, because the raw values of the boolean cells are 1 and 0.
+func (fn *formulaFuncs) prepareMatchCells(arg formulaArg, values []formulaArg) {
+	var refs []cellRef
+	if arg.cellRanges != nil && arg.cellRanges.Len() == 1 {
+		cr := arg.cellRanges.Front().Value.(cellRange)
+		rng := []int{cr.From.Col, cr.From.Row, cr.To.Col, cr.To.Row}
+		_ = sortCoordinates(rng)
+		for row := rng[1]; row <= rng[3] && len(refs) < len(values); row++ {
+			for col := rng[0]; col <= rng[2] && len(refs) < len(values); col++ {
+				refs = append(refs, cellRef{Col: col, Row: row, Sheet: cr.From.Sheet})
 			}
-			if ok, _ := formulaCriteriaEval(arg.Value(), &formulaCriteria{
-				Type: criteriaL, Condition: criteria.Condition,
-			}); ok {
-				if i == 0 {
-					return newErrorFormulaArg(formulaErrorNA, formulaErrorNA)
-				}
-				return newNumberFormulaArg(float64(i))
+		}
+	} else if (arg.cellRanges == nil || arg.cellRanges.Len() == 0) && arg.cellRefs != nil && arg.cellRefs.Len() == 1 {
+		refs = append(refs, arg.cellRefs.Front().Value.(cellRef))
+	}
+	for i, ref := range refs {
+		if i >= len(values) || values[i].Type != ArgString {
+			continue
+		}
+		cell, _ := CoordinatesToCellName(ref.Col, ref.Row)
+		if cellType, _ := fn.f.GetCellType(ref.Sheet, cell); cellType == CellTypeBool {
+			if b := values[i].ToBool(); b.Type == ArgNumber {
+				values[i] = b
 			}
 		}
-	case 1:
+	}
+}
+
+// compareMatchValue compares the value of the lookup array and the lookup
+// value for the formula function MATCH, the values with different types and
+// the blank cells are incomparable. The text TRUE and FALSE are comparable
+// with the logical values, and FALSE is less than TRUE.
+func compareMatchValue(lhs, rhs formulaArg) byte {
+	toBool := func(arg formulaArg) formulaArg {
+		if arg.Type == ArgString && (strings.EqualFold(arg.String, "TRUE") || strings.EqualFold(arg.String, "FALSE")) {
+			return newBoolFormulaArg(strings.EqualFold(arg.String, "TRUE"))
+		}
+		return arg
+	}
+	if lhs.Boolean {
+		rhs = toBool(rhs)
+	}
+	if rhs.Boolean {
+		lhs = toBool(lhs)
+	}
+	if lhs.Type != rhs.Type || lhs.Boolean != rhs.Boolean || (lhs.Type != ArgNumber && lhs.Type != ArgString) {
+		return criteriaErr
+	}
+	return compareFormulaArg(lhs, rhs, newNumberFormulaArg(matchModeExact), false)
+}
+
+// calcMatch returns the position of the value by given match type, lookup
+// value and lookup array for the formula function MATCH. The exact match
+// type 0 checks each value sequentially and supports wildcards for text. The
+// match type 1 and -1 use binary search, which returns the position of the
+// largest value that is less than or equal to the lookup value in the
+// ascending array, and the position of the smallest value that is greater
+// than or equal to the lookup value in the descending array. Like Excel, the
+// result is unpredictable if the lookup array isn't sorted in the order.
+func calcMatch(matchType int, lookupValue formulaArg, lookupArray []formulaArg) formulaArg {
+	lookupValue = prepareMatchValue(lookupValue)
+	if matchType == 0 {
 		for i, arg := range lookupArray {
-			if ok, _ := formulaCriteriaEval(arg.Value(), criteria); ok {
-				return newNumberFormulaArg(float64(i + 1))
-			}
-			if ok, _ := formulaCriteriaEval(arg.Value(), &formulaCriteria{
-				Type: criteriaG, Condition: criteria.Condition,
-			}); ok {
-				if i == 0 {
-					return newErrorFormulaArg(formulaErrorNA, formulaErrorNA)
+			arg = prepareMatchValue(arg)
+			if lookupValue.Type == ArgString && arg.Type == ArgString {
+				if matchPattern(strings.ToLower(lookupValue.String), strings.ToLower(arg.String)) {
+					return newNumberFormulaArg(float64(i + 1))
 				}
-				return newNumberFormulaArg(float64(i))
+				continue
+			}
+			if compareMatchValue(arg, lookupValue) == criteriaEq {
+				return newNumberFormulaArg(float64(i + 1))
 			}
 		}
+		return newErrorFormulaArg(formulaErrorNA, formulaErrorNA)
 	}
-	return newErrorFormulaArg(formulaErrorNA, formulaErrorNA)
+	satisfied := func(result byte) bool {
+		return result == criteriaEq || (matchType == 1 && result == criteriaL) ||
+			(matchType == -1 && result == criteriaG)
+	}
+	low, high, matchIdx := 0, len(lookupArray)-1, -1
+	for low <= high {
+		mid := low + (high-low)/2
+		idx, result := mid, compareMatchValue(prepareMatchValue(lookupArray[mid]), lookupValue)
+		for result == criteriaErr && idx > low {
+			idx--
+			result = compareMatchValue(prepareMatchValue(lookupArray[idx]), lookupValue)
+		}
+		if result == criteriaErr {
+			low = mid + 1
+			continue
+		}
+		if satisfied(result) {
+			matchIdx, low = idx, mid+1
+			continue
+		}
+		high = idx - 1
+	}
+	if matchIdx == -1 {
+		return newErrorFormulaArg(formulaErrorNA, formulaErrorNA)
+	}
+	return newNumberFormulaArg(float64(matchIdx + 1))
 }
 
 // MATCH function looks up a value in an array, and returns the position of
@@ -14277,28 +15097,37 @@ func (fn *formulaFuncs) MATCH(argsList *list.List) formulaArg {
 	var (
 		matchType      = 1
 		lookupArray    []formulaArg
+		lookupValue    = argsList.Front().Value.(formulaArg)
 		lookupArrayArg = argsList.Front().Next().Value.(formulaArg)
 		lookupArrayErr = "MATCH arguments lookup_array should be one-dimensional array"
 	)
+	if lookupValue.Type == ArgError {
+		return lookupValue
+	}
 	if argsList.Len() == 3 {
 		matchTypeArg := argsList.Back().Value.(formulaArg).ToNumber()
 		if matchTypeArg.Type != ArgNumber {
 			return newErrorFormulaArg(formulaErrorVALUE, "MATCH requires numeric match_type argument")
 		}
-		if matchTypeArg.Number == -1 || matchTypeArg.Number == 0 {
-			matchType = int(matchTypeArg.Number)
+		if matchTypeArg.Number == 0 {
+			matchType = 0
+		} else if matchTypeArg.Number < 0 {
+			matchType = -1
 		}
 	}
 	switch lookupArrayArg.Type {
 	case ArgMatrix:
-		if len(lookupArrayArg.Matrix[0]) != 1 {
+		if len(lookupArrayArg.Matrix[0]) != 1 && len(lookupArrayArg.Matrix) != 1 {
 			return newErrorFormulaArg(formulaErrorNA, lookupArrayErr)
 		}
 		lookupArray = lookupArrayArg.ToList()
 	default:
 		return newErrorFormulaArg(formulaErrorNA, lookupArrayErr)
 	}
-	return calcMatch(matchType, formulaCriteriaParser(argsList.Front().Value.(formulaArg).Value()), lookupArray)
+	lookupValues := []formulaArg{lookupValue}
+	fn.prepareMatchCells(lookupValue, lookupValues)
+	fn.prepareMatchCells(lookupArrayArg, lookupArray)
+	return calcMatch(matchType, lookupValues[0], lookupArray)
 }
 
 // TRANSPOSE function 'transposes' an array of cells (i.e. the function copies
@@ -14332,6 +15161,224 @@ func (fn *formulaFuncs) TRANSPOSE(argsList *list.List) formulaArg {
 	return newMatrixFormulaArg(mtx)
 }
 
+// formulaArgToMatrix converts the formula argument to a matrix, a list will
+// be converted to a single row matrix and a single value will be converted
+// to a 1 x 1 matrix.
+func formulaArgToMatrix(arg formulaArg) [][]formulaArg {
+	switch arg.Type {
+	case ArgMatrix:
+		return arg.Matrix
+	case ArgList:
+		return [][]formulaArg{arg.List}
+	}
+	return [][]formulaArg{{arg}}
+}
+
+// transposeFormulaArgMatrix returns the transpose of the given formula
+// arguments matrix.
+func transposeFormulaArgMatrix(matrix [][]formulaArg) [][]formulaArg {
+	if len(matrix) == 0 {
+		return matrix
+	}
+	mtx := make([][]formulaArg, len(matrix[0]))
+	for c := range mtx {
+		mtx[c] = make([]formulaArg, len(matrix))
+		for r := range matrix {
+			mtx[c][r] = matrix[r][c]
+		}
+	}
+	return mtx
+}
+
+// UNIQUE function returns a list of unique values in a list or range. The
+// by_col specifies whether to compare the columns instead of the rows, and
+// the exactly_once specifies whether to return only the rows or columns that
+// occur exactly once. The comparison is not case-sensitive. The syntax of the
+// function is:
+//
+//	UNIQUE(array,[by_col],[exactly_once])
+func (fn *formulaFuncs) UNIQUE(argsList *list.List) formulaArg {
+	if argsList.Len() < 1 {
+		return newErrorFormulaArg(formulaErrorVALUE, "UNIQUE requires at least 1 argument")
+	}
+	if argsList.Len() > 3 {
+		return newErrorFormulaArg(formulaErrorVALUE, "UNIQUE allows at most 3 arguments")
+	}
+	var byCol, exactlyOnce bool
+	if argsList.Len() > 1 {
+		arg := argsList.Front().Next().Value.(formulaArg).ToBool()
+		if arg.Type == ArgError {
+			return arg
+		}
+		byCol = arg.Number == 1
+	}
+	if argsList.Len() > 2 {
+		arg := argsList.Back().Value.(formulaArg).ToBool()
+		if arg.Type == ArgError {
+			return arg
+		}
+		exactlyOnce = arg.Number == 1
+	}
+	matrix := formulaArgToMatrix(argsList.Front().Value.(formulaArg))
+	if byCol {
+		matrix = transposeFormulaArgMatrix(matrix)
+	}
+	var keys []string
+	counts, items := map[string]int{}, map[string][]formulaArg{}
+	for _, row := range matrix {
+		values := make([]string, len(row))
+		for i, cell := range row {
+			values[i] = strings.ToLower(cell.Value())
+		}
+		key := strings.Join(values, "\x00")
+		if _, ok := counts[key]; !ok {
+			keys = append(keys, key)
+			items[key] = row
+		}
+		counts[key]++
+	}
+	var result [][]formulaArg
+	for _, key := range keys {
+		if !exactlyOnce || counts[key] == 1 {
+			result = append(result, items[key])
+		}
+	}
+	if len(result) == 0 {
+		return newErrorFormulaArg(formulaErrorCALC, formulaErrorCALC)
+	}
+	if byCol {
+		result = transposeFormulaArgMatrix(result)
+	}
+	return newMatrixFormulaArg(result)
+}
+
+// sortByValueRank returns the rank of the type of the formula argument in the
+// ascending sort order: numbers, text, logical values, errors and blanks, and
+// the formula argument converted to the type for comparison.
+func sortByValueRank(arg formulaArg) (int, formulaArg) {
+	switch arg.Type {
+	case ArgNumber:
+		if arg.Boolean {
+			return 2, arg
+		}
+		return 0, arg
+	case ArgString:
+		if arg.String == "" {
+			return 4, arg
+		}
+		if num := arg.ToNumber(); num.Type == ArgNumber {
+			return 0, num
+		}
+		if val := strings.ToUpper(arg.String); val == "TRUE" || val == "FALSE" {
+			return 2, newBoolFormulaArg(val == "TRUE")
+		}
+		return 1, arg
+	case ArgError:
+		return 3, arg
+	}
+	return 4, arg
+}
+
+// compareSortByValue compares the formula arguments in the ascending sort
+// order, and returns -1, 0 or 1 if the left-hand side is less than, equal to
+// or greater than the right-hand side.
+func compareSortByValue(lhs, rhs formulaArg) int {
+	lRank, lArg := sortByValueRank(lhs)
+	rRank, rArg := sortByValueRank(rhs)
+	if lRank != rRank {
+		if lRank < rRank {
+			return -1
+		}
+		return 1
+	}
+	switch lRank {
+	case 0, 2:
+		if lArg.Number < rArg.Number {
+			return -1
+		}
+		if lArg.Number > rArg.Number {
+			return 1
+		}
+	case 1:
+		return strings.Compare(strings.ToLower(lArg.String), strings.ToLower(rArg.String))
+	}
+	return 0
+}
+
+// SORTBY function sorts the contents of a range or array based on the values
+// in a corresponding range or array. Each by_array must be a single row or a
+// single column with the same size as the array, and the sort_order is 1 for
+// ascending order (default) or -1 for descending order. The sort is stable,
+// the later by_array is used to sort the rows or columns that have the same
+// values in the earlier by_array. The syntax of the function is:
+//
+//	SORTBY(array,by_array1,[sort_order1],[by_array2,sort_order2],...)
+func (fn *formulaFuncs) SORTBY(argsList *list.List) formulaArg {
+	if argsList.Len() < 2 {
+		return newErrorFormulaArg(formulaErrorVALUE, "SORTBY requires at least 2 arguments")
+	}
+	matrix := formulaArgToMatrix(argsList.Front().Value.(formulaArg))
+	if len(matrix) == 0 || len(matrix[0]) == 0 {
+		return newErrorFormulaArg(formulaErrorVALUE, formulaErrorVALUE)
+	}
+	var byCol, byRow bool
+	var keys [][]formulaArg
+	var orders []float64
+	for arg := argsList.Front().Next(); arg != nil; arg = arg.Next() {
+		byArray := formulaArgToMatrix(arg.Value.(formulaArg))
+		var key []formulaArg
+		switch {
+		case len(byArray) == 0:
+			return newErrorFormulaArg(formulaErrorVALUE, formulaErrorVALUE)
+		case len(byArray) == len(matrix) && len(byArray[0]) == 1 && !byCol:
+			byRow = true
+			for _, row := range byArray {
+				key = append(key, row[0])
+			}
+		case len(byArray) == 1 && len(byArray[0]) == len(matrix[0]) && !byRow:
+			byCol, key = true, byArray[0]
+		default:
+			return newErrorFormulaArg(formulaErrorVALUE, formulaErrorVALUE)
+		}
+		order := 1.0
+		if arg.Next() != nil {
+			arg = arg.Next()
+			num := arg.Value.(formulaArg).ToNumber()
+			if num.Type == ArgError {
+				return num
+			}
+			if num.Number != 1 && num.Number != -1 {
+				return newErrorFormulaArg(formulaErrorVALUE, formulaErrorVALUE)
+			}
+			order = num.Number
+		}
+		keys, orders = append(keys, key), append(orders, order)
+	}
+	if byCol {
+		matrix = transposeFormulaArgMatrix(matrix)
+	}
+	indexes := make([]int, len(matrix))
+	for i := range indexes {
+		indexes[i] = i
+	}
+	sort.SliceStable(indexes, func(i, j int) bool {
+		for k, key := range keys {
+			if cmp := compareSortByValue(key[indexes[i]], key[indexes[j]]); cmp != 0 {
+				return float64(cmp)*orders[k] < 0
+			}
+		}
+		return false
+	})
+	result := make([][]formulaArg, len(indexes))
+	for i, idx := range indexes {
+		result[i] = matrix[idx]
+	}
+	if byCol {
+		result = transposeFormulaArgMatrix(result)
+	}
+	return newMatrixFormulaArg(result)
+}
+
 // lookupLinearSearch sequentially checks each look value of the lookup array until
 // a match is found or the whole list has been searched.
 func lookupLinearSearch(vertical bool, lookupValue, lookupArray, matchMode, searchMode formulaArg) (int, bool) {
@@ -14362,7 +15409,7 @@ start:
 			}
 		}
 		if matchMode.Number == matchModeMinGreater || matchMode.Number == matchModeMaxLess {
-			matchIdx = int(calcMatch(int(matchMode.Number), formulaCriteriaParser(lookupValue.Value()), tableArray).Number)
+			matchIdx = int(calcMatchLinear(int(matchMode.Number), formulaCriteriaParser(lookupValue.Value()), tableArray).Number)
 			continue
 		}
 	}
@@ -14670,7 +15717,9 @@ func (fn *formulaFuncs) INDEX(argsList *list.List) formulaArg {
 		return newErrorFormulaArg(formulaErrorVALUE, "INDEX requires 2 or 3 arguments")
 	}
 	array := argsList.Front().Value.(formulaArg)
-	if array.Type != ArgMatrix && array.Type != ArgList {
+	if array.Type == ArgList {
+		array = newMatrixFormulaArg([][]formulaArg{array.List})
+	} else if array.Type != ArgMatrix {
 		array = newMatrixFormulaArg([][]formulaArg{{array}})
 	}
 	rowArg := argsList.Front().Next().Value.(formulaArg).ToNumber()
@@ -14685,6 +15734,13 @@ func (fn *formulaFuncs) INDEX(argsList *list.List) formulaArg {
 		}
 		colIdx = int(colArg.Number) - 1
 	}
+	if rowIdx < -1 || colIdx < -1 {
+		return newErrorFormulaArg(formulaErrorVALUE, "INDEX requires non-negative row_num and col_num arguments")
+	}
+	// The row_num argument selects the column of an array with only one row
+	if argsList.Len() == 2 && len(array.Matrix) == 1 && len(array.Matrix[0]) > 1 {
+		rowIdx, colIdx = 0, rowIdx
+	}
 	if rowIdx == -1 && colIdx == -1 {
 		if len(array.ToList()) != 1 {
 			return newErrorFormulaArg(formulaErrorVALUE, formulaErrorVALUE)
@@ -14947,7 +16003,7 @@ func (fn *formulaFuncs) ACCRINT(argsList *list.List) formulaArg {
 			return newErrorFormulaArg(formulaErrorVALUE, formulaErrorVALUE)
 		}
 	}
-	frac1 := yearFrac(issue.Number, settlement.Number, int(basis.Number))
+	frac1 := yearFrac(issue.Number, settlement.Number, int(basis.Number), fn.date1904())
 	if frac1.Type != ArgNumber {
 		return frac1
 	}
@@ -14984,7 +16040,7 @@ func (fn *formulaFuncs) ACCRINTM(argsList *list.List) formulaArg {
 			return newErrorFormulaArg(formulaErrorNUM, formulaErrorNUM)
 		}
 	}
-	frac := yearFrac(issue.Number, settlement.Number, int(basis.Number))
+	frac := yearFrac(issue.Number, settlement.Number, int(basis.Number), fn.date1904())
 	if frac.Type != ArgNumber {
 		return frac
 	}
@@ -15066,7 +16122,7 @@ func (fn *formulaFuncs) AMORDEGRC(argsList *list.List) formulaArg {
 		amorCoeff = 2
 	}
 	rate.Number *= amorCoeff
-	frac := yearFrac(datePurchased.Number, firstPeriod.Number, int(basis.Number))
+	frac := yearFrac(datePurchased.Number, firstPeriod.Number, int(basis.Number), fn.date1904())
 	if frac.Type != ArgNumber {
 		return frac
 	}
@@ -15104,7 +16160,7 @@ func (fn *formulaFuncs) AMORLINC(argsList *list.List) formulaArg {
 		return args
 	}
 	cost, datePurchased, firstPeriod, salvage, period, rate, basis := args.List[0], args.List[1], args.List[2], args.List[3], args.List[4], args.List[5], args.List[6]
-	frac := yearFrac(datePurchased.Number, firstPeriod.Number, int(basis.Number))
+	frac := yearFrac(datePurchased.Number, firstPeriod.Number, int(basis.Number), fn.date1904())
 	if frac.Type != ArgNumber {
 		return frac
 	}
@@ -15189,7 +16245,7 @@ func coupdays(from, to time.Time, basis int) float64 {
 	toY, toM, toD := to.Date()
 	fromDay, toDay := getDayOnBasis(fromY, int(fromM), fromD, basis), getDayOnBasis(toY, int(toM), toD, basis)
 	if !is30BasisMethod(basis) {
-		return (daysBetween(excelMinTime1900.Unix(), makeDate(toY, toM, toDay)) + 1) - (daysBetween(excelMinTime1900.Unix(), makeDate(fromY, fromM, fromDay)) + 1)
+		return toExcelDate(toY, toM, toDay, false) - toExcelDate(fromY, fromM, fromDay, false)
 	}
 	if basis == 0 {
 		if (int(fromM) == 2 || fromDay < 30) && toD == 31 {
@@ -15229,8 +16285,8 @@ func (fn *formulaFuncs) COUPDAYBS(argsList *list.List) formulaArg {
 	if args.Type != ArgList {
 		return args
 	}
-	settlement := timeFromExcelTime(args.List[0].Number, false)
-	pcd := timeFromExcelTime(fn.COUPPCD(argsList).Number, false)
+	settlement := timeFromExcelTime(args.List[0].Number, fn.date1904())
+	pcd := timeFromExcelTime(fn.COUPPCD(argsList).Number, fn.date1904())
 	return newNumberFormulaArg(coupdays(pcd, settlement, int(args.List[3].Number)))
 }
 
@@ -15246,7 +16302,7 @@ func (fn *formulaFuncs) COUPDAYS(argsList *list.List) formulaArg {
 	freq := args.List[2].Number
 	basis := int(args.List[3].Number)
 	if basis == 1 {
-		pcd := timeFromExcelTime(fn.COUPPCD(argsList).Number, false)
+		pcd := timeFromExcelTime(fn.COUPPCD(argsList).Number, fn.date1904())
 		next := pcd.AddDate(0, 12/int(freq), 0)
 		return newNumberFormulaArg(coupdays(pcd, next, basis))
 	}
@@ -15262,16 +16318,16 @@ func (fn *formulaFuncs) COUPDAYSNC(argsList *list.List) formulaArg {
 	if args.Type != ArgList {
 		return args
 	}
-	settlement := timeFromExcelTime(args.List[0].Number, false)
+	settlement := timeFromExcelTime(args.List[0].Number, fn.date1904())
 	basis := int(args.List[3].Number)
-	ncd := timeFromExcelTime(fn.COUPNCD(argsList).Number, false)
+	ncd := timeFromExcelTime(fn.COUPNCD(argsList).Number, fn.date1904())
 	return newNumberFormulaArg(coupdays(settlement, ncd, basis))
 }
 
 // coupons is an implementation of the formula functions COUPNCD and COUPPCD.
 func (fn *formulaFuncs) coupons(name string, arg formulaArg) formulaArg {
-	settlement := timeFromExcelTime(arg.List[0].Number, false)
-	maturity := timeFromExcelTime(arg.List[1].Number, false)
+	settlement := timeFromExcelTime(arg.List[0].Number, fn.date1904())
+	maturity := timeFromExcelTime(arg.List[1].Number, fn.date1904())
 	maturityDays := (maturity.Year()-settlement.Year())*12 + (int(maturity.Month()) - int(settlement.Month()))
 	coupon := 12 / int(arg.List[2].Number)
 	mod := maturityDays % coupon
@@ -15299,7 +16355,7 @@ func (fn *formulaFuncs) coupons(name string, arg formulaArg) formulaArg {
 	} else if day > 27 && day > days {
 		day = days
 	}
-	return newNumberFormulaArg(daysBetween(excelMinTime1900.Unix(), makeDate(year, time.Month(month), day)) + 1)
+	return newNumberFormulaArg(toExcelDate(year, time.Month(month), day, fn.date1904()))
 }
 
 // COUPNCD function calculates the number of coupons payable, between a
@@ -15325,7 +16381,7 @@ func (fn *formulaFuncs) COUPNUM(argsList *list.List) formulaArg {
 	if args.Type != ArgList {
 		return args
 	}
-	frac := yearFrac(args.List[0].Number, args.List[1].Number, 0)
+	frac := yearFrac(args.List[0].Number, args.List[1].Number, 0, fn.date1904())
 	return newNumberFormulaArg(math.Ceil(frac.Number * args.List[2].Number))
 }
 
@@ -15593,7 +16649,7 @@ func (fn *formulaFuncs) DISC(argsList *list.List) formulaArg {
 			return newErrorFormulaArg(formulaErrorNUM, formulaErrorNUM)
 		}
 	}
-	frac := yearFrac(settlement.Number, maturity.Number, int(basis.Number))
+	frac := yearFrac(settlement.Number, maturity.Number, int(basis.Number), fn.date1904())
 	if frac.Type != ArgNumber {
 		return frac
 	}
@@ -15693,7 +16749,7 @@ func (fn *formulaFuncs) prepareDurationArgs(name string, argsList *list.List) fo
 
 // duration is an implementation of the formula function DURATION.
 func (fn *formulaFuncs) duration(settlement, maturity, coupon, yld, frequency, basis formulaArg) formulaArg {
-	frac := yearFrac(settlement.Number, maturity.Number, int(basis.Number))
+	frac := yearFrac(settlement.Number, maturity.Number, int(basis.Number), fn.date1904())
 	if frac.Type != ArgNumber {
 		return frac
 	}
@@ -15946,7 +17002,7 @@ func (fn *formulaFuncs) INTRATE(argsList *list.List) formulaArg {
 			return newErrorFormulaArg(formulaErrorNUM, formulaErrorNUM)
 		}
 	}
-	frac := yearFrac(settlement.Number, maturity.Number, int(basis.Number))
+	frac := yearFrac(settlement.Number, maturity.Number, int(basis.Number), fn.date1904())
 	if frac.Type != ArgNumber {
 		return frac
 	}
@@ -16315,7 +17371,7 @@ func changeMonth(date time.Time, numMonths float64, returnLastMonth bool) time.T
 	newDate := date.AddDate(0, int(numMonths), offsetDay)
 	if returnLastMonth {
 		lastDay := getDaysInMonth(newDate.Year(), int(newDate.Month()))
-		return timeFromExcelTime(daysBetween(excelMinTime1900.Unix(), makeDate(newDate.Year(), newDate.Month(), lastDay))+1, false)
+		return time.Date(newDate.Year(), newDate.Month(), lastDay, 0, 0, 0, 0, time.UTC)
 	}
 	return newDate
 }
@@ -16346,8 +17402,8 @@ func datesAggregate(startDate, endDate time.Time, numMonths float64, f func(pcd,
 }
 
 // coupNumber is a part of implementation of the formula function ODDFPRICE.
-func coupNumber(maturity, settlement, numMonths float64) float64 {
-	maturityTime, settlementTime := timeFromExcelTime(maturity, false), timeFromExcelTime(settlement, false)
+func coupNumber(maturity, settlement, numMonths float64, date1904 bool) float64 {
+	maturityTime, settlementTime := timeFromExcelTime(maturity, date1904), timeFromExcelTime(settlement, date1904)
 	my, mm, md := maturityTime.Year(), maturityTime.Month(), maturityTime.Day()
 	sy, sm, sd := settlementTime.Year(), settlementTime.Month(), settlementTime.Day()
 	couponsTemp, endOfMonthTemp := 0.0, getDaysInMonth(my, int(mm)) == md
@@ -16438,10 +17494,10 @@ func (fn *formulaFuncs) ODDFPRICE(argsList *list.List) formulaArg {
 	if basisArg.Number < 0 || basisArg.Number > 4 {
 		return newErrorFormulaArg(formulaErrorNUM, "invalid basis")
 	}
-	issueTime := timeFromExcelTime(issue.Number, false)
-	settlementTime := timeFromExcelTime(settlement.Number, false)
-	maturityTime := timeFromExcelTime(maturity.Number, false)
-	firstCouponTime := timeFromExcelTime(firstCoupon.Number, false)
+	issueTime := timeFromExcelTime(issue.Number, fn.date1904())
+	settlementTime := timeFromExcelTime(settlement.Number, fn.date1904())
+	maturityTime := timeFromExcelTime(maturity.Number, fn.date1904())
+	firstCouponTime := timeFromExcelTime(firstCoupon.Number, fn.date1904())
 	basis := int(basisArg.Number)
 	monthDays := getDaysInMonth(maturityTime.Year(), int(maturityTime.Month()))
 	returnLastMonth := monthDays == maturityTime.Day()
@@ -16487,9 +17543,9 @@ func (fn *formulaFuncs) ODDFPRICE(argsList *list.List) formulaArg {
 	nc := fn.COUPNUM(fnArgs)
 	lastCoupon := firstCoupon.Number
 	aggrFunc := func(acc []float64, index float64) []float64 {
-		lastCouponTime := timeFromExcelTime(lastCoupon, false)
-		earlyCoupon := daysBetween(excelMinTime1900.Unix(), makeDate(lastCouponTime.Year(), time.Month(float64(lastCouponTime.Month())+numMonthsNeg), lastCouponTime.Day())) + 1
-		earlyCouponTime := timeFromExcelTime(earlyCoupon, false)
+		lastCouponTime := timeFromExcelTime(lastCoupon, fn.date1904())
+		earlyCoupon := toExcelDate(lastCouponTime.Year(), time.Month(float64(lastCouponTime.Month())+numMonthsNeg), lastCouponTime.Day(), fn.date1904())
+		earlyCouponTime := timeFromExcelTime(earlyCoupon, fn.date1904())
 		nl := e.Number
 		if basis == 1 {
 			nl = coupdays(earlyCouponTime, lastCouponTime, basis)
@@ -16506,8 +17562,8 @@ func (fn *formulaFuncs) ODDFPRICE(argsList *list.List) formulaArg {
 		if settlement.Number < lastCoupon {
 			endDate = settlement.Number
 		}
-		startDateTime := timeFromExcelTime(startDate, false)
-		endDateTime := timeFromExcelTime(endDate, false)
+		startDateTime := timeFromExcelTime(startDate, fn.date1904())
+		endDateTime := timeFromExcelTime(endDate, fn.date1904())
 		a := coupdays(startDateTime, endDateTime, basis)
 		lastCoupon = earlyCoupon
 		dcnl := acc[0]
@@ -16522,14 +17578,14 @@ func (fn *formulaFuncs) ODDFPRICE(argsList *list.List) formulaArg {
 	fnArgs.PushBack(firstCoupon)
 	fnArgs.PushBack(frequency)
 	if basis == 2 || basis == 3 {
-		d := timeFromExcelTime(fn.COUPNCD(fnArgs).Number, false)
+		d := timeFromExcelTime(fn.COUPNCD(fnArgs).Number, fn.date1904())
 		dsc = coupdays(settlementTime, d, basis)
 	} else {
-		d := timeFromExcelTime(fn.COUPPCD(fnArgs).Number, false)
+		d := timeFromExcelTime(fn.COUPPCD(fnArgs).Number, fn.date1904())
 		a := coupdays(d, settlementTime, basis)
 		dsc = e.Number - a
 	}
-	nq := coupNumber(firstCoupon.Number, settlement.Number, numMonths)
+	nq := coupNumber(firstCoupon.Number, settlement.Number, numMonths, fn.date1904())
 	fnArgs.Init()
 	fnArgs.PushBack(firstCoupon)
 	fnArgs.PushBack(maturity)
@@ -16750,7 +17806,7 @@ func (fn *formulaFuncs) PRICEDISC(argsList *list.List) formulaArg {
 			return newErrorFormulaArg(formulaErrorNUM, formulaErrorNUM)
 		}
 	}
-	frac := yearFrac(settlement.Number, maturity.Number, int(basis.Number))
+	frac := yearFrac(settlement.Number, maturity.Number, int(basis.Number), fn.date1904())
 	if frac.Type != ArgNumber {
 		return frac
 	}
@@ -16796,12 +17852,12 @@ func (fn *formulaFuncs) PRICEMAT(argsList *list.List) formulaArg {
 			return newErrorFormulaArg(formulaErrorNUM, formulaErrorNUM)
 		}
 	}
-	dsm := yearFrac(settlement.Number, maturity.Number, int(basis.Number))
+	dsm := yearFrac(settlement.Number, maturity.Number, int(basis.Number), fn.date1904())
 	if dsm.Type != ArgNumber {
 		return dsm
 	}
-	dis := yearFrac(issue.Number, settlement.Number, int(basis.Number))
-	dim := yearFrac(issue.Number, maturity.Number, int(basis.Number))
+	dis := yearFrac(issue.Number, settlement.Number, int(basis.Number), fn.date1904())
+	dim := yearFrac(issue.Number, maturity.Number, int(basis.Number), fn.date1904())
 	return newNumberFormulaArg(((1+dim.Number*rate.Number)/(1+dsm.Number*yld.Number) - dis.Number*rate.Number) * 100)
 }
 
@@ -16953,7 +18009,7 @@ func (fn *formulaFuncs) RECEIVED(argsList *list.List) formulaArg {
 			return newErrorFormulaArg(formulaErrorNUM, formulaErrorNUM)
 		}
 	}
-	frac := yearFrac(settlement.Number, maturity.Number, int(basis.Number))
+	frac := yearFrac(settlement.Number, maturity.Number, int(basis.Number), fn.date1904())
 	if frac.Type != ArgNumber {
 		return frac
 	}
@@ -17521,7 +18577,7 @@ func (fn *formulaFuncs) YIELDDISC(argsList *list.List) formulaArg {
 			return newErrorFormulaArg(formulaErrorNUM, formulaErrorNUM)
 		}
 	}
-	frac := yearFrac(settlement.Number, maturity.Number, int(basis.Number))
+	frac := yearFrac(settlement.Number, maturity.Number, int(basis.Number), fn.date1904())
 	if frac.Type != ArgNumber {
 		return frac
 	}
@@ -17573,12 +18629,12 @@ func (fn *formulaFuncs) YIELDMAT(argsList *list.List) formulaArg {
 			return newErrorFormulaArg(formulaErrorNUM, formulaErrorNUM)
 		}
 	}
-	dim := yearFrac(issue.Number, maturity.Number, int(basis.Number))
+	dim := yearFrac(issue.Number, maturity.Number, int(basis.Number), fn.date1904())
 	if dim.Type != ArgNumber {
 		return dim
 	}
-	dis := yearFrac(issue.Number, settlement.Number, int(basis.Number))
-	dsm := yearFrac(settlement.Number, maturity.Number, int(basis.Number))
+	dis := yearFrac(issue.Number, settlement.Number, int(basis.Number), fn.date1904())
+	dsm := yearFrac(settlement.Number, maturity.Number, int(basis.Number), fn.date1904())
 	f1 := dim.Number * rate.Number
 	result := 1 + math.Nextafter(f1, f1)
 	result /= pr.Number/100 + dis.Number*rate.Number
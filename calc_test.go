@@ -1410,6 +1410,28 @@ func TestCalcCellValue(t *testing.T) {
 		"=IFS(4>1,5/4,4<-1,-5/4,TRUE,0)":     "1.25",
 		"=IFS(-2>1,5/-2,-2<-1,-5/-2,TRUE,0)": "2.5",
 		"=IFS(0>1,5/0,0<-1,-5/0,TRUE,0)":     "0",
+		"=IFS(TRUE,1,FALSE,UNKNOWN())":       "1",
+		"=1+IFS(FALSE,1,TRUE,2*3)":           "7",
+		"=IFS(A1=1,\"one\",TRUE,1/0)":        "one",
+		"=LET(x,2,IFS(x>1,x*10,TRUE,1/0))":   "20",
+		"=IFS(2,\"two\",TRUE,0)":             "two",
+		"=IFS(\"false\",1,\"TRUE\",2)":       "2",
+		// LET
+		"=LET(x,1+2,x*2)":                              "6",
+		"=LET(x,5,y,x+1,x*y)":                          "30",
+		"=LET(X,2,x+1)":                                "3",
+		"=LET(r,A1:A4,SUM(r))":                         "6",
+		"=LET(r,A1:A4,SUM(r)+1)":                       "7",
+		"=LET(x,A1,y,(x+1)*2,SUM(x,y))":                "5",
+		"=LET(x,1,LET(x,2,x)+x)":                       "3",
+		"=LET(x,\"a\",x&\"b\")":                        "ab",
+		"=LET(x,D2,UPPER(x))":                          "JAN",
+		"=LET(x,TRUE,IF(x,\"yes\",\"no\"))":            "yes",
+		"=LET(x,-3,ABS(x))":                            "3",
+		"=LET(x,SUM(A1:A4),IF(x>5,\"big\",\"small\"))": "big",
+		"=1+LET(x,2,x)":                                "3",
+		"=SUM(LET(x,2,x*3),4)":                         "10",
+		"=_xlfn.LET(_xlpm.x,2,_xlpm.x+1)":              "3",
 		// NOT
 		"=NOT(FALSE())":     "TRUE",
 		"=NOT(\"false\")":   "TRUE",
@@ -1425,6 +1447,10 @@ func TestCalcCellValue(t *testing.T) {
 		"=SWITCH(1,1,\"A\",2,\"B\",3,\"C\",\"N\")": "A",
 		"=SWITCH(3,1,\"A\",2,\"B\",3,\"C\",\"N\")": "C",
 		"=SWITCH(4,1,\"A\",2,\"B\",3,\"C\",\"N\")": "N",
+		"=SWITCH(1,1,\"A\",2,1/0)":                 "A",
+		"=SWITCH(2,1,UNKNOWN(),2,\"B\")":           "B",
+		"=SWITCH(5,1,\"A\",2+3,\"B\",UNKNOWN())":   "B",
+		"=SUM(SWITCH(3,1,UNKNOWN(),A1:A4))":        "6",
 		// TRUE
 		"=TRUE()": "TRUE",
 		// XOR
@@ -1806,7 +1832,10 @@ func TestCalcCellValue(t *testing.T) {
 		"=INDEX(0,0,0)":          "0",
 		"=INDEX(A1,0,0)":         "1",
 		"=INDEX(A1:A1,0,0)":      "1",
-		"=SUM(INDEX(A1:B1,1))":   "5",
+		"=SUM(INDEX(A1:B1,1))":   "1",
+		"=INDEX(A1:B1,2)":        "4",
+		"=INDEX(A1:B2,2,2)":      "5",
+		"=INDEX(D1:F9,9,3)":      "45500",
 		"=SUM(INDEX(A1:B1,1,0))": "5",
 		"=SUM(INDEX(A1:B2,2,0))": "7",
 		"=SUM(INDEX(A1:B4,0,2))": "9",
@@ -3389,8 +3418,18 @@ func TestCalcCellValue(t *testing.T) {
 		// IFNA
 		"=IFNA()": "IFNA requires 2 arguments",
 		// IFS
-		"=IFS()":            "IFS requires at least 2 arguments",
-		"=IFS(FALSE,FALSE)": "#N/A",
+		"=IFS()":                 "IFS requires at least 2 arguments",
+		"=IFS(FALSE,FALSE)":      "#N/A",
+		"=IFS(TRUE,1,TRUE)":      "IFS requires an even number of arguments",
+		"=IFS(NA(),1)":           "#N/A",
+		"=IFS(\"abc\",1,TRUE,2)": "#VALUE!",
+		"=IFS(FALSE,1,\"1\",2)":  "#VALUE!",
+		// LET
+		"=LET(x,1)":      "LET requires at least 3 arguments",
+		"=LET(x,1,y,2)":  "LET requires an odd number of arguments",
+		"=LET(A1,1,A1)":  "#NAME?",
+		"=LET(1,1,1)":    "#NAME?",
+		"=LET(x,NA(),1)": "#N/A",
 		// NOT
 		"=NOT()":      "NOT requires 1 argument",
 		"=NOT(NOT())": "NOT requires 1 argument",
@@ -3402,8 +3441,9 @@ func TestCalcCellValue(t *testing.T) {
 		"=OR()":                                  "OR requires at least 1 argument",
 		"=OR(1" + strings.Repeat(",1", 30) + ")": "OR accepts at most 30 arguments",
 		// SWITCH
-		"=SWITCH()":      "SWITCH requires at least 3 arguments",
-		"=SWITCH(0,1,2)": "#N/A",
+		"=SWITCH()":           "SWITCH requires at least 3 arguments",
+		"=SWITCH(0,1,2)":      "#N/A",
+		"=SWITCH(NA(),1,2,3)": "#N/A",
 		// TRUE
 		"=TRUE(A1)": "TRUE takes no arguments",
 		// XOR
@@ -3725,7 +3765,7 @@ func TestCalcCellValue(t *testing.T) {
 		"=MATCH(0,A1:A1,0,0)":   "MATCH requires 1 or 2 arguments",
 		"=MATCH(0,A1:A1,\"x\")": "MATCH requires numeric match_type argument",
 		"=MATCH(0,A1)":          "MATCH arguments lookup_array should be one-dimensional array",
-		"=MATCH(0,A1:B1)":       "MATCH arguments lookup_array should be one-dimensional array",
+		"=MATCH(0,A1:B2)":       "MATCH arguments lookup_array should be one-dimensional array",
 		// TRANSPOSE
 		"=TRANSPOSE()": "TRANSPOSE requires 1 argument",
 		// HYPERLINK
@@ -3745,15 +3785,18 @@ func TestCalcCellValue(t *testing.T) {
 		"=VLOOKUP(MUNIT(2),MUNIT(3),1)":  "VLOOKUP no result found",
 		"=VLOOKUP(1,G1:H2,1,FALSE)":      "VLOOKUP no result found",
 		// INDEX
-		"=INDEX()":          "INDEX requires 2 or 3 arguments",
-		"=INDEX(A1,2)":      "INDEX row_num out of range",
-		"=INDEX(A1,0,2)":    "INDEX col_num out of range",
-		"=INDEX(A1:A1,2)":   "INDEX row_num out of range",
-		"=INDEX(A1:A1,0,2)": "INDEX col_num out of range",
-		"=INDEX(A1:B2,2,3)": "INDEX col_num out of range",
-		"=INDEX(A1:A2,0,0)": "#VALUE!",
-		"=INDEX(0,\"\")":    "strconv.ParseFloat: parsing \"\": invalid syntax",
-		"=INDEX(0,0,\"\")":  "strconv.ParseFloat: parsing \"\": invalid syntax",
+		"=INDEX()":           "INDEX requires 2 or 3 arguments",
+		"=INDEX(A1,2)":       "INDEX row_num out of range",
+		"=INDEX(A1,0,2)":     "INDEX col_num out of range",
+		"=INDEX(A1:A1,2)":    "INDEX row_num out of range",
+		"=INDEX(A1:A1,0,2)":  "INDEX col_num out of range",
+		"=INDEX(A1:B2,2,3)":  "INDEX col_num out of range",
+		"=INDEX(A1:A2,0,0)":  "#VALUE!",
+		"=INDEX(A1:B1,3)":    "INDEX col_num out of range",
+		"=INDEX(A1:B2,-1)":   "INDEX requires non-negative row_num and col_num arguments",
+		"=INDEX(A1:B2,1,-1)": "INDEX requires non-negative row_num and col_num arguments",
+		"=INDEX(0,\"\")":     "strconv.ParseFloat: parsing \"\": invalid syntax",
+		"=INDEX(0,0,\"\")":   "strconv.ParseFloat: parsing \"\": invalid syntax",
 		// INDIRECT
 		"=INDIRECT()":                     "INDIRECT requires 1 or 2 arguments",
 		"=INDIRECT(\"E\"&1,TRUE,1)":       "INDIRECT requires 1 or 2 arguments",
@@ -4467,6 +4510,148 @@ func TestCalcTRANSPOSE(t *testing.T) {
 	assert.NoError(t, err, formula)
 }
 
+func TestCalcSEQUENCE(t *testing.T) {
+	f := NewFile()
+	calc := map[string]string{
+		"=_xlfn.SEQUENCE(3)":                    "1",
+		"=SUM(SEQUENCE(2,3))":                   "21",
+		"=SUM(SEQUENCE(2,2,10,-1))":             "34",
+		"=INDEX(SEQUENCE(3,2,1,2),3,2)":         "11",
+		"=INDEX(SEQUENCE(1,4,0.5,0.25),1,4)":    "1.25",
+		"=SUM(SEQUENCE(1024,1024,0,0))":         "0",
+		"=INDEX(SEQUENCE(1025,1024),1025,1024)": "1049600",
+		"=INDEX(SEQUENCE(2,16384),2,16384)":     "32768",
+	}
+	for formula, expected := range calc {
+		assert.NoError(t, f.SetCellFormula("Sheet1", "A1", formula))
+		result, err := f.CalcCellValue("Sheet1", "A1")
+		assert.NoError(t, err, formula)
+		assert.Equal(t, expected, result, formula)
+	}
+	calcError := map[string]string{
+		"=SEQUENCE()":          "SEQUENCE requires at least 1 argument",
+		"=SEQUENCE(1,2,3,4,5)": "SEQUENCE allows at most 4 arguments",
+		`=SEQUENCE("X")`:       "strconv.ParseFloat: parsing \"X\": invalid syntax",
+		"=SEQUENCE(0)":         "#CALC!",
+		"=SEQUENCE(1,16385)":   "#VALUE!",
+		"=SEQUENCE(1048577)":   "#VALUE!",
+		"=SEQUENCE(1E+300)":    "#VALUE!",
+		"=SEQUENCE(-1E+300)":   "#CALC!",
+	}
+	for formula, expected := range calcError {
+		assert.NoError(t, f.SetCellFormula("Sheet1", "A1", formula))
+		result, err := f.CalcCellValue("Sheet1", "A1")
+		assert.EqualError(t, err, expected, formula)
+		assert.Equal(t, "", result, formula)
+	}
+}
+
+func TestCalcUNIQUE(t *testing.T) {
+	cellData := [][]interface{}{
+		{"b", 1, "x"},
+		{"A", 2, "y"},
+		{"a", 2, "y"},
+		{"B", 3, "x"},
+		{"c", 1, "x"},
+	}
+	f := prepareCalcData(cellData)
+	calc := map[string]string{
+		"=_xlfn.UNIQUE(A1:A5)":                      "b",
+		"=COUNTA(UNIQUE(A1:A5))":                    "3",
+		"=INDEX(UNIQUE(A1:A5),2,1)":                 "A",
+		"=INDEX(UNIQUE(A1:A5,FALSE,TRUE),1,1)":      "c",
+		"=SUM(UNIQUE(B1:B5))":                       "6",
+		"=COUNTA(UNIQUE(B1:C5))":                    "6",
+		"=INDEX(UNIQUE(B1:C5,FALSE,TRUE),1,2)":      "x",
+		"=COUNTA(UNIQUE(TRANSPOSE(A1:A5),TRUE))":    "3",
+		"=INDEX(UNIQUE(TRANSPOSE(B1:B5),TRUE),1,3)": "3",
+		"=UNIQUE(1)":                                "1",
+	}
+	for formula, expected := range calc {
+		assert.NoError(t, f.SetCellFormula("Sheet1", "E1", formula))
+		result, err := f.CalcCellValue("Sheet1", "E1")
+		assert.NoError(t, err, formula)
+		assert.Equal(t, expected, result, formula)
+	}
+	calcError := map[string]string{
+		"=UNIQUE()":                    "UNIQUE requires at least 1 argument",
+		"=UNIQUE(A1:A5,FALSE,FALSE,1)": "UNIQUE allows at most 3 arguments",
+		`=UNIQUE(A1:A5,"X")`:           "strconv.ParseBool: parsing \"X\": invalid syntax",
+		`=UNIQUE(A1:A5,FALSE,"X")`:     "strconv.ParseBool: parsing \"X\": invalid syntax",
+		"=UNIQUE(C1:C5,FALSE,TRUE)":    "#CALC!",
+	}
+	for formula, expected := range calcError {
+		assert.NoError(t, f.SetCellFormula("Sheet1", "E1", formula))
+		result, err := f.CalcCellValue("Sheet1", "E1")
+		assert.EqualError(t, err, expected, formula)
+		assert.Equal(t, "", result, formula)
+	}
+}
+
+func TestCalcSORTBY(t *testing.T) {
+	cellData := [][]interface{}{
+		{"b", 3, 1, "x"},
+		{"A", 1, 2, nil},
+		{"a", 2, 3, "y"},
+		{"c", 1, 4, 5},
+	}
+	f := prepareCalcData(cellData)
+	calc := map[string]string{
+		"=_xlfn.SORTBY(A1:A4,B1:B4)":                               "A",
+		"=INDEX(SORTBY(A1:A4,B1:B4),2,1)":                          "c",
+		"=INDEX(SORTBY(A1:A4,B1:B4,1,C1:C4,-1),1,1)":               "c",
+		"=INDEX(SORTBY(A1:C4,B1:B4,-1),4,3)":                       "4",
+		"=INDEX(SORTBY(A1:C4,A1:A4),1,3)":                          "2",
+		"=INDEX(SORTBY(A1:C4,D1:D4),1,1)":                          "c",
+		"=INDEX(SORTBY(A1:C4,D1:D4),3,1)":                          "a",
+		"=INDEX(SORTBY(A1:C4,D1:D4),4,1)":                          "A",
+		"=INDEX(SORTBY(A1:C4,D1:D4,-1),2,1)":                       "a",
+		"=INDEX(SORTBY(TRANSPOSE(A1:A4),TRANSPOSE(C1:C4),-1),1,1)": "c",
+	}
+	for formula, expected := range calc {
+		assert.NoError(t, f.SetCellFormula("Sheet1", "F1", formula))
+		result, err := f.CalcCellValue("Sheet1", "F1")
+		assert.NoError(t, err, formula)
+		assert.Equal(t, expected, result, formula)
+	}
+	calcError := map[string]string{
+		"=SORTBY(A1:A4)":                          "SORTBY requires at least 2 arguments",
+		"=SORTBY(A1:A4,B1:B3)":                    "#VALUE!",
+		"=SORTBY(A1:C4,B1:B4,1,TRANSPOSE(A1:C1))": "#VALUE!",
+		`=SORTBY(A1:A4,B1:B4,"X")`:                "strconv.ParseFloat: parsing \"X\": invalid syntax",
+		"=SORTBY(A1:A4,B1:B4,0)":                  "#VALUE!",
+	}
+	for formula, expected := range calcError {
+		assert.NoError(t, f.SetCellFormula("Sheet1", "F1", formula))
+		result, err := f.CalcCellValue("Sheet1", "F1")
+		assert.EqualError(t, err, expected, formula)
+		assert.Equal(t, "", result, formula)
+	}
+	// Test sort by with empty arrays
+	fn := formulaFuncs{}
+	argsList := list.New()
+	argsList.PushBack(newMatrixFormulaArg(nil))
+	argsList.PushBack(newNumberFormulaArg(1))
+	assert.Equal(t, formulaErrorVALUE, fn.SORTBY(argsList).String)
+	argsList.Init()
+	argsList.PushBack(newNumberFormulaArg(1))
+	argsList.PushBack(newMatrixFormulaArg(nil))
+	assert.Equal(t, formulaErrorVALUE, fn.SORTBY(argsList).String)
+	// Test compare values in the sort order: numbers, text, logical values,
+	// errors and blanks
+	values := []formulaArg{
+		newNumberFormulaArg(-1), newStringFormulaArg("2"), newStringFormulaArg("a"),
+		newStringFormulaArg("B"), newBoolFormulaArg(false), newStringFormulaArg("TRUE"),
+		newErrorFormulaArg(formulaErrorNA, formulaErrorNA), newStringFormulaArg(""),
+	}
+	for i := 1; i < len(values); i++ {
+		assert.Equal(t, -1, compareSortByValue(values[i-1], values[i]))
+		assert.Equal(t, 1, compareSortByValue(values[i], values[i-1]))
+	}
+	assert.Equal(t, 0, compareSortByValue(newStringFormulaArg("a"), newStringFormulaArg("A")))
+	assert.Equal(t, 0, compareSortByValue(newEmptyFormulaArg(), newStringFormulaArg("")))
+}
+
 func TestCalcVLOOKUP(t *testing.T) {
 	cellData := [][]interface{}{
 		{nil, nil, nil, nil, nil, nil},
@@ -5245,6 +5430,17 @@ func TestCalcMATCH(t *testing.T) {
 		"=MATCH(8,C1:C6,1)":        "3",
 		"=MATCH(6,B1:B6,-1)":       "1",
 		"=MATCH(10,D1:D6,-1)":      "3",
+		"=MATCH(9,C1:C6,2)":        "3",
+		"=MATCH(12,D1:D6,-2)":      "1",
+		"=MATCH(20,C1:C6)":         "6",
+		"=MATCH(\"10\",C1:C6,0)":   "4",
+		"=MATCH(\"B*\",A1:A6,0)":   "4",
+		"=MATCH(\"dddd\",A1:A6)":   "4",
+		"=MATCH(7,A1:D1,0)":        "2",
+		"=MATCH(16,B1:D1)":         "3",
+		"=MATCH(5,B6:D6,-1)":       "2",
+		"=MATCH(C2,C1:C6,0)":       "2",
+		"=MATCH(5,C1:C6,-1)":       "6",
 	}
 	for formula, expected := range formulaList {
 		assert.NoError(t, f.SetCellFormula("Sheet1", "E1", formula))
@@ -5253,8 +5449,11 @@ func TestCalcMATCH(t *testing.T) {
 		assert.Equal(t, expected, result, formula)
 	}
 	calcError := map[string]string{
-		"=MATCH(3,C1:C6,1)":  "#N/A",
-		"=MATCH(5,C1:C6,-1)": "#N/A",
+		"=MATCH(3,C1:C6,1)":   "#N/A",
+		"=MATCH(20,D1:D6,-1)": "#N/A",
+		"=MATCH(5,A1:A6,0)":   "#N/A",
+		"=MATCH(\"a\",B1:B6)": "#N/A",
+		"=MATCH(NA(),A1:A6)":  "#N/A",
 	}
 	for formula, expected := range calcError {
 		assert.NoError(t, f.SetCellFormula("Sheet1", "E1", formula))
@@ -5262,7 +5461,39 @@ func TestCalcMATCH(t *testing.T) {
 		assert.EqualError(t, err, expected, formula)
 		assert.Equal(t, "", result, formula)
 	}
-	assert.Equal(t, newErrorFormulaArg(formulaErrorNA, formulaErrorNA), calcMatch(2, nil, []formulaArg{}))
+	assert.Equal(t, newErrorFormulaArg(formulaErrorNA, formulaErrorNA), calcMatch(1, newNumberFormulaArg(1), []formulaArg{}))
+	assert.Equal(t, newErrorFormulaArg(formulaErrorNA, formulaErrorNA), calcMatchLinear(2, nil, []formulaArg{}))
+	// Test binary search with the incomparable values in the lookup array
+	assert.Equal(t, newNumberFormulaArg(4), calcMatch(1, newNumberFormulaArg(5), []formulaArg{
+		newNumberFormulaArg(1), newStringFormulaArg("a"), newStringFormulaArg(""), newNumberFormulaArg(4), newNumberFormulaArg(9),
+	}))
+	assert.Equal(t, newNumberFormulaArg(1), calcMatch(1, newNumberFormulaArg(5), []formulaArg{
+		newNumberFormulaArg(1), newStringFormulaArg("a"), newStringFormulaArg(""), newStringFormulaArg("b"), newNumberFormulaArg(9),
+	}))
+	// Test match the logical values
+	for cell, value := range map[string]interface{}{"F1": 1, "F2": false, "F3": true, "F4": "TRUE", "G1": true} {
+		assert.NoError(t, f.SetCellValue("Sheet1", cell, value))
+	}
+	for formula, expected := range map[string]string{
+		"=MATCH(TRUE,F1:F4,0)":   "3",
+		"=MATCH(FALSE,F1:F4,0)":  "2",
+		"=MATCH(1,F1:F4,0)":      "1",
+		"=MATCH(G1,F1:F4,0)":     "3",
+		"=MATCH(TRUE,F2:F3,1)":   "2",
+		"=MATCH(FALSE,F2:F3,1)":  "1",
+		"=MATCH(FALSE,G1:G1,-1)": "1",
+	} {
+		assert.NoError(t, f.SetCellFormula("Sheet1", "E1", formula))
+		result, err := f.CalcCellValue("Sheet1", "E1")
+		assert.NoError(t, err, formula)
+		assert.Equal(t, expected, result, formula)
+	}
+	for _, formula := range []string{"=MATCH(0,F2:F3,0)", "=MATCH(TRUE,F1:F1,0)", "=MATCH(TRUE,B1:B6,1)"} {
+		assert.NoError(t, f.SetCellFormula("Sheet1", "E1", formula))
+		result, err := f.CalcCellValue("Sheet1", "E1")
+		assert.EqualError(t, err, "#N/A", formula)
+		assert.Equal(t, "", result, formula)
+	}
 }
 
 func TestCalcISFORMULA(t *testing.T) {
@@ -5650,6 +5881,47 @@ func TestCalcNETWORKDAYSandWORKDAY(t *testing.T) {
 	}
 }
 
+func TestCalcDate1904(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetWorkbookPrOptions(Date1904(true)))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", 42374))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A2", "01/07/2020"))
+	formulaList := map[string]string{
+		"=EDATE(42369,1)":                       "42400",
+		"=EDATE(\"01/31/2020\",1)":              "42428",
+		"=EOMONTH(42369,0)":                     "42399",
+		"=EOMONTH(\"01/15/2020\",1)":            "42428",
+		"=WORKDAY(42369,5)":                     "42376",
+		"=WORKDAY(42369,5,A1)":                  "42377",
+		"=WORKDAY(42369,5,A1:A2)":               "42378",
+		"=WORKDAY(\"01/01/2020\",-1)":           "42368",
+		"=NETWORKDAYS(42369,42376)":             "6",
+		"=NETWORKDAYS(42369,42376,A1)":          "5",
+		"=NETWORKDAYS(42369,42376,A1:A2)":       "4",
+		"=NETWORKDAYS(\"01/01/2020\",42376,A1)": "5",
+		"=DAYS360(42369,42399)":                 "30",
+		"=DATE(2020,1,1)":                       "2020-01-01 00:00:00 +0000 UTC",
+		"=DATEDIF(42369,42400,\"md\")":          "0",
+		"=DATEDIF(42369,42400,\"yd\")":          "31",
+		"=DATEVALUE(\"01/01/2020\")":            "42369",
+		"=VALUE(\"01/01/2020\")":                "42369",
+		"=YEAR(42369)":                          "2020",
+		"=MONTH(42369)":                         "1",
+		"=DAY(42369)":                           "1",
+		"=DAY(1)":                               "2",
+		"=WEEKDAY(42369)":                       "4",
+		"=WEEKNUM(42375)":                       "2",
+		"=ISOWEEKNUM(42375)":                    "2",
+		"=YEARFRAC(42369,42735)":                "1",
+	}
+	for formula, expected := range formulaList {
+		assert.NoError(t, f.SetCellFormula("Sheet1", "C1", formula))
+		result, err := f.CalcCellValue("Sheet1", "C1")
+		assert.NoError(t, err, formula)
+		assert.Equal(t, expected, result, formula)
+	}
+}
+
 func TestCalcZTEST(t *testing.T) {
 	f := NewFile()
 	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]int{4, 5, 2, 5, 8, 9, 3, 2, 3, 8, 9, 5}))
@@ -5740,3 +6012,156 @@ func TestNestedFunctionsWithOperators(t *testing.T) {
 		assert.Equal(t, expected, result, formula)
 	}
 }
+
+func TestGetCellPrecedents(t *testing.T) {
+	f := NewFile()
+	f.NewSheet("Sheet 2")
+	f.NewSheet("Sheet3")
+	f.NewSheet("a,b")
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "Amount", RefersTo: "Sheet3!$B$1:$B$5"}))
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "Refs", RefersTo: "'a,b'!$A$1,Sheet3!$C$1"}))
+	for cell, formula := range map[string]string{
+		"C6": "=SUM(Refs)",
+		"C1": "=SUM($A$1:B2)+'Sheet 2'!A1+A1",
+		"C2": "=SUM(Amount)*SUM(D:D)+SUM(2:3)",
+		"C3": "=SUM(Sheet1:Sheet3!E1)+[1]Sheet1!A1+\"A1\"",
+		"C4": "=1+1",
+	} {
+		assert.NoError(t, f.SetCellFormula("Sheet1", cell, formula))
+	}
+	for cell, expected := range map[string][]string{
+		"C1": {"Sheet1!A1:B2", "'Sheet 2'!A1", "Sheet1!A1"},
+		"C2": {"Sheet3!B1:B5", "Sheet1!D1:D1048576", "Sheet1!A2:XFD3"},
+		"C3": {"Sheet1!E1", "'Sheet 2'!E1", "Sheet3!E1"},
+		"C6": {"'a,b'!A1", "Sheet3!C1"},
+		"C4": nil,
+		"C5": nil,
+	} {
+		precedents, err := f.GetCellPrecedents("Sheet1", cell)
+		assert.NoError(t, err, cell)
+		assert.Equal(t, expected, precedents, cell)
+	}
+	// Test get cell precedents with not exist worksheet
+	_, err := f.GetCellPrecedents("SheetN", "A1")
+	assert.EqualError(t, err, "sheet SheetN is not exist")
+	// Test get cell precedents with invalid cell reference
+	_, err = f.GetCellPrecedents("Sheet1", "A")
+	assert.EqualError(t, err, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
+}
+
+func TestGetCellDependents(t *testing.T) {
+	f := NewFile()
+	f.NewSheet("Sheet2")
+	assert.NoError(t, f.SetCellFormula("Sheet1", "B1", "=A1*2"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "B2", "=SUM(A:A)"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "B3", "=C1"))
+	assert.NoError(t, f.SetCellFormula("Sheet2", "A1", "=Sheet1!A1+Sheet1!A1"))
+	formulaType, ref := STCellFormulaTypeShared, "C1:C3"
+	assert.NoError(t, f.SetCellFormula("Sheet2", "C1", "=Sheet1!A1", FormulaOpts{Type: &formulaType, Ref: &ref}))
+	dependents, err := f.GetCellDependents("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Sheet1!B1", "Sheet1!B2", "Sheet2!A1", "Sheet2!C1"}, dependents)
+	dependents, err = f.GetCellDependents("Sheet1", "A2")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Sheet1!B2", "Sheet2!C2"}, dependents)
+	dependents, err = f.GetCellDependents("Sheet2", "A1")
+	assert.NoError(t, err)
+	assert.Nil(t, dependents)
+	// Test get cell dependents with chart sheet
+	assert.NoError(t, f.AddChartSheet("Chart1", `{"type":"col","series":[{"name":"Sheet1!$A$1","categories":"Sheet1!$A$2","values":"Sheet1!$B$2"}]}`))
+	dependents, err = f.GetCellDependents("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Len(t, dependents, 4)
+	// Test get cell dependents with unsupported charset worksheet
+	f.Sheet.Delete("xl/worksheets/sheet2.xml")
+	f.Pkg.Store("xl/worksheets/sheet2.xml", MacintoshCyrillicCharset)
+	_, err = f.GetCellDependents("Sheet1", "A1")
+	assert.EqualError(t, err, "xml decode error: XML syntax error on line 1: invalid UTF-8")
+	// Test get cell dependents with not exist worksheet
+	_, err = f.GetCellDependents("SheetN", "A1")
+	assert.EqualError(t, err, "sheet SheetN is not exist")
+	// Test get cell dependents with invalid cell reference
+	_, err = f.GetCellDependents("Sheet1", "A")
+	assert.EqualError(t, err, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
+}
+
+func TestFlattenFormulas(t *testing.T) {
+	f := NewFile()
+	f.NewSheet("Sheet2")
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{1, 2}))
+	// The formula in B2 depends on the formula in the later cell C3
+	assert.NoError(t, f.SetCellFormula("Sheet1", "B2", "C3*2"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "C3", "SUM(A1:B1)"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A4", `"Total: "&B2`))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A5", "A1>B1"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A6", "1/0"))
+	assert.NoError(t, f.SetCellFormula("Sheet2", "A1", "Sheet1!C3+1"))
+	assert.NoError(t, f.FlattenFormulas("Sheet1"))
+	for cell, expected := range map[string]string{"B2": "6", "C3": "3", "A4": "Total: 6", "A5": "FALSE", "A6": "#DIV/0!"} {
+		formula, err := f.GetCellFormula("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Empty(t, formula)
+		val, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, val)
+	}
+	cellTypes := map[string]CellType{"B2": CellTypeUnset, "A4": CellTypeString, "A5": CellTypeBool, "A6": CellTypeError}
+	for cell, expected := range cellTypes {
+		cellType, err := f.GetCellType("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, cellType, cell)
+	}
+	formula, err := f.GetCellFormula("Sheet2", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "Sheet1!C3+1", formula)
+
+	// Test flatten the formulas of all worksheets
+	assert.NoError(t, f.SetCellFormula("Sheet1", "D1", "SUM(1,2)"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "D2", "D1"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "D3", "D1", FormulaOpts{Ref: stringPtr("D3:D4"), Type: stringPtr(STCellFormulaTypeShared)}))
+	assert.NoError(t, f.FlattenFormulas(""))
+	for _, cell := range []string{"D2", "D3", "D4"} {
+		formula, err = f.GetCellFormula("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Empty(t, formula)
+	}
+	val, err := f.GetCellValue("Sheet2", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "4", val)
+	val, err = f.GetCellValue("Sheet1", "D4")
+	assert.NoError(t, err)
+	assert.Equal(t, "3", val)
+
+	// Test flatten formulas with not exist worksheet
+	assert.EqualError(t, f.FlattenFormulas("SheetN"), "sheet SheetN is not exist")
+	// Test flatten formulas with unsupported function
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A7", "UNSUPPORT(A1)"))
+	assert.EqualError(t, f.FlattenFormulas("Sheet1"), "not support UNSUPPORT function")
+	formula, err = f.GetCellFormula("Sheet1", "A7")
+	assert.NoError(t, err)
+	assert.Equal(t, "UNSUPPORT(A1)", formula)
+	// Test flatten formulas with the invalid cell reference
+	f = NewFile()
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A1", "1+1"))
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	ws.SheetData.Row = append(ws.SheetData.Row, xlsxRow{R: 2, C: []xlsxC{{R: "A", F: &xlsxF{Content: "1+2"}}}})
+	assert.EqualError(t, f.FlattenFormulas("Sheet1"), newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
+	formula, err = f.GetCellFormula("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "1+1", formula)
+	// Test flatten the chain of the dependent formulas
+	f = NewFile()
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", 1))
+	for row := 2; row <= 2000; row++ {
+		cell, err := CoordinatesToCellName(1, row)
+		assert.NoError(t, err)
+		prev, err := CoordinatesToCellName(1, row-1)
+		assert.NoError(t, err)
+		assert.NoError(t, f.SetCellFormula("Sheet1", cell, prev+"+1"))
+	}
+	assert.NoError(t, f.FlattenFormulas("Sheet1"))
+	val, err = f.GetCellValue("Sheet1", "A2000")
+	assert.NoError(t, err)
+	assert.Equal(t, "2000", val)
+}
@@ -12,10 +12,14 @@
 package excelize
 
 import (
+	"encoding/json"
 	"encoding/xml"
 	"fmt"
+	"math"
+	"net/url"
 	"os"
 	"reflect"
+	"regexp"
 	"strconv"
 	"strings"
 	"time"
@@ -34,6 +38,16 @@ const (
 	CellTypeString
 )
 
+// ShiftDirection is the type of direction to shift the existing cells when
+// inserting cells.
+type ShiftDirection byte
+
+// Shift direction enumeration for inserting cells.
+const (
+	ShiftCellsRight ShiftDirection = iota
+	ShiftCellsDown
+)
+
 const (
 	// STCellFormulaTypeArray defined the formula is an array formula.
 	STCellFormulaTypeArray = "array"
@@ -60,10 +74,15 @@ var cellTypes = map[string]CellType{
 // worksheet name and axis in spreadsheet file. If it is possible to apply a
 // format to the cell value, it will do so, if not then an error will be
 // returned, along with the raw value of the cell. All cells' values will be
-// the same in a merged range.
+// the same in a merged range. Specify the RawCellValue option to get the
+// stored value without applying the number format, the shared strings will
+// still be resolved for the text cells. For example, get the value
+// "0.333333333" rather than "33%" of the cell with percent format:
+//
+//	val, err := f.GetCellValue("Sheet1", "A1", excelize.Options{RawCellValue: true})
 func (f *File) GetCellValue(sheet, axis string, opts ...Options) (string, error) {
 	return f.getCellStringFunc(sheet, axis, func(x *xlsxWorksheet, c *xlsxC) (string, bool, error) {
-		val, err := c.getValueFrom(f, f.sharedStringsReader(), parseOptions(opts...).RawCellValue)
+		val, err := c.getValueFrom(f, f.sharedStringsReader(), f.getOptions(opts...).RawCellValue)
 		return val, true, err
 	})
 }
@@ -108,6 +127,34 @@ func (f *File) GetCellType(sheet, axis string) (CellType, error) {
 //	time.Time
 //	bool
 //	nil
+//	*url.URL
+//	Hyperlink
+//	json.Number
+//
+// The json.Number type value will be set as a numeric cell with the exact
+// digits of the number, which avoids the precision loss of converting it to
+// float64, the invalid number will be set as a string, and an error will be
+// returned if the number exceeds the range of float64. For example, set the
+// values decoded by the json.Decoder with the UseNumber option:
+//
+//	d := json.NewDecoder(strings.NewReader(`{"id":12345678901234567,"price":0.1}`))
+//	d.UseNumber()
+//	var data map[string]interface{}
+//	if err := d.Decode(&data); err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	err := f.SetCellValue("Sheet1", "A1", data["price"])
+//
+// The *url.URL and Hyperlink type value will be set as the text of the cell
+// with a hyperlink, the default hyperlink style (blue color and underline
+// font) will be applied if the cell has no style. For example, set a
+// hyperlink with the display text:
+//
+//	err := f.SetCellValue("Sheet1", "A1", excelize.Hyperlink{
+//	    Link:    "https://github.com/xuri/excelize",
+//	    Display: "Excelize",
+//	})
 //
 // Note that default date format is m/d/yy h:mm of time.Time type value. You
 // can set numbers format by SetCellStyle() method. If you need to set the
@@ -141,12 +188,65 @@ func (f *File) SetCellValue(sheet, axis string, value interface{}) error {
 		err = f.SetCellBool(sheet, axis, v)
 	case nil:
 		err = f.SetCellDefault(sheet, axis, "")
+	case *url.URL:
+		if v == nil {
+			return f.SetCellDefault(sheet, axis, "")
+		}
+		err = f.setCellHyperlinkValue(sheet, axis, Hyperlink{Link: v.String()})
+	case url.URL:
+		err = f.setCellHyperlinkValue(sheet, axis, Hyperlink{Link: v.String()})
+	case Hyperlink:
+		err = f.setCellHyperlinkValue(sheet, axis, v)
+	case *Hyperlink:
+		if v == nil {
+			return f.SetCellDefault(sheet, axis, "")
+		}
+		err = f.setCellHyperlinkValue(sheet, axis, *v)
+	case json.Number:
+		if !isJSONNumber(v.String()) {
+			return f.SetCellStr(sheet, axis, v.String())
+		}
+		if err = checkJSONNumberRange(v.String()); err != nil {
+			return err
+		}
+		err = f.SetCellDefault(sheet, axis, v.String())
 	default:
 		err = f.SetCellStr(sheet, axis, fmt.Sprint(value))
 	}
 	return err
 }
 
+// setCellHyperlinkValue provides a function to set the text and hyperlink of
+// a cell by given worksheet name, cell coordinates and hyperlink value, and
+// apply the default hyperlink style if the cell has no style.
+func (f *File) setCellHyperlinkValue(sheet, axis string, link Hyperlink) error {
+	linkType, display := link.LinkType, link.Display
+	if linkType == "" {
+		linkType = "External"
+	}
+	if display == "" {
+		display = link.Link
+	}
+	opts := HyperlinkOpts{Display: &display}
+	if link.Tooltip != "" {
+		opts.Tooltip = &link.Tooltip
+	}
+	if err := f.SetCellHyperLink(sheet, axis, link.Link, linkType, opts); err != nil {
+		return err
+	}
+	if err := f.SetCellStr(sheet, axis, display); err != nil {
+		return err
+	}
+	styleID, err := f.GetCellStyle(sheet, axis)
+	if err != nil || styleID != 0 {
+		return err
+	}
+	if styleID, err = f.NewStyle(&Style{Font: &Font{Color: "#0563C1", Underline: "single"}}); err != nil {
+		return err
+	}
+	return f.SetCellStyle(sheet, axis, axis, styleID)
+}
+
 // String extracts characters from a string item.
 func (x xlsxSI) String() string {
 	if len(x.R) > 0 {
@@ -164,9 +264,9 @@ func (x xlsxSI) String() string {
 	return ""
 }
 
-// hasValue determine if cell non-blank value.
+// hasValue determine if cell non-blank value or metadata.
 func (c *xlsxC) hasValue() bool {
-	return c.S != 0 || c.V != "" || c.F != nil || c.T != ""
+	return c.S != 0 || c.V != "" || c.F != nil || c.T != "" || c.Cm != nil || c.Vm != nil
 }
 
 // removeFormula delete formula for the cell.
@@ -229,12 +329,8 @@ func (f *File) setCellTimeFunc(sheet, axis string, value time.Time) error {
 	ws.Lock()
 	cellData.S = f.prepareCellStyle(ws, col, row, cellData.S)
 	ws.Unlock()
-	date1904, wb := false, f.workbookReader()
-	if wb != nil && wb.WorkbookPr != nil {
-		date1904 = wb.WorkbookPr.Date1904
-	}
 	var isNum bool
-	cellData.T, cellData.V, isNum, err = setCellTime(value, date1904)
+	cellData.T, cellData.V, isNum, err = setCellTime(value, f.date1904())
 	if err != nil {
 		return err
 	}
@@ -481,6 +577,26 @@ func (f *File) SetCellDefault(sheet, axis, value string) error {
 	return err
 }
 
+// jsonNumberExp defined the regular expression of the number literal in the
+// JSON format.
+var jsonNumberExp = regexp.MustCompile(`^-?(0|[1-9]\d*)(\.\d+)?([eE][+-]?\d+)?$`)
+
+// isJSONNumber checks if the given string is a valid number literal in the
+// JSON format.
+func isJSONNumber(s string) bool {
+	return jsonNumberExp.MatchString(s)
+}
+
+// checkJSONNumberRange checks if the given number literal in the JSON format
+// overflows the range of the double-precision floating-point number, which
+// can't be stored as a numeric cell.
+func checkJSONNumberRange(s string) error {
+	if num, _ := strconv.ParseFloat(s, 64); math.IsInf(num, 0) {
+		return ErrJSONNumberRange
+	}
+	return nil
+}
+
 // setCellDefault prepares cell type and string type cell value by a given
 // string.
 func setCellDefault(value string) (t string, v string) {
@@ -521,8 +637,9 @@ func (f *File) GetCellFormulaMulti(sheet string, axis []string) (map[string]stri
 
 // FormulaOpts can be passed to SetCellFormula to use other formula types.
 type FormulaOpts struct {
-	Type *string // Formula type
-	Ref  *string // Shared formula ref
+	Type        *string     // Formula type
+	Ref         *string     // Shared formula ref
+	CachedValue interface{} // Cached result of the formula
 }
 
 // SetCellFormula provides a function to set formula on the cell is taken
@@ -601,6 +718,14 @@ type FormulaOpts struct {
 //	        fmt.Println(err)
 //	    }
 //	}
+//
+// Example 8, set formula "=A1*B1" for the cell "C1" on "Sheet1" with the
+// cached result 6, which will be returned by GetCellValue and used by the
+// applications that don't recalculate formulas when opening the workbook.
+// The cached result could be a number, string or boolean value:
+//
+//	err := f.SetCellFormula("Sheet1", "C1", "=A1*B1",
+//	    excelize.FormulaOpts{CachedValue: 6})
 func (f *File) SetCellFormula(sheet, axis, formula string, opts ...FormulaOpts) error {
 	ws, err := f.workSheetReader(sheet)
 	if err != nil {
@@ -637,11 +762,39 @@ func (f *File) SetCellFormula(sheet, axis, formula string, opts ...FormulaOpts)
 		if o.Ref != nil {
 			cellData.F.Ref = *o.Ref
 		}
+		if o.CachedValue != nil {
+			if cellData.T, cellData.V, err = setCellFormulaCachedValue(o.CachedValue); err != nil {
+				return err
+			}
+		}
 	}
 	cellData.IS = nil
 	return err
 }
 
+// setCellFormulaCachedValue prepares cell type and string type cell value by
+// a given cached result of the formula.
+func setCellFormulaCachedValue(value interface{}) (t string, v string, err error) {
+	switch val := value.(type) {
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		v = fmt.Sprint(val)
+	case float32:
+		t, v = setCellFloat(float64(val), -1, 32)
+	case float64:
+		t, v = setCellFloat(val, -1, 64)
+	case bool:
+		t, v = setCellBool(val)
+	case string:
+		if len(val) > TotalCellChars {
+			return t, v, ErrCellCharsLength
+		}
+		t, v = "str", val
+	default:
+		err = ErrParameterInvalid
+	}
+	return
+}
+
 // setSharedFormula set shared formula for the cells.
 func (ws *xlsxWorksheet) setSharedFormula(ref string) error {
 	coordinates, err := areaRefToCoordinates(ref)
@@ -678,35 +831,106 @@ func (ws *xlsxWorksheet) countSharedFormula() (count int) {
 
 // GetCellHyperLink gets a cell hyperlink based on the given worksheet name and
 // cell coordinates. If the cell has a hyperlink, it will return 'true' and
-// the link address, otherwise it will return 'false' and an empty link
-// address.
+// the resolved link address, otherwise it will return 'false' and an empty
+// link address. Use the GetCellHyperLinkInfo function to get the type,
+// display text and tooltip of the hyperlink.
 //
 // For example, get a hyperlink to a 'H6' cell on a worksheet named 'Sheet1':
 //
 //	link, target, err := f.GetCellHyperLink("Sheet1", "H6")
 func (f *File) GetCellHyperLink(sheet, axis string) (bool, string, error) {
+	link, err := f.GetCellHyperLinkInfo(sheet, axis)
+	if err != nil || link == nil {
+		return false, "", err
+	}
+	return true, link.Link, err
+}
+
+// GetCellHyperLinkInfo provides a function to get the hyperlink of the cell
+// by given worksheet name and cell reference, returns nil if the cell doesn't
+// have a hyperlink. The Link of the returned hyperlink is the resolved
+// target: for the "External" link type, the relationship of the hyperlink
+// will be resolved to the URL or file path, and the location inside the
+// target document will be appended after the "#" sign; for the "Location"
+// link type, the Link is the location in the workbook, such as "Sheet1!A1".
+// A hyperlink which applies to a range of cells will be returned for each
+// cell in the range.
+//
+// For example, get the hyperlink of the cell H6 on Sheet1:
+//
+//	link, err := f.GetCellHyperLinkInfo("Sheet1", "H6")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	if link != nil {
+//	    fmt.Println(link.LinkType, link.Link, link.Display, link.Tooltip)
+//	}
+func (f *File) GetCellHyperLinkInfo(sheet, axis string) (*Hyperlink, error) {
 	// Check for correct cell name
 	if _, _, err := SplitCellName(axis); err != nil {
-		return false, "", err
+		return nil, err
 	}
 	ws, err := f.workSheetReader(sheet)
 	if err != nil {
-		return false, "", err
+		return nil, err
 	}
 	if axis, err = f.mergeCellsParser(ws, axis); err != nil {
-		return false, "", err
+		return nil, err
 	}
-	if ws.Hyperlinks != nil {
-		for _, link := range ws.Hyperlinks.Hyperlink {
-			if link.Ref == axis {
-				if link.RID != "" {
-					return true, f.getSheetRelationshipsTargetByID(sheet, link.RID), err
-				}
-				return true, link.Location, err
+	if ws.Hyperlinks == nil {
+		return nil, err
+	}
+	col, row, err := CellNameToCoordinates(axis)
+	if err != nil {
+		return nil, err
+	}
+	for _, link := range ws.Hyperlinks.Hyperlink {
+		if link.Ref != axis {
+			coordinates, err := areaRefToCoordinates(link.Ref)
+			if err != nil {
+				continue
+			}
+			_ = sortCoordinates(coordinates)
+			if !cellInRef([]int{col, row}, coordinates) {
+				continue
 			}
 		}
+		return f.resolveHyperlink(sheet, link), err
 	}
-	return false, "", err
+	return nil, err
+}
+
+// resolveHyperlink provides a function to resolve the target of the
+// worksheet hyperlink by given worksheet name and hyperlink.
+func (f *File) resolveHyperlink(sheet string, link xlsxHyperlink) *Hyperlink {
+	hyperlink := Hyperlink{
+		LinkType: "Location",
+		Link:     strings.TrimPrefix(link.Location, "#"),
+		Display:  link.Display,
+		Tooltip:  link.Tooltip,
+	}
+	if link.RID != "" {
+		hyperlink.LinkType = "External"
+		hyperlink.Link = f.getSheetRelationshipsTargetByID(sheet, link.RID)
+		if location := strings.TrimPrefix(link.Location, "#"); location != "" {
+			hyperlink.Link += "#" + location
+		}
+	}
+	return &hyperlink
+}
+
+// Hyperlink directly maps the value of a cell with hyperlink, which can be
+// set by the SetCellValue function. Link specifies the URL address or the
+// location of the hyperlink. LinkType specifies the type of the hyperlink,
+// "External" or "Location", the default type is "External". Display specifies
+// the text of the cell, the default value is the link. Tooltip specifies the
+// screen tip of the hyperlink.
+type Hyperlink struct {
+	Link     string
+	LinkType string
+	Display  string
+	Tooltip  string
 }
 
 // HyperlinkOpts can be passed to SetCellHyperlink to set optional hyperlink
@@ -1060,6 +1284,99 @@ func (f *File) SetCellRichText(sheet, cell string, runs []RichTextRun) error {
 	return err
 }
 
+// SetCellPhoneticText provides a function to set the cell value with the
+// phonetic runs (furigana) by given worksheet name, cell reference, text of
+// the cell and phonetic runs. The phonetic runs must be in ascending order,
+// not overlapped and within the range of the text. The phonetic text can be
+// used by the PHONETIC function and the sorting of the spreadsheet
+// application. For example, set the Japanese text with the phonetic hints in
+// the cell A1 on Sheet1:
+//
+//	err := f.SetCellPhoneticText("Sheet1", "A1", "東京都", []excelize.PhoneticRun{
+//	    {Start: 0, End: 2, Text: "トウキョウ"},
+//	    {Start: 2, End: 3, Text: "ト"},
+//	})
+func (f *File) SetCellPhoneticText(sheet, cell, text string, runs []PhoneticRun) error {
+	if len(text) > TotalCellChars {
+		return ErrCellCharsLength
+	}
+	length, end := len([]rune(text)), 0
+	for _, run := range runs {
+		if run.Start < end || run.Start >= run.End || run.End > length || run.Text == "" {
+			return ErrPhoneticRun
+		}
+		end = run.End
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	cellData, col, row, err := f.prepareCell(ws, cell)
+	if err != nil {
+		return err
+	}
+	if err := f.sharedStringsLoader(); err != nil {
+		return err
+	}
+	cellData.S = f.prepareCellStyle(ws, col, row, cellData.S)
+	si := xlsxSI{T: &xlsxT{}, PhoneticPr: &xlsxPhoneticPr{FontID: intPtr(0)}}
+	_, si.T.Val, si.T.Space = setCellStr(text)
+	for _, run := range runs {
+		si.RPh = append(si.RPh, &xlsxPhoneticRun{Sb: uint32(run.Start), Eb: uint32(run.End), T: run.Text})
+	}
+	sst := f.sharedStringsReader()
+	for idx, strItem := range sst.SI {
+		if reflect.DeepEqual(strItem, si) {
+			cellData.T, cellData.V = "s", strconv.Itoa(idx)
+			return err
+		}
+	}
+	sst.SI = append(sst.SI, si)
+	sst.Count++
+	sst.UniqueCount++
+	cellData.T, cellData.V = "s", strconv.Itoa(len(sst.SI)-1)
+	return err
+}
+
+// GetCellPhoneticText provides a function to get the phonetic runs
+// (furigana) of the cell by given worksheet name and cell reference. It
+// returns nil if the cell doesn't have the phonetic runs. For example, get
+// the phonetic runs of the cell A1 on Sheet1:
+//
+//	runs, err := f.GetCellPhoneticText("Sheet1", "A1")
+func (f *File) GetCellPhoneticText(sheet, cell string) (runs []PhoneticRun, err error) {
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return
+	}
+	cellData, _, _, err := f.prepareCell(ws, cell)
+	if err != nil {
+		return
+	}
+	var si *xlsxSI
+	switch cellData.T {
+	case "s":
+		siIdx, err := strconv.Atoi(cellData.V)
+		if err != nil {
+			return runs, nil
+		}
+		sst := f.sharedStringsReader()
+		if len(sst.SI) <= siIdx || siIdx < 0 {
+			return runs, nil
+		}
+		si = &sst.SI[siIdx]
+	case "inlineStr":
+		si = cellData.IS
+	}
+	if si == nil {
+		return
+	}
+	for _, run := range si.RPh {
+		runs = append(runs, PhoneticRun{Start: int(run.Sb), End: int(run.Eb), Text: run.T})
+	}
+	return
+}
+
 // SetSheetRow writes an array to row by given worksheet name, starting
 // coordinate and a pointer to array type 'slice'. For example, writes an
 // array to row 6 start with the cell B6 on Sheet1:
@@ -1092,6 +1409,105 @@ func (f *File) SetSheetRow(sheet, axis string, slice interface{}) error {
 	return err
 }
 
+// SetRowWithStyles writes values and styles to row by given worksheet name,
+// starting coordinate, a slice of values and a slice of style IDs, the style
+// ID of styles[i] will be applied to the cell of values[i]. The cells with
+// the style ID -1, and the cells without the corresponding style ID when the
+// styles slice is shorter than the values, will keep their existing styles.
+// For example, writes a ledger row start with the cell A2 on Sheet1, apply the
+// date style on the cell A2 and the currency style on the cell C2, and keep
+// the existing styles of the cells B2 and D2:
+//
+//	dateStyle, err := f.NewStyle(&excelize.Style{NumFmt: 14})
+//	if err != nil {
+//	    fmt.Println(err)
+//	}
+//	currencyStyle, err := f.NewStyle(&excelize.Style{NumFmt: 164})
+//	if err != nil {
+//	    fmt.Println(err)
+//	}
+//	err = f.SetRowWithStyles("Sheet1", "A2",
+//	    []interface{}{time.Now(), "Office supplies", 12.5, "Paid"},
+//	    []int{dateStyle, -1, currencyStyle})
+func (f *File) SetRowWithStyles(sheet, axis string, values []interface{}, styles []int) error {
+	col, row, err := CellNameToCoordinates(axis)
+	if err != nil {
+		return err
+	}
+	if col+len(values)-1 > MaxColumns {
+		return ErrColumnNumber
+	}
+	if len(styles) > len(values) {
+		styles = styles[:len(values)]
+	}
+	for _, styleID := range styles {
+		if styleID < -1 {
+			return newInvalidStyleID(styleID)
+		}
+	}
+	for i, value := range values {
+		cell, err := CoordinatesToCellName(col+i, row)
+		if err != nil {
+			return err
+		}
+		if err := f.SetCellValue(sheet, cell, value); err != nil {
+			return err
+		}
+	}
+	if len(styles) == 0 {
+		return err
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	prepareSheetXML(ws, col+len(styles)-1, row)
+	ws.Lock()
+	defer ws.Unlock()
+	for i, styleID := range styles {
+		if styleID != -1 {
+			ws.SheetData.Row[row-1].C[col+i-1].S = styleID
+		}
+	}
+	return err
+}
+
+// InsertCells provides a function to insert blank cells in the given range
+// reference of the worksheet, and shift the existing cells right or down,
+// the same as the "Insert Cells" command of Excel. Unlike inserting whole
+// rows or columns, only the cells in the same rows (when shifting right) or
+// in the same columns (when shifting down) with the given range will be
+// moved. The inserted cells inherit the style of the cells above them when
+// shifting down, or on the left of them when shifting right. The references
+// of the formulas, hyperlinks, merged cells, auto filter, conditional
+// formats, data validations, tables and calculation chain in the worksheet
+// pointing to the moved cells will be updated, a range reference will be
+// updated only when all of its rows (when shifting right) or columns (when
+// shifting down) are moved. For example, insert blank cells in the range
+// B2:C3 of Sheet1 and shift the existing cells down:
+//
+//	err := f.InsertCells("Sheet1", "B2:C3", excelize.ShiftCellsDown)
+//
+// Use this method with caution, the references in the other worksheets,
+// defined names, charts, and so on will not be updated. If a merged cell or
+// a table is only partly inside the cells to be shifted, an error will be
+// returned. Inserting cells down inside a table inserts the rows into the
+// table, and the formulas of the calculated columns will be set on them.
+func (f *File) InsertCells(sheet, rangeRef string, shift ShiftDirection) error {
+	coordinates, err := rangeRefToCoordinates(rangeRef)
+	if err != nil {
+		return err
+	}
+	if shift != ShiftCellsRight && shift != ShiftCellsDown {
+		return ErrParameterInvalid
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	return f.insertCells(ws, sheet, coordinates, shift)
+}
+
 // getCellInfo does common preparation for all SetCell* methods.
 func (f *File) prepareCell(ws *xlsxWorksheet, cell string) (*xlsxC, int, int, error) {
 	var err error
@@ -1242,10 +1658,7 @@ func (f *File) formattedValue(s int, v string, raw bool) string {
 	if styleSheet.CellXfs.Xf[s].NumFmtID != nil {
 		numFmtID = *styleSheet.CellXfs.Xf[s].NumFmtID
 	}
-	date1904, wb := false, f.workbookReader()
-	if wb != nil && wb.WorkbookPr != nil {
-		date1904 = wb.WorkbookPr.Date1904
-	}
+	date1904 := f.date1904()
 	if ok := builtInNumFmtFunc[numFmtID]; ok != nil {
 		return ok(v, builtInNumFmt[numFmtID], date1904)
 	}
@@ -1260,6 +1673,33 @@ func (f *File) formattedValue(s int, v string, raw bool) string {
 	return v
 }
 
+// formattedBoolValue provides a function to returns a boolean value after
+// formatted by the number format of the cell style, the text TRUE or FALSE
+// will be returned if the cell doesn't have a number format.
+func (f *File) formattedBoolValue(s int, v bool) string {
+	text := "FALSE"
+	if v {
+		text = "TRUE"
+	}
+	styleSheet := f.stylesReader()
+	if s == 0 || s >= len(styleSheet.CellXfs.Xf) || styleSheet.CellXfs.Xf[s].NumFmtID == nil {
+		return text
+	}
+	numFmtID := *styleSheet.CellXfs.Xf[s].NumFmtID
+	if numFmt, ok := builtInNumFmt[numFmtID]; ok {
+		return formatBool(v, numFmt)
+	}
+	if styleSheet.NumFmts == nil {
+		return text
+	}
+	for _, xlsxFmt := range styleSheet.NumFmts.NumFmt {
+		if xlsxFmt.NumFmtID == numFmtID {
+			return formatBool(v, xlsxFmt.FormatCode)
+		}
+	}
+	return text
+}
+
 // prepareCellStyle provides a function to prepare style index of cell in
 // worksheet by given column index and style index.
 func (f *File) prepareCellStyle(ws *xlsxWorksheet, col, row, style int) int {
@@ -1,7 +1,10 @@
 package excelize
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
+	"net/url"
 	"os"
 	"path/filepath"
 	"reflect"
@@ -173,6 +176,40 @@ func TestSetCellValue(t *testing.T) {
 	assert.Equal(t, "0.50", B2)
 }
 
+func TestSetCellValueJSONNumber(t *testing.T) {
+	f := NewFile()
+	d := json.NewDecoder(strings.NewReader(`[12345678901234567,0.1,-1.5e+300,3]`))
+	d.UseNumber()
+	var values []interface{}
+	assert.NoError(t, d.Decode(&values))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &values))
+	assert.NoError(t, f.SetCellValue("Sheet1", "E1", json.Number("1,000")))
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	for idx, expected := range []xlsxC{
+		{R: "A1", V: "12345678901234567"},
+		{R: "B1", V: "0.1"},
+		{R: "C1", V: "-1.5e+300"},
+		{R: "D1", V: "3"},
+		{R: "E1", T: "s", V: "0"},
+	} {
+		c := ws.(*xlsxWorksheet).SheetData.Row[0].C[idx]
+		assert.Equal(t, expected.T, c.T, expected.R)
+		assert.Equal(t, expected.V, c.V, expected.R)
+	}
+	value, err := f.GetCellValue("Sheet1", "E1")
+	assert.NoError(t, err)
+	assert.Equal(t, "1,000", value)
+	assert.EqualError(t, f.SetCellValue("Sheet1", "A", json.Number("1")), newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
+	// Test set cell value with the JSON number exceeds the range of float64
+	assert.EqualError(t, f.SetCellValue("Sheet1", "F1", json.Number("1e400")), ErrJSONNumberRange.Error())
+	assert.NoError(t, f.SetCellValue("Sheet1", "F1", json.Number("1e-400")))
+	assert.False(t, isJSONNumber("01"))
+	assert.False(t, isJSONNumber("1."))
+	assert.False(t, isJSONNumber("NaN"))
+	assert.True(t, isJSONNumber("-0.5E-3"))
+}
+
 func TestSetCellValues(t *testing.T) {
 	f := NewFile()
 	err := f.SetCellValue("Sheet1", "A1", time.Date(2010, time.December, 31, 0, 0, 0, 0, time.UTC))
@@ -361,6 +398,95 @@ func TestGetCellType(t *testing.T) {
 	assert.EqualError(t, err, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
 }
 
+func TestGetCellValueRaw(t *testing.T) {
+	f := NewFile()
+	style, err := f.NewStyle(&Style{NumFmt: 9})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", 0.333333333))
+	assert.NoError(t, f.SetCellValue("Sheet1", "B1", "text"))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A1", "B1", style))
+	buf, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+
+	check := func(f *File, raw bool, expected []string) {
+		val, err := f.GetCellValue("Sheet1", "A1")
+		assert.NoError(t, err)
+		assert.Equal(t, expected[0], val)
+		val, err = f.GetCellValue("Sheet1", "B1")
+		assert.NoError(t, err)
+		assert.Equal(t, expected[1], val)
+		rows, err := f.GetRows("Sheet1")
+		assert.NoError(t, err)
+		assert.Equal(t, [][]string{expected}, rows)
+		cols, err := f.GetCols("Sheet1")
+		assert.NoError(t, err)
+		assert.Equal(t, [][]string{{expected[0]}, {expected[1]}}, cols)
+		// Test the option given on reading override the option of opening
+		val, err = f.GetCellValue("Sheet1", "A1", Options{RawCellValue: !raw})
+		assert.NoError(t, err)
+		assert.NotEqual(t, expected[0], val)
+	}
+	f, err = OpenReader(bytes.NewReader(buf.Bytes()))
+	assert.NoError(t, err)
+	check(f, false, []string{"33%", "text"})
+	// Test get raw cell value with the option specified on opening spreadsheet
+	f, err = OpenReader(bytes.NewReader(buf.Bytes()), Options{RawCellValue: true})
+	assert.NoError(t, err)
+	check(f, true, []string{"0.333333333", "text"})
+}
+
+func TestSetCellValueHyperlink(t *testing.T) {
+	f := NewFile()
+	link, err := url.Parse("https://github.com/xuri/excelize")
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", link))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A2", *link))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A3", Hyperlink{Link: link.String(), Display: "Excelize", Tooltip: "Excelize on GitHub"}))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A4", &Hyperlink{Link: "Sheet1!A1", LinkType: "Location"}))
+	// Test set hyperlink value on the cell with style
+	style, err := f.NewStyle(&Style{Font: &Font{Bold: true}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A5", "A5", style))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A5", link))
+	for cell, expected := range map[string][]string{
+		"A1": {"https://github.com/xuri/excelize", "https://github.com/xuri/excelize"},
+		"A2": {"https://github.com/xuri/excelize", "https://github.com/xuri/excelize"},
+		"A3": {"Excelize", "https://github.com/xuri/excelize"},
+		"A4": {"Sheet1!A1", "Sheet1!A1"},
+		"A5": {"https://github.com/xuri/excelize", "https://github.com/xuri/excelize"},
+	} {
+		val, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected[0], val)
+		ok, target, err := f.GetCellHyperLink("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, expected[1], target)
+	}
+	linkStyle, err := f.GetCellStyle("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.NotEqual(t, 0, linkStyle)
+	for _, cell := range []string{"A2", "A3", "A4"} {
+		styleID, err := f.GetCellStyle("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, linkStyle, styleID)
+	}
+	styleID, err := f.GetCellStyle("Sheet1", "A5")
+	assert.NoError(t, err)
+	assert.Equal(t, style, styleID)
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "Excelize on GitHub", ws.Hyperlinks.Hyperlink[2].Tooltip)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetCellValueHyperlink.xlsx")))
+	// Test set nil hyperlink value
+	assert.NoError(t, f.SetCellValue("Sheet1", "B1", (*url.URL)(nil)))
+	assert.NoError(t, f.SetCellValue("Sheet1", "B2", (*Hyperlink)(nil)))
+	// Test set hyperlink value with invalid arguments
+	assert.EqualError(t, f.SetCellValue("Sheet1", "A", link), newInvalidCellNameError("A").Error())
+	assert.EqualError(t, f.SetCellValue("SheetN", "A1", link), "sheet SheetN is not exist")
+	assert.EqualError(t, f.SetCellValue("Sheet1", "A1", Hyperlink{Link: link.String(), LinkType: "None"}), `invalid link type "None"`)
+}
+
 func TestGetValueFrom(t *testing.T) {
 	f := NewFile()
 	c := xlsxC{T: "s"}
@@ -494,6 +620,38 @@ func TestSetCellFormula(t *testing.T) {
 	formulaType = STCellFormulaTypeDataTable
 	assert.NoError(t, f.SetCellFormula("Sheet1", "C2", "=SUM(Table1[[A]:[B]])", FormulaOpts{Type: &formulaType}))
 	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetCellFormula6.xlsx")))
+
+	// Test set cell formula with cached value.
+	f = NewFile()
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", "Text"))
+	for _, c := range []struct {
+		cachedValue interface{}
+		t, v        string
+		expected    string
+	}{
+		{6, "", "6", "6"},
+		{uint8(6), "", "6", "6"},
+		{float32(1.5), "", "1.5", "1.5"},
+		{2.25, "", "2.25", "2.25"},
+		{true, "b", "1", "TRUE"},
+		{"Hello", "str", "Hello", "Hello"},
+	} {
+		assert.NoError(t, f.SetCellFormula("Sheet1", "A1", "=B1", FormulaOpts{CachedValue: c.cachedValue}))
+		ws, err := f.workSheetReader("Sheet1")
+		assert.NoError(t, err)
+		assert.Equal(t, c.t, ws.SheetData.Row[0].C[0].T)
+		assert.Equal(t, c.v, ws.SheetData.Row[0].C[0].V)
+		val, err := f.GetCellValue("Sheet1", "A1")
+		assert.NoError(t, err)
+		assert.Equal(t, c.expected, val)
+		formula, err := f.GetCellFormula("Sheet1", "A1")
+		assert.NoError(t, err)
+		assert.Equal(t, "=B1", formula)
+	}
+	// Test set cell formula with invalid cached value.
+	assert.EqualError(t, f.SetCellFormula("Sheet1", "A2", "=B1", FormulaOpts{CachedValue: []int{1}}), ErrParameterInvalid.Error())
+	assert.EqualError(t, f.SetCellFormula("Sheet1", "A2", "=B1", FormulaOpts{CachedValue: strings.Repeat("c", TotalCellChars+1)}), ErrCellCharsLength.Error())
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetCellFormula7.xlsx")))
 }
 
 func TestGetCellRichText(t *testing.T) {
@@ -561,6 +719,66 @@ func TestGetCellRichText(t *testing.T) {
 	assert.EqualError(t, err, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
 }
 
+func TestSetCellPhoneticText(t *testing.T) {
+	f := NewFile()
+	runs := []PhoneticRun{{Start: 0, End: 2, Text: "トウキョウ"}, {Start: 2, End: 3, Text: "ト"}}
+	assert.NoError(t, f.SetCellPhoneticText("Sheet1", "A1", "東京都", runs))
+	assert.NoError(t, f.SetCellPhoneticText("Sheet1", "A2", "東京都", runs))
+	assert.Len(t, f.SharedStrings.SI, 1)
+	assert.NoError(t, f.SetCellPhoneticText("Sheet1", "A3", "大阪", nil))
+	val, err := f.GetCellValue("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "東京都", val)
+	// Test the phonetic runs are preserved on round-trip
+	buf, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+	f, err = OpenReader(buf)
+	assert.NoError(t, err)
+	phoneticRuns, err := f.GetCellPhoneticText("Sheet1", "A2")
+	assert.NoError(t, err)
+	assert.Equal(t, runs, phoneticRuns)
+	phoneticRuns, err = f.GetCellPhoneticText("Sheet1", "A3")
+	assert.NoError(t, err)
+	assert.Nil(t, phoneticRuns)
+	assert.Equal(t, 0, *f.SharedStrings.SI[0].PhoneticPr.FontID)
+	// Test set the value of the cell with the phonetic runs
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", "大阪"))
+	phoneticRuns, err = f.GetCellPhoneticText("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Nil(t, phoneticRuns)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetCellPhoneticText.xlsx")))
+	// Test get the phonetic runs of the inline string and the cell without string
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	ws.SheetData.Row[0].C[0] = xlsxC{R: "A1", T: "inlineStr", IS: &xlsxSI{T: &xlsxT{Val: "東京"}, RPh: []*xlsxPhoneticRun{{Sb: 0, Eb: 2, T: "トウキョウ"}}}}
+	phoneticRuns, err = f.GetCellPhoneticText("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, []PhoneticRun{{Start: 0, End: 2, Text: "トウキョウ"}}, phoneticRuns)
+	for _, cell := range []xlsxC{{R: "A1"}, {R: "A1", T: "s", V: "A"}, {R: "A1", T: "s", V: "10"}} {
+		ws.SheetData.Row[0].C[0] = cell
+		phoneticRuns, err = f.GetCellPhoneticText("Sheet1", "A1")
+		assert.NoError(t, err)
+		assert.Nil(t, phoneticRuns)
+	}
+	// Test set phonetic runs with invalid parameters
+	for _, runs := range [][]PhoneticRun{
+		{{Start: -1, End: 1, Text: "ト"}},
+		{{Start: 1, End: 1, Text: "ト"}},
+		{{Start: 0, End: 4, Text: "ト"}},
+		{{Start: 0, End: 1, Text: ""}},
+		{{Start: 1, End: 2, Text: "キョウ"}, {Start: 0, End: 1, Text: "トウ"}},
+	} {
+		assert.Equal(t, ErrPhoneticRun, f.SetCellPhoneticText("Sheet1", "A1", "東京都", runs))
+	}
+	assert.Equal(t, ErrCellCharsLength, f.SetCellPhoneticText("Sheet1", "A1", strings.Repeat("a", TotalCellChars+1), nil))
+	assert.EqualError(t, f.SetCellPhoneticText("SheetN", "A1", "東京", nil), "sheet SheetN is not exist")
+	assert.EqualError(t, f.SetCellPhoneticText("Sheet1", "A", "東京", nil), newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
+	_, err = f.GetCellPhoneticText("SheetN", "A1")
+	assert.EqualError(t, err, "sheet SheetN is not exist")
+	_, err = f.GetCellPhoneticText("Sheet1", "A")
+	assert.EqualError(t, err, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
+}
+
 func TestSetCellRichText(t *testing.T) {
 	f := NewFile()
 	assert.NoError(t, f.SetRowHeight("Sheet1", 1, 35))
@@ -768,3 +986,112 @@ func TestSharedStringsError(t *testing.T) {
 		return assert.NoError(t, os.Remove(v.(string)))
 	})
 }
+
+func TestInsertCells(t *testing.T) {
+	f := NewFile()
+	for r := 1; r <= 3; r++ {
+		for c := 1; c <= 3; c++ {
+			cell, _ := CoordinatesToCellName(c, r)
+			assert.NoError(t, f.SetCellValue("Sheet1", cell, cell))
+		}
+	}
+	assert.NoError(t, f.SetCellFormula("Sheet1", "E1", `SUM(B2,$B$3,Sheet1!C2,'Sheet1'!B2:C3,Sheet2!B2,"B2",LOG10(B2))`))
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "B3", "https://github.com/xuri/excelize", "External"))
+	assert.NoError(t, f.MergeCell("Sheet1", "C3", "D3"))
+	// Test insert cells and shift cells right
+	assert.NoError(t, f.InsertCells("Sheet1", "B2:C2", ShiftCellsRight))
+	for cell, expected := range map[string]string{"A2": "A2", "B2": "", "C2": "", "D2": "B2", "E2": "C2", "B1": "B1", "B3": "B3"} {
+		val, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, val, cell)
+	}
+	formula, err := f.GetCellFormula("Sheet1", "E1")
+	assert.NoError(t, err)
+	assert.Equal(t, `SUM(D2,$B$3,Sheet1!E2,'Sheet1'!B2:C3,Sheet2!B2,"B2",LOG10(D2))`, formula)
+	// Test insert a single cell and shift cells down
+	assert.NoError(t, f.InsertCells("Sheet1", "B1", ShiftCellsDown))
+	for cell, expected := range map[string]string{"B1": "", "B2": "B1", "B3": "", "B4": "B3", "A2": "A2", "C3": "C3"} {
+		val, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, val, cell)
+	}
+	formula, err = f.GetCellFormula("Sheet1", "E1")
+	assert.NoError(t, err)
+	assert.Equal(t, `SUM(D2,$B$4,Sheet1!E2,'Sheet1'!B2:C3,Sheet2!B2,"B2",LOG10(D2))`, formula)
+	link, target, err := f.GetCellHyperLink("Sheet1", "B4")
+	assert.NoError(t, err)
+	assert.True(t, link)
+	assert.Equal(t, "https://github.com/xuri/excelize", target)
+	mergeCells, err := f.GetMergeCells("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "C3", mergeCells[0].GetStartAxis())
+	assert.Equal(t, "D3", mergeCells[0].GetEndAxis())
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestInsertCells.xlsx")))
+
+	// Test insert cells would change merged cells partially
+	assert.Equal(t, ErrInsertCellsMergeCell, f.InsertCells("Sheet1", "D3", ShiftCellsRight))
+	assert.Equal(t, ErrInsertCellsMergeCell, f.InsertCells("Sheet1", "C1", ShiftCellsDown))
+	// Test insert cells with invalid parameters
+	assert.EqualError(t, f.InsertCells("Sheet1", "A", ShiftCellsRight), newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
+	assert.Equal(t, ErrParameterInvalid, f.InsertCells("Sheet1", "A1", ShiftDirection(2)))
+	assert.EqualError(t, f.InsertCells("SheetN", "A1", ShiftCellsDown), "sheet SheetN is not exist")
+	// Test insert cells exceeds the worksheet limits
+	assert.NoError(t, f.SetCellValue("Sheet1", "XFD1", "XFD1"))
+	assert.Equal(t, ErrColumnNumber, f.InsertCells("Sheet1", "A1", ShiftCellsRight))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1048576", "A1048576"))
+	assert.Equal(t, ErrMaxRows, f.InsertCells("Sheet1", "A1", ShiftCellsDown))
+	// Test insert cells on the worksheet with invalid merged cells reference
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).MergeCells = &xlsxMergeCells{Cells: []*xlsxMergeCell{{Ref: "A1"}}}
+	assert.Equal(t, ErrParameterInvalid, f.InsertCells("Sheet1", "A1", ShiftCellsDown))
+
+	// Test insert cells with the style, auto filter, conditional formats, data validations and tables
+	f = NewFile()
+	for r := 1; r <= 5; r++ {
+		assert.NoError(t, f.SetSheetRow("Sheet1", fmt.Sprintf("A%d", r), &[]interface{}{"A", "B", "C", "D"}))
+	}
+	style, err := f.NewStyle(&Style{Font: &Font{Bold: true}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "F1", "G1", style))
+	assert.NoError(t, f.AddTable("Sheet1", "A1", "B4", `{"table_name":"Table1"}`))
+	assert.NoError(t, f.SetTableColumnFormula("Sheet1", "Table1", "B", "=[A]"))
+	assert.NoError(t, f.AutoFilter("Sheet1", "F1", "G5", ""))
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "F2:G3 H2", `[{"type":"cell","criteria":">","format":0,"value":"G2"}]`))
+	dv := NewDataValidation(true)
+	dv.Sqref = "F3:G4"
+	assert.NoError(t, dv.SetRange("G3", "G4", DataValidationTypeWhole, DataValidationOperatorBetween))
+	assert.NoError(t, f.AddDataValidation("Sheet1", dv))
+	assert.NoError(t, f.InsertCells("Sheet1", "F2:G2", ShiftCellsDown))
+	assert.NoError(t, f.InsertCells("Sheet1", "A3:B3", ShiftCellsDown))
+	for cell, expected := range map[string]int{"F2": style, "G2": style, "A3": 0} {
+		styleID, err := f.GetCellStyle("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, styleID, cell)
+	}
+	tables, err := f.GetTables("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "A1:B5", tables[0].Range)
+	expected, err := f.GetCellFormula("Sheet1", "B2")
+	assert.NoError(t, err)
+	formula, err = f.GetCellFormula("Sheet1", "B3")
+	assert.NoError(t, err)
+	assert.Equal(t, expected, formula)
+	assert.NotEmpty(t, formula)
+	ws, ok = f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	assert.Equal(t, "$F$1:$G$6", ws.(*xlsxWorksheet).AutoFilter.Ref)
+	assert.Equal(t, "F3:G4 H2", ws.(*xlsxWorksheet).ConditionalFormatting[0].SQRef)
+	assert.Equal(t, []string{"G3"}, ws.(*xlsxWorksheet).ConditionalFormatting[0].CfRule[0].Formula)
+	assert.Equal(t, "F4:G5", ws.(*xlsxWorksheet).DataValidations.DataValidation[0].Sqref)
+	assert.Equal(t, "<formula1>G4</formula1>", ws.(*xlsxWorksheet).DataValidations.DataValidation[0].Formula1)
+	assert.Equal(t, "<formula2>G5</formula2>", ws.(*xlsxWorksheet).DataValidations.DataValidation[0].Formula2)
+	// Test insert cells would change the table partially
+	assert.Equal(t, ErrInsertCellsTable, f.InsertCells("Sheet1", "A2", ShiftCellsDown))
+	assert.Equal(t, ErrInsertCellsTable, f.InsertCells("Sheet1", "B2:C2", ShiftCellsRight))
+	assert.Equal(t, ErrInsertCellsTable, f.InsertCells("Sheet1", "A1:A4", ShiftCellsRight))
+	assert.NoError(t, f.InsertCells("Sheet1", "A1:A5", ShiftCellsRight))
+	tables, err = f.GetTables("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "B1:C5", tables[0].Range)
+}
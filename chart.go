@@ -12,9 +12,11 @@
 package excelize
 
 import (
+	"bytes"
 	"encoding/json"
 	"encoding/xml"
 	"fmt"
+	"io"
 	"strconv"
 	"strings"
 )
@@ -646,6 +648,10 @@ func parseFormatChartSet(formatSet string) (*formatChart, error) {
 //	values
 //	line
 //	marker
+//	trendline
+//	error_bars
+//	data_points
+//	explosion
 //
 // name: Set the name for the series. The name is displayed in the chart legend and in the formula bar. The name property is optional and if it isn't supplied it will default to Series 1..n. The name can also be a formula such as Sheet1!$A$1
 //
@@ -670,6 +676,40 @@ func parseFormatChartSet(formatSet string) (*formatChart, error) {
 //	x
 //	auto
 //
+// trendline: This sets the trendline of the area, bar, column, line, scatter and bubble chart series, 3D and stacked charts are not supported. The options that can be set are:
+//
+//	type
+//	name
+//	order
+//	period
+//	display_equation
+//	display_r_squared
+//
+// The enumeration value of field 'type' are 'exponential', 'linear', 'logarithmic', 'moving_average', 'polynomial' and 'power'. The 'order' is the order of the polynomial trendline, the range is 2-6 (default value is 2). The 'period' is the period of the moving average trendline, the minimum value is 2 (default value is 2). Specifies the equation and the R-squared value of the trendline shall be shown on the chart by 'display_equation' and 'display_r_squared'. For example, add a linear trendline with equation shown on the scatter chart series:
+//
+//	"trendline": {"type": "linear", "display_equation": true, "display_r_squared": true}
+//
+// error_bars: This sets the error bars of the area, bar, column, line, scatter and bubble chart series, 3D charts are not supported. The options that can be set are:
+//
+//	type
+//	direction
+//	value
+//	no_end_cap
+//
+// The enumeration value of field 'type' are 'fixed', 'percentage', 'standard_deviation' and 'standard_error'. The 'value' is the fixed value, percentage or number of standard deviations of the error amount, and is ignored for the standard error. The enumeration value of field 'direction' are 'both', 'minus' and 'plus' (default value is 'both'). The error bars of scatter and bubble chart series are drawn in the Y direction. Specifies the error bars shall be drawn without end caps by 'no_end_cap'. For example, add 5 percent error bars on the chart series:
+//
+//	"error_bars": {"type": "percentage", "value": 5}
+//
+// data_points: This sets the fill color of the individual data points of the chart series, which overrides the color of the series. The options that can be set are 'index' and 'color'. The 'index' is the zero-based index of the data point in the series, and the 'color' is the color of the data point in the hex RGB format. The markers of the data points will be colored for the line and scatter chart. For example, highlight the third data point of the column chart series in red:
+//
+//	"data_points": [{"index": 2, "color": "#FF0000"}]
+//
+// The data point of the pie, 3D pie, doughnut, pie of pie and bar of pie chart can be exploded by the 'explosion' option of the data point, which specifies the percentage of the distance to move the data point away from the center of the chart, the range is 1 - 400. For example, explode the first slice of the pie chart by 20 percent:
+//
+//	"data_points": [{"index": 0, "explosion": 20}]
+//
+// explosion: This sets the explosion percentage of all data points of the pie, 3D pie, doughnut, pie of pie and bar of pie chart series, the range is 1 - 400. The explosion of the individual data point can be set by data_points.
+//
 // Set properties of the chart legend. The options that can be set are:
 //
 //	none
@@ -708,6 +748,12 @@ func parseFormatChartSet(formatSet string) (*formatChart, error) {
 //
 // Specifies that each data marker in the series has a different color by vary_colors. The default value is true.
 //
+// Specifies the size of the hole in the doughnut chart by hole_size, as a percentage of the size of the chart. The range is 1 - 90, and the default value is 75.
+//
+// Specifies the angle of the first slice of the pie and doughnut chart by first_slice_angle, in degrees clockwise from the top of the chart. The range is 0 - 360, and the default value is 0. For example, create a doughnut chart with 50 percent hole and the first slice start at 90 degrees:
+//
+//	err := f.AddChart("Sheet1", "E1", `{"type":"doughnut","series":[{"name":"Sheet1!$A$2","categories":"Sheet1!$B$1:$D$1","values":"Sheet1!$B$2:$D$2","explosion":5}],"hole_size":50,"first_slice_angle":90}`)
+//
 // Set chart offset, scale, aspect ratio setting and print settings by format, same as function AddPicture.
 //
 // Set the position of the chart plot area by plotarea. The properties that can be set are:
@@ -740,6 +786,9 @@ func parseFormatChartSet(formatSet string) (*formatChart, error) {
 //	reverse_order
 //	maximum
 //	minimum
+//	logbase
+//	major_unit
+//	minor_unit
 //
 // The properties of y_axis that can be set are:
 //
@@ -747,9 +796,16 @@ func parseFormatChartSet(formatSet string) (*formatChart, error) {
 //	major_grid_lines
 //	minor_grid_lines
 //	major_unit
+//	minor_unit
 //	reverse_order
 //	maximum
 //	minimum
+//	logbase
+//
+// The horizontal axis of the scatter and bubble charts is a value axis, the
+// logbase, major_unit and minor_unit properties of x_axis only work with
+// these charts. When the scatter or bubble chart is combined with the charts
+// which have a category axis, it will be plotted on the secondary axes.
 //
 // none: Disable axes.
 //
@@ -759,6 +815,8 @@ func parseFormatChartSet(formatSet string) (*formatChart, error) {
 //
 // major_unit: Specifies the distance between major ticks. Shall contain a positive floating-point number. The major_unit property is optional. The default value is auto.
 //
+// minor_unit: Specifies the distance between minor ticks. Shall contain a positive floating-point number. The minor_unit property is optional. The default value is auto.
+//
 // tick_label_skip: Specifies how many tick labels to skip between label that is drawn. The tick_label_skip property is optional. The default value is auto.
 //
 // reverse_order: Specifies that the categories or values on reverse order (orientation of the chart). The reverse_order property is optional. The default value is false.
@@ -767,6 +825,8 @@ func parseFormatChartSet(formatSet string) (*formatChart, error) {
 //
 // minimum: Specifies that the fixed minimum, 0 is auto. The minimum property is optional. The default value is auto.
 //
+// logbase: Specifies the base of the logarithmic scale of the value axis, shall be in range 2 - 1000. The logbase property is optional. The default value is auto, the value axis uses the linear scale.
+//
 // Set chart size by dimension property. The dimension property is optional. The default width is 480, and height is 290.
 //
 // combo: Specifies the create a chart that combines two or more chart types
@@ -909,10 +969,165 @@ func (f *File) AddChart(sheet, cell, format string, combo ...string) error {
 	return err
 }
 
+// AddChartFromRange provides the method to add chart in a sheet by given
+// worksheet name, data range and chart format set, the series and categories
+// of the chart will be inferred from the data range like inserting a chart
+// on a selection in Excel. The data range could be a cell range of the
+// worksheet such as A1:D10, or a cell range with the worksheet name such as
+// Sheet2!A1:D10. The format set is the same as the AddChart function except
+// the series, which will be replaced by the inferred series, and the
+// following additional options:
+//
+//	 Options        | Description
+//	----------------+-----------------------------------------------------------
+//	 cell           | The top-left cell of the chart, the default value is the
+//	                | cell two columns to the right of the top-right cell of the
+//	                | data range.
+//	 series_in_rows | Specifies each row of the data range as a series instead
+//	                | of each column, the default value is false.
+//	 header         | Specifies whether the first row (or the first column when
+//	                | the series in rows) of the data range contains the names
+//	                | of the series, the header will be detected automatically
+//	                | if this option is omitted: the first row will be used as
+//	                | the header if it doesn't contain empty or numeric cells
+//	                | except the top-left cell of the data range.
+//
+// The first column (or the first row when the series in rows) of the data
+// range will be used as the categories when the data range contains more
+// than one column (or row). For example, create a clustered column chart at
+// cell F1 with data Sheet1!$A$1:$D$6 and the first row as the series names:
+//
+//	err := f.AddChartFromRange("Sheet1", "A1:D6", `{
+//	    "type": "col",
+//	    "cell": "F1",
+//	    "header": true,
+//	    "title":
+//	    {
+//	        "name": "Fruit Sales"
+//	    }
+//	}`)
+func (f *File) AddChartFromRange(sheet, dataRange, format string) error {
+	options := map[string]interface{}{}
+	if err := json.Unmarshal([]byte(parseFormatSet(format)), &options); err != nil {
+		return err
+	}
+	var rangeOpts struct {
+		Cell         string `json:"cell"`
+		SeriesInRows bool   `json:"series_in_rows"`
+		Header       *bool  `json:"header"`
+	}
+	if err := json.Unmarshal([]byte(parseFormatSet(format)), &rangeOpts); err != nil {
+		return err
+	}
+	dataSheet := sheet
+	if idx := strings.LastIndex(dataRange, "!"); idx != -1 {
+		dataSheet = strings.ReplaceAll(strings.Trim(dataRange[:idx], "'"), "''", "'")
+		dataRange = dataRange[idx+1:]
+	}
+	if !strings.Contains(dataRange, ":") {
+		dataRange += ":" + dataRange
+	}
+	coordinates, err := areaRefToCoordinates(dataRange)
+	if err != nil {
+		return err
+	}
+	_ = sortCoordinates(coordinates)
+	if _, err = f.workSheetReader(dataSheet); err != nil {
+		return err
+	}
+	series, err := f.getChartSeriesFromRange(dataSheet, coordinates, rangeOpts.SeriesInRows, rangeOpts.Header)
+	if err != nil {
+		return err
+	}
+	cell := rangeOpts.Cell
+	if cell == "" {
+		if cell, err = CoordinatesToCellName(coordinates[2]+2, coordinates[1]); err != nil {
+			return err
+		}
+	}
+	for _, key := range []string{"cell", "series_in_rows", "header"} {
+		delete(options, key)
+	}
+	options["series"] = series
+	formatSet, _ := json.Marshal(options)
+	return f.AddChart(sheet, cell, string(formatSet))
+}
+
+// getChartSeriesFromRange provides a function to infer the series of the
+// chart by given worksheet name, coordinates of the data range, series
+// direction and header setting.
+func (f *File) getChartSeriesFromRange(sheet string, coordinates []int, seriesInRows bool, header *bool) ([]map[string]string, error) {
+	// Transpose the coordinates to treat the series in rows as the series in
+	// columns.
+	cellName := func(col, row int) string {
+		if seriesInRows {
+			col, row = row, col
+		}
+		name, _ := CoordinatesToCellName(col, row, true)
+		return name
+	}
+	firstCol, firstRow, lastCol, lastRow := coordinates[0], coordinates[1], coordinates[2], coordinates[3]
+	if seriesInRows {
+		firstCol, firstRow, lastCol, lastRow = firstRow, firstCol, lastRow, lastCol
+	}
+	if header == nil {
+		isHeader := lastRow > firstRow
+		for col := firstCol; col <= lastCol && isHeader; col++ {
+			if col == firstCol && lastCol > firstCol {
+				continue
+			}
+			val, err := f.GetCellValue(sheet, strings.ReplaceAll(cellName(col, firstRow), "$", ""))
+			if err != nil {
+				return nil, err
+			}
+			if isNum, _ := isNumeric(val); val == "" || isNum {
+				isHeader = false
+			}
+		}
+		header = &isHeader
+	}
+	dataRow := firstRow
+	if *header {
+		dataRow++
+	}
+	if dataRow > lastRow {
+		return nil, ErrParameterInvalid
+	}
+	sheetRef := sheet
+	if strings.ContainsAny(sheet, " !\"'#$%&()*+,-/:;<=>?@[]^`{|}~") {
+		sheetRef = "'" + strings.ReplaceAll(sheet, "'", "''") + "'"
+	}
+	var (
+		series     []map[string]string
+		categories string
+		seriesCol  = firstCol
+	)
+	if lastCol > firstCol {
+		categories = sheetRef + "!" + cellName(firstCol, dataRow) + ":" + cellName(firstCol, lastRow)
+		seriesCol++
+	}
+	for col := seriesCol; col <= lastCol; col++ {
+		s := map[string]string{
+			"categories": categories,
+			"values":     sheetRef + "!" + cellName(col, dataRow) + ":" + cellName(col, lastRow),
+		}
+		if *header {
+			s["name"] = sheetRef + "!" + cellName(col, firstRow)
+		}
+		series = append(series, s)
+	}
+	return series, nil
+}
+
 // AddChartSheet provides the method to create a chartsheet by given chart
 // format set (such as offset, scale, aspect ratio setting and print settings)
 // and properties set. In Excel a chartsheet is a worksheet that only contains
-// a chart.
+// a chart, which fills the whole page of the sheet. The format set and the
+// combo charts are the same as the AddChart function. For example, create a
+// chartsheet named "Chart1" with a clustered column chart on the data of
+// Sheet1:
+//
+//	err := f.AddChartSheet("Chart1", `{"type":"col","series":[{"name":"Sheet1!$A$2","categories":"Sheet1!$B$1:$D$1","values":"Sheet1!$B$2:$D$2"}],"title":{"name":"Fruit Column Chart"}}`)
 func (f *File) AddChartSheet(sheet, format string, combo ...string) error {
 	// Check if the worksheet already exists
 	if f.GetSheetIndex(sheet) != -1 {
@@ -1004,6 +1219,175 @@ func (f *File) DeleteChart(sheet, cell string) (err error) {
 	return f.deleteDrawing(col, row, drawingXML, "Chart")
 }
 
+// GetChartSeries provides a function to get the series of the charts by given
+// worksheet name and the cell where the top-left corner of the chart is
+// anchored. It returns the type of the chart, the references of the name,
+// categories and values of each series, which could be used to find the
+// source data of the chart. For example, get the series of the chart
+// inserted at Sheet1!E1:
+//
+//	series, err := f.GetChartSeries("Sheet1", "E1")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	for _, ser := range series {
+//	    fmt.Println(ser.ChartType, ser.Name, ser.Categories, ser.Values)
+//	}
+func (f *File) GetChartSeries(sheet, cell string) ([]ChartSeries, error) {
+	col, row, err := CellNameToCoordinates(cell)
+	if err != nil {
+		return nil, err
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil || ws.Drawing == nil {
+		return nil, err
+	}
+	target := f.getSheetRelationshipsTargetByID(sheet, ws.Drawing.RID)
+	drawingXML := strings.ReplaceAll(target, "..", "xl")
+	drawingRelationships := strings.ReplaceAll(
+		strings.ReplaceAll(target, "../drawings", "xl/drawings/_rels"), ".xml", ".xml.rels")
+	wsDr, _ := f.drawingParser(drawingXML)
+	var series []ChartSeries
+	for _, anchor := range append(wsDr.OneCellAnchor, wsDr.TwoCellAnchor...) {
+		var frame decodeChartGraphicFrame
+		if err = f.xmlNewDecoder(strings.NewReader("<decodeChartGraphicFrame>" + anchor.GraphicFrame + "</decodeChartGraphicFrame>")).
+			Decode(&frame); err != nil && err != io.EOF {
+			return series, err
+		}
+		if anchor.From != nil {
+			frame.From = &decodeFrom{Col: anchor.From.Col, Row: anchor.From.Row}
+		}
+		chart := frame.GraphicFrame.Graphic.GraphicData.Chart
+		if frame.From == nil || frame.From.Col != col-1 || frame.From.Row != row-1 || chart == nil {
+			continue
+		}
+		drawRel := f.getDrawingRelationships(drawingRelationships, chart.RID)
+		if drawRel == nil {
+			continue
+		}
+		chartXML := strings.ReplaceAll(drawRel.Target, "..", "xl")
+		if strings.HasPrefix(drawRel.Target, "/") {
+			chartXML = strings.TrimPrefix(drawRel.Target, "/")
+		}
+		chartSeries, err := f.getChartSeries(chartXML)
+		if err != nil {
+			return series, err
+		}
+		series = append(series, chartSeries...)
+	}
+	return series, nil
+}
+
+// getChartSeries provides a function to get the series of the chart by given
+// path of the chart part.
+func (f *File) getChartSeries(chartXML string) ([]ChartSeries, error) {
+	var (
+		chartSpace decodeChartSpace
+		series     []ChartSeries
+	)
+	if err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(f.readXML(chartXML)))).
+		Decode(&chartSpace); err != nil && err != io.EOF {
+		return series, err
+	}
+	getRef := func(source *decodeChartDataSource) string {
+		if source == nil {
+			return ""
+		}
+		for _, ref := range []*decodeChartRef{source.StrRef, source.NumRef, source.MultiLvlStrRef} {
+			if ref != nil {
+				return ref.F
+			}
+		}
+		return ""
+	}
+	for _, group := range chartSpace.Chart.PlotArea.Charts {
+		chartType := getChartType(&group)
+		for _, ser := range group.Ser {
+			chartSeries := ChartSeries{
+				ChartType:  chartType,
+				Categories: getRef(ser.Cat),
+				Values:     getRef(ser.Val),
+				Sizes:      getRef(ser.BubbleSize),
+			}
+			if ser.XVal != nil {
+				chartSeries.Categories = getRef(ser.XVal)
+			}
+			if ser.YVal != nil {
+				chartSeries.Values = getRef(ser.YVal)
+			}
+			if ser.Tx != nil {
+				if chartSeries.Name = ser.Tx.V; ser.Tx.StrRef != nil {
+					chartSeries.Name = ser.Tx.StrRef.F
+				}
+			}
+			series = append(series, chartSeries)
+		}
+	}
+	return series, nil
+}
+
+// getChartType provides a function to get the chart type by given chart group
+// of the plot area, such as get "colStacked" from the barChart element with
+// the column direction and stacked grouping.
+func getChartType(group *decodeChartGroup) string {
+	getVal := func(attr *attrValString) string {
+		if attr == nil || attr.Val == nil {
+			return ""
+		}
+		return *attr.Val
+	}
+	grouping := getVal(group.Grouping)
+	suffix := map[string]string{"stacked": "Stacked", "percentStacked": "PercentStacked"}[grouping]
+	wireframe := group.Wireframe != nil && group.Wireframe.Val != nil && *group.Wireframe.Val
+	name := strings.TrimSuffix(group.XMLName.Local, "Chart")
+	switch name {
+	case "area", "area3D":
+		return name + suffix
+	case "bar", "bar3D":
+		direction := Bar
+		if getVal(group.BarDir) == "col" {
+			direction = Col
+		}
+		if name == "bar" {
+			return direction + suffix
+		}
+		shape := getVal(group.Shape)
+		if shape == "box" || shape == "" {
+			shape = ""
+		} else {
+			shape = strings.ToUpper(shape[:1]) + shape[1:]
+		}
+		if grouping == "clustered" {
+			suffix = "Clustered"
+		}
+		return direction + "3D" + shape + suffix
+	case "bubble":
+		for _, ser := range group.Ser {
+			if ser.Bubble3D != nil && ser.Bubble3D.Val != nil && *ser.Bubble3D.Val {
+				return Bubble3D
+			}
+		}
+		return Bubble
+	case "ofPie":
+		if getVal(group.OfPieType) == "bar" {
+			return BarOfPieChart
+		}
+		return PieOfPieChart
+	case "surface3D":
+		if wireframe {
+			return WireframeSurface3D
+		}
+		return Surface3D
+	case "surface":
+		if wireframe {
+			return WireframeContour
+		}
+		return Contour
+	}
+	return name
+}
+
 // countCharts provides a function to get chart files count storage in the
 // folder xl/charts.
 func (f *File) countCharts() int {
@@ -1026,3 +1410,41 @@ func (f *File) ptToEMUs(pt float64) int {
 	}
 	return int(12700 * pt)
 }
+
+// adjustChartReferences provides a function to update the worksheet
+// references in the formulas of the chart by given XML content of the chart
+// part and the mapping of the old and new worksheet names, the keys of the
+// mapping should be in lower case. The rest of the content will be kept as
+// it is.
+func adjustChartReferences(content string, sheets map[string]string) (string, error) {
+	elements, err := getXMLElements(content, -1)
+	if err != nil {
+		return content, err
+	}
+	for idx := len(elements) - 1; idx >= 0; idx-- {
+		el := elements[idx]
+		if el.name.Local != "f" {
+			continue
+		}
+		element := content[el.start:el.end]
+		_, _, end, err := getXMLStartElement(element)
+		if err != nil {
+			return content, err
+		}
+		if strings.HasSuffix(element[:end], "/>") {
+			continue
+		}
+		var formula string
+		if err = xml.Unmarshal([]byte(element), &formula); err != nil {
+			return content, err
+		}
+		var b strings.Builder
+		b.WriteString(element[:end])
+		if err = xml.EscapeText(&b, []byte(adjustFormulaReferences(formula, sheets, nil))); err != nil {
+			return content, err
+		}
+		b.WriteString("</" + getXMLRawName(el.name) + ">")
+		content = content[:el.start] + b.String() + content[el.end:]
+	}
+	return content, err
+}
@@ -258,6 +258,59 @@ func TestDeleteChart(t *testing.T) {
 	assert.NoError(t, f.Close())
 }
 
+func TestGetChartSeries(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.AddChart("Sheet1", "E1", `{"type":"colStacked","series":[{"name":"Sheet1!$A$2","categories":"Sheet1!$B$1:$D$1","values":"Sheet1!$B$2:$D$2"},{"name":"Sheet1!$A$3","categories":"Sheet1!$B$1:$D$1","values":"Sheet1!$B$3:$D$3"}]}`))
+	assert.NoError(t, f.AddChart("Sheet1", "E20", `{"type":"scatter","series":[{"name":"Sheet1!$A$2","categories":"Sheet1!$B$1:$D$1","values":"Sheet1!$B$2:$D$2"}]}`, `{"type":"bar3DConeClustered","series":[{"name":"Sheet1!$A$3","categories":"Sheet1!$B$1:$D$1","values":"Sheet1!$B$3:$D$3"}]}`))
+	assert.NoError(t, f.AddChart("Sheet1", "N1", `{"type":"bubble3D","series":[{"name":"Sheet1!$A$2","categories":"Sheet1!$B$1:$D$1","values":"Sheet1!$B$2:$D$2"}]}`))
+	expected := map[string][]ChartSeries{
+		"E1": {
+			{ChartType: ColStacked, Name: "Sheet1!$A$2", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$2:$D$2"},
+			{ChartType: ColStacked, Name: "Sheet1!$A$3", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$3:$D$3"},
+		},
+		"E20": {
+			{ChartType: Bar3DConeClustered, Name: "Sheet1!$A$3", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$3:$D$3"},
+			{ChartType: Scatter, Name: "Sheet1!$A$2", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$2:$D$2"},
+		},
+		"N1": {
+			{ChartType: Bubble3D, Name: "Sheet1!$A$2", Values: "Sheet1!$B$2:$D$2", Sizes: "Sheet1!$B$2:$D$2"},
+		},
+	}
+	check := func(f *File) {
+		for cell, series := range expected {
+			result, err := f.GetChartSeries("Sheet1", cell)
+			assert.NoError(t, err)
+			assert.ElementsMatch(t, series, result, cell)
+		}
+		// Test get chart series on the cell without chart
+		result, err := f.GetChartSeries("Sheet1", "A1")
+		assert.NoError(t, err)
+		assert.Empty(t, result)
+	}
+	check(f)
+	// Test get chart series after reopening the workbook
+	buf, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+	f, err = OpenReader(buf)
+	assert.NoError(t, err)
+	check(f)
+	// Test get chart series with invalid cell reference
+	_, err = f.GetChartSeries("Sheet1", "A")
+	assert.EqualError(t, err, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
+	// Test get chart series on not exists worksheet
+	_, err = f.GetChartSeries("SheetN", "A1")
+	assert.EqualError(t, err, "sheet SheetN is not exist")
+	// Test get chart series on the worksheet without drawing
+	result, err := NewFile().GetChartSeries("Sheet1", "E1")
+	assert.NoError(t, err)
+	assert.Empty(t, result)
+	// Test get chart series with unsupported charset chart part
+	f.Pkg.Store("xl/charts/chart1.xml", MacintoshCyrillicCharset)
+	_, err = f.GetChartSeries("Sheet1", "E1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
 func TestChartWithLogarithmicBase(t *testing.T) {
 	// Create test XLSX file with data
 	f := NewFile()
@@ -374,3 +427,302 @@ func TestChartWithLogarithmicBase(t *testing.T) {
 		}
 	}
 }
+
+func TestAddChartAxisScaling(t *testing.T) {
+	f := NewFile()
+	for idx, row := range [][]interface{}{{"X", "Y"}, {1, 10}, {10, 1000}, {100, 100000}, {1000, 10000000}} {
+		cell, err := CoordinatesToCellName(1, idx+1)
+		assert.NoError(t, err)
+		assert.NoError(t, f.SetSheetRow("Sheet1", cell, &row))
+	}
+	series := `"series":[{"name":"Sheet1!$B$1","categories":"Sheet1!$A$2:$A$5","values":"Sheet1!$B$2:$B$5"}]`
+	assert.NoError(t, f.AddChart("Sheet1", "D1", `{"type":"scatter",`+series+`,`+
+		`"x_axis":{"logbase":10,"minimum":1,"maximum":1000,"major_unit":10,"minor_unit":2,"major_grid_lines":true},`+
+		`"y_axis":{"logbase":100,"minimum":1,"maximum":100000000,"major_unit":100,"minor_unit":10,"minor_grid_lines":true}}`))
+	assert.NoError(t, f.AddChart("Sheet1", "D16", `{"type":"bubble",`+series+`,"x_axis":{"logbase":1,"major_unit":-1,"minor_unit":-1},"y_axis":{"major_unit":-1}}`))
+	assert.NoError(t, f.AddChart("Sheet1", "D31", `{"type":"line",`+series+`,"x_axis":{"logbase":10,"major_unit":10},"y_axis":{"minor_unit":5}}`))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddChartAxisScaling.xlsx")))
+
+	chartSpaces := make([]xlsxChartSpace, 3)
+	for i := range chartSpaces {
+		assert.NoError(t, xml.Unmarshal(f.readXML(fmt.Sprintf("xl/charts/chart%d.xml", i+1)), &chartSpaces[i]))
+	}
+	// Test the value axes of the scatter chart
+	plotArea := chartSpaces[0].Chart.PlotArea
+	assert.Nil(t, plotArea.CatAx)
+	assert.Len(t, plotArea.ValAx, 2)
+	for i, expected := range []struct {
+		axID, crossAx                           int
+		logBase, min, max, majorUnit, minorUnit float64
+	}{
+		{754001153, 753999904, 10, 1, 1000, 10, 2},
+		{753999904, 754001153, 100, 1, 100000000, 100, 10},
+	} {
+		ax := plotArea.ValAx[i]
+		assert.Equal(t, expected.axID, *ax.AxID.Val)
+		assert.Equal(t, expected.crossAx, *ax.CrossAx.Val)
+		assert.Equal(t, expected.logBase, *ax.Scaling.LogBase.Val)
+		assert.Equal(t, expected.min, *ax.Scaling.Min.Val)
+		assert.Equal(t, expected.max, *ax.Scaling.Max.Val)
+		assert.Equal(t, expected.majorUnit, *ax.MajorUnit.Val)
+		assert.Equal(t, expected.minorUnit, *ax.MinorUnit.Val)
+	}
+	assert.NotNil(t, plotArea.ValAx[0].MajorGridlines)
+	assert.NotNil(t, plotArea.ValAx[1].MinorGridlines)
+	assert.Equal(t, "b", *plotArea.ValAx[0].AxPos.Val)
+	// Test the value axes of the bubble chart with the invalid units
+	plotArea = chartSpaces[1].Chart.PlotArea
+	assert.Nil(t, plotArea.CatAx)
+	assert.Len(t, plotArea.ValAx, 2)
+	assert.Nil(t, plotArea.ValAx[0].Scaling.LogBase)
+	assert.Nil(t, plotArea.ValAx[0].MajorUnit)
+	assert.Nil(t, plotArea.ValAx[0].MinorUnit)
+	assert.Nil(t, plotArea.ValAx[1].MajorUnit)
+	// Test the category axis of the line chart ignores the scaling options
+	plotArea = chartSpaces[2].Chart.PlotArea
+	assert.Len(t, plotArea.CatAx, 1)
+	assert.Nil(t, plotArea.CatAx[0].Scaling.LogBase)
+	assert.Nil(t, plotArea.CatAx[0].MajorUnit)
+	assert.Equal(t, 5.0, *plotArea.ValAx[0].MinorUnit.Val)
+}
+
+func TestAddChartComboAxisID(t *testing.T) {
+	f := NewFile()
+	for idx, row := range [][]interface{}{{"X", "Y"}, {1, 2}, {2, 4}, {3, 5}, {4, 9}} {
+		cell, err := CoordinatesToCellName(1, idx+1)
+		assert.NoError(t, err)
+		assert.NoError(t, f.SetSheetRow("Sheet1", cell, &row))
+	}
+	series := `"series":[{"name":"Sheet1!$B$1","categories":"Sheet1!$A$2:$A$5","values":"Sheet1!$B$2:$B$5"}]`
+	for i, c := range []struct {
+		types [2]string
+		axes  int
+	}{
+		{[2]string{"col", "scatter"}, 4},
+		{[2]string{"scatter", "col"}, 4},
+		{[2]string{"bubble", "line"}, 4},
+		{[2]string{"line", "bubble"}, 4},
+		{[2]string{"scatter", "bubble"}, 2},
+		{[2]string{"col", "line"}, 2},
+	} {
+		types := c.types
+		cell, err := CoordinatesToCellName(4, i*15+1)
+		assert.NoError(t, err)
+		assert.NoError(t, f.AddChart("Sheet1", cell,
+			`{"type":"`+types[0]+`",`+series+`,"x_axis":{"major_unit":1},"y_axis":{"logbase":10,"minor_unit":1}}`,
+			`{"type":"`+types[1]+`",`+series+`,"x_axis":{"logbase":10}}`))
+		var chartSpace xlsxChartSpace
+		assert.NoError(t, xml.Unmarshal(f.readXML(fmt.Sprintf("xl/charts/chart%d.xml", i+1)), &chartSpace))
+		plotArea := chartSpace.Chart.PlotArea
+		// Test the axis ID of each axis is unique and the axes cross each other
+		axes := map[int]int{}
+		for _, ax := range append(plotArea.CatAx, plotArea.ValAx...) {
+			_, ok := axes[*ax.AxID.Val]
+			assert.False(t, ok, types)
+			axes[*ax.AxID.Val] = *ax.CrossAx.Val
+		}
+		assert.Len(t, axes, c.axes, types)
+		for axID, crossAx := range axes {
+			assert.Equal(t, axID, axes[crossAx], types)
+		}
+		// Test each chart type references a pair of the existing axes which cross each other
+		for _, charts := range []*cCharts{plotArea.BarChart, plotArea.LineChart, plotArea.ScatterChart, plotArea.BubbleChart} {
+			if charts == nil {
+				continue
+			}
+			assert.Len(t, charts.AxID, 2, types)
+			assert.Equal(t, *charts.AxID[1].Val, axes[*charts.AxID[0].Val], types)
+		}
+	}
+	// Test the scatter chart combined with the column chart is plotted on the secondary axes
+	var chartSpace xlsxChartSpace
+	assert.NoError(t, xml.Unmarshal(f.readXML("xl/charts/chart1.xml"), &chartSpace))
+	plotArea := chartSpace.Chart.PlotArea
+	assert.Equal(t, []int{754001153, 753999905}, []int{*plotArea.ScatterChart.AxID[0].Val, *plotArea.ScatterChart.AxID[1].Val})
+	assert.Len(t, plotArea.ValAx, 3)
+	assert.Equal(t, "t", *plotArea.ValAx[1].AxPos.Val)
+	assert.Equal(t, 10.0, *plotArea.ValAx[1].Scaling.LogBase.Val)
+	assert.Equal(t, "r", *plotArea.ValAx[2].AxPos.Val)
+	assert.Equal(t, "max", *plotArea.ValAx[2].Crosses.Val)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddChartComboAxisID.xlsx")))
+}
+
+func TestAddChartTrendlineErrorBars(t *testing.T) {
+	f := NewFile()
+	for idx, row := range [][]interface{}{{"X", "Y"}, {1, 2}, {2, 4}, {3, 5}, {4, 9}} {
+		cell, err := CoordinatesToCellName(1, idx+1)
+		assert.NoError(t, err)
+		assert.NoError(t, f.SetSheetRow("Sheet1", cell, &row))
+	}
+	assert.NoError(t, f.AddChart("Sheet1", "D1", `{"type":"scatter","series":[{"name":"Sheet1!$B$1","categories":"Sheet1!$A$2:$A$5","values":"Sheet1!$B$2:$B$5","trendline":{"type":"linear","display_equation":true,"display_r_squared":true},"error_bars":{"type":"fixed","value":0.5}}],"title":{"name":"Scatter Chart with Trendline"}}`))
+	assert.NoError(t, f.AddChart("Sheet1", "D16", `{"type":"col","series":[{"name":"Sheet1!$B$1","categories":"Sheet1!$A$2:$A$5","values":"Sheet1!$B$2:$B$5","trendline":{"type":"polynomial","order":3},"error_bars":{"type":"percentage","direction":"plus","value":5}}],"title":{"name":"Column Chart with Error Bars"}}`))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddChartTrendlineErrorBars.xlsx")))
+
+	series := formatChartSeries{
+		Trendline: &formatChartTrendline{Type: "moving_average"},
+		ErrorBars: &formatChartErrorBars{Type: "standard_error"},
+	}
+	trendline := f.drawChartSeriesTrendline(series, &formatChart{Type: Line})
+	assert.Len(t, trendline, 1)
+	assert.Equal(t, "movingAvg", *trendline[0].TrendlineType.Val)
+	assert.Equal(t, 2, *trendline[0].Period.Val)
+	errBars := f.drawChartSeriesErrBars(series, &formatChart{Type: Line})
+	assert.Equal(t, "stdErr", *errBars.ErrValType.Val)
+	assert.Equal(t, "both", *errBars.ErrBarType.Val)
+	assert.Nil(t, errBars.ErrDir)
+	assert.Nil(t, errBars.Val)
+	// Test trendline and error bars on unsupported chart type
+	assert.Nil(t, f.drawChartSeriesTrendline(series, &formatChart{Type: Pie}))
+	assert.Nil(t, f.drawChartSeriesErrBars(series, &formatChart{Type: Pie}))
+	// Test trendline and error bars with unknown type
+	series.Trendline.Type, series.ErrorBars.Type = "unknown", "unknown"
+	assert.Nil(t, f.drawChartSeriesTrendline(series, &formatChart{Type: Line}))
+	assert.Nil(t, f.drawChartSeriesErrBars(series, &formatChart{Type: Line}))
+	// Test error bars with unknown direction
+	series.ErrorBars.Type, series.ErrorBars.Direction = "fixed", "unknown"
+	assert.Nil(t, f.drawChartSeriesErrBars(series, &formatChart{Type: Line}))
+	assert.NoError(t, f.Close())
+}
+
+func TestAddChartDataPoints(t *testing.T) {
+	f := NewFile()
+	for idx, row := range [][]interface{}{{"Month", "Sales"}, {"Jan", 2}, {"Feb", 9}, {"Mar", 4}} {
+		cell, err := CoordinatesToCellName(1, idx+1)
+		assert.NoError(t, err)
+		assert.NoError(t, f.SetSheetRow("Sheet1", cell, &row))
+	}
+	for idx, chartType := range []string{Col, Line, Pie} {
+		cell, err := CoordinatesToCellName(4, idx*16+1)
+		assert.NoError(t, err)
+		assert.NoError(t, f.AddChart("Sheet1", cell, fmt.Sprintf(`{"type":"%s","series":[{"name":"Sheet1!$B$1","categories":"Sheet1!$A$2:$A$4","values":"Sheet1!$B$2:$B$4","data_points":[{"index":1,"color":"#FF0000"},{"index":0,"color":"00ff00"}]}]}`, chartType)))
+	}
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddChartDataPoints.xlsx")))
+
+	formatSet := &formatChart{Type: Col, Series: []formatChartSeries{{DataPoints: []formatChartDataPoint{
+		{Index: 2, Color: "#ff0000"}, {Index: 0, Color: "0000FF"}, {Index: -1, Color: "FF0000"}, {Index: 1, Color: "red"},
+	}}}}
+	dPt := f.drawChartSeriesDPt(0, formatSet)
+	assert.Len(t, dPt, 2)
+	assert.Equal(t, 0, *dPt[0].IDx.Val)
+	assert.Equal(t, "0000FF", *dPt[0].SpPr.SolidFill.SrgbClr.Val)
+	assert.Equal(t, 2, *dPt[1].IDx.Val)
+	assert.Equal(t, "FF0000", *dPt[1].SpPr.SolidFill.SrgbClr.Val)
+	// Test data points of the line chart series are drawn on the markers
+	formatSet.Type = Line
+	dPt = f.drawChartSeriesDPt(0, formatSet)
+	assert.Nil(t, dPt[0].SpPr)
+	assert.Equal(t, "0000FF", *dPt[0].Marker.SpPr.SolidFill.SrgbClr.Val)
+	// Test data points override the default data point of the pie chart
+	formatSet.Type = Pie
+	dPt = f.drawChartSeriesDPt(0, formatSet)
+	assert.Len(t, dPt, 2)
+	assert.Equal(t, "0000FF", *dPt[0].SpPr.SolidFill.SrgbClr.Val)
+	formatSet.Series[0].DataPoints = nil
+	assert.Len(t, f.drawChartSeriesDPt(0, formatSet), 1)
+	formatSet.Type = Col
+	assert.Nil(t, f.drawChartSeriesDPt(0, formatSet))
+	assert.NoError(t, f.Close())
+}
+
+func TestAddChartExplosion(t *testing.T) {
+	f := NewFile()
+	for idx, row := range [][]interface{}{{"Month", "Sales"}, {"Jan", 2}, {"Feb", 9}, {"Mar", 4}} {
+		cell, err := CoordinatesToCellName(1, idx+1)
+		assert.NoError(t, err)
+		assert.NoError(t, f.SetSheetRow("Sheet1", cell, &row))
+	}
+	assert.NoError(t, f.AddChart("Sheet1", "D1", `{"type":"doughnut","series":[{"name":"Sheet1!$B$1","categories":"Sheet1!$A$2:$A$4","values":"Sheet1!$B$2:$B$4","explosion":10}],"hole_size":50,"first_slice_angle":90}`))
+	assert.NoError(t, f.AddChart("Sheet1", "D17", `{"type":"pie","series":[{"name":"Sheet1!$B$1","categories":"Sheet1!$A$2:$A$4","values":"Sheet1!$B$2:$B$4","data_points":[{"index":0,"explosion":25},{"index":1,"color":"#FF0000","explosion":15}]}],"first_slice_angle":45}`))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddChartExplosion.xlsx")))
+
+	formatSet := &formatChart{Type: Doughnut, HoleSize: 50, FirstSliceAngle: 90, Series: []formatChartSeries{{Explosion: 10}}}
+	plotArea := f.drawDoughnutChart(formatSet)
+	assert.Equal(t, 50, *plotArea.DoughnutChart.HoleSize.Val)
+	assert.Equal(t, 90, *plotArea.DoughnutChart.FirstSliceAng.Val)
+	assert.Equal(t, 10, *(*plotArea.DoughnutChart.Ser)[0].Explosion.Val)
+	formatSet = &formatChart{Type: Pie, Series: []formatChartSeries{{DataPoints: []formatChartDataPoint{
+		{Index: 0, Explosion: 25}, {Index: 1, Color: "#FF0000", Explosion: 15}, {Index: 2, Explosion: 500},
+	}}}}
+	plotArea = f.drawPieChart(formatSet)
+	assert.Nil(t, plotArea.PieChart.FirstSliceAng)
+	ser := (*plotArea.PieChart.Ser)[0]
+	assert.Nil(t, ser.Explosion)
+	assert.Len(t, ser.DPt, 2)
+	assert.Equal(t, 25, *ser.DPt[0].Explosion.Val)
+	// Test explosion of the data point keeps the default color of the pie chart
+	assert.Equal(t, "accent1", ser.DPt[0].SpPr.SolidFill.SchemeClr.Val)
+	assert.Equal(t, 15, *ser.DPt[1].Explosion.Val)
+	assert.Equal(t, "FF0000", *ser.DPt[1].SpPr.SolidFill.SrgbClr.Val)
+	// Test explosion and first slice angle out of range and unsupported chart type
+	assert.Nil(t, f.drawChartFirstSliceAng(&formatChart{Type: Pie, FirstSliceAngle: 361}))
+	assert.Nil(t, f.drawChartSeriesExplosion(401, &formatChart{Type: Pie}))
+	assert.Nil(t, f.drawChartSeriesExplosion(10, &formatChart{Type: Col}))
+	assert.NoError(t, f.Close())
+}
+
+func TestAddChartFromRange(t *testing.T) {
+	f := NewFile()
+	for idx, row := range [][]interface{}{
+		{nil, "Apple", "Orange", "Pear"},
+		{"Small", 2, 3, 3},
+		{"Normal", 5, 2, 4},
+		{"Large", 6, 7, 8},
+	} {
+		cell, err := CoordinatesToCellName(1, idx+1)
+		assert.NoError(t, err)
+		assert.NoError(t, f.SetSheetRow("Sheet1", cell, &row))
+	}
+	getSeries := func(dataSheet, dataRange string, seriesInRows bool, header *bool) []map[string]string {
+		coordinates, err := areaRefToCoordinates(dataRange)
+		assert.NoError(t, err)
+		series, err := f.getChartSeriesFromRange(dataSheet, coordinates, seriesInRows, header)
+		assert.NoError(t, err)
+		return series
+	}
+	// Test infer series in columns with auto detected header
+	assert.Equal(t, []map[string]string{
+		{"name": "Sheet1!$B$1", "categories": "Sheet1!$A$2:$A$4", "values": "Sheet1!$B$2:$B$4"},
+		{"name": "Sheet1!$C$1", "categories": "Sheet1!$A$2:$A$4", "values": "Sheet1!$C$2:$C$4"},
+		{"name": "Sheet1!$D$1", "categories": "Sheet1!$A$2:$A$4", "values": "Sheet1!$D$2:$D$4"},
+	}, getSeries("Sheet1", "A1:D4", false, nil))
+	// Test infer series in rows with auto detected header
+	assert.Equal(t, []map[string]string{
+		{"name": "Sheet1!$A$2", "categories": "Sheet1!$B$1:$D$1", "values": "Sheet1!$B$2:$D$2"},
+		{"name": "Sheet1!$A$3", "categories": "Sheet1!$B$1:$D$1", "values": "Sheet1!$B$3:$D$3"},
+		{"name": "Sheet1!$A$4", "categories": "Sheet1!$B$1:$D$1", "values": "Sheet1!$B$4:$D$4"},
+	}, getSeries("Sheet1", "A1:D4", true, nil))
+	// Test infer series without header
+	assert.Equal(t, []map[string]string{
+		{"categories": "Sheet1!$B$2:$B$4", "values": "Sheet1!$C$2:$C$4"},
+	}, getSeries("Sheet1", "B2:C4", false, nil))
+	assert.Equal(t, []map[string]string{
+		{"name": "Sheet1!$C$2", "categories": "", "values": "Sheet1!$C$3:$C$4"},
+	}, getSeries("Sheet1", "C2:C4", false, boolPtr(true)))
+	f.NewSheet("Sales Data")
+	assert.Equal(t, []map[string]string{
+		{"categories": "", "values": "'Sales Data'!$A$1:$A$2"},
+	}, getSeries("Sales Data", "A1:A2", false, nil))
+
+	assert.NoError(t, f.AddChartFromRange("Sheet1", "A1:D4", `{"type":"col","title":{"name":"Fruit"}}`))
+	assert.NoError(t, f.AddChartFromRange("Sheet1", "Sheet1!$A$1:$D$4", `{"type":"line","cell":"F20","series_in_rows":true,"header":false}`))
+	assert.NoError(t, f.AddChartFromRange("Sales Data", "'Sheet1'!A1:D4", `{"type":"pie"}`))
+	assert.Contains(t, string(f.readXML("xl/charts/chart1.xml")), "<f>Sheet1!$B$1</f>")
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddChartFromRange.xlsx")))
+
+	// Test add chart from range with invalid options
+	assert.EqualError(t, f.AddChartFromRange("Sheet1", "A1:D4", `{"type":"col","header":"yes"}`), "json: cannot unmarshal string into Go struct field .header of type bool")
+	assert.EqualError(t, f.AddChartFromRange("Sheet1", "A1:D4", `{`), "unexpected end of JSON input")
+	assert.EqualError(t, f.AddChartFromRange("Sheet1", "A:D", `{"type":"col"}`), newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
+	assert.EqualError(t, f.AddChartFromRange("Sheet1", "SheetN!A1:D4", `{"type":"col"}`), "sheet SheetN is not exist")
+	assert.EqualError(t, f.AddChartFromRange("Sheet1", "A1:D1", `{"type":"col","header":true}`), ErrParameterInvalid.Error())
+	assert.EqualError(t, f.AddChartFromRange("Sheet1", "XFD1", `{"type":"col"}`), ErrColumnNumber.Error())
+	assert.EqualError(t, f.AddChartFromRange("Sheet1", "A1:D4", `{"type":"unknown"}`), "unsupported chart type unknown")
+}
+
+func TestAdjustChartReferences(t *testing.T) {
+	content, err := adjustChartReferences(`<c:chart><c:f>Sheet1!$A$1&amp;"Sheet1!"</c:f><c:f/></c:chart>`, map[string]string{"sheet1": "Sheet 2"})
+	assert.NoError(t, err)
+	assert.Equal(t, `<c:chart><c:f>&#39;Sheet 2&#39;!$A$1&amp;&#34;Sheet1!&#34;</c:f><c:f/></c:chart>`, content)
+	_, err = adjustChartReferences(`</c:f>`, nil)
+	assert.EqualError(t, err, ErrParameterInvalid.Error())
+}
@@ -96,7 +96,7 @@ func (cols *Cols) Rows(opts ...Options) ([]string, error) {
 	if cols.stashCol >= cols.curCol {
 		return rows, err
 	}
-	cols.rawCellValue = parseOptions(opts...).RawCellValue
+	cols.rawCellValue = cols.f.getOptions(opts...).RawCellValue
 	d := cols.f.sharedStringsReader()
 	decoder := cols.f.xmlNewDecoder(bytes.NewReader(cols.sheetXML))
 	for {
@@ -238,6 +238,51 @@ func (f *File) Cols(sheet string) (*Cols, error) {
 	return &colIterator.cols, nil
 }
 
+// GetUsedCols provides a function to get the names of the columns which
+// contain any cell value on the worksheet by given worksheet name, in
+// ascending order. The worksheet will be read row by row with the streaming
+// reader, the columns only have formatted empty cells will not be counted.
+// For example, get the used columns on a worksheet named 'Sheet1':
+//
+//	cols, err := f.GetUsedCols("Sheet1")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	for _, col := range cols {
+//	    fmt.Println(col)
+//	}
+func (f *File) GetUsedCols(sheet string) ([]string, error) {
+	rows, err := f.Rows(sheet)
+	if err != nil {
+		return nil, err
+	}
+	used := make([]uint64, (MaxColumns+63)/64)
+	for rows.Next() {
+		row, err := rows.Columns(Options{RawCellValue: true})
+		if err != nil {
+			_ = rows.Close()
+			return nil, err
+		}
+		for idx, value := range row {
+			if value != "" {
+				used[idx/64] |= 1 << uint(idx%64)
+			}
+		}
+	}
+	if err = rows.Close(); err != nil {
+		return nil, err
+	}
+	cols := []string{}
+	for idx := 0; idx < MaxColumns; idx++ {
+		if used[idx/64]&(1<<uint(idx%64)) != 0 {
+			name, _ := ColumnNumberToName(idx + 1)
+			cols = append(cols, name)
+		}
+	}
+	return cols, nil
+}
+
 // GetColVisible provides a function to get visible of a single column by given
 // worksheet name and column name. For example, get visible state of column D
 // in Sheet1:
@@ -287,7 +332,7 @@ func (f *File) SetColVisible(sheet, columns string, visible bool) error {
 	colData := xlsxCol{
 		Min:         start,
 		Max:         end,
-		Width:       defaultColWidth, // default width
+		Width:       ws.getDefaultColWidth(), // default width
 		Hidden:      !visible,
 		CustomWidth: true,
 	}
@@ -425,7 +470,7 @@ func (f *File) SetColStyle(sheet, columns string, styleID int) error {
 	ws.Cols.Col = flatCols(xlsxCol{
 		Min:   start,
 		Max:   end,
-		Width: defaultColWidth,
+		Width: ws.getDefaultColWidth(),
 		Style: styleID,
 	}, ws.Cols.Col, func(fc, c xlsxCol) xlsxCol {
 		fc.BestFit = c.BestFit
@@ -496,6 +541,47 @@ func (f *File) SetColWidth(sheet, startCol, endCol string, width float64) error
 	return err
 }
 
+// SetColWidthPixels provides a function to set the width of a single column
+// or multiple columns in pixels. The pixels will be converted to the width in
+// characters by the maximum digit width of the default font of the workbook,
+// which is 7 pixels for the 11 point font. For example, set the width of the
+// columns A to H to 100 pixels:
+//
+//	err := f.SetColWidthPixels("Sheet1", "A", "H", 100)
+func (f *File) SetColWidthPixels(sheet, startCol, endCol string, pixels int) error {
+	if pixels < 0 {
+		return ErrParameterInvalid
+	}
+	return f.SetColWidth(sheet, startCol, endCol, convertPixelsToColWidth(float64(pixels), f.getMaxDigitWidth()))
+}
+
+// getMaxDigitWidth provides a function to get the maximum digit width in
+// pixels of the default font of the workbook, which is scaled from 7 pixels
+// of the 11 point font by the font size.
+func (f *File) getMaxDigitWidth() float64 {
+	var maxDigitWidth float64 = 7
+	if s := f.stylesReader(); s.Fonts != nil && len(s.Fonts.Font) > 0 {
+		if font := s.Fonts.Font[0]; font.Sz != nil && font.Sz.Val != nil && *font.Sz.Val > 0 {
+			maxDigitWidth = math.Max(math.Round(maxDigitWidth**font.Sz.Val/11), 1)
+		}
+	}
+	return maxDigitWidth
+}
+
+// convertPixelsToColWidth provides a function to convert the width of a
+// column from pixels to the width in characters by given maximum digit width
+// in pixels, the width will be truncated to 1/256 of the character width.
+// The padding of the column is twice the quarter of the maximum digit width
+// rounded up, plus 1 pixel for the gridline.
+func convertPixelsToColWidth(pixels, maxDigitWidth float64) float64 {
+	padding := 2*math.Ceil(maxDigitWidth/4) + 1
+	if pixels <= maxDigitWidth+padding {
+		return math.Trunc(pixels/(maxDigitWidth+padding)*256) / 256
+	}
+	chars := math.Trunc((pixels-padding)/maxDigitWidth*100+0.5) / 100
+	return math.Trunc((chars*maxDigitWidth+padding)/maxDigitWidth*256) / 256
+}
+
 // flatCols provides a method for the column's operation functions to flatten
 // and check the worksheet columns.
 func flatCols(col xlsxCol, cols []xlsxCol, replacer func(fc, c xlsxCol) xlsxCol) []xlsxCol {
@@ -627,10 +713,23 @@ func (f *File) getColWidth(sheet string, col int) int {
 			return int(convertColWidthToPixels(width))
 		}
 	}
+	if ws.SheetFormatPr != nil && ws.SheetFormatPr.DefaultColWidth > 0 {
+		return int(convertColWidthToPixels(ws.SheetFormatPr.DefaultColWidth))
+	}
 	// Optimization for when the column widths haven't changed.
 	return int(defaultColWidthPixels)
 }
 
+// getDefaultColWidth provides a function to get the default column width of
+// the worksheet, which could be set by the DefaultColWidth option of the
+// worksheet formatting properties.
+func (ws *xlsxWorksheet) getDefaultColWidth() float64 {
+	if ws.SheetFormatPr != nil && ws.SheetFormatPr.DefaultColWidth > 0 {
+		return ws.SheetFormatPr.DefaultColWidth
+	}
+	return defaultColWidth
+}
+
 // GetColWidth provides a function to get column width by given worksheet name
 // and column name.
 func (f *File) GetColWidth(sheet, col string) (float64, error) {
@@ -654,7 +753,7 @@ func (f *File) GetColWidth(sheet, col string) (float64, error) {
 		}
 	}
 	// Optimization for when the column widths haven't changed.
-	return defaultColWidth, err
+	return ws.getDefaultColWidth(), err
 }
 
 // InsertCol provides a function to insert a new column before given column
@@ -134,6 +134,32 @@ func TestGetColsError(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestGetUsedCols(t *testing.T) {
+	f := NewFile()
+	cols, err := f.GetUsedCols("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{}, cols)
+	for cell, value := range map[string]interface{}{"B1": "B", "D3": 1, "AA5": true, "D6": "D"} {
+		assert.NoError(t, f.SetCellValue("Sheet1", cell, value))
+	}
+	style, err := f.NewStyle(&Style{Font: &Font{Bold: true}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "AB1", "AC10", style))
+	assert.NoError(t, f.SetCellValue("Sheet1", "XFD2", ""))
+	cols, err = f.GetUsedCols("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"B", "D", "AA"}, cols)
+	// Test get used columns on not exists worksheet
+	_, err = f.GetUsedCols("SheetN")
+	assert.EqualError(t, err, "sheet SheetN is not exist")
+	// Test get used columns with invalid worksheet cell reference
+	f.Sheet.Delete("xl/worksheets/sheet1.xml")
+	f.Pkg.Store("xl/worksheets/sheet1.xml", []byte(`<worksheet><sheetData><row r="1"><c r="A" t="str"><v>A</v></c></row></sheetData></worksheet>`))
+	f.checked = nil
+	_, err = f.GetUsedCols("Sheet1")
+	assert.EqualError(t, err, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
+}
+
 func TestColsRows(t *testing.T) {
 	f := NewFile()
 	f.NewSheet("Sheet1")
@@ -339,6 +365,35 @@ func TestColWidth(t *testing.T) {
 	convertRowHeightToPixels(0)
 }
 
+func TestSetColWidthPixels(t *testing.T) {
+	f := NewFile()
+	for pixels, expected := range map[int]float64{0: 0, 6: 0.5, 64: defaultColWidth, 100: 14.28125, 1790: 255.7109375} {
+		assert.Equal(t, expected, convertPixelsToColWidth(float64(pixels), 7), pixels)
+	}
+	// Test convert pixels to column width with the padding of the maximum digit width
+	for pixels, expected := range map[int]float64{9: 0.52734375, 17: 1, 109: 10.8984375} {
+		assert.Equal(t, expected, convertPixelsToColWidth(float64(pixels), 10), pixels)
+	}
+	assert.NoError(t, f.SetColWidthPixels("Sheet1", "A", "B", 64))
+	width, err := f.GetColWidth("Sheet1", "B")
+	assert.NoError(t, err)
+	assert.Equal(t, defaultColWidth, width)
+	// Test set column width in pixels with the larger default font size
+	styleSheet := f.stylesReader()
+	styleSheet.Fonts.Font[0].Sz.Val = float64Ptr(22)
+	assert.NoError(t, f.SetColWidthPixels("Sheet1", "C", "C", 100))
+	width, err = f.GetColWidth("Sheet1", "C")
+	assert.NoError(t, err)
+	assert.Equal(t, 7.140625, width)
+	// Test set column width in pixels with invalid pixels
+	assert.EqualError(t, f.SetColWidthPixels("Sheet1", "A", "B", -1), ErrParameterInvalid.Error())
+	assert.EqualError(t, f.SetColWidthPixels("Sheet1", "A", "B", 4000), ErrColumnWidth.Error())
+	// Test set column width in pixels with illegal column name
+	assert.EqualError(t, f.SetColWidthPixels("Sheet1", "*", "B", 100), newInvalidColumnNameError("*").Error())
+	// Test set column width in pixels on not exists worksheet
+	assert.EqualError(t, f.SetColWidthPixels("SheetN", "A", "B", 100), "sheet SheetN is not exist")
+}
+
 func TestInsertCol(t *testing.T) {
 	f := NewFile()
 	sheet1 := f.GetSheetName(0)
@@ -13,6 +13,7 @@ package excelize
 
 import (
 	"bytes"
+	"crypto/rand"
 	"encoding/json"
 	"encoding/xml"
 	"fmt"
@@ -21,6 +22,9 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
+
+	"golang.org/x/image/font"
 )
 
 // parseFormatCommentsSet provides a function to parse the format settings of
@@ -93,56 +97,519 @@ func (f *File) getSheetComments(sheetFile string) string {
 // comment in Sheet1!$A$30:
 //
 //	err := f.AddComment("Sheet1", "A30", `{"author":"Excelize: ","text":"This is a comment."}`)
+//
+// By default, the VML drawing of the comment will be added for displaying the
+// comment box in Excel. Set the no_legacy_drawing option to add the comment
+// without the VML drawing, which reduces the file size when adding a large
+// number of comments, but the comments will not be displayed by the
+// applications that depend on the VML drawing, such as the legacy versions of
+// Excel. For example:
+//
+//	err := f.AddComment("Sheet1", "A31", `{"author":"Excelize: ","text":"This is a comment.","no_legacy_drawing":true}`)
+//
+// By default, the comment box will be placed next to the cell. Set the anchor
+// option to place the comment box on the given cells, the from and to cells
+// specifies where the top left and bottom right corners of the comment box
+// are located, and the optional offsets specifies the distance in pixels from
+// the top left corner of the cells. The bottom right corner must be on the
+// right of and below the top left corner. For example, place the comment box
+// of the cell A32 from the cell E2 to the cell H8:
+//
+//	err := f.AddComment("Sheet1", "A32", `{"author":"Excelize: ","text":"This is a comment.","anchor":{"from":"E2","to":"H8","to_offset_x":10}}`)
+//
+// Set the auto_size option to fit the size of the comment box to the text of
+// the comment, the text will be measured by the metrics of the built-in
+// fonts, and the lines which are wider than the max_width option will be
+// wrapped. The max_width specifies the maximum width of the comment box in
+// pixels, the default value is 300. The auto_size option will be ignored if
+// the anchor option is set. For example, add the auto-sized comment box
+// which wraps the text at 200 pixels for the cell A33:
+//
+//	err := f.AddComment("Sheet1", "A33", `{"author":"Excelize: ","text":"This is a long review comment.","auto_size":true,"max_width":200}`)
 func (f *File) AddComment(sheet, cell, format string) error {
 	formatSet, err := parseFormatCommentsSet(format)
 	if err != nil {
 		return err
 	}
+	return f.addSheetComment(sheet, cell, formatSet)
+}
+
+// addSheetComment provides a function to add the legacy comment and the VML
+// drawing of the comment in a sheet by given worksheet name, cell and format
+// set. The VML drawing will not be added if the no_legacy_drawing option of
+// the format set is enabled.
+func (f *File) addSheetComment(sheet, cell string, formatSet *formatComment) error {
 	// Read sheet data.
 	ws, err := f.workSheetReader(sheet)
 	if err != nil {
 		return err
 	}
+	if _, _, err = CellNameToCoordinates(cell); err != nil {
+		return err
+	}
+	anchor, err := parseCommentAnchor(formatSet.Anchor)
+	if err != nil {
+		return err
+	}
+	var width, height int
+	if formatSet.AutoSize && anchor == "" && !formatSet.NoLegacyDrawing {
+		if anchor, width, height, err = f.getCommentAutoSize(sheet, cell, formatSet); err != nil {
+			return err
+		}
+	}
+	sheetXMLPath, _ := f.getSheetXMLPath(sheet)
+	sheetRels := "xl/worksheets/_rels/" + strings.TrimPrefix(sheetXMLPath, "xl/worksheets/") + ".rels"
 	commentID := f.countComments() + 1
-	drawingVML := "xl/drawings/vmlDrawing" + strconv.Itoa(commentID) + ".vml"
-	sheetRelationshipsComments := "../comments" + strconv.Itoa(commentID) + ".xml"
-	sheetRelationshipsDrawingVML := "../drawings/vmlDrawing" + strconv.Itoa(commentID) + ".vml"
-	if ws.LegacyDrawing != nil {
-		// The worksheet already has a comments relationships, use the relationships drawing ../drawings/vmlDrawing%d.vml.
-		sheetRelationshipsDrawingVML = f.getSheetRelationshipsTargetByID(sheet, ws.LegacyDrawing.RID)
-		commentID, _ = strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(sheetRelationshipsDrawingVML, "../drawings/vmlDrawing"), ".vml"))
-		drawingVML = strings.ReplaceAll(sheetRelationshipsDrawingVML, "..", "xl")
+	commentsXML := "xl/comments" + strconv.Itoa(commentID) + ".xml"
+	if target := f.getSheetComments(filepath.Base(sheetXMLPath)); target != "" {
+		// The worksheet already has a comments relationships, use the relationships comments ../comments%d.xml.
+		if commentsXML = strings.TrimPrefix(target, "/"); !strings.HasPrefix(target, "/") {
+			commentsXML = "xl" + strings.TrimPrefix(target, "..")
+		}
+		commentID, _ = strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(commentsXML, "xl/comments"), ".xml"))
 	} else {
-		// Add first comment for given sheet.
-		sheetXMLPath, _ := f.getSheetXMLPath(sheet)
-		sheetRels := "xl/worksheets/_rels/" + strings.TrimPrefix(sheetXMLPath, "xl/worksheets/") + ".rels"
-		rID := f.addRels(sheetRels, SourceRelationshipDrawingVML, sheetRelationshipsDrawingVML, "")
-		f.addRels(sheetRels, SourceRelationshipComments, sheetRelationshipsComments, "")
-		f.addSheetNameSpace(sheet, SourceRelationship)
-		f.addSheetLegacyDrawing(sheet, rID)
+		f.addRels(sheetRels, SourceRelationshipComments, "../comments"+strconv.Itoa(commentID)+".xml", "")
 	}
-	commentsXML := "xl/comments" + strconv.Itoa(commentID) + ".xml"
-	var colCount int
-	for i, l := range strings.Split(formatSet.Text, "\n") {
-		if ll := len(l); ll > colCount {
-			if i == 0 {
-				ll += len(formatSet.Author)
+	if !formatSet.NoLegacyDrawing {
+		drawingVML := "xl/drawings/vmlDrawing" + strconv.Itoa(commentID) + ".vml"
+		if ws.LegacyDrawing != nil {
+			// The worksheet already has a VML drawing relationships, use the relationships drawing ../drawings/vmlDrawing%d.vml.
+			drawingVML = strings.ReplaceAll(f.getSheetRelationshipsTargetByID(sheet, ws.LegacyDrawing.RID), "..", "xl")
+		} else {
+			rID := f.addRels(sheetRels, SourceRelationshipDrawingVML, "../drawings/vmlDrawing"+strconv.Itoa(commentID)+".vml", "")
+			f.addSheetNameSpace(sheet, SourceRelationship)
+			f.addSheetLegacyDrawing(sheet, rID)
+		}
+		vmlID, _ := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(drawingVML, "xl/drawings/vmlDrawing"), ".vml"))
+		var colCount int
+		for i, l := range strings.Split(formatSet.Text, "\n") {
+			if ll := len(l); ll > colCount {
+				if i == 0 && !formatSet.threaded {
+					ll += len(formatSet.Author)
+				}
+				colCount = ll
 			}
-			colCount = ll
 		}
+		if err = f.addDrawingVML(vmlID, drawingVML, cell, anchor, strings.Count(formatSet.Text, "\n")+1, colCount, width, height); err != nil {
+			return err
+		}
+	}
+	f.addComment(commentsXML, cell, formatSet)
+	f.addContentTypePart(commentID, "comments")
+	return err
+}
+
+// threadedCommentLegacyText defined the leading text of the legacy comment
+// for the threaded comment, which will be displayed by the applications that
+// don't support the threaded comments.
+const threadedCommentLegacyText = "[Threaded comment]\n\nYour version of Excel allows you to read this threaded comment; however, any edits to it will get removed if the file is opened in a newer version of Excel. Learn more: https://go.microsoft.com/fwlink/?linkid=870924\n\nComment:\n    "
+
+// parseFormatThreadedCommentSet provides a function to parse the format
+// settings of the threaded comment with default value.
+func parseFormatThreadedCommentSet(formatSet string) (*formatComment, error) {
+	format := formatComment{Author: "Author"}
+	err := json.Unmarshal([]byte(formatSet), &format)
+	if len(format.Author) > MaxFieldLength {
+		format.Author = format.Author[:MaxFieldLength]
+	}
+	return &format, err
+}
+
+// AddThreadedComment provides the method to add a threaded comment, which
+// starts a new thread of the comments, in a sheet by given worksheet name,
+// cell and format set (such as author and text). The backward-compatible
+// legacy comment of the thread will be added to the cell for the
+// applications that don't support the threaded comments. Use the
+// ReplyThreadedComment function to reply to the thread. For example, add a
+// threaded comment in Sheet1!$A$30:
+//
+//	err := f.AddThreadedComment("Sheet1", "A30", `{"author":"Excelize","text":"This is a threaded comment."}`)
+func (f *File) AddThreadedComment(sheet, cell, format string) error {
+	formatSet, err := parseFormatThreadedCommentSet(format)
+	if err != nil {
+		return err
 	}
-	err = f.addDrawingVML(commentID, drawingVML, cell, strings.Count(formatSet.Text, "\n")+1, colCount)
+	col, row, err := CellNameToCoordinates(cell)
 	if err != nil {
 		return err
 	}
-	f.addComment(commentsXML, cell, formatSet)
-	f.addContentTypePart(commentID, "comments")
+	if cell, err = CoordinatesToCellName(col, row); err != nil {
+		return err
+	}
+	if _, err = f.workSheetReader(sheet); err != nil {
+		return err
+	}
+	sheetXMLPath, _ := f.getSheetXMLPath(sheet)
+	if f.getSheetCommentByRef(sheetXMLPath, cell) != nil {
+		return ErrCommentExists
+	}
+	threadedCommentsXML := f.getSheetThreadedComments(sheetXMLPath)
+	if threadedCommentsXML == "" {
+		threadedCommentsID := f.countThreadedComments() + 1
+		threadedCommentsXML = "xl/threadedComments/threadedComment" + strconv.Itoa(threadedCommentsID) + ".xml"
+		sheetRels := "xl/worksheets/_rels/" + strings.TrimPrefix(sheetXMLPath, "xl/worksheets/") + ".rels"
+		f.addRels(sheetRels, SourceRelationshipThreadedComment, "../threadedComments/threadedComment"+strconv.Itoa(threadedCommentsID)+".xml", "")
+		f.addContentTypePart(threadedCommentsID, "threadedComments")
+	}
+	threadedComments, err := f.threadedCommentsReader(threadedCommentsXML)
+	if err != nil {
+		return err
+	}
+	for _, c := range threadedComments.ThreadedComment {
+		if c.Ref == cell && c.ParentID == "" {
+			return ErrCommentExists
+		}
+	}
+	personID, err := f.addPerson(formatSet.Author)
+	if err != nil {
+		return err
+	}
+	threadedComment := xlsxThreadedComment{
+		Ref:      cell,
+		DT:       time.Now().Format("2006-01-02T15:04:05.00"),
+		PersonID: personID,
+		ID:       newGUID(),
+		Text:     formatSet.Text,
+	}
+	threadedComments.ThreadedComment = append(threadedComments.ThreadedComment, threadedComment)
+	f.threadedCommentsWriter(threadedCommentsXML, threadedComments)
+	return f.addSheetComment(sheet, cell, &formatComment{
+		Author:   "tc=" + threadedComment.ID,
+		Text:     threadedCommentLegacyText + formatSet.Text,
+		threaded: true,
+	})
+}
+
+// ReplyThreadedComment provides the method to reply to the threaded comment
+// of a cell by given worksheet name, cell and format set (such as author and
+// text). The backward-compatible legacy comment of the thread will be
+// updated with the reply. For example, reply to the threaded comment in
+// Sheet1!$A$30:
+//
+//	err := f.ReplyThreadedComment("Sheet1", "A30", `{"author":"Excelize","text":"This is a reply."}`)
+func (f *File) ReplyThreadedComment(sheet, cell, format string) error {
+	formatSet, err := parseFormatThreadedCommentSet(format)
+	if err != nil {
+		return err
+	}
+	col, row, err := CellNameToCoordinates(cell)
+	if err != nil {
+		return err
+	}
+	if cell, err = CoordinatesToCellName(col, row); err != nil {
+		return err
+	}
+	if _, err = f.workSheetReader(sheet); err != nil {
+		return err
+	}
+	sheetXMLPath, _ := f.getSheetXMLPath(sheet)
+	threadedCommentsXML := f.getSheetThreadedComments(sheetXMLPath)
+	if threadedCommentsXML == "" {
+		return ErrThreadedCommentNotExist
+	}
+	threadedComments, err := f.threadedCommentsReader(threadedCommentsXML)
+	if err != nil {
+		return err
+	}
+	var thread []xlsxThreadedComment
+	for _, c := range threadedComments.ThreadedComment {
+		if c.Ref == cell && c.ParentID == "" {
+			thread = append(thread, c)
+		}
+	}
+	if len(thread) == 0 {
+		return ErrThreadedCommentNotExist
+	}
+	for _, c := range threadedComments.ThreadedComment {
+		if c.ParentID == thread[0].ID {
+			thread = append(thread, c)
+		}
+	}
+	personID, err := f.addPerson(formatSet.Author)
+	if err != nil {
+		return err
+	}
+	reply := xlsxThreadedComment{
+		Ref:      cell,
+		DT:       time.Now().Format("2006-01-02T15:04:05.00"),
+		PersonID: personID,
+		ID:       newGUID(),
+		ParentID: thread[0].ID,
+		Text:     formatSet.Text,
+	}
+	threadedComments.ThreadedComment = append(threadedComments.ThreadedComment, reply)
+	f.threadedCommentsWriter(threadedCommentsXML, threadedComments)
+	if cmt := f.getSheetCommentByRef(sheetXMLPath, cell); cmt != nil {
+		text := threadedCommentLegacyText + thread[0].Text
+		for _, c := range append(thread[1:], reply) {
+			text += "\nReply:\n    " + c.Text
+		}
+		if runes := []rune(text); len(runes) > 32512 {
+			text = string(runes[:32512])
+		}
+		cmt.Text = xlsxText{T: stringPtr(text)}
+	}
 	return err
 }
 
+// GetThreadedComments provides the method to get all threaded comments of a
+// worksheet by given worksheet name. The comments are returned in the order
+// of the worksheet part, and the replies can be grouped to the threads by
+// the ParentID. For example:
+//
+//	comments, err := f.GetThreadedComments("Sheet1")
+func (f *File) GetThreadedComments(sheet string) ([]ThreadedComment, error) {
+	sheetXMLPath, ok := f.getSheetXMLPath(sheet)
+	if !ok {
+		return nil, ErrSheetNotExist{sheet}
+	}
+	threadedCommentsXML := f.getSheetThreadedComments(sheetXMLPath)
+	if threadedCommentsXML == "" {
+		return nil, nil
+	}
+	threadedComments, err := f.threadedCommentsReader(threadedCommentsXML)
+	if err != nil {
+		return nil, err
+	}
+	persons, err := f.personsReader(f.getPersonsPath())
+	if err != nil {
+		return nil, err
+	}
+	authors := make(map[string]string, len(persons.Person))
+	for _, person := range persons.Person {
+		authors[person.ID] = person.DisplayName
+	}
+	var comments []ThreadedComment
+	for _, c := range threadedComments.ThreadedComment {
+		comments = append(comments, ThreadedComment{
+			ID:       c.ID,
+			ParentID: c.ParentID,
+			Ref:      c.Ref,
+			Author:   authors[c.PersonID],
+			Text:     c.Text,
+			Date:     c.DT,
+		})
+	}
+	return comments, nil
+}
+
+// getSheetCommentByRef provides a function to get the legacy comment of the
+// cell by given worksheet file path and cell reference.
+func (f *File) getSheetCommentByRef(sheetXMLPath, cell string) *xlsxComment {
+	target := f.getSheetComments(filepath.Base(sheetXMLPath))
+	if target == "" {
+		return nil
+	}
+	if !strings.HasPrefix(target, "/") {
+		target = "xl" + strings.TrimPrefix(target, "..")
+	}
+	if comments := f.commentsReader(strings.TrimPrefix(target, "/")); comments != nil {
+		for idx := range comments.CommentList.Comment {
+			if comments.CommentList.Comment[idx].Ref == cell {
+				return &comments.CommentList.Comment[idx]
+			}
+		}
+	}
+	return nil
+}
+
+// getSheetThreadedComments provides the method to get the path of the
+// threaded comments part by given worksheet file path.
+func (f *File) getSheetThreadedComments(sheetXMLPath string) string {
+	rels := "xl/worksheets/_rels/" + filepath.Base(sheetXMLPath) + ".rels"
+	if sheetRels := f.relsReader(rels); sheetRels != nil {
+		sheetRels.Lock()
+		defer sheetRels.Unlock()
+		for _, v := range sheetRels.Relationships {
+			if v.Type == SourceRelationshipThreadedComment {
+				if strings.HasPrefix(v.Target, "/") {
+					return strings.TrimPrefix(v.Target, "/")
+				}
+				return "xl" + strings.TrimPrefix(v.Target, "..")
+			}
+		}
+	}
+	return ""
+}
+
+// getPersonsPath provides the method to get the path of the persons part of
+// the workbook, which contains the authors of the threaded comments.
+func (f *File) getPersonsPath() string {
+	return f.getWorkbookRelsTarget(SourceRelationshipPerson)
+}
+
+// addPerson provides a function to add the author of the threaded comments
+// to the persons part of the workbook by given display name if not exists,
+// and returns the ID of the person.
+func (f *File) addPerson(name string) (string, error) {
+	personsXML := f.getPersonsPath()
+	if personsXML == "" {
+		personsXML = "xl/persons/person.xml"
+		f.addRels(f.getWorkbookRelsPath(), SourceRelationshipPerson, "/"+personsXML, "")
+		f.addContentTypePart(0, "person")
+	}
+	persons, err := f.personsReader(personsXML)
+	if err != nil {
+		return "", err
+	}
+	for _, person := range persons.Person {
+		if person.DisplayName == name {
+			return person.ID, err
+		}
+	}
+	person := xlsxPerson{DisplayName: name, ID: newGUID(), UserID: name, ProviderID: "None"}
+	persons.Person = append(persons.Person, person)
+	output, err := xml.Marshal(persons)
+	f.saveFileList(personsXML, output)
+	return person.ID, err
+}
+
+// countThreadedComments provides a function to get threaded comments files
+// count storage in the folder xl/threadedComments.
+func (f *File) countThreadedComments() int {
+	count := 0
+	f.Pkg.Range(func(k, v interface{}) bool {
+		if strings.Contains(k.(string), "xl/threadedComments/threadedComment") {
+			count++
+		}
+		return true
+	})
+	return count
+}
+
+// threadedCommentsReader provides a function to get the structure after
+// deserialization of xl/threadedComments/threadedComment%d.xml.
+func (f *File) threadedCommentsReader(path string) (*xlsxThreadedComments, error) {
+	threadedComments := new(xlsxThreadedComments)
+	if err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(f.readXML(path)))).
+		Decode(threadedComments); err != nil && err != io.EOF {
+		return threadedComments, err
+	}
+	return threadedComments, nil
+}
+
+// threadedCommentsWriter provides a function to save
+// xl/threadedComments/threadedComment%d.xml after serialize structure.
+func (f *File) threadedCommentsWriter(path string, threadedComments *xlsxThreadedComments) {
+	output, _ := xml.Marshal(threadedComments)
+	f.saveFileList(path, output)
+}
+
+// personsReader provides a function to get the structure after
+// deserialization of xl/persons/person.xml.
+func (f *File) personsReader(path string) (*xlsxPersonList, error) {
+	persons := new(xlsxPersonList)
+	if path == "" {
+		return persons, nil
+	}
+	if err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(f.readXML(path)))).
+		Decode(persons); err != nil && err != io.EOF {
+		return persons, err
+	}
+	return persons, nil
+}
+
+// newGUID provides a function to generate a random GUID in the registry
+// format, such as {3F2504E0-4F89-41D3-9A0C-0305E82C3301}.
+func newGUID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	b[6], b[8] = b[6]&0x0f|0x40, b[8]&0x3f|0x80
+	return fmt.Sprintf("{%X-%X-%X-%X-%X}", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
+// parseCommentAnchor provides a function to convert the anchor of the comment
+// box to the anchor of the VML shape in the form of "LeftColumn, LeftOffset,
+// TopRow, TopOffset, RightColumn, RightOffset, BottomRow, BottomOffset". An
+// empty string will be returned if the given anchor is nil, and an error will
+// be returned if the bottom right corner isn't on the right of and below the
+// top left corner of the comment box.
+func parseCommentAnchor(anchor *CommentAnchor) (string, error) {
+	if anchor == nil {
+		return "", nil
+	}
+	fromCol, fromRow, err := CellNameToCoordinates(anchor.From)
+	if err != nil {
+		return "", err
+	}
+	toCol, toRow, err := CellNameToCoordinates(anchor.To)
+	if err != nil {
+		return "", err
+	}
+	if anchor.FromOffsetX < 0 || anchor.FromOffsetY < 0 || anchor.ToOffsetX < 0 || anchor.ToOffsetY < 0 ||
+		toCol < fromCol || (toCol == fromCol && anchor.ToOffsetX <= anchor.FromOffsetX) ||
+		toRow < fromRow || (toRow == fromRow && anchor.ToOffsetY <= anchor.FromOffsetY) {
+		return "", ErrParameterInvalid
+	}
+	return fmt.Sprintf("%d, %d, %d, %d, %d, %d, %d, %d",
+		fromCol-1, anchor.FromOffsetX, fromRow-1, anchor.FromOffsetY,
+		toCol-1, anchor.ToOffsetX, toRow-1, anchor.ToOffsetY), err
+}
+
+// commentBoxPadding defined the horizontal and vertical inner margins of the
+// comment box in pixels.
+var commentBoxPadding = [2]int{10, 5}
+
+// getCommentAutoSize provides a function to measure the text of the comment
+// by the metrics of the built-in fonts, and get the anchor and the size in
+// pixels of the comment box which fits the text by given worksheet name,
+// cell and format set. The lines which are wider than the maximum width of
+// the comment box will be wrapped.
+func (f *File) getCommentAutoSize(sheet, cell string, formatSet *formatComment) (string, int, int, error) {
+	col, row, err := CellNameToCoordinates(cell)
+	if err != nil {
+		return "", 0, 0, err
+	}
+	maxWidth := formatSet.MaxWidth
+	if maxWidth < 0 {
+		return "", 0, 0, ErrParameterInvalid
+	}
+	if maxWidth == 0 {
+		maxWidth = 300
+	}
+	if renderFontsOnce.Do(parseRenderFonts); renderFontsErr != nil {
+		return "", 0, 0, renderFontsErr
+	}
+	r := &renderer{scale: 1, faces: make(map[string]font.Face)}
+	regular, err := r.getFace(&Font{Size: 9})
+	if err != nil {
+		return "", 0, 0, err
+	}
+	bold, err := r.getFace(&Font{Bold: true, Size: 9})
+	if err != nil {
+		return "", 0, 0, err
+	}
+	text, extra := formatSet.Text, 0
+	if !formatSet.threaded {
+		// the author is shown in bold at the beginning of the first line
+		text = formatSet.Author + text
+		extra = font.MeasureString(bold, formatSet.Author).Ceil() - font.MeasureString(regular, formatSet.Author).Ceil()
+	}
+	var width int
+	lines := wrapText(regular, text, maxWidth-2*commentBoxPadding[0])
+	for idx, line := range lines {
+		lineWidth := font.MeasureString(regular, line).Ceil()
+		if idx == 0 {
+			lineWidth += extra
+		}
+		if lineWidth > width {
+			width = lineWidth
+		}
+	}
+	if width += 2 * commentBoxPadding[0]; width > maxWidth {
+		width = maxWidth
+	}
+	height := len(lines)*regular.Metrics().Height.Ceil() + 2*commentBoxPadding[1]
+	colStart, rowStart, colEnd, rowEnd, x2, y2 := f.positionObjectPixels(sheet, col, row-1, 15, 2, width, height)
+	return fmt.Sprintf("%d, 15, %d, 2, %d, %d, %d, %d", colStart, rowStart, colEnd, x2, rowEnd, y2), width, height, err
+}
+
 // addDrawingVML provides a function to create comment as
-// xl/drawings/vmlDrawing%d.vml by given commit ID and cell.
-func (f *File) addDrawingVML(commentID int, drawingVML, cell string, lineCount, colCount int) error {
+// xl/drawings/vmlDrawing%d.vml by given commit ID, cell, the anchor and the
+// size in pixels of the comment box, the comment box will be placed next to
+// the cell if the given anchor is empty, and the default size will be used
+// if the given size is zero.
+func (f *File) addDrawingVML(commentID int, drawingVML, cell, anchor string, lineCount, colCount, width, height int) error {
 	col, row, err := CellNameToCoordinates(cell)
 	if err != nil {
 		return err
@@ -193,6 +660,11 @@ func (f *File) addDrawingVML(commentID int, drawingVML, cell string, lineCount,
 			}
 		}
 	}
+	if anchor == "" {
+		anchor = fmt.Sprintf(
+			"%d, 23, %d, 0, %d, %d, %d, 5",
+			1+yAxis, 1+xAxis, 2+yAxis+lineCount, colCount+yAxis, 2+xAxis+lineCount)
+	}
 	sp := encodeShape{
 		Fill: &vFill{
 			Color2: "#fbfe82",
@@ -219,19 +691,20 @@ func (f *File) addDrawingVML(commentID int, drawingVML, cell string, lineCount,
 		},
 		ClientData: &xClientData{
 			ObjectType: "Note",
-			Anchor: fmt.Sprintf(
-				"%d, 23, %d, 0, %d, %d, %d, 5",
-				1+yAxis, 1+xAxis, 2+yAxis+lineCount, colCount+yAxis, 2+xAxis+lineCount),
-			AutoFill: "True",
-			Row:      xAxis,
-			Column:   yAxis,
+			Anchor:     anchor,
+			AutoFill:   "True",
+			Row:        xAxis,
+			Column:     yAxis,
 		},
 	}
+	if width == 0 || height == 0 {
+		width, height = 144, 79
+	}
 	s, _ := xml.Marshal(sp)
 	shape := xlsxShape{
 		ID:          "_x0000_s1025",
 		Type:        "#_x0000_t202",
-		Style:       "position:absolute;73.5pt;width:108pt;height:59.25pt;z-index:1;visibility:hidden",
+		Style:       fmt.Sprintf("position:absolute;73.5pt;width:%gpt;height:%gpt;z-index:1;visibility:hidden", float64(width)*0.75, float64(height)*0.75),
 		Fillcolor:   "#fbf6d6",
 		Strokecolor: "#edeaa1",
 		Val:         string(s[13 : len(s)-14]),
@@ -249,8 +722,8 @@ func (f *File) addComment(commentsXML, cell string, formatSet *formatComment) {
 	if len(a) > MaxFieldLength {
 		a = a[:MaxFieldLength]
 	}
-	if len(t) > 32512 {
-		t = t[:32512]
+	if runes := []rune(t); len(runes) > 32512 {
+		t = string(runes[:32512])
 	}
 	comments := f.commentsReader(commentsXML)
 	authorID := 0
@@ -273,7 +746,7 @@ func (f *File) addComment(commentsXML, cell string, formatSet *formatComment) {
 						B:  &bold,
 						Sz: &attrValFloat{Val: float64Ptr(9)},
 						Color: &xlsxColor{
-							Indexed: 81,
+							Indexed: intPtr(81),
 						},
 						RFont:  &attrValString{Val: stringPtr(defaultFont)},
 						Family: &attrValInt{Val: intPtr(2)},
@@ -284,7 +757,7 @@ func (f *File) addComment(commentsXML, cell string, formatSet *formatComment) {
 					RPr: &xlsxRPr{
 						Sz: &attrValFloat{Val: float64Ptr(9)},
 						Color: &xlsxColor{
-							Indexed: 81,
+							Indexed: intPtr(81),
 						},
 						RFont:  &attrValString{Val: stringPtr(defaultFont)},
 						Family: &attrValInt{Val: intPtr(2)},
@@ -294,6 +767,9 @@ func (f *File) addComment(commentsXML, cell string, formatSet *formatComment) {
 			},
 		},
 	}
+	if formatSet.threaded {
+		cmt.Text = xlsxText{T: stringPtr(t)}
+	}
 	comments.CommentList.Comment = append(comments.CommentList.Comment, cmt)
 	f.Comments[commentsXML] = comments
 }
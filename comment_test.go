@@ -16,6 +16,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"unicode/utf8"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -46,6 +47,89 @@ func TestAddComments(t *testing.T) {
 	assert.EqualValues(t, len(NewFile().GetComments()), 0)
 }
 
+func TestAddCommentsWithoutLegacyDrawing(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.AddComment("Sheet1", "A1", `{"author":"Excelize: ","text":"Lean comment.","no_legacy_drawing":true}`))
+	assert.NoError(t, f.AddComment("Sheet1", "A2", `{"author":"Excelize: ","text":"Lean comment.","no_legacy_drawing":true}`))
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Nil(t, ws.LegacyDrawing)
+	assert.Empty(t, f.VMLDrawing)
+	assert.Equal(t, "../comments1.xml", f.getSheetComments("sheet1.xml"))
+	// Test add comment with VML drawing on the worksheet with lean comments
+	assert.NoError(t, f.AddComment("Sheet1", "A3", `{"author":"Excelize: ","text":"Comment."}`))
+	assert.NotNil(t, ws.LegacyDrawing)
+	assert.NotNil(t, f.VMLDrawing["xl/drawings/vmlDrawing1.vml"])
+	// Test add lean comment on the worksheet with VML drawing
+	f.NewSheet("Sheet2")
+	assert.NoError(t, f.AddComment("Sheet2", "B1", `{"author":"Excelize: ","text":"Comment."}`))
+	assert.NoError(t, f.AddComment("Sheet2", "B2", `{"author":"Excelize: ","text":"Lean comment.","no_legacy_drawing":true}`))
+	assert.Len(t, f.VMLDrawing["xl/drawings/vmlDrawing2.vml"].Shape, 1)
+	comments := f.GetComments()
+	assert.Len(t, comments["Sheet1"], 3)
+	assert.Len(t, comments["Sheet2"], 2)
+	// Test add lean comment with illegal cell coordinates
+	assert.EqualError(t, f.AddComment("Sheet1", "A", `{"text":"Comment.","no_legacy_drawing":true}`), newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddCommentsWithoutLegacyDrawing.xlsx")))
+}
+
+func TestAddCommentWithAnchor(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.AddComment("Sheet1", "A1", `{"author":"Excelize: ","text":"Comment.","anchor":{"from":"E2","to":"H8","to_offset_x":10,"to_offset_y":5}}`))
+	assert.NoError(t, f.AddComment("Sheet1", "A2", `{"author":"Excelize: ","text":"Comment."}`))
+	vml := f.VMLDrawing["xl/drawings/vmlDrawing1.vml"]
+	assert.Len(t, vml.Shape, 2)
+	assert.Contains(t, vml.Shape[0].Val, "<x:Anchor>4, 0, 1, 0, 7, 10, 7, 5</x:Anchor>")
+	assert.Contains(t, vml.Shape[1].Val, "<x:Anchor>1, 23, 2, 0, 3, 18, 4, 5</x:Anchor>")
+	// Test add comment with invalid anchor
+	for _, anchor := range []string{
+		`{"from":"E2","to":"A1"}`,
+		`{"from":"E2","to":"H8","from_offset_x":-1}`,
+		`{"from":"E2","to":"H8","to_offset_y":-1}`,
+		`{"from":"E2","to":"E2"}`,
+		`{"from":"E2","to":"E8","from_offset_x":10,"to_offset_x":10}`,
+		`{"from":"E2","to":"H2","from_offset_y":5,"to_offset_y":3}`,
+	} {
+		assert.EqualError(t, f.AddComment("Sheet1", "A3", `{"text":"Comment.","anchor":`+anchor+`}`), ErrParameterInvalid.Error())
+	}
+	assert.NoError(t, f.AddComment("Sheet1", "A4", `{"text":"Comment.","anchor":{"from":"E2","to":"E2","to_offset_x":60,"to_offset_y":20}}`))
+	assert.EqualError(t, f.AddComment("Sheet1", "A3", `{"text":"Comment.","anchor":{"from":"E","to":"H8"}}`), newCellNameToCoordinatesError("E", newInvalidCellNameError("E")).Error())
+	assert.EqualError(t, f.AddComment("Sheet1", "A3", `{"text":"Comment.","anchor":{"from":"E2","to":"H"}}`), newCellNameToCoordinatesError("H", newInvalidCellNameError("H")).Error())
+	assert.Len(t, f.GetComments()["Sheet1"], 3)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddCommentWithAnchor.xlsx")))
+}
+
+func TestAddCommentWithAutoSize(t *testing.T) {
+	f := NewFile()
+	text := strings.Repeat("This is a long review comment. ", 10)
+	assert.NoError(t, f.AddComment("Sheet1", "A1", `{"author":"Excelize: ","text":"`+text+`","auto_size":true,"max_width":200}`))
+	assert.NoError(t, f.AddComment("Sheet1", "A2", `{"author":"Excelize: ","text":"OK","auto_size":true}`))
+	assert.NoError(t, f.AddComment("Sheet1", "A3", `{"author":"Excelize: ","text":"Line 1\nLine 2\nLine 3","auto_size":true}`))
+	// Test the anchor option has higher priority than the auto_size option
+	assert.NoError(t, f.AddComment("Sheet1", "A4", `{"text":"Comment.","auto_size":true,"anchor":{"from":"E2","to":"H8"}}`))
+	vml := f.VMLDrawing["xl/drawings/vmlDrawing1.vml"]
+	assert.Len(t, vml.Shape, 4)
+	// Test the long text is wrapped at the maximum width
+	assert.Contains(t, vml.Shape[0].Style, "width:141pt;height:123pt")
+	assert.Contains(t, vml.Shape[0].Val, "<x:Anchor>1, 15, 0, 2, 4, 11, 8, 6</x:Anchor>")
+	assert.Contains(t, vml.Shape[1].Style, "width:69.75pt;height:18pt")
+	assert.Contains(t, vml.Shape[1].Val, "<x:Anchor>1, 15, 1, 2, 2, 44, 2, 6</x:Anchor>")
+	assert.Contains(t, vml.Shape[2].Style, "width:82.5pt;height:39pt")
+	assert.Contains(t, vml.Shape[3].Style, "width:108pt;height:59.25pt")
+	assert.Contains(t, vml.Shape[3].Val, "<x:Anchor>4, 0, 1, 0, 7, 0, 7, 0</x:Anchor>")
+	// Test the size of the comment box fits the widths of the columns
+	assert.NoError(t, f.SetColWidth("Sheet1", "B", "B", 50))
+	assert.NoError(t, f.AddComment("Sheet1", "A5", `{"author":"Excelize: ","text":"OK","auto_size":true}`))
+	assert.Contains(t, f.VMLDrawing["xl/drawings/vmlDrawing1.vml"].Shape[4].Val, "<x:Anchor>1, 15, 4, 2, 1, 108, 5, 6</x:Anchor>")
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddCommentWithAutoSize.xlsx")))
+	// Test add auto-sized comment with invalid maximum width
+	assert.EqualError(t, f.AddComment("Sheet1", "A6", `{"text":"Comment.","auto_size":true,"max_width":-1}`), ErrParameterInvalid.Error())
+	assert.Len(t, f.GetComments()["Sheet1"], 5)
+	// Test get comment auto size with invalid cell reference
+	_, _, _, err := f.getCommentAutoSize("Sheet1", "A", &formatComment{})
+	assert.EqualError(t, err, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
+}
+
 func TestDecodeVMLDrawingReader(t *testing.T) {
 	f := NewFile()
 	path := "xl/drawings/vmlDrawing1.xml"
@@ -65,3 +149,83 @@ func TestCountComments(t *testing.T) {
 	f.Comments["xl/comments1.xml"] = nil
 	assert.Equal(t, f.countComments(), 1)
 }
+
+func TestThreadedComment(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.AddThreadedComment("Sheet1", "A1", `{"author":"Excelize","text":"Root comment"}`))
+	assert.NoError(t, f.ReplyThreadedComment("Sheet1", "A1", `{"author":"Reviewer","text":"First reply"}`))
+	assert.NoError(t, f.ReplyThreadedComment("Sheet1", "a1", `{"text":"Second reply"}`))
+	assert.NoError(t, f.AddThreadedComment("Sheet1", "B2", `{"author":"Excelize","text":"Another thread"}`))
+	// Test add threaded comment on the cell which already has comment
+	assert.EqualError(t, f.AddThreadedComment("Sheet1", "A1", `{"text":"Text"}`), ErrCommentExists.Error())
+	assert.NoError(t, f.AddComment("Sheet1", "C3", `{"author":"Excelize","text":"Legacy"}`))
+	assert.EqualError(t, f.AddThreadedComment("Sheet1", "C3", `{"text":"Text"}`), ErrCommentExists.Error())
+	// Test reply to the cell without threaded comment
+	assert.EqualError(t, f.ReplyThreadedComment("Sheet1", "C3", `{"text":"Text"}`), ErrThreadedCommentNotExist.Error())
+	assert.EqualError(t, f.ReplyThreadedComment("Sheet1", "D4", `{"text":"Text"}`), ErrThreadedCommentNotExist.Error())
+
+	check := func(f *File) {
+		comments, err := f.GetThreadedComments("Sheet1")
+		assert.NoError(t, err)
+		if !assert.Len(t, comments, 4) {
+			return
+		}
+		assert.Equal(t, "A1", comments[0].Ref)
+		assert.Equal(t, "Excelize", comments[0].Author)
+		assert.Equal(t, "Root comment", comments[0].Text)
+		assert.Empty(t, comments[0].ParentID)
+		assert.Equal(t, comments[0].ID, comments[1].ParentID)
+		assert.Equal(t, "Reviewer", comments[1].Author)
+		assert.Equal(t, "First reply", comments[1].Text)
+		assert.Equal(t, "Author", comments[2].Author)
+		assert.Equal(t, comments[0].ID, comments[2].ParentID)
+		assert.Equal(t, "B2", comments[3].Ref)
+		// Test the legacy comment of the thread
+		legacy := f.GetComments()["Sheet1"]
+		assert.Len(t, legacy, 3)
+		assert.Equal(t, "tc="+comments[0].ID, legacy[0].Author)
+		assert.Equal(t, threadedCommentLegacyText+"Root comment\nReply:\n    First reply\nReply:\n    Second reply", legacy[0].Text)
+	}
+	check(f)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestThreadedComment.xlsx")))
+	f, err := OpenFile(filepath.Join("test", "TestThreadedComment.xlsx"))
+	assert.NoError(t, err)
+	check(f)
+
+	// Test the legacy comment of the thread truncated on the character boundary
+	assert.NoError(t, f.AddThreadedComment("Sheet1", "E5", `{"text":"`+strings.Repeat("中", 20000)+`"}`))
+	assert.NoError(t, f.ReplyThreadedComment("Sheet1", "E5", `{"text":"`+strings.Repeat("文", 20000)+`"}`))
+	legacy := f.GetComments()["Sheet1"]
+	assert.Equal(t, "E5", legacy[len(legacy)-1].Ref)
+	assert.True(t, utf8.ValidString(legacy[len(legacy)-1].Text))
+	assert.Equal(t, 32512, utf8.RuneCountInString(legacy[len(legacy)-1].Text))
+	// Test get threaded comments on the worksheet without threaded comment
+	f.NewSheet("Sheet2")
+	comments, err := f.GetThreadedComments("Sheet2")
+	assert.NoError(t, err)
+	assert.Empty(t, comments)
+	// Test threaded comment on not exists worksheet
+	assert.EqualError(t, f.AddThreadedComment("SheetN", "A1", `{"text":"Text"}`), "sheet SheetN is not exist")
+	assert.EqualError(t, f.ReplyThreadedComment("SheetN", "A1", `{"text":"Text"}`), "sheet SheetN is not exist")
+	_, err = f.GetThreadedComments("SheetN")
+	assert.EqualError(t, err, "sheet SheetN is not exist")
+	// Test threaded comment with invalid arguments
+	assert.EqualError(t, f.AddThreadedComment("Sheet1", "A1", ""), "unexpected end of JSON input")
+	assert.EqualError(t, f.ReplyThreadedComment("Sheet1", "A1", ""), "unexpected end of JSON input")
+	assert.EqualError(t, f.AddThreadedComment("Sheet1", "A", `{"text":"Text"}`), newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
+	assert.EqualError(t, f.ReplyThreadedComment("Sheet1", "A", `{"text":"Text"}`), newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
+	// Test threaded comment with unsupported charset
+	f = NewFile()
+	assert.NoError(t, f.AddThreadedComment("Sheet1", "A1", `{"text":"Text"}`))
+	f.Pkg.Store("xl/threadedComments/threadedComment1.xml", MacintoshCyrillicCharset)
+	assert.EqualError(t, f.AddThreadedComment("Sheet1", "B1", `{"text":"Text"}`), "XML syntax error on line 1: invalid UTF-8")
+	assert.EqualError(t, f.ReplyThreadedComment("Sheet1", "A1", `{"text":"Text"}`), "XML syntax error on line 1: invalid UTF-8")
+	_, err = f.GetThreadedComments("Sheet1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	f = NewFile()
+	assert.NoError(t, f.AddThreadedComment("Sheet1", "A1", `{"text":"Text"}`))
+	f.Pkg.Store("xl/persons/person.xml", MacintoshCyrillicCharset)
+	assert.EqualError(t, f.AddThreadedComment("Sheet1", "B1", `{"text":"Text"}`), "XML syntax error on line 1: invalid UTF-8")
+	_, err = f.GetThreadedComments("Sheet1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+}
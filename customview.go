@@ -0,0 +1,345 @@
+// Copyright 2016 - 2022 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to and
+// read from XLAM / XLSM / XLSX / XLTM / XLTX files. Supports reading and
+// writing spreadsheet documents generated by Microsoft Excel™ 2007 and later.
+// Supports complex components by high compatibility, and provided streaming
+// API for generating or reading data from a worksheet with huge amounts of
+// data. This library needs Go version 1.15 or later.
+
+package excelize
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/mohae/deepcopy"
+)
+
+// CustomViewOptions directly maps the settings of a custom view.
+// IncludePrintSettings specifies whether the print settings (page margins,
+// page setup, header and footer, page breaks, print area and print titles) of
+// the worksheets are captured in the custom view. IncludeHiddenRowCol
+// specifies whether the hidden rows and columns of the worksheets are
+// captured in the custom view.
+type CustomViewOptions struct {
+	IncludePrintSettings bool
+	IncludeHiddenRowCol  bool
+}
+
+// CustomView directly maps the name and settings of a custom view in the
+// workbook.
+type CustomView struct {
+	Name                 string
+	IncludePrintSettings bool
+	IncludeHiddenRowCol  bool
+}
+
+// AddCustomView provides a function to add a custom view by given name and
+// settings. The custom view captures the current display settings, panes,
+// selection, auto filter and visibility of each worksheet in the workbook, and
+// the print settings and hidden rows and columns if they are included in the
+// options. Note that the name of the custom view is case-insensitive and must
+// be unique in the workbook. For example, add a custom view named
+// "Print View" with the print settings and hidden rows and columns:
+//
+//	err := f.AddCustomView("Print View", excelize.CustomViewOptions{
+//	    IncludePrintSettings: true,
+//	    IncludeHiddenRowCol:  true,
+//	})
+func (f *File) AddCustomView(name string, opts CustomViewOptions) error {
+	if name == "" {
+		return ErrParameterRequired
+	}
+	wb := f.workbookReader()
+	if wb.CustomWorkbookViews != nil {
+		for _, view := range wb.CustomWorkbookViews.CustomWorkbookView {
+			if view.Name != nil && strings.EqualFold(*view.Name, name) {
+				return ErrCustomViewDuplicate
+			}
+		}
+	}
+	GUID := newGUID()
+	for idx, sheet := range wb.Sheets.Sheet {
+		ws, err := f.workSheetReader(sheet.Name)
+		if err != nil {
+			if err.Error() == fmt.Sprintf("sheet %s is not a worksheet", trimSheetName(sheet.Name)) {
+				continue
+			}
+			return err
+		}
+		view := newCustomSheetView(ws, GUID, sheet.State, opts)
+		f.addCustomViewDefinedNames(wb, ws, view, idx, sheet.Name, opts)
+		if ws.CustomSheetViews == nil {
+			ws.CustomSheetViews = &xlsxCustomSheetViews{}
+		}
+		ws.CustomSheetViews.CustomSheetView = append(ws.CustomSheetViews.CustomSheetView, view)
+	}
+	windowWidth, windowHeight := 28800, 15000
+	if wb.BookViews != nil && len(wb.BookViews.WorkBookView) > 0 {
+		if bookView := wb.BookViews.WorkBookView[0]; bookView.WindowWidth > 0 && bookView.WindowHeight > 0 {
+			windowWidth, windowHeight = bookView.WindowWidth, bookView.WindowHeight
+		}
+	}
+	if wb.CustomWorkbookViews == nil {
+		wb.CustomWorkbookViews = &xlsxCustomWorkbookViews{}
+	}
+	wb.CustomWorkbookViews.CustomWorkbookView = append(wb.CustomWorkbookViews.CustomWorkbookView, xlsxCustomWorkbookView{
+		ActiveSheetID:        intPtr(f.getActiveSheetID()),
+		GUID:                 stringPtr(GUID),
+		IncludeHiddenRowCol:  boolPtr(opts.IncludeHiddenRowCol),
+		IncludePrintSettings: boolPtr(opts.IncludePrintSettings),
+		Name:                 stringPtr(name),
+		WindowHeight:         intPtr(windowHeight),
+		WindowWidth:          intPtr(windowWidth),
+	})
+	return nil
+}
+
+// GetCustomViews provides a function to get all custom views of the
+// workbook.
+func (f *File) GetCustomViews() []CustomView {
+	var views []CustomView
+	wb := f.workbookReader()
+	if wb.CustomWorkbookViews == nil {
+		return views
+	}
+	for _, view := range wb.CustomWorkbookViews.CustomWorkbookView {
+		customView := CustomView{IncludePrintSettings: true, IncludeHiddenRowCol: true}
+		if view.Name != nil {
+			customView.Name = *view.Name
+		}
+		if view.IncludePrintSettings != nil {
+			customView.IncludePrintSettings = *view.IncludePrintSettings
+		}
+		if view.IncludeHiddenRowCol != nil {
+			customView.IncludeHiddenRowCol = *view.IncludeHiddenRowCol
+		}
+		views = append(views, customView)
+	}
+	return views
+}
+
+// DeleteCustomView provides a function to delete a custom view by given
+// name. The settings of the custom view in each worksheet and the defined
+// names of the custom view will be deleted. For example, delete the custom
+// view named "Print View":
+//
+//	err := f.DeleteCustomView("Print View")
+func (f *File) DeleteCustomView(name string) error {
+	wb := f.workbookReader()
+	var GUID string
+	if wb.CustomWorkbookViews != nil {
+		for idx, view := range wb.CustomWorkbookViews.CustomWorkbookView {
+			if view.Name != nil && strings.EqualFold(*view.Name, name) {
+				if view.GUID != nil {
+					GUID = *view.GUID
+				}
+				views := wb.CustomWorkbookViews.CustomWorkbookView
+				wb.CustomWorkbookViews.CustomWorkbookView = append(views[:idx:idx], views[idx+1:]...)
+				if len(wb.CustomWorkbookViews.CustomWorkbookView) == 0 {
+					wb.CustomWorkbookViews = nil
+				}
+				return f.deleteCustomSheetViews(wb, GUID)
+			}
+		}
+	}
+	return ErrCustomViewNotExist
+}
+
+// deleteCustomSheetViews provides a function to delete the settings of the
+// custom view in each worksheet and the defined names of the custom view by
+// given custom view GUID.
+func (f *File) deleteCustomSheetViews(wb *xlsxWorkbook, GUID string) error {
+	if GUID == "" {
+		return nil
+	}
+	for _, sheet := range wb.Sheets.Sheet {
+		ws, err := f.workSheetReader(sheet.Name)
+		if err != nil {
+			if err.Error() == fmt.Sprintf("sheet %s is not a worksheet", trimSheetName(sheet.Name)) {
+				continue
+			}
+			return err
+		}
+		if ws.CustomSheetViews == nil {
+			continue
+		}
+		var views []*xlsxCustomSheetView
+		for _, view := range ws.CustomSheetViews.CustomSheetView {
+			if !strings.EqualFold(view.GUID, GUID) {
+				views = append(views, view)
+			}
+		}
+		if ws.CustomSheetViews.CustomSheetView = views; len(views) == 0 {
+			ws.CustomSheetViews = nil
+		}
+	}
+	if wb.DefinedNames != nil {
+		prefix := getCustomViewDefinedNamePrefix(GUID)
+		var definedNames []xlsxDefinedName
+		for _, dn := range wb.DefinedNames.DefinedName {
+			if !strings.HasPrefix(strings.ToLower(dn.Name), strings.ToLower(prefix)) {
+				definedNames = append(definedNames, dn)
+			}
+		}
+		wb.DefinedNames.DefinedName = definedNames
+	}
+	return nil
+}
+
+// newCustomSheetView provides a function to create the settings of a custom
+// view for the worksheet by given worksheet, custom view GUID, sheet state
+// and custom view settings.
+func newCustomSheetView(ws *xlsxWorksheet, GUID, state string, opts CustomViewOptions) *xlsxCustomSheetView {
+	view := &xlsxCustomSheetView{GUID: GUID, State: state}
+	if ws.SheetViews != nil && len(ws.SheetViews.SheetView) > 0 {
+		sheetView := ws.SheetViews.SheetView[0]
+		view.Pane = sheetView.Pane
+		if len(sheetView.Selection) > 0 {
+			view.Selection = sheetView.Selection[0]
+		}
+		view.Scale = int(sheetView.ZoomScale)
+		view.ShowFormulas = sheetView.ShowFormulas
+		if sheetView.ShowGridLines != nil && !*sheetView.ShowGridLines {
+			view.ShowGridLines = boolPtr(false)
+		}
+		if sheetView.ShowRowColHeaders != nil && !*sheetView.ShowRowColHeaders {
+			view.ShowRowCol = boolPtr(false)
+		}
+		if sheetView.ShowZeros != nil && !*sheetView.ShowZeros {
+			view.ZeroValues = boolPtr(false)
+		}
+		if sheetView.ShowRuler != nil && !*sheetView.ShowRuler {
+			view.ShowRuler = boolPtr(false)
+		}
+		view.View = sheetView.View
+		view.TopLeftCell = sheetView.TopLeftCell
+	}
+	if ws.AutoFilter != nil {
+		view.AutoFilter = ws.AutoFilter
+		view.Filter, view.ShowAutoFilter = true, true
+	}
+	if opts.IncludePrintSettings {
+		view.RowBreaks, view.ColBreaks = ws.RowBreaks, ws.ColBreaks
+		view.PageMargins, view.PrintOptions = ws.PageMargins, ws.PrintOptions
+		if ws.PageSetUp != nil {
+			pageSetup := *ws.PageSetUp
+			pageSetup.RID = ""
+			view.PageSetup = &pageSetup
+		}
+		view.HeaderFooter = ws.HeaderFooter
+		if ws.SheetPr != nil && ws.SheetPr.PageSetUpPr != nil {
+			view.FitToPage = ws.SheetPr.PageSetUpPr.FitToPage
+		}
+	}
+	return deepcopy.Copy(view).(*xlsxCustomSheetView)
+}
+
+// getCustomViewDefinedNamePrefix provides a function to get the prefix of
+// the defined names of the custom view by given custom view GUID, for
+// example, Z_3F2504E0_4F89_41D3_9A0C_0305E82C3301_.wvu.
+func getCustomViewDefinedNamePrefix(GUID string) string {
+	return "Z_" + strings.NewReplacer("{", "", "}", "", "-", "_").Replace(GUID) + "_.wvu."
+}
+
+// addCustomViewDefinedNames provides a function to add the hidden defined
+// names of the custom view for the worksheet, which contain the print area,
+// print titles, filter range and hidden rows and columns captured in the
+// custom view.
+func (f *File) addCustomViewDefinedNames(wb *xlsxWorkbook, ws *xlsxWorksheet, view *xlsxCustomSheetView, sheetIndex int, sheet string, opts CustomViewOptions) {
+	prefix := getCustomViewDefinedNamePrefix(view.GUID)
+	var definedNames []xlsxDefinedName
+	addDefinedName := func(name, refersTo string) {
+		definedNames = append(definedNames, xlsxDefinedName{
+			Name: prefix + name, Hidden: true, LocalSheetID: intPtr(sheetIndex), Data: refersTo,
+		})
+	}
+	if wb.DefinedNames != nil {
+		for _, dn := range wb.DefinedNames.DefinedName {
+			if dn.LocalSheetID == nil || *dn.LocalSheetID != sheetIndex {
+				continue
+			}
+			switch dn.Name {
+			case "_xlnm._FilterDatabase":
+				if view.AutoFilter != nil {
+					addDefinedName("FilterData", dn.Data)
+				}
+			case "_xlnm.Print_Area":
+				if opts.IncludePrintSettings {
+					view.PrintArea = true
+					addDefinedName("PrintArea", dn.Data)
+				}
+			case "_xlnm.Print_Titles":
+				if opts.IncludePrintSettings {
+					addDefinedName("PrintTitles", dn.Data)
+				}
+			}
+		}
+	}
+	if opts.IncludeHiddenRowCol {
+		sheetRef := "'" + strings.ReplaceAll(sheet, "'", "''") + "'!"
+		if cols := getHiddenColsRef(ws, sheetRef); cols != "" {
+			view.HiddenColumns = true
+			addDefinedName("Cols", cols)
+		}
+		if rows := getHiddenRowsRef(ws, sheetRef); rows != "" {
+			view.HiddenRows = true
+			addDefinedName("Rows", rows)
+		}
+	}
+	if len(definedNames) == 0 {
+		return
+	}
+	if wb.DefinedNames == nil {
+		wb.DefinedNames = &xlsxDefinedNames{}
+	}
+	wb.DefinedNames.DefinedName = append(wb.DefinedNames.DefinedName, definedNames...)
+}
+
+// getHiddenColsRef provides a function to get the references of the hidden
+// columns in the worksheet, such as 'Sheet1'!$B:$C,'Sheet1'!$E:$E.
+func getHiddenColsRef(ws *xlsxWorksheet, sheetRef string) string {
+	var refs []string
+	if ws.Cols == nil {
+		return ""
+	}
+	for _, col := range ws.Cols.Col {
+		if !col.Hidden {
+			continue
+		}
+		minCol, _ := ColumnNumberToName(col.Min)
+		maxCol, _ := ColumnNumberToName(col.Max)
+		refs = append(refs, sheetRef+"$"+minCol+":$"+maxCol)
+	}
+	return strings.Join(refs, ",")
+}
+
+// getHiddenRowsRef provides a function to get the references of the hidden
+// rows in the worksheet, the adjacent hidden rows will be merged, such as
+// 'Sheet1'!$2:$3,'Sheet1'!$5:$5.
+func getHiddenRowsRef(ws *xlsxWorksheet, sheetRef string) string {
+	var refs []string
+	start, end := 0, 0
+	flush := func() {
+		if start > 0 {
+			refs = append(refs, sheetRef+"$"+strconv.Itoa(start)+":$"+strconv.Itoa(end))
+		}
+	}
+	for _, row := range ws.SheetData.Row {
+		if !row.Hidden {
+			continue
+		}
+		if start > 0 && row.R == end+1 {
+			end = row.R
+			continue
+		}
+		flush()
+		start, end = row.R, row.R
+	}
+	flush()
+	return strings.Join(refs, ",")
+}
@@ -0,0 +1,89 @@
+package excelize
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCustomView(t *testing.T) {
+	f := NewFile()
+	f.NewSheet("Sheet2")
+	assert.NoError(t, f.SetRowVisible("Sheet1", 2, false))
+	assert.NoError(t, f.SetRowVisible("Sheet1", 3, false))
+	assert.NoError(t, f.SetRowVisible("Sheet1", 5, false))
+	assert.NoError(t, f.SetColVisible("Sheet1", "B:C", false))
+	assert.NoError(t, f.AutoFilter("Sheet1", "A1", "C10", ""))
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "_xlnm.Print_Area", RefersTo: "Sheet1!$A$1:$C$10", Scope: "Sheet1"}))
+	assert.NoError(t, f.SetPageMargins("Sheet1", PageMarginTop(1.5)))
+	assert.NoError(t, f.SetSheetViewOptions("Sheet1", 0, ShowGridLines(false), ZoomScale(80)))
+
+	assert.NoError(t, f.AddCustomView("Print View", CustomViewOptions{IncludePrintSettings: true, IncludeHiddenRowCol: true}))
+	assert.NoError(t, f.AddCustomView("Normal View", CustomViewOptions{}))
+	assert.EqualError(t, f.AddCustomView("print view", CustomViewOptions{}), ErrCustomViewDuplicate.Error())
+	assert.EqualError(t, f.AddCustomView("", CustomViewOptions{}), ErrParameterRequired.Error())
+
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, ws.CustomSheetViews.CustomSheetView, 2)
+	view := ws.CustomSheetViews.CustomSheetView[0]
+	assert.Equal(t, 80, view.Scale)
+	assert.Equal(t, boolPtr(false), view.ShowGridLines)
+	assert.True(t, view.Filter)
+	assert.True(t, view.PrintArea)
+	assert.True(t, view.HiddenRows)
+	assert.True(t, view.HiddenColumns)
+	assert.Equal(t, 1.5, view.PageMargins.Top)
+	assert.False(t, ws.CustomSheetViews.CustomSheetView[1].HiddenRows)
+	assert.Nil(t, ws.CustomSheetViews.CustomSheetView[1].PageMargins)
+
+	prefix := getCustomViewDefinedNamePrefix(view.GUID)
+	definedNames := map[string]string{}
+	for _, dn := range f.GetDefinedName() {
+		definedNames[dn.Name] = dn.RefersTo
+	}
+	assert.Equal(t, "'Sheet1'!$2:$3,'Sheet1'!$5:$5", definedNames[prefix+"Rows"])
+	assert.Equal(t, "'Sheet1'!$B:$C", definedNames[prefix+"Cols"])
+	assert.Equal(t, "Sheet1!$A$1:$C$10", definedNames[prefix+"PrintArea"])
+	assert.Equal(t, "'Sheet1'!$A$1:$C$10", definedNames[prefix+"FilterData"])
+
+	path := filepath.Join("test", "TestCustomView.xlsx")
+	assert.NoError(t, f.SaveAs(path))
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, []CustomView{
+		{Name: "Print View", IncludePrintSettings: true, IncludeHiddenRowCol: true},
+		{Name: "Normal View"},
+	}, f.GetCustomViews())
+	ws, err = f.workSheetReader("Sheet2")
+	assert.NoError(t, err)
+	assert.Len(t, ws.CustomSheetViews.CustomSheetView, 2)
+
+	assert.NoError(t, f.DeleteCustomView("PRINT VIEW"))
+	assert.EqualError(t, f.DeleteCustomView("Print View"), ErrCustomViewNotExist.Error())
+	assert.Equal(t, []CustomView{{Name: "Normal View"}}, f.GetCustomViews())
+	for _, dn := range f.GetDefinedName() {
+		assert.NotContains(t, dn.Name, prefix)
+	}
+	ws, err = f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, ws.CustomSheetViews.CustomSheetView, 1)
+	assert.NoError(t, f.DeleteCustomView("Normal View"))
+	assert.Nil(t, f.GetCustomViews())
+	assert.Nil(t, ws.CustomSheetViews)
+	assert.NoError(t, f.Close())
+
+	// Test custom views with chart sheet and invalid worksheet
+	f = NewFile()
+	assert.NoError(t, f.AddChartSheet("Chart1", `{"type":"col","series":[{"name":"Sheet1!$A$1","categories":"Sheet1!$A$2","values":"Sheet1!$B$2"}]}`))
+	assert.NoError(t, f.AddCustomView("View", CustomViewOptions{}))
+	assert.Len(t, f.GetCustomViews(), 1)
+	assert.NoError(t, f.DeleteCustomView("View"))
+	f.Pkg.Store("xl/worksheets/sheet1.xml", MacintoshCyrillicCharset)
+	f.Sheet.Delete("xl/worksheets/sheet1.xml")
+	f.checked = nil
+	assert.EqualError(t, f.AddCustomView("View", CustomViewOptions{}), "xml decode error: XML syntax error on line 1: invalid UTF-8")
+}
@@ -15,7 +15,10 @@ import (
 	"fmt"
 	"math"
 	"strings"
+	"time"
 	"unicode/utf16"
+
+	"github.com/mohae/deepcopy"
 )
 
 // DataValidationType defined the type of data validation.
@@ -78,6 +81,14 @@ var formulaEscaper = strings.NewReplacer(
 	`"`, `""`,
 )
 
+// sqrefEscaper escapes the XML special characters of the reference of the
+// drop list source, the spill range operator "#" will be kept as is.
+var sqrefEscaper = strings.NewReplacer(
+	`&`, `&amp;`,
+	`<`, `&lt;`,
+	`>`, `&gt;`,
+)
+
 // NewDataValidation return data validation struct.
 func NewDataValidation(allowBlank bool) *DataValidation {
 	return &DataValidation{
@@ -159,6 +170,69 @@ func (dd *DataValidation) SetRange(f1, f2 interface{}, t DataValidationType, o D
 	return nil
 }
 
+// SetDateRange provides a function to set data validation that allows any
+// date within the range by given minimum and maximum date and whether the
+// workbook uses the 1904 date system. The dates will be converted to the
+// serial numbers of the date system of the workbook, and the validation
+// operator is "between" by default, change the Operator field to use other
+// operator, such as "notBetween". For example, restrict the entry dates of
+// the range Sheet1!A1:A10 in the fiscal year:
+//
+//	var date1904 excelize.Date1904
+//	if err := f.GetWorkbookPrOptions(&date1904); err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	dvRange := excelize.NewDataValidation(true)
+//	dvRange.Sqref = "A1:A10"
+//	err := dvRange.SetDateRange(
+//	    time.Date(2022, time.April, 1, 0, 0, 0, 0, time.UTC),
+//	    time.Date(2023, time.March, 31, 0, 0, 0, 0, time.UTC),
+//	    bool(date1904),
+//	)
+//	f.AddDataValidation("Sheet1", dvRange)
+func (dd *DataValidation) SetDateRange(min, max time.Time, date1904 bool) error {
+	minTime := excelMinTime1900
+	if date1904 {
+		minTime = excel1904Epoc
+	}
+	if min.Before(minTime) || max.Before(minTime) {
+		return ErrDataValidationRange
+	}
+	if max.Before(min) {
+		return ErrParameterInvalid
+	}
+	formula1, _ := timeToExcelTime(min, date1904)
+	formula2, _ := timeToExcelTime(max, date1904)
+	return dd.SetRange(formula1, formula2, DataValidationTypeDate, DataValidationOperatorBetween)
+}
+
+// SetTimeRange provides a function to set data validation that allows any
+// time within the range by given minimum and maximum time, only the time of
+// day part of the given values will be used. The validation operator is
+// "between" by default, change the Operator field to use other operator,
+// such as "notBetween". For example, restrict the entry times of the range
+// Sheet1!B1:B10 in working hours:
+//
+//	dvRange := excelize.NewDataValidation(true)
+//	dvRange.Sqref = "B1:B10"
+//	err := dvRange.SetTimeRange(
+//	    time.Date(0, 1, 1, 9, 0, 0, 0, time.UTC),
+//	    time.Date(0, 1, 1, 17, 30, 0, 0, time.UTC),
+//	)
+//	f.AddDataValidation("Sheet1", dvRange)
+func (dd *DataValidation) SetTimeRange(min, max time.Time) error {
+	timeOfDay := func(t time.Time) float64 {
+		hour, minute, sec := t.Clock()
+		return (float64(hour*3600+minute*60+sec) + float64(t.Nanosecond())/1e9) / 86400
+	}
+	formula1, formula2 := timeOfDay(min), timeOfDay(max)
+	if formula2 < formula1 {
+		return ErrParameterInvalid
+	}
+	return dd.SetRange(formula1, formula2, DataValidationTypeTime, DataValidationOperatorBetween)
+}
+
 // SetSqrefDropList provides set data validation on a range with source
 // reference range of the worksheet by given data validation object and
 // worksheet name. The data validation object can be created by
@@ -170,8 +244,15 @@ func (dd *DataValidation) SetRange(f1, f2 interface{}, t DataValidationType, o D
 //	dvRange.Sqref = "A7:B8"
 //	dvRange.SetSqrefDropList("$E$1:$E$3")
 //	f.AddDataValidation("Sheet1", dvRange)
+//
+// The source can also be the spill range reference of a dynamic array
+// formula, which ends with the "#" operator, so that the dropdown grows with
+// the result of the formula. For example, use the spilled result of the
+// formula in Sheet1!G1 as the source:
+//
+//	dvRange.SetSqrefDropList("Sheet1!$G$1#")
 func (dd *DataValidation) SetSqrefDropList(sqref string) {
-	dd.Formula1 = fmt.Sprintf("<formula1>%s</formula1>", sqref)
+	dd.Formula1 = fmt.Sprintf("<formula1>%s</formula1>", sqrefEscaper.Replace(strings.TrimPrefix(sqref, "=")))
 	dd.Type = convDataValidationType(typeList)
 }
 
@@ -259,9 +340,44 @@ func (f *File) AddDataValidation(sheet string, dv *DataValidation) error {
 	return err
 }
 
+// GetDataValidations provides a function to get the data validations of the
+// worksheet by given worksheet name. The formulas of the data validations are
+// returned as they are stored in the worksheet, include the spill range
+// references. The returned data validations are copies, modifying them will
+// not change the worksheet. For example, get the data validations on Sheet1:
+//
+//	dvs, err := f.GetDataValidations("Sheet1")
+func (f *File) GetDataValidations(sheet string) ([]*DataValidation, error) {
+	ws, err := f.workSheetReader(sheet)
+	if err != nil || ws.DataValidations == nil {
+		return nil, err
+	}
+	ws.Lock()
+	defer ws.Unlock()
+	dvs := make([]*DataValidation, 0, len(ws.DataValidations.DataValidation))
+	for _, dv := range ws.DataValidations.DataValidation {
+		dvs = append(dvs, deepcopy.Copy(dv).(*DataValidation))
+	}
+	return dvs, err
+}
+
 // DeleteDataValidation delete data validation by given worksheet name and
-// reference sequence. All data validations in the worksheet will be deleted
-// if not specify reference sequence parameter.
+// reference sequence. The data validations which intersect with the given
+// cell ranges will be removed from the cells in the ranges, and the rest
+// cells of the partially covered data validations keep the rules. All data
+// validations in the worksheet will be deleted if not specify reference
+// sequence parameter. For example, replace the drop list on Sheet1!A2:A10
+// with a new one:
+//
+//	err := f.DeleteDataValidation("Sheet1", "A2:A10")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	dv := excelize.NewDataValidation(true)
+//	dv.Sqref = "A2:A10"
+//	dv.SetDropList([]string{"Low", "Medium", "High"})
+//	err = f.AddDataValidation("Sheet1", dv)
 func (f *File) DeleteDataValidation(sheet string, sqref ...string) error {
 	ws, err := f.workSheetReader(sheet)
 	if err != nil {
@@ -274,27 +390,30 @@ func (f *File) DeleteDataValidation(sheet string, sqref ...string) error {
 		ws.DataValidations = nil
 		return nil
 	}
-	delCells, err := f.flatSqref(sqref[0])
+	delRanges, err := flatSqref(strings.Join(sqref, " "))
 	if err != nil {
 		return err
 	}
 	dv := ws.DataValidations
 	for i := 0; i < len(dv.DataValidation); i++ {
-		var applySqref []string
-		colCells, err := f.flatSqref(dv.DataValidation[i].Sqref)
+		ranges, err := flatSqref(dv.DataValidation[i].Sqref)
 		if err != nil {
 			return err
 		}
-		for col, cells := range delCells {
-			for _, cell := range cells {
-				idx := inCoordinates(colCells[col], cell)
-				if idx != -1 {
-					colCells[col] = append(colCells[col][:idx], colCells[col][idx+1:]...)
-				}
+		for _, delRange := range delRanges {
+			var rest [][]int
+			for _, rng := range ranges {
+				rest = append(rest, subtractCoordinates(rng, delRange)...)
 			}
+			ranges = rest
 		}
-		for _, col := range colCells {
-			applySqref = append(applySqref, f.squashSqref(col)...)
+		var applySqref []string
+		for _, rng := range ranges {
+			ref, _ := f.coordinatesToAreaRef(rng)
+			if rng[0] == rng[2] && rng[1] == rng[3] {
+				ref, _ = CoordinatesToCellName(rng[0], rng[1])
+			}
+			applySqref = append(applySqref, ref)
 		}
 		dv.DataValidation[i].Sqref = strings.Join(applySqref, " ")
 		if len(applySqref) == 0 {
@@ -309,31 +428,50 @@ func (f *File) DeleteDataValidation(sheet string, sqref ...string) error {
 	return nil
 }
 
-// squashSqref generates cell reference sequence by given cells coordinates list.
-func (f *File) squashSqref(cells [][]int) []string {
-	if len(cells) == 1 {
-		cell, _ := CoordinatesToCellName(cells[0][0], cells[0][1])
-		return []string{cell}
-	} else if len(cells) == 0 {
-		return []string{}
-	}
-	var res []string
-	l, r := 0, 0
-	for i := 1; i < len(cells); i++ {
-		if cells[i][0] == cells[r][0] && cells[i][1]-cells[r][1] > 1 {
-			curr, _ := f.coordinatesToAreaRef(append(cells[l], cells[r]...))
-			if l == r {
-				curr, _ = CoordinatesToCellName(cells[l][0], cells[l][1])
-			}
-			res = append(res, curr)
-			l, r = i, i
-		} else {
-			r++
+// flatSqref provides a function to get the sorted coordinates of the cell
+// ranges by given reference sequence.
+func flatSqref(sqref string) ([][]int, error) {
+	var ranges [][]int
+	for _, ref := range strings.Fields(sqref) {
+		if !strings.Contains(ref, ":") {
+			ref += ":" + ref
+		}
+		coordinates, err := areaRefToCoordinates(ref)
+		if err != nil {
+			return ranges, err
 		}
+		_ = sortCoordinates(coordinates)
+		ranges = append(ranges, coordinates)
+	}
+	return ranges, nil
+}
+
+// subtractCoordinates provides a function to remove the cell range b from the
+// cell range a, and returns the rest parts of a as up to four cell ranges:
+// the rows above and below b, and the columns left and right of b.
+func subtractCoordinates(a, b []int) [][]int {
+	if b[0] > a[2] || b[2] < a[0] || b[1] > a[3] || b[3] < a[1] {
+		return [][]int{a}
+	}
+	var rest [][]int
+	if a[1] < b[1] {
+		rest = append(rest, []int{a[0], a[1], a[2], b[1] - 1})
+	}
+	if b[3] < a[3] {
+		rest = append(rest, []int{a[0], b[3] + 1, a[2], a[3]})
+	}
+	y1, y2 := a[1], a[3]
+	if b[1] > y1 {
+		y1 = b[1]
+	}
+	if b[3] < y2 {
+		y2 = b[3]
+	}
+	if a[0] < b[0] {
+		rest = append(rest, []int{a[0], y1, b[0] - 1, y2})
 	}
-	curr, _ := f.coordinatesToAreaRef(append(cells[l], cells[r]...))
-	if l == r {
-		curr, _ = CoordinatesToCellName(cells[l][0], cells[l][1])
+	if b[2] < a[2] {
+		rest = append(rest, []int{b[2] + 1, y1, a[2], y2})
 	}
-	return append(res, curr)
+	return rest
 }
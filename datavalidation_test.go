@@ -16,6 +16,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -130,6 +131,91 @@ func TestDataValidationError(t *testing.T) {
 	assert.EqualError(t, f.AddDataValidation("SheetN", nil), "sheet SheetN is not exist")
 }
 
+func TestDataValidationSpillRange(t *testing.T) {
+	f := NewFile()
+	dvRange := NewDataValidation(true)
+	dvRange.Sqref = "A1:A2"
+	dvRange.SetSqrefDropList("=Sheet1!$C$1#")
+	assert.Equal(t, "<formula1>Sheet1!$C$1#</formula1>", dvRange.Formula1)
+	assert.NoError(t, f.AddDataValidation("Sheet1", dvRange))
+	dvRange = NewDataValidation(true)
+	dvRange.Sqref = "B1"
+	dvRange.SetSqrefDropList("'R&D'!$A$1#")
+	assert.Equal(t, "<formula1>'R&amp;D'!$A$1#</formula1>", dvRange.Formula1)
+	assert.NoError(t, f.AddDataValidation("Sheet1", dvRange))
+	buf, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+
+	f, err = OpenReader(buf)
+	assert.NoError(t, err)
+	dvs, err := f.GetDataValidations("Sheet1")
+	assert.NoError(t, err)
+	if assert.Len(t, dvs, 2) {
+		assert.Equal(t, "A1:A2", dvs[0].Sqref)
+		assert.Equal(t, "<formula1>Sheet1!$C$1#</formula1>", dvs[0].Formula1)
+		assert.Equal(t, "<formula1>'R&amp;D'!$A$1#</formula1>", dvs[1].Formula1)
+		// Test modify the returned data validation doesn't change the worksheet
+		dvs[0].Sqref = "C1"
+		dvs, err = f.GetDataValidations("Sheet1")
+		assert.NoError(t, err)
+		assert.Equal(t, "A1:A2", dvs[0].Sqref)
+	}
+	// Test get data validations on worksheet without data validation.
+	f.NewSheet("Sheet2")
+	dvs, err = f.GetDataValidations("Sheet2")
+	assert.NoError(t, err)
+	assert.Nil(t, dvs)
+	// Test get data validations on not exists worksheet.
+	_, err = f.GetDataValidations("SheetN")
+	assert.EqualError(t, err, "sheet SheetN is not exist")
+}
+
+func TestDataValidationDateTimeRange(t *testing.T) {
+	f := NewFile()
+	dvRange := NewDataValidation(true)
+	dvRange.Sqref = "A1:A10"
+	assert.NoError(t, dvRange.SetDateRange(
+		time.Date(2022, time.April, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2023, time.March, 31, 0, 0, 0, 0, time.UTC), false,
+	))
+	assert.Equal(t, "date", dvRange.Type)
+	assert.Equal(t, "between", dvRange.Operator)
+	assert.Equal(t, "<formula1>44652</formula1>", dvRange.Formula1)
+	assert.Equal(t, "<formula2>45016</formula2>", dvRange.Formula2)
+	dvRange.Operator = "notBetween"
+	assert.NoError(t, f.AddDataValidation("Sheet1", dvRange))
+	// Test set date range in the 1904 date system
+	dvRange = NewDataValidation(true)
+	assert.NoError(t, dvRange.SetDateRange(
+		time.Date(2022, time.April, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2023, time.March, 31, 0, 0, 0, 0, time.UTC), true,
+	))
+	assert.Equal(t, "<formula1>43190</formula1>", dvRange.Formula1)
+	assert.Equal(t, "<formula2>43554</formula2>", dvRange.Formula2)
+
+	dvRange = NewDataValidation(true)
+	dvRange.Sqref = "B1:B10"
+	assert.NoError(t, dvRange.SetTimeRange(
+		time.Date(2022, time.April, 1, 9, 0, 0, 0, time.UTC),
+		time.Date(0, 1, 1, 18, 0, 0, 0, time.UTC),
+	))
+	assert.Equal(t, "time", dvRange.Type)
+	assert.Equal(t, "between", dvRange.Operator)
+	assert.Equal(t, "<formula1>0.375</formula1>", dvRange.Formula1)
+	assert.Equal(t, "<formula2>0.75</formula2>", dvRange.Formula2)
+	assert.NoError(t, f.AddDataValidation("Sheet1", dvRange))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestDataValidationDateTimeRange.xlsx")))
+
+	// Test set date and time range with invalid parameters
+	assert.EqualError(t, dvRange.SetDateRange(time.Date(1899, time.January, 1, 0, 0, 0, 0, time.UTC), time.Now(), false), ErrDataValidationRange.Error())
+	assert.EqualError(t, dvRange.SetDateRange(time.Date(1903, time.December, 31, 0, 0, 0, 0, time.UTC), time.Now(), true), ErrDataValidationRange.Error())
+	assert.EqualError(t, dvRange.SetDateRange(time.Now(), time.Now().AddDate(-1, 0, 0), false), ErrParameterInvalid.Error())
+	assert.EqualError(t, dvRange.SetTimeRange(
+		time.Date(0, 1, 1, 18, 0, 0, 0, time.UTC),
+		time.Date(0, 1, 1, 9, 0, 0, 0, time.UTC),
+	), ErrParameterInvalid.Error())
+}
+
 func TestDeleteDataValidation(t *testing.T) {
 	f := NewFile()
 	assert.NoError(t, f.DeleteDataValidation("Sheet1", "A1:B2"))
@@ -156,7 +242,37 @@ func TestDeleteDataValidation(t *testing.T) {
 	dvRange.SetInput("input title", "input body")
 	assert.NoError(t, f.AddDataValidation("Sheet1", dvRange))
 	assert.NoError(t, f.DeleteDataValidation("Sheet1", "D3"))
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	getSqrefs := func() (sqrefs []string) {
+		if dvs := ws.(*xlsxWorksheet).DataValidations; dvs != nil {
+			for _, dv := range dvs.DataValidation {
+				sqrefs = append(sqrefs, dv.Sqref)
+			}
+		}
+		return
+	}
+	assert.Equal(t, []string{"C2:C3 C5", "D2 D4"}, getSqrefs())
 
+	// Test delete data validation which partially covered by the ranges
+	assert.NoError(t, f.DeleteDataValidation("Sheet1"))
+	dvRange.Sqref = "B2:D4"
+	assert.NoError(t, f.AddDataValidation("Sheet1", dvRange))
+	assert.NoError(t, f.DeleteDataValidation("Sheet1", "C3"))
+	assert.Equal(t, []string{"B2:D2 B4:D4 B3 D3"}, getSqrefs())
+	assert.NoError(t, f.DeleteDataValidation("Sheet1", "A1:B3", "D4:E5"))
+	assert.Equal(t, []string{"C2:D2 B4:C4 D3"}, getSqrefs())
+	// Test delete data validation on the large cell range
+	dvRange = NewDataValidation(true)
+	dvRange.Sqref = "F1:F1048576"
+	assert.NoError(t, f.AddDataValidation("Sheet1", dvRange))
+	assert.NoError(t, f.DeleteDataValidation("Sheet1", "F2:F1048575"))
+	assert.Equal(t, []string{"C2:D2 B4:C4 D3", "F1 F1048576"}, getSqrefs())
+	assert.NoError(t, f.DeleteDataValidation("Sheet1", "A1:F1048576"))
+	assert.Nil(t, ws.(*xlsxWorksheet).DataValidations)
+
+	dvRange.Sqref = "C2:C3 C5"
+	assert.NoError(t, f.AddDataValidation("Sheet1", dvRange))
 	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestDeleteDataValidation.xlsx")))
 
 	dvRange.Sqref = "A"
@@ -164,7 +280,7 @@ func TestDeleteDataValidation(t *testing.T) {
 	assert.EqualError(t, f.DeleteDataValidation("Sheet1", "A1"), newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
 
 	assert.EqualError(t, f.DeleteDataValidation("Sheet1", "A1:A"), newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
-	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	ws, ok = f.Sheet.Load("xl/worksheets/sheet1.xml")
 	assert.True(t, ok)
 	ws.(*xlsxWorksheet).DataValidations.DataValidation[0].Sqref = "A1:A"
 	assert.EqualError(t, f.DeleteDataValidation("Sheet1", "A1:B2"), newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
@@ -53,6 +53,11 @@ import (
 //	                   | The content of this element shall be of the form XX.YYYY where X and Y
 //	                   | represent numerical values, or the document shall be considered
 //	                   | non-conformant.
+//	                   |
+//	 FileVersion       | Specifies the application name, the last edited version, the lowest
+//	                   | edited version and the build number of the application which accessed
+//	                   | the workbook. The original file version of the workbook will be
+//	                   | preserved if this property is nil.
 //
 // For example:
 //
@@ -64,6 +69,12 @@ import (
 //	    LinksUpToDate:     true,
 //	    HyperlinksChanged: true,
 //	    AppVersion:        "16.0000",
+//	    FileVersion: &excelize.FileVersion{
+//	        AppName:      "xl",
+//	        LastEdited:   "7",
+//	        LowestEdited: "7",
+//	        RupBuild:     "22228",
+//	    },
 //	})
 func (f *File) SetAppProps(appProperties *AppProperties) (err error) {
 	var (
@@ -95,10 +106,19 @@ func (f *File) SetAppProps(appProperties *AppProperties) (err error) {
 	app.Vt = NameSpaceDocumentPropertiesVariantTypes.Value
 	output, err = xml.Marshal(app)
 	f.saveFileList(defaultXMLPathDocPropsApp, output)
+	if fileVersion := appProperties.FileVersion; fileVersion != nil {
+		wb := f.workbookReader()
+		if wb.FileVersion == nil {
+			wb.FileVersion = new(xlsxFileVersion)
+		}
+		wb.FileVersion.AppName, wb.FileVersion.LastEdited = fileVersion.AppName, fileVersion.LastEdited
+		wb.FileVersion.LowestEdited, wb.FileVersion.RupBuild = fileVersion.LowestEdited, fileVersion.RupBuild
+	}
 	return
 }
 
-// GetAppProps provides a function to get document application properties.
+// GetAppProps provides a function to get document application properties,
+// including the file version of the workbook.
 func (f *File) GetAppProps() (ret *AppProperties, err error) {
 	app := new(xlsxProperties)
 	if err = f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(f.readXML(defaultXMLPathDocPropsApp)))).
@@ -115,6 +135,14 @@ func (f *File) GetAppProps() (ret *AppProperties, err error) {
 		HyperlinksChanged: app.HyperlinksChanged,
 		AppVersion:        app.AppVersion,
 	}, nil
+	if wb := f.workbookReader(); wb.FileVersion != nil {
+		ret.FileVersion = &FileVersion{
+			AppName:      wb.FileVersion.AppName,
+			LastEdited:   wb.FileVersion.LastEdited,
+			LowestEdited: wb.FileVersion.LowestEdited,
+			RupBuild:     wb.FileVersion.RupBuild,
+		}
+	}
 	return
 }
 
@@ -35,6 +35,9 @@ func TestSetAppProps(t *testing.T) {
 		AppVersion:        "16.0000",
 	}))
 	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetAppProps.xlsx")))
+	props, err := f.GetAppProps()
+	assert.NoError(t, err)
+	assert.Equal(t, "16.0000", props.AppVersion)
 	f.Pkg.Store(defaultXMLPathDocPropsApp, nil)
 	assert.NoError(t, f.SetAppProps(&AppProperties{}))
 	assert.NoError(t, f.Close())
@@ -45,6 +48,48 @@ func TestSetAppProps(t *testing.T) {
 	assert.EqualError(t, f.SetAppProps(&AppProperties{}), "xml decode error: XML syntax error on line 1: invalid UTF-8")
 }
 
+func TestSetAppPropsFileVersion(t *testing.T) {
+	f := NewFile()
+	wb := f.workbookReader()
+	wb.FileVersion = &xlsxFileVersion{AppName: "xl", CodeName: "{00000000-0000-0000-0000-000000000000}", LastEdited: "5", LowestEdited: "4", RupBuild: "9303"}
+	path := filepath.Join("test", "TestSetAppPropsFileVersion.xlsx")
+	assert.NoError(t, f.SaveAs(path))
+	assert.NoError(t, f.Close())
+
+	// Test the original file version will be preserved on round-trip
+	f, err := OpenFile(path)
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetAppProps(&AppProperties{Application: "Microsoft Excel"}))
+	props, err := f.GetAppProps()
+	assert.NoError(t, err)
+	assert.Equal(t, &FileVersion{AppName: "xl", LastEdited: "5", LowestEdited: "4", RupBuild: "9303"}, props.FileVersion)
+
+	// Test override the file version
+	fileVersion := &FileVersion{AppName: "xl", LastEdited: "7", LowestEdited: "7", RupBuild: "22228"}
+	assert.NoError(t, f.SetAppProps(&AppProperties{Application: "Microsoft Excel", FileVersion: fileVersion}))
+	assert.NoError(t, f.SaveAs(path))
+	assert.NoError(t, f.Close())
+	f, err = OpenFile(path)
+	assert.NoError(t, err)
+	props, err = f.GetAppProps()
+	assert.NoError(t, err)
+	assert.Equal(t, "Microsoft Excel", props.Application)
+	assert.Equal(t, fileVersion, props.FileVersion)
+	assert.Equal(t, "{00000000-0000-0000-0000-000000000000}", f.workbookReader().FileVersion.CodeName)
+	assert.NoError(t, f.Close())
+
+	// Test set file version for the workbook without file version
+	f = NewFile()
+	f.workbookReader().FileVersion = nil
+	props, err = f.GetAppProps()
+	assert.NoError(t, err)
+	assert.Nil(t, props.FileVersion)
+	assert.NoError(t, f.SetAppProps(&AppProperties{FileVersion: fileVersion}))
+	props, err = f.GetAppProps()
+	assert.NoError(t, err)
+	assert.Equal(t, fileVersion, props.FileVersion)
+}
+
 func TestGetAppProps(t *testing.T) {
 	f, err := OpenFile(filepath.Join("test", "Book1.xlsx"))
 	if !assert.NoError(t, err) {
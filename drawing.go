@@ -17,7 +17,9 @@ import (
 	"fmt"
 	"io"
 	"log"
+	"math"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
 )
@@ -61,7 +63,7 @@ func (f *File) addChart(formatSet *formatChart, comboCharts []*formatChart) {
 	count := f.countCharts()
 	xlsxChartSpace := xlsxChartSpace{
 		XMLNSa:         NameSpaceDrawingML.Value,
-		Date1904:       &attrValBool{Val: boolPtr(false)},
+		Date1904:       &attrValBool{Val: boolPtr(f.date1904())},
 		Lang:           &attrValString{Val: stringPtr("en-US")},
 		RoundedCorners: &attrValBool{Val: boolPtr(false)},
 		Chart: cChart{
@@ -248,16 +250,30 @@ func (f *File) addChart(formatSet *formatChart, comboCharts []*formatChart) {
 			if field.IsNil() {
 				continue
 			}
+			if axs, ok := field.Interface().([]*cAxs); ok {
+				target := immutable.FieldByName(mutable.Type().Field(i).Name)
+				field = reflect.ValueOf(addPlotAreaAxes(target.Interface().([]*cAxs), axs))
+			}
 			immutable.FieldByName(mutable.Type().Field(i).Name).Set(field)
 		}
 	}
-	addChart(xlsxChartSpace.Chart.PlotArea, plotAreaFunc[formatSet.Type](formatSet))
+	plotAreas := []*cPlotArea{plotAreaFunc[formatSet.Type](formatSet)}
 	order := len(formatSet.Series)
 	for idx := range comboCharts {
 		comboCharts[idx].order = order
-		addChart(xlsxChartSpace.Chart.PlotArea, plotAreaFunc[comboCharts[idx].Type](comboCharts[idx]))
+		plotAreas = append(plotAreas, plotAreaFunc[comboCharts[idx].Type](comboCharts[idx]))
 		order += len(comboCharts[idx].Series)
 	}
+	var hasCatAx bool
+	for _, plotArea := range plotAreas {
+		hasCatAx = hasCatAx || plotArea.CatAx != nil
+	}
+	for _, plotArea := range plotAreas {
+		if hasCatAx {
+			drawPlotAreaSecondaryAxes(plotArea)
+		}
+		addChart(xlsxChartSpace.Chart.PlotArea, plotArea)
+	}
 	chart, _ := xml.Marshal(xlsxChartSpace)
 	media := "xl/charts/chart" + strconv.Itoa(count+1) + ".xml"
 	f.saveFileList(media, chart)
@@ -297,6 +313,11 @@ func (f *File) drawBaseChart(formatSet *formatChart) *cPlotArea {
 	}
 	catAx := f.drawPlotAreaCatAx(formatSet)
 	valAx := f.drawPlotAreaValAx(formatSet)
+	bubble := c
+	bubble.AxID = []*attrValInt{
+		{Val: intPtr(754001153)},
+		{Val: intPtr(753999904)},
+	}
 	charts := map[string]*cPlotArea{
 		"area": {
 			AreaChart: &c,
@@ -499,14 +520,12 @@ func (f *File) drawBaseChart(formatSet *formatChart) *cPlotArea {
 			ValAx:      valAx,
 		},
 		"bubble": {
-			BubbleChart: &c,
-			CatAx:       catAx,
-			ValAx:       valAx,
+			BubbleChart: &bubble,
+			ValAx:       f.drawPlotAreaXYValAx(formatSet),
 		},
 		"bubble3D": {
-			BubbleChart: &c,
-			CatAx:       catAx,
-			ValAx:       valAx,
+			BubbleChart: &bubble,
+			ValAx:       f.drawPlotAreaXYValAx(formatSet),
 		},
 	}
 	return charts[formatSet.Type]
@@ -525,8 +544,9 @@ func (f *File) drawDoughnutChart(formatSet *formatChart) *cPlotArea {
 			VaryColors: &attrValBool{
 				Val: boolPtr(formatSet.VaryColors),
 			},
-			Ser:      f.drawChartSeries(formatSet),
-			HoleSize: &attrValInt{Val: intPtr(holeSize)},
+			Ser:           f.drawChartSeries(formatSet),
+			FirstSliceAng: f.drawChartFirstSliceAng(formatSet),
+			HoleSize:      &attrValInt{Val: intPtr(holeSize)},
 		},
 	}
 }
@@ -565,7 +585,8 @@ func (f *File) drawPieChart(formatSet *formatChart) *cPlotArea {
 			VaryColors: &attrValBool{
 				Val: boolPtr(formatSet.VaryColors),
 			},
-			Ser: f.drawChartSeries(formatSet),
+			Ser:           f.drawChartSeries(formatSet),
+			FirstSliceAng: f.drawChartFirstSliceAng(formatSet),
 		},
 	}
 }
@@ -654,12 +675,11 @@ func (f *File) drawScatterChart(formatSet *formatChart) *cPlotArea {
 			Ser:   f.drawChartSeries(formatSet),
 			DLbls: f.drawChartDLbls(formatSet),
 			AxID: []*attrValInt{
-				{Val: intPtr(754001152)},
+				{Val: intPtr(754001153)},
 				{Val: intPtr(753999904)},
 			},
 		},
-		CatAx: f.drawPlotAreaCatAx(formatSet),
-		ValAx: f.drawPlotAreaValAx(formatSet),
+		ValAx: f.drawPlotAreaXYValAx(formatSet),
 	}
 }
 
@@ -753,10 +773,13 @@ func (f *File) drawChartSeries(formatSet *formatChart) *[]cSer {
 				},
 			},
 			SpPr:             f.drawChartSeriesSpPr(k, formatSet),
+			Explosion:        f.drawChartSeriesExplosion(formatSet.Series[k].Explosion, formatSet),
 			Marker:           f.drawChartSeriesMarker(k, formatSet),
 			DPt:              f.drawChartSeriesDPt(k, formatSet),
 			DLbls:            f.drawChartSeriesDLbls(formatSet),
 			InvertIfNegative: &attrValBool{Val: boolPtr(false)},
+			Trendline:        f.drawChartSeriesTrendline(formatSet.Series[k], formatSet),
+			ErrBars:          f.drawChartSeriesErrBars(formatSet.Series[k], formatSet),
 			Cat:              f.drawChartSeriesCat(formatSet.Series[k], formatSet),
 			Val:              f.drawChartSeriesVal(formatSet.Series[k], formatSet),
 			XVal:             f.drawChartSeriesXVal(formatSet.Series[k], formatSet),
@@ -768,6 +791,30 @@ func (f *File) drawChartSeries(formatSet *formatChart) *[]cSer {
 	return &ser
 }
 
+// drawChartFirstSliceAng provides a function to draw the c:firstSliceAng
+// element of the pie and doughnut chart by given format sets, the angle of
+// the first slice shall be in the range of 1 to 360 degrees.
+func (f *File) drawChartFirstSliceAng(formatSet *formatChart) *attrValInt {
+	if formatSet.FirstSliceAngle <= 0 || formatSet.FirstSliceAngle > 360 {
+		return nil
+	}
+	return &attrValInt{Val: intPtr(formatSet.FirstSliceAngle)}
+}
+
+// drawChartSeriesExplosion provides a function to draw the c:explosion
+// element of the pie and doughnut chart series or data point by given
+// explosion percentage and format sets, the explosion shall be in the range
+// of 1 to 400 percent.
+func (f *File) drawChartSeriesExplosion(explosion int, formatSet *formatChart) *attrValInt {
+	if explosion <= 0 || explosion > 400 {
+		return nil
+	}
+	if inStrSlice([]string{Pie, Pie3D, Doughnut, PieOfPieChart, BarOfPieChart}, formatSet.Type, true) == -1 {
+		return nil
+	}
+	return &attrValInt{Val: intPtr(explosion)}
+}
+
 // drawChartSeriesSpPr provides a function to draw the c:spPr element by given
 // format sets.
 func (f *File) drawChartSeriesSpPr(i int, formatSet *formatChart) *cSpPr {
@@ -816,7 +863,58 @@ func (f *File) drawChartSeriesDPt(i int, formatSet *formatChart) []*cDPt {
 		},
 	}}
 	chartSeriesDPt := map[string][]*cDPt{Pie: dpt, Pie3D: dpt}
-	return chartSeriesDPt[formatSet.Type]
+	dataPoints := f.drawChartSeriesDataPoints(formatSet.Series[i], formatSet)
+	for _, d := range chartSeriesDPt[formatSet.Type] {
+		if p, ok := dataPoints[*d.IDx.Val]; !ok {
+			dataPoints[*d.IDx.Val] = d
+		} else if p.SpPr == nil {
+			p.SpPr = d.SpPr
+		}
+	}
+	if len(dataPoints) == 0 {
+		return nil
+	}
+	dPt := make([]*cDPt, 0, len(dataPoints))
+	for _, d := range dataPoints {
+		dPt = append(dPt, d)
+	}
+	sort.Slice(dPt, func(i, j int) bool { return *dPt[i].IDx.Val < *dPt[j].IDx.Val })
+	return dPt
+}
+
+// drawChartSeriesDataPoints provides a function to draw the c:dPt elements
+// for the data points with custom color or explosion of the chart series, the
+// returned map is keyed by the index of the data point.
+func (f *File) drawChartSeriesDataPoints(v formatChartSeries, formatSet *formatChart) map[int]*cDPt {
+	dataPoints := make(map[int]*cDPt, len(v.DataPoints))
+	for _, dataPoint := range v.DataPoints {
+		color := strings.TrimPrefix(strings.ToUpper(dataPoint.Color), "#")
+		explosion := f.drawChartSeriesExplosion(dataPoint.Explosion, formatSet)
+		if dataPoint.Index < 0 || (!isHexColor(color) && explosion == nil) {
+			continue
+		}
+		dPt := &cDPt{IDx: &attrValInt{Val: intPtr(dataPoint.Index)}, Explosion: explosion}
+		if explosion != nil {
+			dPt.Bubble3D = &attrValBool{Val: boolPtr(false)}
+		}
+		if isHexColor(color) {
+			spPr := &cSpPr{
+				SolidFill: &aSolidFill{SrgbClr: &attrValString{Val: stringPtr(color)}},
+			}
+			switch formatSet.Type {
+			case Line, Scatter:
+				spPr.Ln = &aLn{W: 9252, SolidFill: &aSolidFill{SrgbClr: &attrValString{Val: stringPtr(color)}}}
+				dPt.Marker = &cMarker{SpPr: spPr}
+			case Pie, Pie3D:
+				dPt.Bubble3D = &attrValBool{Val: boolPtr(false)}
+				dPt.SpPr = spPr
+			default:
+				dPt.SpPr = spPr
+			}
+		}
+		dataPoints[dataPoint.Index] = dPt
+	}
+	return dataPoints
 }
 
 // drawChartSeriesCat provides a function to draw the c:cat element by given
@@ -930,6 +1028,92 @@ func (f *File) drawCharSeriesBubble3D(formatSet *formatChart) *attrValBool {
 	return &attrValBool{Val: boolPtr(true)}
 }
 
+// drawChartSeriesTrendline provides a function to draw the c:trendline
+// element by given chart series and format sets.
+func (f *File) drawChartSeriesTrendline(v formatChartSeries, formatSet *formatChart) []*cTrendline {
+	if v.Trendline == nil {
+		return nil
+	}
+	if _, ok := map[string]bool{
+		Area: true, Bar: true, Col: true, Line: true, Scatter: true, Bubble: true,
+	}[formatSet.Type]; !ok {
+		return nil
+	}
+	trendlineType, ok := map[string]string{
+		"exponential":    "exp",
+		"linear":         "linear",
+		"logarithmic":    "log",
+		"moving_average": "movingAvg",
+		"polynomial":     "poly",
+		"power":          "power",
+	}[v.Trendline.Type]
+	if !ok {
+		return nil
+	}
+	trendline := &cTrendline{
+		Name:          v.Trendline.Name,
+		TrendlineType: &attrValString{Val: stringPtr(trendlineType)},
+		DispRSqr:      &attrValBool{Val: boolPtr(v.Trendline.DisplayRSquared)},
+		DispEq:        &attrValBool{Val: boolPtr(v.Trendline.DisplayEquation)},
+	}
+	if trendlineType == "poly" {
+		order := v.Trendline.Order
+		if order < 2 || order > 6 {
+			order = 2
+		}
+		trendline.Order = &attrValInt{Val: intPtr(order)}
+	}
+	if trendlineType == "movingAvg" {
+		period := v.Trendline.Period
+		if period < 2 {
+			period = 2
+		}
+		trendline.Period = &attrValInt{Val: intPtr(period)}
+	}
+	return []*cTrendline{trendline}
+}
+
+// drawChartSeriesErrBars provides a function to draw the c:errBars element by
+// given chart series and format sets.
+func (f *File) drawChartSeriesErrBars(v formatChartSeries, formatSet *formatChart) *cErrBars {
+	if v.ErrorBars == nil {
+		return nil
+	}
+	if _, ok := map[string]bool{
+		Area: true, AreaStacked: true, AreaPercentStacked: true,
+		Bar: true, BarStacked: true, BarPercentStacked: true,
+		Col: true, ColStacked: true, ColPercentStacked: true,
+		Line: true, Scatter: true, Bubble: true,
+	}[formatSet.Type]; !ok {
+		return nil
+	}
+	errValType, ok := map[string]string{
+		"fixed":              "fixedVal",
+		"percentage":         "percentage",
+		"standard_deviation": "stdDev",
+		"standard_error":     "stdErr",
+	}[v.ErrorBars.Type]
+	if !ok {
+		return nil
+	}
+	errBarType, ok := map[string]string{"": "both", "both": "both", "minus": "minus", "plus": "plus"}[v.ErrorBars.Direction]
+	if !ok {
+		return nil
+	}
+	errBars := &cErrBars{
+		ErrBarType: &attrValString{Val: stringPtr(errBarType)},
+		ErrValType: &attrValString{Val: stringPtr(errValType)},
+		NoEndCap:   &attrValBool{Val: boolPtr(v.ErrorBars.NoEndCap)},
+	}
+	if formatSet.Type == Scatter || formatSet.Type == Bubble {
+		errBars.ErrDir = &attrValString{Val: stringPtr("y")}
+	}
+	if errValType != "stdErr" {
+		errBars.Val = &attrValFloat{Val: float64Ptr(v.ErrorBars.Value)}
+	}
+	return errBars
+}
+
 // drawChartDLbls provides a function to draw the c:dLbls element by given
 // format sets.
 func (f *File) drawChartDLbls(formatSet *formatChart) *cDLbls {
@@ -1008,29 +1192,12 @@ func (f *File) drawPlotAreaCatAx(formatSet *formatChart) []*cAxs {
 
 // drawPlotAreaValAx provides a function to draw the c:valAx element.
 func (f *File) drawPlotAreaValAx(formatSet *formatChart) []*cAxs {
-	max := &attrValFloat{Val: formatSet.YAxis.Maximum}
-	min := &attrValFloat{Val: formatSet.YAxis.Minimum}
-	if formatSet.YAxis.Maximum == nil {
-		max = nil
-	}
-	if formatSet.YAxis.Minimum == nil {
-		min = nil
-	}
-	var logBase *attrValFloat
-	if formatSet.YAxis.LogBase >= 2 && formatSet.YAxis.LogBase <= 1000 {
-		logBase = &attrValFloat{Val: float64Ptr(formatSet.YAxis.LogBase)}
-	}
 	axs := []*cAxs{
 		{
-			AxID: &attrValInt{Val: intPtr(753999904)},
-			Scaling: &cScaling{
-				LogBase:     logBase,
-				Orientation: &attrValString{Val: stringPtr(orientation[formatSet.YAxis.ReverseOrder])},
-				Max:         max,
-				Min:         min,
-			},
-			Delete: &attrValBool{Val: boolPtr(formatSet.YAxis.None)},
-			AxPos:  &attrValString{Val: stringPtr(valAxPos[formatSet.YAxis.ReverseOrder])},
+			AxID:    &attrValInt{Val: intPtr(753999904)},
+			Scaling: drawPlotAreaValAxScaling(&formatSet.YAxis),
+			Delete:  &attrValBool{Val: boolPtr(formatSet.YAxis.None)},
+			AxPos:   &attrValString{Val: stringPtr(valAxPos[formatSet.YAxis.ReverseOrder])},
 			NumFmt: &cNumFmt{
 				FormatCode:   chartValAxNumFmtFormatCode[formatSet.Type],
 				SourceLinked: true,
@@ -1045,21 +1212,119 @@ func (f *File) drawPlotAreaValAx(formatSet *formatChart) []*cAxs {
 			CrossBetween:  &attrValString{Val: stringPtr(chartValAxCrossBetween[formatSet.Type])},
 		},
 	}
-	if formatSet.YAxis.MajorGridlines {
-		axs[0].MajorGridlines = &cChartLines{SpPr: f.drawPlotAreaSpPr()}
-	}
-	if formatSet.YAxis.MinorGridlines {
-		axs[0].MinorGridlines = &cChartLines{SpPr: f.drawPlotAreaSpPr()}
-	}
 	if pos, ok := valTickLblPos[formatSet.Type]; ok {
 		axs[0].TickLblPos.Val = stringPtr(pos)
 	}
-	if formatSet.YAxis.MajorUnit != 0 {
-		axs[0].MajorUnit = &attrValFloat{Val: float64Ptr(formatSet.YAxis.MajorUnit)}
+	f.drawPlotAreaValAxOptions(axs[0], &formatSet.YAxis)
+	return axs
+}
+
+// drawPlotAreaXYValAx provides a function to draw the c:valAx elements of the
+// horizontal and vertical axes for the scatter and bubble charts, which plot
+// the numeric values instead of the categories on the horizontal axis.
+func (f *File) drawPlotAreaXYValAx(formatSet *formatChart) []*cAxs {
+	axs := []*cAxs{
+		{
+			AxID:    &attrValInt{Val: intPtr(754001153)},
+			Scaling: drawPlotAreaValAxScaling(&formatSet.XAxis),
+			Delete:  &attrValBool{Val: boolPtr(formatSet.XAxis.None)},
+			AxPos:   &attrValString{Val: stringPtr(catAxPos[formatSet.XAxis.ReverseOrder])},
+			NumFmt: &cNumFmt{
+				FormatCode:   "General",
+				SourceLinked: true,
+			},
+			MajorTickMark: &attrValString{Val: stringPtr("none")},
+			MinorTickMark: &attrValString{Val: stringPtr("none")},
+			TickLblPos:    &attrValString{Val: stringPtr("nextTo")},
+			SpPr:          f.drawPlotAreaSpPr(),
+			TxPr:          f.drawPlotAreaTxPr(),
+			CrossAx:       &attrValInt{Val: intPtr(753999904)},
+			Crosses:       &attrValString{Val: stringPtr("autoZero")},
+			CrossBetween:  &attrValString{Val: stringPtr("midCat")},
+		},
 	}
+	f.drawPlotAreaValAxOptions(axs[0], &formatSet.XAxis)
+	axs = append(axs, f.drawPlotAreaValAx(formatSet)...)
+	axs[1].CrossAx.Val = intPtr(754001153)
 	return axs
 }
 
+// drawPlotAreaSecondaryAxes provides a function to move the scatter or bubble
+// chart in the given plot area to the secondary axes, the horizontal value
+// axis will be placed on the top and the vertical value axis will be placed
+// on the right of the plot area. This is used for the combo charts with the
+// category axis, which can't share the vertical axis with the horizontal
+// value axis.
+func drawPlotAreaSecondaryAxes(plotArea *cPlotArea) {
+	for _, c := range []*cCharts{plotArea.ScatterChart, plotArea.BubbleChart} {
+		if c == nil || len(plotArea.ValAx) != 2 {
+			continue
+		}
+		c.AxID[1].Val = intPtr(753999905)
+		plotArea.ValAx[0].AxPos.Val = stringPtr("t")
+		plotArea.ValAx[0].CrossAx.Val = intPtr(753999905)
+		plotArea.ValAx[0].Crosses.Val = stringPtr("max")
+		plotArea.ValAx[1].AxID.Val = intPtr(753999905)
+		plotArea.ValAx[1].AxPos.Val = stringPtr("r")
+		plotArea.ValAx[1].Crosses.Val = stringPtr("max")
+	}
+}
+
+// addPlotAreaAxes provides a function to merge the axes of the combo charts,
+// the axis with the same axis ID will be replaced by the later one.
+func addPlotAreaAxes(axs, others []*cAxs) []*cAxs {
+	for _, other := range others {
+		idx := -1
+		for i, ax := range axs {
+			if *ax.AxID.Val == *other.AxID.Val {
+				idx = i
+			}
+		}
+		if idx == -1 {
+			axs = append(axs, other)
+			continue
+		}
+		axs[idx] = other
+	}
+	return axs
+}
+
+// drawPlotAreaValAxScaling provides a function to draw the c:scaling element
+// of the value axis by given axis format sets. The logarithmic scale will be
+// used if the base of the logarithm is in range 2 - 1000.
+func drawPlotAreaValAxScaling(axis *formatChartAxis) *cScaling {
+	scaling := &cScaling{
+		Orientation: &attrValString{Val: stringPtr(orientation[axis.ReverseOrder])},
+	}
+	if axis.LogBase >= 2 && axis.LogBase <= 1000 {
+		scaling.LogBase = &attrValFloat{Val: float64Ptr(axis.LogBase)}
+	}
+	if axis.Maximum != nil {
+		scaling.Max = &attrValFloat{Val: axis.Maximum}
+	}
+	if axis.Minimum != nil {
+		scaling.Min = &attrValFloat{Val: axis.Minimum}
+	}
+	return scaling
+}
+
+// drawPlotAreaValAxOptions provides a function to set the gridlines and the
+// major and minor units of the value axis by given axis format sets.
+func (f *File) drawPlotAreaValAxOptions(ax *cAxs, axis *formatChartAxis) {
+	if axis.MajorGridlines {
+		ax.MajorGridlines = &cChartLines{SpPr: f.drawPlotAreaSpPr()}
+	}
+	if axis.MinorGridlines {
+		ax.MinorGridlines = &cChartLines{SpPr: f.drawPlotAreaSpPr()}
+	}
+	if axis.MajorUnit > 0 {
+		ax.MajorUnit = &attrValFloat{Val: float64Ptr(axis.MajorUnit)}
+	}
+	if axis.MinorUnit > 0 {
+		ax.MinorUnit = &attrValFloat{Val: float64Ptr(axis.MinorUnit)}
+	}
+}
+
 // drawPlotAreaSerAx provides a function to draw the c:serAx element.
 func (f *File) drawPlotAreaSerAx(formatSet *formatChart) []*cAxs {
 	max := &attrValFloat{Val: formatSet.YAxis.Maximum}
@@ -1194,7 +1459,7 @@ func (f *File) drawingParser(path string) (*xlsxWsDr, int) {
 	}
 	wsDr.Lock()
 	defer wsDr.Unlock()
-	return wsDr, len(wsDr.OneCellAnchor) + len(wsDr.TwoCellAnchor) + 2
+	return wsDr, len(wsDr.AlternateContent) + len(wsDr.OneCellAnchor) + len(wsDr.TwoCellAnchor) + 2
 }
 
 // addDrawingChart provides a function to add chart graphic frame by given
@@ -1335,3 +1600,190 @@ func (f *File) deleteDrawing(col, row int, drawingXML, drawingType string) (err
 	f.Drawings.Store(drawingXML, wsDr)
 	return err
 }
+
+// GroupDrawings provides a function to group the charts, pictures and shapes
+// of the worksheet into a group shape by given worksheet name and the cells
+// where the top-left corner of the drawing objects are anchored, and then
+// the drawing objects could be moved and resized together as a unit. All the
+// two cell anchored drawing objects start from the given cells will be
+// grouped, and at least two drawing objects are required. Note that the
+// grouped pictures can't be read by the GetPicture function. For example,
+// group the charts inserted at Sheet1!A1 and Sheet1!J1:
+//
+//	err := f.GroupDrawings("Sheet1", []string{"A1", "J1"})
+func (f *File) GroupDrawings(sheet string, anchors []string) error {
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	cells := make(map[[2]int]bool, len(anchors))
+	for _, cell := range anchors {
+		col, row, err := CellNameToCoordinates(cell)
+		if err != nil {
+			return err
+		}
+		cells[[2]int{col - 1, row - 1}] = false
+	}
+	if ws.Drawing == nil {
+		return ErrDrawingNotExist
+	}
+	drawingXML := strings.ReplaceAll(f.getSheetRelationshipsTargetByID(sheet, ws.Drawing.RID), "..", "xl")
+	wsDr, cNvPrID := f.drawingParser(drawingXML)
+	var (
+		group, rest []*xdrCellAnchor
+		children    strings.Builder
+		from        xlsxFrom
+		to          xlsxTo
+		x1, y1      = math.MaxInt32, math.MaxInt32
+		x2, y2      int
+	)
+	for _, anchor := range wsDr.TwoCellAnchor {
+		content, err := getCellAnchorContent(anchor)
+		if err != nil {
+			return err
+		}
+		deAnchor := decodeTwoCellAnchor{}
+		if err = f.xmlNewDecoder(strings.NewReader(content)).Decode(&deAnchor); err != nil && err != io.EOF {
+			return err
+		}
+		if deAnchor.From == nil || deAnchor.To == nil {
+			rest = append(rest, anchor)
+			continue
+		}
+		cell := [2]int{deAnchor.From.Col, deAnchor.From.Row}
+		if _, ok := cells[cell]; !ok {
+			rest = append(rest, anchor)
+			continue
+		}
+		cells[cell] = true
+		left, top := f.getCellAnchorEMU(sheet, deAnchor.From.Col, deAnchor.From.ColOff, deAnchor.From.Row, deAnchor.From.RowOff)
+		right, bottom := f.getCellAnchorEMU(sheet, deAnchor.To.Col, deAnchor.To.ColOff, deAnchor.To.Row, deAnchor.To.RowOff)
+		shape, err := setDrawingObjectXfrm(content, left, top, right-left, bottom-top)
+		if err != nil {
+			return err
+		}
+		children.WriteString(shape)
+		if left < x1 {
+			x1, from.Col, from.ColOff = left, deAnchor.From.Col, deAnchor.From.ColOff
+		}
+		if top < y1 {
+			y1, from.Row, from.RowOff = top, deAnchor.From.Row, deAnchor.From.RowOff
+		}
+		if right > x2 {
+			x2, to.Col, to.ColOff = right, deAnchor.To.Col, deAnchor.To.ColOff
+		}
+		if bottom > y2 {
+			y2, to.Row, to.RowOff = bottom, deAnchor.To.Row, deAnchor.To.RowOff
+		}
+		group = append(group, anchor)
+	}
+	for _, cell := range anchors {
+		col, row, _ := CellNameToCoordinates(cell)
+		if !cells[[2]int{col - 1, row - 1}] {
+			return ErrDrawingNotExist
+		}
+	}
+	if len(group) < 2 {
+		return ErrParameterInvalid
+	}
+	off, ext := xlsxOff{X: x1, Y: y1}, xlsxExt{Cx: x2 - x1, Cy: y2 - y1}
+	grpSp, _ := xml.Marshal(xdrGrpSp{
+		NvGrpSpPr: xdrNvGrpSpPr{CNvPr: &xlsxCNvPr{ID: cNvPrID, Name: "Group " + strconv.Itoa(cNvPrID)}},
+		GrpSpPr:   xdrGrpSpPr{Xfrm: xlsxGroupXfrm{Off: off, Ext: ext, ChOff: off, ChExt: ext}},
+		Content:   children.String(),
+	})
+	wsDr.Lock()
+	defer wsDr.Unlock()
+	wsDr.TwoCellAnchor = append(rest, &xdrCellAnchor{
+		From:         &from,
+		To:           &to,
+		GraphicFrame: string(grpSp),
+		ClientData:   &xdrClientData{FLocksWithSheet: true, FPrintsWithSheet: true},
+	})
+	f.Drawings.Store(drawingXML, wsDr)
+	return err
+}
+
+// getCellAnchorContent provides a function to get the XML content of the
+// cell anchor, which includes the anchor position and the drawing object.
+func getCellAnchorContent(anchor *xdrCellAnchor) (string, error) {
+	if anchor.From == nil {
+		return "<xdrCellAnchor>" + anchor.GraphicFrame + "</xdrCellAnchor>", nil
+	}
+	content, err := xml.Marshal(anchor)
+	return string(content), err
+}
+
+// getCellAnchorEMU provides a function to get the absolute position of the
+// cell anchor in EMUs from the top-left corner of the worksheet by given
+// zero-based column and row index and the offsets in the cell.
+func (f *File) getCellAnchorEMU(sheet string, col, colOff, row, rowOff int) (int, int) {
+	var x, y int
+	for c := 1; c <= col; c++ {
+		x += f.getColWidth(sheet, c)
+	}
+	for r := 1; r <= row; r++ {
+		y += f.getRowHeight(sheet, r)
+	}
+	return x*EMU + colOff, y*EMU + rowOff
+}
+
+// setDrawingObjectXfrm provides a function to get the drawing objects in the
+// XML content of the cell anchor, and set the position and size in EMUs of
+// the first 2D transform of each drawing object.
+func setDrawingObjectXfrm(content string, x, y, cx, cy int) (string, error) {
+	objects, err := getXMLElements(content, 1)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	for _, obj := range objects {
+		if inStrSlice([]string{"from", "to", "ext", "pos", "clientData"}, obj.name.Local, true) != -1 {
+			continue
+		}
+		object := content[obj.start:obj.end]
+		elements, err := getXMLElements(object, -1)
+		if err != nil {
+			return "", err
+		}
+		var xfrm *xmlElementRange
+		for idx := range elements {
+			if elements[idx].name.Local == "xfrm" && (xfrm == nil || elements[idx].start < xfrm.start) {
+				xfrm = &elements[idx]
+			}
+		}
+		if xfrm == nil {
+			b.WriteString(object)
+			continue
+		}
+		inner := object[xfrm.start:xfrm.end]
+		children, err := getXMLElements(inner, 1)
+		if err != nil {
+			return "", err
+		}
+		prefix := "a:"
+		var rest strings.Builder
+		for _, child := range children {
+			if child.name.Local == "off" || child.name.Local == "ext" {
+				if child.name.Space != "" {
+					prefix = child.name.Space + ":"
+				}
+				continue
+			}
+			rest.WriteString(inner[child.start:child.end])
+		}
+		head := inner[:strings.Index(inner, ">")+1]
+		tag := xfrm.name.Local
+		if xfrm.name.Space != "" {
+			tag = xfrm.name.Space + ":" + tag
+		}
+		if strings.HasSuffix(head, "/>") {
+			head = strings.TrimSuffix(head, "/>") + ">"
+		}
+		b.WriteString(object[:xfrm.start])
+		b.WriteString(fmt.Sprintf(`%s<%soff x="%d" y="%d"/><%sext cx="%d" cy="%d"/>%s</%s>`,
+			head, prefix, x, y, prefix, cx, cy, rest.String(), tag))
+		b.WriteString(object[xfrm.end:])
+	}
+	return b.String(), nil
+}
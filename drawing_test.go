@@ -13,8 +13,12 @@ package excelize
 
 import (
 	"encoding/xml"
+	"path/filepath"
+	"strings"
 	"sync"
 	"testing"
+
+	"github.com/stretchr/testify/assert"
 )
 
 func TestDrawingParser(t *testing.T) {
@@ -33,3 +37,63 @@ func TestDrawingParser(t *testing.T) {
 	f.Pkg.Store("wsDr", []byte(xml.Header+`<xdr:wsDr xmlns:xdr="http://schemas.openxmlformats.org/drawingml/2006/spreadsheetDrawing"><mc:AlternateContent xmlns:mc="http://schemas.openxmlformats.org/markup-compatibility/2006"><mc:Choice xmlns:a14="http://schemas.microsoft.com/office/drawing/2010/main" Requires="a14"><xdr:twoCellAnchor editAs="oneCell"></xdr:twoCellAnchor></mc:Choice><mc:Fallback/></mc:AlternateContent></xdr:wsDr>`))
 	f.drawingParser("wsDr")
 }
+
+func TestGroupDrawings(t *testing.T) {
+	f := NewFile()
+	// Test group drawings on the worksheet without drawing
+	assert.EqualError(t, f.GroupDrawings("Sheet1", []string{"A1", "J1"}), ErrDrawingNotExist.Error())
+	chart := `{"type":"col","series":[{"name":"Sheet1!$A$1","values":"Sheet1!$B$1:$B$2"}],"dimension":{"width":320,"height":200}}`
+	assert.NoError(t, f.AddChart("Sheet1", "A1", chart))
+	assert.NoError(t, f.AddChart("Sheet1", "J1", chart))
+	assert.NoError(t, f.AddPicture("Sheet1", "A20", filepath.Join("test", "images", "excel.png"), ""))
+	assert.NoError(t, f.AddShape("Sheet1", "J20", `{"type":"rect","paragraph":[{"text":"Rectangle"}]}`))
+	assert.NoError(t, f.GroupDrawings("Sheet1", []string{"A1", "J1", "A20"}))
+	drawing, ok := f.Drawings.Load("xl/drawings/drawing1.xml")
+	assert.True(t, ok)
+	wsDr := drawing.(*xlsxWsDr)
+	assert.Len(t, wsDr.TwoCellAnchor, 2)
+	assert.NotNil(t, wsDr.TwoCellAnchor[0].Sp)
+	group := wsDr.TwoCellAnchor[1]
+	assert.Equal(t, &xlsxFrom{}, group.From)
+	assert.Equal(t, 1, strings.Count(group.GraphicFrame, "<xdr:grpSp>"))
+	assert.Equal(t, 2, strings.Count(group.GraphicFrame, "<xdr:graphicFrame"))
+	assert.Equal(t, 1, strings.Count(group.GraphicFrame, "<xdr:pic>"))
+	assert.Contains(t, group.GraphicFrame, `<xdr:xfrm><a:off x="0" y="0"/><a:ext cx="3048000" cy="1905000"/></xdr:xfrm>`)
+	assert.Contains(t, group.GraphicFrame, `<a:xfrm><a:off x="0" y="3619500"/>`)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestGroupDrawings.xlsx")))
+	assert.NoError(t, f.Close())
+
+	// Test group the drawings read from the workbook into a nested group
+	f, err := OpenFile(filepath.Join("test", "TestGroupDrawings.xlsx"))
+	assert.NoError(t, err)
+	assert.NoError(t, f.GroupDrawings("Sheet1", []string{"J20", "A1"}))
+	drawing, ok = f.Drawings.Load("xl/drawings/drawing1.xml")
+	assert.True(t, ok)
+	wsDr = drawing.(*xlsxWsDr)
+	assert.Len(t, wsDr.TwoCellAnchor, 1)
+	assert.Equal(t, 2, strings.Count(wsDr.TwoCellAnchor[0].GraphicFrame, "<xdr:grpSp>"))
+	assert.Equal(t, 2, strings.Count(wsDr.TwoCellAnchor[0].GraphicFrame, "<a:chOff"))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestGroupDrawings2.xlsx")))
+
+	// Test group drawings with invalid parameters
+	assert.EqualError(t, f.GroupDrawings("SheetN", []string{"A1"}), "sheet SheetN is not exist")
+	assert.EqualError(t, f.GroupDrawings("Sheet1", []string{"A"}), newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
+	assert.EqualError(t, f.GroupDrawings("Sheet1", []string{"A1", "B2"}), ErrDrawingNotExist.Error())
+	assert.EqualError(t, f.GroupDrawings("Sheet1", []string{"A1"}), ErrParameterInvalid.Error())
+	// Test group drawings with invalid drawing object
+	wsDr.TwoCellAnchor = append(wsDr.TwoCellAnchor, &xdrCellAnchor{GraphicFrame: "<xdr:from><xdr:col>1</xdr:col></xdr:from><xdr:to></xdr:to><xdr:sp>"})
+	assert.EqualError(t, f.GroupDrawings("Sheet1", []string{"A1", "B1"}), "XML syntax error on line 1: element <sp> closed by </xdrCellAnchor>")
+	wsDr.TwoCellAnchor[1].GraphicFrame = "<xdr:from><xdr:col>1</xdr:col></xdr:from><xdr:to></xdr:to><xdr:sp></xdr:sp></xdr:sp>"
+	assert.EqualError(t, f.GroupDrawings("Sheet1", []string{"A1", "B1"}), "XML syntax error on line 1: element <xdrCellAnchor> closed by </sp>")
+	assert.NoError(t, f.Close())
+}
+
+func TestSetDrawingObjectXfrm(t *testing.T) {
+	content, err := setDrawingObjectXfrm(`<xdrCellAnchor><xdr:sp><xdr:spPr><a:xfrm rot="60000"/></xdr:spPr></xdr:sp><xdr:cxnSp/></xdrCellAnchor>`, 1, 2, 3, 4)
+	assert.NoError(t, err)
+	assert.Equal(t, `<xdr:sp><xdr:spPr><a:xfrm rot="60000"><a:off x="1" y="2"/><a:ext cx="3" cy="4"/></a:xfrm></xdr:spPr></xdr:sp><xdr:cxnSp/>`, content)
+	_, err = setDrawingObjectXfrm(`<xdrCellAnchor><xdr:sp><a:xfrm></xdr:sp></xdrCellAnchor>`, 1, 2, 3, 4)
+	assert.EqualError(t, err, ErrParameterInvalid.Error())
+	_, err = setDrawingObjectXfrm(`<xdrCellAnchor><xdr:sp><a:xfrm><a:off></a:xfrm></xdr:sp></xdrCellAnchor>`, 1, 2, 3, 4)
+	assert.EqualError(t, err, ErrParameterInvalid.Error())
+}
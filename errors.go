@@ -70,6 +70,18 @@ func newCellNameToCoordinatesError(cell string, err error) error {
 	return fmt.Errorf("cannot convert cell %q to coordinates: %v", cell, err)
 }
 
+// newDefinedNameError defined the error message on receiving the invalid
+// defined name.
+func newDefinedNameError(name string, err error) error {
+	return fmt.Errorf("invalid defined name %q: %v", name, err)
+}
+
+// newDuplicateSheetNameError defined the error message on the worksheet name
+// is used by more than one worksheets in the workbook.
+func newDuplicateSheetNameError(name string) error {
+	return fmt.Errorf("the sheet name %q is duplicated", name)
+}
+
 var (
 	// ErrStreamSetColWidth defined the error message on set column width in
 	// stream writing mode.
@@ -106,12 +118,18 @@ var (
 	// ErrImgExt defined the error message on receive an unsupported image
 	// extension.
 	ErrImgExt = errors.New("unsupported image extension")
+	// ErrPicturePositioning defined the error message on receive an
+	// unsupported positioning of the picture.
+	ErrPicturePositioning = errors.New("unsupported positioning, the positioning must be one of twoCell, oneCell or absolute")
 	// ErrWorkbookFileFormat defined the error message on receive an
 	// unsupported workbook file format.
 	ErrWorkbookFileFormat = errors.New("unsupported workbook file format")
 	// ErrMaxFilePathLength defined the error message on receive the file path
 	// length overflow.
 	ErrMaxFilePathLength = errors.New("file path length exceeds maximum limit")
+	// ErrCompressionLevel defined the error message on receive the invalid
+	// compression level.
+	ErrCompressionLevel = errors.New("compression level must be between 0 and 9")
 	// ErrUnknownEncryptMechanism defined the error message on unsupported
 	// encryption mechanism.
 	ErrUnknownEncryptMechanism = errors.New("unknown encryption mechanism")
@@ -127,6 +145,24 @@ var (
 	// ErrPasswordLengthInvalid defined the error message on invalid password
 	// length.
 	ErrPasswordLengthInvalid = errors.New("password length invalid")
+	// ErrSheetCodeName defined the error message on receive the invalid code
+	// name of the worksheet.
+	ErrSheetCodeName = errors.New("the code name must start with a letter, contain only letters, numbers and underscores, and not exceed 31 characters")
+	// ErrSheetCodeNameDuplicate defined the error message on receive the code
+	// name which is already used by another worksheet.
+	ErrSheetCodeNameDuplicate = errors.New("the code name already exists")
+	// ErrPivotTableNotExist defined the error message on the pivot table
+	// doesn't exist.
+	ErrPivotTableNotExist = errors.New("the pivot table does not exist")
+	// ErrPivotCacheNotExist defined the error message on the pivot cache of
+	// the pivot table doesn't exist.
+	ErrPivotCacheNotExist = errors.New("the pivot cache of the pivot table does not exist")
+	// ErrPivotCacheSource defined the error message on receive the
+	// unsupported source of the pivot cache.
+	ErrPivotCacheSource = errors.New("unsupported pivot cache source, the source must be a range in the worksheet")
+	// ErrPivotCacheFields defined the error message on the number of the
+	// pivot cache fields doesn't match the source data range.
+	ErrPivotCacheFields = errors.New("the number of the pivot cache fields does not match the source data range")
 	// ErrParameterRequired defined the error message on receive the empty
 	// parameter.
 	ErrParameterRequired = errors.New("parameter is required")
@@ -139,6 +175,18 @@ var (
 	// ErrDefinedNameDuplicate defined the error message on the same name
 	// already exists on the scope.
 	ErrDefinedNameDuplicate = errors.New("the same name already exists on the scope")
+	// ErrCustomViewDuplicate defined the error message on the same name custom
+	// view already exists.
+	ErrCustomViewDuplicate = errors.New("the same name custom view already exists")
+	// ErrCustomViewNotExist defined the error message on the custom view not
+	// exists.
+	ErrCustomViewNotExist = errors.New("the custom view does not exist")
+	// ErrCellIndent defined the error message on receive the invalid indent
+	// level of the cell alignment.
+	ErrCellIndent = fmt.Errorf("the indent level must be greater than or equal to 0 and less than or equal to %d", MaxCellIndent)
+	// ErrTextRotation defined the error message on receive the invalid text
+	// rotation of the alignment settings.
+	ErrTextRotation = fmt.Errorf("text rotation must be between -90 and 180 degrees or %d for vertical text", TextRotationVertical)
 	// ErrCustomNumFmt defined the error message on receive the empty custom number format.
 	ErrCustomNumFmt = errors.New("custom number format can not be empty")
 	// ErrFontLength defined the error message on the length of the font
@@ -189,7 +237,98 @@ var (
 	// ErrSparklineStyle defined the error message on receive the invalid
 	// sparkline Style parameters.
 	ErrSparklineStyle = errors.New("parameter 'Style' must between 0-35")
+	// ErrInsertCellsMergeCell defined the error message on insert cells with
+	// merged cells only partly inside the cells to be shifted.
+	ErrInsertCellsMergeCell = errors.New("cannot shift cells that would change the merged cells partially")
+	// ErrIndexedColors defined the error message on receiving the invalid
+	// indexed colors palette.
+	ErrIndexedColors = fmt.Errorf("indexed colors must be up to %d colors in the RRGGBB format", len(defaultIndexedColors))
+	// ErrCommentExists defined the error message on adding a comment to the
+	// cell which already has a comment.
+	ErrCommentExists = errors.New("the cell already has a comment")
+	// ErrThreadedCommentNotExist defined the error message on replying to the
+	// cell which has no threaded comment.
+	ErrThreadedCommentNotExist = errors.New("the cell has no threaded comment")
 	// ErrWorkbookPassword defined the error message on receiving the incorrect
 	// workbook password.
 	ErrWorkbookPassword = errors.New("the supplied open workbook password is not correct")
+	// ErrTableNotExist defined the error message on the table doesn't exist.
+	ErrTableNotExist = errors.New("the table does not exist")
+	// ErrTableColumnNotExist defined the error message on the column of the
+	// table doesn't exist.
+	ErrTableColumnNotExist = errors.New("the table column does not exist")
+	// ErrDrawingNotExist defined the error message on the drawing object
+	// doesn't exist in the given cell.
+	ErrDrawingNotExist = errors.New("the drawing object does not exist")
+	// ErrPaneSplitPosition defined the error message on receiving the
+	// invalid split position of the split panes.
+	ErrPaneSplitPosition = errors.New("the split position of the split panes must be positive")
+	// ErrActivePane defined the error message on receiving the pane which
+	// doesn't exist in the arrangement of the split panes.
+	ErrActivePane = errors.New("the pane does not exist in the arrangement of the split panes")
+	// ErrThemeNotExist defined the error message on the workbook doesn't
+	// contain a theme.
+	ErrThemeNotExist = errors.New("the theme of the workbook does not exist")
+	// ErrPageMarginsUnit defined the error message on receiving the
+	// unsupported unit of the page margins.
+	ErrPageMarginsUnit = errors.New("the unit of the page margins must be one of cm, mm or in")
+	// ErrNumFmtCondition defined the error message on receiving the invalid
+	// condition of the number format section.
+	ErrNumFmtCondition = errors.New("the condition of the number format section is invalid")
+	// ErrNumFmtSections defined the error message on the number of the
+	// number format sections exceeds the limit.
+	ErrNumFmtSections = errors.New("the number format can have at most 4 sections and 2 conditions")
+	// ErrNumFmtSection defined the error message on receiving the number
+	// format section which contains the section separator or condition.
+	ErrNumFmtSection = errors.New("the number format section can not contain section separator or condition")
+	// ErrPhoneticRun defined the error message on receiving the invalid
+	// phonetic runs of the cell text.
+	ErrPhoneticRun = errors.New("the phonetic runs must be in ascending order and within the range of the cell text")
+	// ErrUnprotectWorkbook defined the error message on workbook has set no
+	// protection.
+	ErrUnprotectWorkbook = errors.New("workbook has set no protect")
+	// ErrUnprotectWorkbookPassword defined the error message on remove
+	// workbook protection with password verification failed.
+	ErrUnprotectWorkbookPassword = errors.New("workbook protect password not match")
+	// ErrRenderImageSize defined the error message on receiving the range
+	// which the size of the rendered image exceeds limit.
+	ErrRenderImageSize = fmt.Errorf("the size of the rendered image exceeds %d pixels", MaxRenderPixels)
+	// ErrCellColor defined the error message on receiving the invalid color
+	// of the cell background.
+	ErrCellColor = errors.New("the cell background color must be in the #RRGGBB format")
+	// ErrSlicerField defined the error message on the field of the slicer
+	// doesn't exist in the table or pivot table.
+	ErrSlicerField = errors.New("the field of the slicer does not exist in the table or pivot table")
+	// ErrTableName defined the error message on receiving the invalid table
+	// name or the table name which already exists in the workbook.
+	ErrTableName = errors.New("the table name is invalid or already exists in the workbook")
+	// ErrTableOverlap defined the error message on receiving the range which
+	// overlaps with the existing table.
+	ErrTableOverlap = errors.New("the range overlaps with the existing table")
+	// ErrCellMetadataIndex defined the error message on receiving the metadata
+	// index of the cell which doesn't exist in the metadata part.
+	ErrCellMetadataIndex = errors.New("the metadata index of the cell does not exist in the metadata part")
+	// ErrBorderStyle defined the error message on receiving the invalid line
+	// style of the border.
+	ErrBorderStyle = errors.New("the line style of the border must be in range 0 - 13")
+	// ErrDefinedNameInvalid defined the error message on receiving the name
+	// which isn't a legal identifier for the defined name.
+	ErrDefinedNameInvalid = errors.New("the name must start with a letter, an underscore or a backslash, contain no spaces and not be a cell reference")
+	// ErrDefinedNameRefersTo defined the error message on receiving the invalid
+	// reference of the defined name.
+	ErrDefinedNameRefersTo = errors.New("the reference of the defined name is invalid")
+	// ErrJSONNumberRange defined the error message on receiving the JSON
+	// number which exceeds the range of the double-precision floating-point
+	// number.
+	ErrJSONNumberRange = errors.New("the JSON number exceeds the range of the double-precision floating-point number")
+	// ErrRenderCells defined the error message on receiving the range which
+	// the number of the cells to be rendered exceeds limit.
+	ErrRenderCells = fmt.Errorf("the number of the rendered cells exceeds %d", MaxRenderCells)
+	// ErrTimePeriodCriteria defined the error message on receiving the
+	// criteria of the time period conditional format which isn't a time
+	// period.
+	ErrTimePeriodCriteria = errors.New("the criteria of the time period conditional format is invalid")
+	// ErrInsertCellsTable defined the error message on insert cells which
+	// would shift the cells of the table partially.
+	ErrInsertCellsTable = errors.New("cannot shift cells that would change the table partially")
 )
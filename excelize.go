@@ -36,6 +36,7 @@ type File struct {
 	xmlAttr          map[string][]xml.Attr
 	checked          map[string]bool
 	sheetMap         map[string]string
+	sheetNameRepairs []SheetNameRepair
 	streams          map[string]*StreamWriter
 	tempFiles        sync.Map
 	CalcChain        *xlsxCalcChain
@@ -63,13 +64,26 @@ type charsetTranscoderFn func(charset string, input io.Reader) (rdr io.Reader, e
 
 // Options define the options for open and reading spreadsheet.
 //
+// CompressionLevel specifies the compression level of the zip writer on
+// saving the spreadsheet, the value range is from 0 to 9, 0 means store the
+// package parts without compression, which makes it easy to inspect the
+// generated XML, 1 gives the best speed and 9 gives the best compression.
+// The default compression level will be used if this value is nil.
+//
 // MaxCalcIterations specifies the maximum iterations for iterative
 // calculation, the default value is 0.
 //
 // Password specifies the password of the spreadsheet in plain text.
 //
+// RepairSheetNames specifies if rename the worksheets which have the
+// duplicate names on opening the spreadsheet, the names compare is case
+// insensitive, the renamed worksheets can be got by the RepairSheetNames
+// function.
+//
 // RawCellValue specifies if apply the number format for the cell value or get
-// the raw value.
+// the raw value. When specified on opening the spreadsheet, this setting will
+// be used as the default for the cell value getters, such as GetCellValue,
+// GetRows and GetCols.
 //
 // UnzipSizeLimit specifies the unzip size limit in bytes on open the
 // spreadsheet, this value should be greater than or equal to
@@ -81,9 +95,11 @@ type charsetTranscoderFn func(charset string, input io.Reader) (rdr io.Reader, e
 // should be less than or equal to UnzipSizeLimit, the default value is
 // 16MB.
 type Options struct {
+	CompressionLevel  *int
 	MaxCalcIterations uint
 	Password          string
 	RawCellValue      bool
+	RepairSheetNames  bool
 	UnzipSizeLimit    int64
 	UnzipXMLSizeLimit int64
 }
@@ -180,6 +196,9 @@ func OpenReader(r io.Reader, opt ...Options) (*File, error) {
 	}
 	f.CalcChain = f.calcChainReader()
 	f.sheetMap = f.getSheetMap()
+	if f.options.RepairSheetNames {
+		f.RepairSheetNames()
+	}
 	f.Styles = f.stylesReader()
 	f.Theme = f.themeReader()
 	return f, nil
@@ -195,6 +214,16 @@ func parseOptions(opts ...Options) *Options {
 	return opt
 }
 
+// getOptions provides a function to get the optional settings for reading
+// spreadsheet, the options specified on opening the spreadsheet will be used
+// if not given.
+func (f *File) getOptions(opts ...Options) *Options {
+	if len(opts) == 0 && f.options != nil {
+		return f.options
+	}
+	return parseOptions(opts...)
+}
+
 // CharsetTranscoder Set user defined codepage transcoder function for open
 // XLSX from non UTF-8 encoding.
 func (f *File) CharsetTranscoder(fn charsetTranscoderFn) *File { f.CharsetReader = fn; return f }
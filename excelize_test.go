@@ -14,6 +14,7 @@ import (
 	"math"
 	"os"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 	"testing"
@@ -206,6 +207,42 @@ func TestCharsetTranscoder(t *testing.T) {
 	f.CharsetTranscoder(*new(charsetTranscoderFn))
 }
 
+func TestPreserveEmbeddedFonts(t *testing.T) {
+	// Test the embedded font parts are preserved on round-trip, the workbook
+	// has no API to embed fonts because spreadsheet applications don't load
+	// the fonts from the workbook.
+	const (
+		fontPath = "xl/fonts/font1.odttf"
+		fontType = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/font"
+	)
+	fontData := []byte{0x00, 0x01, 0x00, 0x00}
+	f := NewFile()
+	f.Pkg.Store(fontPath, fontData)
+	content := f.contentTypesReader()
+	content.Defaults = append(content.Defaults, xlsxDefault{
+		Extension:   "odttf",
+		ContentType: "application/vnd.openxmlformats-officedocument.obfuscatedFont",
+	})
+	rID := f.addRels(f.getWorkbookRelsPath(), fontType, "fonts/font1.odttf", "")
+	buf, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+
+	f, err = OpenReader(buf)
+	assert.NoError(t, err)
+	data, ok := f.Pkg.Load(fontPath)
+	assert.True(t, ok)
+	assert.Equal(t, fontData, data)
+	var extensions []string
+	for _, d := range f.contentTypesReader().Defaults {
+		extensions = append(extensions, d.Extension)
+	}
+	assert.Contains(t, extensions, "odttf")
+	rels := f.relsReader(f.getWorkbookRelsPath())
+	assert.Contains(t, rels.Relationships, xlsxRelationship{
+		ID: "rId" + strconv.Itoa(rID), Type: fontType, Target: "fonts/font1.odttf",
+	})
+}
+
 func TestOpenReader(t *testing.T) {
 	_, err := OpenReader(strings.NewReader(""))
 	assert.EqualError(t, err, zip.ErrFormat.Error())
@@ -332,7 +369,7 @@ func TestNewFile(t *testing.T) {
 func TestAddDrawingVML(t *testing.T) {
 	// Test addDrawingVML with illegal cell coordinates.
 	f := NewFile()
-	assert.EqualError(t, f.addDrawingVML(0, "", "*", 0, 0), newCellNameToCoordinatesError("*", newInvalidCellNameError("*")).Error())
+	assert.EqualError(t, f.addDrawingVML(0, "", "*", "", 0, 0, 0, 0), newCellNameToCoordinatesError("*", newInvalidCellNameError("*")).Error())
 }
 
 func TestSetCellHyperLink(t *testing.T) {
@@ -427,6 +464,45 @@ func TestGetCellHyperLink(t *testing.T) {
 	assert.Equal(t, target, "")
 }
 
+func TestGetCellHyperLinkInfo(t *testing.T) {
+	f := NewFile()
+	display, tooltip := "Excelize", "Go to the repository"
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "A1", "https://github.com/xuri/excelize", "External", HyperlinkOpts{Display: &display, Tooltip: &tooltip}))
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "A2", "Sheet1!B10", "Location"))
+	link, err := f.GetCellHyperLinkInfo("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, &Hyperlink{Link: "https://github.com/xuri/excelize", LinkType: "External", Display: display, Tooltip: tooltip}, link)
+	link, err = f.GetCellHyperLinkInfo("Sheet1", "A2")
+	assert.NoError(t, err)
+	assert.Equal(t, &Hyperlink{Link: "Sheet1!B10", LinkType: "Location"}, link)
+	link, err = f.GetCellHyperLinkInfo("Sheet1", "A3")
+	assert.NoError(t, err)
+	assert.Nil(t, link)
+
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	// Test get the external hyperlink with location and the hyperlink of range
+	ws.(*xlsxWorksheet).Hyperlinks.Hyperlink[0].Location = "Sheet2!A1"
+	ws.(*xlsxWorksheet).Hyperlinks.Hyperlink = append(ws.(*xlsxWorksheet).Hyperlinks.Hyperlink,
+		xlsxHyperlink{Ref: "C1:D5", Location: "#'Sheet 2'!C3"}, xlsxHyperlink{Ref: "E"})
+	link, err = f.GetCellHyperLinkInfo("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "https://github.com/xuri/excelize#Sheet2!A1", link.Link)
+	ok, target, err := f.GetCellHyperLink("Sheet1", "D4")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "'Sheet 2'!C3", target)
+	link, err = f.GetCellHyperLinkInfo("Sheet1", "E1")
+	assert.NoError(t, err)
+	assert.Nil(t, link)
+	// Test get hyperlink with invalid cell reference
+	_, err = f.GetCellHyperLinkInfo("Sheet1", "A")
+	assert.EqualError(t, err, newInvalidCellNameError("A").Error())
+	// Test get hyperlink on not exists worksheet
+	_, err = f.GetCellHyperLinkInfo("SheetN", "A1")
+	assert.EqualError(t, err, "sheet SheetN is not exist")
+}
+
 func TestSetSheetBackground(t *testing.T) {
 	f, err := OpenFile(filepath.Join("test", "Book1.xlsx"))
 	if !assert.NoError(t, err) {
@@ -976,6 +1052,169 @@ func TestCopySheetError(t *testing.T) {
 	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestCopySheetError.xlsx")))
 }
 
+func TestDeepCopySheet(t *testing.T) {
+	f := NewFile()
+	f.NewSheet("Template")
+	assert.NoError(t, f.SetCellValue("Template", "A1", "Header"))
+	assert.NoError(t, f.SetCellValue("Template", "A2", 1))
+	assert.NoError(t, f.SetCellValue("Template", "B2", 2))
+	assert.NoError(t, f.MergeCell("Template", "A1", "B1"))
+	dv := NewDataValidation(true)
+	dv.Sqref = "A2:B2"
+	assert.NoError(t, dv.SetRange(0, 10, DataValidationTypeWhole, DataValidationOperatorBetween))
+	assert.NoError(t, f.AddDataValidation("Template", dv))
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "_xlnm.Print_Area", RefersTo: "Template!$A$1:$B$2", Scope: "Template"}))
+	assert.NoError(t, f.AddPicture("Template", "D1", filepath.Join("test", "images", "excel.png"), ""))
+	assert.NoError(t, f.AddChart("Template", "D10", `{"type":"col","series":[{"name":"Template!$A$1","categories":"Template!$A$2:$B$2","values":"Template!$A$2:$B$2"}]}`))
+	assert.NoError(t, f.AddTable("Template", "A4", "B6", `{"table_name":"Sales"}`))
+	assert.NoError(t, f.AddThreadedComment("Template", "A1", `{"author":"Excelize","text":"Note"}`))
+	f.NewSheet("MyTemplate")
+	assert.NoError(t, f.SetCellFormula("Template", "A8", "SUM(Sales[Column1])+Template!A2+MyTemplate!A2"))
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "Refs", RefersTo: "MyTemplate!$A$1,Template!$A$1", Scope: "Template"}))
+	assert.NoError(t, f.SetSheetRow("Template", "F20", &[]interface{}{"Name", "Value"}))
+	assert.NoError(t, f.SetSheetRow("Template", "F21", &[]interface{}{"A", 1}))
+	assert.NoError(t, f.SetSheetRow("Template", "F22", &[]interface{}{"B", 2}))
+	assert.NoError(t, f.AddPivotTable(&PivotTableOption{
+		DataRange:       "Template!$F$20:$G$22",
+		PivotTableRange: "Template!$I$20:$K$25",
+		Rows:            []PivotTableField{{Data: "Name"}},
+		Data:            []PivotTableField{{Data: "Value"}},
+	}))
+	assert.NoError(t, f.RefreshPivotCache("Template", "Pivot Table1"))
+
+	assert.NoError(t, f.DeepCopySheet("Template", "Report"))
+	assert.EqualError(t, f.DeepCopySheet("Template", "Report"), ErrExistsWorksheet.Error())
+	assert.EqualError(t, f.DeepCopySheet("SheetN", "Report2"), "sheet SheetN is not exist")
+	assert.NoError(t, f.SetCellValue("Report", "A2", 100))
+	path := filepath.Join("test", "TestDeepCopySheet.xlsx")
+	assert.NoError(t, f.SaveAs(path))
+	assert.NoError(t, f.Close())
+
+	f, err := OpenFile(path)
+	assert.NoError(t, err)
+	for sheet, expected := range map[string]string{"Template": "1", "Report": "100"} {
+		val, err := f.GetCellValue(sheet, "A2")
+		assert.NoError(t, err)
+		assert.Equal(t, expected, val)
+	}
+	val, err := f.GetCellValue("Report", "B2")
+	assert.NoError(t, err)
+	assert.Equal(t, "2", val)
+	mergeCells, err := f.GetMergeCells("Report")
+	assert.NoError(t, err)
+	assert.Len(t, mergeCells, 1)
+	ws, err := f.workSheetReader("Report")
+	assert.NoError(t, err)
+	assert.Len(t, ws.DataValidations.DataValidation, 1)
+	file, raw, err := f.GetPicture("Report", "D1")
+	assert.NoError(t, err)
+	assert.Equal(t, "image1.png", file)
+	assert.NotEmpty(t, raw)
+	assert.Equal(t, []string{"xl/charts/chart1.xml", "xl/charts/chart2.xml"}, func() (charts []string) {
+		f.Pkg.Range(func(k, v interface{}) bool {
+			if strings.HasPrefix(k.(string), "xl/charts/chart") {
+				charts = append(charts, k.(string))
+			}
+			return true
+		})
+		sort.Strings(charts)
+		return
+	}())
+	assert.Contains(t, string(f.readXML("xl/drawings/_rels/drawing2.xml.rels")), "../charts/chart2.xml")
+	assert.Contains(t, string(f.readXML("xl/tables/table2.xml")), `name="Table2"`)
+	chart := string(f.readXML("xl/charts/chart2.xml"))
+	assert.Contains(t, chart, "<f>&#39;Report&#39;!$A$2:$B$2</f>")
+	assert.NotContains(t, chart, "Template!")
+	formula, err := f.GetCellFormula("Report", "A8")
+	assert.NoError(t, err)
+	assert.Equal(t, "SUM(Table2[Column1])+'Report'!A2+MyTemplate!A2", formula)
+	formula, err = f.GetCellFormula("Template", "A8")
+	assert.NoError(t, err)
+	assert.Equal(t, "SUM(Sales[Column1])+Template!A2+MyTemplate!A2", formula)
+	// Test the copied pivot table has its own pivot cache
+	_, pt, err := f.getPivotTable("Report", "Pivot Table1")
+	assert.NoError(t, err)
+	_, sourcePT, err := f.getPivotTable("Template", "Pivot Table1")
+	assert.NoError(t, err)
+	assert.NotEqual(t, sourcePT.CacheID, pt.CacheID)
+	assert.Equal(t, "xl/pivotCache/pivotCacheDefinition2.xml", f.getRelsTargetPath("xl/pivotTables/pivotTable2.xml", SourceRelationshipPivotCache))
+	assert.Equal(t, "xl/pivotCache/pivotCacheRecords2.xml", f.getRelsTargetPath("xl/pivotCache/pivotCacheDefinition2.xml", SourceRelationshipPivotCacheRecords))
+	pc, err := f.pivotCacheDefinitionReader("xl/pivotCache/pivotCacheDefinition2.xml")
+	assert.NoError(t, err)
+	assert.Equal(t, "Report", pc.CacheSource.WorksheetSource.Sheet)
+	pc, err = f.pivotCacheDefinitionReader("xl/pivotCache/pivotCacheDefinition1.xml")
+	assert.NoError(t, err)
+	assert.Equal(t, "Template", pc.CacheSource.WorksheetSource.Sheet)
+	assert.NoError(t, f.RefreshPivotCache("Report", "Pivot Table1"))
+	comments := f.GetComments()
+	assert.Len(t, comments["Report"], 1)
+	threadedComments, err := f.GetThreadedComments("Template")
+	assert.NoError(t, err)
+	copiedThreadedComments, err := f.GetThreadedComments("Report")
+	assert.NoError(t, err)
+	assert.Len(t, copiedThreadedComments, 1)
+	assert.Equal(t, "Note", copiedThreadedComments[0].Text)
+	assert.NotEqual(t, threadedComments[0].ID, copiedThreadedComments[0].ID)
+	assert.Equal(t, "tc="+copiedThreadedComments[0].ID, comments["Report"][0].Author)
+	var printArea []DefinedName
+	for _, dn := range f.GetDefinedName() {
+		if dn.Name == "_xlnm.Print_Area" {
+			printArea = append(printArea, dn)
+		}
+	}
+	assert.Equal(t, []DefinedName{
+		{Name: "_xlnm.Print_Area", RefersTo: "Template!$A$1:$B$2", Scope: "Template"},
+		{Name: "_xlnm.Print_Area", RefersTo: "'Report'!$A$1:$B$2", Scope: "Report"},
+	}, printArea)
+	var refs []DefinedName
+	for _, dn := range f.GetDefinedName() {
+		if dn.Name == "Refs" {
+			refs = append(refs, dn)
+		}
+	}
+	assert.Equal(t, []DefinedName{
+		{Name: "Refs", RefersTo: "MyTemplate!$A$1,Template!$A$1", Scope: "Template"},
+		{Name: "Refs", RefersTo: "MyTemplate!$A$1,'Report'!$A$1", Scope: "Report"},
+	}, refs)
+	// Test copy worksheet from the opened workbook
+	assert.NoError(t, f.DeepCopySheet("Report", "Report2"))
+	file, _, err = f.GetPicture("Report2", "D1")
+	assert.NoError(t, err)
+	assert.Equal(t, "image1.png", file)
+	assert.Len(t, f.GetComments()["Report2"], 1)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestDeepCopySheet2.xlsx")))
+	assert.NoError(t, f.Close())
+	// Test delete the target worksheet and the copied parts on error
+	f = NewFile()
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{"Name", "Value"}))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A2", &[]interface{}{"A", 1}))
+	assert.NoError(t, f.AddPivotTable(&PivotTableOption{
+		DataRange:       "Sheet1!$A$1:$B$2",
+		PivotTableRange: "Sheet1!$D$1:$F$5",
+		Rows:            []PivotTableField{{Data: "Name"}},
+		Data:            []PivotTableField{{Data: "Value"}},
+	}))
+	assert.NoError(t, f.AddTable("Sheet1", "H1", "I3", `{"table_name":"Sales"}`))
+	f.Pkg.Store("xl/tables/table1.xml", MacintoshCyrillicCharset)
+	getParts := func() (parts []string) {
+		f.Pkg.Range(func(k, v interface{}) bool {
+			parts = append(parts, k.(string))
+			return true
+		})
+		sort.Strings(parts)
+		return
+	}
+	parts, contentTypes := getParts(), len(f.contentTypesReader().Overrides)
+	workbookRels := len(f.relsReader(f.getWorkbookRelsPath()).Relationships)
+	assert.EqualError(t, f.DeepCopySheet("Sheet1", "Report"), "XML syntax error on line 1: invalid UTF-8")
+	assert.Equal(t, -1, f.GetSheetIndex("Report"))
+	assert.Equal(t, []string{"Sheet1"}, f.GetSheetList())
+	assert.Equal(t, parts, getParts())
+	assert.Len(t, f.contentTypesReader().Overrides, contentTypes)
+	assert.Len(t, f.relsReader(f.getWorkbookRelsPath()).Relationships, workbookRels)
+	assert.Len(t, f.workbookReader().PivotCaches.PivotCache, 1)
+}
+
 func TestGetSheetComments(t *testing.T) {
 	f := NewFile()
 	assert.Equal(t, "", f.getSheetComments("sheet0"))
@@ -991,6 +1230,28 @@ func TestGetActiveSheetIndex(t *testing.T) {
 	f := NewFile()
 	f.WorkBook.BookViews = nil
 	assert.Equal(t, 0, f.GetActiveSheetIndex())
+	assert.Equal(t, "Sheet1", f.GetActiveSheetName())
+}
+
+func TestGetActiveSheetName(t *testing.T) {
+	f := NewFile()
+	f.NewSheet("Sheet2")
+	f.NewSheet("Sheet3")
+	f.SetActiveSheet(2)
+	assert.Equal(t, 2, f.GetActiveSheetIndex())
+	assert.Equal(t, "Sheet3", f.GetActiveSheetName())
+	// Test get active sheet with active tab out of range
+	f.WorkBook.BookViews.WorkBookView[0].ActiveTab = 5
+	assert.Equal(t, 0, f.GetActiveSheetIndex())
+	assert.Equal(t, "Sheet1", f.GetActiveSheetName())
+	// Test get active sheet with active tab points to a hidden sheet
+	f.WorkBook.Sheets.Sheet[0].State = "hidden"
+	f.WorkBook.BookViews.WorkBookView[0].ActiveTab = 0
+	assert.Equal(t, 1, f.GetActiveSheetIndex())
+	assert.Equal(t, "Sheet2", f.GetActiveSheetName())
+	// Test get active sheet name on the workbook without sheets
+	f.WorkBook.Sheets.Sheet = nil
+	assert.Equal(t, "", f.GetActiveSheetName())
 }
 
 func TestRelsWriter(t *testing.T) {
@@ -1140,6 +1401,49 @@ func TestSetSheetRow(t *testing.T) {
 	assert.NoError(t, f.Close())
 }
 
+func TestSetRowWithStyles(t *testing.T) {
+	f := NewFile()
+	dateStyle, err := f.NewStyle(&Style{NumFmt: 14})
+	assert.NoError(t, err)
+	currencyStyle, err := f.NewStyle(&Style{NumFmt: 4})
+	assert.NoError(t, err)
+	textStyle, err := f.NewStyle(&Style{NumFmt: 49})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "C2", "C2", textStyle))
+	assert.NoError(t, f.SetRowWithStyles("Sheet1", "A2", []interface{}{time.Date(2022, 8, 31, 0, 0, 0, 0, time.UTC), 12.5, "Office supplies"},
+		[]int{dateStyle, currencyStyle}))
+	for cell, expected := range map[string]struct {
+		value   string
+		styleID int
+	}{
+		"A2": {"08-31-22", dateStyle},
+		"B2": {"12.50", currencyStyle},
+		"C2": {"Office supplies", textStyle},
+	} {
+		val, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected.value, val, cell)
+		styleID, err := f.GetCellStyle("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected.styleID, styleID, cell)
+	}
+	// Test set row with styles keep the existing style of the middle cell
+	assert.NoError(t, f.SetCellStyle("Sheet1", "B3", "B3", textStyle))
+	assert.NoError(t, f.SetRowWithStyles("Sheet1", "A3", []interface{}{1, 2, 3}, []int{currencyStyle, -1, currencyStyle}))
+	for cell, expected := range map[string]int{"A3": currencyStyle, "B3": textStyle, "C3": currencyStyle} {
+		styleID, err := f.GetCellStyle("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, styleID, cell)
+	}
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetRowWithStyles.xlsx")))
+	// Test set row with styles with invalid parameters
+	assert.EqualError(t, f.SetRowWithStyles("Sheet1", "A", []interface{}{1}, []int{dateStyle}),
+		newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
+	assert.EqualError(t, f.SetRowWithStyles("Sheet1", "XFD1", []interface{}{1, 2}, nil), ErrColumnNumber.Error())
+	assert.EqualError(t, f.SetRowWithStyles("Sheet1", "A1", []interface{}{1}, []int{-2}), newInvalidStyleID(-2).Error())
+	assert.EqualError(t, f.SetRowWithStyles("SheetN", "A1", []interface{}{1}, []int{dateStyle}), "sheet SheetN is not exist")
+}
+
 func TestHSL(t *testing.T) {
 	var hsl HSL
 	r, g, b, a := hsl.RGBA()
@@ -14,6 +14,7 @@ package excelize
 import (
 	"archive/zip"
 	"bytes"
+	"compress/flate"
 	"encoding/xml"
 	"io"
 	"os"
@@ -66,7 +67,11 @@ func (f *File) Save() error {
 }
 
 // SaveAs provides a function to create or update to a spreadsheet at the
-// provided path.
+// provided path. For example, save the spreadsheet without compression for
+// inspecting the generated XML:
+//
+//	level := 0
+//	err := f.SaveAs("Book1.xlsx", excelize.Options{CompressionLevel: &level})
 func (f *File) SaveAs(name string, opt ...Options) error {
 	if len(name) > MaxFilePathLength {
 		return ErrMaxFilePathLength
@@ -137,7 +142,10 @@ func (f *File) WriteTo(w io.Writer) (int64, error) {
 // and it allocates space in memory. Be careful when the file size is large.
 func (f *File) WriteToBuffer() (*bytes.Buffer, error) {
 	buf := new(bytes.Buffer)
-	zw := zip.NewWriter(buf)
+	if err := f.checkCompressionLevel(); err != nil {
+		return buf, err
+	}
+	zw := f.newZipWriter(buf)
 
 	if err := f.writeToZip(zw); err != nil {
 		return buf, zw.Close()
@@ -160,7 +168,10 @@ func (f *File) WriteToBuffer() (*bytes.Buffer, error) {
 
 // writeDirectToWriter provides a function to write to io.Writer.
 func (f *File) writeDirectToWriter(w io.Writer) error {
-	zw := zip.NewWriter(w)
+	if err := f.checkCompressionLevel(); err != nil {
+		return err
+	}
+	zw := f.newZipWriter(w)
 	if err := f.writeToZip(zw); err != nil {
 		_ = zw.Close()
 		return err
@@ -168,6 +179,48 @@ func (f *File) writeDirectToWriter(w io.Writer) error {
 	return zw.Close()
 }
 
+// getCompressionLevel provides a function to get the compression level of
+// the zip writer for saving the spreadsheet, the value -1 means use the
+// default compression level.
+func (f *File) getCompressionLevel() int {
+	if f.options != nil && f.options.CompressionLevel != nil {
+		return *f.options.CompressionLevel
+	}
+	return flate.DefaultCompression
+}
+
+// checkCompressionLevel provides a function to check the compression level
+// of the zip writer for saving the spreadsheet.
+func (f *File) checkCompressionLevel() error {
+	if level := f.getCompressionLevel(); level < flate.DefaultCompression || level > flate.BestCompression {
+		return ErrCompressionLevel
+	}
+	return nil
+}
+
+// newZipWriter provides a function to create a zip.Writer with the deflate
+// compressor of the specified compression level.
+func (f *File) newZipWriter(w io.Writer) *zip.Writer {
+	zw := zip.NewWriter(w)
+	if level := f.getCompressionLevel(); level > flate.NoCompression && level <= flate.BestCompression {
+		zw.RegisterCompressor(zip.Deflate, func(out io.Writer) (io.WriteCloser, error) {
+			return flate.NewWriter(out, level)
+		})
+	}
+	return zw
+}
+
+// createZipEntry provides a function to add a file to the zip.Writer by
+// given path, the file will be stored without compression when the
+// compression level is 0.
+func (f *File) createZipEntry(zw *zip.Writer, path string) (io.Writer, error) {
+	header := &zip.FileHeader{Name: path, Method: zip.Deflate}
+	if f.getCompressionLevel() == flate.NoCompression {
+		header.Method = zip.Store
+	}
+	return zw.CreateHeader(header)
+}
+
 // writeToZip provides a function to write to zip.Writer
 func (f *File) writeToZip(zw *zip.Writer) error {
 	f.calcChainWriter()
@@ -183,7 +236,7 @@ func (f *File) writeToZip(zw *zip.Writer) error {
 	f.styleSheetWriter()
 
 	for path, stream := range f.streams {
-		fi, err := zw.Create(path)
+		fi, err := f.createZipEntry(zw, path)
 		if err != nil {
 			return err
 		}
@@ -208,7 +261,7 @@ func (f *File) writeToZip(zw *zip.Writer) error {
 			return true
 		}
 		var fi io.Writer
-		fi, err = zw.Create(path.(string))
+		fi, err = f.createZipEntry(zw, path.(string))
 		if err != nil {
 			return false
 		}
@@ -220,7 +273,7 @@ func (f *File) writeToZip(zw *zip.Writer) error {
 			return true
 		}
 		var fi io.Writer
-		fi, err = zw.Create(path.(string))
+		fi, err = f.createZipEntry(zw, path.(string))
 		if err != nil {
 			return false
 		}
@@ -1,9 +1,12 @@
 package excelize
 
 import (
+	"archive/zip"
 	"bufio"
 	"bytes"
 	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
 	"sync"
 	"testing"
@@ -78,3 +81,50 @@ func TestClose(t *testing.T) {
 	f.tempFiles.Store("/d/", "/d/")
 	require.Error(t, f.Close())
 }
+
+func TestCompressionLevel(t *testing.T) {
+	newBook := func() *File {
+		f := NewFile()
+		for row := 1; row <= 100; row++ {
+			assert.NoError(t, f.SetSheetRow("Sheet1", "A"+strconv.Itoa(row), &[]string{"This is test data", "This is test data"}))
+		}
+		return f
+	}
+	sizes := make(map[int]int)
+	for _, level := range []int{0, 1, 9} {
+		level := level
+		f := newBook()
+		f.options = &Options{CompressionLevel: &level}
+		buf, err := f.WriteToBuffer()
+		assert.NoError(t, err)
+		sizes[level] = buf.Len()
+		zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		assert.NoError(t, err)
+		for _, file := range zr.File {
+			if level == 0 {
+				assert.Equal(t, zip.Store, file.Method)
+				continue
+			}
+			assert.Equal(t, zip.Deflate, file.Method)
+		}
+		// Test read the saved spreadsheet
+		f, err = OpenReader(buf)
+		assert.NoError(t, err)
+		cell, err := f.GetCellValue("Sheet1", "B100")
+		assert.NoError(t, err)
+		assert.Equal(t, "This is test data", cell)
+	}
+	assert.Greater(t, sizes[0], sizes[1])
+	assert.GreaterOrEqual(t, sizes[1], sizes[9])
+	// Test save spreadsheet without compression
+	level := 0
+	assert.NoError(t, newBook().SaveAs(filepath.Join("test", "TestCompressionLevel.xlsx"), Options{CompressionLevel: &level}))
+	// Test save spreadsheet with invalid compression level
+	for _, level := range []int{-2, 10} {
+		level := level
+		f := newBook()
+		assert.EqualError(t, f.SaveAs(filepath.Join("test", "TestCompressionLevel.xlsx"), Options{CompressionLevel: &level}), ErrCompressionLevel.Error())
+		_, err := f.WriteToBuffer()
+		assert.EqualError(t, err, ErrCompressionLevel.Error())
+	}
+}
@@ -23,6 +23,7 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"unicode"
 )
 
 // ReadZipReader extract spreadsheet with given options.
@@ -126,6 +127,15 @@ func (f *File) readTemp(name string) (file *os.File, err error) {
 	return
 }
 
+// readXMLContent provides a function to read the content of the XML part in
+// the transitional namespace without the XML declaration by given part path,
+// which can be patched and saved by the saveFileList function.
+func (f *File) readXMLContent(name string) (string, error) {
+	content := string(namespaceStrictToTransitional(f.readXML(name)))
+	_, start, _, err := getXMLStartElement(content)
+	return content[start:], err
+}
+
 // saveFileList provides a function to update given file content in file list
 // of spreadsheet.
 func (f *File) saveFileList(name string, content []byte) {
@@ -225,22 +235,79 @@ func ColumnNumberToName(num int) (string, error) {
 	if num < MinColumns || num > MaxColumns {
 		return "", ErrColumnNumber
 	}
-	var col string
-	for num > 0 {
-		col = string(rune((num-1)%26+65)) + col
+	if num <= 26 {
+		return columnLetters[num-1 : num], nil
+	}
+	var buf [3]byte
+	return string(appendColumnName(buf[:0], num)), nil
+}
+
+// columnLetters defined the names of the first 26 columns, used to get the
+// single letter column name without allocation.
+const columnLetters = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+
+// appendColumnName appends the column name of the given column number to the
+// byte slice and returns the extended slice.
+func appendColumnName(dst []byte, num int) []byte {
+	var buf [3]byte
+	i := len(buf)
+	for num > 0 && i > 0 {
+		i--
+		buf[i] = byte((num-1)%26 + 'A')
 		num = (num - 1) / 26
 	}
-	return col, nil
+	return append(dst, buf[i:]...)
+}
+
+// ColumnNamesToNumbers provides a function to convert a batch of Excel sheet
+// column names (case-insensitive) to int. The function returns an error if
+// any column name incorrect.
+//
+// Example:
+//
+//	excelize.ColumnNamesToNumbers([]string{"A", "AK"}) // returns []int{1, 37}, nil
+func ColumnNamesToNumbers(names []string) ([]int, error) {
+	nums := make([]int, len(names))
+	for i, name := range names {
+		num, err := ColumnNameToNumber(name)
+		if err != nil {
+			return nil, err
+		}
+		nums[i] = num
+	}
+	return nums, nil
+}
+
+// ColumnNumbersToNames provides a function to convert a batch of integers to
+// Excel sheet column titles.
+//
+// Example:
+//
+//	excelize.ColumnNumbersToNames([]int{1, 37}) // returns []string{"A", "AK"}, nil
+func ColumnNumbersToNames(nums []int) ([]string, error) {
+	names := make([]string, len(nums))
+	for i, num := range nums {
+		name, err := ColumnNumberToName(num)
+		if err != nil {
+			return nil, err
+		}
+		names[i] = name
+	}
+	return names, nil
 }
 
 // CellNameToCoordinates converts alphanumeric cell name to [X, Y] coordinates
-// or returns an error.
+// or returns an error. The conversion of a valid cell name doesn't allocate,
+// so it's suitable for processing a huge number of cell references.
 //
 // Example:
 //
 //	excelize.CellNameToCoordinates("A1") // returns 1, 1, nil
 //	excelize.CellNameToCoordinates("Z3") // returns 26, 3, nil
 func CellNameToCoordinates(cell string) (int, int, error) {
+	if col, row, ok := parseCellName(cell); ok {
+		return col, row, nil
+	}
 	colName, row, err := SplitCellName(cell)
 	if err != nil {
 		return -1, -1, newCellNameToCoordinatesError(cell, err)
@@ -252,6 +319,43 @@ func CellNameToCoordinates(cell string) (int, int, error) {
 	return col, row, err
 }
 
+// parseCellName parses the well-formed cell name without allocation, such as
+// "A1" or "$A$1". The returned flag is false when the cell name needs to be
+// validated by the full parser.
+func parseCellName(cell string) (col, row int, ok bool) {
+	i, letters := 0, 0
+	for ; i < len(cell); i++ {
+		c := cell[i]
+		if c == '$' {
+			continue
+		}
+		if 'a' <= c && c <= 'z' {
+			c -= 'a' - 'A'
+		}
+		if c < 'A' || c > 'Z' {
+			break
+		}
+		if col = col*26 + int(c-'A'+1); col > MaxColumns {
+			return
+		}
+		letters++
+	}
+	if letters == 0 || i == len(cell) {
+		return
+	}
+	for ; i < len(cell); i++ {
+		c := cell[i]
+		if c < '0' || c > '9' {
+			return
+		}
+		if row = row*10 + int(c-'0'); row > TotalRows {
+			return
+		}
+	}
+	ok = row > 0
+	return
+}
+
 // CoordinatesToCellName converts [X, Y] coordinates to alpha-numeric cell
 // name or returns an error.
 //
@@ -263,14 +367,25 @@ func CoordinatesToCellName(col, row int, abs ...bool) (string, error) {
 	if col < 1 || row < 1 {
 		return "", fmt.Errorf("invalid cell coordinates [%d, %d]", col, row)
 	}
-	sign := ""
+	if col > MaxColumns {
+		return "", ErrColumnNumber
+	}
+	var absolute bool
 	for _, a := range abs {
 		if a {
-			sign = "$"
+			absolute = true
 		}
 	}
-	colName, err := ColumnNumberToName(col)
-	return sign + colName + sign + strconv.Itoa(row), err
+	var buf [24]byte
+	cell := buf[:0]
+	if absolute {
+		cell = append(cell, '$')
+	}
+	cell = appendColumnName(cell, col)
+	if absolute {
+		cell = append(cell, '$')
+	}
+	return string(strconv.AppendInt(cell, int64(row), 10)), nil
 }
 
 // areaRefToCoordinates provides a function to convert area reference to a
@@ -283,6 +398,21 @@ func areaRefToCoordinates(ref string) ([]int, error) {
 	return areaRangeToCoordinates(rng[0], rng[1])
 }
 
+// rangeRefToCoordinates provides a function to convert the range reference
+// or the single cell reference to the sorted coordinates, such convert B2 to
+// [2, 2, 2, 2] and C3:A1 to [1, 1, 3, 3].
+func rangeRefToCoordinates(ref string) ([]int, error) {
+	if !strings.Contains(ref, ":") {
+		ref += ":" + ref
+	}
+	coordinates, err := areaRefToCoordinates(ref)
+	if err != nil {
+		return coordinates, err
+	}
+	_ = sortCoordinates(coordinates)
+	return coordinates, err
+}
+
 // areaRangeToCoordinates provides a function to convert cell range to a
 // pair of coordinates.
 func areaRangeToCoordinates(firstCell, lastCell string) ([]int, error) {
@@ -330,64 +460,113 @@ func (f *File) coordinatesToAreaRef(coordinates []int) (string, error) {
 
 // getDefinedNameRefTo convert defined name to reference range.
 func (f *File) getDefinedNameRefTo(definedNameName string, currentSheet string) (refTo string) {
-	var workbookRefTo, worksheetRefTo string
-	for _, definedName := range f.GetDefinedName() {
-		if definedName.Name == definedNameName {
-			// worksheet scope takes precedence over scope workbook when both definedNames exist
-			if definedName.Scope == "Workbook" {
-				workbookRefTo = definedName.RefersTo
-			}
-			if definedName.Scope == currentSheet {
-				worksheetRefTo = definedName.RefersTo
-			}
-		}
-	}
-	refTo = workbookRefTo
-	if worksheetRefTo != "" {
-		refTo = worksheetRefTo
+	var err error
+	if refTo, err = f.ResolveDefinedName(definedNameName, currentSheet); err != nil {
+		refTo, _ = f.ResolveDefinedName(definedNameName, "")
 	}
 	return
 }
 
-// flatSqref convert reference sequence to cell coordinates list.
-func (f *File) flatSqref(sqref string) (cells map[int][][]int, err error) {
-	var coordinates []int
-	cells = make(map[int][][]int)
-	for _, ref := range strings.Fields(sqref) {
-		rng := strings.Split(ref, ":")
-		switch len(rng) {
-		case 1:
-			var col, row int
-			col, row, err = CellNameToCoordinates(rng[0])
-			if err != nil {
-				return
+// adjustFormulaReferences provides a function to update the worksheet names
+// and the table names referenced in the formula by given mapping of the old
+// and new worksheet names and table names, the keys of the mappings should be
+// in lower case. The quoted and unquoted worksheet names followed by "!" and
+// the table names followed by "[" are matched as whole names, the string
+// literals, the content in the brackets and the references to the external
+// workbooks will be kept as it is. The new worksheet names will be quoted.
+// For example, "=MySheet1!A1+Sheet1!A1&\"Sheet1!\"" will be updated to
+// "=MySheet1!A1+'Report'!A1&\"Sheet1!\"" by the mapping of "sheet1" to
+// "Report".
+func adjustFormulaReferences(formula string, sheets, tables map[string]string) string {
+	if formula == "" || (len(sheets) == 0 && len(tables) == 0) {
+		return formula
+	}
+	isNameRune := func(r rune) bool {
+		return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '.'
+	}
+	var (
+		b     strings.Builder
+		runes = []rune(formula)
+	)
+	for i := 0; i < len(runes); {
+		switch r := runes[i]; {
+		case r == '"' || r == '\'':
+			j := i + 1
+			for ; j < len(runes); j++ {
+				if runes[j] == r {
+					if j+1 < len(runes) && runes[j+1] == r {
+						j++
+						continue
+					}
+					j++
+					break
+				}
 			}
-			cells[col] = append(cells[col], []int{col, row})
-		case 2:
-			if coordinates, err = areaRefToCoordinates(ref); err != nil {
-				return
+			if r == '\'' && j < len(runes) && runes[j] == '!' && j-i > 1 {
+				name := strings.ReplaceAll(string(runes[i+1:j-1]), "''", "'")
+				if newName, ok := sheets[strings.ToLower(name)]; ok {
+					b.WriteString("'" + strings.ReplaceAll(newName, "'", "''") + "'")
+					i = j
+					continue
+				}
 			}
-			_ = sortCoordinates(coordinates)
-			for c := coordinates[0]; c <= coordinates[2]; c++ {
-				for r := coordinates[1]; r <= coordinates[3]; r++ {
-					cells[c] = append(cells[c], []int{c, r})
+			b.WriteString(string(runes[i:j]))
+			i = j
+		case r == '[':
+			j, depth := i, 0
+			for ; j < len(runes); j++ {
+				if runes[j] == '\'' {
+					j++
+					continue
+				}
+				if runes[j] == '[' {
+					depth++
+				}
+				if runes[j] == ']' {
+					if depth--; depth == 0 {
+						j++
+						break
+					}
 				}
 			}
+			if j > len(runes) {
+				j = len(runes)
+			}
+			b.WriteString(string(runes[i:j]))
+			i = j
+		case isNameRune(r):
+			j := i
+			for j < len(runes) && isNameRune(runes[j]) {
+				j++
+			}
+			name := strings.ToLower(string(runes[i:j]))
+			if newName, ok := sheets[name]; ok && j < len(runes) && runes[j] == '!' && (i == 0 || runes[i-1] != ']') {
+				b.WriteString("'" + strings.ReplaceAll(newName, "'", "''") + "'")
+				i = j
+				continue
+			}
+			if newName, ok := tables[name]; ok && j < len(runes) && runes[j] == '[' {
+				b.WriteString(newName)
+				i = j
+				continue
+			}
+			b.WriteString(string(runes[i:j]))
+			i = j
+		default:
+			b.WriteRune(r)
+			i++
 		}
 	}
-	return
+	return b.String()
 }
 
-// inCoordinates provides a method to check if a coordinate is present in
-// coordinates array, and return the index of its location, otherwise
-// return -1.
-func inCoordinates(a [][]int, x []int) int {
-	for idx, n := range a {
-		if x[0] == n[0] && x[1] == n[1] {
-			return idx
-		}
-	}
-	return -1
+// isFormulaReferencesSheet provides a function to check if the formula
+// references the worksheet by given formula and worksheet name, the worksheet
+// name is case-insensitive.
+func isFormulaReferencesSheet(formula, sheet string) bool {
+	// The colon isn't allowed in the worksheet name, so the formula will be
+	// changed only if the worksheet is referenced.
+	return adjustFormulaReferences(formula, map[string]string{strings.ToLower(sheet): ":"}, nil) != formula
 }
 
 // inStrSlice provides a method to check if an element is present in an array,
@@ -689,6 +868,200 @@ func (f *File) addSheetNameSpace(sheet string, ns xml.Attr) {
 	f.addNameSpaces(name, ns)
 }
 
+// xmlElementRange specifies the name and the position of an element in the
+// XML content.
+type xmlElementRange struct {
+	name       xml.Name
+	start, end int
+}
+
+// getXMLElements provides a function to get the name and position of the
+// elements in the XML content at the given depth, the elements which nested
+// deeper will be included if the depth is negative.
+func getXMLElements(content string, depth int) ([]xmlElementRange, error) {
+	var (
+		elements []xmlElementRange
+		stack    []xmlElementRange
+		d        = xml.NewDecoder(strings.NewReader(content))
+	)
+	for {
+		start := int(d.InputOffset())
+		token, err := d.RawToken()
+		if err == io.EOF {
+			return elements, nil
+		}
+		if err != nil {
+			return elements, err
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			stack = append(stack, xmlElementRange{name: t.Name, start: start})
+		case xml.EndElement:
+			if len(stack) == 0 || stack[len(stack)-1].name != t.Name {
+				return elements, ErrParameterInvalid
+			}
+			el := stack[len(stack)-1]
+			if stack = stack[:len(stack)-1]; depth < 0 || len(stack) == depth {
+				el.end = int(d.InputOffset())
+				elements = append(elements, el)
+			}
+		}
+	}
+}
+
+// getXMLChildElements provides a function to get the position of the child
+// elements with the given local name of the first element in the XML
+// content.
+func getXMLChildElements(content, name string) ([]xmlElementRange, error) {
+	var children []xmlElementRange
+	elements, err := getXMLElements(content, 1)
+	for _, el := range elements {
+		if el.name.Local == name {
+			children = append(children, el)
+		}
+	}
+	return children, err
+}
+
+// getXMLStartElement provides a function to get the first start element and
+// the position of its start tag in the XML content.
+func getXMLStartElement(content string) (xml.StartElement, int, int, error) {
+	d := xml.NewDecoder(strings.NewReader(content))
+	for {
+		start := int(d.InputOffset())
+		token, err := d.RawToken()
+		if err == io.EOF {
+			return xml.StartElement{}, start, start, ErrParameterInvalid
+		}
+		if err != nil {
+			return xml.StartElement{}, start, start, err
+		}
+		if t, ok := token.(xml.StartElement); ok {
+			return t, start, int(d.InputOffset()), err
+		}
+	}
+}
+
+// getXMLRawName returns the name with the namespace prefix of the element or
+// attribute which was read by the raw token of the XML decoder.
+func getXMLRawName(name xml.Name) string {
+	if name.Space == "" {
+		return name.Local
+	}
+	return name.Space + ":" + name.Local
+}
+
+// setXMLElementAttrs provides a function to set the attributes of the first
+// element in the XML content by given attributes, and keeps the rest of the
+// content as it is. The value of the existing attribute will be replaced,
+// and the others will be appended. The namespace of the attribute should be
+// declared in the element or be given by the attributes in the "xmlns"
+// space. For example, set the relationship ID of the element:
+//
+//	content, err := setXMLElementAttrs(content, []xml.Attr{
+//	    SourceRelationship,
+//	    {Name: xml.Name{Space: SourceRelationship.Value, Local: "id"}, Value: "rId1"},
+//	})
+func setXMLElementAttrs(content string, attrs []xml.Attr) (string, error) {
+	element, start, end, err := getXMLStartElement(content)
+	if err != nil {
+		return content, err
+	}
+	getPrefix := func(space string) (string, bool) {
+		for _, attr := range element.Attr {
+			if attr.Name.Space == "xmlns" && attr.Value == space {
+				return attr.Name.Local, true
+			}
+		}
+		return "", false
+	}
+	for _, attr := range attrs {
+		name := xml.Name{Local: attr.Name.Local}
+		if attr.Name.Space == "xmlns" {
+			if _, ok := getPrefix(attr.Value); ok {
+				continue
+			}
+			name.Space = attr.Name.Space
+		} else if attr.Name.Space != "" {
+			prefix, ok := getPrefix(attr.Name.Space)
+			if !ok {
+				return content, ErrParameterInvalid
+			}
+			name.Space = prefix
+		}
+		idx := len(element.Attr)
+		for i, a := range element.Attr {
+			if a.Name == name {
+				idx = i
+				break
+			}
+		}
+		if idx == len(element.Attr) {
+			element.Attr = append(element.Attr, xml.Attr{Name: name})
+		}
+		element.Attr[idx].Value = attr.Value
+	}
+	var b strings.Builder
+	b.WriteString("<" + getXMLRawName(element.Name))
+	for _, attr := range element.Attr {
+		b.WriteString(" " + getXMLRawName(attr.Name) + "=\"")
+		if err = xml.EscapeText(&b, []byte(attr.Value)); err != nil {
+			return content, err
+		}
+		b.WriteString("\"")
+	}
+	if strings.HasSuffix(content[start:end], "/>") {
+		b.WriteString("/")
+	}
+	b.WriteString(">")
+	return content[:start] + b.String() + content[end:], err
+}
+
+// replaceXMLChildElement provides a function to replace the first child
+// element with the given local name of the first element in the XML content
+// by given new child element, the new child element will be inserted as the
+// first child if the child element doesn't exist.
+func replaceXMLChildElement(content, name, child string) (string, error) {
+	children, err := getXMLElements(content, 1)
+	if err != nil {
+		return content, err
+	}
+	for _, el := range children {
+		if el.name.Local == name {
+			return content[:el.start] + child + content[el.end:], err
+		}
+	}
+	element, start, end, err := getXMLStartElement(content)
+	if err != nil {
+		return content, err
+	}
+	if strings.HasSuffix(content[start:end], "/>") {
+		return content[:end-2] + ">" + child + "</" + getXMLRawName(element.Name) + ">" + content[end:], err
+	}
+	return content[:end] + child + content[end:], err
+}
+
+// appendXMLChildElement provides a function to append the given child element
+// as the last child of the first element in the XML content.
+func appendXMLChildElement(content, child string) (string, error) {
+	element, start, end, err := getXMLStartElement(content)
+	if err != nil {
+		return content, err
+	}
+	if strings.HasSuffix(content[start:end], "/>") {
+		return content[:end-2] + ">" + child + "</" + getXMLRawName(element.Name) + ">" + content[end:], err
+	}
+	elements, err := getXMLElements(content, 0)
+	if err != nil {
+		return content, err
+	}
+	if len(elements) == 0 {
+		return content, ErrParameterInvalid
+	}
+	offset := strings.LastIndex(content[:elements[0].end], "</")
+	return content[:offset] + child + content[offset:], err
+}
+
 // isNumeric determines whether an expression is a valid numeric type and get
 // the precision for the numeric.
 func isNumeric(s string) (bool, int) {
@@ -738,6 +1111,9 @@ var (
 // initial underscore shall itself be escaped (i.e. stored as _x005F_). For
 // example: The string literal _x0008_ would be stored as _x005F_x0008_.
 func bstrUnmarshal(s string) (result string) {
+	if !strings.Contains(s, "_x") {
+		return s
+	}
 	matches, l, cursor := bstrExp.FindAllStringSubmatchIndex(s, -1), len(s), 0
 	for _, match := range matches {
 		result += s[cursor:match[0]]
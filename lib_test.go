@@ -217,6 +217,67 @@ func TestCoordinatesToCellName_Error(t *testing.T) {
 	}
 }
 
+func TestColumnNamesToNumbers(t *testing.T) {
+	names := make([]string, len(validColumns))
+	for i, col := range validColumns {
+		names[i] = col.Name
+	}
+	nums, err := ColumnNamesToNumbers(names)
+	assert.NoError(t, err)
+	for i, col := range validColumns {
+		assert.Equal(t, col.Num, nums[i], col.Name)
+	}
+	names, err = ColumnNumbersToNames(nums)
+	assert.NoError(t, err)
+	for i, col := range validColumns {
+		assert.Equal(t, strings.ToUpper(col.Name), names[i], col.Num)
+	}
+	nums, err = ColumnNamesToNumbers(nil)
+	assert.NoError(t, err)
+	assert.Empty(t, nums)
+	// Test convert batch with invalid column
+	_, err = ColumnNamesToNumbers([]string{"A", "1A"})
+	assert.EqualError(t, err, newInvalidColumnNameError("1A").Error())
+	_, err = ColumnNumbersToNames([]int{1, MaxColumns + 1})
+	assert.EqualError(t, err, ErrColumnNumber.Error())
+}
+
+func TestCellNameCoordinatesAllocs(t *testing.T) {
+	for _, cell := range []string{"A1", "$XFD$1048576", "ak37", "A$1"} {
+		assert.Zero(t, testing.AllocsPerRun(100, func() {
+			_, _, _ = CellNameToCoordinates(cell)
+		}), cell)
+	}
+	col, row, err := CellNameToCoordinates("$XFD$1048576")
+	assert.NoError(t, err)
+	assert.Equal(t, []int{MaxColumns, TotalRows}, []int{col, row})
+	assert.Zero(t, testing.AllocsPerRun(100, func() {
+		_, _ = ColumnNumberToName(26)
+	}))
+	assert.Equal(t, 1.0, testing.AllocsPerRun(100, func() {
+		_, _ = CoordinatesToCellName(MaxColumns, TotalRows, true)
+	}))
+	cell, err := CoordinatesToCellName(MaxColumns, TotalRows, true)
+	assert.NoError(t, err)
+	assert.Equal(t, "$XFD$1048576", cell)
+	_, err = CoordinatesToCellName(MaxColumns+1, 1)
+	assert.EqualError(t, err, ErrColumnNumber.Error())
+}
+
+func BenchmarkCellNameToCoordinates(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, _, _ = CellNameToCoordinates("$AK$1048576")
+	}
+}
+
+func BenchmarkCoordinatesToCellName(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, _ = CoordinatesToCellName(37, 1048576)
+	}
+}
+
 func TestCoordinatesToAreaRef(t *testing.T) {
 	f := NewFile()
 	_, err := f.coordinatesToAreaRef([]int{})
@@ -234,6 +295,17 @@ func TestSortCoordinates(t *testing.T) {
 	assert.EqualError(t, sortCoordinates(make([]int, 3)), ErrCoordinates.Error())
 }
 
+func TestRangeRefToCoordinates(t *testing.T) {
+	coordinates, err := rangeRefToCoordinates("$B$2")
+	assert.NoError(t, err)
+	assert.Equal(t, []int{2, 2, 2, 2}, coordinates)
+	coordinates, err = rangeRefToCoordinates("C3:A1")
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 1, 3, 3}, coordinates)
+	_, err = rangeRefToCoordinates("A")
+	assert.EqualError(t, err, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
+}
+
 func TestInStrSlice(t *testing.T) {
 	assert.EqualValues(t, -1, inStrSlice([]string{}, "", true))
 }
@@ -373,3 +445,82 @@ func TestUnzipToTemp(t *testing.T) {
 	_, err = f.unzipToTemp(z.File[0])
 	assert.EqualError(t, err, "EOF")
 }
+
+func TestAdjustFormulaReferences(t *testing.T) {
+	sheets, tables := map[string]string{"sheet1": "Report", "my sheet": "It's"}, map[string]string{"sales": "Table2"}
+	for _, c := range [][]string{
+		{"", ""},
+		{"Sheet1!A1+MySheet1!A1+Sheet10!A1", "'Report'!A1+MySheet1!A1+Sheet10!A1"},
+		{"SHEET1!A1&\"Sheet1!A1\"&'My Sheet'!A1&'My Sheet1'!A1", "'Report'!A1&\"Sheet1!A1\"&'It''s'!A1&'My Sheet1'!A1"},
+		{"SUM(Sales[Sales],MySales[Sales],Sales[[#This Row],[Sheet1!]])", "SUM(Table2[Sales],MySales[Sales],Table2[[#This Row],[Sheet1!]])"},
+		{"[1]Sheet1!A1+'[1]Sheet1'!A1+Sheet1.A!A1", "[1]Sheet1!A1+'[1]Sheet1'!A1+Sheet1.A!A1"},
+		{"SUM(Sheet1:Sheet3!A1)+\"unterminated", "SUM(Sheet1:Sheet3!A1)+\"unterminated"},
+		{"Sales[[#This Row],['[Sales']]", "Table2[[#This Row],['[Sales']]"},
+	} {
+		assert.Equal(t, c[1], adjustFormulaReferences(c[0], sheets, tables), c[0])
+	}
+	assert.Equal(t, "Sheet1!A1", adjustFormulaReferences("Sheet1!A1", nil, nil))
+}
+
+func TestIsFormulaReferencesSheet(t *testing.T) {
+	for formula, expected := range map[string]bool{
+		"Sheet1!A1":             true,
+		"'sheet1'!A1":           true,
+		"MySheet1!A1":           false,
+		"'My Sheet1'!A1":        false,
+		"\"Sheet1!\"&Sheet2!A1": false,
+		"[1]Sheet1!A1":          false,
+	} {
+		assert.Equal(t, expected, isFormulaReferencesSheet(formula, "Sheet1"), formula)
+	}
+}
+
+func TestGetXMLElements(t *testing.T) {
+	_, err := getXMLElements("<a><b", 0)
+	assert.EqualError(t, err, "XML syntax error on line 1: unexpected EOF")
+	_, err = getXMLElements("</a>", 0)
+	assert.EqualError(t, err, ErrParameterInvalid.Error())
+}
+
+func TestSetXMLElementAttrs(t *testing.T) {
+	content, err := setXMLElementAttrs(`<?xml version="1.0"?><a:b xmlns:a="a" c="1"/>`, []xml.Attr{
+		{Name: xml.Name{Local: "c"}, Value: "<2>"},
+		SourceRelationship,
+		{Name: xml.Name{Space: SourceRelationship.Value, Local: "id"}, Value: "rId1"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, `<?xml version="1.0"?><a:b xmlns:a="a" c="&lt;2&gt;" xmlns:r="`+SourceRelationship.Value+`" r:id="rId1"/>`, content)
+	_, err = setXMLElementAttrs(`<a/>`, []xml.Attr{{Name: xml.Name{Space: SourceRelationship.Value, Local: "id"}}})
+	assert.EqualError(t, err, ErrParameterInvalid.Error())
+	_, err = setXMLElementAttrs("", nil)
+	assert.EqualError(t, err, ErrParameterInvalid.Error())
+	_, err = setXMLElementAttrs("<a", nil)
+	assert.EqualError(t, err, "XML syntax error on line 1: unexpected EOF")
+
+	content, err = replaceXMLChildElement(`<a:b xmlns:a="a"/>`, "c", "<c/>")
+	assert.NoError(t, err)
+	assert.Equal(t, `<a:b xmlns:a="a"><c/></a:b>`, content)
+	content, err = replaceXMLChildElement(`<a><b/><c>1</c><c>2</c></a>`, "c", "<c/>")
+	assert.NoError(t, err)
+	assert.Equal(t, `<a><b/><c/><c>2</c></a>`, content)
+	content, err = replaceXMLChildElement(`<a><b/></a>`, "c", "<c/>")
+	assert.NoError(t, err)
+	assert.Equal(t, `<a><c/><b/></a>`, content)
+	_, err = replaceXMLChildElement("</a>", "c", "<c/>")
+	assert.EqualError(t, err, ErrParameterInvalid.Error())
+	_, err = replaceXMLChildElement("", "c", "<c/>")
+	assert.EqualError(t, err, ErrParameterInvalid.Error())
+
+	content, err = appendXMLChildElement(`<a:b xmlns:a="a"/>`, "<c/>")
+	assert.NoError(t, err)
+	assert.Equal(t, `<a:b xmlns:a="a"><c/></a:b>`, content)
+	content, err = appendXMLChildElement(`<a><b></b></a><d/>`, "<c/>")
+	assert.NoError(t, err)
+	assert.Equal(t, `<a><b></b><c/></a><d/>`, content)
+	_, err = appendXMLChildElement("<a>", "<c/>")
+	assert.EqualError(t, err, ErrParameterInvalid.Error())
+	_, err = appendXMLChildElement("<a></b>", "<c/>")
+	assert.EqualError(t, err, ErrParameterInvalid.Error())
+	_, err = appendXMLChildElement("", "<c/>")
+	assert.EqualError(t, err, ErrParameterInvalid.Error())
+}
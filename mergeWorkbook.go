@@ -0,0 +1,714 @@
+// Copyright 2016 - 2022 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to and
+// read from XLAM / XLSM / XLSX / XLTM / XLTX files. Supports reading and
+// writing spreadsheet documents generated by Microsoft Excel™ 2007 and later.
+// Supports complex components by high compatibility, and provided streaming
+// API for generating or reading data from a worksheet with huge amounts of
+// data. This library needs Go version 1.15 or later.
+
+package excelize
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"path"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/mohae/deepcopy"
+)
+
+// workbookMerger directly maps the state of merging worksheets from the
+// source workbook into the target workbook, including the mapping of the
+// style, differential format, shared string and part indexes between them,
+// and the renamed worksheets and tables keyed by the old names in lower case.
+type workbookMerger struct {
+	dst, src *File
+	sheets   map[string]string
+	tables   map[string]string
+	styles   map[int]int
+	dxfs     map[int]int
+	strings  map[int]int
+	parts    map[string]string
+}
+
+// MergeWorkbook provides a function to copy the worksheets by given sheet
+// names from the source workbook into the target workbook. All worksheets of
+// the source workbook will be copied if the given sheet names list is empty.
+// The cell styles, conditional formats and table styles will be remapped to
+// the styles of the target workbook, the shared strings will be added into
+// the shared string table of the target workbook, and the drawings, pictures,
+// charts, comments and tables will be duplicated with the worksheets. If a
+// sheet name already exists in the target workbook, the copied worksheet will
+// be renamed with a sequence number suffix like "Sheet1 (2)", and the
+// references to it in formulas, conditional formats, hyperlinks, data
+// validations, charts and defined names will be updated. If a table name
+// already exists in the target workbook, the copied table will be renamed
+// like "Table2", and the structured references to it will be updated as
+// well. The defined names scoped to the copied worksheets will be scoped to
+// the new worksheets, and the workbook-level defined names which reference
+// the copied worksheets will be copied as workbook-level defined names, or
+// scoped to the new worksheet if the name already exists in the target
+// workbook. Pivot tables and threaded comments will not be copied. The
+// styles, charts and tables of the source worksheets will be checked before
+// merging, and the target workbook will not be modified if any of them is
+// invalid. For example, copy the worksheets named "Sales" and "Summary" from the workbook
+// src into the workbook dst:
+//
+//	err := excelize.MergeWorkbook(dst, src, []string{"Sales", "Summary"})
+func MergeWorkbook(dst, src *File, sheets []string) error {
+	if dst == nil || src == nil || dst == src {
+		return ErrParameterInvalid
+	}
+	if len(sheets) == 0 {
+		for _, sheet := range src.GetSheetList() {
+			if sheetXMLPath, _ := src.getSheetXMLPath(sheet); strings.HasPrefix(sheetXMLPath, "xl/worksheets/") {
+				sheets = append(sheets, sheet)
+			}
+		}
+	}
+	sources := make([]string, 0, len(sheets))
+	for _, sheet := range sheets {
+		if _, err := src.workSheetReader(sheet); err != nil {
+			return err
+		}
+		for _, name := range src.GetSheetList() {
+			if strings.EqualFold(name, trimSheetName(sheet)) {
+				sources = append(sources, name)
+				break
+			}
+		}
+	}
+	for _, f := range []*File{src, dst} {
+		if err := f.sharedStringsLoader(); err != nil {
+			return err
+		}
+	}
+	m := &workbookMerger{
+		dst: dst, src: src,
+		sheets: map[string]string{}, tables: map[string]string{}, styles: map[int]int{},
+		dxfs: map[int]int{}, strings: map[int]int{}, parts: map[string]string{},
+	}
+	names := make(map[string]bool)
+	for _, name := range dst.GetSheetList() {
+		names[strings.ToLower(name)] = true
+	}
+	targets := make([]string, len(sources))
+	for idx, source := range sources {
+		targets[idx] = uniqueSheetName(source, names)
+		names[strings.ToLower(targets[idx])] = true
+		if targets[idx] != source {
+			m.sheets[strings.ToLower(source)] = targets[idx]
+		}
+	}
+	if err := m.checkSheets(sources); err != nil {
+		return err
+	}
+	for idx, source := range sources {
+		if err := m.mergeSheet(source, targets[idx]); err != nil {
+			return err
+		}
+	}
+	for _, target := range targets {
+		ws, err := dst.workSheetReader(target)
+		if err != nil {
+			return err
+		}
+		adjustWorksheetReferences(ws, m.sheets, m.tables)
+	}
+	m.mergeDefinedNames(sources, targets)
+	return nil
+}
+
+// checkSheets provides a function to check the styles and the parts
+// referenced by the given source worksheets before merging them, so that the
+// target workbook will not be modified if the source workbook is corrupted.
+func (m *workbookMerger) checkSheets(sources []string) error {
+	var xfs int
+	s := m.src.stylesReader()
+	s.Lock()
+	if s.CellXfs != nil {
+		xfs = len(s.CellXfs.Xf)
+	}
+	s.Unlock()
+	checkStyle := func(styleID int) error {
+		if styleID != 0 && (styleID < 0 || styleID >= xfs) {
+			return newInvalidStyleID(styleID)
+		}
+		return nil
+	}
+	checked := make(map[string]bool)
+	for _, source := range sources {
+		ws, err := m.src.workSheetReader(source)
+		if err != nil {
+			return err
+		}
+		if ws.Cols != nil {
+			for _, col := range ws.Cols.Col {
+				if err = checkStyle(col.Style); err != nil {
+					return err
+				}
+			}
+		}
+		for _, row := range ws.SheetData.Row {
+			if err = checkStyle(row.S); err != nil {
+				return err
+			}
+			for _, c := range row.C {
+				if err = checkStyle(c.S); err != nil {
+					return err
+				}
+			}
+		}
+		sheetXMLPath, _ := m.src.getSheetXMLPath(source)
+		if err = m.checkRels(sheetXMLPath, checked); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkRels provides a function to check the charts and tables referenced by
+// the relationships of the given part of the source workbook can be merged
+// into the target workbook.
+func (m *workbookMerger) checkRels(from string, checked map[string]bool) error {
+	rels := m.src.relsReader(getPartRelsPath(from))
+	if rels == nil {
+		return nil
+	}
+	rels.Lock()
+	relationships := append([]xlsxRelationship{}, rels.Relationships...)
+	rels.Unlock()
+	for _, rel := range relationships {
+		if rel.Type == SourceRelationshipPivotTable || rel.Type == SourceRelationshipThreadedComment || rel.TargetMode == "External" {
+			continue
+		}
+		partPath := getRelsTargetPath(from, rel.Target)
+		if checked[partPath] {
+			continue
+		}
+		checked[partPath] = true
+		var err error
+		switch rel.Type {
+		case SourceRelationshipChart:
+			_, err = adjustChartReferences(string(m.src.readPart(partPath)), m.sheets)
+		case SourceRelationshipTable:
+			if err = m.src.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(m.src.readPart(partPath)))).
+				Decode(new(xlsxTable)); err == io.EOF {
+				err = nil
+			}
+			if err == nil {
+				_, _, err = m.getTargetTables()
+			}
+		}
+		if err != nil {
+			return err
+		}
+		if err = m.checkRels(partPath, checked); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// mergeSheet provides a function to copy the worksheet by given source sheet
+// name into the target workbook with the given target sheet name.
+func (m *workbookMerger) mergeSheet(source, target string) error {
+	ws, err := m.src.workSheetReader(source)
+	if err != nil {
+		return err
+	}
+	fromSheetXMLPath, _ := m.src.getSheetXMLPath(source)
+	m.dst.NewSheet(target)
+	toSheetXMLPath, _ := m.dst.getSheetXMLPath(target)
+	worksheet := deepcopy.Copy(ws).(*xlsxWorksheet)
+	if worksheet.SheetViews != nil {
+		for idx := range worksheet.SheetViews.SheetView {
+			worksheet.SheetViews.SheetView[idx].TabSelected = false
+		}
+	}
+	if worksheet.SheetPr != nil {
+		worksheet.SheetPr.CodeName = ""
+	}
+	if err = m.mergeSheetData(worksheet); err != nil {
+		return err
+	}
+	m.dst.Sheet.Store(toSheetXMLPath, worksheet)
+	m.dst.xmlAttr[toSheetXMLPath] = append([]xml.Attr{}, m.src.xmlAttr[fromSheetXMLPath]...)
+	return m.mergeRels(fromSheetXMLPath, toSheetXMLPath)
+}
+
+// mergeSheetData provides a function to remap the styles and shared strings of
+// the given copied worksheet for the target workbook.
+func (m *workbookMerger) mergeSheetData(ws *xlsxWorksheet) error {
+	var err error
+	if ws.Cols != nil {
+		for idx := range ws.Cols.Col {
+			if ws.Cols.Col[idx].Style, err = m.mergeStyle(ws.Cols.Col[idx].Style); err != nil {
+				return err
+			}
+		}
+	}
+	for rowIdx := range ws.SheetData.Row {
+		row := &ws.SheetData.Row[rowIdx]
+		if row.S, err = m.mergeStyle(row.S); err != nil {
+			return err
+		}
+		for colIdx := range row.C {
+			c := &row.C[colIdx]
+			if c.S, err = m.mergeStyle(c.S); err != nil {
+				return err
+			}
+			if c.T == "s" {
+				if c.V, err = m.mergeSharedString(c.V); err != nil {
+					return err
+				}
+			}
+			c.Cm, c.Vm = nil, nil
+		}
+	}
+	for _, cf := range ws.ConditionalFormatting {
+		for _, rule := range cf.CfRule {
+			if rule.DxfID != nil {
+				rule.DxfID = intPtr(m.mergeDxf(*rule.DxfID))
+			}
+		}
+	}
+	return err
+}
+
+// mergeStyle provides a function to get the style index in the target
+// workbook by given style index of the source workbook, the number format,
+// font, fill, border and cell format will be added to the target workbook if
+// not exist.
+func (m *workbookMerger) mergeStyle(styleID int) (int, error) {
+	if styleID == 0 {
+		return 0, nil
+	}
+	if ID, ok := m.styles[styleID]; ok {
+		return ID, nil
+	}
+	var (
+		numFmt string
+		font   *xlsxFont
+		fill   *xlsxFill
+		border *xlsxBorder
+	)
+	s := m.src.stylesReader()
+	s.Lock()
+	if s.CellXfs == nil || styleID < 0 || styleID >= len(s.CellXfs.Xf) {
+		s.Unlock()
+		return 0, newInvalidStyleID(styleID)
+	}
+	palette := s.getIndexedColors()
+	xf := deepcopy.Copy(s.CellXfs.Xf[styleID]).(xlsxXf)
+	if xf.NumFmtID != nil && *xf.NumFmtID >= 164 && s.NumFmts != nil {
+		for _, nf := range s.NumFmts.NumFmt {
+			if nf.NumFmtID == *xf.NumFmtID {
+				numFmt = nf.FormatCode
+			}
+		}
+	}
+	if xf.FontID != nil && s.Fonts != nil && *xf.FontID < len(s.Fonts.Font) {
+		font = deepcopy.Copy(m.src.resolveFontColor(palette, s.Fonts.Font[*xf.FontID])).(*xlsxFont)
+	}
+	if xf.FillID != nil && s.Fills != nil && *xf.FillID < len(s.Fills.Fill) {
+		fill = deepcopy.Copy(m.src.resolveFillColor(palette, s.Fills.Fill[*xf.FillID])).(*xlsxFill)
+	}
+	if xf.BorderID != nil && s.Borders != nil && *xf.BorderID < len(s.Borders.Border) {
+		border = deepcopy.Copy(m.src.resolveBorderColor(palette, s.Borders.Border[*xf.BorderID])).(*xlsxBorder)
+	}
+	s.Unlock()
+
+	s = m.dst.stylesReader()
+	s.Lock()
+	defer s.Unlock()
+	if numFmt != "" {
+		numFmtID := getCustomNumFmtIDImmediate(s, numFmt)
+		if numFmtID == -1 {
+			numFmtID = setCustomNumFmtImmediate(s, &xlsxNumFmt{FormatCode: numFmt})
+		}
+		xf.NumFmtID = intPtr(numFmtID)
+	}
+	if font != nil {
+		if s.Fonts == nil {
+			s.Fonts = &xlsxFonts{}
+		}
+		fontID := m.dst.getFontIDImmediate(s, font)
+		if fontID == -1 {
+			s.Fonts.Font = append(s.Fonts.Font, font)
+			s.Fonts.Count = len(s.Fonts.Font)
+			fontID = s.Fonts.Count - 1
+		}
+		xf.FontID = intPtr(fontID)
+	}
+	if fill != nil {
+		if s.Fills == nil {
+			s.Fills = &xlsxFills{}
+		}
+		fillID := getFillIDImmediate(s, fill)
+		if fillID == -1 {
+			s.Fills.Fill = append(s.Fills.Fill, fill)
+			s.Fills.Count = len(s.Fills.Fill)
+			fillID = s.Fills.Count - 1
+		}
+		xf.FillID = intPtr(fillID)
+	}
+	if border != nil {
+		if s.Borders == nil {
+			s.Borders = &xlsxBorders{}
+		}
+		borderID := getBorderIDImmediate(s, border)
+		if borderID == -1 {
+			s.Borders.Border = append(s.Borders.Border, border)
+			s.Borders.Count = len(s.Borders.Border)
+			borderID = s.Borders.Count - 1
+		}
+		xf.BorderID = intPtr(borderID)
+	}
+	xf.XfID = intPtr(0)
+	if s.CellXfs == nil {
+		s.CellXfs = &xlsxCellXfs{}
+	}
+	ID := -1
+	for idx, cellXf := range s.CellXfs.Xf {
+		if reflect.DeepEqual(cellXf, xf) {
+			ID = idx
+			break
+		}
+	}
+	if ID == -1 {
+		s.CellXfs.Xf = append(s.CellXfs.Xf, xf)
+		s.CellXfs.Count = len(s.CellXfs.Xf)
+		ID = s.CellXfs.Count - 1
+	}
+	m.styles[styleID] = ID
+	return ID, nil
+}
+
+// mergeDxf provides a function to get the differential format index in the
+// target workbook by given differential format index of the source workbook.
+// The differential format will be added to the target workbook if not exist.
+func (m *workbookMerger) mergeDxf(dxfID int) int {
+	if ID, ok := m.dxfs[dxfID]; ok {
+		return ID
+	}
+	s := m.src.stylesReader()
+	s.Lock()
+	if s.Dxfs == nil || dxfID < 0 || dxfID >= len(s.Dxfs.Dxfs) {
+		s.Unlock()
+		return dxfID
+	}
+	dxf := s.Dxfs.Dxfs[dxfID].Dxf
+	s.Unlock()
+	s = m.dst.stylesReader()
+	s.Lock()
+	defer s.Unlock()
+	if s.Dxfs == nil {
+		s.Dxfs = &xlsxDxfs{}
+	}
+	ID := -1
+	for idx, d := range s.Dxfs.Dxfs {
+		if d.Dxf == dxf {
+			ID = idx
+			break
+		}
+	}
+	if ID == -1 {
+		s.Dxfs.Dxfs = append(s.Dxfs.Dxfs, &xlsxDxf{Dxf: dxf})
+		s.Dxfs.Count = len(s.Dxfs.Dxfs)
+		ID = s.Dxfs.Count - 1
+	}
+	m.dxfs[dxfID] = ID
+	return ID
+}
+
+// mergeSharedString provides a function to get the shared string index in
+// the target workbook by given shared string index of the source workbook.
+// The string item will be added to the shared string table of the target
+// workbook if not exist.
+func (m *workbookMerger) mergeSharedString(val string) (string, error) {
+	idx, err := strconv.Atoi(val)
+	if err != nil {
+		return val, nil
+	}
+	if ID, ok := m.strings[idx]; ok {
+		return strconv.Itoa(ID), nil
+	}
+	sst := m.src.sharedStringsReader()
+	m.src.Lock()
+	if idx < 0 || idx >= len(sst.SI) {
+		m.src.Unlock()
+		return val, nil
+	}
+	si := deepcopy.Copy(sst.SI[idx]).(xlsxSI)
+	m.src.Unlock()
+	var ID int
+	if len(si.R) == 0 && len(si.RPh) == 0 && si.PhoneticPr == nil {
+		var text string
+		if si.T != nil {
+			text = si.T.Val
+		}
+		if ID, err = m.dst.setSharedString(text); err != nil {
+			return val, err
+		}
+	} else {
+		sst = m.dst.sharedStringsReader()
+		m.dst.Lock()
+		sst.SI = append(sst.SI, si)
+		sst.Count++
+		sst.UniqueCount++
+		ID = len(sst.SI) - 1
+		m.dst.Unlock()
+	}
+	m.strings[idx] = ID
+	return strconv.Itoa(ID), err
+}
+
+// readPart provides a function to read the content of the part by given part
+// path in the package, the drawings, comments and VML drawings which have
+// been loaded in memory will be serialized.
+func (f *File) readPart(partPath string) []byte {
+	if content, ok := f.Drawings.Load(partPath); ok && content != nil {
+		output, _ := xml.Marshal(content.(*xlsxWsDr))
+		return append([]byte(xml.Header), output...)
+	}
+	if cmts := f.Comments[partPath]; cmts != nil {
+		output, _ := xml.Marshal(cmts)
+		return append([]byte(xml.Header), output...)
+	}
+	if vml := f.VMLDrawing[partPath]; vml != nil {
+		output, _ := xml.Marshal(vml)
+		return append([]byte(xml.Header), output...)
+	}
+	return append([]byte{}, f.readBytes(partPath)...)
+}
+
+// mergeRels provides a function to copy the parts referenced by the
+// relationships of the given part of the source workbook into the target
+// workbook, and create the relationships for the given part of the target
+// workbook.
+func (m *workbookMerger) mergeRels(from, to string) error {
+	rels := m.src.relsReader(getPartRelsPath(from))
+	if rels == nil {
+		return nil
+	}
+	rels.Lock()
+	relationships := append([]xlsxRelationship{}, rels.Relationships...)
+	rels.Unlock()
+	toRels := &xlsxRelationships{}
+	for _, rel := range relationships {
+		if rel.Type == SourceRelationshipPivotTable || rel.Type == SourceRelationshipThreadedComment {
+			continue
+		}
+		if rel.TargetMode != "External" {
+			partPath, err := m.mergePart(getRelsTargetPath(from, rel.Target), rel.Type)
+			if err != nil {
+				return err
+			}
+			rel.Target = path.Join(path.Dir(rel.Target), path.Base(partPath))
+		}
+		toRels.Relationships = append(toRels.Relationships, rel)
+	}
+	m.dst.Relationships.Store(getPartRelsPath(to), toRels)
+	return nil
+}
+
+// mergePart provides a function to copy the part by given part path and
+// relationship type of the source workbook into the target workbook, and
+// returns the path of the part in the target workbook. Each part will be
+// copied only once.
+func (m *workbookMerger) mergePart(partPath, relType string) (string, error) {
+	if newPartPath, ok := m.parts[partPath]; ok {
+		return newPartPath, nil
+	}
+	var err error
+	content, newPartPath := m.src.readPart(partPath), m.dst.getNextPartPath(partPath)
+	m.parts[partPath] = newPartPath
+	switch relType {
+	case SourceRelationshipChart:
+		chart, err := adjustChartReferences(string(content), m.sheets)
+		if err != nil {
+			return newPartPath, err
+		}
+		content = []byte(chart)
+	case SourceRelationshipTable:
+		if content, err = m.mergeTable(content); err != nil {
+			return newPartPath, err
+		}
+	}
+	m.dst.Pkg.Store(newPartPath, content)
+	m.mergeContentType(partPath, newPartPath)
+	return newPartPath, m.mergeRels(partPath, newPartPath)
+}
+
+// mergeContentType provides a function to register the content type of the
+// given part of the source workbook for the part of the target workbook.
+func (m *workbookMerger) mergeContentType(from, to string) {
+	var override, ext xlsxDefault
+	content := m.src.contentTypesReader()
+	content.Lock()
+	for _, o := range content.Overrides {
+		if o.PartName == "/"+from {
+			override.ContentType = o.ContentType
+		}
+	}
+	for _, d := range content.Defaults {
+		if strings.EqualFold("."+d.Extension, path.Ext(from)) {
+			ext = d
+		}
+	}
+	content.Unlock()
+	content = m.dst.contentTypesReader()
+	content.Lock()
+	defer content.Unlock()
+	if override.ContentType != "" {
+		content.Overrides = append(content.Overrides, xlsxOverride{PartName: "/" + to, ContentType: override.ContentType})
+		return
+	}
+	if ext.Extension == "" {
+		return
+	}
+	for _, d := range content.Defaults {
+		if strings.EqualFold(d.Extension, ext.Extension) {
+			return
+		}
+	}
+	content.Defaults = append(content.Defaults, ext)
+}
+
+// mergeTable provides a function to remap the table by given table part
+// content of the source workbook for the target workbook, the table will be
+// assigned a new unique ID, and renamed if the table name already exists in
+// the target workbook.
+func (m *workbookMerger) mergeTable(content []byte) ([]byte, error) {
+	table := new(xlsxTable)
+	if err := m.src.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(content))).
+		Decode(table); err != nil && err != io.EOF {
+		return content, err
+	}
+	maxID, names, err := m.getTargetTables()
+	if err != nil {
+		return content, err
+	}
+	table.ID = maxID + 1
+	if names[strings.ToLower(table.Name)] {
+		for idx := table.ID; ; idx++ {
+			if name := "Table" + strconv.Itoa(idx); !names[strings.ToLower(name)] {
+				m.tables[strings.ToLower(table.Name)] = name
+				table.Name, table.DisplayName = name, name
+				break
+			}
+		}
+	}
+	for _, dxfID := range []*int{&table.DataDxfID, &table.HeaderRowDxfID, &table.TotalsRowDxfID, &table.HeaderRowBorderDxfID} {
+		if *dxfID != 0 {
+			*dxfID = m.mergeDxf(*dxfID)
+		}
+	}
+	if table.TableColumns != nil {
+		for _, col := range table.TableColumns.TableColumn {
+			for _, dxfID := range []*int{&col.DataDxfID, &col.HeaderRowDxfID, &col.TotalsRowDxfID} {
+				if *dxfID != 0 {
+					*dxfID = m.mergeDxf(*dxfID)
+				}
+			}
+			for _, formula := range []*xlsxTableFormula{col.CalculatedColumnFormula, col.TotalsRowFormula} {
+				if formula != nil {
+					formula.Content = adjustFormulaReferences(formula.Content, m.sheets, m.tables)
+				}
+			}
+		}
+	}
+	output, err := xml.Marshal(table)
+	return append([]byte(xml.Header), output...), err
+}
+
+// getTargetTables provides a function to get the maximum table ID and the
+// table names in lower case of the target workbook.
+func (m *workbookMerger) getTargetTables() (int, map[string]bool, error) {
+	var (
+		err   error
+		maxID int
+		names = map[string]bool{}
+	)
+	m.dst.Pkg.Range(func(k, v interface{}) bool {
+		if strings.Contains(k.(string), "xl/tables/table") {
+			var t xlsxTable
+			if err = m.dst.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(v.([]byte)))).
+				Decode(&t); err != nil && err != io.EOF {
+				return false
+			}
+			err = nil
+			if t.ID > maxID {
+				maxID = t.ID
+			}
+			names[strings.ToLower(t.Name)] = true
+		}
+		return true
+	})
+	return maxID, names, err
+}
+
+// mergeDefinedNames provides a function to copy the defined names scoped to
+// the given source worksheets, and the workbook-level defined names which
+// reference the given source worksheets into the target workbook.
+func (m *workbookMerger) mergeDefinedNames(sources, targets []string) {
+	from := m.src.workbookReader()
+	if from.DefinedNames == nil {
+		return
+	}
+	to := m.dst.workbookReader()
+	localTargets := make(map[int]string)
+	for idx, source := range sources {
+		localTargets[m.src.GetSheetIndex(source)] = targets[idx]
+	}
+	exists := func(name string, localSheetID *int) bool {
+		if to.DefinedNames == nil {
+			return false
+		}
+		for _, dn := range to.DefinedNames.DefinedName {
+			if strings.EqualFold(dn.Name, name) && reflect.DeepEqual(dn.LocalSheetID, localSheetID) {
+				return true
+			}
+		}
+		return false
+	}
+	for _, dn := range from.DefinedNames.DefinedName {
+		data := dn.Data
+		dn.Data = adjustFormulaReferences(dn.Data, m.sheets, m.tables)
+		if dn.LocalSheetID != nil {
+			target, ok := localTargets[*dn.LocalSheetID]
+			if !ok {
+				continue
+			}
+			dn.LocalSheetID = intPtr(m.dst.GetSheetIndex(target))
+		} else {
+			var target string
+			for idx, source := range sources {
+				if isFormulaReferencesSheet(data, source) {
+					target = targets[idx]
+					break
+				}
+			}
+			if target == "" {
+				continue
+			}
+			if exists(dn.Name, nil) {
+				dn.LocalSheetID = intPtr(m.dst.GetSheetIndex(target))
+			}
+		}
+		if exists(dn.Name, dn.LocalSheetID) {
+			continue
+		}
+		if to.DefinedNames == nil {
+			to.DefinedNames = &xlsxDefinedNames{}
+		}
+		to.DefinedNames.DefinedName = append(to.DefinedNames.DefinedName, dn)
+	}
+}
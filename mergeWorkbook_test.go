@@ -0,0 +1,157 @@
+package excelize
+
+import (
+	"encoding/xml"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeWorkbook(t *testing.T) {
+	src := NewFile()
+	src.SetSheetName("Sheet1", "Data")
+	src.NewSheet("Other")
+	src.NewSheet("MyData")
+	assert.NoError(t, src.SetCellValue("Data", "A1", "Header"))
+	assert.NoError(t, src.SetCellValue("Data", "A2", 1))
+	assert.NoError(t, src.SetCellValue("Data", "B2", 2))
+	assert.NoError(t, src.SetCellFormula("Data", "C2", "SUM(Data!A2:B2)"))
+	assert.NoError(t, src.SetCellFormula("Data", "C3", `MyData!A1+'Data'!A1+SUM(Sales[Column1])&"Data!"`))
+	assert.NoError(t, src.SetCellRichText("Data", "A3", []RichTextRun{{Text: "bold", Font: &Font{Bold: true}}, {Text: " text"}}))
+	style, err := src.NewStyle(&Style{
+		Font:         &Font{Bold: true, Color: "#FF0000"},
+		Fill:         Fill{Type: "pattern", Color: []string{"#FFFF00"}, Pattern: 1},
+		Border:       []Border{{Type: "left", Color: "#0000FF", Style: 1}},
+		CustomNumFmt: stringPtr("0.000"),
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, src.SetCellStyle("Data", "A2", "B2", style))
+	dxf, err := src.NewConditionalStyle(`{"font":{"color":"#9A0511"}}`)
+	assert.NoError(t, err)
+	assert.NoError(t, src.SetConditionalFormat("Data", "A2:B2", `[{"type":"cell","criteria":">","format":`+strconv.Itoa(dxf)+`,"value":"1"}]`))
+	assert.NoError(t, src.SetDefinedName(&DefinedName{Name: "_xlnm.Print_Area", RefersTo: "Data!$A$1:$B$2", Scope: "Data"}))
+	assert.NoError(t, src.SetDefinedName(&DefinedName{Name: "Amount", RefersTo: "Data!$A$2"}))
+	assert.NoError(t, src.SetDefinedName(&DefinedName{Name: "Unrelated", RefersTo: "Other!$A$1"}))
+	assert.NoError(t, src.SetDefinedName(&DefinedName{Name: "Mine", RefersTo: "MyData!$A$1"}))
+	assert.NoError(t, src.SetDefinedName(&DefinedName{Name: "Total", RefersTo: "SUM(Sales[Column1])+Data!$A$3"}))
+	assert.NoError(t, src.AddPicture("Data", "D1", filepath.Join("test", "images", "excel.png"), ""))
+	assert.NoError(t, src.AddChart("Data", "D10", `{"type":"col","series":[{"name":"Data!$A$1","categories":"Data!$A$2:$B$2","values":"Data!$A$2:$B$2"}]}`))
+	assert.NoError(t, src.AddTable("Data", "A5", "B7", `{"table_name":"Sales"}`))
+	assert.NoError(t, src.AddComment("Data", "A1", `{"author":"Excelize","text":"Note"}`))
+
+	dst := NewFile()
+	dst.SetSheetName("Sheet1", "Data")
+	assert.NoError(t, dst.SetCellValue("Data", "A1", "Existing"))
+	assert.NoError(t, dst.SetDefinedName(&DefinedName{Name: "Amount", RefersTo: "Data!$B$1"}))
+	assert.NoError(t, dst.AddTable("Data", "A5", "B7", `{"table_name":"Sales"}`))
+
+	assert.NoError(t, MergeWorkbook(dst, src, []string{"Data"}))
+	assert.Equal(t, []string{"Data", "Data (2)"}, dst.GetSheetList())
+	path := filepath.Join("test", "TestMergeWorkbook.xlsx")
+	assert.NoError(t, dst.SaveAs(path))
+	assert.NoError(t, dst.Close())
+
+	f, err := OpenFile(path)
+	assert.NoError(t, err)
+	for cell, expected := range map[string]string{"A1": "Header", "A2": "1", "B2": "2", "A3": "bold text"} {
+		val, err := f.GetCellValue("Data (2)", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, val)
+	}
+	val, err := f.GetCellValue("Data", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "Existing", val)
+	formula, err := f.GetCellFormula("Data (2)", "C2")
+	assert.NoError(t, err)
+	assert.Equal(t, "SUM('Data (2)'!A2:B2)", formula)
+	formula, err = f.GetCellFormula("Data (2)", "C3")
+	assert.NoError(t, err)
+	assert.Equal(t, `MyData!A1+'Data (2)'!A1+SUM(Table2[Column1])&"Data!"`, formula)
+	runs, err := f.GetCellRichText("Data (2)", "A3")
+	assert.NoError(t, err)
+	assert.Len(t, runs, 2)
+	assert.True(t, runs[0].Font.Bold)
+	styleID, err := f.GetCellStyle("Data (2)", "A2")
+	assert.NoError(t, err)
+	s := f.stylesReader()
+	xf := s.CellXfs.Xf[styleID]
+	assert.Equal(t, "0.000", s.NumFmts.NumFmt[0].FormatCode)
+	assert.Equal(t, s.NumFmts.NumFmt[0].NumFmtID, *xf.NumFmtID)
+	assert.NotNil(t, s.Fonts.Font[*xf.FontID].B)
+	assert.Equal(t, "FFFFFF00", s.Fills.Fill[*xf.FillID].PatternFill.FgColor.RGB)
+	assert.Equal(t, "FF0000FF", s.Borders.Border[*xf.BorderID].Left.Color.RGB)
+	ws, err := f.workSheetReader("Data (2)")
+	assert.NoError(t, err)
+	assert.Contains(t, s.Dxfs.Dxfs[*ws.ConditionalFormatting[0].CfRule[0].DxfID].Dxf, "FF9A0511")
+	file, raw, err := f.GetPicture("Data (2)", "D1")
+	assert.NoError(t, err)
+	assert.Equal(t, "image1.png", file)
+	assert.NotEmpty(t, raw)
+	assert.Contains(t, string(f.readXML("xl/charts/chart1.xml")), "<f>&#39;Data (2)&#39;!$A$2:$B$2</f>")
+	assert.Contains(t, string(f.readXML("xl/tables/table2.xml")), `name="Table2"`)
+	assert.Len(t, f.GetComments()["Data (2)"], 1)
+	assert.Equal(t, []DefinedName{
+		{Name: "Amount", RefersTo: "Data!$B$1", Scope: "Workbook"},
+		{Name: "_xlnm.Print_Area", RefersTo: "'Data (2)'!$A$1:$B$2", Scope: "Data (2)"},
+		{Name: "Amount", RefersTo: "'Data (2)'!$A$2", Scope: "Data (2)"},
+		{Name: "Total", RefersTo: "SUM(Table2[Column1])+'Data (2)'!$A$3", Scope: "Workbook"},
+	}, f.GetDefinedName())
+
+	// Test merge all worksheets into the workbook without the name collision
+	dst = NewFile()
+	assert.NoError(t, MergeWorkbook(dst, f, nil))
+	assert.Equal(t, []string{"Sheet1", "Data", "Data (2)"}, dst.GetSheetList())
+	val, err = dst.GetCellValue("Data (2)", "B2")
+	assert.NoError(t, err)
+	assert.Equal(t, "2", val)
+	formula, err = dst.GetCellFormula("Data (2)", "C2")
+	assert.NoError(t, err)
+	assert.Equal(t, "SUM('Data (2)'!A2:B2)", formula)
+	assert.NoError(t, dst.SaveAs(filepath.Join("test", "TestMergeWorkbook2.xlsx")))
+	assert.NoError(t, f.Close())
+}
+
+func TestMergeWorkbookError(t *testing.T) {
+	src, dst := NewFile(), NewFile()
+	assert.EqualError(t, MergeWorkbook(nil, src, nil), ErrParameterInvalid.Error())
+	assert.EqualError(t, MergeWorkbook(dst, nil, nil), ErrParameterInvalid.Error())
+	assert.EqualError(t, MergeWorkbook(dst, dst, nil), ErrParameterInvalid.Error())
+	assert.EqualError(t, MergeWorkbook(dst, src, []string{"SheetN"}), "sheet SheetN is not exist")
+	// Test merge with the invalid cell style index
+	ws, err := src.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	ws.SheetData.Row = []xlsxRow{{R: 1, C: []xlsxC{{R: "A1", S: 100}}}}
+	assert.EqualError(t, MergeWorkbook(dst, src, nil), newInvalidStyleID(100).Error())
+	assert.Equal(t, []string{"Sheet1"}, dst.GetSheetList())
+	// Test merge with the invalid chart and table parts
+	src = NewFile()
+	assert.NoError(t, src.AddChart("Sheet1", "D1", `{"type":"col","series":[{"name":"Sheet1!$A$1","categories":"Sheet1!$A$2:$B$2","values":"Sheet1!$A$2:$B$2"}]}`))
+	assert.NoError(t, src.AddTable("Sheet1", "A1", "B3", ""))
+	assert.NoError(t, src.SetCellValue("Sheet1", "A1", "text"))
+	src.Pkg.Store("xl/charts/chart1.xml", MacintoshCyrillicCharset)
+	var parts int
+	dst.Pkg.Range(func(k, v interface{}) bool {
+		parts++
+		return true
+	})
+	assert.EqualError(t, MergeWorkbook(dst, src, nil), "XML syntax error on line 1: invalid UTF-8")
+	src.Pkg.Store("xl/charts/chart1.xml", []byte(xml.Header+`<c:chartSpace xmlns:c="http://schemas.openxmlformats.org/drawingml/2006/chart"></c:chartSpace>`))
+	src.Pkg.Store("xl/tables/table1.xml", MacintoshCyrillicCharset)
+	assert.EqualError(t, MergeWorkbook(dst, src, nil), "XML syntax error on line 1: invalid UTF-8")
+	src.Pkg.Store("xl/tables/table1.xml", []byte(`<table></table>`))
+	dst.Pkg.Store("xl/tables/table1.xml", MacintoshCyrillicCharset)
+	assert.EqualError(t, MergeWorkbook(dst, src, nil), "XML syntax error on line 1: invalid UTF-8")
+	dst.Pkg.Delete("xl/tables/table1.xml")
+	// Test the target workbook is not modified on error
+	assert.Equal(t, []string{"Sheet1"}, dst.GetSheetList())
+	dst.Pkg.Range(func(k, v interface{}) bool {
+		parts--
+		return true
+	})
+	assert.Zero(t, parts)
+	assert.Len(t, dst.sharedStringsReader().SI, 0)
+	assert.NoError(t, MergeWorkbook(dst, src, nil))
+	assert.Equal(t, []string{"Sheet1", "Sheet1 (2)"}, dst.GetSheetList())
+}
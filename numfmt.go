@@ -17,6 +17,7 @@ import (
 	"strconv"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/xuri/nfp"
 )
@@ -312,6 +313,103 @@ func format(value, numFmt string, date1904 bool) string {
 	return value
 }
 
+// formatBool provides a function to return the boolean value parse by number
+// format expression. The TRUE and FALSE will be treated as the number 1 and 0
+// to choose the section of the number format, and the result of the section
+// will be returned if it only consists of the literal texts, such as the
+// format code "Yes";"Yes";"No" or [=1]"Yes";[=0]"No". Otherwise, the text
+// section will be applied to the text TRUE or FALSE if it exists, and the
+// text TRUE or FALSE will be returned if neither of them is applicable.
+func formatBool(value bool, numFmt string) string {
+	number, text := 0.0, "FALSE"
+	if value {
+		number, text = 1, "TRUE"
+	}
+	p := nfp.NumberFormatParser()
+	nf := numberFormat{section: p.Parse(numFmt), value: text}
+	var numeric []nfp.Section
+	for i, section := range nf.section {
+		if section.Type == nfp.TokenSectionText {
+			nf.sectionIdx = i
+			continue
+		}
+		numeric = append(numeric, section)
+	}
+	if section, ok := getBoolSection(numeric, number); ok {
+		var result string
+		for _, token := range section.Items {
+			switch token.TType {
+			case nfp.TokenTypeLiteral:
+				result += token.TValue
+			case nfp.TokenTypeColor, nfp.TokenTypeCondition:
+			default:
+				ok = false
+			}
+		}
+		if ok {
+			return result
+		}
+	}
+	if len(nf.section) > nf.sectionIdx && nf.section[nf.sectionIdx].Type == nfp.TokenSectionText {
+		return nf.textHandler()
+	}
+	return text
+}
+
+// getBoolSection provides a function to get the section of the number format
+// applicable to the given number of the boolean value by the conditions of
+// the sections, or by the sign of the number if the sections have no
+// conditions.
+func getBoolSection(sections []nfp.Section, number float64) (nfp.Section, bool) {
+	var conditional bool
+	for _, section := range sections {
+		condition := ""
+		for _, token := range section.Items {
+			if token.TType == nfp.TokenTypeCondition {
+				condition, conditional = token.TValue, true
+			}
+		}
+		if conditional && (condition == "" || matchNumFmtCondition(condition, number)) {
+			return section, true
+		}
+	}
+	if conditional || len(sections) == 0 {
+		return nfp.Section{}, false
+	}
+	if number == 0 && len(sections) > 2 {
+		return sections[2], true
+	}
+	return sections[0], true
+}
+
+// matchNumFmtCondition provides a function to check if the given number
+// matches the condition of the number format section, such as "=1" or ">0".
+func matchNumFmtCondition(condition string, number float64) bool {
+	for _, operator := range []string{">=", "<=", "<>", ">", "<", "="} {
+		if !strings.HasPrefix(condition, operator) {
+			continue
+		}
+		val, err := strconv.ParseFloat(strings.TrimPrefix(condition, operator), 64)
+		if err != nil {
+			return false
+		}
+		switch operator {
+		case ">=":
+			return number >= val
+		case "<=":
+			return number <= val
+		case "<>":
+			return number != val
+		case ">":
+			return number > val
+		case "<":
+			return number < val
+		}
+		return number == val
+	}
+	return false
+}
+
 // positiveHandler will be handling positive selection for a number format
 // expression.
 func (nf *numberFormat) positiveHandler() (result string) {
@@ -952,3 +1050,77 @@ func (nf *numberFormat) getValueSectionType(value string) (float64, string) {
 	}
 	return number, nfp.TokenSectionZero
 }
+
+// CurrencyFormat provides a function to build the number format code of the
+// currency by given currency symbol, locale ID (LCID), number of decimal
+// places, the position of the symbol and whether to show the negative numbers
+// in red. The symbol and the hexadecimal LCID will be wrapped in the currency
+// tag such as [$kr-414], the LCID will be omitted if it isn't positive. The
+// symbols which contain the characters can't be used in the currency tag,
+// such as "-", "[", "]", "\" and the double quotes, will be quoted as the
+// literal text after the locale tag such as [$-409]"R-$", and the backslash
+// and the double quotes in the symbol will be escaped. A space will be
+// added between the number and the symbol after the number or the symbol
+// before the number which has more than one character. The returned format
+// code can be used as the custom number format of the style. For example,
+// create a style for the Norwegian krone (LCID 0x414) with 2 decimal places
+// and show the negative numbers in red:
+//
+//	exp := excelize.CurrencyFormat("kr", 0x414, 2, true, true)
+//	// exp: [$kr-414]\ #,##0.00;[Red]\-[$kr-414]\ #,##0.00
+//	style, err := f.NewStyle(&excelize.Style{CustomNumFmt: &exp})
+func CurrencyFormat(symbol string, lcid, decimals int, symbolBefore bool, negRed bool) string {
+	if decimals < 0 {
+		decimals = 0
+	}
+	if decimals > 30 {
+		decimals = 30
+	}
+	number := "#,##0"
+	if decimals > 0 {
+		number += "." + strings.Repeat("0", decimals)
+	}
+	var tag, locale string
+	if lcid > 0 {
+		locale = fmt.Sprintf("-%X", lcid)
+	}
+	if symbol != "" {
+		if strings.ContainsAny(symbol, "-[]\\\"") {
+			var b strings.Builder
+			if locale != "" {
+				b.WriteString("[$" + locale + "]")
+			}
+			var quoted bool
+			for _, r := range symbol {
+				if quoted == (r == '"' || r == '\\') {
+					b.WriteRune('"')
+					quoted = !quoted
+				}
+				if !quoted {
+					b.WriteRune('\\')
+				}
+				b.WriteRune(r)
+			}
+			if quoted {
+				b.WriteRune('"')
+			}
+			tag = b.String()
+		} else {
+			tag = "[$" + symbol + locale + "]"
+		}
+	}
+	code := number
+	switch {
+	case tag == "":
+	case !symbolBefore:
+		code = number + "\\ " + tag
+	case utf8.RuneCountInString(symbol) > 1:
+		code = tag + "\\ " + number
+	default:
+		code = tag + number
+	}
+	if negRed {
+		code += ";[Red]\\-" + code
+	}
+	return code
+}
@@ -1,9 +1,13 @@
 package excelize
 
 import (
+	"fmt"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/xuri/nfp"
 )
 
 func TestNumFmt(t *testing.T) {
@@ -1009,3 +1013,113 @@ func TestNumFmt(t *testing.T) {
 		assert.Equal(t, item[2], result, item)
 	}
 }
+
+func TestCurrencyFormat(t *testing.T) {
+	for _, c := range []struct {
+		symbol       string
+		lcid         int
+		decimals     int
+		symbolBefore bool
+		negRed       bool
+		expected     string
+	}{
+		{"$", 0x409, 2, true, false, "[$$-409]#,##0.00"},
+		{"€", 0, 0, false, false, "#,##0\\ [$€]"},
+		{"kr", 0x414, 2, true, true, "[$kr-414]\\ #,##0.00;[Red]\\-[$kr-414]\\ #,##0.00"},
+		{"kr.", 0x406, 1, false, true, "#,##0.0\\ [$kr.-406];[Red]\\-#,##0.0\\ [$kr.-406]"},
+		{"R-$", 0x409, 2, true, false, "[$-409]\"R-$\"\\ #,##0.00"},
+		{"[x]", -1, -1, false, false, "#,##0\\ \"[x]\""},
+		{"a\"b\\", 0x804, 0, true, false, "[$-804]\"a\"\\\"\"b\"\\\\\\ #,##0"},
+		{"", 0x409, 3, true, false, "#,##0.000"},
+		{"$", 0, 31, true, false, "[$$]#,##0." + strings.Repeat("0", 30)},
+	} {
+		assert.Equal(t, c.expected, CurrencyFormat(c.symbol, c.lcid, c.decimals, c.symbolBefore, c.negRed))
+	}
+	// Test the symbol will be parsed as the currency or the literal text
+	for _, symbol := range []string{"kr", "R-$", "a\"b\\"} {
+		var text string
+		p := nfp.NumberFormatParser()
+		for _, token := range p.Parse(CurrencyFormat(symbol, 0x409, 0, true, false))[0].Items {
+			switch token.TType {
+			case nfp.TokenTypeCurrencyLanguage:
+				for _, part := range token.Parts {
+					if part.Token.TType == nfp.TokenSubTypeCurrencyString {
+						text += part.Token.TValue
+					}
+				}
+			case nfp.TokenTypeLiteral:
+				text += token.TValue
+			}
+		}
+		assert.Equal(t, symbol+" ", text)
+	}
+	f := NewFile()
+	for i, exp := range []string{CurrencyFormat("kr", 0x414, 2, true, true), CurrencyFormat("R-$", 0x409, 0, false, false)} {
+		exp := exp
+		style, err := f.NewStyle(&Style{CustomNumFmt: &exp})
+		assert.NoError(t, err)
+		cell := fmt.Sprintf("A%d", i+1)
+		assert.NoError(t, f.SetCellValue("Sheet1", cell, -1234.5))
+		assert.NoError(t, f.SetCellStyle("Sheet1", cell, cell, style))
+	}
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestCurrencyFormat.xlsx")))
+}
+
+func TestFormatBool(t *testing.T) {
+	for _, item := range []struct {
+		numFmt      string
+		true, false string
+	}{
+		{"General", "TRUE", "FALSE"},
+		{"0.00", "TRUE", "FALSE"},
+		{"@", "TRUE", "FALSE"},
+		{`"Yes";"Yes";"No"`, "Yes", "No"},
+		{`"Yes";"No"`, "Yes", "Yes"},
+		{`[Red]"Yes";;[Blue]"No"`, "Yes", "No"},
+		{`[=1]"Yes";[=0]"No"`, "Yes", "No"},
+		{`[>0]"Positive";"Other"`, "Positive", "Other"},
+		{`[<>1]"Not one";[<=1]"One"`, "One", "Not one"},
+		{`[>=2]"Big";[<0]"Negative"`, "TRUE", "FALSE"},
+		{`0;0;0;"Ans: "@`, "Ans: TRUE", "Ans: FALSE"},
+		{`"Yes";"Yes";0;"Ans: "@`, "Yes", "Ans: FALSE"},
+	} {
+		assert.Equal(t, item.true, formatBool(true, item.numFmt), item.numFmt)
+		assert.Equal(t, item.false, formatBool(false, item.numFmt), item.numFmt)
+	}
+	assert.False(t, matchNumFmtCondition("=a", 1))
+	assert.False(t, matchNumFmtCondition("1", 1))
+
+	f := NewFile()
+	assert.NoError(t, f.SetCellBool("Sheet1", "A1", true))
+	assert.NoError(t, f.SetCellBool("Sheet1", "A2", false))
+	style, err := f.NewStyle(&Style{CustomNumFmt: stringPtr(`"Yes";"Yes";"No"`)})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A1", "A2", style))
+	for cell, expected := range map[string]string{"A1": "Yes", "A2": "No"} {
+		val, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, val)
+		val, err = f.GetCellValue("Sheet1", cell, Options{RawCellValue: true})
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]string{"A1": "1", "A2": "0"}[cell], val)
+	}
+	rows, err := f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"Yes"}, {"No"}}, rows)
+	// Test get boolean cell value with the built-in number format
+	style, err = f.NewStyle(&Style{NumFmt: 2})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A1", "A1", style))
+	val, err := f.GetCellValue("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "TRUE", val)
+	// Test get boolean cell value with the number format which doesn't exist
+	f.Styles.CellXfs.Xf[style].NumFmtID = intPtr(200)
+	val, err = f.GetCellValue("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "TRUE", val)
+	f.Styles.NumFmts = nil
+	val, err = f.GetCellValue("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "TRUE", val)
+}
@@ -34,8 +34,16 @@ func parseFormatPictureSet(formatSet string) (*formatPicture, error) {
 		XScale:           1,
 		YScale:           1,
 	}
-	err := json.Unmarshal(parseFormatSet(formatSet), &format)
-	return &format, err
+	if err := json.Unmarshal(parseFormatSet(formatSet), &format); err != nil {
+		return &format, err
+	}
+	if inStrSlice([]string{"", "twoCell", "oneCell", "absolute"}, format.Positioning, true) == -1 {
+		return &format, ErrPicturePositioning
+	}
+	if format.Positioning == "twoCell" {
+		format.Positioning = ""
+	}
+	return &format, nil
 }
 
 // AddPicture provides the method to add picture in a sheet by given picture
@@ -81,10 +89,18 @@ func parseFormatPictureSet(formatSet string) (*formatPicture, error) {
 // cells in this workbook. When the "hyperlink_type" is "Location",
 // coordinates need to start with "#".
 //
-// The optional parameter "positioning" defines two types of the position of an
-// image in an Excel spreadsheet, "oneCell" (Move but don't size with
-// cells) or "absolute" (Don't move or size with cells). If you don't set this
-// parameter, the default positioning is move and size with cells.
+// The optional parameter "positioning" defines the anchor type of an image in
+// an Excel spreadsheet, which decide how the image behaves when the cells
+// under it are moved or resized:
+//
+//	 Positioning | Behavior
+//	-------------+--------------------------------
+//	 twoCell     | Move and size with cells
+//	 oneCell     | Move but don't size with cells
+//	 absolute    | Don't move or size with cells
+//
+// If you don't set this parameter, the default positioning is move and size
+// with cells.
 //
 // The optional parameter "print_obj" indicates whether the image is printed
 // when the worksheet is printed, the default value of that is 'true'.
@@ -407,24 +423,34 @@ func (f *File) addContentTypePart(index int, contentType string) {
 		"drawings": f.setContentTypePartImageExtensions,
 	}
 	partNames := map[string]string{
-		"chart":         "/xl/charts/chart" + strconv.Itoa(index) + ".xml",
-		"chartsheet":    "/xl/chartsheets/sheet" + strconv.Itoa(index) + ".xml",
-		"comments":      "/xl/comments" + strconv.Itoa(index) + ".xml",
-		"drawings":      "/xl/drawings/drawing" + strconv.Itoa(index) + ".xml",
-		"table":         "/xl/tables/table" + strconv.Itoa(index) + ".xml",
-		"pivotTable":    "/xl/pivotTables/pivotTable" + strconv.Itoa(index) + ".xml",
-		"pivotCache":    "/xl/pivotCache/pivotCacheDefinition" + strconv.Itoa(index) + ".xml",
-		"sharedStrings": "/xl/sharedStrings.xml",
+		"chart":             "/xl/charts/chart" + strconv.Itoa(index) + ".xml",
+		"chartsheet":        "/xl/chartsheets/sheet" + strconv.Itoa(index) + ".xml",
+		"comments":          "/xl/comments" + strconv.Itoa(index) + ".xml",
+		"drawings":          "/xl/drawings/drawing" + strconv.Itoa(index) + ".xml",
+		"table":             "/xl/tables/table" + strconv.Itoa(index) + ".xml",
+		"pivotTable":        "/xl/pivotTables/pivotTable" + strconv.Itoa(index) + ".xml",
+		"pivotCache":        "/xl/pivotCache/pivotCacheDefinition" + strconv.Itoa(index) + ".xml",
+		"pivotCacheRecords": "/xl/pivotCache/pivotCacheRecords" + strconv.Itoa(index) + ".xml",
+		"sharedStrings":     "/xl/sharedStrings.xml",
+		"threadedComments":  "/xl/threadedComments/threadedComment" + strconv.Itoa(index) + ".xml",
+		"person":            "/xl/persons/person.xml",
+		"slicer":            "/xl/slicers/slicer" + strconv.Itoa(index) + ".xml",
+		"slicerCache":       "/xl/slicerCaches/slicerCache" + strconv.Itoa(index) + ".xml",
 	}
 	contentTypes := map[string]string{
-		"chart":         ContentTypeDrawingML,
-		"chartsheet":    ContentTypeSpreadSheetMLChartsheet,
-		"comments":      ContentTypeSpreadSheetMLComments,
-		"drawings":      ContentTypeDrawing,
-		"table":         ContentTypeSpreadSheetMLTable,
-		"pivotTable":    ContentTypeSpreadSheetMLPivotTable,
-		"pivotCache":    ContentTypeSpreadSheetMLPivotCacheDefinition,
-		"sharedStrings": ContentTypeSpreadSheetMLSharedStrings,
+		"chart":             ContentTypeDrawingML,
+		"chartsheet":        ContentTypeSpreadSheetMLChartsheet,
+		"comments":          ContentTypeSpreadSheetMLComments,
+		"drawings":          ContentTypeDrawing,
+		"table":             ContentTypeSpreadSheetMLTable,
+		"pivotTable":        ContentTypeSpreadSheetMLPivotTable,
+		"pivotCache":        ContentTypeSpreadSheetMLPivotCacheDefinition,
+		"pivotCacheRecords": ContentTypeSpreadSheetMLPivotCacheRecords,
+		"sharedStrings":     ContentTypeSpreadSheetMLSharedStrings,
+		"threadedComments":  ContentTypeThreadedComments,
+		"person":            ContentTypePerson,
+		"slicer":            ContentTypeSlicer,
+		"slicerCache":       ContentTypeSlicerCache,
 	}
 	s, ok := setContentType[contentType]
 	if ok {
@@ -10,6 +10,7 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"testing"
 
@@ -72,6 +73,23 @@ func TestAddPicture(t *testing.T) {
 	assert.NoError(t, f.Close())
 }
 
+func TestAddPicturePositioning(t *testing.T) {
+	f := NewFile()
+	for i, positioning := range []string{"", "twoCell", "oneCell", "absolute"} {
+		assert.NoError(t, f.AddPicture("Sheet1", "A"+strconv.Itoa(i*20+1), filepath.Join("test", "images", "excel.jpg"), `{"positioning":"`+positioning+`"}`))
+	}
+	wsDr, _ := f.drawingParser("xl/drawings/drawing1.xml")
+	if assert.Len(t, wsDr.TwoCellAnchor, 4) {
+		for i, editAs := range []string{"", "", "oneCell", "absolute"} {
+			assert.Equal(t, editAs, wsDr.TwoCellAnchor[i].EditAs)
+		}
+	}
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddPicturePositioning.xlsx")))
+	// Test add picture with unsupported positioning
+	assert.EqualError(t, f.AddPicture("Sheet1", "A1", filepath.Join("test", "images", "excel.jpg"), `{"positioning":"cell"}`), ErrPicturePositioning.Error())
+	assert.EqualError(t, f.AddPictureFromBytes("Sheet1", "A1", `{"positioning":"cell"}`, "Excel Logo", ".jpg", []byte{}), ErrPicturePositioning.Error())
+}
+
 func TestAddPictureErrors(t *testing.T) {
 	f, err := OpenFile(filepath.Join("test", "Book1.xlsx"))
 	assert.NoError(t, err)
@@ -12,8 +12,12 @@
 package excelize
 
 import (
+	"bytes"
 	"encoding/xml"
 	"fmt"
+	"io"
+	"math"
+	"path"
 	"strconv"
 	"strings"
 )
@@ -295,7 +299,7 @@ func (f *File) addPivotCache(pivotCacheXML string, opt *PivotTableOption) error
 				V: "",
 			}
 			sharedItems.Count++
-			sharedItems.S = &s
+			sharedItems.S = []xlsxString{s}
 		}
 
 		pc.CacheFields.CacheField = append(pc.CacheFields.CacheField, &xlsxCacheField{
@@ -713,3 +717,562 @@ func (f *File) addWorkbookPivotCache(RID int) int {
 	})
 	return cacheID
 }
+
+// pivotCacheValue directly maps the value of a cell in the source data range
+// of the pivot cache.
+type pivotCacheValue struct {
+	blank    bool
+	isNumber bool
+	number   float64
+	text     string
+}
+
+// RefreshPivotCache provides a function to refresh the pivot cache of the
+// pivot table by given worksheet name and pivot table name. The field names,
+// shared items and cached records will be regenerated from the source data
+// range of the pivot cache, the items of the row, column and filter fields
+// and the location of the pivot table in the compact form will be updated,
+// and the other content of the pivot cache definition and the pivot table
+// will be kept as it is. Note that the number of the fields in the source
+// data range should not be changed. For example,
+// refresh the pivot table named "Pivot Table1" in Sheet1 after changing the
+// source data:
+//
+//	err := f.RefreshPivotCache("Sheet1", "Pivot Table1")
+func (f *File) RefreshPivotCache(sheet, pivotName string) error {
+	pivotTableXML, pt, err := f.getPivotTable(sheet, pivotName)
+	if err != nil {
+		return err
+	}
+	pivotCacheXML := f.getRelsTargetPath(pivotTableXML, SourceRelationshipPivotCache)
+	if pivotCacheXML == "" {
+		return ErrPivotCacheNotExist
+	}
+	pc, err := f.pivotCacheDefinitionReader(pivotCacheXML)
+	if err != nil {
+		return err
+	}
+	dataSheet, coordinates, err := f.getPivotCacheSourceRange(pc, sheet)
+	if err != nil {
+		return err
+	}
+	if pt.PivotFields == nil || len(pt.PivotFields.PivotField) != coordinates[2]-coordinates[0]+1 {
+		return ErrPivotCacheFields
+	}
+	records := make([]xlsxPivotCacheRecord, coordinates[3]-coordinates[1])
+	var (
+		names       []string
+		sharedItems []*xlsxSharedItems
+		fieldsItems []*xlsxItems
+	)
+	for col := coordinates[0]; col <= coordinates[2]; col++ {
+		idx := col - coordinates[0]
+		cell, _ := CoordinatesToCellName(col, coordinates[1])
+		name, err := f.GetCellValue(dataSheet, cell)
+		if err != nil {
+			return err
+		}
+		values := make([]pivotCacheValue, 0, len(records))
+		for row := coordinates[1] + 1; row <= coordinates[3]; row++ {
+			value, err := f.getPivotCacheValue(dataSheet, col, row)
+			if err != nil {
+				return err
+			}
+			values = append(values, value)
+		}
+		pivotField := pt.PivotFields.PivotField[idx]
+		fieldSharedItems, recordValues := newPivotCacheSharedItems(values, pivotField.Axis != "")
+		for i := range records {
+			records[i].Values = append(records[i].Values, recordValues[i])
+		}
+		names, sharedItems = append(names, name), append(sharedItems, fieldSharedItems)
+		var fieldItems *xlsxItems
+		if pivotField.Axis != "" {
+			var items []*xlsxItem
+			for i := 0; i < fieldSharedItems.Count; i++ {
+				items = append(items, &xlsxItem{X: intPtr(i)})
+			}
+			if pivotField.DefaultSubtotal == nil || *pivotField.DefaultSubtotal {
+				items = append(items, &xlsxItem{T: "default"})
+			}
+			fieldItems = &xlsxItems{Count: len(items), Item: items}
+		}
+		fieldsItems = append(fieldsItems, fieldItems)
+	}
+	pivotCache, err := f.readXMLContent(pivotCacheXML)
+	if err != nil {
+		return err
+	}
+	if pivotCache, err = setPivotCacheFields(pivotCache, names, sharedItems); err != nil {
+		return err
+	}
+	if pivotCache, err = setXMLElementAttrs(pivotCache, []xml.Attr{
+		{Name: xml.Name{Local: "saveData"}, Value: "1"},
+		{Name: xml.Name{Local: "recordCount"}, Value: strconv.Itoa(len(records))},
+	}); err != nil {
+		return err
+	}
+	pivotTable, err := f.readXMLContent(pivotTableXML)
+	if err != nil {
+		return err
+	}
+	if pivotTable, err = setPivotTableFields(pivotTable, fieldsItems); err != nil {
+		return err
+	}
+	if pivotTable, err = setPivotTableLocation(pivotTable, pt, records); err != nil {
+		return err
+	}
+	pivotCacheRecordsXML := f.getRelsTargetPath(pivotCacheXML, SourceRelationshipPivotCacheRecords)
+	if pivotCacheRecordsXML == "" {
+		pivotCacheRecordsID := f.countPivotCacheRecords() + 1
+		pivotCacheRecordsXML = "xl/pivotCache/pivotCacheRecords" + strconv.Itoa(pivotCacheRecordsID) + ".xml"
+		pivotCacheRels := "xl/pivotCache/_rels/" + path.Base(pivotCacheXML) + ".rels"
+		rID := f.addRels(pivotCacheRels, SourceRelationshipPivotCacheRecords, path.Base(pivotCacheRecordsXML), "")
+		f.addContentTypePart(pivotCacheRecordsID, "pivotCacheRecords")
+		if pivotCache, err = setXMLElementAttrs(pivotCache, []xml.Attr{
+			SourceRelationship,
+			{Name: xml.Name{Space: SourceRelationship.Value, Local: "id"}, Value: "rId" + strconv.Itoa(rID)},
+		}); err != nil {
+			return err
+		}
+	}
+	output, err := xml.Marshal(xlsxPivotCacheRecords{Count: len(records), R: records})
+	f.saveFileList(pivotCacheRecordsXML, output)
+	f.saveFileList(pivotCacheXML, []byte(pivotCache))
+	f.saveFileList(pivotTableXML, []byte(pivotTable))
+	return err
+}
+
+// setPivotCacheFields provides a function to set the names and shared items
+// of the cache fields in the XML content of the pivot cache definition by
+// given field names and shared items, and keeps the rest of the content as
+// it is.
+func setPivotCacheFields(content string, names []string, sharedItems []*xlsxSharedItems) (string, error) {
+	elements, err := getXMLElements(content, 1)
+	if err != nil {
+		return content, err
+	}
+	for _, el := range elements {
+		if el.name.Local != "cacheFields" {
+			continue
+		}
+		cacheFields := content[el.start:el.end]
+		fields, err := getXMLChildElements(cacheFields, "cacheField")
+		if err != nil {
+			return content, err
+		}
+		if len(fields) != len(names) {
+			return content, ErrPivotCacheFields
+		}
+		for idx := len(fields) - 1; idx >= 0; idx-- {
+			field := cacheFields[fields[idx].start:fields[idx].end]
+			if field, err = setXMLElementAttrs(field, []xml.Attr{{Name: xml.Name{Local: "name"}, Value: names[idx]}}); err != nil {
+				return content, err
+			}
+			var b bytes.Buffer
+			if err = xml.NewEncoder(&b).EncodeElement(sharedItems[idx], xml.StartElement{Name: xml.Name{Local: "sharedItems"}}); err != nil {
+				return content, err
+			}
+			if field, err = replaceXMLChildElement(field, "sharedItems", b.String()); err != nil {
+				return content, err
+			}
+			cacheFields = cacheFields[:fields[idx].start] + field + cacheFields[fields[idx].end:]
+		}
+		return content[:el.start] + cacheFields + content[el.end:], err
+	}
+	return content, ErrPivotCacheFields
+}
+
+// setPivotTableFields provides a function to set the items of the pivot
+// fields on the axis in the XML content of the pivot table definition by
+// given items of each pivot field, and keeps the rest of the content as it
+// is. The pivot field will be kept if its items is nil.
+func setPivotTableFields(content string, fieldsItems []*xlsxItems) (string, error) {
+	elements, err := getXMLElements(content, 1)
+	if err != nil {
+		return content, err
+	}
+	for _, el := range elements {
+		if el.name.Local != "pivotFields" {
+			continue
+		}
+		pivotFields := content[el.start:el.end]
+		fields, err := getXMLChildElements(pivotFields, "pivotField")
+		if err != nil {
+			return content, err
+		}
+		if len(fields) != len(fieldsItems) {
+			return content, ErrPivotCacheFields
+		}
+		for idx := len(fields) - 1; idx >= 0; idx-- {
+			if fieldsItems[idx] == nil {
+				continue
+			}
+			var b bytes.Buffer
+			if err = xml.NewEncoder(&b).EncodeElement(fieldsItems[idx], xml.StartElement{Name: xml.Name{Local: "items"}}); err != nil {
+				return content, err
+			}
+			field, err := replaceXMLChildElement(pivotFields[fields[idx].start:fields[idx].end], "items", b.String())
+			if err != nil {
+				return content, err
+			}
+			pivotFields = pivotFields[:fields[idx].start] + field + pivotFields[fields[idx].end:]
+		}
+		return content[:el.start] + pivotFields + content[el.end:], err
+	}
+	return content, ErrPivotCacheFields
+}
+
+// setPivotTableLocation provides a function to set the location of the pivot
+// table in the XML content of the pivot table definition by given pivot
+// table definition and the records of the pivot cache. The size of the
+// location will be calculated in the compact form from the distinct items of
+// the row and column fields, and the top-left cell of the location will be
+// kept.
+func setPivotTableLocation(content string, pt *xlsxPivotTableDefinition, records []xlsxPivotCacheRecord) (string, error) {
+	if pt.Location == nil {
+		return content, nil
+	}
+	col, row, err := CellNameToCoordinates(strings.Split(pt.Location.Ref, ":")[0])
+	if err != nil {
+		return content, err
+	}
+	var rowFields, colFields []int
+	if pt.RowFields != nil {
+		for _, field := range pt.RowFields.Field {
+			rowFields = append(rowFields, field.X)
+		}
+	}
+	if pt.ColFields != nil {
+		for _, field := range pt.ColFields.Field {
+			colFields = append(colFields, field.X)
+		}
+	}
+	dataCount := 1
+	if pt.DataFields != nil && len(pt.DataFields.DataField) > 1 {
+		dataCount = len(pt.DataFields.DataField)
+	}
+	subtotal := func(field int) bool {
+		if field < 0 || field >= len(pt.PivotFields.PivotField) {
+			return false
+		}
+		return defaultTrue(pt.PivotFields.PivotField[field].DefaultSubtotal)
+	}
+	firstDataRow, firstDataCol, rows, cols := 1, 0, 1, 1
+	if len(rowFields) > 0 {
+		firstDataCol, rows = 1, 0
+		for _, count := range countPivotAxisItems(rowFields, dataCount, records) {
+			rows += count
+		}
+		if defaultTrue(pt.RowGrandTotals) {
+			rows += getPivotAxisDataCount(rowFields, dataCount)
+		}
+	}
+	if len(colFields) > 0 {
+		counts := countPivotAxisItems(colFields, dataCount, records)
+		firstDataRow, cols = len(colFields)+1, counts[len(counts)-1]
+		for level, count := range counts[:len(counts)-1] {
+			if subtotal(colFields[level]) {
+				cols += count * getPivotAxisDataCount(colFields[level+1:], dataCount)
+			}
+		}
+		if defaultTrue(pt.ColGrandTotals) {
+			cols += getPivotAxisDataCount(colFields, dataCount)
+		}
+	}
+	topLeftCell, _ := CoordinatesToCellName(col, row)
+	bottomRightCell, err := CoordinatesToCellName(col+firstDataCol+cols-1, row+firstDataRow+rows-1)
+	if err != nil {
+		return content, err
+	}
+	elements, err := getXMLChildElements(content, "location")
+	if err != nil || len(elements) == 0 {
+		return content, err
+	}
+	location, err := setXMLElementAttrs(content[elements[0].start:elements[0].end], []xml.Attr{
+		{Name: xml.Name{Local: "ref"}, Value: topLeftCell + ":" + bottomRightCell},
+		{Name: xml.Name{Local: "firstDataRow"}, Value: strconv.Itoa(firstDataRow)},
+		{Name: xml.Name{Local: "firstDataCol"}, Value: strconv.Itoa(firstDataCol)},
+	})
+	return content[:elements[0].start] + location + content[elements[0].end:], err
+}
+
+// countPivotAxisItems provides a function to count the distinct items of
+// each level of the pivot table axis by given field indexes on the axis, the
+// number of the data fields and the records of the pivot cache. The index -2
+// represents the values field, which has an item for each data field.
+func countPivotAxisItems(fields []int, dataCount int, records []xlsxPivotCacheRecord) []int {
+	keys := make([]map[string]struct{}, len(fields))
+	for level := range keys {
+		keys[level] = make(map[string]struct{})
+	}
+	for _, record := range records {
+		var key string
+		for level, field := range fields {
+			if field >= 0 && field < len(record.Values) {
+				key += record.Values[field].XMLName.Local + record.Values[field].V + "\x00"
+			}
+			keys[level][key] = struct{}{}
+		}
+	}
+	counts, multiple := make([]int, len(fields)), 1
+	for level, field := range fields {
+		if field == -2 {
+			multiple = dataCount
+		}
+		counts[level] = len(keys[level]) * multiple
+	}
+	return counts
+}
+
+// getPivotAxisDataCount returns the number of the data fields if the values
+// field is on the given fields of the pivot table axis, otherwise returns 1.
+func getPivotAxisDataCount(fields []int, dataCount int) int {
+	for _, field := range fields {
+		if field == -2 {
+			return dataCount
+		}
+	}
+	return 1
+}
+
+// SetPivotCacheRefreshOnLoad provides a function to set whether the pivot
+// cache of the pivot table should be refreshed when the spreadsheet is
+// opened by given worksheet name, pivot table name and flag. For example,
+// disable refreshing the pivot table named "Pivot Table1" in Sheet1 on load
+// after refreshing the pivot cache:
+//
+//	err := f.SetPivotCacheRefreshOnLoad("Sheet1", "Pivot Table1", false)
+func (f *File) SetPivotCacheRefreshOnLoad(sheet, pivotName string, refreshOnLoad bool) error {
+	pivotTableXML, _, err := f.getPivotTable(sheet, pivotName)
+	if err != nil {
+		return err
+	}
+	pivotCacheXML := f.getRelsTargetPath(pivotTableXML, SourceRelationshipPivotCache)
+	if pivotCacheXML == "" {
+		return ErrPivotCacheNotExist
+	}
+	value := "0"
+	if refreshOnLoad {
+		value = "1"
+	}
+	pivotCache, err := f.readXMLContent(pivotCacheXML)
+	if err != nil {
+		return err
+	}
+	if pivotCache, err = setXMLElementAttrs(pivotCache, []xml.Attr{{Name: xml.Name{Local: "refreshOnLoad"}, Value: value}}); err != nil {
+		return err
+	}
+	f.saveFileList(pivotCacheXML, []byte(pivotCache))
+	return err
+}
+
+// getPivotTable provides a function to get the path and the definition of
+// the pivot table by given worksheet name and pivot table name.
+func (f *File) getPivotTable(sheet, pivotName string) (string, *xlsxPivotTableDefinition, error) {
+	if _, err := f.workSheetReader(sheet); err != nil {
+		return "", nil, err
+	}
+	sheetXMLPath, _ := f.getSheetXMLPath(sheet)
+	rels := f.relsReader(path.Dir(sheetXMLPath) + "/_rels/" + path.Base(sheetXMLPath) + ".rels")
+	if rels == nil {
+		return "", nil, ErrPivotTableNotExist
+	}
+	rels.Lock()
+	var pivotTables []string
+	for _, rel := range rels.Relationships {
+		if rel.Type == SourceRelationshipPivotTable {
+			pivotTables = append(pivotTables, getRelsTargetPath(sheetXMLPath, rel.Target))
+		}
+	}
+	rels.Unlock()
+	for _, pivotTableXML := range pivotTables {
+		pt := new(xlsxPivotTableDefinition)
+		if err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(f.readXML(pivotTableXML)))).
+			Decode(pt); err != nil && err != io.EOF {
+			return pivotTableXML, pt, err
+		}
+		if strings.EqualFold(pt.Name, pivotName) {
+			return pivotTableXML, pt, nil
+		}
+	}
+	return "", nil, ErrPivotTableNotExist
+}
+
+// pivotCacheDefinitionReader provides a function to get the structure after
+// deserialization of xl/pivotCache/pivotCacheDefinition%d.xml.
+func (f *File) pivotCacheDefinitionReader(path string) (*xlsxPivotCacheDefinition, error) {
+	pc := new(xlsxPivotCacheDefinition)
+	if err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(f.readXML(path)))).
+		Decode(pc); err != nil && err != io.EOF {
+		return pc, err
+	}
+	return pc, nil
+}
+
+// getRelsTargetPath provides a function to get the path of the target part
+// of the first relationship of the given type in the relationships part of
+// the given part, returns an empty string if not found.
+func (f *File) getRelsTargetPath(partPath, relType string) string {
+	if rels := f.relsReader(path.Dir(partPath) + "/_rels/" + path.Base(partPath) + ".rels"); rels != nil {
+		rels.Lock()
+		defer rels.Unlock()
+		for _, rel := range rels.Relationships {
+			if rel.Type == relType {
+				return getRelsTargetPath(partPath, rel.Target)
+			}
+		}
+	}
+	return ""
+}
+
+// getRelsTargetPath provides a function to convert the target of the
+// relationship to the path of the part in the package by given source part
+// path and relationship target.
+func getRelsTargetPath(partPath, target string) string {
+	if strings.HasPrefix(target, "/") {
+		return strings.TrimPrefix(target, "/")
+	}
+	return path.Join(path.Dir(partPath), target)
+}
+
+// getPivotCacheSourceRange provides a function to get the worksheet name and
+// coordinates of the source data range of the pivot cache.
+func (f *File) getPivotCacheSourceRange(pc *xlsxPivotCacheDefinition, sheet string) (string, []int, error) {
+	if pc.CacheSource == nil || pc.CacheSource.WorksheetSource == nil {
+		return "", nil, ErrPivotCacheSource
+	}
+	src := pc.CacheSource.WorksheetSource
+	dataRange := src.Sheet + "!" + src.Ref
+	if src.Name != "" {
+		if dataRange = f.getDefinedNameRefTo(src.Name, sheet); dataRange == "" {
+			return "", nil, ErrPivotCacheSource
+		}
+	}
+	dataSheet, coordinates, err := f.adjustRange(dataRange)
+	if err != nil {
+		return dataSheet, coordinates, fmt.Errorf("parameter 'DataRange' parsing error: %s", err.Error())
+	}
+	return strings.Trim(dataSheet, "'"), coordinates, err
+}
+
+// getPivotCacheValue provides a function to get the value of the cell in the
+// source data range of the pivot cache by given worksheet name and cell
+// coordinates.
+func (f *File) getPivotCacheValue(sheet string, col, row int) (pivotCacheValue, error) {
+	var value pivotCacheValue
+	cell, _ := CoordinatesToCellName(col, row)
+	cellType, err := f.GetCellType(sheet, cell)
+	if err != nil {
+		return value, err
+	}
+	raw, err := f.GetCellValue(sheet, cell, Options{RawCellValue: true})
+	if err != nil {
+		return value, err
+	}
+	if value.blank = raw == ""; value.blank {
+		return value, err
+	}
+	if cellType == CellTypeUnset || cellType == CellTypeNumber {
+		if number, err := strconv.ParseFloat(raw, 64); err == nil {
+			value.isNumber, value.number = true, number
+			return value, nil
+		}
+	}
+	value.text, err = f.GetCellValue(sheet, cell)
+	return value, err
+}
+
+// newPivotCacheSharedItems provides a function to create the shared items of
+// the cache field and the values of the records by given values of the field
+// in the source data range. The numbers will be stored in the records
+// directly unless the field contains text or is used on the axis of the
+// pivot table.
+func newPivotCacheSharedItems(values []pivotCacheValue, axis bool) (*xlsxSharedItems, []xlsxPivotCacheRecordValue) {
+	var (
+		hasBlank, hasString bool
+		isInteger           = true
+		numbers             []float64
+		texts               []string
+		numberIdx           = make(map[float64]int)
+		textIdx             = make(map[string]int)
+		sharedItems         = &xlsxSharedItems{}
+	)
+	for _, value := range values {
+		if value.blank {
+			hasBlank = true
+			continue
+		}
+		if value.isNumber {
+			if _, ok := numberIdx[value.number]; !ok {
+				numberIdx[value.number] = len(numbers)
+				numbers = append(numbers, value.number)
+			}
+			if len(numbers) == 1 || value.number < sharedItems.MinValue {
+				sharedItems.MinValue = value.number
+			}
+			if len(numbers) == 1 || value.number > sharedItems.MaxValue {
+				sharedItems.MaxValue = value.number
+			}
+			isInteger = isInteger && value.number == math.Trunc(value.number)
+			continue
+		}
+		hasString = true
+		if _, ok := textIdx[value.text]; !ok {
+			textIdx[value.text] = len(texts)
+			texts = append(texts, value.text)
+		}
+	}
+	sharedItems.ContainsBlank = hasBlank
+	sharedItems.ContainsNumber = len(numbers) > 0
+	sharedItems.ContainsInteger = len(numbers) > 0 && isInteger
+	sharedItems.ContainsMixedTypes = hasString && len(numbers) > 0
+	if !hasString {
+		sharedItems.ContainsString = boolPtr(false)
+		if !hasBlank {
+			sharedItems.ContainsSemiMixedTypes = boolPtr(false)
+		}
+	}
+	shared, offset := axis || hasString, 0
+	if shared {
+		if hasBlank {
+			sharedItems.M, offset = []xlsxMissing{{}}, 1
+		}
+		for _, number := range numbers {
+			sharedItems.N = append(sharedItems.N, xlsxNumber{V: number})
+		}
+		for _, text := range texts {
+			sharedItems.S = append(sharedItems.S, xlsxString{V: text})
+		}
+		sharedItems.Count = len(sharedItems.M) + len(sharedItems.N) + len(sharedItems.S)
+	}
+	recordValues := make([]xlsxPivotCacheRecordValue, len(values))
+	for i, value := range values {
+		switch {
+		case shared && value.blank:
+			recordValues[i] = xlsxPivotCacheRecordValue{XMLName: xml.Name{Local: "x"}, V: "0"}
+		case shared && value.isNumber:
+			recordValues[i] = xlsxPivotCacheRecordValue{XMLName: xml.Name{Local: "x"}, V: strconv.Itoa(offset + numberIdx[value.number])}
+		case shared:
+			recordValues[i] = xlsxPivotCacheRecordValue{XMLName: xml.Name{Local: "x"}, V: strconv.Itoa(offset + len(numbers) + textIdx[value.text])}
+		case value.blank:
+			recordValues[i] = xlsxPivotCacheRecordValue{XMLName: xml.Name{Local: "m"}}
+		default:
+			recordValues[i] = xlsxPivotCacheRecordValue{XMLName: xml.Name{Local: "n"}, V: strconv.FormatFloat(value.number, 'f', -1, 64)}
+		}
+	}
+	return sharedItems, recordValues
+}
+
+// countPivotCacheRecords provides a function to get pivot cache records files
+// count storage in the folder xl/pivotCache.
+func (f *File) countPivotCacheRecords() int {
+	count := 0
+	f.Pkg.Range(func(k, v interface{}) bool {
+		if strings.Contains(k.(string), "xl/pivotCache/pivotCacheRecords") {
+			count++
+		}
+		return true
+	})
+	return count
+}
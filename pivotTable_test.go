@@ -1,6 +1,7 @@
 package excelize
 
 import (
+	"encoding/xml"
 	"fmt"
 	"math/rand"
 	"path/filepath"
@@ -305,3 +306,141 @@ func TestGetPivotTableFieldName(t *testing.T) {
 	f := NewFile()
 	f.getPivotTableFieldName("-", []PivotTableField{})
 }
+
+func TestRefreshPivotCache(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]string{"Month", "Year", "Type", "Sales", "Region"}))
+	for row, data := range [][]interface{}{
+		{"Jan", 2017, "Meat", 100, "East"},
+		{"Feb", 2018, "Dairy", 200.5, "West"},
+		{"Jan", 2018, "Meat", 300, nil},
+		{"Mar", 2017, 1, 400, "East"},
+	} {
+		assert.NoError(t, f.SetSheetRow("Sheet1", fmt.Sprintf("A%d", row+2), &data))
+	}
+	assert.NoError(t, f.AddPivotTable(&PivotTableOption{
+		DataRange:       "Sheet1!$A$1:$E$5",
+		PivotTableRange: "Sheet1!$G$2:$M$34",
+		Rows:            []PivotTableField{{Data: "Month", DefaultSubtotal: true}, {Data: "Year"}},
+		Filter:          []PivotTableField{{Data: "Region"}},
+		Columns:         []PivotTableField{{Data: "Type", DefaultSubtotal: true}},
+		Data:            []PivotTableField{{Data: "Sales", Subtotal: "Sum", Name: "Summarize by Sum"}},
+	}))
+	// Test refresh pivot cache keeps the unknown content of the pivot cache
+	// definition and the pivot table
+	pivotCache := strings.Replace(string(f.readXML("xl/pivotCache/pivotCacheDefinition1.xml")), "<cacheFields",
+		`<extLst><ext uri="{725AE2AE-9491-48be-B2B4-4EB974FC3084}"><x14:pivotCacheDefinition xmlns:x14="http://schemas.microsoft.com/office/spreadsheetml/2009/9/main" pivotCacheId="1"/></ext></extLst><cacheFields`, 1)
+	pivotCache = strings.Replace(pivotCache, `<cacheField name="Year" numFmtId="0">`, `<cacheField name="Year" numFmtId="0" databaseField="1"><fieldGroup base="1"/>`, 1)
+	f.Pkg.Store("xl/pivotCache/pivotCacheDefinition1.xml", []byte(pivotCache))
+	pivotTable := strings.Replace(string(f.readXML("xl/pivotTables/pivotTable1.xml")), "<pivotFields",
+		`<extLst><ext uri="{962EF5D1-5CA2-4c93-8EF4-DBF5C05439D2}"/></extLst><pivotFields`, 1)
+	f.Pkg.Store("xl/pivotTables/pivotTable1.xml", []byte(pivotTable))
+	assert.NoError(t, f.RefreshPivotCache("Sheet1", "Pivot Table1"))
+	pivotCache = string(f.readXML("xl/pivotCache/pivotCacheDefinition1.xml"))
+	assert.Contains(t, pivotCache, `pivotCacheId="1"`)
+	assert.Contains(t, pivotCache, `databaseField="1"`)
+	assert.Contains(t, pivotCache, `<fieldGroup base="1"/>`)
+	assert.Contains(t, string(f.readXML("xl/pivotTables/pivotTable1.xml")), `<ext uri="{962EF5D1-5CA2-4c93-8EF4-DBF5C05439D2}"/>`)
+	// Test refresh pivot cache again after changing the source data
+	assert.NoError(t, f.SetCellValue("Sheet1", "A5", "Apr"))
+	assert.NoError(t, f.RefreshPivotCache("Sheet1", "pivot table1"))
+
+	pc, err := f.pivotCacheDefinitionReader("xl/pivotCache/pivotCacheDefinition1.xml")
+	assert.NoError(t, err)
+	assert.True(t, pc.SaveData)
+	assert.Equal(t, 4, pc.RecordCount)
+	assert.Equal(t, "rId1", pc.RID)
+	fields := pc.CacheFields.CacheField
+	assert.Len(t, fields, 5)
+	assert.Equal(t, "Month", fields[0].Name)
+	assert.Equal(t, []xlsxString{{V: "Jan"}, {V: "Feb"}, {V: "Apr"}}, fields[0].SharedItems.S)
+	assert.Equal(t, []xlsxNumber{{V: 2017}, {V: 2018}}, fields[1].SharedItems.N)
+	assert.True(t, fields[2].SharedItems.ContainsMixedTypes)
+	assert.Equal(t, 3, fields[2].SharedItems.Count)
+	assert.Equal(t, 0, fields[3].SharedItems.Count)
+	assert.Equal(t, 100.0, fields[3].SharedItems.MinValue)
+	assert.Equal(t, 400.0, fields[3].SharedItems.MaxValue)
+	assert.False(t, fields[3].SharedItems.ContainsInteger)
+	assert.False(t, *fields[3].SharedItems.ContainsString)
+	assert.True(t, fields[4].SharedItems.ContainsBlank)
+	assert.Len(t, fields[4].SharedItems.M, 1)
+
+	records := new(xlsxPivotCacheRecords)
+	assert.NoError(t, xml.Unmarshal(f.readXML("xl/pivotCache/pivotCacheRecords1.xml"), records))
+	assert.Equal(t, 4, records.Count)
+	var values []string
+	for _, value := range records.R[3].Values {
+		values = append(values, value.XMLName.Local+value.V)
+	}
+	assert.Equal(t, []string{"x2", "x0", "x0", "n400", "x1"}, values)
+	values = nil
+	for _, value := range records.R[2].Values {
+		values = append(values, value.XMLName.Local+value.V)
+	}
+	assert.Equal(t, []string{"x0", "x1", "x1", "n300", "x0"}, values)
+
+	_, pt, err := f.getPivotTable("Sheet1", "Pivot Table1")
+	assert.NoError(t, err)
+	assert.Equal(t, 4, pt.PivotFields.PivotField[0].Items.Count)
+	assert.Equal(t, "default", pt.PivotFields.PivotField[0].Items.Item[3].T)
+	assert.Equal(t, 2, pt.PivotFields.PivotField[1].Items.Count)
+	assert.Equal(t, 4, pt.PivotFields.PivotField[4].Items.Count)
+	assert.Nil(t, pt.PivotFields.PivotField[3].Items)
+	assert.Equal(t, &xlsxLocation{Ref: "G2:J10", FirstHeaderRow: 1, FirstDataRow: 2, FirstDataCol: 1}, pt.Location)
+
+	assert.NoError(t, f.SetPivotCacheRefreshOnLoad("Sheet1", "Pivot Table1", false))
+	pc, err = f.pivotCacheDefinitionReader("xl/pivotCache/pivotCacheDefinition1.xml")
+	assert.NoError(t, err)
+	assert.False(t, pc.RefreshOnLoad)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestRefreshPivotCache.xlsx")))
+
+	// Test refresh pivot cache with the source data range of defined name
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "dataRange", RefersTo: "Sheet1!$A$1:$E$5"}))
+	pc.CacheSource.WorksheetSource = &xlsxWorksheetSource{Name: "dataRange"}
+	output, err := xml.Marshal(pc)
+	assert.NoError(t, err)
+	f.saveFileList("xl/pivotCache/pivotCacheDefinition1.xml", output)
+	assert.NoError(t, f.RefreshPivotCache("Sheet1", "Pivot Table1"))
+	// Test refresh pivot cache after the source data range grows
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A6", &[]interface{}{"May", 2019, "Fish", 500, "North"}))
+	assert.NoError(t, f.DeleteDefinedName(&DefinedName{Name: "dataRange"}))
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "dataRange", RefersTo: "Sheet1!$A$1:$E$6"}))
+	assert.NoError(t, f.RefreshPivotCache("Sheet1", "Pivot Table1"))
+	_, pt, err = f.getPivotTable("Sheet1", "Pivot Table1")
+	assert.NoError(t, err)
+	assert.Equal(t, "G2:K12", pt.Location.Ref)
+	assert.Equal(t, 5, pt.PivotFields.PivotField[0].Items.Count)
+	pc, err = f.pivotCacheDefinitionReader("xl/pivotCache/pivotCacheDefinition1.xml")
+	assert.NoError(t, err)
+	assert.Equal(t, 5, pc.RecordCount)
+	// Test refresh pivot cache with the unsupported source
+	pc.CacheSource.WorksheetSource = &xlsxWorksheetSource{Name: "unknown"}
+	output, err = xml.Marshal(pc)
+	assert.NoError(t, err)
+	f.saveFileList("xl/pivotCache/pivotCacheDefinition1.xml", output)
+	assert.EqualError(t, f.RefreshPivotCache("Sheet1", "Pivot Table1"), ErrPivotCacheSource.Error())
+	pc.CacheSource.WorksheetSource = &xlsxWorksheetSource{Sheet: "Sheet1", Ref: "A1:D5"}
+	output, err = xml.Marshal(pc)
+	assert.NoError(t, err)
+	f.saveFileList("xl/pivotCache/pivotCacheDefinition1.xml", output)
+	assert.EqualError(t, f.RefreshPivotCache("Sheet1", "Pivot Table1"), ErrPivotCacheFields.Error())
+	pc.CacheSource = nil
+	output, err = xml.Marshal(pc)
+	assert.NoError(t, err)
+	f.saveFileList("xl/pivotCache/pivotCacheDefinition1.xml", output)
+	assert.EqualError(t, f.RefreshPivotCache("Sheet1", "Pivot Table1"), ErrPivotCacheSource.Error())
+	// Test refresh pivot cache on not exists pivot table
+	assert.EqualError(t, f.RefreshPivotCache("Sheet1", "Pivot Table2"), ErrPivotTableNotExist.Error())
+	assert.EqualError(t, f.SetPivotCacheRefreshOnLoad("Sheet1", "Pivot Table2", true), ErrPivotTableNotExist.Error())
+	f.NewSheet("Sheet2")
+	assert.EqualError(t, f.RefreshPivotCache("Sheet2", "Pivot Table1"), ErrPivotTableNotExist.Error())
+	assert.EqualError(t, f.RefreshPivotCache("SheetN", "Pivot Table1"), "sheet SheetN is not exist")
+	// Test refresh pivot cache without pivot cache
+	f.Relationships.Delete("xl/pivotTables/_rels/pivotTable1.xml.rels")
+	f.Pkg.Delete("xl/pivotTables/_rels/pivotTable1.xml.rels")
+	assert.EqualError(t, f.RefreshPivotCache("Sheet1", "Pivot Table1"), ErrPivotCacheNotExist.Error())
+	assert.EqualError(t, f.SetPivotCacheRefreshOnLoad("Sheet1", "Pivot Table1", true), ErrPivotCacheNotExist.Error())
+	// Test refresh pivot cache with unsupported charset
+	f.Pkg.Store("xl/pivotTables/pivotTable1.xml", MacintoshCyrillicCharset)
+	assert.EqualError(t, f.RefreshPivotCache("Sheet1", "Pivot Table1"), "XML syntax error on line 1: invalid UTF-8")
+}
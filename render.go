@@ -0,0 +1,654 @@
+// Copyright 2016 - 2022 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to and
+// read from XLAM / XLSM / XLSX / XLTM / XLTX files. Supports reading and
+// writing spreadsheet documents generated by Microsoft Excel™ 2007 and later.
+// Supports complex components by high compatibility, and provided streaming
+// API for generating or reading data from a worksheet with huge amounts of
+// data. This library needs Go version 1.15 or later.
+
+package excelize
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/gobolditalic"
+	"golang.org/x/image/font/gofont/goitalic"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/math/fixed"
+)
+
+// RenderOptions directly maps the settings of rendering a range of cells to
+// an image. The Scale specifies the zoom factor of the image, the default
+// value is 1, which renders the cells in the size of 96 DPI. Set the
+// ShowGridLines as true to draw the gridlines of the cells without fill.
+type RenderOptions struct {
+	Scale         float64
+	ShowGridLines bool
+}
+
+// renderStyle directly maps the resolved style settings of a cell to be
+// rendered.
+type renderStyle struct {
+	fill      color.Color
+	font      *Font
+	borders   []Border
+	alignment *Alignment
+}
+
+// renderCell directly maps a single cell or a merged cell to be rendered.
+type renderCell struct {
+	col, row int
+	rect     image.Rectangle
+	value    string
+	cellType CellType
+	merged   bool
+	style    *renderStyle
+}
+
+// renderer directly maps the state of rendering a range of cells.
+type renderer struct {
+	img   *image.RGBA
+	scale float64
+	faces map[string]font.Face
+}
+
+var (
+	renderFontsOnce sync.Once
+	renderFonts     [4]*opentype.Font
+	renderFontsErr  error
+	renderGridColor = color.RGBA{R: 0xD4, G: 0xD4, B: 0xD4, A: 0xFF}
+	// renderBorderDashes defined the dash patterns of the border styles in
+	// pixels, the solid border doesn't have dash pattern.
+	renderBorderDashes = map[int][]int{
+		3: {3, 1}, 4: {1, 1}, 7: {1, 1}, 8: {9, 3}, 9: {9, 3, 3, 3}, 10: {9, 3, 3, 3},
+		11: {9, 3, 3, 3, 3, 3}, 12: {9, 3, 3, 3, 3, 3}, 13: {11, 1, 5, 1},
+	}
+	// renderBorderWidths defined the widths of the border styles in pixels.
+	renderBorderWidths = map[int]int{2: 2, 5: 3, 6: 3, 8: 2, 10: 2, 12: 2, 13: 2}
+)
+
+// RenderRangeToImage provides a function to render a range of cells of the
+// worksheet to an image in the PNG format by given worksheet name, range
+// reference and render options. The renderer supports the cell values with
+// number formats, the fonts size, bold, italic, underline, strikethrough and
+// color, the pattern and gradient fills, the borders, the horizontal and
+// vertical alignment, wrap text, indent and merged cells. The text will be
+// drawn with the built-in Go fonts in the size of the cell fonts, the font
+// families of the cells will be ignored, and the charts, pictures, shapes and
+// conditional formats will not be rendered. The number of the cells in the
+// range can't exceed MaxRenderCells, and the number of the pixels of the image
+// can't exceed MaxRenderPixels. For example, render the range Sheet1!A1:F20
+// with gridlines to an image file:
+//
+//	data, err := f.RenderRangeToImage("Sheet1", "A1:F20", excelize.RenderOptions{
+//	    ShowGridLines: true,
+//	})
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	if err := os.WriteFile("report.png", data, 0644); err != nil {
+//	    fmt.Println(err)
+//	}
+func (f *File) RenderRangeToImage(sheet, rangeRef string, opts RenderOptions) ([]byte, error) {
+	coordinates, err := rangeRefToCoordinates(rangeRef)
+	if err != nil {
+		return nil, err
+	}
+	if opts.Scale < 0 {
+		return nil, ErrParameterInvalid
+	}
+	if opts.Scale == 0 {
+		opts.Scale = 1
+	}
+	if (coordinates[2]-coordinates[0]+1)*(coordinates[3]-coordinates[1]+1) > MaxRenderCells {
+		return nil, ErrRenderCells
+	}
+	if _, err = f.workSheetReader(sheet); err != nil {
+		return nil, err
+	}
+	xs, ys, err := f.getRenderGrid(sheet, coordinates, opts.Scale)
+	if err != nil {
+		return nil, err
+	}
+	width, height := int(math.Max(float64(xs[len(xs)-1]), 1)), int(math.Max(float64(ys[len(ys)-1]), 1))
+	if width*height > MaxRenderPixels {
+		return nil, ErrRenderImageSize
+	}
+	cells, err := f.getRenderCells(sheet, coordinates, xs, ys)
+	if err != nil {
+		return nil, err
+	}
+	if renderFontsOnce.Do(parseRenderFonts); renderFontsErr != nil {
+		return nil, renderFontsErr
+	}
+	r := &renderer{
+		img:   image.NewRGBA(image.Rect(0, 0, width, height)),
+		scale: opts.Scale,
+		faces: make(map[string]font.Face),
+	}
+	defer func() {
+		for _, face := range r.faces {
+			_ = face.Close()
+		}
+	}()
+	draw.Draw(r.img, r.img.Bounds(), image.White, image.Point{}, draw.Src)
+	for _, cell := range cells {
+		if cell.style.fill != nil {
+			draw.Draw(r.img, cell.rect, image.NewUniform(cell.style.fill), image.Point{}, draw.Src)
+			continue
+		}
+		if opts.ShowGridLines && !cell.rect.Empty() {
+			r.drawGridLines(cell.rect)
+		}
+	}
+	for _, cell := range cells {
+		r.drawBorders(cell)
+	}
+	empty := getRenderEmptyCells(cells)
+	for _, cell := range cells {
+		if err = r.drawText(cell, getRenderClip(cell, empty, coordinates)); err != nil {
+			return nil, err
+		}
+	}
+	var buf bytes.Buffer
+	err = png.Encode(&buf, r.img)
+	return buf.Bytes(), err
+}
+
+// parseRenderFonts provides a function to parse the built-in regular, bold,
+// italic and bold italic fonts used for rendering text.
+func parseRenderFonts() {
+	for idx, data := range [][]byte{goregular.TTF, gobold.TTF, goitalic.TTF, gobolditalic.TTF} {
+		if renderFonts[idx], renderFontsErr = opentype.Parse(data); renderFontsErr != nil {
+			return
+		}
+	}
+}
+
+// getRenderGrid provides a function to get the offsets of the column and row
+// boundaries in pixels by given worksheet name, coordinates of the range and
+// scale. The hidden columns and rows have zero width and height.
+func (f *File) getRenderGrid(sheet string, coordinates []int, scale float64) ([]int, []int, error) {
+	xs := make([]int, coordinates[2]-coordinates[0]+2)
+	ys := make([]int, coordinates[3]-coordinates[1]+2)
+	for col := coordinates[0]; col <= coordinates[2]; col++ {
+		name, err := ColumnNumberToName(col)
+		if err != nil {
+			return xs, ys, err
+		}
+		var pixels float64
+		visible, err := f.GetColVisible(sheet, name)
+		if err != nil {
+			return xs, ys, err
+		}
+		if visible {
+			width, err := f.GetColWidth(sheet, name)
+			if err != nil {
+				return xs, ys, err
+			}
+			pixels = convertColWidthToPixels(width)
+		}
+		idx := col - coordinates[0] + 1
+		xs[idx] = xs[idx-1] + int(math.Round(pixels*scale))
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return xs, ys, err
+	}
+	for row := coordinates[1]; row <= coordinates[3]; row++ {
+		var pixels float64
+		if row > len(ws.SheetData.Row) || !ws.SheetData.Row[row-1].Hidden {
+			height, err := f.GetRowHeight(sheet, row)
+			if err != nil {
+				return xs, ys, err
+			}
+			pixels = convertRowHeightToPixels(height)
+		}
+		idx := row - coordinates[1] + 1
+		ys[idx] = ys[idx-1] + int(math.Round(pixels*scale))
+	}
+	return xs, ys, nil
+}
+
+// getRenderCells provides a function to get the cells to be rendered in the
+// range by given worksheet name, coordinates of the range and the offsets of
+// the column and row boundaries. The cells covered by a merged cell will be
+// rendered as a single cell with the value and style of its top-left cell.
+func (f *File) getRenderCells(sheet string, coordinates []int, xs, ys []int) ([]renderCell, error) {
+	mergeCells, err := f.GetMergeCells(sheet)
+	if err != nil {
+		return nil, err
+	}
+	covered := make(map[[2]int]bool)
+	var cells []renderCell
+	styles := make(map[int]*renderStyle)
+	addCell := func(col, row, lastCol, lastRow int, axis string, merged bool) error {
+		cell := renderCell{
+			col: col, row: row, merged: merged,
+			rect: image.Rect(xs[col-coordinates[0]], ys[row-coordinates[1]],
+				xs[lastCol-coordinates[0]+1], ys[lastRow-coordinates[1]+1]),
+		}
+		if cell.value, err = f.GetCellValue(sheet, axis); err != nil {
+			return err
+		}
+		if cell.cellType, err = f.GetCellType(sheet, axis); err != nil {
+			return err
+		}
+		styleID, err := f.GetCellStyle(sheet, axis)
+		if err != nil {
+			return err
+		}
+		if cell.style = styles[styleID]; cell.style == nil {
+			cell.style = f.getRenderStyle(styleID)
+			styles[styleID] = cell.style
+		}
+		cells = append(cells, cell)
+		return err
+	}
+	for _, mergeCell := range mergeCells {
+		rect, err := areaRefToCoordinates(mergeCell.GetStartAxis() + ":" + mergeCell.GetEndAxis())
+		if err != nil {
+			return cells, err
+		}
+		_ = sortCoordinates(rect)
+		col, row := int(math.Max(float64(rect[0]), float64(coordinates[0]))), int(math.Max(float64(rect[1]), float64(coordinates[1])))
+		lastCol, lastRow := int(math.Min(float64(rect[2]), float64(coordinates[2]))), int(math.Min(float64(rect[3]), float64(coordinates[3])))
+		if col > lastCol || row > lastRow {
+			continue
+		}
+		for c := col; c <= lastCol; c++ {
+			for r := row; r <= lastRow; r++ {
+				covered[[2]int{c, r}] = true
+			}
+		}
+		if err = addCell(col, row, lastCol, lastRow, mergeCell.GetStartAxis(), true); err != nil {
+			return cells, err
+		}
+	}
+	for row := coordinates[1]; row <= coordinates[3]; row++ {
+		for col := coordinates[0]; col <= coordinates[2]; col++ {
+			if covered[[2]int{col, row}] {
+				continue
+			}
+			axis, _ := CoordinatesToCellName(col, row)
+			if err = addCell(col, row, col, row, axis, false); err != nil {
+				return cells, err
+			}
+		}
+	}
+	return cells, err
+}
+
+// getRenderStyle provides a function to resolve the font, fill, borders and
+// alignment of the cell style by given style index.
+func (f *File) getRenderStyle(styleID int) *renderStyle {
+	var (
+		style          = &renderStyle{}
+		fnt            *xlsxFont
+		fill           *xlsxFill
+		border         *xlsxBorder
+		fontID, fillID = 0, 0
+	)
+	s := f.stylesReader()
+	s.Lock()
+	if s.CellXfs != nil && styleID >= 0 && styleID < len(s.CellXfs.Xf) {
+		xf := s.CellXfs.Xf[styleID]
+		if xf.FontID != nil {
+			fontID = *xf.FontID
+		}
+		if xf.FillID != nil {
+			fillID = *xf.FillID
+		}
+		if s.Borders != nil && xf.BorderID != nil && *xf.BorderID >= 0 && *xf.BorderID < len(s.Borders.Border) {
+			border = s.Borders.Border[*xf.BorderID]
+		}
+		style.alignment = extractAlignment(xf.Alignment)
+	}
+	if s.Fonts != nil && fontID >= 0 && fontID < len(s.Fonts.Font) {
+		fnt = s.Fonts.Font[fontID]
+	}
+	if s.Fills != nil && fillID >= 0 && fillID < len(s.Fills.Fill) {
+		fill = s.Fills.Fill[fillID]
+	}
+	s.Unlock()
+	if style.font = f.extractFont(fnt); style.font == nil {
+		style.font = &Font{}
+	}
+	style.fill = f.getRenderFillColor(fill)
+	style.borders = f.extractBorders(border)
+	if style.alignment == nil {
+		style.alignment = &Alignment{}
+	}
+	return style
+}
+
+// getRenderFillColor provides a function to get the color to fill the cell
+// by given fill. The foreground color will be used for the pattern fill, and
+// the average color of the gradient stops will be used for the gradient fill.
+func (f *File) getRenderFillColor(fill *xlsxFill) color.Color {
+	if fill == nil {
+		return nil
+	}
+	if fill.GradientFill != nil {
+		var r, g, b, n int
+		for _, stop := range fill.GradientFill.Stop {
+			if clr, ok := parseRenderColor(f.getColorRGB(&stop.Color)); ok {
+				r, g, b, n = r+int(clr.R), g+int(clr.G), b+int(clr.B), n+1
+			}
+		}
+		if n == 0 {
+			return nil
+		}
+		return color.RGBA{R: uint8(r / n), G: uint8(g / n), B: uint8(b / n), A: 0xFF}
+	}
+	if fill.PatternFill == nil || fill.PatternFill.PatternType == "" || fill.PatternFill.PatternType == "none" {
+		return nil
+	}
+	rgb := f.getColorRGB(fill.PatternFill.FgColor)
+	if rgb == "" && fill.PatternFill.PatternType != "solid" {
+		rgb = f.getColorRGB(fill.PatternFill.BgColor)
+	}
+	if clr, ok := parseRenderColor(rgb); ok {
+		return clr
+	}
+	return nil
+}
+
+// parseRenderColor provides a function to parse the color in the RRGGBB or
+// #RRGGBB format.
+func parseRenderColor(rgb string) (color.RGBA, bool) {
+	val, err := strconv.ParseUint(strings.TrimPrefix(rgb, "#"), 16, 32)
+	if err != nil || len(strings.TrimPrefix(rgb, "#")) != 6 {
+		return color.RGBA{A: 0xFF}, false
+	}
+	return color.RGBA{R: uint8(val >> 16), G: uint8(val >> 8), B: uint8(val), A: 0xFF}, true
+}
+
+// getRenderEmptyCells provides a function to get the rectangles of the empty
+// single cells indexed by the column and row number of the cells.
+func getRenderEmptyCells(cells []renderCell) map[[2]int]image.Rectangle {
+	empty := make(map[[2]int]image.Rectangle)
+	for _, cell := range cells {
+		if !cell.merged && cell.value == "" {
+			empty[[2]int{cell.col, cell.row}] = cell.rect
+		}
+	}
+	return empty
+}
+
+// getRenderClip provides a function to get the clip rectangle of the text in
+// the cell by given cell, rectangles of the empty cells and coordinates of the
+// range. The text which isn't wrapped in a single cell can overflow into the
+// adjacent empty cells in the same row.
+func getRenderClip(cell renderCell, empty map[[2]int]image.Rectangle, coordinates []int) image.Rectangle {
+	clip := cell.rect
+	if cell.merged || cell.style.alignment.WrapText || cell.cellType != CellTypeString {
+		return clip
+	}
+	horizontal := cell.style.alignment.Horizontal
+	if horizontal != "right" {
+		for col := cell.col + 1; col <= coordinates[2]; col++ {
+			rect, ok := empty[[2]int{col, cell.row}]
+			if !ok {
+				break
+			}
+			clip.Max.X = rect.Max.X
+		}
+	}
+	if horizontal == "right" || horizontal == "center" {
+		for col := cell.col - 1; col >= coordinates[0]; col-- {
+			rect, ok := empty[[2]int{col, cell.row}]
+			if !ok {
+				break
+			}
+			clip.Min.X = rect.Min.X
+		}
+	}
+	return clip
+}
+
+// pixels provides a function to get the number of pixels in the scale of the
+// renderer by given number of pixels at 100% zoom, at least 1 pixel.
+func (r *renderer) pixels(n int) int {
+	return int(math.Max(math.Round(float64(n)*r.scale), 1))
+}
+
+// drawGridLines provides a function to draw the gridlines on the edges of the
+// cell by given rectangle of the cell.
+func (r *renderer) drawGridLines(rect image.Rectangle) {
+	grid := image.NewUniform(renderGridColor)
+	draw.Draw(r.img, image.Rect(rect.Max.X-1, rect.Min.Y, rect.Max.X, rect.Max.Y), grid, image.Point{}, draw.Src)
+	draw.Draw(r.img, image.Rect(rect.Min.X, rect.Max.Y-1, rect.Max.X, rect.Max.Y), grid, image.Point{}, draw.Src)
+	if rect.Min.X == 0 {
+		draw.Draw(r.img, image.Rect(0, rect.Min.Y, 1, rect.Max.Y), grid, image.Point{}, draw.Src)
+	}
+	if rect.Min.Y == 0 {
+		draw.Draw(r.img, image.Rect(rect.Min.X, 0, rect.Max.X, 1), grid, image.Point{}, draw.Src)
+	}
+}
+
+// drawBorders provides a function to draw the borders of the cell.
+func (r *renderer) drawBorders(cell renderCell) {
+	rect := cell.rect
+	if rect.Empty() {
+		return
+	}
+	for _, border := range cell.style.borders {
+		clr, ok := parseRenderColor(border.Color)
+		if !ok {
+			clr = color.RGBA{A: 0xFF}
+		}
+		width := r.pixels(1)
+		if w, ok := renderBorderWidths[border.Style]; ok {
+			width = r.pixels(w)
+		}
+		var dashes []int
+		for _, dash := range renderBorderDashes[border.Style] {
+			dashes = append(dashes, r.pixels(dash))
+		}
+		double := border.Style == 6
+		switch border.Type {
+		case "left":
+			r.drawBorderLine(image.Rect(rect.Min.X, rect.Min.Y, rect.Min.X+width, rect.Max.Y), clr, dashes, double)
+		case "right":
+			r.drawBorderLine(image.Rect(rect.Max.X-width, rect.Min.Y, rect.Max.X, rect.Max.Y), clr, dashes, double)
+		case "top":
+			r.drawBorderLine(image.Rect(rect.Min.X, rect.Min.Y, rect.Max.X, rect.Min.Y+width), clr, dashes, double)
+		case "bottom":
+			r.drawBorderLine(image.Rect(rect.Min.X, rect.Max.Y-width, rect.Max.X, rect.Max.Y), clr, dashes, double)
+		case "diagonalUp":
+			r.drawDiagonal(rect.Min.X, rect.Max.Y-1, rect.Max.X-1, rect.Min.Y, width, clr)
+		case "diagonalDown":
+			r.drawDiagonal(rect.Min.X, rect.Min.Y, rect.Max.X-1, rect.Max.Y-1, width, clr)
+		}
+	}
+}
+
+// drawBorderLine provides a function to draw a horizontal or vertical border
+// line by given rectangle of the line, color, dash pattern and if the line is
+// a double line.
+func (r *renderer) drawBorderLine(line image.Rectangle, clr color.Color, dashes []int, double bool) {
+	src := image.NewUniform(clr)
+	vertical := line.Dx() < line.Dy()
+	if double {
+		var first, second image.Rectangle
+		if vertical {
+			first = image.Rect(line.Min.X, line.Min.Y, line.Min.X+line.Dx()/3, line.Max.Y)
+			second = image.Rect(line.Max.X-line.Dx()/3, line.Min.Y, line.Max.X, line.Max.Y)
+		} else {
+			first = image.Rect(line.Min.X, line.Min.Y, line.Max.X, line.Min.Y+line.Dy()/3)
+			second = image.Rect(line.Min.X, line.Max.Y-line.Dy()/3, line.Max.X, line.Max.Y)
+		}
+		draw.Draw(r.img, first, src, image.Point{}, draw.Src)
+		draw.Draw(r.img, second, src, image.Point{}, draw.Src)
+		return
+	}
+	if len(dashes) == 0 {
+		draw.Draw(r.img, line, src, image.Point{}, draw.Src)
+		return
+	}
+	start, end := line.Min.X, line.Max.X
+	if vertical {
+		start, end = line.Min.Y, line.Max.Y
+	}
+	for pos, idx := start, 0; pos < end; idx++ {
+		length := dashes[idx%len(dashes)]
+		if idx%2 == 0 {
+			segment := image.Rect(pos, line.Min.Y, int(math.Min(float64(pos+length), float64(end))), line.Max.Y)
+			if vertical {
+				segment = image.Rect(line.Min.X, pos, line.Max.X, int(math.Min(float64(pos+length), float64(end))))
+			}
+			draw.Draw(r.img, segment, src, image.Point{}, draw.Src)
+		}
+		pos += length
+	}
+}
+
+// drawDiagonal provides a function to draw the diagonal border line between
+// the two points by given width and color of the line.
+func (r *renderer) drawDiagonal(x0, y0, x1, y1, width int, clr color.Color) {
+	steps := int(math.Max(math.Abs(float64(x1-x0)), math.Abs(float64(y1-y0))))
+	src := image.NewUniform(clr)
+	for i := 0; i <= steps; i++ {
+		t := 0.0
+		if steps > 0 {
+			t = float64(i) / float64(steps)
+		}
+		x := int(math.Round(float64(x0) + t*float64(x1-x0)))
+		y := int(math.Round(float64(y0) + t*float64(y1-y0)))
+		draw.Draw(r.img, image.Rect(x, y, x+width, y+1), src, image.Point{}, draw.Src)
+	}
+}
+
+// getFace provides a function to get the font face by given font settings
+// of the cell, the default font size is 11 points.
+func (r *renderer) getFace(fnt *Font) (font.Face, error) {
+	size, idx := fnt.Size, 0
+	if size <= 0 {
+		size = 11
+	}
+	if fnt.Bold {
+		idx |= 1
+	}
+	if fnt.Italic {
+		idx |= 2
+	}
+	key := fmt.Sprintf("%d-%g", idx, size)
+	if face, ok := r.faces[key]; ok {
+		return face, nil
+	}
+	face, err := opentype.NewFace(renderFonts[idx], &opentype.FaceOptions{
+		Size: size, DPI: 96 * r.scale, Hinting: font.HintingFull,
+	})
+	if err != nil {
+		return face, err
+	}
+	r.faces[key] = face
+	return face, err
+}
+
+// wrapText provides a function to break the text into lines which fit the
+// given width in pixels by the font face.
+func wrapText(face font.Face, text string, width int) []string {
+	var lines []string
+	for _, paragraph := range strings.Split(text, "\n") {
+		line := ""
+		for _, word := range strings.Fields(paragraph) {
+			candidate := word
+			if line != "" {
+				candidate = line + " " + word
+			}
+			if line != "" && font.MeasureString(face, candidate).Ceil() > width {
+				lines = append(lines, line)
+				candidate = word
+			}
+			line = candidate
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// drawText provides a function to draw the value of the cell by given clip
+// rectangle of the text.
+func (r *renderer) drawText(cell renderCell, clip image.Rectangle) error {
+	if cell.value == "" || cell.rect.Empty() {
+		return nil
+	}
+	face, err := r.getFace(cell.style.font)
+	if err != nil {
+		return err
+	}
+	clr, ok := parseRenderColor(cell.style.font.Color)
+	if !ok {
+		clr = color.RGBA{A: 0xFF}
+	}
+	alignment, padding := cell.style.alignment, r.pixels(2)
+	indent := alignment.Indent * 3 * font.MeasureString(face, "0").Ceil()
+	lines := []string{strings.ReplaceAll(cell.value, "\n", " ")}
+	if alignment.WrapText {
+		lines = wrapText(face, cell.value, cell.rect.Dx()-2*padding-indent)
+	}
+	metrics := face.Metrics()
+	lineHeight, ascent := metrics.Height.Ceil(), metrics.Ascent.Ceil()
+	top := cell.rect.Max.Y - padding - lineHeight*len(lines)
+	switch alignment.Vertical {
+	case "top":
+		top = cell.rect.Min.Y + padding
+	case "center", "justify", "distributed":
+		top = cell.rect.Min.Y + (cell.rect.Dy()-lineHeight*len(lines))/2
+	}
+	horizontal := alignment.Horizontal
+	if horizontal == "" || horizontal == "general" {
+		switch cell.cellType {
+		case CellTypeBool, CellTypeError:
+			horizontal = "center"
+		case CellTypeUnset, CellTypeNumber, CellTypeDate:
+			horizontal = "right"
+		}
+	}
+	dst := r.img.SubImage(clip.Intersect(r.img.Bounds())).(*image.RGBA)
+	src := image.NewUniform(clr)
+	for idx, line := range lines {
+		width := font.MeasureString(face, line).Ceil()
+		x := cell.rect.Min.X + padding + indent
+		switch horizontal {
+		case "right":
+			x = cell.rect.Max.X - padding - indent - width
+		case "center", "centerContinuous", "distributed":
+			x = cell.rect.Min.X + (cell.rect.Dx()-width)/2
+		}
+		baseline := top + idx*lineHeight + ascent
+		drawer := &font.Drawer{Dst: dst, Src: src, Face: face, Dot: fixed.P(x, baseline)}
+		drawer.DrawString(line)
+		thickness := r.pixels(1)
+		switch cell.style.font.Underline {
+		case "single", "singleAccounting":
+			draw.Draw(dst, image.Rect(x, baseline+thickness, x+width, baseline+2*thickness), src, image.Point{}, draw.Over)
+		case "double", "doubleAccounting":
+			draw.Draw(dst, image.Rect(x, baseline+thickness, x+width, baseline+2*thickness), src, image.Point{}, draw.Over)
+			draw.Draw(dst, image.Rect(x, baseline+3*thickness, x+width, baseline+4*thickness), src, image.Point{}, draw.Over)
+		}
+		if cell.style.font.Strike {
+			y := baseline - ascent*3/10
+			draw.Draw(dst, image.Rect(x, y, x+width, y+thickness), src, image.Point{}, draw.Over)
+		}
+	}
+	return err
+}
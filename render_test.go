@@ -0,0 +1,120 @@
+package excelize
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderRangeToImage(t *testing.T) {
+	f := NewFile()
+	for cell, value := range map[string]interface{}{
+		"A1": "Quarterly Report", "A2": "Region", "B2": "Sales", "C2": "Growth",
+		"A3": "North", "B3": 1250.5, "C3": 0.12, "A4": "South", "B4": 980, "C4": true,
+		"A5": "A long text which should be wrapped in the cell", "B6": "#N/A",
+	} {
+		assert.NoError(t, f.SetCellValue("Sheet1", cell, value))
+	}
+	assert.NoError(t, f.MergeCell("Sheet1", "A1", "C1"))
+	assert.NoError(t, f.SetColWidth("Sheet1", "A", "A", 20))
+	assert.NoError(t, f.SetRowHeight("Sheet1", 5, 45))
+	titleStyle, err := f.NewStyle(&Style{
+		Font:      &Font{Bold: true, Italic: true, Size: 14, Color: "#FFFFFF", Underline: "double"},
+		Fill:      Fill{Type: "pattern", Pattern: 1, Color: []string{"#4472C4"}},
+		Alignment: &Alignment{Horizontal: "center", Vertical: "center"},
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A1", "A1", titleStyle))
+	var borders []Border
+	for idx, typ := range []string{"left", "right", "top", "bottom", "diagonalUp", "diagonalDown"} {
+		borders = append(borders, Border{Type: typ, Color: "#FF0000", Style: idx + 1})
+	}
+	headerStyle, err := f.NewStyle(&Style{
+		Border:    borders,
+		Font:      &Font{Underline: "single", Strike: true},
+		Fill:      Fill{Type: "gradient", Color: []string{"#FFFFFF", "#E0EBF5"}, Shading: 1},
+		Alignment: &Alignment{Horizontal: "right", Vertical: "top", Indent: 1},
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A2", "C2", headerStyle))
+	for idx := 7; idx < 14; idx++ {
+		style, err := f.NewStyle(&Style{Border: []Border{
+			{Type: "left", Style: idx}, {Type: "top", Style: idx}, {Type: "right", Style: idx}, {Type: "bottom", Style: idx},
+		}})
+		assert.NoError(t, err)
+		cell, err := CoordinatesToCellName(idx-6, 7)
+		assert.NoError(t, err)
+		assert.NoError(t, f.SetCellStyle("Sheet1", cell, cell, style))
+	}
+	numberStyle, err := f.NewStyle(&Style{NumFmt: 10, Fill: Fill{Type: "pattern", Pattern: 2, Color: []string{"#FFFF00"}}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "C3", "C3", numberStyle))
+	wrapStyle, err := f.NewStyle(&Style{Alignment: &Alignment{WrapText: true}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A5", "A5", wrapStyle))
+
+	data, err := f.RenderRangeToImage("Sheet1", "A1:C7", RenderOptions{ShowGridLines: true})
+	assert.NoError(t, err)
+	img, err := png.Decode(bytes.NewReader(data))
+	assert.NoError(t, err)
+	// The width of the columns are 146, 70 and 70 pixels, and the height of
+	// the rows are 20 pixels except the row 5 with 60 pixels.
+	assert.Equal(t, image.Rect(0, 0, 286, 180), img.Bounds())
+	assert.Equal(t, color.RGBA{R: 0x44, G: 0x72, B: 0xC4, A: 0xFF}, color.RGBAModel.Convert(img.At(2, 2)))
+	assert.Equal(t, color.RGBA{R: 0xD4, G: 0xD4, B: 0xD4, A: 0xFF}, color.RGBAModel.Convert(img.At(144, 79)))
+	assert.NoError(t, os.WriteFile(filepath.Join("test", "TestRenderRangeToImage.png"), data, 0o644))
+
+	// Test render range with scale, hidden row and column in reverse order
+	assert.NoError(t, f.SetColVisible("Sheet1", "B", false))
+	assert.NoError(t, f.SetRowVisible("Sheet1", 2, false))
+	data, err = f.RenderRangeToImage("Sheet1", "$C$4:$A$1", RenderOptions{Scale: 2})
+	assert.NoError(t, err)
+	img, err = png.Decode(bytes.NewReader(data))
+	assert.NoError(t, err)
+	assert.Equal(t, image.Rect(0, 0, 432, 120), img.Bounds())
+	assert.Equal(t, color.RGBA{R: 0xFF, G: 0xFF, B: 0xFF, A: 0xFF}, color.RGBAModel.Convert(img.At(431, 119)))
+
+	// Test render single cell and the part of merged cell
+	for _, rangeRef := range []string{"B6", "B1:C3"} {
+		_, err = f.RenderRangeToImage("Sheet1", rangeRef, RenderOptions{})
+		assert.NoError(t, err)
+	}
+	data, err = f.RenderRangeToImage("Sheet1", "B2", RenderOptions{})
+	assert.NoError(t, err)
+	img, err = png.Decode(bytes.NewReader(data))
+	assert.NoError(t, err)
+	assert.Equal(t, image.Rect(0, 0, 1, 1), img.Bounds())
+
+	// Test render range with invalid parameters
+	_, err = f.RenderRangeToImage("Sheet1", "A", RenderOptions{})
+	assert.EqualError(t, err, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
+	_, err = f.RenderRangeToImage("Sheet1", "A1:B", RenderOptions{})
+	assert.EqualError(t, err, newCellNameToCoordinatesError("B", newInvalidCellNameError("B")).Error())
+	_, err = f.RenderRangeToImage("Sheet1", "A1:B2", RenderOptions{Scale: -1})
+	assert.EqualError(t, err, ErrParameterInvalid.Error())
+	_, err = f.RenderRangeToImage("Sheet1", "A1:XFD1048576", RenderOptions{})
+	assert.EqualError(t, err, ErrRenderCells.Error())
+	_, err = f.RenderRangeToImage("Sheet1", "A1:AP25000", RenderOptions{})
+	assert.EqualError(t, err, ErrRenderCells.Error())
+	_, err = f.RenderRangeToImage("Sheet1", "A1:Z1000", RenderOptions{Scale: 10})
+	assert.EqualError(t, err, ErrRenderImageSize.Error())
+	_, err = f.RenderRangeToImage("SheetN", "A1:B2", RenderOptions{})
+	assert.EqualError(t, err, "sheet SheetN is not exist")
+	assert.NoError(t, f.Close())
+}
+
+func TestParseRenderColor(t *testing.T) {
+	clr, ok := parseRenderColor("#4472C4")
+	assert.True(t, ok)
+	assert.Equal(t, color.RGBA{R: 0x44, G: 0x72, B: 0xC4, A: 0xFF}, clr)
+	for _, rgb := range []string{"", "#FFF", "GGGGGG", "FF4472C4"} {
+		_, ok = parseRenderColor(rgb)
+		assert.False(t, ok, rgb)
+	}
+}
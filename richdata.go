@@ -0,0 +1,237 @@
+// Copyright 2016 - 2022 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to and
+// read from XLAM / XLSM / XLSX / XLTM / XLTX files. Supports reading and
+// writing spreadsheet documents generated by Microsoft Excel™ 2007 and later.
+// Supports complex components by high compatibility, and provided streaming
+// API for generating or reading data from a worksheet with huge amounts of
+// data. This library needs Go version 1.15 or later.
+
+package excelize
+
+import (
+	"bytes"
+	"io"
+)
+
+// metadataReader provides a function to get the pointer to the structure
+// after deserialization of xl/metadata.xml.
+func (f *File) metadataReader() (*xlsxMetadata, error) {
+	metadata := new(xlsxMetadata)
+	path := f.getWorkbookRelsTarget(SourceRelationshipSheetMetadata)
+	if path == "" {
+		return metadata, nil
+	}
+	if err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(f.readXML(path)))).
+		Decode(metadata); err != nil && err != io.EOF {
+		return metadata, err
+	}
+	return metadata, nil
+}
+
+// richValueReader provides a function to get the pointer to the structure
+// after deserialization of xl/richData/rdrichvalue.xml.
+func (f *File) richValueReader() (*xlsxRichValueData, error) {
+	richValue := new(xlsxRichValueData)
+	path := f.getWorkbookRelsTarget(SourceRelationshipRichValue)
+	if path == "" {
+		return richValue, nil
+	}
+	if err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(f.readXML(path)))).
+		Decode(richValue); err != nil && err != io.EOF {
+		return richValue, err
+	}
+	return richValue, nil
+}
+
+// richValueStructuresReader provides a function to get the pointer to the
+// structure after deserialization of xl/richData/rdrichvaluestructure.xml.
+func (f *File) richValueStructuresReader() (*xlsxRichValueStructures, error) {
+	structures := new(xlsxRichValueStructures)
+	path := f.getWorkbookRelsTarget(SourceRelationshipRichValueStructure)
+	if path == "" {
+		return structures, nil
+	}
+	if err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(f.readXML(path)))).
+		Decode(structures); err != nil && err != io.EOF {
+		return structures, err
+	}
+	return structures, nil
+}
+
+// getRichValueIndex provides a function to get the index of the rich value by
+// given 1-based value metadata index of the cell, returns -1 if the value
+// metadata doesn't refer to a rich value.
+func (metadata *xlsxMetadata) getRichValueIndex(vm int) int {
+	if metadata.ValueMetadata == nil || metadata.MetadataTypes == nil ||
+		vm < 1 || vm > len(metadata.ValueMetadata.Bk) {
+		return -1
+	}
+	for _, rc := range metadata.ValueMetadata.Bk[vm-1].Rc {
+		if rc.T < 1 || rc.T > len(metadata.MetadataTypes.MetadataType) {
+			continue
+		}
+		name := metadata.MetadataTypes.MetadataType[rc.T-1].Name
+		for _, future := range metadata.FutureMetadata {
+			if future.Name != name || rc.V < 0 || rc.V >= len(future.Bk) {
+				continue
+			}
+			for _, ext := range future.Bk[rc.V].ExtLst.Ext {
+				if ext.Rvb != nil {
+					return ext.Rvb.I
+				}
+			}
+		}
+	}
+	return -1
+}
+
+// GetCellRichValue provides a function to get the rich value of the cell
+// which contains the rich data type, such as stocks and geography, by given
+// worksheet name and cell reference. It returns nil if the cell doesn't
+// contain the rich data type. The rich value parts and the linkage of the
+// cells will be preserved on saving the spreadsheet. Only the top-level
+// values of the rich value will be returned, the values which refer to
+// another rich value or the supporting property bag are returned as the
+// index. For example, get the display string of the stock in the cell A1 on
+// Sheet1:
+//
+//	richValue, err := f.GetCellRichValue("Sheet1", "A1")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	if richValue != nil {
+//	    for _, field := range richValue.Fields {
+//	        if field.Name == "_DisplayString" {
+//	            fmt.Println(field.Value)
+//	        }
+//	    }
+//	}
+func (f *File) GetCellRichValue(sheet, cell string) (*RichValue, error) {
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return nil, err
+	}
+	cellData, _, _, err := f.prepareCell(ws, cell)
+	if err != nil || cellData.Vm == nil {
+		return nil, err
+	}
+	metadata, err := f.metadataReader()
+	if err != nil {
+		return nil, err
+	}
+	idx := metadata.getRichValueIndex(int(*cellData.Vm))
+	if idx == -1 {
+		return nil, err
+	}
+	richValues, err := f.richValueReader()
+	if err != nil || idx >= len(richValues.Rv) {
+		return nil, err
+	}
+	structures, err := f.richValueStructuresReader()
+	if err != nil {
+		return nil, err
+	}
+	rv, richValue := richValues.Rv[idx], &RichValue{}
+	var keys []xlsxRichValueStructKey
+	if rv.S >= 0 && rv.S < len(structures.S) {
+		richValue.Type, keys = structures.S[rv.S].T, structures.S[rv.S].K
+	}
+	for i, v := range rv.V {
+		field := RichValueField{Type: v.T, Value: v.Val}
+		if i < len(keys) {
+			field.Name = keys[i].N
+			if field.Type == "" {
+				field.Type = keys[i].T
+			}
+		}
+		richValue.Fields = append(richValue.Fields, field)
+	}
+	return richValue, err
+}
+
+// GetCellMetadata provides a function to get the cell metadata and value
+// metadata indices of the cell by given worksheet name and cell reference.
+// The dynamic array formulas, such as FILTER and SORT, refer to the cell
+// metadata, and the rich data types refer to the value metadata. For example,
+// get the metadata indices of the cell A1 on Sheet1:
+//
+//	metadata, err := f.GetCellMetadata("Sheet1", "A1")
+func (f *File) GetCellMetadata(sheet, cell string) (CellMetadata, error) {
+	var metadata CellMetadata
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return metadata, err
+	}
+	cellData, _, _, err := f.prepareCell(ws, cell)
+	if err != nil {
+		return metadata, err
+	}
+	if cellData.Cm != nil {
+		metadata.CellMetadata = int(*cellData.Cm)
+	}
+	if cellData.Vm != nil {
+		metadata.ValueMetadata = int(*cellData.Vm)
+	}
+	return metadata, err
+}
+
+// SetCellMetadata provides a low-level function to set the cell metadata and
+// value metadata indices of the cell by given worksheet name, cell reference
+// and the 1-based indices of the metadata blocks in the metadata part of the
+// workbook, the zero index removes the metadata of the cell. The metadata
+// part and the metadata indices of the cells will be preserved on saving the
+// spreadsheet. For example, mark the array formula in the cell A1 on Sheet1
+// as a spilled dynamic array formula by the first cell metadata block, which
+// specifies the dynamic array properties in the metadata part:
+//
+//	formulaType, ref := excelize.STCellFormulaTypeArray, "A1:A3"
+//	if err := f.SetCellFormula("Sheet1", "A1", "_xlfn._xlws.SORT(B1:B3)",
+//	    excelize.FormulaOpts{Type: &formulaType, Ref: &ref}); err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	err := f.SetCellMetadata("Sheet1", "A1", excelize.CellMetadata{CellMetadata: 1})
+func (f *File) SetCellMetadata(sheet, cell string, metadata CellMetadata) error {
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	cellData, _, _, err := f.prepareCell(ws, cell)
+	if err != nil {
+		return err
+	}
+	parts, err := f.metadataReader()
+	if err != nil {
+		return err
+	}
+	for _, idx := range []struct {
+		value  int
+		blocks *xlsxMetadataBlocks
+	}{
+		{value: metadata.CellMetadata, blocks: parts.CellMetadata},
+		{value: metadata.ValueMetadata, blocks: parts.ValueMetadata},
+	} {
+		if idx.value == 0 {
+			continue
+		}
+		if idx.value < 0 || idx.blocks == nil || idx.value > len(idx.blocks.Bk) {
+			return ErrCellMetadataIndex
+		}
+	}
+	ws.Lock()
+	defer ws.Unlock()
+	cellData.Cm, cellData.Vm = nil, nil
+	if metadata.CellMetadata > 0 {
+		cm := uint(metadata.CellMetadata)
+		cellData.Cm = &cm
+	}
+	if metadata.ValueMetadata > 0 {
+		vm := uint(metadata.ValueMetadata)
+		cellData.Vm = &vm
+	}
+	return err
+}
@@ -0,0 +1,128 @@
+package excelize
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetCellRichValue(t *testing.T) {
+	f := NewFile()
+	f.Pkg.Store("xl/metadata.xml", []byte(`<metadata xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:xlrd="http://schemas.microsoft.com/office/spreadsheetml/2017/richdata"><metadataTypes count="1"><metadataType name="XLRICHVALUE" minSupportedVersion="120000"/></metadataTypes><futureMetadata name="XLRICHVALUE" count="2"><bk><extLst><ext uri="{3e2802c4-a4d2-4d8b-9148-e3be6c30e623}"><xlrd:rvb i="0"/></ext></extLst></bk><bk><extLst><ext uri="{3e2802c4-a4d2-4d8b-9148-e3be6c30e623}"><xlrd:rvb i="5"/></ext></extLst></bk></futureMetadata><valueMetadata count="3"><bk><rc t="1" v="0"/></bk><bk><rc t="1" v="1"/></bk><bk><rc t="2" v="0"/></bk></valueMetadata></metadata>`))
+	f.Pkg.Store("xl/richData/rdrichvalue.xml", []byte(`<rvData xmlns="http://schemas.microsoft.com/office/spreadsheetml/2017/richdata" count="1"><rv s="0"><v>MSFT</v><v>Microsoft Corp</v><v t="s">2</v><v>247.81</v></rv></rvData>`))
+	f.Pkg.Store("xl/richData/rdrichvaluestructure.xml", []byte(`<rvStructures xmlns="http://schemas.microsoft.com/office/spreadsheetml/2017/richdata" count="1"><s t="_linkedentity"><k n="%EntityId" t="s"/><k n="_DisplayString" t="s"/></s></rvStructures>`))
+	f.addRels(f.getWorkbookRelsPath(), SourceRelationshipSheetMetadata, "metadata.xml", "")
+	f.addRels(f.getWorkbookRelsPath(), SourceRelationshipRichValue, "richData/rdrichvalue.xml", "")
+	f.addRels(f.getWorkbookRelsPath(), SourceRelationshipRichValueStructure, "/xl/richData/rdrichvaluestructure.xml", "")
+	assert.NoError(t, f.SetCellValue("Sheet1", "B1", "text"))
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	for idx, vm := range []uint{1, 2, 3, 4} {
+		cell, _ := CoordinatesToCellName(1, idx+1)
+		cellData, _, _, err := f.prepareCell(ws, cell)
+		assert.NoError(t, err)
+		valueMetadata := vm
+		cellData.T, cellData.V, cellData.Vm = "e", "#VALUE!", &valueMetadata
+	}
+	// Test the rich value parts and the linkage of the cells are preserved
+	buf, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+	f, err = OpenReader(buf)
+	assert.NoError(t, err)
+	richValue, err := f.GetCellRichValue("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, &RichValue{Type: "_linkedentity", Fields: []RichValueField{
+		{Name: "%EntityId", Type: "s", Value: "MSFT"},
+		{Name: "_DisplayString", Type: "s", Value: "Microsoft Corp"},
+		{Type: "s", Value: "2"},
+		{Value: "247.81"},
+	}}, richValue)
+	for _, cell := range []string{"A2", "A3", "A4", "B1", "C1"} {
+		richValue, err = f.GetCellRichValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Nil(t, richValue, cell)
+	}
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestGetCellRichValue.xlsx")))
+	// Test get rich value with the structure doesn't exist
+	f.Pkg.Store("xl/richData/rdrichvalue.xml", []byte(`<rvData><rv s="1"><v>MSFT</v></rv></rvData>`))
+	richValue, err = f.GetCellRichValue("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, &RichValue{Fields: []RichValueField{{Value: "MSFT"}}}, richValue)
+	// Test get rich value with unsupported charset rich data parts
+	for _, part := range []string{"xl/richData/rdrichvaluestructure.xml", "xl/richData/rdrichvalue.xml", "xl/metadata.xml"} {
+		f.Pkg.Store(part, MacintoshCyrillicCharset)
+		_, err = f.GetCellRichValue("Sheet1", "A1")
+		assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	}
+	// Test get rich value without rich data parts
+	f = NewFile()
+	ws, err = f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	cellData, _, _, err := f.prepareCell(ws, "A1")
+	assert.NoError(t, err)
+	valueMetadata := uint(1)
+	cellData.Vm = &valueMetadata
+	richValue, err = f.GetCellRichValue("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Nil(t, richValue)
+	// Test get rich value with invalid parameters
+	_, err = f.GetCellRichValue("SheetN", "A1")
+	assert.EqualError(t, err, "sheet SheetN is not exist")
+	_, err = f.GetCellRichValue("Sheet1", "A")
+	assert.EqualError(t, err, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
+}
+
+func TestCellMetadata(t *testing.T) {
+	f := NewFile()
+	f.Pkg.Store("xl/metadata.xml", []byte(`<metadata xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:xda="http://schemas.microsoft.com/office/spreadsheetml/2017/dynamicarray"><metadataTypes count="1"><metadataType name="XLDAPR" minSupportedVersion="120000" copy="1" pasteAll="1" pasteValues="1" merge="1" splitFirst="1" rowColShift="1" clearFormats="1" clearComments="1" assign="1" coerce="1" cellMeta="1"/></metadataTypes><futureMetadata name="XLDAPR" count="1"><bk><extLst><ext uri="{bdbb8cdc-fa1e-496e-a857-3c3f30c029c3}"><xda:dynamicArrayProperties fDynamic="1" fCollapsed="0"/></ext></extLst></bk></futureMetadata><cellMetadata count="1"><bk><rc t="1" v="0"/></bk></cellMetadata><valueMetadata count="1"><bk><rc t="1" v="0"/></bk></valueMetadata></metadata>`))
+	f.addRels(f.getWorkbookRelsPath(), SourceRelationshipSheetMetadata, "metadata.xml", "")
+	for row, value := range []int{3, 1, 2} {
+		assert.NoError(t, f.SetCellValue("Sheet1", fmt.Sprintf("B%d", row+1), value))
+	}
+	formulaType, ref := STCellFormulaTypeArray, "A1:A3"
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A1", "_xlfn._xlws.SORT(B1:B3)", FormulaOpts{Type: &formulaType, Ref: &ref}))
+	assert.NoError(t, f.SetCellMetadata("Sheet1", "A1", CellMetadata{CellMetadata: 1}))
+	assert.NoError(t, f.SetCellMetadata("Sheet1", "C1", CellMetadata{CellMetadata: 1, ValueMetadata: 1}))
+	// Test the metadata part and the metadata indices of the cells are preserved
+	buf, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+	f, err = OpenReader(buf)
+	assert.NoError(t, err)
+	metadata, err := f.GetCellMetadata("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, CellMetadata{CellMetadata: 1}, metadata)
+	assert.Contains(t, string(f.readXML("xl/metadata.xml")), `<xda:dynamicArrayProperties fDynamic="1" fCollapsed="0"/>`)
+	// Test the metadata indices are preserved on updating the worksheet
+	assert.NoError(t, f.SetCellValue("Sheet1", "B1", 4))
+	assert.NoError(t, f.InsertRow("Sheet1", 1))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestCellMetadata.xlsx")))
+	for cell, expected := range map[string]CellMetadata{
+		"A1": {}, "A2": {CellMetadata: 1}, "C2": {CellMetadata: 1, ValueMetadata: 1}, "B2": {},
+	} {
+		metadata, err = f.GetCellMetadata("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, metadata, cell)
+	}
+	// Test remove the metadata of the cell
+	assert.NoError(t, f.SetCellMetadata("Sheet1", "C2", CellMetadata{}))
+	metadata, err = f.GetCellMetadata("Sheet1", "C2")
+	assert.NoError(t, err)
+	assert.Equal(t, CellMetadata{}, metadata)
+	// Test set cell metadata with invalid metadata index
+	for _, metadata := range []CellMetadata{{CellMetadata: 2}, {CellMetadata: -1}, {ValueMetadata: 2}} {
+		assert.Equal(t, ErrCellMetadataIndex, f.SetCellMetadata("Sheet1", "A1", metadata))
+	}
+	assert.Equal(t, ErrCellMetadataIndex, NewFile().SetCellMetadata("Sheet1", "A1", CellMetadata{CellMetadata: 1}))
+	// Test get and set cell metadata with invalid parameters
+	_, err = f.GetCellMetadata("SheetN", "A1")
+	assert.EqualError(t, err, "sheet SheetN is not exist")
+	_, err = f.GetCellMetadata("Sheet1", "A")
+	assert.EqualError(t, err, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
+	assert.EqualError(t, f.SetCellMetadata("SheetN", "A1", CellMetadata{}), "sheet SheetN is not exist")
+	assert.EqualError(t, f.SetCellMetadata("Sheet1", "A", CellMetadata{}), newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
+	// Test set cell metadata with unsupported charset metadata part
+	f.Pkg.Store("xl/metadata.xml", MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetCellMetadata("Sheet1", "A1", CellMetadata{}), "XML syntax error on line 1: invalid UTF-8")
+}
@@ -12,7 +12,9 @@
 package excelize
 
 import (
+	"bufio"
 	"bytes"
+	"encoding/csv"
 	"encoding/xml"
 	"fmt"
 	"io"
@@ -22,6 +24,9 @@ import (
 	"math/big"
 	"os"
 	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
 
 	"github.com/mohae/deepcopy"
 )
@@ -97,6 +102,260 @@ func (f *File) GetRowsX(sheet string, opts ...Options) ([][]*ColumnX, error) {
 	return results[:max], rows.Close()
 }
 
+// CSVOptions directly maps the settings of exporting the worksheet as CSV.
+// The Delimiter is the field delimiter, the default delimiter is the comma,
+// use '\t' for TSV. If the RawCellValue is true, the cell values will be
+// exported without applying the number format. If the AlwaysQuote is true,
+// all fields will be quoted, otherwise only the fields which contain the
+// delimiter, quote, line break or leading space will be quoted. If the
+// UseCRLF is true, each line will be terminated with \r\n instead of \n.
+type CSVOptions struct {
+	Delimiter    rune
+	RawCellValue bool
+	AlwaysQuote  bool
+	UseCRLF      bool
+}
+
+// ExportCSV provides a function to export the worksheet as CSV to the writer
+// by given worksheet name and CSV options. The rows are read by the streaming
+// reader and written one by one, so that the whole worksheet will not be kept
+// in memory. As the same as GetRows, the continually blank cells in the tail
+// of each row and the blank rows in the tail of the worksheet will be skipped,
+// so the number of fields of each line may be inconsistent. For example,
+// export the worksheet named 'Sheet1' as TSV file:
+//
+//	file, err := os.Create("Sheet1.tsv")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	defer file.Close()
+//	if err := f.ExportCSV("Sheet1", file, excelize.CSVOptions{Delimiter: '\t'}); err != nil {
+//	    fmt.Println(err)
+//	}
+func (f *File) ExportCSV(sheet string, w io.Writer, opts CSVOptions) error {
+	delimiter, err := getCSVDelimiter(opts.Delimiter)
+	if err != nil {
+		return err
+	}
+	rows, err := f.Rows(sheet)
+	if err != nil {
+		return err
+	}
+	lineBreak := "\n"
+	if opts.UseCRLF {
+		lineBreak = "\r\n"
+	}
+	bw, emptyRows := bufio.NewWriter(w), 0
+	for rows.Next() {
+		row, err := rows.Columns(Options{RawCellValue: opts.RawCellValue})
+		if err != nil {
+			_ = rows.Close()
+			return err
+		}
+		if len(row) == 0 {
+			emptyRows++
+			continue
+		}
+		for ; emptyRows > 0; emptyRows-- {
+			_, _ = bw.WriteString(lineBreak)
+		}
+		for i, cell := range row {
+			if i > 0 {
+				_, _ = bw.WriteRune(delimiter)
+			}
+			writeCSVField(bw, cell, delimiter, opts.AlwaysQuote)
+		}
+		_, _ = bw.WriteString(lineBreak)
+	}
+	if err = rows.Close(); err != nil {
+		return err
+	}
+	return bw.Flush()
+}
+
+// getCSVDelimiter provides a function to get the field delimiter of CSV, the
+// default delimiter is the comma.
+func getCSVDelimiter(delimiter rune) (rune, error) {
+	if delimiter == 0 {
+		return ',', nil
+	}
+	if delimiter == '"' || delimiter == '\r' || delimiter == '\n' || !utf8.ValidRune(delimiter) || delimiter == utf8.RuneError {
+		return delimiter, ErrParameterInvalid
+	}
+	return delimiter, nil
+}
+
+// CSVImportOptions directly maps the settings of importing CSV to the
+// worksheet. The Delimiter is the field delimiter, the default delimiter is
+// the comma. The Cell is the top-left cell of the imported data, the default
+// cell is A1. The fields will be imported as strings unless the type
+// inference is enabled: if the InferNumbers is true, the numeric fields will
+// be imported as numbers, except the fields with leading zeros or more than
+// 15 significant digits, such as the zip codes and the identity numbers; if
+// the InferBooleans is true, the fields "TRUE" and "FALSE" will be imported
+// as booleans (case-insensitive); if the InferDates is true, the fields
+// matching one of the DateLayouts will be imported as dates. The default date
+// layouts are "2006-01-02", "2006-01-02 15:04:05" and RFC 3339. If the
+// StreamWriter is true, the data will be written by the stream writer for
+// large inputs, which replaces all the existing cells of the worksheet.
+type CSVImportOptions struct {
+	Delimiter     rune
+	Cell          string
+	InferNumbers  bool
+	InferBooleans bool
+	InferDates    bool
+	DateLayouts   []string
+	StreamWriter  bool
+}
+
+// ImportCSV provides a function to import the CSV from the reader to the
+// worksheet by given worksheet name and import options. The empty fields will
+// be skipped, so the existing cells and styles of the worksheet will be kept
+// unless the StreamWriter is used. The dates without time will use the number
+// format "mm-dd-yy" and the dates with time will use the number format
+// "m/d/yy h:mm" if the cell has no style. For example, import the CSV file
+// into the worksheet named 'Sheet1' from the cell B2, and infer the numbers
+// and the dates:
+//
+//	file, err := os.Open("data.csv")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	defer file.Close()
+//	err = f.ImportCSV("Sheet1", file, excelize.CSVImportOptions{
+//	    Cell:         "B2",
+//	    InferNumbers: true,
+//	    InferDates:   true,
+//	})
+func (f *File) ImportCSV(sheet string, r io.Reader, opts CSVImportOptions) error {
+	delimiter, err := getCSVDelimiter(opts.Delimiter)
+	if err != nil {
+		return err
+	}
+	if opts.Cell == "" {
+		opts.Cell = "A1"
+	}
+	col, row, err := CellNameToCoordinates(opts.Cell)
+	if err != nil {
+		return err
+	}
+	if len(opts.DateLayouts) == 0 {
+		opts.DateLayouts = []string{"2006-01-02", "2006-01-02 15:04:05", time.RFC3339}
+	}
+	var sw *StreamWriter
+	if opts.StreamWriter {
+		if sw, err = f.NewStreamWriter(sheet); err != nil {
+			return err
+		}
+	} else if _, err = f.workSheetReader(sheet); err != nil {
+		return err
+	}
+	reader := csv.NewReader(r)
+	reader.Comma, reader.FieldsPerRecord, reader.ReuseRecord = delimiter, -1, true
+	dateStyles := map[int]int{}
+	for ; ; row++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if row > TotalRows {
+			return ErrMaxRows
+		}
+		values := make([]interface{}, len(record))
+		for i, field := range record {
+			if field == "" {
+				continue
+			}
+			value, numFmt := inferCSVValue(field, &opts)
+			if sw != nil {
+				if values[i] = value; numFmt != 0 {
+					if _, ok := dateStyles[numFmt]; !ok {
+						if dateStyles[numFmt], err = f.NewStyle(&Style{NumFmt: numFmt}); err != nil {
+							return err
+						}
+					}
+					values[i] = Cell{StyleID: dateStyles[numFmt], Value: value}
+				}
+				continue
+			}
+			cell, err := CoordinatesToCellName(col+i, row)
+			if err != nil {
+				return err
+			}
+			if numFmt != 0 {
+				if err = f.setDefaultTimeStyle(sheet, cell, numFmt); err != nil {
+					return err
+				}
+			}
+			if err = f.SetCellValue(sheet, cell, value); err != nil {
+				return err
+			}
+		}
+		if sw != nil {
+			cell, _ := CoordinatesToCellName(col, row)
+			if err = sw.SetRow(cell, values); err != nil {
+				return err
+			}
+		}
+	}
+	if sw != nil {
+		return sw.Flush()
+	}
+	return nil
+}
+
+// inferCSVValue provides a function to convert the field of CSV to the cell
+// value by the type inference settings, and returns the number format ID of
+// the date value: 14 for the date without time, 22 for the date with time and
+// 0 for the other values.
+func inferCSVValue(field string, opts *CSVImportOptions) (interface{}, int) {
+	if opts.InferNumbers {
+		if isNum, precision := isNumeric(field); isNum && precision <= 15 {
+			digits := strings.TrimPrefix(field, "-")
+			if len(digits) < 2 || digits[0] != '0' || digits[1] == '.' {
+				if num, err := strconv.ParseFloat(field, 64); err == nil {
+					return num, 0
+				}
+			}
+		}
+	}
+	if opts.InferBooleans {
+		if strings.EqualFold(field, "TRUE") || strings.EqualFold(field, "FALSE") {
+			return strings.EqualFold(field, "TRUE"), 0
+		}
+	}
+	if opts.InferDates {
+		for _, layout := range opts.DateLayouts {
+			if t, err := time.Parse(layout, field); err == nil {
+				if t.Equal(time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())) {
+					return t, 14
+				}
+				return t, 22
+			}
+		}
+	}
+	return field, 0
+}
+
+// writeCSVField provides a function to write the field of CSV, the field will
+// be quoted if needed, and the quotes in the field will be escaped by double
+// quotes.
+func writeCSVField(bw *bufio.Writer, field string, delimiter rune, alwaysQuote bool) {
+	if !alwaysQuote && (field == "" || !strings.ContainsRune(field, delimiter) &&
+		!strings.ContainsAny(field, "\"\r\n") && field[0] != ' ' && field[0] != '\t') {
+		_, _ = bw.WriteString(field)
+		return
+	}
+	_ = bw.WriteByte('"')
+	_, _ = bw.WriteString(strings.ReplaceAll(field, `"`, `""`))
+	_ = bw.WriteByte('"')
+}
+
 // Rows defines an iterator to a sheet.
 type Rows struct {
 	err                     error
@@ -171,7 +430,7 @@ func (rows *Rows) Columns(opts ...Options) ([]string, error) {
 	}
 	var rowIterator rowXMLIterator
 	var token xml.Token
-	rows.rawCellValue, rows.sst = parseOptions(opts...).RawCellValue, rows.f.sharedStringsReader()
+	rows.rawCellValue, rows.sst = rows.f.getOptions(opts...).RawCellValue, rows.f.sharedStringsReader()
 	for {
 		if rows.token != nil {
 			token = rows.token
@@ -218,7 +477,7 @@ func (rows *Rows) ColumnsX(opts ...Options) ([]*ColumnX, error) {
 	}
 	var rowIterator rowXMLIterator
 	var token xml.Token
-	rows.rawCellValue, rows.sst = parseOptions(opts...).RawCellValue, rows.f.sharedStringsReader()
+	rows.rawCellValue, rows.sst = rows.f.getOptions(opts...).RawCellValue, rows.f.sharedStringsReader()
 	for {
 		if rows.token != nil {
 			token = rows.token
@@ -485,6 +744,9 @@ func (f *File) getRowHeight(sheet string, row int) int {
 			return int(convertRowHeightToPixels(v.Ht))
 		}
 	}
+	if ws.SheetFormatPr != nil && ws.SheetFormatPr.CustomHeight && ws.SheetFormatPr.DefaultRowHeight > 0 {
+		return int(convertRowHeightToPixels(ws.SheetFormatPr.DefaultRowHeight))
+	}
 	// Optimization for when the row heights haven't changed.
 	return int(defaultRowHeightPixels)
 }
@@ -557,6 +819,24 @@ func (f *File) sharedStringsReader() *xlsxSST {
 	return f.SharedStrings
 }
 
+// getString provides a function to get the text of the shared string item
+// by given index. The text of each item will be resolved only once and cached
+// in a slice indexed by the item index, since the items of the shared string
+// table are only appended, this avoids the repeated conversion on reading the
+// cells referencing the same item.
+func (sst *xlsxSST) getString(index int) string {
+	if len(sst.values) < len(sst.SI) {
+		values := make([]*string, len(sst.SI))
+		copy(values, sst.values)
+		sst.values = values
+	}
+	if sst.values[index] == nil {
+		value := sst.SI[index].String()
+		sst.values[index] = &value
+	}
+	return *sst.values[index]
+}
+
 // getValueFrom return a value from a column/row cell, this function is
 // intended to be used with for range on rows an argument with the spreadsheet
 // opened file.
@@ -566,11 +846,8 @@ func (c *xlsxC) getValueFrom(f *File, d *xlsxSST, raw bool) (string, error) {
 	switch c.T {
 	case "b":
 		if !raw {
-			if c.V == "1" {
-				return "TRUE", nil
-			}
-			if c.V == "0" {
-				return "FALSE", nil
+			if c.V == "1" || c.V == "0" {
+				return f.formattedBoolValue(c.S, c.V == "1"), nil
 			}
 		}
 		return f.formattedValue(c.S, c.V, raw), nil
@@ -582,7 +859,7 @@ func (c *xlsxC) getValueFrom(f *File, d *xlsxSST, raw bool) (string, error) {
 				return f.formattedValue(c.S, f.getFromStringItem(xlsxSI), raw), nil
 			}
 			if len(d.SI) > xlsxSI {
-				return f.formattedValue(c.S, d.SI[xlsxSI].String(), raw), nil
+				return f.formattedValue(c.S, d.getString(xlsxSI), raw), nil
 			}
 		}
 		return f.formattedValue(c.S, c.V, raw), nil
@@ -698,6 +975,46 @@ func (f *File) GetRowOutlineLevel(sheet string, row int) (uint8, error) {
 	return ws.SheetData.Row[row-1].OutlineLevel, nil
 }
 
+// GetRowOutline provides a function to get the outline level number and the
+// collapsed state of a single row by given worksheet name and Excel row
+// number. The collapsed state of an outline group is stored on the summary
+// row of the group, which is the first row after the group with lower
+// outline level when the summary rows are below the detail (the default), or
+// the last row before the group otherwise. For the row in an outline group,
+// the collapsed reports whether the group is collapsed, and for the row
+// which is not in any group (level 0), the collapsed reports whether the row
+// is the summary row of a collapsed group. Use the GetRowVisible function to
+// get the hidden state of the detail rows. For example, get the outline
+// level and collapsed state of row 2 in Sheet1:
+//
+//	level, collapsed, err := f.GetRowOutline("Sheet1", 2)
+func (f *File) GetRowOutline(sheet string, row int) (uint8, bool, error) {
+	if row < 1 {
+		return 0, false, newInvalidRowNumberError(row)
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return 0, false, err
+	}
+	if row > len(ws.SheetData.Row) {
+		return 0, false, nil
+	}
+	level := ws.SheetData.Row[row-1].OutlineLevel
+	if level == 0 {
+		return level, ws.SheetData.Row[row-1].Collapsed, err
+	}
+	step := 1
+	if ws.SheetPr != nil && ws.SheetPr.OutlinePr != nil && !ws.SheetPr.OutlinePr.SummaryBelow {
+		step = -1
+	}
+	for r := row - 1 + step; r >= 0 && r < len(ws.SheetData.Row); r += step {
+		if ws.SheetData.Row[r].OutlineLevel < level {
+			return level, ws.SheetData.Row[r].Collapsed, err
+		}
+	}
+	return level, false, err
+}
+
 // RemoveRow provides a function to remove single row by given worksheet name
 // and Excel row number. For example, remove row 3 in Sheet1:
 //
@@ -732,8 +1049,10 @@ func (f *File) RemoveRow(sheet string, row int) error {
 }
 
 // InsertRow provides a function to insert a new row after given Excel row
-// number starting from 1. For example, create a new row before row 3 in
-// Sheet1:
+// number starting from 1. Inserting the row inside a table, or right below
+// the last row of a table which has no totals row, adds the row to the
+// table, and the formulas of the calculated columns of the table will be
+// set on the row. For example, create a new row before row 3 in Sheet1:
 //
 //	err := f.InsertRow("Sheet1", 3)
 //
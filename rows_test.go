@@ -3,14 +3,142 @@ package excelize
 import (
 	"bytes"
 	"encoding/xml"
+	"errors"
 	"fmt"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
+type errWriter struct{}
+
+func (errWriter) Write([]byte) (int, error) { return 0, errors.New("write error") }
+
+func TestExportCSV(t *testing.T) {
+	f := NewFile()
+	for cell, value := range map[string]interface{}{
+		"A1": "name", "B1": "value", "A2": "a,b", "B2": 1.5,
+		"A3": `say "hi"`, "A5": " lead", "C5": "x\ty",
+	} {
+		assert.NoError(t, f.SetCellValue("Sheet1", cell, value))
+	}
+	style, err := f.NewStyle(&Style{NumFmt: 2})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "B2", "B2", style))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A10", "A10", style))
+
+	var buf bytes.Buffer
+	assert.NoError(t, f.ExportCSV("Sheet1", &buf, CSVOptions{}))
+	assert.Equal(t, "name,value\n\"a,b\",1.50\n\"say \"\"hi\"\"\"\n\n\" lead\",,x\ty\n", buf.String())
+
+	buf.Reset()
+	assert.NoError(t, f.ExportCSV("Sheet1", &buf, CSVOptions{Delimiter: '\t', RawCellValue: true, UseCRLF: true}))
+	assert.Equal(t, "name\tvalue\r\na,b\t1.5\r\n\"say \"\"hi\"\"\"\r\n\r\n\" lead\"\t\t\"x\ty\"\r\n", buf.String())
+
+	buf.Reset()
+	assert.NoError(t, f.ExportCSV("Sheet1", &buf, CSVOptions{Delimiter: ';', AlwaysQuote: true}))
+	assert.True(t, strings.HasPrefix(buf.String(), "\"name\";\"value\"\n\"a,b\";\"1.50\"\n"))
+	assert.True(t, strings.HasSuffix(buf.String(), "\"\n\n\" lead\";\"\";\"x\ty\"\n"))
+
+	// Test export CSV with invalid parameters
+	for _, delimiter := range []rune{'"', '\r', '\n', '\uFFFD', -1} {
+		assert.EqualError(t, f.ExportCSV("Sheet1", &buf, CSVOptions{Delimiter: delimiter}), ErrParameterInvalid.Error())
+	}
+	assert.EqualError(t, f.ExportCSV("SheetN", &buf, CSVOptions{}), "sheet SheetN is not exist")
+	assert.EqualError(t, f.ExportCSV("Sheet1", errWriter{}, CSVOptions{}), "write error")
+	// Test export CSV with invalid cell value
+	f.Sheet.Delete("xl/worksheets/sheet1.xml")
+	f.Pkg.Store("xl/worksheets/sheet1.xml", []byte(`<worksheet><sheetData><row r="A"><c r="A1"><v>1</v></c></row></sheetData></worksheet>`))
+	f.checked = nil
+	assert.EqualError(t, f.ExportCSV("Sheet1", &buf, CSVOptions{}), `strconv.Atoi: parsing "A": invalid syntax`)
+}
+
+func TestImportCSV(t *testing.T) {
+	const data = "name,value,flag,date\nzip,00123,true,2022-05-01\n\"a,b\",-1.5,FALSE,2022-05-01 10:30:00\nid,1234567890123456,x,\n"
+	f := NewFile()
+	style, err := f.NewStyle(&Style{Font: &Font{Bold: true}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "E4", "E4", style))
+	assert.NoError(t, f.ImportCSV("Sheet1", strings.NewReader(data), CSVImportOptions{
+		Cell: "B2", InferNumbers: true, InferBooleans: true, InferDates: true,
+	}))
+	// Test only the style of the cell with the default date format is created
+	assert.Equal(t, style+2, len(f.Styles.CellXfs.Xf))
+	rows, err := f.GetRows("Sheet1", Options{RawCellValue: true})
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{
+		nil,
+		{"", "name", "value", "flag", "date"},
+		{"", "zip", "00123", "1", "44682"},
+		{"", "a,b", "-1.5", "0", "44682.4375"},
+		{"", "id", "1234567890123456", "x"},
+	}, rows)
+	for cell, typ := range map[string]CellType{"C3": CellTypeString, "C4": CellTypeUnset, "D3": CellTypeBool, "C5": CellTypeString} {
+		cellType, err := f.GetCellType("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, typ, cellType, cell)
+	}
+	for cell, numFmt := range map[string]int{"E3": 14, "E4": 0} {
+		styleID, err := f.GetCellStyle("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, numFmt, *f.Styles.CellXfs.Xf[styleID].NumFmtID, cell)
+	}
+	// Test the style of the cell with date time is kept
+	styleID, err := f.GetCellStyle("Sheet1", "E4")
+	assert.NoError(t, err)
+	assert.Equal(t, style, styleID)
+
+	// Test import CSV without type inference by the stream writer
+	f = NewFile()
+	assert.NoError(t, f.ImportCSV("Sheet1", strings.NewReader(data), CSVImportOptions{StreamWriter: true}))
+	rows, err = f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"zip", "00123", "true", "2022-05-01"}, rows[1])
+	cellType, err := f.GetCellType("Sheet1", "C3")
+	assert.NoError(t, err)
+	assert.Equal(t, CellTypeString, cellType)
+
+	// Test import CSV with type inference and custom date layouts by the stream writer
+	f = NewFile()
+	assert.NoError(t, f.ImportCSV("Sheet1", strings.NewReader("1;05/01/2022;TRUE\n"), CSVImportOptions{
+		Delimiter: ';', StreamWriter: true, InferNumbers: true, InferBooleans: true,
+		InferDates: true, DateLayouts: []string{"01/02/2006"},
+	}))
+	rows, err = f.GetRows("Sheet1", Options{RawCellValue: true})
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"1", "44682", "1"}}, rows)
+	styleID, err = f.GetCellStyle("Sheet1", "B1")
+	assert.NoError(t, err)
+	assert.Equal(t, 14, *f.Styles.CellXfs.Xf[styleID].NumFmtID)
+
+	// Test import CSV with the dates and date times by the stream writer
+	f = NewFile()
+	assert.NoError(t, f.ImportCSV("Sheet1", strings.NewReader("2022-05-01 10:30:00,2022-05-01,2022-05-02 08:00:00\n"), CSVImportOptions{
+		StreamWriter: true, InferDates: true,
+	}))
+	rows, err = f.GetRows("Sheet1", Options{RawCellValue: true})
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"44682.4375", "44682", "44683.333333333336"}}, rows)
+	for cell, numFmt := range map[string]int{"A1": 22, "B1": 14, "C1": 22} {
+		styleID, err := f.GetCellStyle("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, numFmt, *f.Styles.CellXfs.Xf[styleID].NumFmtID, cell)
+	}
+
+	// Test import CSV with invalid parameters
+	assert.EqualError(t, f.ImportCSV("Sheet1", strings.NewReader(data), CSVImportOptions{Delimiter: '"'}), ErrParameterInvalid.Error())
+	assert.EqualError(t, f.ImportCSV("Sheet1", strings.NewReader(data), CSVImportOptions{Cell: "A"}), newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
+	assert.EqualError(t, f.ImportCSV("SheetN", strings.NewReader(data), CSVImportOptions{}), "sheet SheetN is not exist")
+	assert.EqualError(t, f.ImportCSV("SheetN", strings.NewReader(data), CSVImportOptions{StreamWriter: true}), "sheet SheetN is not exist")
+	assert.EqualError(t, f.ImportCSV("Sheet1", strings.NewReader("a\"b"), CSVImportOptions{}), `parse error on line 1, column 2: bare " in non-quoted-field`)
+	assert.EqualError(t, f.ImportCSV("Sheet1", strings.NewReader("a,b"), CSVImportOptions{Cell: "XFD1"}), ErrColumnNumber.Error())
+	assert.EqualError(t, f.ImportCSV("Sheet1", strings.NewReader("a,b"), CSVImportOptions{Cell: "XFD1", StreamWriter: true}), ErrColumnNumber.Error())
+	assert.EqualError(t, f.ImportCSV("Sheet1", strings.NewReader("a\nb"), CSVImportOptions{Cell: "A1048576", StreamWriter: true}), ErrMaxRows.Error())
+}
+
 func TestRows(t *testing.T) {
 	const sheet2 = "Sheet2"
 
@@ -231,6 +359,35 @@ func TestSharedStringsReader(t *testing.T) {
 	assert.EqualValues(t, "", si.String())
 }
 
+func TestSharedStringsGetString(t *testing.T) {
+	sst := &xlsxSST{SI: []xlsxSI{
+		{T: &xlsxT{Val: "a"}},
+		{R: []xlsxR{{T: &xlsxT{Val: "b"}}, {T: &xlsxT{Val: "c"}}}},
+		{T: &xlsxT{Val: "_x0008__x005F_x0008_"}},
+	}}
+	assert.Equal(t, "bc", sst.getString(1))
+	assert.Len(t, sst.values, 3)
+	assert.Nil(t, sst.values[0])
+	assert.Equal(t, "a", sst.getString(0))
+	assert.Equal(t, "\b_x0008_", sst.getString(2))
+	// Test get the string of the shared string item appended after caching
+	sst.SI = append(sst.SI, xlsxSI{T: &xlsxT{Val: "d"}})
+	assert.Equal(t, "d", sst.getString(3))
+	assert.Equal(t, "bc", sst.getString(1))
+
+	f := NewFile()
+	for row := 1; row <= 3; row++ {
+		assert.NoError(t, f.SetSheetRow("Sheet1", fmt.Sprintf("A%d", row), &[]interface{}{"x", "y", fmt.Sprint(row)}))
+	}
+	rows, err := f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"x", "y", "1"}, {"x", "y", "2"}, {"x", "y", "3"}}, rows)
+	assert.NoError(t, f.SetCellValue("Sheet1", "D1", "z"))
+	val, err := f.GetCellValue("Sheet1", "D1")
+	assert.NoError(t, err)
+	assert.Equal(t, "z", val)
+}
+
 func TestRowVisibility(t *testing.T) {
 	f, err := prepareTestBook1()
 	if !assert.NoError(t, err) {
@@ -257,6 +414,43 @@ func TestRowVisibility(t *testing.T) {
 	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestRowVisibility.xlsx")))
 }
 
+func TestGetRowOutline(t *testing.T) {
+	f := NewFile()
+	// Group rows 2-4 with nested group rows 3-4, the summary rows are 5 and 4
+	for row, level := range map[int]uint8{2: 1, 3: 2, 4: 2, 6: 1} {
+		assert.NoError(t, f.SetRowOutlineLevel("Sheet1", row, level))
+	}
+	assert.NoError(t, f.SetRowHeight("Sheet1", 8, 20))
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	ws.SheetData.Row[4].Collapsed = true
+	check := func(expected map[int][]interface{}) {
+		for row, state := range expected {
+			level, collapsed, err := f.GetRowOutline("Sheet1", row)
+			assert.NoError(t, err)
+			assert.Equal(t, state[0], level, row)
+			assert.Equal(t, state[1], collapsed, row)
+		}
+	}
+	check(map[int][]interface{}{
+		1: {uint8(0), false}, 2: {uint8(1), true}, 3: {uint8(2), true}, 4: {uint8(2), true},
+		5: {uint8(0), true}, 6: {uint8(1), false}, 7: {uint8(0), false}, 100: {uint8(0), false},
+	})
+	// Test get row outline with summary rows above detail
+	assert.NoError(t, f.SetSheetPrOptions("Sheet1", OutlineSummaryBelow(false)))
+	ws.SheetData.Row[4].Collapsed = false
+	ws.SheetData.Row[0].Collapsed = true
+	check(map[int][]interface{}{
+		1: {uint8(0), true}, 2: {uint8(1), true}, 3: {uint8(2), false}, 4: {uint8(2), false},
+		5: {uint8(0), false}, 6: {uint8(1), false},
+	})
+	// Test get row outline with invalid arguments
+	_, _, err = f.GetRowOutline("Sheet1", 0)
+	assert.EqualError(t, err, newInvalidRowNumberError(0).Error())
+	_, _, err = f.GetRowOutline("SheetN", 1)
+	assert.EqualError(t, err, "sheet SheetN is not exist")
+}
+
 func TestRemoveRow(t *testing.T) {
 	f := NewFile()
 	sheet1 := f.GetSheetName(0)
@@ -31,6 +31,7 @@ import (
 	"unicode/utf8"
 
 	"github.com/mohae/deepcopy"
+	"github.com/xuri/efp"
 )
 
 // NewSheet provides the function to create a new sheet by given a worksheet
@@ -311,17 +312,77 @@ func (f *File) GetActiveSheetIndex() (index int) {
 	return
 }
 
+// GetActiveSheetName provides a function to get active sheet name of the
+// spreadsheet. If the active tab of the workbook view points to a hidden
+// sheet or out of the sheets list, the first visible sheet will be returned.
+// For example, remember the active sheet before processing the workbook and
+// restore it afterwards:
+//
+//	name := f.GetActiveSheetName()
+//	// ...
+//	f.SetActiveSheet(f.GetSheetIndex(name))
+func (f *File) GetActiveSheetName() string {
+	return f.GetSheetName(f.GetActiveSheetIndex())
+}
+
+// SetSheetsSelected provides a function to select multiple worksheets by
+// given worksheet names, the selected worksheets will be grouped when the
+// spreadsheet is opened. The first worksheet in the list will be the active
+// sheet. Note that the chart sheets, dialog sheets and macro sheets are not
+// supported. For example, group Sheet1 and Sheet2 and make Sheet2 as the
+// active sheet:
+//
+//	err := f.SetSheetsSelected([]string{"Sheet2", "Sheet1"})
+func (f *File) SetSheetsSelected(sheets []string) error {
+	if len(sheets) == 0 {
+		return ErrParameterRequired
+	}
+	selected := make(map[int]bool, len(sheets))
+	for _, sheet := range sheets {
+		if _, err := f.workSheetReader(sheet); err != nil {
+			return err
+		}
+		selected[f.GetSheetIndex(sheet)] = true
+	}
+	f.SetActiveSheet(f.GetSheetIndex(sheets[0]))
+	for idx, name := range f.GetSheetList() {
+		ws, err := f.workSheetReader(name)
+		if err != nil {
+			// Chartsheet, macrosheet or dialogsheet
+			continue
+		}
+		if ws.SheetViews == nil || len(ws.SheetViews.SheetView) == 0 {
+			if !selected[idx] {
+				continue
+			}
+			ws.SheetViews = &xlsxSheetViews{
+				SheetView: []xlsxSheetView{{WorkbookViewID: 0}},
+			}
+		}
+		ws.SheetViews.SheetView[0].TabSelected = selected[idx]
+	}
+	return nil
+}
+
 // getActiveSheetID provides a function to get active sheet ID of the
-// spreadsheet. If not found the active sheet will be return integer 0.
+// spreadsheet. If the active tab is hidden or invalid, the ID of the first
+// visible sheet will be returned. If not found the active sheet will be
+// return integer 0.
 func (f *File) getActiveSheetID() int {
 	wb := f.workbookReader()
 	if wb != nil {
 		if wb.BookViews != nil && len(wb.BookViews.WorkBookView) > 0 {
 			activeTab := wb.BookViews.WorkBookView[0].ActiveTab
-			if len(wb.Sheets.Sheet) > activeTab && wb.Sheets.Sheet[activeTab].SheetID != 0 {
+			if activeTab >= 0 && len(wb.Sheets.Sheet) > activeTab && wb.Sheets.Sheet[activeTab].SheetID != 0 &&
+				isSheetVisibleState(wb.Sheets.Sheet[activeTab].State) {
 				return wb.Sheets.Sheet[activeTab].SheetID
 			}
 		}
+		for _, sheet := range wb.Sheets.Sheet {
+			if isSheetVisibleState(sheet.State) {
+				return sheet.SheetID
+			}
+		}
 		if len(wb.Sheets.Sheet) >= 1 {
 			return wb.Sheets.Sheet[0].SheetID
 		}
@@ -329,6 +390,12 @@ func (f *File) getActiveSheetID() int {
 	return 0
 }
 
+// isSheetVisibleState provides a function to check if the given sheet state
+// attribute value represents a visible sheet.
+func isSheetVisibleState(state string) bool {
+	return state == "" || state == "visible"
+}
+
 // SetSheetName provides a function to set the worksheet name by given old and
 // new worksheet names. Maximum 31 characters are allowed in sheet title and
 // this function only changes the name of the sheet and will not update the
@@ -350,6 +417,83 @@ func (f *File) SetSheetName(oldName, newName string) {
 	}
 }
 
+// SheetNameRepair directly maps the worksheet renamed by the RepairSheetNames
+// function. Index specifies the index of the worksheet in the workbook,
+// OldName and NewName specifies the duplicate name and the new name of the
+// worksheet.
+type SheetNameRepair struct {
+	Index   int
+	OldName string
+	NewName string
+}
+
+// CheckSheetNameUniqueness provides a function to check if the names of the
+// worksheets in the workbook are unique, the names compare is case
+// insensitive as the spreadsheet application does. It returns an error with
+// the first duplicate name if any.
+func (f *File) CheckSheetNameUniqueness() error {
+	names := make(map[string]bool)
+	for _, sheet := range f.workbookReader().Sheets.Sheet {
+		name := strings.ToLower(sheet.Name)
+		if names[name] {
+			return newDuplicateSheetNameError(sheet.Name)
+		}
+		names[name] = true
+	}
+	return nil
+}
+
+// RepairSheetNames provides a function to rename the worksheets which have
+// the duplicate names in the workbook, the first worksheet keeps the name,
+// and the following worksheets will be renamed with the sequence number
+// suffix, such as "Sheet (2)". It returns all worksheets renamed by this
+// function, including the worksheets renamed on opening the spreadsheet with
+// the RepairSheetNames option. For example:
+//
+//	for _, repair := range f.RepairSheetNames() {
+//	    fmt.Printf("sheet %d renamed from %q to %q\n", repair.Index, repair.OldName, repair.NewName)
+//	}
+func (f *File) RepairSheetNames() []SheetNameRepair {
+	wb := f.workbookReader()
+	names := make(map[string]bool)
+	for _, sheet := range wb.Sheets.Sheet {
+		names[strings.ToLower(sheet.Name)] = true
+	}
+	var repaired bool
+	seen := make(map[string]bool)
+	for idx, sheet := range wb.Sheets.Sheet {
+		name := strings.ToLower(sheet.Name)
+		if !seen[name] {
+			seen[name] = true
+			continue
+		}
+		newName := uniqueSheetName(sheet.Name, names)
+		names[strings.ToLower(newName)], seen[strings.ToLower(newName)] = true, true
+		wb.Sheets.Sheet[idx].Name, repaired = newName, true
+		f.sheetNameRepairs = append(f.sheetNameRepairs, SheetNameRepair{Index: idx, OldName: sheet.Name, NewName: newName})
+	}
+	if repaired {
+		f.sheetMap = f.getSheetMap()
+	}
+	return f.sheetNameRepairs
+}
+
+// uniqueSheetName provides a function to get a sheet name which doesn't exist
+// in the given lowercase sheet names, by appending a sequence number suffix
+// like " (2)" to the given sheet name and truncating it to the maximum length
+// of the sheet name.
+func uniqueSheetName(name string, names map[string]bool) string {
+	newName := name
+	for seq := 2; names[strings.ToLower(newName)]; seq++ {
+		suffix, base := fmt.Sprintf(" (%d)", seq), []rune(name)
+		if len(base)+len(suffix) > MaxSheetNameLength {
+			base = base[:MaxSheetNameLength-len(suffix)]
+		}
+		newName = string(base) + suffix
+	}
+	return newName
+}
+
 // GetSheetName provides a function to get the sheet name of the workbook by
 // the given sheet index. If the given sheet index is invalid, it will return
 // an empty string.
@@ -427,6 +571,97 @@ func (f *File) GetSheetList() (list []string) {
 	return
 }
 
+// SheetInfo directly maps the summary information of a sheet in the
+// workbook. The Visibility will be one of "visible", "hidden" or
+// "veryHidden", and the TabColor is the tab color of the sheet resolved to
+// the RRGGBB format, which will be empty if the sheet doesn't have a tab
+// color or uses the automatic color.
+type SheetInfo struct {
+	Index      int
+	Name       string
+	Visibility string
+	TabColor   string
+}
+
+// GetSheetInfo provides a function to get the index, name, visibility and
+// tab color of the worksheets, chart sheets and dialog sheets of the workbook
+// in one call. The tab color which references the theme color or the indexed
+// color palette will be resolved to the RGB value, and the tint of the color
+// will be applied. For example, print the visible sheets with their tab
+// color:
+//
+//	sheets, err := f.GetSheetInfo()
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	for _, sheet := range sheets {
+//	    if sheet.Visibility == "visible" {
+//	        fmt.Println(sheet.Index, sheet.Name, sheet.TabColor)
+//	    }
+//	}
+func (f *File) GetSheetInfo() ([]SheetInfo, error) {
+	var list []SheetInfo
+	wb := f.workbookReader()
+	if wb == nil {
+		return list, nil
+	}
+	for idx, sheet := range wb.Sheets.Sheet {
+		tabColor, err := f.getSheetTabColor(sheet.Name)
+		if err != nil {
+			return list, err
+		}
+		info := SheetInfo{Index: idx, Name: sheet.Name, Visibility: sheet.State, TabColor: f.getTabColorRGB(tabColor)}
+		if info.Visibility == "" {
+			info.Visibility = "visible"
+		}
+		list = append(list, info)
+	}
+	return list, nil
+}
+
+// getSheetTabColor provides a function to get the tab color settings of the
+// worksheet or chart sheet by given sheet name.
+func (f *File) getSheetTabColor(sheet string) (*xlsxTabColor, error) {
+	name, ok := f.getSheetXMLPath(sheet)
+	if !ok {
+		return nil, fmt.Errorf("sheet %s is not exist", sheet)
+	}
+	if strings.HasPrefix(name, "xl/chartsheets") {
+		cs := xlsxChartsheet{}
+		if err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(f.readXML(name)))).
+			Decode(&cs); err != nil && err != io.EOF {
+			return nil, err
+		}
+		if cs.SheetPr != nil {
+			return cs.SheetPr.TabColor, nil
+		}
+		return nil, nil
+	}
+	if strings.HasPrefix(name, "xl/dialogsheet") || strings.HasPrefix(name, "xl/macrosheet") {
+		return nil, nil
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil || ws.SheetPr == nil {
+		return nil, err
+	}
+	return ws.SheetPr.TabColor, nil
+}
+
+// getTabColorRGB provides a function to resolve the tab color to the RRGGBB
+// format by the color resolver of the styles, the theme color will be used
+// if neither the RGB value nor the indexed color has been set.
+func (f *File) getTabColorRGB(tabColor *xlsxTabColor) string {
+	if tabColor == nil {
+		return ""
+	}
+	clr := &xlsxColor{Auto: tabColor.Auto, RGB: tabColor.RGB, Indexed: tabColor.Indexed, Tint: tabColor.Tint}
+	if clr.RGB == "" && clr.Indexed == nil {
+		clr.Theme = intPtr(tabColor.Theme)
+	}
+	return f.getColorRGB(clr)
+}
+
 // getSheetMap provides a function to get worksheet name and XML file path map
 // of the spreadsheet.
 func (f *File) getSheetMap() map[string]string {
@@ -627,6 +862,481 @@ func (f *File) copySheet(from, to int) error {
 	return err
 }
 
+// DeepCopySheet provides a function to duplicate a worksheet into a new
+// worksheet by given source and target worksheet name. Unlike CopySheet, the
+// cell values, styles, merged cells, data validations, conditional formats,
+// page settings, sheet-scoped defined names (such as the print area and print
+// titles), drawings (pictures, charts and shapes), comments, tables and pivot
+// tables of the source worksheet will be duplicated. The charts, drawings,
+// comments, tables and pivot tables are copied into new parts of the
+// package, each copied pivot table has its own pivot cache, and the media
+// files of the pictures are shared with the source worksheet. The copied
+// tables will be renamed to avoid conflicting with the source. The
+// references to the source worksheet and its tables in the copied formulas,
+// conditional formats, data validations, hyperlinks, charts, tables, pivot
+// caches and defined names will be updated to the target worksheet and the
+// copied tables. The target worksheet must not exist, and it will be deleted
+// with the copied parts if an error occurs. For example, clone the worksheet
+// named Template to a new worksheet named Report:
+//
+//	err := f.DeepCopySheet("Template", "Report")
+func (f *File) DeepCopySheet(source, target string) error {
+	if f.GetSheetIndex(target) != -1 {
+		return ErrExistsWorksheet
+	}
+	ws, err := f.workSheetReader(source)
+	if err != nil {
+		return err
+	}
+	for _, name := range f.GetSheetList() {
+		if strings.EqualFold(name, trimSheetName(source)) {
+			source = name
+			break
+		}
+	}
+	fromSheetXMLPath, _ := f.getSheetXMLPath(source)
+	f.NewSheet(target)
+	toSheetXMLPath, _ := f.getSheetXMLPath(target)
+	worksheet := deepcopy.Copy(ws).(*xlsxWorksheet)
+	if worksheet.SheetViews != nil {
+		for idx := range worksheet.SheetViews.SheetView {
+			worksheet.SheetViews.SheetView[idx].TabSelected = false
+		}
+	}
+	if worksheet.SheetPr != nil {
+		worksheet.SheetPr.CodeName = ""
+	}
+	f.Sheet.Store(toSheetXMLPath, worksheet)
+	f.xmlAttr[toSheetXMLPath] = append([]xml.Attr{}, f.xmlAttr[fromSheetXMLPath]...)
+	c := &sheetCopy{
+		source: source, target: target,
+		sheets:             map[string]string{strings.ToLower(source): target},
+		tables:             map[string]string{},
+		threadedCommentIDs: map[string]string{},
+	}
+	if rels := f.relsReader(getPartRelsPath(fromSheetXMLPath)); rels != nil {
+		rels.Lock()
+		toRels := &xlsxRelationships{Relationships: append([]xlsxRelationship{}, rels.Relationships...)}
+		rels.Unlock()
+		var comments []string
+		for idx, rel := range toRels.Relationships {
+			if rel.TargetMode == "External" {
+				continue
+			}
+			partPath := getRelsTargetPath(fromSheetXMLPath, rel.Target)
+			newPartPath, err := f.copySheetPart(partPath, rel.Type, c)
+			if err != nil {
+				f.deleteSheetCopy(c)
+				return err
+			}
+			if rel.Type == SourceRelationshipComments {
+				comments = append(comments, newPartPath)
+			}
+			toRels.Relationships[idx].Target = path.Join(path.Dir(rel.Target), path.Base(newPartPath))
+		}
+		for _, commentsPath := range comments {
+			if cmts := f.commentsReader(commentsPath); cmts != nil {
+				for idx, author := range cmts.Authors.Author {
+					if ID, ok := c.threadedCommentIDs[strings.TrimPrefix(author, "tc=")]; ok {
+						cmts.Authors.Author[idx] = "tc=" + ID
+					}
+				}
+			}
+		}
+		f.Relationships.Store(getPartRelsPath(toSheetXMLPath), toRels)
+	}
+	adjustWorksheetReferences(worksheet, c.sheets, c.tables)
+	f.copySheetDefinedNames(c)
+	return nil
+}
+
+// sheetCopy directly maps the state of duplicating a worksheet, including the
+// source and target worksheet names, the mapping of the worksheet names,
+// table names and threaded comment IDs between them, and the duplicated
+// parts and the relationship IDs of the duplicated pivot caches in the
+// workbook. The keys of the worksheet names and table names mappings are in
+// lower case.
+type sheetCopy struct {
+	source, target     string
+	sheets             map[string]string
+	tables             map[string]string
+	threadedCommentIDs map[string]string
+	parts              []string
+	pivotCacheRIDs     []string
+}
+
+// deleteSheetCopy provides a function to delete the target worksheet and the
+// parts which have been duplicated by given state of duplicating the
+// worksheet, and remove the duplicated pivot caches from the workbook.
+func (f *File) deleteSheetCopy(c *sheetCopy) {
+	f.DeleteSheet(c.target)
+	for _, partPath := range c.parts {
+		f.Pkg.Delete(partPath)
+		f.Drawings.Delete(partPath)
+		f.Relationships.Delete(getPartRelsPath(partPath))
+		delete(f.Comments, partPath)
+		delete(f.VMLDrawing, partPath)
+		f.deleteSheetFromContentTypes("/" + partPath)
+	}
+	if len(c.pivotCacheRIDs) == 0 {
+		return
+	}
+	wb := f.workbookReader()
+	for _, rID := range c.pivotCacheRIDs {
+		f.deleteSheetFromWorkbookRels(rID)
+		if wb.PivotCaches == nil {
+			continue
+		}
+		for idx, pivotCache := range wb.PivotCaches.PivotCache {
+			if pivotCache.RID == rID {
+				wb.PivotCaches.PivotCache = append(wb.PivotCaches.PivotCache[:idx], wb.PivotCaches.PivotCache[idx+1:]...)
+				break
+			}
+		}
+	}
+	if wb.PivotCaches != nil && len(wb.PivotCaches.PivotCache) == 0 {
+		wb.PivotCaches = nil
+	}
+}
+
+// adjustWorksheetReferences provides a function to update the worksheet
+// names and table names referenced in the cell formulas, conditional
+// formats, data validations and hyperlinks of the worksheet by given mapping
+// of the old and new worksheet names and table names.
+func adjustWorksheetReferences(ws *xlsxWorksheet, sheets, tables map[string]string) {
+	for rowIdx := range ws.SheetData.Row {
+		row := &ws.SheetData.Row[rowIdx]
+		for colIdx := range row.C {
+			if c := &row.C[colIdx]; c.F != nil {
+				c.F.Content = adjustFormulaReferences(c.F.Content, sheets, tables)
+			}
+		}
+	}
+	for _, cf := range ws.ConditionalFormatting {
+		for _, rule := range cf.CfRule {
+			for idx := range rule.Formula {
+				rule.Formula[idx] = adjustFormulaReferences(rule.Formula[idx], sheets, tables)
+			}
+		}
+	}
+	if ws.Hyperlinks != nil {
+		for idx := range ws.Hyperlinks.Hyperlink {
+			ws.Hyperlinks.Hyperlink[idx].Location = adjustFormulaReferences(ws.Hyperlinks.Hyperlink[idx].Location, sheets, tables)
+		}
+	}
+	if ws.DataValidations != nil {
+		for _, dv := range ws.DataValidations.DataValidation {
+			dv.Formula1 = adjustFormulaReferences(dv.Formula1, sheets, tables)
+			dv.Formula2 = adjustFormulaReferences(dv.Formula2, sheets, tables)
+		}
+	}
+}
+
+// getPartRelsPath provides a function to get the path of the relationships
+// part by given part path in the package.
+func getPartRelsPath(partPath string) string {
+	return path.Dir(partPath) + "/_rels/" + path.Base(partPath) + ".rels"
+}
+
+// getNextPartPath provides a function to get an unused path in the package
+// with the same folder, name prefix and extension of the given part path, for
+// example, xl/drawings/drawing3.xml for xl/drawings/drawing1.xml.
+func (f *File) getNextPartPath(partPath string) string {
+	ext := path.Ext(partPath)
+	prefix := strings.TrimRight(strings.TrimSuffix(partPath, ext), "0123456789")
+	for idx := 1; ; idx++ {
+		name := prefix + strconv.Itoa(idx) + ext
+		if _, ok := f.Pkg.Load(name); ok {
+			continue
+		}
+		if _, ok := f.Drawings.Load(name); ok {
+			continue
+		}
+		if f.Comments[name] != nil || f.VMLDrawing[name] != nil {
+			continue
+		}
+		return name
+	}
+}
+
+// copyContentType provides a function to register the content type of the
+// given source part for the target part.
+func (f *File) copyContentType(from, to string) {
+	content := f.contentTypesReader()
+	content.Lock()
+	defer content.Unlock()
+	for _, override := range content.Overrides {
+		if override.PartName == "/"+from {
+			content.Overrides = append(content.Overrides, xlsxOverride{
+				PartName:    "/" + to,
+				ContentType: override.ContentType,
+			})
+			return
+		}
+	}
+}
+
+// copySheetPart provides a function to duplicate the part of the package
+// referenced by a worksheet by given part path, relationship type and the
+// state of duplicating the worksheet, and returns the path of the duplicated
+// part. Media files, printer settings and the other shared parts will not be
+// duplicated.
+func (f *File) copySheetPart(partPath, relType string, c *sheetCopy) (string, error) {
+	var newPartPath string
+	switch relType {
+	case SourceRelationshipDrawingML:
+		newPartPath = f.getNextPartPath(partPath)
+		if content, ok := f.Drawings.Load(partPath); ok && content != nil {
+			output, _ := xml.Marshal(content.(*xlsxWsDr))
+			f.saveFileList(newPartPath, output)
+			break
+		}
+		f.Pkg.Store(newPartPath, append([]byte{}, f.readXML(partPath)...))
+	case SourceRelationshipChart:
+		newPartPath = f.getNextPartPath(partPath)
+		content, err := adjustChartReferences(string(f.readXML(partPath)), c.sheets)
+		if err != nil {
+			return newPartPath, err
+		}
+		f.Pkg.Store(newPartPath, []byte(content))
+	case SourceRelationshipPivotTable:
+		newPartPath = f.getNextPartPath(partPath)
+		c.parts = append(c.parts, newPartPath)
+		return newPartPath, f.copyPivotTable(partPath, newPartPath, c)
+	case SourceRelationshipComments:
+		newPartPath = f.getNextPartPath(partPath)
+		if cmts := f.commentsReader(partPath); cmts != nil {
+			f.Comments[newPartPath] = deepcopy.Copy(cmts).(*xlsxComments)
+		}
+	case SourceRelationshipDrawingVML:
+		newPartPath = f.getNextPartPath(partPath)
+		if vml := f.VMLDrawing[partPath]; vml != nil {
+			f.VMLDrawing[newPartPath] = deepcopy.Copy(vml).(*vmlDrawing)
+		} else {
+			f.Pkg.Store(newPartPath, append([]byte{}, f.readXML(partPath)...))
+		}
+	case SourceRelationshipTable:
+		newPartPath = f.getNextPartPath(partPath)
+		if err := f.copyTable(partPath, newPartPath, c); err != nil {
+			return newPartPath, err
+		}
+	case SourceRelationshipThreadedComment:
+		newPartPath = f.getNextPartPath(partPath)
+		threadedComments, err := f.threadedCommentsReader(partPath)
+		if err != nil {
+			return newPartPath, err
+		}
+		for idx, threadedComment := range threadedComments.ThreadedComment {
+			c.threadedCommentIDs[threadedComment.ID] = newGUID()
+			threadedComments.ThreadedComment[idx].ID = c.threadedCommentIDs[threadedComment.ID]
+		}
+		for idx, threadedComment := range threadedComments.ThreadedComment {
+			if ID, ok := c.threadedCommentIDs[threadedComment.ParentID]; ok {
+				threadedComments.ThreadedComment[idx].ParentID = ID
+			}
+		}
+		f.threadedCommentsWriter(newPartPath, threadedComments)
+	default:
+		return partPath, nil
+	}
+	c.parts = append(c.parts, newPartPath)
+	f.copyContentType(partPath, newPartPath)
+	rels := f.relsReader(getPartRelsPath(partPath))
+	if rels == nil {
+		return newPartPath, nil
+	}
+	rels.Lock()
+	newRels := &xlsxRelationships{Relationships: append([]xlsxRelationship{}, rels.Relationships...)}
+	rels.Unlock()
+	if relType == SourceRelationshipDrawingML {
+		for idx, rel := range newRels.Relationships {
+			if rel.Type != SourceRelationshipChart || rel.TargetMode == "External" {
+				continue
+			}
+			chartPath, err := f.copySheetPart(getRelsTargetPath(partPath, rel.Target), rel.Type, c)
+			if err != nil {
+				return newPartPath, err
+			}
+			newRels.Relationships[idx].Target = path.Join(path.Dir(rel.Target), path.Base(chartPath))
+		}
+	}
+	f.Relationships.Store(getPartRelsPath(newPartPath), newRels)
+	return newPartPath, nil
+}
+
+// copyPivotTable provides a function to duplicate the pivot table part by
+// given source and target part path and the state of duplicating the
+// worksheet. The pivot cache definition and records of the pivot table will
+// be duplicated and added to the workbook as a new pivot cache, and the
+// source worksheet of the copied pivot cache will be updated to the target
+// worksheet if it's the source worksheet.
+func (f *File) copyPivotTable(from, to string, c *sheetCopy) error {
+	pivotTable, err := f.readXMLContent(from)
+	if err != nil {
+		return err
+	}
+	var newRels *xlsxRelationships
+	if rels := f.relsReader(getPartRelsPath(from)); rels != nil {
+		rels.Lock()
+		newRels = &xlsxRelationships{Relationships: append([]xlsxRelationship{}, rels.Relationships...)}
+		rels.Unlock()
+	}
+	if newRels != nil {
+		for idx, rel := range newRels.Relationships {
+			if rel.Type != SourceRelationshipPivotCache || rel.TargetMode == "External" {
+				continue
+			}
+			pivotCacheXML := getRelsTargetPath(from, rel.Target)
+			newPivotCacheXML, err := f.copyPivotCache(pivotCacheXML, c)
+			if err != nil {
+				return err
+			}
+			rID := f.addRels(f.getWorkbookRelsPath(), SourceRelationshipPivotCache, "/"+newPivotCacheXML, "")
+			c.pivotCacheRIDs = append(c.pivotCacheRIDs, fmt.Sprintf("rId%d", rID))
+			if pivotTable, err = setXMLElementAttrs(pivotTable, []xml.Attr{
+				{Name: xml.Name{Local: "cacheId"}, Value: strconv.Itoa(f.addWorkbookPivotCache(rID))},
+			}); err != nil {
+				return err
+			}
+			newRels.Relationships[idx].Target = path.Join(path.Dir(rel.Target), path.Base(newPivotCacheXML))
+		}
+		f.Relationships.Store(getPartRelsPath(to), newRels)
+	}
+	f.saveFileList(to, []byte(pivotTable))
+	f.copyContentType(from, to)
+	return err
+}
+
+// copyPivotCache provides a function to duplicate the pivot cache definition
+// and records parts by given pivot cache definition part path and the state
+// of duplicating the worksheet, and returns the path of the duplicated pivot
+// cache definition part.
+func (f *File) copyPivotCache(pivotCacheXML string, c *sheetCopy) (string, error) {
+	newPivotCacheXML := f.getNextPartPath(pivotCacheXML)
+	pivotCache, err := f.readXMLContent(pivotCacheXML)
+	if err != nil {
+		return newPivotCacheXML, err
+	}
+	pc, err := f.pivotCacheDefinitionReader(pivotCacheXML)
+	if err != nil {
+		return newPivotCacheXML, err
+	}
+	if pc.CacheSource != nil && pc.CacheSource.WorksheetSource != nil && strings.EqualFold(pc.CacheSource.WorksheetSource.Sheet, c.source) {
+		cacheSource, err := getXMLChildElements(pivotCache, "cacheSource")
+		if err != nil || len(cacheSource) == 0 {
+			return newPivotCacheXML, ErrPivotCacheSource
+		}
+		content := pivotCache[cacheSource[0].start:cacheSource[0].end]
+		worksheetSource, err := getXMLChildElements(content, "worksheetSource")
+		if err != nil || len(worksheetSource) == 0 {
+			return newPivotCacheXML, ErrPivotCacheSource
+		}
+		source, err := setXMLElementAttrs(content[worksheetSource[0].start:worksheetSource[0].end],
+			[]xml.Attr{{Name: xml.Name{Local: "sheet"}, Value: c.target}})
+		if err != nil {
+			return newPivotCacheXML, err
+		}
+		content = content[:worksheetSource[0].start] + source + content[worksheetSource[0].end:]
+		pivotCache = pivotCache[:cacheSource[0].start] + content + pivotCache[cacheSource[0].end:]
+	}
+	f.saveFileList(newPivotCacheXML, []byte(pivotCache))
+	c.parts = append(c.parts, newPivotCacheXML)
+	f.copyContentType(pivotCacheXML, newPivotCacheXML)
+	rels := f.relsReader(getPartRelsPath(pivotCacheXML))
+	if rels == nil {
+		return newPivotCacheXML, err
+	}
+	rels.Lock()
+	newRels := &xlsxRelationships{Relationships: append([]xlsxRelationship{}, rels.Relationships...)}
+	rels.Unlock()
+	for idx, rel := range newRels.Relationships {
+		if rel.Type != SourceRelationshipPivotCacheRecords || rel.TargetMode == "External" {
+			continue
+		}
+		recordsXML := getRelsTargetPath(pivotCacheXML, rel.Target)
+		newRecordsXML := f.getNextPartPath(recordsXML)
+		f.Pkg.Store(newRecordsXML, append([]byte{}, f.readXML(recordsXML)...))
+		c.parts = append(c.parts, newRecordsXML)
+		f.copyContentType(recordsXML, newRecordsXML)
+		newRels.Relationships[idx].Target = path.Join(path.Dir(rel.Target), path.Base(newRecordsXML))
+	}
+	f.Relationships.Store(getPartRelsPath(newPivotCacheXML), newRels)
+	return newPivotCacheXML, err
+}
+
+// copyTable provides a function to duplicate the table part by given source
+// and target part path and the state of duplicating the worksheet, assign a
+// new unique ID and name to the table, and update the references in the
+// formulas of the table columns.
+func (f *File) copyTable(from, to string, c *sheetCopy) error {
+	var (
+		err      error
+		maxID    int
+		names    = map[string]bool{}
+		newTable = new(xlsxTable)
+	)
+	f.Pkg.Range(func(k, v interface{}) bool {
+		if strings.Contains(k.(string), "xl/tables/table") {
+			var t xlsxTable
+			if err = f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(v.([]byte)))).
+				Decode(&t); err != nil && err != io.EOF {
+				return false
+			}
+			if t.ID > maxID {
+				maxID = t.ID
+			}
+			names[strings.ToLower(t.Name)] = true
+			if k.(string) == from {
+				*newTable = t
+			}
+		}
+		return true
+	})
+	if err != nil && err != io.EOF {
+		return err
+	}
+	newTable.ID = maxID + 1
+	for idx := newTable.ID; ; idx++ {
+		if name := "Table" + strconv.Itoa(idx); !names[strings.ToLower(name)] {
+			c.tables[strings.ToLower(newTable.Name)] = name
+			newTable.Name, newTable.DisplayName = name, name
+			break
+		}
+	}
+	if newTable.TableColumns != nil {
+		for _, col := range newTable.TableColumns.TableColumn {
+			for _, formula := range []*xlsxTableFormula{col.CalculatedColumnFormula, col.TotalsRowFormula} {
+				if formula != nil {
+					formula.Content = adjustFormulaReferences(formula.Content, c.sheets, c.tables)
+				}
+			}
+		}
+	}
+	table, _ := xml.Marshal(newTable)
+	f.saveFileList(to, table)
+	return nil
+}
+
+// copySheetDefinedNames provides a function to duplicate the defined names
+// scoped to the source worksheet for the target worksheet by given state of
+// duplicating the worksheet, and update the references to the source
+// worksheet and its tables in the defined names.
+func (f *File) copySheetDefinedNames(c *sheetCopy) {
+	wb := f.workbookReader()
+	if wb.DefinedNames == nil {
+		return
+	}
+	fromIdx, toIdx := f.GetSheetIndex(c.source), f.GetSheetIndex(c.target)
+	for _, dn := range wb.DefinedNames.DefinedName {
+		if dn.LocalSheetID == nil || *dn.LocalSheetID != fromIdx {
+			continue
+		}
+		dn.LocalSheetID = intPtr(toIdx)
+		dn.Data = adjustFormulaReferences(dn.Data, c.sheets, c.tables)
+		wb.DefinedNames.DefinedName = append(wb.DefinedNames.DefinedName, dn)
+	}
+}
+
 // SetSheetVisible provides a function to set worksheet visible by given worksheet
 // name. A workbook must contain at least one visible worksheet. If the given
 // worksheet has been activated, this setting will be invalidated. Sheet state
@@ -745,6 +1455,12 @@ func parseFormatPanesSet(formatSet string) (*formatPanes, error) {
 // sqref (Sequence of References): Range of the selection. Can be non-contiguous
 // set of ranges.
 //
+// For the split panes, the x_split and y_split can't be negative and at
+// least one of them should be positive, the active pane and the pane of the
+// selections must exist in the arrangement of the split panes, for example,
+// the bottomRight pane doesn't exist if the window only has been split
+// vertically into the left and right panes.
+//
 // An example of how to freeze column A in the Sheet1 and set the active cell on
 // Sheet1!K16:
 //
@@ -777,6 +1493,11 @@ func (f *File) SetPanes(sheet, panes string) error {
 	}
 	if fs.Freeze {
 		p.State = "frozen"
+	} else if fs.Split {
+		if err = checkSplitPanes(fs); err != nil {
+			return err
+		}
+		p.State = "split"
 	}
 	if ws.SheetViews == nil {
 		ws.SheetViews = &xlsxSheetViews{SheetView: []xlsxSheetView{{}}}
@@ -799,6 +1520,95 @@ func (f *File) SetPanes(sheet, panes string) error {
 	return err
 }
 
+// checkSplitPanes provides a function to check the split position, the
+// active pane, the panes of the selections and the top left cell of the
+// split panes settings.
+func checkSplitPanes(fs *formatPanes) error {
+	if fs.XSplit < 0 || fs.YSplit < 0 || fs.XSplit+fs.YSplit == 0 {
+		return ErrPaneSplitPosition
+	}
+	panes := []string{"", "topLeft", "topRight", "bottomLeft", "bottomRight"}
+	if fs.XSplit == 0 {
+		panes = []string{"", "topLeft", "bottomLeft"}
+	}
+	if fs.YSplit == 0 {
+		panes = []string{"", "topLeft", "topRight"}
+	}
+	if inStrSlice(panes, fs.ActivePane, true) == -1 {
+		return ErrActivePane
+	}
+	for _, p := range fs.Panes {
+		if inStrSlice(panes, p.Pane, true) == -1 {
+			return ErrActivePane
+		}
+	}
+	if fs.TopLeftCell != "" {
+		if _, _, err := CellNameToCoordinates(fs.TopLeftCell); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SplitPanes provides a function to split the worksheet window into the
+// panes with the adjustable split bars by given worksheet name, the
+// horizontal and vertical position of the split bars in pixels, and the top
+// left visible cell in the bottom right pane, the same as the "Split"
+// command in the View menu of Excel. The split position will be converted to
+// 1/20th of a point which stored in the worksheet. Set the x or y as 0 to
+// split the window into the left and right panes, or the top and bottom
+// panes. The bottom right pane will be activated. For example, split the
+// window of Sheet1 into four panes at 200 pixels from the left and 100
+// pixels from the top, and scroll the bottom right pane to cell D10:
+//
+//	err := f.SplitPanes("Sheet1", 200, 100, "D10")
+func (f *File) SplitPanes(sheet string, x, y int, topLeftCell string) error {
+	activePane := "bottomRight"
+	if x == 0 {
+		activePane = "bottomLeft"
+	}
+	if y == 0 {
+		activePane = "topRight"
+	}
+	panes, _ := json.Marshal(formatPanes{
+		Split:       true,
+		XSplit:      x * 15,
+		YSplit:      y * 15,
+		TopLeftCell: topLeftCell,
+		ActivePane:  activePane,
+	})
+	return f.SetPanes(sheet, string(panes))
+}
+
+// FreezeTopRow provides a function to freeze the first row of the worksheet
+// by given worksheet name, the same as the "Freeze Top Row" command in the
+// View menu of Excel. For example, keep the header row of Sheet1 visible
+// while scrolling through the rest of the worksheet:
+//
+//	err := f.FreezeTopRow("Sheet1")
+func (f *File) FreezeTopRow(sheet string) error {
+	return f.SetPanes(sheet, `{"freeze":true,"split":false,"x_split":0,"y_split":1,"top_left_cell":"A2","active_pane":"bottomLeft","panes":[{"sqref":"A2","active_cell":"A2","pane":"bottomLeft"}]}`)
+}
+
+// FreezeFirstColumn provides a function to freeze the first column of the
+// worksheet by given worksheet name, the same as the "Freeze First Column"
+// command in the View menu of Excel. For example, keep column A of Sheet1
+// visible while scrolling through the rest of the worksheet:
+//
+//	err := f.FreezeFirstColumn("Sheet1")
+func (f *File) FreezeFirstColumn(sheet string) error {
+	return f.SetPanes(sheet, `{"freeze":true,"split":false,"x_split":1,"y_split":0,"top_left_cell":"B1","active_pane":"topRight","panes":[{"sqref":"B1","active_cell":"B1","pane":"topRight"}]}`)
+}
+
+// Unfreeze provides a function to remove all freeze panes and split panes of
+// the worksheet by given worksheet name, the same as the "Unfreeze Panes"
+// command in the View menu of Excel. For example:
+//
+//	err := f.Unfreeze("Sheet1")
+func (f *File) Unfreeze(sheet string) error {
+	return f.SetPanes(sheet, `{"freeze":false,"split":false}`)
+}
+
 // GetSheetVisible provides a function to get worksheet visible by given worksheet
 // name. For example, get visible state of Sheet1:
 //
@@ -955,18 +1765,28 @@ func attrValToBool(name string, attrs []xml.Attr) (val bool, err error) {
 //
 // Headers and footers are specified using the following settings fields:
 //
-//	 Fields           | Description
-//	------------------+-----------------------------------------------------------
-//	 AlignWithMargins | Align header footer margins with page margins
-//	 DifferentFirst   | Different first-page header and footer indicator
-//	 DifferentOddEven | Different odd and even page headers and footers indicator
-//	 ScaleWithDoc     | Scale header and footer with document scaling
-//	 OddFooter        | Odd Page Footer
-//	 OddHeader        | Odd Header
-//	 EvenFooter       | Even Page Footer
-//	 EvenHeader       | Even Page Header
-//	 FirstFooter      | First Page Footer
-//	 FirstHeader      | First Page Header
+//	 Fields             | Description
+//	--------------------+-----------------------------------------------------------
+//	 AlignWithMargins   | Align header footer margins with page margins
+//	 DifferentFirst     | Different first-page header and footer indicator
+//	 DifferentOddEven   | Different odd and even page headers and footers indicator
+//	 ScaleWithDoc       | Scale header and footer with document scaling
+//	 OddFooter          | Odd Page Footer
+//	 OddHeader          | Odd Header
+//	 EvenFooter         | Even Page Footer
+//	 EvenHeader         | Even Page Header
+//	 FirstFooter        | First Page Footer
+//	 FirstHeader        | First Page Header
+//	 NoAlignWithMargins | Don't align header footer margins with page margins
+//	 NoScaleWithDoc     | Don't scale header and footer with document scaling
+//
+// The headers and footers (including the images) scale with the print
+// scaling of the worksheet and align with the left and right page margins
+// by default. Set ScaleWithDoc or AlignWithMargins to true to write these
+// settings explicitly, and set NoScaleWithDoc to true to keep the headers
+// and footers a fixed size, or set NoAlignWithMargins to true to not align
+// them with the page margins. The NoScaleWithDoc and NoAlignWithMargins
+// fields take precedence over the ScaleWithDoc and AlignWithMargins fields.
 //
 // The following formatting codes can be used in 6 string type fields:
 // OddHeader, OddFooter, EvenHeader, EvenFooter, FirstFooter, FirstHeader
@@ -1036,6 +1856,8 @@ func attrValToBool(name string, attrs []xml.Attr) (val bool, err error) {
 // For example:
 //
 //	err := f.SetHeaderFooter("Sheet1", &excelize.FormatHeaderFooter{
+//	    AlignWithMargins: true,
+//	    ScaleWithDoc:     true,
 //	    DifferentFirst:   true,
 //	    DifferentOddEven: true,
 //	    OddHeader:        "&R&P",
@@ -1047,6 +1869,9 @@ func attrValToBool(name string, attrs []xml.Attr) (val bool, err error) {
 //
 // This example shows:
 //
+// - The headers and footers scale with the document and align with the page
+// margins
+//
 // - The first page has its own header and footer
 //
 // - Odd and even-numbered pages have different headers and footers
@@ -1078,16 +1903,25 @@ func (f *File) SetHeaderFooter(sheet string, settings *FormatHeaderFooter) error
 	v := reflect.ValueOf(*settings)
 	// Check 6 string type fields: OddHeader, OddFooter, EvenHeader, EvenFooter,
 	// FirstFooter, FirstHeader
-	for i := 4; i < v.NumField()-1; i++ {
-		if len(utf16.Encode([]rune(v.Field(i).String()))) > MaxFieldLength {
+	for i := 4; i < v.NumField(); i++ {
+		if v.Field(i).Kind() == reflect.String && len(utf16.Encode([]rune(v.Field(i).String()))) > MaxFieldLength {
 			return newFieldLengthError(v.Type().Field(i).Name)
 		}
 	}
+	getAttr := func(enable, disable bool) *bool {
+		if disable {
+			return boolPtr(false)
+		}
+		if enable {
+			return boolPtr(true)
+		}
+		return nil
+	}
 	ws.HeaderFooter = &xlsxHeaderFooter{
-		AlignWithMargins: settings.AlignWithMargins,
+		AlignWithMargins: getAttr(settings.AlignWithMargins, settings.NoAlignWithMargins),
 		DifferentFirst:   settings.DifferentFirst,
 		DifferentOddEven: settings.DifferentOddEven,
-		ScaleWithDoc:     settings.ScaleWithDoc,
+		ScaleWithDoc:     getAttr(settings.ScaleWithDoc, settings.NoScaleWithDoc),
 		OddHeader:        settings.OddHeader,
 		OddFooter:        settings.OddFooter,
 		EvenHeader:       settings.EvenHeader,
@@ -1233,6 +2067,13 @@ type (
 	PageLayoutOrientation string
 	// PageLayoutPaperSize defines the paper size of the worksheet.
 	PageLayoutPaperSize int
+	// PageLayoutPaperSizeName defines the paper size of the worksheet by the
+	// name of the paper, such as "A4" or "Letter". See SetPageLayout() for the
+	// supported names.
+	PageLayoutPaperSizeName string
+	// PageLayoutPrintQuality defines the print quality of the worksheet in
+	// dots per inch, which applies to both horizontal and vertical resolution.
+	PageLayoutPrintQuality uint
 	// FitToHeight specified the number of vertical pages to fit on.
 	FitToHeight int
 	// FitToWidth specified the number of horizontal pages to fit on.
@@ -1317,6 +2158,86 @@ func (p *PageLayoutPaperSize) getPageLayout(ps *xlsxPageSetUp) {
 	*p = PageLayoutPaperSize(*ps.PaperSize)
 }
 
+// pageLayoutPaperSizeNames defined the names of the commonly used paper sizes
+// and the index number of them.
+var pageLayoutPaperSizeNames = map[string]int{
+	"Letter":          1,
+	"LetterSmall":     2,
+	"Tabloid":         3,
+	"Ledger":          4,
+	"Legal":           5,
+	"Statement":       6,
+	"Executive":       7,
+	"A3":              8,
+	"A4":              9,
+	"A4Small":         10,
+	"A5":              11,
+	"B4":              12,
+	"B5":              13,
+	"Folio":           14,
+	"Quarto":          15,
+	"Note":            18,
+	"Envelope10":      20,
+	"C":               24,
+	"D":               25,
+	"E":               26,
+	"EnvelopeDL":      27,
+	"EnvelopeC5":      28,
+	"EnvelopeC3":      29,
+	"EnvelopeC4":      30,
+	"EnvelopeC6":      31,
+	"EnvelopeMonarch": 37,
+	"A2":              66,
+	"A6":              70,
+	"B6":              88,
+}
+
+// setPageLayout provides a method to set the paper size by the name of the
+// paper for the worksheet. The unknown name will be ignored.
+func (p PageLayoutPaperSizeName) setPageLayout(ps *xlsxPageSetUp) {
+	for name, size := range pageLayoutPaperSizeNames {
+		if strings.EqualFold(name, string(p)) {
+			ps.PaperSize = intPtr(size)
+			return
+		}
+	}
+}
+
+// getPageLayout provides a method to get the name of the paper size for the
+// worksheet. It will be empty if the paper size has no name.
+func (p *PageLayoutPaperSizeName) getPageLayout(ps *xlsxPageSetUp) {
+	var size PageLayoutPaperSize
+	size.getPageLayout(ps)
+	*p = ""
+	for name, idx := range pageLayoutPaperSizeNames {
+		if idx == int(size) {
+			*p = PageLayoutPaperSizeName(name)
+			return
+		}
+	}
+}
+
+// setPageLayout provides a method to set the print quality for the worksheet.
+func (p PageLayoutPrintQuality) setPageLayout(ps *xlsxPageSetUp) {
+	ps.HorizontalDPI, ps.VerticalDPI = "", ""
+	if p > 0 {
+		ps.HorizontalDPI = strconv.Itoa(int(p))
+		ps.VerticalDPI = ps.HorizontalDPI
+	}
+}
+
+// getPageLayout provides a method to get the print quality for the worksheet.
+func (p *PageLayoutPrintQuality) getPageLayout(ps *xlsxPageSetUp) {
+	// Excel default: 600
+	if ps != nil {
+		if dpi, _ := strconv.Atoi(ps.HorizontalDPI); dpi > 0 {
+			*p = PageLayoutPrintQuality(dpi)
+			return
+		}
+	}
+	*p = 600
+}
+
 // setPageLayout provides a method to set the fit to height for the worksheet.
 func (p FitToHeight) setPageLayout(ps *xlsxPageSetUp) {
 	if int(p) > 0 {
@@ -1373,10 +2294,55 @@ func (p *PageLayoutScale) getPageLayout(ps *xlsxPageSetUp) {
 //	FirstPageNumber(uint)
 //	PageLayoutOrientation(string)
 //	PageLayoutPaperSize(int)
+//	PageLayoutPaperSizeName(string)
+//	PageLayoutPrintQuality(uint)
 //	FitToHeight(int)
 //	FitToWidth(int)
 //	PageLayoutScale(uint)
 //
+// For example, set the paper size of Sheet1 to A4 and the print quality to
+// 300 dpi:
+//
+//	err := f.SetPageLayout("Sheet1",
+//	    excelize.PageLayoutPaperSizeName("A4"),
+//	    excelize.PageLayoutPrintQuality(300),
+//	)
+//
+// The PageLayoutPaperSizeName is not case-sensitive, and supports the
+// following names of the paper size:
+//
+//	 Name            | Index
+//	-----------------+-------
+//	 Letter          | 1
+//	 LetterSmall     | 2
+//	 Tabloid         | 3
+//	 Ledger          | 4
+//	 Legal           | 5
+//	 Statement       | 6
+//	 Executive       | 7
+//	 A3              | 8
+//	 A4              | 9
+//	 A4Small         | 10
+//	 A5              | 11
+//	 B4              | 12
+//	 B5              | 13
+//	 Folio           | 14
+//	 Quarto          | 15
+//	 Note            | 18
+//	 Envelope10      | 20
+//	 C               | 24
+//	 D               | 25
+//	 E               | 26
+//	 EnvelopeDL      | 27
+//	 EnvelopeC5      | 28
+//	 EnvelopeC3      | 29
+//	 EnvelopeC4      | 30
+//	 EnvelopeC6      | 31
+//	 EnvelopeMonarch | 37
+//	 A2              | 66
+//	 A6              | 70
+//	 B6              | 88
+//
 // The following shows the paper size sorted by excelize index number:
 //
 //	 Index | Paper Size
@@ -1520,8 +2486,17 @@ func (f *File) SetPageLayout(sheet string, opts ...PageLayoutOption) error {
 //
 //	PageLayoutOrientation(string)
 //	PageLayoutPaperSize(int)
+//	PageLayoutPaperSizeName(string)
+//	PageLayoutPrintQuality(uint)
 //	FitToHeight(int)
 //	FitToWidth(int)
+//
+// The PageLayoutPaperSizeName will be empty if the paper size of the
+// worksheet is not one of the named paper sizes. For example, get the name of
+// the paper size of Sheet1:
+//
+//	var paperSize excelize.PageLayoutPaperSizeName
+//	err := f.GetPageLayout("Sheet1", &paperSize)
 func (f *File) GetPageLayout(sheet string, opts ...PageLayoutOptionPtr) error {
 	s, err := f.workSheetReader(sheet)
 	if err != nil {
@@ -1576,6 +2551,158 @@ func (f *File) SetDefinedName(definedName *DefinedName) error {
 	return nil
 }
 
+// SetDefinedNames provides a function to set multiple defined names of the
+// workbook or worksheets in a batch. Each defined name will be validated
+// before applying: the name must be a legal identifier which isn't a cell
+// reference, the scope must be an existing worksheet or empty (or
+// "Workbook") for the workbook scope, the name must not conflict with the
+// existing defined names or the other names in the batch on the same scope,
+// and the reference must be a well-formed formula referencing existing
+// worksheets. The defined names are applied atomically, no defined name will
+// be set if any of them is invalid, and the returned error contains the
+// invalid defined name. For example:
+//
+//	err := f.SetDefinedNames([]excelize.DefinedName{
+//	    {Name: "Amount", RefersTo: "Sheet1!$A$2:$D$5"},
+//	    {Name: "Rate", RefersTo: "Sheet1!$F$1", Scope: "Sheet1"},
+//	})
+func (f *File) SetDefinedNames(definedNames []DefinedName) error {
+	wb := f.workbookReader()
+	names := map[string]bool{}
+	if wb.DefinedNames != nil {
+		for _, dn := range wb.DefinedNames.DefinedName {
+			localSheetID := -1
+			if dn.LocalSheetID != nil {
+				localSheetID = *dn.LocalSheetID
+			}
+			names[fmt.Sprintf("%d!%s", localSheetID, strings.ToLower(dn.Name))] = true
+		}
+	}
+	var items []xlsxDefinedName
+	for _, definedName := range definedNames {
+		if err := checkDefinedName(definedName.Name); err != nil {
+			return newDefinedNameError(definedName.Name, err)
+		}
+		d := xlsxDefinedName{Name: definedName.Name, Comment: definedName.Comment, Data: strings.TrimPrefix(definedName.RefersTo, "=")}
+		localSheetID := -1
+		if definedName.Scope != "" && definedName.Scope != "Workbook" {
+			if localSheetID = f.GetSheetIndex(definedName.Scope); localSheetID == -1 {
+				return newDefinedNameError(definedName.Name, ErrSheetNotExist{definedName.Scope})
+			}
+			d.LocalSheetID = intPtr(localSheetID)
+		}
+		key := fmt.Sprintf("%d!%s", localSheetID, strings.ToLower(definedName.Name))
+		if names[key] {
+			return newDefinedNameError(definedName.Name, ErrDefinedNameDuplicate)
+		}
+		names[key] = true
+		if err := f.checkDefinedNameRefersTo(d.Data); err != nil {
+			return newDefinedNameError(definedName.Name, err)
+		}
+		items = append(items, d)
+	}
+	if len(items) == 0 {
+		return nil
+	}
+	if wb.DefinedNames == nil {
+		wb.DefinedNames = &xlsxDefinedNames{}
+	}
+	wb.DefinedNames.DefinedName = append(wb.DefinedNames.DefinedName, items...)
+	return nil
+}
+
+var (
+	// definedNameRegexp matches the legal identifier of the defined name, which
+	// starts with a letter, an underscore or a backslash.
+	definedNameRegexp = regexp.MustCompile(`^[\p{L}_\\][\p{L}\p{N}_.\\]*$`)
+	// definedNameR1C1Regexp matches the R1C1 style cell reference.
+	definedNameR1C1Regexp = regexp.MustCompile(`^(?i)R[0-9]*C[0-9]*$`)
+)
+
+// checkDefinedName provides a function to check if the given name is a legal
+// identifier for the defined name.
+func checkDefinedName(name string) error {
+	if len(name) > 255 || !definedNameRegexp.MatchString(name) ||
+		strings.EqualFold(name, "R") || strings.EqualFold(name, "C") ||
+		definedNameR1C1Regexp.MatchString(name) {
+		return ErrDefinedNameInvalid
+	}
+	if _, _, err := CellNameToCoordinates(name); err == nil {
+		return ErrDefinedNameInvalid
+	}
+	return nil
+}
+
+// checkDefinedNameRefersTo provides a function to check if the reference of
+// the defined name is a well-formed formula, and the cell references in the
+// formula are valid and the referenced worksheets exist.
+func (f *File) checkDefinedNameRefersTo(refersTo string) error {
+	if strings.TrimSpace(refersTo) == "" {
+		return ErrDefinedNameRefersTo
+	}
+	var depth int
+	var inString, inQuote bool
+	for _, r := range refersTo {
+		switch {
+		case r == '"' && !inQuote:
+			inString = !inString
+		case r == '\'' && !inString:
+			inQuote = !inQuote
+		case inString || inQuote:
+		case r == '(':
+			depth++
+		case r == ')':
+			if depth--; depth < 0 {
+				return ErrDefinedNameRefersTo
+			}
+		}
+	}
+	if depth != 0 || inString || inQuote {
+		return ErrDefinedNameRefersTo
+	}
+	ps := efp.ExcelParser()
+	tokens := ps.Parse(refersTo)
+	for idx, token := range tokens {
+		if token.TType == efp.TokenTypeUnknown {
+			return ErrDefinedNameRefersTo
+		}
+		if idx == len(tokens)-1 && (token.TType == efp.TokenTypeOperatorInfix || token.TType == efp.TokenTypeOperatorPrefix) {
+			return ErrDefinedNameRefersTo
+		}
+		if token.TType != efp.TokenTypeOperand || token.TSubType != efp.TokenSubTypeRange ||
+			strings.HasPrefix(token.TValue, "[") {
+			continue
+		}
+		if idx := strings.LastIndex(token.TValue, "!"); idx != -1 && !isValidRangeRef(token.TValue[idx+1:]) {
+			return ErrDefinedNameRefersTo
+		}
+	}
+	for _, sheet := range getFormulaSheetRefs(refersTo) {
+		if f.GetSheetIndex(sheet) == -1 {
+			return ErrSheetNotExist{sheet}
+		}
+	}
+	return nil
+}
+
+// isValidRangeRef provides a function to check if the given reference is a
+// valid cell reference, range reference, full column or full row reference.
+func isValidRangeRef(ref string) bool {
+	if _, ok, err := prepareFullRangeRef(ref); ok {
+		return err == nil
+	}
+	rng := strings.Split(strings.ReplaceAll(ref, "$", ""), ":")
+	if len(rng) > 2 {
+		return false
+	}
+	for _, cell := range rng {
+		if _, _, err := CellNameToCoordinates(cell); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
 // DeleteDefinedName provides a function to delete the defined names of the
 // workbook or worksheet. If not specified scope, the default scope is
 // workbook. For example:
@@ -1627,6 +2754,47 @@ func (f *File) GetDefinedName() []DefinedName {
 	return definedNames
 }
 
+// ResolveDefinedName provides a function to get the reference of the defined
+// name visible on the given worksheet, following the scope precedence of
+// Excel: a name scoped to the worksheet takes precedence over the workbook
+// name with the same name. Defined names are matched case-insensitively. If
+// the sheet name is empty, only workbook scoped names will be resolved. This
+// function returns ErrDefinedNameScope when no such name is visible. For
+// example, resolve the name "Amount" for formulas on Sheet2:
+//
+//	refersTo, err := f.ResolveDefinedName("Amount", "Sheet2")
+func (f *File) ResolveDefinedName(name, sheet string) (string, error) {
+	localSheetID := -1
+	if sheet != "" {
+		if localSheetID = f.GetSheetIndex(sheet); localSheetID == -1 {
+			return "", ErrSheetNotExist{sheet}
+		}
+	}
+	var workbookRefTo, worksheetRefTo *string
+	wb := f.workbookReader()
+	if wb.DefinedNames != nil {
+		for idx, dn := range wb.DefinedNames.DefinedName {
+			if !strings.EqualFold(dn.Name, name) {
+				continue
+			}
+			if dn.LocalSheetID == nil || *dn.LocalSheetID < 0 {
+				workbookRefTo = &wb.DefinedNames.DefinedName[idx].Data
+				continue
+			}
+			if *dn.LocalSheetID == localSheetID {
+				worksheetRefTo = &wb.DefinedNames.DefinedName[idx].Data
+			}
+		}
+	}
+	if worksheetRefTo != nil {
+		return *worksheetRefTo, nil
+	}
+	if workbookRefTo != nil {
+		return *workbookRefTo, nil
+	}
+	return "", ErrDefinedNameScope
+}
+
 // GroupSheets provides a function to group worksheets by given worksheets
 // name. Group worksheets must contain an active worksheet.
 func (f *File) GroupSheets(sheets []string) error {
@@ -1792,6 +2960,187 @@ func (f *File) RemovePageBreak(sheet, cell string) (err error) {
 	return
 }
 
+// SetSheetDimension provides a function to set or remove the used range of
+// the worksheet by a given range reference. It specifies the row and column
+// bounds of the used cells in the worksheet. The range reference could be a
+// single cell reference or a range reference. Set the range reference as
+// empty string to remove the dimension of the worksheet. For example, set the
+// used range of Sheet1 as A1:D5:
+//
+//	err := f.SetSheetDimension("Sheet1", "A1:D5")
+func (f *File) SetSheetDimension(sheet, rangeRef string) error {
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	if rangeRef == "" {
+		ws.Dimension = nil
+		return err
+	}
+	ref := strings.ReplaceAll(rangeRef, "$", "")
+	cells := strings.Split(ref, ":")
+	if len(cells) > 2 {
+		return ErrParameterInvalid
+	}
+	if len(cells) == 1 {
+		var col, row int
+		if col, row, err = CellNameToCoordinates(ref); err != nil {
+			return err
+		}
+		ref, err = CoordinatesToCellName(col, row)
+	} else {
+		var coordinates []int
+		if coordinates, err = areaRefToCoordinates(ref); err != nil {
+			return err
+		}
+		_ = sortCoordinates(coordinates)
+		ref, err = f.coordinatesToAreaRef(coordinates)
+	}
+	if err != nil {
+		return err
+	}
+	ws.Dimension = &xlsxDimension{Ref: ref}
+	return err
+}
+
+// GetSheetDimension provides a function to get the used range of the worksheet
+// which specified in the dimension element. It returns an empty string if the
+// dimension of the worksheet is not specified.
+func (f *File) GetSheetDimension(sheet string) (string, error) {
+	var ref string
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return ref, err
+	}
+	if ws.Dimension != nil {
+		ref = ws.Dimension.Ref
+	}
+	return ref, err
+}
+
+// AddIgnoredError provides a function to ignore the error conditions for the
+// range of cells by given worksheet name, range reference and error types, so
+// the spreadsheet application will not flag these cells with the error
+// indicator (green triangle). The range reference could be a single cell, a
+// cell range or a space-separated list of them. The ignored errors of the
+// same range reference will be merged. For example, ignore the "number stored
+// as text" error for the range Sheet1!A1:A100:
+//
+//	err := f.AddIgnoredError("Sheet1", "A1:A100", excelize.IgnoredErrorTypes{
+//	    NumberStoredAsText: true,
+//	})
+func (f *File) AddIgnoredError(sheet, rangeRef string, errorTypes IgnoredErrorTypes) error {
+	if errorTypes == (IgnoredErrorTypes{}) {
+		return ErrParameterInvalid
+	}
+	refs := strings.Fields(rangeRef)
+	if len(refs) == 0 {
+		return ErrParameterInvalid
+	}
+	for _, ref := range refs {
+		var err error
+		if strings.Contains(ref, ":") {
+			_, err = areaRefToCoordinates(ref)
+		} else {
+			_, _, err = CellNameToCoordinates(ref)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	if ws.IgnoredErrors == nil {
+		ws.IgnoredErrors = &xlsxIgnoredErrors{}
+	}
+	sqref := strings.Join(refs, " ")
+	var ignoredError *xlsxIgnoredError
+	for _, item := range ws.IgnoredErrors.IgnoredError {
+		if item.Sqref == sqref {
+			ignoredError = item
+			break
+		}
+	}
+	if ignoredError == nil {
+		ignoredError = &xlsxIgnoredError{Sqref: sqref}
+		ws.IgnoredErrors.IgnoredError = append(ws.IgnoredErrors.IgnoredError, ignoredError)
+	}
+	ignoredError.EvalError = ignoredError.EvalError || errorTypes.EvalError
+	ignoredError.TwoDigitTextYear = ignoredError.TwoDigitTextYear || errorTypes.TwoDigitTextYear
+	ignoredError.NumberStoredAsText = ignoredError.NumberStoredAsText || errorTypes.NumberStoredAsText
+	ignoredError.Formula = ignoredError.Formula || errorTypes.Formula
+	ignoredError.FormulaRange = ignoredError.FormulaRange || errorTypes.FormulaRange
+	ignoredError.UnlockedFormula = ignoredError.UnlockedFormula || errorTypes.UnlockedFormula
+	ignoredError.EmptyCellReference = ignoredError.EmptyCellReference || errorTypes.EmptyCellReference
+	ignoredError.ListDataValidation = ignoredError.ListDataValidation || errorTypes.ListDataValidation
+	ignoredError.CalculatedColumn = ignoredError.CalculatedColumn || errorTypes.CalculatedColumn
+	return nil
+}
+
+// SetSheetDefaultStyle provides a function to set the default style of the
+// whole worksheet by given worksheet name and style ID, so the blank cells,
+// including the cells that have never been written, will be shown and
+// printed with the style. This function sets the style of all columns and
+// the rows with custom format, and the blank cells without style in the
+// worksheet, the existing styles of the non-blank cells won't be changed.
+// For example, set the default font of Sheet1:
+//
+//	style, err := f.NewStyle(&excelize.Style{
+//	    Font: &excelize.Font{Family: "Arial", Size: 10},
+//	})
+//	if err != nil {
+//	    fmt.Println(err)
+//	}
+//	err = f.SetSheetDefaultStyle("Sheet1", style)
+func (f *File) SetSheetDefaultStyle(sheet string, styleID int) error {
+	s := f.stylesReader()
+	if styleID < 0 || s.CellXfs == nil || styleID >= len(s.CellXfs.Xf) {
+		return newInvalidStyleID(styleID)
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	ws.Lock()
+	defer ws.Unlock()
+	if ws.SheetFormatPr == nil {
+		ws.SheetFormatPr = &xlsxSheetFormatPr{DefaultRowHeight: defaultRowHeight}
+	}
+	if ws.Cols == nil {
+		ws.Cols = &xlsxCols{}
+	}
+	ws.Cols.Col = flatCols(xlsxCol{
+		Min:   1,
+		Max:   MaxColumns,
+		Width: ws.getDefaultColWidth(),
+		Style: styleID,
+	}, ws.Cols.Col, func(fc, c xlsxCol) xlsxCol {
+		fc.BestFit = c.BestFit
+		fc.Collapsed = c.Collapsed
+		fc.CustomWidth = c.CustomWidth
+		fc.Hidden = c.Hidden
+		fc.OutlineLevel = c.OutlineLevel
+		fc.Phonetic = c.Phonetic
+		fc.Width = c.Width
+		return fc
+	})
+	f.mergeExpandedCols(ws)
+	for r := range ws.SheetData.Row {
+		row := &ws.SheetData.Row[r]
+		if row.CustomFormat {
+			row.S = styleID
+		}
+		for c := range row.C {
+			if cell := &row.C[c]; cell.S == 0 && cell.V == "" && cell.F == nil && cell.IS == nil {
+				cell.S = styleID
+			}
+		}
+	}
+	return nil
+}
+
 // relsReader provides a function to get the pointer to the structure
 // after deserialization of xl/worksheets/_rels/sheet%d.xml.rels.
 func (f *File) relsReader(path string) *xlsxRelationships {
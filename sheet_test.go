@@ -1,6 +1,7 @@
 package excelize
 
 import (
+	"bytes"
 	"encoding/xml"
 	"fmt"
 	"path/filepath"
@@ -112,6 +113,58 @@ func TestSetPane(t *testing.T) {
 	assert.NoError(t, f.SetPanes("Sheet1", `{"freeze":true,"split":false,"x_split":1,"y_split":0,"top_left_cell":"B1","active_pane":"topRight","panes":[{"sqref":"K16","active_cell":"K16","pane":"topRight"}]}`))
 }
 
+func TestFreezePanes(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.FreezeTopRow("Sheet1"))
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	sheetView := ws.SheetViews.SheetView[len(ws.SheetViews.SheetView)-1]
+	assert.Equal(t, &xlsxPane{YSplit: 1, TopLeftCell: "A2", ActivePane: "bottomLeft", State: "frozen"}, sheetView.Pane)
+	assert.Equal(t, []*xlsxSelection{{Pane: "bottomLeft", ActiveCell: "A2", SQRef: "A2"}}, sheetView.Selection)
+
+	assert.NoError(t, f.FreezeFirstColumn("Sheet1"))
+	sheetView = ws.SheetViews.SheetView[len(ws.SheetViews.SheetView)-1]
+	assert.Equal(t, &xlsxPane{XSplit: 1, TopLeftCell: "B1", ActivePane: "topRight", State: "frozen"}, sheetView.Pane)
+	assert.Equal(t, []*xlsxSelection{{Pane: "topRight", ActiveCell: "B1", SQRef: "B1"}}, sheetView.Selection)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestFreezePanes.xlsx")))
+
+	assert.NoError(t, f.Unfreeze("Sheet1"))
+	ws, err = f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	sheetView = ws.SheetViews.SheetView[len(ws.SheetViews.SheetView)-1]
+	assert.Nil(t, sheetView.Pane)
+	assert.Nil(t, sheetView.Selection)
+	// Test freeze panes on not exists worksheet
+	assert.EqualError(t, f.FreezeTopRow("SheetN"), "sheet SheetN is not exist")
+	assert.EqualError(t, f.FreezeFirstColumn("SheetN"), "sheet SheetN is not exist")
+	assert.EqualError(t, f.Unfreeze("SheetN"), "sheet SheetN is not exist")
+}
+
+func TestSplitPanes(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SplitPanes("Sheet1", 200, 100, "D10"))
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	sheetView := ws.SheetViews.SheetView[len(ws.SheetViews.SheetView)-1]
+	assert.Equal(t, &xlsxPane{XSplit: 3000, YSplit: 1500, TopLeftCell: "D10", ActivePane: "bottomRight", State: "split"}, sheetView.Pane)
+	assert.Nil(t, sheetView.Selection)
+	assert.NoError(t, f.SplitPanes("Sheet1", 0, 100, ""))
+	sheetView = ws.SheetViews.SheetView[len(ws.SheetViews.SheetView)-1]
+	assert.Equal(t, &xlsxPane{YSplit: 1500, ActivePane: "bottomLeft", State: "split"}, sheetView.Pane)
+	assert.NoError(t, f.SplitPanes("Sheet1", 200, 0, "C1"))
+	sheetView = ws.SheetViews.SheetView[len(ws.SheetViews.SheetView)-1]
+	assert.Equal(t, &xlsxPane{XSplit: 3000, TopLeftCell: "C1", ActivePane: "topRight", State: "split"}, sheetView.Pane)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSplitPanes.xlsx")))
+
+	// Test split panes with invalid settings
+	assert.EqualError(t, f.SplitPanes("Sheet1", 0, 0, "A1"), ErrPaneSplitPosition.Error())
+	assert.EqualError(t, f.SplitPanes("Sheet1", -1, 100, "A1"), ErrPaneSplitPosition.Error())
+	assert.EqualError(t, f.SplitPanes("Sheet1", 200, 100, "A"), newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
+	assert.EqualError(t, f.SetPanes("Sheet1", `{"split":true,"x_split":3000,"active_pane":"bottomLeft"}`), ErrActivePane.Error())
+	assert.EqualError(t, f.SetPanes("Sheet1", `{"split":true,"y_split":3000,"active_pane":"bottomLeft","panes":[{"sqref":"A1","active_cell":"A1","pane":"topRight"}]}`), ErrActivePane.Error())
+	assert.EqualError(t, f.SplitPanes("SheetN", 200, 100, "D10"), "sheet SheetN is not exist")
+}
+
 func TestPageLayoutOption(t *testing.T) {
 	const sheet = "Sheet1"
 
@@ -123,6 +176,8 @@ func TestPageLayoutOption(t *testing.T) {
 		{new(FirstPageNumber), FirstPageNumber(2)},
 		{new(PageLayoutOrientation), PageLayoutOrientation(OrientationLandscape)},
 		{new(PageLayoutPaperSize), PageLayoutPaperSize(10)},
+		{new(PageLayoutPaperSizeName), PageLayoutPaperSizeName("A4")},
+		{new(PageLayoutPrintQuality), PageLayoutPrintQuality(300)},
 		{new(FitToHeight), FitToHeight(2)},
 		{new(FitToWidth), FitToWidth(2)},
 		{new(PageLayoutScale), PageLayoutScale(50)},
@@ -222,6 +277,39 @@ func TestSearchSheet(t *testing.T) {
 	assert.Equal(t, []string(nil), result)
 }
 
+func TestPageLayoutPaperSizeName(t *testing.T) {
+	f := NewFile()
+	var (
+		paperSize     PageLayoutPaperSize
+		paperSizeName PageLayoutPaperSizeName
+		printQuality  PageLayoutPrintQuality
+	)
+	assert.NoError(t, f.SetPageLayout("Sheet1", PageLayoutPaperSizeName("legal"), PageLayoutPrintQuality(300)))
+	assert.NoError(t, f.GetPageLayout("Sheet1", &paperSize, &paperSizeName, &printQuality))
+	assert.Equal(t, PageLayoutPaperSize(5), paperSize)
+	assert.Equal(t, PageLayoutPaperSizeName("Legal"), paperSizeName)
+	assert.Equal(t, PageLayoutPrintQuality(300), printQuality)
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "300", ws.PageSetUp.VerticalDPI)
+	// Test set the unknown paper size name
+	assert.NoError(t, f.SetPageLayout("Sheet1", PageLayoutPaperSizeName("Unknown")))
+	assert.NoError(t, f.GetPageLayout("Sheet1", &paperSizeName))
+	assert.Equal(t, PageLayoutPaperSizeName("Legal"), paperSizeName)
+	// Test get the name of the paper size which has no name
+	assert.NoError(t, f.SetPageLayout("Sheet1", PageLayoutPaperSize(90), PageLayoutPrintQuality(0)))
+	assert.NoError(t, f.GetPageLayout("Sheet1", &paperSizeName, &printQuality))
+	assert.Equal(t, PageLayoutPaperSizeName(""), paperSizeName)
+	assert.Equal(t, PageLayoutPrintQuality(600), printQuality)
+	assert.Empty(t, ws.PageSetUp.HorizontalDPI)
+	// Test the names of the paper size are unique
+	sizes := make(map[int]bool)
+	for _, size := range pageLayoutPaperSizeNames {
+		assert.False(t, sizes[size])
+		sizes[size] = true
+	}
+}
+
 func TestSetPageLayout(t *testing.T) {
 	f := NewFile()
 	// Test set page layout on not exists worksheet.
@@ -243,6 +331,9 @@ func TestSetHeaderFooter(t *testing.T) {
 	assert.EqualError(t, f.SetHeaderFooter("Sheet1", &FormatHeaderFooter{
 		OddHeader: strings.Repeat("c", MaxFieldLength+1),
 	}), newFieldLengthError("OddHeader").Error())
+	assert.EqualError(t, f.SetHeaderFooter("Sheet1", &FormatHeaderFooter{
+		FirstFooter: strings.Repeat("c", MaxFieldLength+1),
+	}), newFieldLengthError("FirstFooter").Error())
 
 	assert.NoError(t, f.SetHeaderFooter("Sheet1", nil))
 	text := strings.Repeat("一", MaxFieldLength)
@@ -262,6 +353,23 @@ func TestSetHeaderFooter(t *testing.T) {
 		EvenFooter:       "&L&D&R&T",
 		FirstHeader:      `&CCenter &"-,Bold"Bold&"-,Regular"HeaderU+000A&D`,
 	}))
+	// Test set header and footer with scale with document and align with margins
+	for _, c := range []struct {
+		settings *FormatHeaderFooter
+		expected string
+	}{
+		{&FormatHeaderFooter{OddHeader: "&C&G"}, `<headerFooter><oddHeader>`},
+		{&FormatHeaderFooter{ScaleWithDoc: true, NoAlignWithMargins: true, OddHeader: "&C&G"}, `<headerFooter scaleWithDoc="true" alignWithMargins="false">`},
+		{&FormatHeaderFooter{NoScaleWithDoc: true, OddHeader: "&C&G"}, `<headerFooter scaleWithDoc="false">`},
+		{&FormatHeaderFooter{ScaleWithDoc: true, NoScaleWithDoc: true, AlignWithMargins: true, OddHeader: "&C&G"}, `<headerFooter scaleWithDoc="false" alignWithMargins="true">`},
+	} {
+		assert.NoError(t, f.SetHeaderFooter("Sheet1", c.settings))
+		ws, err := f.workSheetReader("Sheet1")
+		assert.NoError(t, err)
+		output, err := xml.Marshal(ws.HeaderFooter)
+		assert.NoError(t, err)
+		assert.Contains(t, string(output), c.expected)
+	}
 	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetHeaderFooter.xlsx")))
 }
 
@@ -295,6 +403,101 @@ func TestDefinedName(t *testing.T) {
 	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestDefinedName.xlsx")))
 }
 
+func TestSetDefinedNames(t *testing.T) {
+	f := NewFile()
+	f.NewSheet("Sheet 2")
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "Existing", RefersTo: "Sheet1!$A$1"}))
+	assert.NoError(t, f.SetDefinedNames(nil))
+	assert.NoError(t, f.SetDefinedNames([]DefinedName{
+		{Name: "Amount", RefersTo: "Sheet1!$A$2:$D$5", Comment: "comment"},
+		{Name: "Amount", RefersTo: "'Sheet 2'!$B$1", Scope: "Sheet 2"},
+		{Name: "_Rate.Year", RefersTo: "=SUM(Sheet1!$A:$A,Sheet1!1:2)/2", Scope: "Workbook"},
+		{Name: "Text", RefersTo: `"a(b"&"'c"`},
+		{Name: "_xlnm.Print_Area", RefersTo: "Sheet1!$A$1:$B$2", Scope: "Sheet1"},
+	}))
+	assert.Equal(t, []DefinedName{
+		{Name: "Existing", RefersTo: "Sheet1!$A$1", Scope: "Workbook"},
+		{Name: "Amount", RefersTo: "Sheet1!$A$2:$D$5", Comment: "comment", Scope: "Workbook"},
+		{Name: "Amount", RefersTo: "'Sheet 2'!$B$1", Scope: "Sheet 2"},
+		{Name: "_Rate.Year", RefersTo: "SUM(Sheet1!$A:$A,Sheet1!1:2)/2", Scope: "Workbook"},
+		{Name: "Text", RefersTo: `"a(b"&"'c"`, Scope: "Workbook"},
+		{Name: "_xlnm.Print_Area", RefersTo: "Sheet1!$A$1:$B$2", Scope: "Sheet1"},
+	}, f.GetDefinedName())
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetDefinedNames.xlsx")))
+
+	// Test set defined names with invalid names, scopes and references
+	for _, c := range []struct {
+		definedName DefinedName
+		err         error
+	}{
+		{DefinedName{Name: "", RefersTo: "Sheet1!$A$1"}, ErrDefinedNameInvalid},
+		{DefinedName{Name: "1Name", RefersTo: "Sheet1!$A$1"}, ErrDefinedNameInvalid},
+		{DefinedName{Name: "My Name", RefersTo: "Sheet1!$A$1"}, ErrDefinedNameInvalid},
+		{DefinedName{Name: "A1", RefersTo: "Sheet1!$A$1"}, ErrDefinedNameInvalid},
+		{DefinedName{Name: "r", RefersTo: "Sheet1!$A$1"}, ErrDefinedNameInvalid},
+		{DefinedName{Name: "R1C1", RefersTo: "Sheet1!$A$1"}, ErrDefinedNameInvalid},
+		{DefinedName{Name: strings.Repeat("a", 256), RefersTo: "Sheet1!$A$1"}, ErrDefinedNameInvalid},
+		{DefinedName{Name: "Name", RefersTo: "Sheet1!$A$1", Scope: "SheetN"}, ErrSheetNotExist{"SheetN"}},
+		{DefinedName{Name: "existing", RefersTo: "Sheet1!$A$1"}, ErrDefinedNameDuplicate},
+		{DefinedName{Name: "Name", RefersTo: ""}, ErrDefinedNameRefersTo},
+		{DefinedName{Name: "Name", RefersTo: "="}, ErrDefinedNameRefersTo},
+		{DefinedName{Name: "Name", RefersTo: "SUM(Sheet1!$A$1"}, ErrDefinedNameRefersTo},
+		{DefinedName{Name: "Name", RefersTo: "SUM(Sheet1!$A$1))"}, ErrDefinedNameRefersTo},
+		{DefinedName{Name: "Name", RefersTo: `"text`}, ErrDefinedNameRefersTo},
+		{DefinedName{Name: "Name", RefersTo: "'Sheet 2!$A$1"}, ErrDefinedNameRefersTo},
+		{DefinedName{Name: "Name", RefersTo: "Sheet1!$A$1+"}, ErrDefinedNameRefersTo},
+		{DefinedName{Name: "Name", RefersTo: "Sheet1!#REF!"}, ErrDefinedNameRefersTo},
+		{DefinedName{Name: "Name", RefersTo: "Sheet1!$A$0"}, ErrDefinedNameRefersTo},
+		{DefinedName{Name: "Name", RefersTo: "Sheet1!A1:B2:C3"}, ErrDefinedNameRefersTo},
+		{DefinedName{Name: "Name", RefersTo: "Sheet1!$XFE:$XFE"}, ErrDefinedNameRefersTo},
+		{DefinedName{Name: "Name", RefersTo: "SheetN!$A$1"}, ErrSheetNotExist{"SheetN"}},
+	} {
+		assert.EqualError(t, f.SetDefinedNames([]DefinedName{c.definedName}),
+			newDefinedNameError(c.definedName.Name, c.err).Error(), c.definedName)
+	}
+	// Test set defined names atomically with the duplicate name in the batch
+	assert.EqualError(t, f.SetDefinedNames([]DefinedName{
+		{Name: "Valid", RefersTo: "Sheet1!$C$1"},
+		{Name: "VALID", RefersTo: "Sheet1!$C$2"},
+	}), newDefinedNameError("VALID", ErrDefinedNameDuplicate).Error())
+	assert.Len(t, f.GetDefinedName(), 6)
+	// Test set defined names on the workbook without defined names
+	f = NewFile()
+	assert.NoError(t, f.SetDefinedNames([]DefinedName{{Name: "Name", RefersTo: "[1]Sheet1!$A$1"}}))
+	assert.Len(t, f.GetDefinedName(), 1)
+}
+
+func TestResolveDefinedName(t *testing.T) {
+	f := NewFile()
+	f.NewSheet("Sheet2")
+	f.NewSheet("Sheet3")
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "Amount", RefersTo: "Sheet1!$A$1:$A$5"}))
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "Amount", RefersTo: "Sheet2!$B$1:$B$5", Scope: "Sheet2"}))
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "Rate", RefersTo: "Sheet3!$C$1", Scope: "Sheet3"}))
+	for _, c := range []struct {
+		name, sheet, refersTo string
+	}{
+		{"Amount", "Sheet1", "Sheet1!$A$1:$A$5"},
+		{"Amount", "Sheet2", "Sheet2!$B$1:$B$5"},
+		{"amount", "sheet2", "Sheet2!$B$1:$B$5"},
+		{"Amount", "Sheet3", "Sheet1!$A$1:$A$5"},
+		{"Amount", "", "Sheet1!$A$1:$A$5"},
+		{"Rate", "Sheet3", "Sheet3!$C$1"},
+	} {
+		refersTo, err := f.ResolveDefinedName(c.name, c.sheet)
+		assert.NoError(t, err)
+		assert.Equal(t, c.refersTo, refersTo, c)
+	}
+	// Test resolve sheet scoped name from another scope
+	_, err := f.ResolveDefinedName("Rate", "Sheet1")
+	assert.EqualError(t, err, ErrDefinedNameScope.Error())
+	_, err = f.ResolveDefinedName("Rate", "")
+	assert.EqualError(t, err, ErrDefinedNameScope.Error())
+	// Test resolve defined name on not exists worksheet
+	_, err = f.ResolveDefinedName("Amount", "SheetN")
+	assert.EqualError(t, err, "sheet SheetN is not exist")
+}
+
 func TestGroupSheets(t *testing.T) {
 	f := NewFile()
 	sheets := []string{"Sheet2", "Sheet3"}
@@ -353,6 +556,165 @@ func TestRemovePageBreak(t *testing.T) {
 	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestRemovePageBreak.xlsx")))
 }
 
+func TestSetSheetDimension(t *testing.T) {
+	f := NewFile()
+	ref, err := f.GetSheetDimension("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "A1", ref)
+	for _, c := range []struct{ ref, expected string }{
+		{"A1:D5", "A1:D5"},
+		{"$D$5:$A$1", "A1:D5"},
+		{"B3", "B3"},
+		{"d5:a1", "A1:D5"},
+	} {
+		assert.NoError(t, f.SetSheetDimension("Sheet1", c.ref))
+		ref, err = f.GetSheetDimension("Sheet1")
+		assert.NoError(t, err)
+		assert.Equal(t, c.expected, ref)
+	}
+	// Test get the dimension after reopening the workbook
+	buf, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+	f, err = OpenReader(buf)
+	assert.NoError(t, err)
+	ref, err = f.GetSheetDimension("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "A1:D5", ref)
+	// Test remove the dimension of the worksheet
+	assert.NoError(t, f.SetSheetDimension("Sheet1", ""))
+	ref, err = f.GetSheetDimension("Sheet1")
+	assert.NoError(t, err)
+	assert.Empty(t, ref)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetSheetDimension.xlsx")))
+	// Test set the dimension with invalid range reference
+	assert.EqualError(t, f.SetSheetDimension("Sheet1", "A1:B2:C3"), ErrParameterInvalid.Error())
+	assert.EqualError(t, f.SetSheetDimension("Sheet1", "A"), newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
+	assert.EqualError(t, f.SetSheetDimension("Sheet1", "A1:B"), newCellNameToCoordinatesError("B", newInvalidCellNameError("B")).Error())
+	// Test set and get the dimension on not exists worksheet
+	assert.EqualError(t, f.SetSheetDimension("SheetN", "A1"), "sheet SheetN is not exist")
+	_, err = f.GetSheetDimension("SheetN")
+	assert.EqualError(t, err, "sheet SheetN is not exist")
+	assert.NoError(t, f.Close())
+}
+
+func TestAddIgnoredError(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.AddIgnoredError("Sheet1", "A1:A100", IgnoredErrorTypes{NumberStoredAsText: true}))
+	assert.NoError(t, f.AddIgnoredError("Sheet1", "A1:A100", IgnoredErrorTypes{TwoDigitTextYear: true}))
+	assert.NoError(t, f.AddIgnoredError("Sheet1", " C1  D2:E3 ", IgnoredErrorTypes{EvalError: true, Formula: true}))
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []*xlsxIgnoredError{
+		{Sqref: "A1:A100", NumberStoredAsText: true, TwoDigitTextYear: true},
+		{Sqref: "C1 D2:E3", EvalError: true, Formula: true},
+	}, ws.IgnoredErrors.IgnoredError)
+	// Test preserve the ignored errors after reopening the workbook
+	buf, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+	f, err = OpenReader(buf)
+	assert.NoError(t, err)
+	ws, err = f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []*xlsxIgnoredError{
+		{Sqref: "A1:A100", NumberStoredAsText: true, TwoDigitTextYear: true},
+		{Sqref: "C1 D2:E3", EvalError: true, Formula: true},
+	}, ws.IgnoredErrors.IgnoredError)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddIgnoredError.xlsx")))
+	// Test add ignored error with invalid parameters
+	assert.EqualError(t, f.AddIgnoredError("Sheet1", "A1", IgnoredErrorTypes{}), ErrParameterInvalid.Error())
+	assert.EqualError(t, f.AddIgnoredError("Sheet1", " ", IgnoredErrorTypes{Formula: true}), ErrParameterInvalid.Error())
+	assert.EqualError(t, f.AddIgnoredError("Sheet1", "A", IgnoredErrorTypes{Formula: true}), newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
+	assert.EqualError(t, f.AddIgnoredError("Sheet1", "A1:B", IgnoredErrorTypes{Formula: true}), newCellNameToCoordinatesError("B", newInvalidCellNameError("B")).Error())
+	assert.EqualError(t, f.AddIgnoredError("SheetN", "A1", IgnoredErrorTypes{Formula: true}), "sheet SheetN is not exist")
+	assert.NoError(t, f.Close())
+}
+
+func TestSetSheetDefaultStyle(t *testing.T) {
+	f := NewFile()
+	style, err := f.NewStyle(&Style{Font: &Font{Family: "Arial", Size: 10}})
+	assert.NoError(t, err)
+	rowStyle, err := f.NewStyle(&Style{Font: &Font{Bold: true}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetColWidth("Sheet1", "B", "B", 20))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", "value"))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A2", "B2", 0))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A2", "A2", rowStyle))
+	assert.NoError(t, f.SetRowStyle("Sheet1", 3, 3, rowStyle))
+	assert.NoError(t, f.SetSheetDefaultStyle("Sheet1", style))
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []xlsxCol{
+		{Min: 1, Max: 1, Width: defaultColWidth, Style: style},
+		{Min: 2, Max: 2, Width: 20, CustomWidth: true, Style: style},
+		{Min: 3, Max: MaxColumns, Width: defaultColWidth, Style: style},
+	}, ws.Cols.Col)
+	assert.NotNil(t, ws.SheetFormatPr)
+	assert.Equal(t, 0, ws.SheetData.Row[0].C[0].S)
+	assert.Equal(t, rowStyle, ws.SheetData.Row[1].C[0].S)
+	assert.Equal(t, style, ws.SheetData.Row[1].C[1].S)
+	assert.Equal(t, style, ws.SheetData.Row[2].S)
+	for _, cell := range []string{"B2", "A3", "D10"} {
+		styleID, err := f.GetCellStyle("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, style, styleID, cell)
+	}
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetSheetDefaultStyle.xlsx")))
+	// Test set the default style without the worksheet formatting properties
+	ws, err = f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	ws.SheetFormatPr, ws.Cols = nil, nil
+	assert.NoError(t, f.SetSheetDefaultStyle("Sheet1", style))
+	assert.Equal(t, defaultRowHeight, ws.SheetFormatPr.DefaultRowHeight)
+	assert.Equal(t, []xlsxCol{{Min: 1, Max: MaxColumns, Width: defaultColWidth, Style: style}}, ws.Cols.Col)
+	// Test set the default style with invalid parameters
+	assert.EqualError(t, f.SetSheetDefaultStyle("Sheet1", -1), newInvalidStyleID(-1).Error())
+	assert.EqualError(t, f.SetSheetDefaultStyle("Sheet1", 100), newInvalidStyleID(100).Error())
+	assert.EqualError(t, f.SetSheetDefaultStyle("SheetN", style), "sheet SheetN is not exist")
+	assert.NoError(t, f.Close())
+}
+
+func TestRepairSheetNames(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.CheckSheetNameUniqueness())
+	assert.Nil(t, f.RepairSheetNames())
+	longName := strings.Repeat("a", MaxSheetNameLength)
+	for idx, name := range []string{"Sheet2", "Sheet3", "Sheet4", "Sheet5", "Sheet6"} {
+		f.NewSheet(name)
+		assert.NoError(t, f.SetCellValue(name, "A1", idx+2))
+	}
+	for idx, name := range []string{"Data", "data", "Data (2)", "Data", longName, longName} {
+		f.WorkBook.Sheets.Sheet[idx].Name = name
+	}
+	assert.EqualError(t, f.CheckSheetNameUniqueness(), newDuplicateSheetNameError("data").Error())
+	buf, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+	// Test open the spreadsheet without repair the duplicate sheet names
+	f, err = OpenReader(bytes.NewReader(buf.Bytes()))
+	assert.NoError(t, err)
+	assert.EqualError(t, f.CheckSheetNameUniqueness(), newDuplicateSheetNameError("data").Error())
+	// Test open the spreadsheet with repair the duplicate sheet names
+	f, err = OpenReader(buf, Options{RepairSheetNames: true})
+	assert.NoError(t, err)
+	assert.NoError(t, f.CheckSheetNameUniqueness())
+	longNameRepaired := strings.Repeat("a", MaxSheetNameLength-4) + " (2)"
+	expected := []SheetNameRepair{
+		{Index: 1, OldName: "data", NewName: "data (3)"},
+		{Index: 3, OldName: "Data", NewName: "Data (4)"},
+		{Index: 5, OldName: longName, NewName: longNameRepaired},
+	}
+	assert.Equal(t, expected, f.RepairSheetNames())
+	assert.Equal(t, []string{"Data", "data (3)", "Data (2)", "Data (4)", longName, longNameRepaired}, f.GetSheetList())
+	for idx, name := range f.GetSheetList() {
+		val, err := f.GetCellValue(name, "A1")
+		assert.NoError(t, err)
+		if idx > 0 {
+			assert.Equal(t, strconv.Itoa(idx+1), val)
+		}
+	}
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestRepairSheetNames.xlsx")))
+}
+
 func TestGetSheetName(t *testing.T) {
 	f, err := OpenFile(filepath.Join("test", "Book1.xlsx"))
 	assert.NoError(t, err)
@@ -378,6 +740,58 @@ func TestGetSheetMap(t *testing.T) {
 	assert.NoError(t, f.Close())
 }
 
+func TestGetSheetInfo(t *testing.T) {
+	f := NewFile()
+	for _, sheet := range []string{"Sheet2", "Sheet3", "Sheet4", "Sheet5"} {
+		f.NewSheet(sheet)
+	}
+	assert.NoError(t, f.SetSheetPrOptions("Sheet1", TabColorRGB("#ff0000")))
+	assert.NoError(t, f.SetSheetPrOptions("Sheet2", TabColorTheme(4)))
+	assert.NoError(t, f.SetSheetPrOptions("Sheet3", TabColorIndexed(17), TabColorTint(0.4)))
+	assert.NoError(t, f.SetSheetPrOptions("Sheet4", TabColorTheme(1)))
+	assert.NoError(t, f.SetSheetVisible("Sheet4", false))
+	assert.NoError(t, f.AddChartSheet("Chart1", `{"type":"col","series":[{"name":"Sheet1!$A$1","values":"Sheet1!$B$1:$B$2"}]}`))
+	chartSheetPath, ok := f.getSheetXMLPath("Chart1")
+	assert.True(t, ok)
+	f.Pkg.Store(chartSheetPath, bytes.Replace(f.readXML(chartSheetPath),
+		[]byte("<sheetViews>"), []byte(`<sheetPr><tabColor rgb="FF00B050"/></sheetPr><sheetViews>`), 1))
+	sheets, err := f.GetSheetInfo()
+	assert.NoError(t, err)
+	assert.Equal(t, []SheetInfo{
+		{Index: 0, Name: "Sheet1", Visibility: "visible", TabColor: "FF0000"},
+		{Index: 1, Name: "Sheet2", Visibility: "visible", TabColor: "5B9BD5"},
+		{Index: 2, Name: "Sheet3", Visibility: "visible", TabColor: ThemeColor("008000", 0.4)[2:]},
+		{Index: 3, Name: "Sheet4", Visibility: "hidden", TabColor: "000000"},
+		{Index: 4, Name: "Sheet5", Visibility: "visible", TabColor: ""},
+		{Index: 5, Name: "Chart1", Visibility: "visible", TabColor: "00B050"},
+	}, sheets)
+
+	// Test resolve tab color with automatic, invalid and theme colors
+	assert.Equal(t, "", f.getTabColorRGB(&xlsxTabColor{Auto: true, RGB: "FFFF0000"}))
+	assert.Equal(t, "", f.getTabColorRGB(&xlsxTabColor{Indexed: intPtr(100)}))
+	assert.Equal(t, "000000", f.getTabColorRGB(&xlsxTabColor{Indexed: intPtr(0), Theme: 3}))
+	assert.Equal(t, "FFFFFF", f.getTabColorRGB(&xlsxTabColor{}))
+	assert.Equal(t, "44546A", f.getTabColorRGB(&xlsxTabColor{Theme: 3}))
+	assert.Equal(t, "", f.getTabColorRGB(&xlsxTabColor{Theme: 12}))
+	f.Theme = nil
+	assert.Equal(t, "", f.getTabColorRGB(&xlsxTabColor{Theme: 4}))
+
+	// Test get sheet info with unsupported charset worksheet
+	f.Sheet.Delete("xl/worksheets/sheet1.xml")
+	f.Pkg.Store("xl/worksheets/sheet1.xml", MacintoshCyrillicCharset)
+	f.checked = nil
+	_, err = f.GetSheetInfo()
+	assert.EqualError(t, err, "xml decode error: XML syntax error on line 1: invalid UTF-8")
+	// Test get sheet info with unsupported charset chart sheet
+	f = NewFile()
+	assert.NoError(t, f.AddChartSheet("Chart1", `{"type":"col","series":[{"name":"Sheet1!$A$1","values":"Sheet1!$B$1:$B$2"}]}`))
+	f.Pkg.Store("xl/chartsheets/sheet2.xml", MacintoshCyrillicCharset)
+	_, err = f.GetSheetInfo()
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	_, err = f.getSheetTabColor("SheetN")
+	assert.EqualError(t, err, "sheet SheetN is not exist")
+}
+
 func TestSetActiveSheet(t *testing.T) {
 	f := NewFile()
 	f.WorkBook.BookViews = nil
@@ -404,6 +818,40 @@ func TestSetActiveSheet(t *testing.T) {
 	f.SetActiveSheet(idx)
 }
 
+func TestSetSheetsSelected(t *testing.T) {
+	f := NewFile()
+	f.NewSheet("Sheet2")
+	f.NewSheet("Sheet3")
+	f.NewSheet("Sheet4")
+	assert.NoError(t, f.AddChartSheet("Chart1", `{"type":"col","series":[{"name":"Sheet1!$A$1","categories":"Sheet1!$A$2","values":"Sheet1!$B$2"}]}`))
+	ws, err := f.workSheetReader("Sheet4")
+	assert.NoError(t, err)
+	ws.SheetViews = nil
+	assert.NoError(t, f.SetSheetsSelected([]string{"Sheet3", "sheet2"}))
+	assert.Equal(t, "Sheet3", f.GetActiveSheetName())
+	check := func(f *File) {
+		for sheet, selected := range map[string]bool{"Sheet1": false, "Sheet2": true, "Sheet3": true, "Sheet4": false} {
+			ws, err := f.workSheetReader(sheet)
+			assert.NoError(t, err)
+			assert.Equal(t, selected, ws.SheetViews != nil && ws.SheetViews.SheetView[0].TabSelected, sheet)
+		}
+	}
+	check(f)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetSheetsSelected.xlsx")))
+	f, err = OpenFile(filepath.Join("test", "TestSetSheetsSelected.xlsx"))
+	assert.NoError(t, err)
+	check(f)
+	assert.Equal(t, "Sheet3", f.GetActiveSheetName())
+	// Test select sheets with empty list
+	assert.EqualError(t, f.SetSheetsSelected(nil), ErrParameterRequired.Error())
+	// Test select sheets with not exist worksheet
+	assert.EqualError(t, f.SetSheetsSelected([]string{"Sheet1", "SheetN"}), "sheet SheetN is not exist")
+	// Test select chart sheet
+	assert.EqualError(t, f.SetSheetsSelected([]string{"Chart1"}), "sheet Chart1 is not a worksheet")
+	check(f)
+	assert.NoError(t, f.Close())
+}
+
 func TestSetSheetName(t *testing.T) {
 	f := NewFile()
 	// Test set worksheet with the same name.
@@ -11,7 +11,11 @@
 
 package excelize
 
-import "strings"
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
 
 // SheetPrOption is an option of a view of a worksheet. See SetSheetPrOptions().
 type SheetPrOption interface {
@@ -141,17 +145,17 @@ func (o TabColorIndexed) setSheetPrOption(pr *xlsxSheetPr) {
 	if pr.TabColor == nil {
 		pr.TabColor = new(xlsxTabColor)
 	}
-	pr.TabColor.Indexed = int(o)
+	pr.TabColor.Indexed = intPtr(int(o))
 }
 
 // getSheetPrOption implements the SheetPrOptionPtr interface and gets the
 // TabColor Indexed. Defaults to -1 if no indexed has been set.
 func (o *TabColorIndexed) getSheetPrOption(pr *xlsxSheetPr) {
-	if pr == nil || pr.TabColor == nil {
+	if pr == nil || pr.TabColor == nil || pr.TabColor.Indexed == nil {
 		*o = TabColorIndexed(ColorMappingTypeUnset)
 		return
 	}
-	*o = TabColorIndexed(pr.TabColor.Indexed)
+	*o = TabColorIndexed(*pr.TabColor.Indexed)
 }
 
 // setSheetPrOption implements the SheetPrOption interface and specifies a
@@ -293,6 +297,66 @@ func (f *File) GetSheetPrOptions(sheet string, opts ...SheetPrOptionPtr) error {
 	return err
 }
 
+// SetSheetCodeName provides a function to set the code name of the worksheet
+// by given worksheet name and code name. The code name is used by the VBA
+// project to reference the worksheet, which is not changed when the worksheet
+// has been renamed. The code name must start with a letter, contain only
+// letters, numbers and underscores, not exceed 31 characters and be unique in
+// the workbook. Set the code name as an empty string to remove it. This
+// function sets the CodeName option by SetSheetPrOptions after validating
+// the code name. For example, set the code name of the worksheet named
+// Sheet1:
+//
+//	err := f.SetSheetCodeName("Sheet1", "SummarySheet")
+func (f *File) SetSheetCodeName(sheet, codeName string) error {
+	if _, err := f.workSheetReader(sheet); err != nil {
+		return err
+	}
+	if codeName != "" {
+		if !isValidCodeName(codeName) {
+			return ErrSheetCodeName
+		}
+		for _, name := range f.GetSheetList() {
+			if strings.EqualFold(name, trimSheetName(sheet)) {
+				continue
+			}
+			var other CodeName
+			if err := f.GetSheetPrOptions(name, &other); err != nil {
+				// Chartsheet, macrosheet or dialogsheet
+				continue
+			}
+			if strings.EqualFold(string(other), codeName) {
+				return ErrSheetCodeNameDuplicate
+			}
+		}
+	}
+	return f.SetSheetPrOptions(sheet, CodeName(codeName))
+}
+
+// GetSheetCodeName provides a function to get the code name of the worksheet
+// by given worksheet name, the empty string will be returned if the
+// worksheet has no code name.
+func (f *File) GetSheetCodeName(sheet string) (string, error) {
+	var codeName CodeName
+	err := f.GetSheetPrOptions(sheet, &codeName)
+	return string(codeName), err
+}
+
+// isValidCodeName provides a function to check if the given code name is a
+// valid VBA identifier.
+func isValidCodeName(codeName string) bool {
+	if utf8.RuneCountInString(codeName) > 31 {
+		return false
+	}
+	for i, r := range codeName {
+		if unicode.IsLetter(r) || (i > 0 && (unicode.IsDigit(r) || r == '_')) {
+			continue
+		}
+		return false
+	}
+	return true
+}
+
 type (
 	// PageMarginBottom specifies the bottom margin for the page.
 	PageMarginBottom float64
@@ -461,6 +525,81 @@ func (f *File) GetPageMargins(sheet string, opts ...PageMarginsOptionsPtr) error
 	return err
 }
 
+// pageMarginsUnits defined the number of the units per inch of the supported
+// units of the page margins.
+var pageMarginsUnits = map[string]float64{"in": 1, "cm": 2.54, "mm": 25.4}
+
+// SetPageMarginsMetric provides a function to set worksheet page margins in
+// the given unit, the margins will be converted to the inches which stored in
+// the workbook. The supported units are "cm" (centimeters), "mm"
+// (millimeters) and "in" (inches), and the options are the same as the
+// SetPageMargins function. For example, set the left and right margins of
+// Sheet1 to 2 centimeters:
+//
+//	err := f.SetPageMarginsMetric("Sheet1", "cm",
+//	    excelize.PageMarginLeft(2),
+//	    excelize.PageMarginRight(2),
+//	)
+func (f *File) SetPageMarginsMetric(sheet, unit string, opts ...PageMarginsOptions) error {
+	ratio, ok := pageMarginsUnits[unit]
+	if !ok {
+		return ErrPageMarginsUnit
+	}
+	options := make([]PageMarginsOptions, 0, len(opts))
+	for _, opt := range opts {
+		switch o := opt.(type) {
+		case PageMarginBottom:
+			opt = PageMarginBottom(float64(o) / ratio)
+		case PageMarginFooter:
+			opt = PageMarginFooter(float64(o) / ratio)
+		case PageMarginHeader:
+			opt = PageMarginHeader(float64(o) / ratio)
+		case PageMarginLeft:
+			opt = PageMarginLeft(float64(o) / ratio)
+		case PageMarginRight:
+			opt = PageMarginRight(float64(o) / ratio)
+		case PageMarginTop:
+			opt = PageMarginTop(float64(o) / ratio)
+		}
+		options = append(options, opt)
+	}
+	return f.SetPageMargins(sheet, options...)
+}
+
+// GetPageMarginsMetric provides a function to get worksheet page margins in
+// the given unit. The supported units and the options are the same as the
+// SetPageMarginsMetric function. For example, get the left margin of Sheet1
+// in millimeters:
+//
+//	var left excelize.PageMarginLeft
+//	err := f.GetPageMarginsMetric("Sheet1", "mm", &left)
+func (f *File) GetPageMarginsMetric(sheet, unit string, opts ...PageMarginsOptionsPtr) error {
+	ratio, ok := pageMarginsUnits[unit]
+	if !ok {
+		return ErrPageMarginsUnit
+	}
+	if err := f.GetPageMargins(sheet, opts...); err != nil {
+		return err
+	}
+	for _, opt := range opts {
+		switch o := opt.(type) {
+		case *PageMarginBottom:
+			*o = PageMarginBottom(float64(*o) * ratio)
+		case *PageMarginFooter:
+			*o = PageMarginFooter(float64(*o) * ratio)
+		case *PageMarginHeader:
+			*o = PageMarginHeader(float64(*o) * ratio)
+		case *PageMarginLeft:
+			*o = PageMarginLeft(float64(*o) * ratio)
+		case *PageMarginRight:
+			*o = PageMarginRight(float64(*o) * ratio)
+		case *PageMarginTop:
+			*o = PageMarginTop(float64(*o) * ratio)
+		}
+	}
+	return nil
+}
+
 // SheetFormatPrOptions is an option of the formatting properties of a
 // worksheet. See SetSheetFormatPr().
 type SheetFormatPrOptions interface {
@@ -617,6 +756,18 @@ func (p *ThickBottom) getSheetFormatPr(fp *xlsxSheetFormatPr) {
 //	ZeroHeight(bool)
 //	ThickTop(bool)
 //	ThickBottom(bool)
+//
+// The DefaultColWidth will be used as the width of the columns without the
+// custom width, and the DefaultRowHeight will be used as the height of the
+// rows without the custom height when the CustomHeight is true. Set the
+// ZeroHeight to hide all rows by default. For example, set the default
+// column width to 20 and the default row height to 25 points on Sheet1:
+//
+//	err := f.SetSheetFormatPr("Sheet1",
+//	    excelize.DefaultColWidth(20),
+//	    excelize.DefaultRowHeight(25),
+//	    excelize.CustomHeight(true),
+//	)
 func (f *File) SetSheetFormatPr(sheet string, opts ...SheetFormatPrOptions) error {
 	s, err := f.workSheetReader(sheet)
 	if err != nil {
@@ -644,6 +795,14 @@ func (f *File) SetSheetFormatPr(sheet string, opts ...SheetFormatPrOptions) erro
 //	ZeroHeight(bool)
 //	ThickTop(bool)
 //	ThickBottom(bool)
+//
+// For example, get the default column width and row height of Sheet1:
+//
+//	var (
+//	    colWidth  excelize.DefaultColWidth
+//	    rowHeight excelize.DefaultRowHeight
+//	)
+//	err := f.GetSheetFormatPr("Sheet1", &colWidth, &rowHeight)
 func (f *File) GetSheetFormatPr(sheet string, opts ...SheetFormatPrOptionsPtr) error {
 	s, err := f.workSheetReader(sheet)
 	if err != nil {
@@ -2,6 +2,8 @@ package excelize
 
 import (
 	"fmt"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/mohae/deepcopy"
@@ -183,6 +185,42 @@ func TestSetSheetPrOptions(t *testing.T) {
 	assert.EqualError(t, f.SetSheetPrOptions("SheetN"), "sheet SheetN is not exist")
 }
 
+func TestSheetCodeName(t *testing.T) {
+	f := NewFile()
+	f.NewSheet("Sheet2")
+	codeName, err := f.GetSheetCodeName("Sheet1")
+	assert.NoError(t, err)
+	assert.Empty(t, codeName)
+	assert.NoError(t, f.SetSheetCodeName("Sheet1", "Summary_1"))
+	assert.NoError(t, f.SetSheetCodeName("Sheet2", "Детали"))
+	// Test set the same code name on the same worksheet
+	assert.NoError(t, f.SetSheetCodeName("Sheet1", "Summary_1"))
+	// Test set the code name which used by another worksheet
+	assert.EqualError(t, f.SetSheetCodeName("Sheet2", "summary_1"), ErrSheetCodeNameDuplicate.Error())
+	// Test set invalid code name
+	for _, codeName := range []string{"1Sheet", "_Sheet", "Sheet 1", "Sheet-1", strings.Repeat("S", 32)} {
+		assert.EqualError(t, f.SetSheetCodeName("Sheet1", codeName), ErrSheetCodeName.Error(), codeName)
+	}
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSheetCodeName.xlsx")))
+	f, err = OpenFile(filepath.Join("test", "TestSheetCodeName.xlsx"))
+	assert.NoError(t, err)
+	for sheet, expected := range map[string]string{"Sheet1": "Summary_1", "Sheet2": "Детали"} {
+		codeName, err := f.GetSheetCodeName(sheet)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, codeName)
+	}
+	// Test remove the code name
+	assert.NoError(t, f.SetSheetCodeName("Sheet1", ""))
+	codeName, err = f.GetSheetCodeName("Sheet1")
+	assert.NoError(t, err)
+	assert.Empty(t, codeName)
+	// Test the code name on not exists worksheet
+	assert.EqualError(t, f.SetSheetCodeName("SheetN", "SheetN"), "sheet SheetN is not exist")
+	_, err = f.GetSheetCodeName("SheetN")
+	assert.EqualError(t, err, "sheet SheetN is not exist")
+	assert.NoError(t, f.Close())
+}
+
 func TestGetSheetPrOptions(t *testing.T) {
 	f := NewFile()
 	// Test GetSheetPrOptions on not exists worksheet.
@@ -337,6 +375,45 @@ func TestGetPageMargins(t *testing.T) {
 	assert.EqualError(t, f.GetPageMargins("SheetN"), "sheet SheetN is not exist")
 }
 
+func TestPageMarginsMetric(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetPageMarginsMetric("Sheet1", "cm",
+		PageMarginBottom(2.54), PageMarginFooter(1.27), PageMarginHeader(1.27),
+		PageMarginLeft(2), PageMarginRight(2), PageMarginTop(5.08),
+	))
+	var (
+		bottom PageMarginBottom
+		footer PageMarginFooter
+		header PageMarginHeader
+		left   PageMarginLeft
+		right  PageMarginRight
+		top    PageMarginTop
+	)
+	assert.NoError(t, f.GetPageMargins("Sheet1", &bottom, &footer, &header, &left, &right, &top))
+	assert.InDelta(t, 1, float64(bottom), 1e-9)
+	assert.InDelta(t, 0.5, float64(footer), 1e-9)
+	assert.InDelta(t, 0.5, float64(header), 1e-9)
+	assert.InDelta(t, 0.787401575, float64(left), 1e-9)
+	assert.InDelta(t, 0.787401575, float64(right), 1e-9)
+	assert.InDelta(t, 2, float64(top), 1e-9)
+	assert.NoError(t, f.GetPageMarginsMetric("Sheet1", "mm", &bottom, &footer, &header, &left, &right, &top))
+	assert.InDelta(t, 25.4, float64(bottom), 1e-9)
+	assert.InDelta(t, 12.7, float64(footer), 1e-9)
+	assert.InDelta(t, 12.7, float64(header), 1e-9)
+	assert.InDelta(t, 20, float64(left), 1e-9)
+	assert.InDelta(t, 20, float64(right), 1e-9)
+	assert.InDelta(t, 50.8, float64(top), 1e-9)
+	assert.NoError(t, f.SetPageMarginsMetric("Sheet1", "in", PageMarginLeft(1)))
+	assert.NoError(t, f.GetPageMarginsMetric("Sheet1", "in", &left))
+	assert.Equal(t, PageMarginLeft(1), left)
+	// Test set and get page margins with unsupported unit
+	assert.EqualError(t, f.SetPageMarginsMetric("Sheet1", "pt", PageMarginLeft(1)), ErrPageMarginsUnit.Error())
+	assert.EqualError(t, f.GetPageMarginsMetric("Sheet1", "pt", &left), ErrPageMarginsUnit.Error())
+	// Test set and get page margins on not exists worksheet
+	assert.EqualError(t, f.SetPageMarginsMetric("SheetN", "cm"), "sheet SheetN is not exist")
+	assert.EqualError(t, f.GetPageMarginsMetric("SheetN", "cm"), "sheet SheetN is not exist")
+}
+
 func ExampleFile_SetSheetFormatPr() {
 	f := NewFile()
 	const sheet = "Sheet1"
@@ -470,6 +547,26 @@ func TestSetSheetFormatPr(t *testing.T) {
 	assert.NoError(t, f.SetSheetFormatPr("Sheet1", BaseColWidth(1.0)))
 	// Test set formatting properties on not exists worksheet.
 	assert.EqualError(t, f.SetSheetFormatPr("SheetN"), "sheet SheetN is not exist")
+	// Test the default column width and row height of the worksheet
+	assert.NoError(t, f.SetColWidth("Sheet1", "B", "B", 12))
+	assert.NoError(t, f.SetRowHeight("Sheet1", 2, 30))
+	assert.NoError(t, f.SetSheetFormatPr("Sheet1", DefaultColWidth(20), DefaultRowHeight(25), CustomHeight(true)))
+	for col, width := range map[string]float64{"A": 20, "B": 12} {
+		w, err := f.GetColWidth("Sheet1", col)
+		assert.NoError(t, err)
+		assert.Equal(t, width, w, col)
+	}
+	assert.Equal(t, int(convertColWidthToPixels(20)), f.getColWidth("Sheet1", 1))
+	for row, height := range map[int]float64{1: 25, 2: 30, 3: 25} {
+		ht, err := f.GetRowHeight("Sheet1", row)
+		assert.NoError(t, err)
+		assert.Equal(t, height, ht, row)
+	}
+	assert.Equal(t, int(convertRowHeightToPixels(25)), f.getRowHeight("Sheet1", 1))
+	assert.NoError(t, f.SetColVisible("Sheet1", "C", false))
+	w, err := f.GetColWidth("Sheet1", "C")
+	assert.NoError(t, err)
+	assert.Equal(t, 20.0, w)
 }
 
 func TestGetSheetFormatPr(t *testing.T) {
@@ -41,9 +41,9 @@ type (
 	// whether the sheet should display row and column headings.
 	ShowRowColHeaders bool
 	// ShowZeros is a SheetViewOption. It specifies a flag indicating whether
-	// to "show a zero in cells that have zero value". When using a formula to
-	// reference another cell which is empty, the referenced value becomes 0
-	// when the flag is true. (Default setting is true.)
+	// to "show a zero in cells that have zero value". When the flag is false,
+	// the cells that have zero value, including the formulas evaluated to
+	// zero, will be displayed as blank. (Default setting is true.)
 	ShowZeros bool
 	// RightToLeft is a SheetViewOption. It specifies a flag indicating whether
 	// the sheet is in 'right to left' display mode. When in this mode, Column
@@ -66,6 +66,21 @@ type (
 	// restricted to values ranging from 10 to 400. Horizontal & Vertical
 	// scale together.
 	ZoomScale float64
+	// ZoomScaleNormal is a SheetViewOption. It specifies a window zoom
+	// magnification for the normal view representing percent values. This
+	// attribute is restricted to values ranging from 10 to 400, and the value
+	// 0 means the automatic setting.
+	ZoomScaleNormal float64
+	// ZoomScalePageLayoutView is a SheetViewOption. It specifies a window zoom
+	// magnification for the page layout view representing percent values.
+	// This attribute is restricted to values ranging from 10 to 400, and the
+	// value 0 means the automatic setting.
+	ZoomScalePageLayoutView float64
+	// ZoomScaleSheetLayoutView is a SheetViewOption. It specifies a window
+	// zoom magnification for the page break preview representing percent
+	// values. This attribute is restricted to values ranging from 10 to 400,
+	// and the value 0 means the automatic setting.
+	ZoomScaleSheetLayoutView float64
 )
 
 // Defaults for each option are described in XML schema for CT_SheetView
@@ -157,6 +172,39 @@ func (o *ZoomScale) getSheetViewOption(view *xlsxSheetView) {
 	*o = ZoomScale(view.ZoomScale)
 }
 
+func (o ZoomScaleNormal) setSheetViewOption(view *xlsxSheetView) {
+	// This attribute is restricted to values ranging from 10 to 400.
+	if float64(o) == 0 || float64(o) >= 10 && float64(o) <= 400 {
+		view.ZoomScaleNormal = float64(o)
+	}
+}
+
+func (o *ZoomScaleNormal) getSheetViewOption(view *xlsxSheetView) {
+	*o = ZoomScaleNormal(view.ZoomScaleNormal)
+}
+
+func (o ZoomScalePageLayoutView) setSheetViewOption(view *xlsxSheetView) {
+	// This attribute is restricted to values ranging from 10 to 400.
+	if float64(o) == 0 || float64(o) >= 10 && float64(o) <= 400 {
+		view.ZoomScalePageLayoutView = float64(o)
+	}
+}
+
+func (o *ZoomScalePageLayoutView) getSheetViewOption(view *xlsxSheetView) {
+	*o = ZoomScalePageLayoutView(view.ZoomScalePageLayoutView)
+}
+
+func (o ZoomScaleSheetLayoutView) setSheetViewOption(view *xlsxSheetView) {
+	// This attribute is restricted to values ranging from 10 to 400.
+	if float64(o) == 0 || float64(o) >= 10 && float64(o) <= 400 {
+		view.ZoomScaleSheetLayoutView = float64(o)
+	}
+}
+
+func (o *ZoomScaleSheetLayoutView) getSheetViewOption(view *xlsxSheetView) {
+	*o = ZoomScaleSheetLayoutView(view.ZoomScaleSheetLayoutView)
+}
+
 // getSheetView returns the SheetView object
 func (f *File) getSheetView(sheet string, viewIndex int) (*xlsxSheetView, error) {
 	ws, err := f.workSheetReader(sheet)
@@ -195,10 +243,23 @@ func (f *File) getSheetView(sheet string, viewIndex int) (*xlsxSheetView, error)
 //	View(string)
 //	TopLeftCell(string)
 //	ZoomScale(float64)
+//	ZoomScaleNormal(float64)
+//	ZoomScalePageLayoutView(float64)
+//	ZoomScaleSheetLayoutView(float64)
 //
 // Example:
 //
 //	err = f.SetSheetViewOptions("Sheet1", -1, ShowGridLines(false))
+//
+// Display the formulas instead of the calculated results, and hide the zero
+// values on the first view of Sheet1:
+//
+//	err = f.SetSheetViewOptions("Sheet1", 0, ShowFormulas(true), ShowZeros(false))
+//
+// Remember the zoom of 75 percent for the page break preview and 120 percent
+// for the normal view on the first view of Sheet1:
+//
+//	err = f.SetSheetViewOptions("Sheet1", 0, ZoomScaleSheetLayoutView(75), ZoomScaleNormal(120))
 func (f *File) SetSheetViewOptions(sheet string, viewIndex int, opts ...SheetViewOption) error {
 	view, err := f.getSheetView(sheet, viewIndex)
 	if err != nil {
@@ -226,6 +287,9 @@ func (f *File) SetSheetViewOptions(sheet string, viewIndex int, opts ...SheetVie
 //	View(string)
 //	TopLeftCell(string)
 //	ZoomScale(float64)
+//	ZoomScaleNormal(float64)
+//	ZoomScalePageLayoutView(float64)
+//	ZoomScaleSheetLayoutView(float64)
 //
 // Example:
 //
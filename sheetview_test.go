@@ -18,6 +18,9 @@ var _ = []SheetViewOption{
 	View("pageLayout"),
 	TopLeftCell("B2"),
 	ZoomScale(100),
+	ZoomScaleNormal(100),
+	ZoomScalePageLayoutView(100),
+	ZoomScaleSheetLayoutView(100),
 	// SheetViewOptionPtr are also SheetViewOption
 	new(DefaultGridColor),
 	new(ShowFormulas),
@@ -29,6 +32,9 @@ var _ = []SheetViewOption{
 	new(View),
 	new(TopLeftCell),
 	new(ZoomScale),
+	new(ZoomScaleNormal),
+	new(ZoomScalePageLayoutView),
+	new(ZoomScaleSheetLayoutView),
 }
 
 var _ = []SheetViewOptionPtr{
@@ -42,6 +48,9 @@ var _ = []SheetViewOptionPtr{
 	(*View)(nil),
 	(*TopLeftCell)(nil),
 	(*ZoomScale)(nil),
+	(*ZoomScaleNormal)(nil),
+	(*ZoomScalePageLayoutView)(nil),
+	(*ZoomScaleSheetLayoutView)(nil),
 }
 
 func ExampleFile_SetSheetViewOptions() {
@@ -198,6 +207,41 @@ func ExampleFile_GetSheetViewOptions() {
 	// - topLeftCell: B2
 }
 
+func TestSheetViewZoomScales(t *testing.T) {
+	f := NewFile()
+	const sheet = "Sheet1"
+	var (
+		zoomScaleNormal          ZoomScaleNormal
+		zoomScalePageLayoutView  ZoomScalePageLayoutView
+		zoomScaleSheetLayoutView ZoomScaleSheetLayoutView
+	)
+	assert.NoError(t, f.GetSheetViewOptions(sheet, 0, &zoomScaleNormal, &zoomScalePageLayoutView, &zoomScaleSheetLayoutView))
+	assert.Equal(t, ZoomScaleNormal(0), zoomScaleNormal)
+	assert.Equal(t, ZoomScalePageLayoutView(0), zoomScalePageLayoutView)
+	assert.Equal(t, ZoomScaleSheetLayoutView(0), zoomScaleSheetLayoutView)
+
+	assert.NoError(t, f.SetSheetViewOptions(sheet, 0, ZoomScaleNormal(120), ZoomScalePageLayoutView(90), ZoomScaleSheetLayoutView(60)))
+	assert.NoError(t, f.GetSheetViewOptions(sheet, 0, &zoomScaleNormal, &zoomScalePageLayoutView, &zoomScaleSheetLayoutView))
+	assert.Equal(t, ZoomScaleNormal(120), zoomScaleNormal)
+	assert.Equal(t, ZoomScalePageLayoutView(90), zoomScalePageLayoutView)
+	assert.Equal(t, ZoomScaleSheetLayoutView(60), zoomScaleSheetLayoutView)
+
+	// Test set zoom scales with out of range values
+	assert.NoError(t, f.SetSheetViewOptions(sheet, 0, ZoomScaleNormal(5), ZoomScalePageLayoutView(401), ZoomScaleSheetLayoutView(-1)))
+	assert.NoError(t, f.GetSheetViewOptions(sheet, 0, &zoomScaleNormal, &zoomScalePageLayoutView, &zoomScaleSheetLayoutView))
+	assert.Equal(t, ZoomScaleNormal(120), zoomScaleNormal)
+	assert.Equal(t, ZoomScalePageLayoutView(90), zoomScalePageLayoutView)
+	assert.Equal(t, ZoomScaleSheetLayoutView(60), zoomScaleSheetLayoutView)
+
+	// Test reset zoom scales to the automatic setting
+	assert.NoError(t, f.SetSheetViewOptions(sheet, 0, ZoomScaleNormal(0), ZoomScalePageLayoutView(0), ZoomScaleSheetLayoutView(0)))
+	ws, err := f.workSheetReader(sheet)
+	assert.NoError(t, err)
+	assert.Equal(t, float64(0), ws.SheetViews.SheetView[0].ZoomScaleNormal)
+	assert.Equal(t, float64(0), ws.SheetViews.SheetView[0].ZoomScalePageLayoutView)
+	assert.Equal(t, float64(0), ws.SheetViews.SheetView[0].ZoomScaleSheetLayoutView)
+}
+
 func TestSheetViewOptionsErrors(t *testing.T) {
 	f := NewFile()
 	const sheet = "Sheet1"
@@ -0,0 +1,692 @@
+// Copyright 2016 - 2022 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to and
+// read from XLAM / XLSM / XLSX / XLTM / XLTX files. Supports reading and
+// writing spreadsheet documents generated by Microsoft Excel™ 2007 and later.
+// Supports complex components by high compatibility, and provided streaming
+// API for generating or reading data from a worksheet with huge amounts of
+// data. This library needs Go version 1.15 or later.
+
+package excelize
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// slicerSource specifies the table or the pivot table field of the slicer.
+type slicerSource struct {
+	sourceName   string
+	table        *xlsxTable
+	column       int
+	pivotTable   *xlsxPivotTableDefinition
+	pivotCache   *xlsxPivotCacheDefinition
+	pivotCacheID int
+	cachePath    string
+	cacheContent string
+	cacheField   int
+	tabID        int
+}
+
+// parseSlicerOptions provides a function to validate the slicer options and
+// set the default values.
+func parseSlicerOptions(opts *SlicerOptions) (*SlicerOptions, error) {
+	if opts == nil {
+		return nil, ErrParameterRequired
+	}
+	if opts.Name == "" || opts.Cell == "" || opts.TableName == "" {
+		return nil, ErrParameterInvalid
+	}
+	options := *opts
+	if options.Caption == "" {
+		options.Caption = options.Name
+	}
+	if options.Width <= 0 {
+		options.Width = 200
+	}
+	if options.Height <= 0 {
+		options.Height = 200
+	}
+	return &options, nil
+}
+
+// AddSlicer provides the method to add a slicer for the table or the pivot
+// table by given worksheet name and slicer options. The slicer filters the
+// table by the column, or filters the pivot table by the field with the
+// given name. For example, insert a slicer on the Sheet1!E1 to filter the
+// table named Table1 in Sheet1 by the column named Region:
+//
+//	err := f.AddSlicer("Sheet1", &excelize.SlicerOptions{
+//	    Name:       "Region",
+//	    Cell:       "E1",
+//	    TableSheet: "Sheet1",
+//	    TableName:  "Table1",
+//	    Caption:    "Region",
+//	    Width:      200,
+//	    Height:     200,
+//	})
+//
+// Note that the table slicers are supported in Excel 2013 or later, and the
+// pivot table slicers are supported in Excel 2010 or later.
+func (f *File) AddSlicer(sheet string, opts *SlicerOptions) error {
+	opts, err := parseSlicerOptions(opts)
+	if err != nil {
+		return err
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	if _, _, err = CellNameToCoordinates(opts.Cell); err != nil {
+		return err
+	}
+	if opts.TableSheet == "" {
+		opts.TableSheet = sheet
+	}
+	src, err := f.getSlicerSource(opts)
+	if err != nil {
+		return err
+	}
+	slicerName, cacheName, err := f.getSlicerNames(opts.Name)
+	if err != nil {
+		return err
+	}
+	if err = f.checkSlicerExtLst(ws, src); err != nil {
+		return err
+	}
+	if err = f.addSlicerCache(cacheName, opts, src); err != nil {
+		return err
+	}
+	if err = f.addSheetSlicer(sheet, ws, slicerName, cacheName, opts, src); err != nil {
+		return err
+	}
+	if src.cachePath != "" {
+		f.saveFileList(src.cachePath, []byte(src.cacheContent))
+	}
+	return f.addSlicerDrawing(sheet, ws, slicerName, opts, src)
+}
+
+// getSlicerSource provides a function to get the table column or the pivot
+// table field of the slicer by given slicer options. For the pivot table
+// field, the content of the pivot cache definition with the pivot cache ID of
+// the slicer caches will be prepared, which should be saved after the slicer
+// has been added.
+func (f *File) getSlicerSource(opts *SlicerOptions) (*slicerSource, error) {
+	t, _, err := f.getSheetTable(opts.TableSheet, opts.TableName)
+	if err == nil {
+		if t.TableColumns != nil {
+			for _, column := range t.TableColumns.TableColumn {
+				if strings.EqualFold(column.Name, opts.Name) {
+					return &slicerSource{sourceName: column.Name, table: t, column: column.ID}, nil
+				}
+			}
+		}
+		return nil, ErrSlicerField
+	}
+	if err != ErrTableNotExist {
+		return nil, err
+	}
+	pivotTableXML, pt, err := f.getPivotTable(opts.TableSheet, opts.TableName)
+	if err != nil {
+		if err == ErrPivotTableNotExist {
+			return nil, ErrTableNotExist
+		}
+		return nil, err
+	}
+	pivotCacheXML := f.getRelsTargetPath(pivotTableXML, SourceRelationshipPivotCache)
+	if pivotCacheXML == "" {
+		return nil, ErrPivotCacheNotExist
+	}
+	pc, err := f.pivotCacheDefinitionReader(pivotCacheXML)
+	if err != nil {
+		return nil, err
+	}
+	if pc.CacheFields != nil {
+		for idx, field := range pc.CacheFields.CacheField {
+			if strings.EqualFold(field.Name, opts.Name) {
+				src := &slicerSource{
+					sourceName: field.Name, pivotTable: pt, pivotCache: pc,
+					cacheField: idx, tabID: f.getSheetID(opts.TableSheet), cachePath: pivotCacheXML,
+				}
+				if src.cacheContent, err = f.readXMLContent(pivotCacheXML); err != nil {
+					return nil, err
+				}
+				src.cacheContent, src.pivotCacheID, err = setPivotCacheSlicerID(src.cacheContent, pt.CacheID)
+				return src, err
+			}
+		}
+	}
+	return nil, ErrSlicerField
+}
+
+// setPivotCacheSlicerID provides a function to get the ID of the pivot cache
+// which used by the slicer caches by given XML content of the pivot cache
+// definition, the ID will be created by given default value in the extension
+// list if it doesn't exist. The rest of the content will be kept as it is.
+func setPivotCacheSlicerID(content string, cacheID int) (string, int, error) {
+	root, _, _, err := getXMLStartElement(content)
+	if err != nil {
+		return content, cacheID, err
+	}
+	extLst, err := getXMLChildElements(content, "extLst")
+	if err != nil {
+		return content, cacheID, err
+	}
+	definition, _ := xml.Marshal(xlsxX14PivotCacheDefinition{
+		XMLNSX14: NameSpaceSpreadSheetX14.Value, PivotCacheID: cacheID,
+	})
+	if len(extLst) == 0 {
+		prefix := root.Name.Space
+		if prefix != "" {
+			prefix += ":"
+		}
+		content, err = appendXMLChildElement(content, fmt.Sprintf(`<%[1]sextLst><%[1]sext uri="%[2]s">%[3]s</%[1]sext></%[1]sextLst>`,
+			prefix, ExtURIPivotCacheDefinition, definition))
+		return content, cacheID, err
+	}
+	lst := content[extLst[0].start:extLst[0].end]
+	exts, err := getXMLElements(lst, 1)
+	if err != nil {
+		return content, cacheID, err
+	}
+	for _, ext := range exts {
+		element := lst[ext.start:ext.end]
+		var decodeExt xlsxWorksheetExt
+		if err = xml.Unmarshal([]byte(element), &decodeExt); err != nil {
+			return content, cacheID, err
+		}
+		if decodeExt.URI != ExtURIPivotCacheDefinition {
+			continue
+		}
+		var decodePC decodeX14PivotCacheDefinition
+		if err = xml.Unmarshal([]byte(decodeExt.Content), &decodePC); err != nil {
+			return content, cacheID, err
+		}
+		if decodePC.PivotCacheID != 0 {
+			return content, decodePC.PivotCacheID, err
+		}
+		children, err := getXMLElements(element, 1)
+		if err != nil || len(children) == 0 {
+			return content, cacheID, ErrParameterInvalid
+		}
+		child, err := setXMLElementAttrs(element[children[0].start:children[0].end], []xml.Attr{
+			{Name: xml.Name{Local: "pivotCacheId"}, Value: strconv.Itoa(cacheID)},
+		})
+		if err != nil {
+			return content, cacheID, err
+		}
+		offset := extLst[0].start + ext.start
+		return content[:offset+children[0].start] + child + content[offset+children[0].end:], cacheID, err
+	}
+	prefix := extLst[0].name.Space
+	if prefix != "" {
+		prefix += ":"
+	}
+	if lst, err = appendXMLChildElement(lst, fmt.Sprintf(`<%[1]sext uri="%[2]s">%[3]s</%[1]sext>`,
+		prefix, ExtURIPivotCacheDefinition, definition)); err != nil {
+		return content, cacheID, err
+	}
+	return content[:extLst[0].start] + lst + content[extLst[0].end:], cacheID, err
+}
+
+// getSlicerNames provides a function to generate the unique slicer name and
+// slicer cache name in the workbook by given field name.
+func (f *File) getSlicerNames(name string) (string, string, error) {
+	names := map[string]bool{}
+	caches := map[string]bool{}
+	if wb := f.workbookReader(); wb.DefinedNames != nil {
+		for _, dn := range wb.DefinedNames.DefinedName {
+			caches[strings.ToLower(dn.Name)] = true
+		}
+	}
+	var err error
+	f.Pkg.Range(func(k, v interface{}) bool {
+		if strings.HasPrefix(k.(string), "xl/slicers/slicer") {
+			slicers := new(xlsxSlicers)
+			if err = f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(v.([]byte)))).
+				Decode(slicers); err != nil && err != io.EOF {
+				return false
+			}
+			err = nil
+			for _, slicer := range slicers.Slicer {
+				names[strings.ToLower(slicer.Name)] = true
+				caches[strings.ToLower(slicer.Cache)] = true
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return "", "", err
+	}
+	slicerName, cacheName := name, "Slicer_"+strings.Map(func(r rune) rune {
+		if r == '_' || r == '.' || ('0' <= r && r <= '9') || ('a' <= r && r <= 'z') || ('A' <= r && r <= 'Z') || r >= 0x80 {
+			return r
+		}
+		return '_'
+	}, name)
+	for i := 1; names[strings.ToLower(slicerName)]; i++ {
+		slicerName = name + " " + strconv.Itoa(i)
+	}
+	for i, prefix := 1, cacheName; caches[strings.ToLower(cacheName)]; i++ {
+		cacheName = prefix + strconv.Itoa(i)
+	}
+	return slicerName, cacheName, err
+}
+
+// countSlicerParts provides a function to get the number of the parts in the
+// package with the given path prefix.
+func (f *File) countSlicerParts(prefix string) int {
+	count := 0
+	f.Pkg.Range(func(k, v interface{}) bool {
+		if strings.HasPrefix(k.(string), prefix) {
+			count++
+		}
+		return true
+	})
+	return count
+}
+
+// addSlicerCache provides a function to create the slicer cache part, and
+// add the slicer cache and the defined name of it to the workbook.
+func (f *File) addSlicerCache(cacheName string, opts *SlicerOptions, src *slicerSource) error {
+	sortOrder := ""
+	if opts.ItemDesc {
+		sortOrder = "descending"
+	}
+	cache := xlsxSlicerCacheDefinition{
+		XMLNSXMC:   SourceRelationshipCompatibility.Value,
+		XMLNSX:     NameSpaceSpreadSheet.Value,
+		Name:       cacheName,
+		SourceName: src.sourceName,
+	}
+	if src.table != nil {
+		tableCache, _ := xml.Marshal(xlsxTableSlicerCache{TableID: src.table.ID, Column: src.column, SortOrder: sortOrder})
+		cache.ExtLst = &xlsxExtLst{Ext: fmt.Sprintf(`<x:ext uri="%s" xmlns:x15="%s">%s</x:ext>`,
+			ExtURITableSlicerCache, NameSpaceSpreadSheetX15.Value, tableCache)}
+	} else {
+		cache.PivotTables = &xlsxSlicerCachePivotTables{PivotTable: []xlsxSlicerCachePivotTable{
+			{TabID: src.tabID, Name: src.pivotTable.Name},
+		}}
+		tabular := &xlsxTabularSlicerCache{PivotCacheID: src.pivotCacheID, SortOrder: sortOrder}
+		if field := src.pivotCache.CacheFields.CacheField[src.cacheField]; field.SharedItems != nil && field.SharedItems.Count > 0 {
+			tabular.Items = &xlsxTabularSlicerCacheItems{Count: field.SharedItems.Count}
+			for i := 0; i < field.SharedItems.Count; i++ {
+				tabular.Items.I = append(tabular.Items.I, xlsxTabularSlicerCacheItem{X: i, S: true})
+			}
+		}
+		cache.Data = &xlsxSlicerCacheData{Tabular: tabular}
+	}
+	cacheID := f.countSlicerParts("xl/slicerCaches/slicerCache") + 1
+	output, err := xml.Marshal(cache)
+	if err != nil {
+		return err
+	}
+	f.saveFileList("xl/slicerCaches/slicerCache"+strconv.Itoa(cacheID)+".xml", output)
+	f.addContentTypePart(cacheID, "slicerCache")
+	rID := f.addRels(f.getWorkbookRelsPath(), SourceRelationshipSlicerCache, fmt.Sprintf("/xl/slicerCaches/slicerCache%d.xml", cacheID), "")
+	wb := f.workbookReader()
+	uri, list := getSlicerList(src, true)
+	if wb.ExtLst, err = insertExtLstItem(wb.ExtLst, uri, list, xlsxSlicerListItem{
+		XMLName: xml.Name{Local: "x14:slicerCache"}, RID: "rId" + strconv.Itoa(rID),
+	}); err != nil {
+		return err
+	}
+	if wb.DefinedNames == nil {
+		wb.DefinedNames = &xlsxDefinedNames{}
+	}
+	wb.DefinedNames.DefinedName = append(wb.DefinedNames.DefinedName, xlsxDefinedName{Name: cacheName, Data: "#N/A"})
+	return err
+}
+
+// addSheetSlicer provides a function to create the slicer part, and add the
+// slicer to the worksheet.
+func (f *File) addSheetSlicer(sheet string, ws *xlsxWorksheet, slicerName, cacheName string, opts *SlicerOptions, src *slicerSource) error {
+	slicer := xlsxSlicer{
+		Name:        slicerName,
+		Cache:       cacheName,
+		Caption:     opts.Caption,
+		ShowCaption: opts.DisplayHeader,
+		Style:       opts.Style,
+		RowHeight:   241300,
+	}
+	slicerID := f.countSlicerParts("xl/slicers/slicer") + 1
+	output, err := xml.Marshal(xlsxSlicers{
+		XMLNSXMC: SourceRelationshipCompatibility.Value,
+		XMLNSX:   NameSpaceSpreadSheet.Value,
+		Slicer:   []xlsxSlicer{slicer},
+	})
+	if err != nil {
+		return err
+	}
+	f.saveFileList("xl/slicers/slicer"+strconv.Itoa(slicerID)+".xml", output)
+	f.addContentTypePart(slicerID, "slicer")
+	sheetXMLPath, _ := f.getSheetXMLPath(sheet)
+	sheetRels := "xl/worksheets/_rels/" + strings.TrimPrefix(sheetXMLPath, "xl/worksheets/") + ".rels"
+	rID := f.addRels(sheetRels, SourceRelationshipSlicer, "../slicers/slicer"+strconv.Itoa(slicerID)+".xml", "")
+	f.addSheetNameSpace(sheet, SourceRelationship)
+	uri, list := getSlicerList(src, false)
+	ws.ExtLst, err = insertExtLstItem(ws.ExtLst, uri, list, xlsxSlicerListItem{
+		XMLName: xml.Name{Local: "x14:slicer"}, RID: "rId" + strconv.Itoa(rID),
+	})
+	return err
+}
+
+// getSlicerList provides a function to get the URI of the ext element and
+// the list element of the slicer caches in the workbook or the slicers in
+// the worksheet by given slicer source. The table slicers are stored in the
+// x15 lists, and the pivot table slicers are stored in the x14 lists.
+func getSlicerList(src *slicerSource, cache bool) (string, xlsxSlicerList) {
+	uri, name := ExtURISlicerListX14, "x14:slicerList"
+	if cache {
+		uri, name = ExtURISlicerCachesListX14, "x14:slicerCaches"
+	}
+	list := xlsxSlicerList{XMLName: xml.Name{Local: name}, XMLNSX14: NameSpaceSpreadSheetX14.Value}
+	if src.table != nil {
+		uri, name = ExtURISlicerListX15, "x15:slicerList"
+		if cache {
+			uri, name = ExtURISlicerCachesListX15, "x15:slicerCaches"
+		}
+		list.XMLName.Local, list.XMLNSX15 = name, NameSpaceSpreadSheetX15.Value
+	}
+	return uri, list
+}
+
+// checkSlicerExtLst provides a function to check if the slicer cache and the
+// slicer can be inserted into the extension lists of the workbook and the
+// worksheet by given worksheet and slicer source, so that none of the parts
+// of the slicer will be created if any of the extension lists is malformed.
+func (f *File) checkSlicerExtLst(ws *xlsxWorksheet, src *slicerSource) error {
+	for idx, extLst := range []*xlsxExtLst{f.workbookReader().ExtLst, ws.ExtLst} {
+		if extLst == nil {
+			continue
+		}
+		uri, list := getSlicerList(src, idx == 0)
+		if _, err := insertExtLstItem(&xlsxExtLst{Ext: extLst.Ext}, uri, list, xlsxSlicerListItem{}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// insertExtLstItem provides a function to insert the item into the list
+// element of the ext element with the given URI in the extension list, a
+// new ext element with the given list element will be appended if it
+// doesn't exist. The other ext elements will be kept as is.
+func insertExtLstItem(extLst *xlsxExtLst, uri string, list xlsxSlicerList, item xlsxSlicerListItem) (*xlsxExtLst, error) {
+	if extLst == nil {
+		extLst = &xlsxExtLst{}
+	}
+	itemXML, err := xml.Marshal(item)
+	if err != nil {
+		return extLst, err
+	}
+	exts, err := getXMLElements(extLst.Ext, 0)
+	if err != nil {
+		return extLst, err
+	}
+	for _, ext := range exts {
+		content := extLst.Ext[ext.start:ext.end]
+		var decodeExt xlsxWorksheetExt
+		if err = xml.Unmarshal([]byte(content), &decodeExt); err != nil {
+			return extLst, err
+		}
+		if decodeExt.URI != uri {
+			continue
+		}
+		elements, err := getXMLElements(content, 1)
+		if err != nil || len(elements) == 0 {
+			return extLst, ErrParameterInvalid
+		}
+		listXML := content[elements[0].start:elements[0].end]
+		if strings.HasSuffix(listXML, "/>") {
+			name := elements[0].name.Local
+			if elements[0].name.Space != "" {
+				name = elements[0].name.Space + ":" + name
+			}
+			listXML = strings.TrimSuffix(listXML, "/>") + ">" + string(itemXML) + "</" + name + ">"
+		} else {
+			idx := strings.LastIndex(listXML, "</")
+			listXML = listXML[:idx] + string(itemXML) + listXML[idx:]
+		}
+		extLst.Ext = extLst.Ext[:ext.start] + content[:elements[0].start] + listXML +
+			content[elements[0].end:] + extLst.Ext[ext.end:]
+		return extLst, err
+	}
+	list.Content = string(itemXML)
+	listXML, err := xml.Marshal(list)
+	if err != nil {
+		return extLst, err
+	}
+	ext, err := xml.Marshal(xlsxWorksheetExt{URI: uri, Content: string(listXML)})
+	extLst.Ext += string(ext)
+	return extLst, err
+}
+
+// addSlicerDrawing provides a function to add the slicer shape in the
+// drawing of the worksheet.
+func (f *File) addSlicerDrawing(sheet string, ws *xlsxWorksheet, slicerName string, opts *SlicerOptions, src *slicerSource) error {
+	col, row, err := CellNameToCoordinates(opts.Cell)
+	if err != nil {
+		return err
+	}
+	drawingID := f.countDrawings() + 1
+	drawingXML := "xl/drawings/drawing" + strconv.Itoa(drawingID) + ".xml"
+	drawingID, drawingXML = f.prepareDrawing(ws, drawingID, sheet, drawingXML)
+	colStart, rowStart, colEnd, rowEnd, x2, y2 := f.positionObjectPixels(sheet, col-1, row-1, 0, 0, opts.Width, opts.Height)
+	from := xlsxFrom{Col: colStart, Row: rowStart}
+	to := xlsxTo{Col: colEnd, ColOff: x2 * EMU, Row: rowEnd, RowOff: y2 * EMU}
+	content, cNvPrID := f.drawingParser(drawingXML)
+	graphicFrame, err := xml.Marshal(xlsxGraphicFrame{
+		NvGraphicFramePr: xlsxNvGraphicFramePr{
+			CNvPr: &xlsxCNvPr{ID: cNvPrID, Name: slicerName},
+		},
+		Graphic: &xlsxGraphic{
+			GraphicData: &xlsxGraphicData{
+				URI:    NameSpaceDrawingMLSlicer,
+				Slicer: &xlsxSle{Sle: NameSpaceDrawingMLSlicer, Name: slicerName},
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	choice, err := xml.Marshal(xdrTwoCellAnchor{xdrCellAnchor: xdrCellAnchor{
+		EditAs: "oneCell", From: &from, To: &to,
+		GraphicFrame: string(graphicFrame), ClientData: &xdrClientData{FLocksWithSheet: true, FPrintsWithSheet: true},
+	}})
+	if err != nil {
+		return err
+	}
+	x, y := f.getCellAnchorEMU(sheet, colStart, 0, rowStart, 0)
+	text := "This shape represents a slicer. Slicers are supported in Excel 2010 or later."
+	requires := `xmlns:a14="http://schemas.microsoft.com/office/drawing/2010/main" Requires="a14"`
+	if src.table != nil {
+		text = "This shape represents a table slicer. Table slicers are supported in Excel 2013 or later."
+		requires = fmt.Sprintf(`xmlns:sle15="%s" Requires="sle15"`, NameSpaceDrawingMLSlicerX15)
+	}
+	fallback, err := xml.Marshal(xdrTwoCellAnchor{xdrCellAnchor: xdrCellAnchor{
+		EditAs: "oneCell", From: &from, To: &to,
+		Sp: &xdrSp{
+			NvSpPr: &xdrNvSpPr{CNvPr: &xlsxCNvPr{}, CNvSpPr: &xdrCNvSpPr{TxBox: true}},
+			SpPr: &xlsxSpPr{
+				Xfrm:     xlsxXfrm{Off: xlsxOff{X: x, Y: y}, Ext: xlsxExt{Cx: opts.Width * EMU, Cy: opts.Height * EMU}},
+				PrstGeom: xlsxPrstGeom{Prst: "rect"},
+				Ln:       xlsxLineProperties{W: 1},
+			},
+			TxBody: &xdrTxBody{
+				BodyPr: &aBodyPr{VertOverflow: "clip", HorzOverflow: "clip"},
+				P:      []*aP{{R: &aR{RPr: aRPr{Lang: "en-US", Sz: 1100}, T: text}}},
+			},
+		},
+		ClientData: &xdrClientData{FLocksWithSheet: true, FPrintsWithSheet: true},
+	}})
+	if err != nil {
+		return err
+	}
+	content.AlternateContent = append(content.AlternateContent, &xlsxAlternateContent{
+		XMLNSMC: SourceRelationshipCompatibility.Value,
+		Content: fmt.Sprintf(`<mc:Choice %s>%s</mc:Choice><mc:Fallback>%s</mc:Fallback>`, requires, choice, fallback),
+	})
+	f.Drawings.Store(drawingXML, content)
+	f.addContentTypePart(drawingID, "drawings")
+	return err
+}
+
+// GetSlicers provides a function to get the slicers in the worksheet by
+// given worksheet name. The width and height of the slicers will not be
+// returned. For example, get the slicers in Sheet1:
+//
+//	slicers, err := f.GetSlicers("Sheet1")
+func (f *File) GetSlicers(sheet string) ([]SlicerOptions, error) {
+	var slicers []SlicerOptions
+	ws, err := f.workSheetReader(sheet)
+	if err != nil || ws.ExtLst == nil {
+		return slicers, err
+	}
+	exts, err := getXMLElements(ws.ExtLst.Ext, 0)
+	if err != nil {
+		return slicers, err
+	}
+	sheetXMLPath, _ := f.getSheetXMLPath(sheet)
+	cells, err := f.getSlicerCells(sheet, ws)
+	if err != nil {
+		return slicers, err
+	}
+	for _, ext := range exts {
+		var decodeExt xlsxWorksheetExt
+		if err = xml.Unmarshal([]byte(ws.ExtLst.Ext[ext.start:ext.end]), &decodeExt); err != nil {
+			return slicers, err
+		}
+		if decodeExt.URI != ExtURISlicerListX14 && decodeExt.URI != ExtURISlicerListX15 {
+			continue
+		}
+		var list decodeSlicerList
+		if err = xml.Unmarshal([]byte(decodeExt.Content), &list); err != nil {
+			return slicers, err
+		}
+		for _, item := range list.Slicer {
+			slicerXML := getRelsTargetPath(sheetXMLPath, f.getSheetRelationshipsTargetByID(sheet, item.RID))
+			content, ok := f.Pkg.Load(slicerXML)
+			if !ok {
+				continue
+			}
+			parts := new(xlsxSlicers)
+			if err = f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(content.([]byte)))).
+				Decode(parts); err != nil && err != io.EOF {
+				return slicers, err
+			}
+			for _, slicer := range parts.Slicer {
+				opts := SlicerOptions{
+					Cell:          cells[slicer.Name],
+					Caption:       slicer.Caption,
+					DisplayHeader: slicer.ShowCaption,
+					Style:         slicer.Style,
+				}
+				if err = f.getSlicerCacheOptions(slicer.Cache, &opts); err != nil {
+					return slicers, err
+				}
+				slicers = append(slicers, opts)
+			}
+		}
+	}
+	return slicers, err
+}
+
+// getSlicerCells provides a function to get the top-left cell of the slicer
+// shapes in the drawing of the worksheet, returns a map of the slicer names
+// and cell references.
+func (f *File) getSlicerCells(sheet string, ws *xlsxWorksheet) (map[string]string, error) {
+	cells := map[string]string{}
+	if ws.Drawing == nil {
+		return cells, nil
+	}
+	drawingXML := strings.ReplaceAll(f.getSheetRelationshipsTargetByID(sheet, ws.Drawing.RID), "..", "xl")
+	wsDr, _ := f.drawingParser(drawingXML)
+	wsDr.Lock()
+	defer wsDr.Unlock()
+	for _, alternateContent := range wsDr.AlternateContent {
+		var anchor decodeSlicerAnchor
+		if err := xml.Unmarshal([]byte("<AlternateContent>"+alternateContent.Content+"</AlternateContent>"), &anchor); err != nil {
+			return cells, err
+		}
+		twoCellAnchor := anchor.Choice.TwoCellAnchor
+		if slicer := twoCellAnchor.GraphicFrame.Graphic.GraphicData.Slicer; slicer != nil && twoCellAnchor.From != nil {
+			cells[slicer.Name], _ = CoordinatesToCellName(twoCellAnchor.From.Col+1, twoCellAnchor.From.Row+1)
+		}
+	}
+	return cells, nil
+}
+
+// getSlicerCacheOptions provides a function to get the source field, the
+// table or pivot table and the sort order of the slicer by given slicer
+// cache name.
+func (f *File) getSlicerCacheOptions(cacheName string, opts *SlicerOptions) error {
+	rels := f.relsReader(f.getWorkbookRelsPath())
+	if rels == nil {
+		return nil
+	}
+	rels.Lock()
+	var caches []string
+	for _, rel := range rels.Relationships {
+		if rel.Type == SourceRelationshipSlicerCache {
+			caches = append(caches, getRelsTargetPath(f.getWorkbookPath(), rel.Target))
+		}
+	}
+	rels.Unlock()
+	for _, cacheXML := range caches {
+		cache := new(xlsxSlicerCacheDefinition)
+		if err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(f.readXML(cacheXML)))).
+			Decode(cache); err != nil && err != io.EOF {
+			return err
+		}
+		if cache.Name != cacheName {
+			continue
+		}
+		opts.Name = cache.SourceName
+		if cache.PivotTables != nil && len(cache.PivotTables.PivotTable) > 0 {
+			opts.TableSheet = f.GetSheetMap()[cache.PivotTables.PivotTable[0].TabID]
+			opts.TableName = cache.PivotTables.PivotTable[0].Name
+		}
+		if cache.Data != nil && cache.Data.Tabular != nil {
+			opts.ItemDesc = cache.Data.Tabular.SortOrder == "descending"
+		}
+		if cache.ExtLst != nil {
+			var decodeExt decodeSlicerCacheDefinitionExt
+			if err := xml.Unmarshal([]byte("<extLst>"+cache.ExtLst.Ext+"</extLst>"), &decodeExt); err != nil {
+				return err
+			}
+			for _, ext := range decodeExt.Ext {
+				if ext.TableSlicerCache != nil {
+					opts.ItemDesc = ext.TableSlicerCache.SortOrder == "descending"
+					opts.TableSheet, opts.TableName = f.getTableByID(ext.TableSlicerCache.TableID)
+				}
+			}
+		}
+		return nil
+	}
+	return nil
+}
+
+// getTableByID provides a function to get the worksheet name and the table
+// name by given table ID.
+func (f *File) getTableByID(tableID int) (string, string) {
+	for _, sheet := range f.GetSheetList() {
+		ws, err := f.workSheetReader(sheet)
+		if err != nil || ws.TableParts == nil {
+			continue
+		}
+		for _, tbl := range ws.TableParts.TableParts {
+			tableXML := strings.ReplaceAll(f.getSheetRelationshipsTargetByID(sheet, tbl.RID), "..", "xl")
+			if t, err := f.tableReader(tableXML); err == nil && t != nil && t.ID == tableID {
+				return sheet, t.Name
+			}
+		}
+	}
+	return "", ""
+}
@@ -0,0 +1,274 @@
+package excelize
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddSlicer(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]string{"Region", "Type", "Sales"}))
+	for row := 2; row <= 5; row++ {
+		assert.NoError(t, f.SetSheetRow("Sheet1", fmt.Sprintf("A%d", row), &[]interface{}{"East", "Meat", row * 100}))
+	}
+	assert.NoError(t, f.AddTable("Sheet1", "A1", "C5", `{"table_name":"Table1"}`))
+	assert.NoError(t, f.AddPicture("Sheet1", "K1", filepath.Join("test", "images", "excel.png"), ""))
+	assert.NoError(t, f.AddSlicer("Sheet1", &SlicerOptions{
+		Name:       "Region",
+		Cell:       "E1",
+		TableSheet: "Sheet1",
+		TableName:  "Table1",
+		Style:      "SlicerStyleLight1",
+	}))
+	assert.NoError(t, f.AddSlicer("Sheet1", &SlicerOptions{
+		Name:          "Type",
+		Cell:          "H1",
+		TableName:     "Table1",
+		Caption:       "Product Type",
+		DisplayHeader: boolPtr(false),
+		ItemDesc:      true,
+		Width:         150,
+		Height:        100,
+	}))
+	// Test add another slicer for the same column
+	assert.NoError(t, f.AddSlicer("Sheet1", &SlicerOptions{Name: "Region", Cell: "E15", TableName: "Table1"}))
+	expected := []SlicerOptions{
+		{Name: "Region", Cell: "E1", TableSheet: "Sheet1", TableName: "Table1", Caption: "Region", Style: "SlicerStyleLight1"},
+		{Name: "Type", Cell: "H1", TableSheet: "Sheet1", TableName: "Table1", Caption: "Product Type", DisplayHeader: boolPtr(false), ItemDesc: true},
+		{Name: "Region", Cell: "E15", TableSheet: "Sheet1", TableName: "Table1", Caption: "Region"},
+	}
+	slicers, err := f.GetSlicers("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, expected, slicers)
+	wb := f.workbookReader()
+	assert.Equal(t, []xlsxDefinedName{
+		{Name: "Slicer_Region", Data: "#N/A"},
+		{Name: "Slicer_Type", Data: "#N/A"},
+		{Name: "Slicer_Region1", Data: "#N/A"},
+	}, wb.DefinedNames.DefinedName)
+	assert.Equal(t, 1, strings.Count(wb.ExtLst.Ext, ExtURISlicerCachesListX15))
+	assert.Equal(t, 3, strings.Count(wb.ExtLst.Ext, "<x14:slicerCache "))
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, strings.Count(ws.ExtLst.Ext, ExtURISlicerListX15))
+	assert.Equal(t, 3, strings.Count(ws.ExtLst.Ext, "<x14:slicer "))
+	assert.Contains(t, string(f.readXML("xl/slicerCaches/slicerCache2.xml")), `<x15:tableSlicerCache tableId="1" column="2" sortOrder="descending">`)
+	assert.Contains(t, string(f.readXML("xl/slicers/slicer3.xml")), `name="Region 1" cache="Slicer_Region1"`)
+	path := filepath.Join("test", "TestAddSlicer.xlsx")
+	assert.NoError(t, f.SaveAs(path))
+	assert.NoError(t, f.Close())
+
+	// Test preserve the slicers on round-trip, and add slicer to the workbook
+	// which contains slicers
+	f, err = OpenFile(path)
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellValue("Sheet1", "A2", "West"))
+	assert.NoError(t, f.AddSlicer("Sheet1", &SlicerOptions{Name: "Sales", Cell: "E30", TableName: "Table1"}))
+	assert.NoError(t, f.AddPicture("Sheet1", "K20", filepath.Join("test", "images", "excel.png"), ""))
+	assert.NoError(t, f.SaveAs(path))
+	assert.NoError(t, f.Close())
+	f, err = OpenFile(path)
+	assert.NoError(t, err)
+	slicers, err = f.GetSlicers("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, append(expected, SlicerOptions{Name: "Sales", Cell: "E30", TableSheet: "Sheet1", TableName: "Table1", Caption: "Sales"}), slicers)
+	drawing := string(f.readXML("xl/drawings/drawing1.xml"))
+	for id := 2; id <= 7; id++ {
+		assert.Equal(t, 1, strings.Count(drawing, fmt.Sprintf(`<xdr:cNvPr id="%d"`, id)))
+	}
+	assert.NoError(t, f.Close())
+
+	f = NewFile()
+	// Test add slicer with invalid options
+	assert.EqualError(t, f.AddSlicer("Sheet1", nil), ErrParameterRequired.Error())
+	assert.EqualError(t, f.AddSlicer("Sheet1", &SlicerOptions{Cell: "E1", TableName: "Table1"}), ErrParameterInvalid.Error())
+	assert.EqualError(t, f.AddSlicer("SheetN", &SlicerOptions{Name: "Region", Cell: "E1", TableName: "Table1"}), "sheet SheetN is not exist")
+	assert.EqualError(t, f.AddSlicer("Sheet1", &SlicerOptions{Name: "Region", Cell: "E", TableName: "Table1"}), newCellNameToCoordinatesError("E", newInvalidCellNameError("E")).Error())
+	assert.EqualError(t, f.AddSlicer("Sheet1", &SlicerOptions{Name: "Region", Cell: "E1", TableSheet: "SheetN", TableName: "Table1"}), "sheet SheetN is not exist")
+	assert.EqualError(t, f.AddSlicer("Sheet1", &SlicerOptions{Name: "Region", Cell: "E1", TableName: "Table1"}), ErrTableNotExist.Error())
+	assert.NoError(t, f.AddTable("Sheet1", "A1", "C5", `{"table_name":"Table1"}`))
+	assert.EqualError(t, f.AddSlicer("Sheet1", &SlicerOptions{Name: "Region", Cell: "E1", TableName: "Table1"}), ErrSlicerField.Error())
+	// Test add slicer with the invalid slicer part
+	f.Pkg.Store("xl/slicers/slicer1.xml", MacintoshCyrillicCharset)
+	assert.EqualError(t, f.AddSlicer("Sheet1", &SlicerOptions{Name: "Column1", Cell: "E1", TableName: "Table1"}), "XML syntax error on line 1: invalid UTF-8")
+	// Test add slicer with the invalid extension list
+	f.Pkg.Delete("xl/slicers/slicer1.xml")
+	ws, err = f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	ws.ExtLst = &xlsxExtLst{Ext: "<ext></x>"}
+	assert.EqualError(t, f.AddSlicer("Sheet1", &SlicerOptions{Name: "Column1", Cell: "E1", TableName: "Table1"}), ErrParameterInvalid.Error())
+	ws.ExtLst = &xlsxExtLst{Ext: fmt.Sprintf(`<ext uri="%s"/>`, ExtURISlicerListX15)}
+	assert.EqualError(t, f.AddSlicer("Sheet1", &SlicerOptions{Name: "Column1", Cell: "E1", TableName: "Table1"}), ErrParameterInvalid.Error())
+	// Test add slicer failed without creating any part of the slicer
+	for _, part := range []string{"xl/slicerCaches/slicerCache1.xml", "xl/slicers/slicer1.xml", "xl/drawings/drawing1.xml"} {
+		_, ok := f.Pkg.Load(part)
+		assert.False(t, ok, part)
+	}
+	assert.Nil(t, f.WorkBook.DefinedNames)
+	assert.Nil(t, f.WorkBook.ExtLst)
+	rels := f.relsReader("xl/worksheets/_rels/sheet1.xml.rels")
+	assert.Len(t, rels.Relationships, 1)
+	ws.ExtLst, f.WorkBook.ExtLst = nil, &xlsxExtLst{Ext: "<ext></x>"}
+	assert.EqualError(t, f.AddSlicer("Sheet1", &SlicerOptions{Name: "Column1", Cell: "E1", TableName: "Table1"}), ErrParameterInvalid.Error())
+	_, ok := f.Pkg.Load("xl/slicerCaches/slicerCache1.xml")
+	assert.False(t, ok)
+	assert.Nil(t, ws.ExtLst)
+	f.WorkBook.ExtLst = nil
+	// Test add slicer into the empty slicer list
+	ws.ExtLst = &xlsxExtLst{Ext: fmt.Sprintf(`<ext uri="%s"><x15:slicerList/></ext>`, ExtURISlicerListX15)}
+	assert.NoError(t, f.AddSlicer("Sheet1", &SlicerOptions{Name: "Column1", Cell: "E1", TableName: "Table1"}))
+	assert.Contains(t, ws.ExtLst.Ext, `<x15:slicerList><x14:slicer r:id="rId2"></x14:slicer></x15:slicerList>`)
+	assert.NoError(t, f.Close())
+}
+
+func TestAddPivotTableSlicer(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]string{"Region", "Type", "Sales"}))
+	for row, region := range []string{"East", "West", "North", "East"} {
+		assert.NoError(t, f.SetSheetRow("Sheet1", fmt.Sprintf("A%d", row+2), &[]interface{}{region, "Meat", row * 100}))
+	}
+	assert.NoError(t, f.AddPivotTable(&PivotTableOption{
+		DataRange:       "Sheet1!$A$1:$C$5",
+		PivotTableRange: "Sheet1!$E$1:$H$10",
+		Rows:            []PivotTableField{{Data: "Region"}},
+		Columns:         []PivotTableField{{Data: "Type"}},
+		Data:            []PivotTableField{{Data: "Sales", Subtotal: "Sum"}},
+	}))
+	assert.NoError(t, f.RefreshPivotCache("Sheet1", "Pivot Table1"))
+	f.NewSheet("Sheet2")
+	assert.NoError(t, f.AddSlicer("Sheet2", &SlicerOptions{
+		Name:       "Region",
+		Cell:       "A1",
+		TableSheet: "Sheet1",
+		TableName:  "Pivot Table1",
+		ItemDesc:   true,
+	}))
+	assert.NoError(t, f.AddSlicer("Sheet2", &SlicerOptions{Name: "Type", Cell: "D1", TableSheet: "Sheet1", TableName: "Pivot Table1"}))
+	slicers, err := f.GetSlicers("Sheet2")
+	assert.NoError(t, err)
+	assert.Equal(t, []SlicerOptions{
+		{Name: "Region", Cell: "A1", TableSheet: "Sheet1", TableName: "Pivot Table1", Caption: "Region", ItemDesc: true},
+		{Name: "Type", Cell: "D1", TableSheet: "Sheet1", TableName: "Pivot Table1", Caption: "Type"},
+	}, slicers)
+	pivotCache := string(f.readXML("xl/pivotCache/pivotCacheDefinition1.xml"))
+	assert.Equal(t, 1, strings.Count(pivotCache, ExtURIPivotCacheDefinition))
+	assert.Contains(t, pivotCache, `<x14:pivotCacheDefinition xmlns:x14="http://schemas.microsoft.com/office/spreadsheetml/2009/9/main" pivotCacheId="2">`)
+	slicerCache := string(f.readXML("xl/slicerCaches/slicerCache1.xml"))
+	assert.Contains(t, slicerCache, `<pivotTables><pivotTable tabId="1" name="Pivot Table1"></pivotTable></pivotTables>`)
+	assert.Contains(t, slicerCache, `<tabular pivotCacheId="2" sortOrder="descending"><items count="3">`)
+	assert.Equal(t, 1, strings.Count(f.workbookReader().ExtLst.Ext, ExtURISlicerCachesListX14))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddPivotTableSlicer.xlsx")))
+	// Test add slicer with the field which doesn't exist in the pivot table
+	assert.EqualError(t, f.AddSlicer("Sheet2", &SlicerOptions{Name: "Month", Cell: "A1", TableSheet: "Sheet1", TableName: "Pivot Table1"}), ErrSlicerField.Error())
+	assert.NoError(t, f.Close())
+
+	// Test add slicer with the pivot cache which contains the unknown elements
+	f = NewFile()
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]string{"Region", "Sales"}))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A2", &[]interface{}{"East", 100}))
+	assert.NoError(t, f.AddPivotTable(&PivotTableOption{
+		DataRange:       "Sheet1!$A$1:$B$2",
+		PivotTableRange: "Sheet1!$E$1:$H$10",
+		Rows:            []PivotTableField{{Data: "Region"}},
+		Data:            []PivotTableField{{Data: "Sales"}},
+	}))
+	pivotCache = strings.Replace(string(f.readXML("xl/pivotCache/pivotCacheDefinition1.xml")), "<cacheFields", `<unknown a="1"/><cacheFields`, 1)
+	f.Pkg.Store("xl/pivotCache/pivotCacheDefinition1.xml", []byte(pivotCache))
+	// Test add slicer failed without changing the pivot cache
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	ws.ExtLst = &xlsxExtLst{Ext: "<ext></x>"}
+	assert.EqualError(t, f.AddSlicer("Sheet1", &SlicerOptions{Name: "Region", Cell: "J1", TableName: "Pivot Table1"}), ErrParameterInvalid.Error())
+	assert.Equal(t, pivotCache, string(f.readXML("xl/pivotCache/pivotCacheDefinition1.xml")))
+	ws.ExtLst = nil
+	assert.NoError(t, f.AddSlicer("Sheet1", &SlicerOptions{Name: "Region", Cell: "J1", TableName: "Pivot Table1"}))
+	pivotCache = string(f.readXML("xl/pivotCache/pivotCacheDefinition1.xml"))
+	assert.Contains(t, pivotCache, `<unknown a="1"/>`)
+	assert.Contains(t, pivotCache, ExtURIPivotCacheDefinition)
+	assert.NoError(t, f.Close())
+}
+
+func TestSetPivotCacheSlicerID(t *testing.T) {
+	definition := `<x14:pivotCacheDefinition xmlns:x14="` + NameSpaceSpreadSheetX14.Value + `" pivotCacheId="2"></x14:pivotCacheDefinition>`
+	for _, c := range [][]string{
+		{`<a/>`, `<a><extLst><ext uri="` + ExtURIPivotCacheDefinition + `">` + definition + `</ext></extLst></a>`},
+		{`<x:a xmlns:x="x"><x:extLst><x:ext uri="u"/></x:extLst></x:a>`, `<x:a xmlns:x="x"><x:extLst><x:ext uri="u"/><x:ext uri="` + ExtURIPivotCacheDefinition + `">` + definition + `</x:ext></x:extLst></x:a>`},
+		{`<a><extLst><ext uri="` + ExtURIPivotCacheDefinition + `"><x14:pivotCacheDefinition/></ext></extLst></a>`, `<a><extLst><ext uri="` + ExtURIPivotCacheDefinition + `"><x14:pivotCacheDefinition pivotCacheId="2"/></ext></extLst></a>`},
+	} {
+		content, cacheID, err := setPivotCacheSlicerID(c[0], 2)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, cacheID)
+		assert.Equal(t, c[1], content)
+	}
+	// Test get the existing pivot cache ID
+	content := `<a><extLst><ext uri="` + ExtURIPivotCacheDefinition + `"><x14:pivotCacheDefinition pivotCacheId="3"/></ext></extLst></a>`
+	result, cacheID, err := setPivotCacheSlicerID(content, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, cacheID)
+	assert.Equal(t, content, result)
+	// Test set the pivot cache ID with the invalid content
+	for _, content := range []string{
+		"",
+		"<a><extLst></x></extLst></a>",
+		"<a><extLst><ext></x></extLst></a>",
+		`<a><extLst><ext uri="` + ExtURIPivotCacheDefinition + `"></ext></extLst></a>`,
+		`<a><extLst><ext uri="` + ExtURIPivotCacheDefinition + `"><x14:pivotCacheDefinition pivotCacheId="x"/></ext></extLst></a>`,
+	} {
+		_, _, err = setPivotCacheSlicerID(content, 2)
+		assert.Error(t, err, content)
+	}
+}
+
+func TestGetSlicers(t *testing.T) {
+	f := NewFile()
+	slicers, err := f.GetSlicers("Sheet1")
+	assert.NoError(t, err)
+	assert.Empty(t, slicers)
+	// Test get slicers with not exist worksheet
+	_, err = f.GetSlicers("SheetN")
+	assert.EqualError(t, err, "sheet SheetN is not exist")
+	// Test get slicers with the invalid extension list
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	ws.ExtLst = &xlsxExtLst{Ext: "<ext></x>"}
+	_, err = f.GetSlicers("Sheet1")
+	assert.EqualError(t, err, ErrParameterInvalid.Error())
+	// Test get slicers with the slicer part which doesn't exist
+	ws.ExtLst = &xlsxExtLst{Ext: fmt.Sprintf(`<ext uri="%s"><x14:slicerList><x14:slicer r:id="rId1"/></x14:slicerList></ext>`, ExtURISlicerListX14)}
+	slicers, err = f.GetSlicers("Sheet1")
+	assert.NoError(t, err)
+	assert.Empty(t, slicers)
+	// Test get slicers with the invalid slicer part
+	f.Relationships.Store("xl/worksheets/_rels/sheet1.xml.rels", &xlsxRelationships{Relationships: []xlsxRelationship{
+		{ID: "rId1", Type: SourceRelationshipSlicer, Target: "../slicers/slicer1.xml"},
+	}})
+	f.Pkg.Store("xl/slicers/slicer1.xml", MacintoshCyrillicCharset)
+	_, err = f.GetSlicers("Sheet1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	// Test get slicers with the invalid slicer cache part
+	f.Pkg.Store("xl/slicers/slicer1.xml", []byte(`<slicers xmlns="http://schemas.microsoft.com/office/spreadsheetml/2009/9/main"><slicer name="Region" cache="Slicer_Region" rowHeight="241300"/></slicers>`))
+	f.Relationships.Store(f.getWorkbookRelsPath(), &xlsxRelationships{Relationships: []xlsxRelationship{
+		{ID: "rId1", Type: SourceRelationshipSlicerCache, Target: "slicerCaches/slicerCache1.xml"},
+	}})
+	f.Pkg.Store("xl/slicerCaches/slicerCache1.xml", MacintoshCyrillicCharset)
+	_, err = f.GetSlicers("Sheet1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	f.Pkg.Store("xl/slicerCaches/slicerCache1.xml", []byte(`<slicerCacheDefinition xmlns="http://schemas.microsoft.com/office/spreadsheetml/2009/9/main" name="Slicer_Region" sourceName="Region"><extLst><ext/></extLst></slicerCacheDefinition>`))
+	slicers, err = f.GetSlicers("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []SlicerOptions{{Name: "Region"}}, slicers)
+	// Test get slicers with the invalid alternate content in the drawing
+	ws.Drawing = &xlsxDrawing{RID: "rId2"}
+	f.Relationships.Store("xl/worksheets/_rels/sheet1.xml.rels", &xlsxRelationships{Relationships: []xlsxRelationship{
+		{ID: "rId1", Type: SourceRelationshipSlicer, Target: "../slicers/slicer1.xml"},
+		{ID: "rId2", Type: SourceRelationshipDrawingML, Target: "../drawings/drawing1.xml"},
+	}})
+	f.Drawings.Store("xl/drawings/drawing1.xml", &xlsxWsDr{AlternateContent: []*xlsxAlternateContent{{Content: "<mc:Choice>"}}})
+	_, err = f.GetSlicers("Sheet1")
+	assert.EqualError(t, err, "XML syntax error on line 1: element <Choice> closed by </AlternateContent>")
+	assert.NoError(t, f.Close())
+}
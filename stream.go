@@ -13,6 +13,7 @@ package excelize
 
 import (
 	"bytes"
+	"encoding/json"
 	"encoding/xml"
 	"fmt"
 	"io"
@@ -438,11 +439,7 @@ func (sw *StreamWriter) setCellValFunc(c *xlsxC, val interface{}) (err error) {
 		c.T, c.V = setCellDuration(val)
 	case time.Time:
 		var isNum bool
-		date1904, wb := false, sw.File.workbookReader()
-		if wb != nil && wb.WorkbookPr != nil {
-			date1904 = wb.WorkbookPr.Date1904
-		}
-		c.T, c.V, isNum, err = setCellTime(val, date1904)
+		c.T, c.V, isNum, err = setCellTime(val, sw.File.date1904())
 		if isNum && c.S == 0 {
 			style, _ := sw.File.NewStyle(&Style{NumFmt: 22})
 			c.S = style
@@ -451,6 +448,14 @@ func (sw *StreamWriter) setCellValFunc(c *xlsxC, val interface{}) (err error) {
 		c.T, c.V = setCellBool(val)
 	case nil:
 		c.T, c.V, c.XMLSpace = setCellStr("")
+	case json.Number:
+		if isJSONNumber(val.String()) {
+			if err = checkJSONNumberRange(val.String()); err == nil {
+				c.V = val.String()
+			}
+			break
+		}
+		c.T, c.V, c.XMLSpace = setCellStr(val.String())
 	default:
 		c.T, c.V, c.XMLSpace = setCellStr(fmt.Sprint(val))
 	}
@@ -1,6 +1,7 @@
 package excelize
 
 import (
+	"encoding/json"
 	"encoding/xml"
 	"fmt"
 	"io/ioutil"
@@ -244,4 +245,14 @@ func TestSetCellValFunc(t *testing.T) {
 	assert.NoError(t, sw.setCellValFunc(c, true))
 	assert.NoError(t, sw.setCellValFunc(c, nil))
 	assert.NoError(t, sw.setCellValFunc(c, complex64(5+10i)))
+	c = &xlsxC{}
+	assert.NoError(t, sw.setCellValFunc(c, json.Number("12345678901234567.123")))
+	assert.Equal(t, xlsxC{V: "12345678901234567.123"}, *c)
+	c = &xlsxC{}
+	assert.NoError(t, sw.setCellValFunc(c, json.Number(" 1")))
+	assert.Equal(t, "str", c.T)
+	assert.Equal(t, " 1", c.V)
+	c = &xlsxC{}
+	assert.EqualError(t, sw.setCellValFunc(c, json.Number("-1e400")), ErrJSONNumberRange.Error())
+	assert.Empty(t, c.V)
 }
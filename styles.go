@@ -20,8 +20,11 @@ import (
 	"log"
 	"math"
 	"reflect"
+	"regexp"
 	"strconv"
 	"strings"
+
+	"github.com/mohae/deepcopy"
 )
 
 // Excel styles can reference number formats that are built-in, all of which
@@ -62,6 +65,18 @@ var builtInNumFmt = map[int]string{
 	49: "@",
 }
 
+// builtInNumFmtCode defined the built-in number format codes as Excel writes
+// them, which are different from the lower case codes in the builtInNumFmt.
+var builtInNumFmtCode = map[int]string{
+	0:  "General",
+	11: "0.00E+00",
+	18: "h:mm AM/PM",
+	19: "h:mm:ss AM/PM",
+	38: "#,##0 ;[Red](#,##0)",
+	40: "#,##0.00;[Red](#,##0.00)",
+	48: "##0.0E+0",
+}
+
 // langNumFmt defined number format code (with unicode values provided for
 // language glyphs where they occur) in different language.
 var langNumFmt = map[string]map[int]string{
@@ -799,8 +814,8 @@ var validType = map[string]string{
 	"unique":        "uniqueValues",
 	"top":           "top10",
 	"bottom":        "top10",
-	"text":          "text",              // Doesn't support currently
-	"time_period":   "timePeriod",        // Doesn't support currently
+	"text":          "text", // Doesn't support currently
+	"time_period":   "timePeriod",
 	"blanks":        "containsBlanks",    // Doesn't support currently
 	"no_blanks":     "notContainsBlanks", // Doesn't support currently
 	"errors":        "containsErrors",    // Doesn't support currently
@@ -835,13 +850,59 @@ var criteriaType = map[string]string{
 	"ends with":                "endsWith",
 	"yesterday":                "yesterday",
 	"today":                    "today",
+	"tomorrow":                 "tomorrow",
 	"last 7 days":              "last7Days",
 	"last week":                "lastWeek",
 	"this week":                "thisWeek",
-	"continue week":            "continueWeek",
+	"next week":                "nextWeek",
+	"continue week":            "nextWeek",
 	"last month":               "lastMonth",
 	"this month":               "thisMonth",
-	"continue month":           "continueMonth",
+	"next month":               "nextMonth",
+	"continue month":           "nextMonth",
+}
+
+// criteriaNames defined the criteria names of the conditional formatting
+// rules by the operator or time period of the rules.
+var criteriaNames = map[string]string{
+	"between":            "between",
+	"notBetween":         "not between",
+	"equal":              "equal to",
+	"notEqual":           "not equal to",
+	"greaterThan":        "greater than",
+	"lessThan":           "less than",
+	"greaterThanOrEqual": "greater than or equal to",
+	"lessThanOrEqual":    "less than or equal to",
+	"containsText":       "containing",
+	"notContains":        "not containing",
+	"beginsWith":         "begins with",
+	"endsWith":           "ends with",
+	"yesterday":          "yesterday",
+	"today":              "today",
+	"tomorrow":           "tomorrow",
+	"last7Days":          "last 7 days",
+	"lastWeek":           "last week",
+	"thisWeek":           "this week",
+	"nextWeek":           "next week",
+	"lastMonth":          "last month",
+	"thisMonth":          "this month",
+	"nextMonth":          "next month",
+}
+
+// timePeriodFormulas defined the formulas of the time period conditional
+// formatting rules, the placeholder will be replaced with the top-left cell
+// of the range.
+var timePeriodFormulas = map[string]string{
+	"yesterday": "FLOOR(%[1]s,1)=TODAY()-1",
+	"today":     "FLOOR(%[1]s,1)=TODAY()",
+	"tomorrow":  "FLOOR(%[1]s,1)=TODAY()+1",
+	"last7Days": "AND(TODAY()-FLOOR(%[1]s,1)<=6,FLOOR(%[1]s,1)<=TODAY())",
+	"lastWeek":  "AND(TODAY()-ROUNDDOWN(%[1]s,0)>=(WEEKDAY(TODAY())),TODAY()-ROUNDDOWN(%[1]s,0)<(WEEKDAY(TODAY())+7))",
+	"thisWeek":  "AND(TODAY()-ROUNDDOWN(%[1]s,0)<=WEEKDAY(TODAY())-1,ROUNDDOWN(%[1]s,0)-TODAY()<=7-WEEKDAY(TODAY()))",
+	"nextWeek":  "AND(ROUNDDOWN(%[1]s,0)-TODAY()>(7-WEEKDAY(TODAY())),ROUNDDOWN(%[1]s,0)-TODAY()<(15-WEEKDAY(TODAY())))",
+	"lastMonth": "AND(MONTH(%[1]s)=MONTH(EDATE(TODAY(),0-1)),YEAR(%[1]s)=YEAR(EDATE(TODAY(),0-1)))",
+	"thisMonth": "AND(MONTH(%[1]s)=MONTH(TODAY()),YEAR(%[1]s)=YEAR(TODAY()))",
+	"nextMonth": "AND(MONTH(%[1]s)=MONTH(EDATE(TODAY(),0+1)),YEAR(%[1]s)=YEAR(EDATE(TODAY(),0+1)))",
 }
 
 // formatToInt provides a function to convert original string to integer
@@ -974,6 +1035,11 @@ func parseFormatStyleSet(style interface{}) (*Style, error) {
 			return &fs, ErrFontSize
 		}
 	}
+	if fs.Alignment != nil {
+		if rotation := fs.Alignment.TextRotation; (rotation < -90 || rotation > 180) && rotation != TextRotationVertical {
+			return &fs, ErrTextRotation
+		}
+	}
 	if fs.CustomNumFmt != nil && len(*fs.CustomNumFmt) == 0 {
 		err = ErrCustomNumFmt
 	}
@@ -1852,6 +1918,16 @@ func parseFormatStyleFromJson(style string) (*StyleOutput, error) {
 //	err = f.SetCellStyle("Sheet1", "A6", "A6", style)
 //
 // Cell Sheet1!A6 in the Excel Application: martes, 04 de Julio de 2017
+//
+// The QuotePrefix specifies the cell value should be treated as text, as the
+// same as typing a leading apostrophe in Excel, so that the numeric-looking
+// string such as "007" will not be converted to a number when editing the
+// cell, and Excel will not show the number stored as text error indicator.
+// For example:
+//
+//	style, err := f.NewStyle(&excelize.Style{QuotePrefix: true})
+//	err = f.SetCellStyle("Sheet1", "A1", "A1", style)
+//	err = f.SetCellStr("Sheet1", "A1", "007")
 func (f *File) NewStyle(style interface{}) (int, error) {
 	var fs *Style
 	var err error
@@ -1906,6 +1982,9 @@ func (f *File) NewStyle(style interface{}) (int, error) {
 	applyAlignment, alignment := fs.Alignment != nil, newAlignment(fs)
 	applyProtection, protection := fs.Protection != nil, newProtection(fs)
 	cellXfsID = setCellXfs(s, fontID, numFmtID, fillID, borderID, applyAlignment, applyProtection, alignment, protection, fs.Lang)
+	if fs.QuotePrefix {
+		s.CellXfs.Xf[cellXfsID].QuotePrefix = boolPtr(true)
+	}
 	return cellXfsID, nil
 }
 
@@ -2000,6 +2079,9 @@ var getXfIDFuncs = map[string]func(int, xlsxXf, *Style) bool{
 		}
 		return reflect.DeepEqual(xf.Protection, newProtection(style)) && xf.ApplyProtection != nil && *xf.ApplyProtection
 	},
+	"quotePrefix": func(ID int, xf xlsxXf, style *Style) bool {
+		return (xf.QuotePrefix != nil && *xf.QuotePrefix) == style.QuotePrefix
+	},
 }
 
 // getStyleID provides a function to get styleID by given style. If given
@@ -2019,7 +2101,8 @@ func (f *File) getStyleID(ss *xlsxStyleSheet, style *Style) (styleID int) {
 			getXfIDFuncs["fill"](fillID, xf, style) &&
 			getXfIDFuncs["border"](borderID, xf, style) &&
 			getXfIDFuncs["alignment"](0, xf, style) &&
-			getXfIDFuncs["protection"](0, xf, style) {
+			getXfIDFuncs["protection"](0, xf, style) &&
+			getXfIDFuncs["quotePrefix"](0, xf, style) {
 			styleID = xfID
 			return
 		}
@@ -2060,6 +2143,184 @@ func (f *File) NewConditionalStyle(style string) (int, error) {
 	return s.Dxfs.Count - 1, nil
 }
 
+// GetConditionalStyle provides a function to get the style definition of the
+// conditional format by given style index, which is returned by the function
+// NewConditionalStyle or referenced by the format of the conditional
+// formatting rules. The colors which reference the theme or the indexed
+// color palette will be resolved to the RGB value. For example, copy the
+// conditional format style from the workbook f1 to the workbook f2:
+//
+//	style, err := f1.GetConditionalStyle(0)
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	styleJSON, err := json.Marshal(style)
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	format, err := f2.NewConditionalStyle(string(styleJSON))
+func (f *File) GetConditionalStyle(idx int) (*Style, error) {
+	s := f.stylesReader()
+	if s.Dxfs == nil || idx < 0 || idx >= len(s.Dxfs.Dxfs) {
+		return nil, newInvalidStyleID(idx)
+	}
+	var d dxf
+	if err := xml.Unmarshal([]byte("<dxf>"+s.Dxfs.Dxfs[idx].Dxf+"</dxf>"), &d); err != nil {
+		return nil, err
+	}
+	style := &Style{
+		Border:     f.extractBorders(d.Border),
+		Font:       f.extractFont(d.Font),
+		Alignment:  extractAlignment(d.Alignment),
+		Protection: extractProtection(d.Protection),
+	}
+	if d.Fill != nil {
+		style.Fill = f.extractFill(d.Fill)
+	}
+	if d.NumFmt != nil {
+		if style.NumFmt = d.NumFmt.NumFmtID; d.NumFmt.FormatCode != "" {
+			style.CustomNumFmt = stringPtr(d.NumFmt.FormatCode)
+		}
+	}
+	return style, nil
+}
+
+// extractFont provides a function to convert the font to the font format
+// settings.
+func (f *File) extractFont(fnt *xlsxFont) *Font {
+	if fnt == nil {
+		return nil
+	}
+	isSet := func(val *attrValBool) bool {
+		return val != nil && (val.Val == nil || *val.Val)
+	}
+	font := &Font{Bold: isSet(fnt.B), Italic: isSet(fnt.I), Strike: isSet(fnt.Strike)}
+	if fnt.U != nil {
+		if font.Underline = "single"; fnt.U.Val != nil {
+			font.Underline = *fnt.U.Val
+		}
+	}
+	if fnt.VertAlign != nil && fnt.VertAlign.Val != nil && *fnt.VertAlign.Val != "baseline" {
+		font.VertAlign = *fnt.VertAlign.Val
+	}
+	if fnt.Name != nil && fnt.Name.Val != nil {
+		font.Family = *fnt.Name.Val
+	}
+	if fnt.Sz != nil && fnt.Sz.Val != nil {
+		font.Size = *fnt.Sz.Val
+	}
+	if color := f.getColorRGB(fnt.Color); color != "" {
+		font.Color = "#" + color
+	}
+	return font
+}
+
+// extractFill provides a function to convert the fill to the fill format
+// settings. The pattern fill without the pattern type in the differential
+// formatting will be treated as the solid fill.
+func (f *File) extractFill(fill *xlsxFill) Fill {
+	var fl Fill
+	if fill.GradientFill != nil {
+		fl.Type = "gradient"
+		for idx, variant := range styleFillVariants {
+			if fill.GradientFill.Degree == variant {
+				fl.Shading = idx
+			}
+		}
+		if fill.GradientFill.Type == "path" {
+			if fl.Shading = 4; fill.GradientFill.Left == 0.5 {
+				fl.Shading = 5
+			}
+		}
+		for _, stop := range fill.GradientFill.Stop {
+			if color := f.getColorRGB(&stop.Color); color != "" {
+				fl.Color = append(fl.Color, "#"+color)
+			}
+		}
+		return fl
+	}
+	if fill.PatternFill != nil {
+		fl.Type = "pattern"
+		if fl.Pattern = inStrSlice(styleFillPatterns, fill.PatternFill.PatternType, true); fl.Pattern == -1 {
+			fl.Pattern = 1
+		}
+		color := f.getColorRGB(fill.PatternFill.BgColor)
+		if color == "" {
+			color = f.getColorRGB(fill.PatternFill.FgColor)
+		}
+		if color != "" {
+			fl.Color = []string{"#" + color}
+		}
+	}
+	return fl
+}
+
+// extractBorders provides a function to convert the border to the borders
+// format settings.
+func (f *File) extractBorders(border *xlsxBorder) []Border {
+	var borders []Border
+	if border == nil {
+		return borders
+	}
+	add := func(typ string, line xlsxLine) {
+		if idx := inStrSlice(styleBorders, line.Style, true); idx > 0 {
+			b := Border{Type: typ, Style: idx}
+			if color := f.getColorRGB(line.Color); color != "" {
+				b.Color = "#" + color
+			}
+			borders = append(borders, b)
+		}
+	}
+	add("left", border.Left)
+	add("right", border.Right)
+	add("top", border.Top)
+	add("bottom", border.Bottom)
+	if border.DiagonalUp {
+		add("diagonalUp", border.Diagonal)
+	}
+	if border.DiagonalDown {
+		add("diagonalDown", border.Diagonal)
+	}
+	return borders
+}
+
+// extractAlignment provides a function to convert the alignment to the
+// alignment format settings.
+func extractAlignment(alignment *xlsxAlignment) *Alignment {
+	if alignment == nil {
+		return nil
+	}
+	a := &Alignment{
+		Horizontal:      alignment.Horizontal,
+		Indent:          alignment.Indent,
+		JustifyLastLine: alignment.JustifyLastLine,
+		ReadingOrder:    alignment.ReadingOrder,
+		RelativeIndent:  alignment.RelativeIndent,
+		ShrinkToFit:     alignment.ShrinkToFit,
+		TextRotation:    alignment.TextRotation,
+		Vertical:        alignment.Vertical,
+		WrapText:        alignment.WrapText,
+	}
+	if a.TextRotation > 90 && a.TextRotation <= 180 {
+		a.TextRotation = 90 - a.TextRotation
+	}
+	return a
+}
+
+// extractProtection provides a function to convert the protection to the
+// protection format settings.
+func extractProtection(protection *xlsxProtection) *Protection {
+	if protection == nil {
+		return nil
+	}
+	return &Protection{
+		Hidden: protection.Hidden != nil && *protection.Hidden,
+		Locked: protection.Locked == nil || *protection.Locked,
+	}
+}
+
 // GetDefaultFont provides the default font name currently set in the
 // workbook. The spreadsheet generated by excelize default font is Calibri.
 func (f *File) GetDefaultFont() string {
@@ -2086,18 +2347,21 @@ func (f *File) readDefaultFont() *xlsxFont {
 // getFontID provides a function to get font ID.
 // If given font is not exist, will return -1.
 func (f *File) getFontID(styleSheet *xlsxStyleSheet, style *Style) (fontID int) {
+	if style.Font == nil {
+		return -1
+	}
 	return f.getFontIDImmediate(styleSheet, f.newFont(style))
 }
 
-// getFontIDImmediate provides a function to get font ID.
-// If given font is not exist, will return -1.
+// getFontIDImmediate provides a function to get font ID, the fonts are
+// compared by value. If given font is not exist, will return -1.
 func (f *File) getFontIDImmediate(styleSheet *xlsxStyleSheet, font *xlsxFont) (fontID int) {
 	fontID = -1
 	if styleSheet.Fonts == nil || font == nil {
 		return
 	}
 	for idx, fnt := range styleSheet.Fonts.Font {
-		if reflect.DeepEqual(*fnt, font) {
+		if reflect.DeepEqual(*fnt, *font) {
 			fontID = idx
 			return
 		}
@@ -2137,6 +2401,9 @@ func (f *File) newFont(style *Style) *xlsxFont {
 	if ok {
 		fnt.U = &attrValString{Val: stringPtr(val)}
 	}
+	if inStrSlice([]string{"superscript", "subscript"}, style.Font.VertAlign, true) != -1 {
+		fnt.VertAlign = &attrValString{Val: stringPtr(style.Font.VertAlign)}
+	}
 	return &fnt
 }
 
@@ -2368,38 +2635,37 @@ func getFillIDImmediate(styleSheet *xlsxStyleSheet, fills *xlsxFill) (fillID int
 	return
 }
 
+// styleFillPatterns defined the pattern types of the fill by the index of
+// the pattern in the fill format settings.
+var styleFillPatterns = []string{
+	"none",
+	"solid",
+	"mediumGray",
+	"darkGray",
+	"lightGray",
+	"darkHorizontal",
+	"darkVertical",
+	"darkDown",
+	"darkUp",
+	"darkGrid",
+	"darkTrellis",
+	"lightHorizontal",
+	"lightVertical",
+	"lightDown",
+	"lightUp",
+	"lightGrid",
+	"lightTrellis",
+	"gray125",
+	"gray0625",
+}
+
+// styleFillVariants defined the degrees of the gradient fill by the shading
+// index of the fill format settings.
+var styleFillVariants = []float64{90, 0, 45, 135}
+
 // newFills provides a function to add fill elements in the styles.xml by
 // given cell format settings.
 func newFills(style *Style, fg bool) *xlsxFill {
-	patterns := []string{
-		"none",
-		"solid",
-		"mediumGray",
-		"darkGray",
-		"lightGray",
-		"darkHorizontal",
-		"darkVertical",
-		"darkDown",
-		"darkUp",
-		"darkGrid",
-		"darkTrellis",
-		"lightHorizontal",
-		"lightVertical",
-		"lightDown",
-		"lightUp",
-		"lightGrid",
-		"lightTrellis",
-		"gray125",
-		"gray0625",
-	}
-
-	variants := []float64{
-		90,
-		0,
-		45,
-		135,
-	}
-
 	var fill xlsxFill
 	switch style.Fill.Type {
 	case "gradient":
@@ -2409,7 +2675,7 @@ func newFills(style *Style, fg bool) *xlsxFill {
 		var gradient xlsxGradientFill
 		switch style.Fill.Shading {
 		case 0, 1, 2, 3:
-			gradient.Degree = variants[style.Fill.Shading]
+			gradient.Degree = styleFillVariants[style.Fill.Shading]
 		case 4:
 			gradient.Type = "path"
 		case 5:
@@ -2436,7 +2702,7 @@ func newFills(style *Style, fg bool) *xlsxFill {
 			break
 		}
 		var pattern xlsxPatternFill
-		pattern.PatternType = patterns[style.Fill.Pattern]
+		pattern.PatternType = styleFillPatterns[style.Fill.Pattern]
 		if fg {
 			if pattern.FgColor == nil {
 				pattern.FgColor = new(xlsxColor)
@@ -2469,6 +2735,9 @@ func newAlignment(style *Style) *xlsxAlignment {
 		alignment.RelativeIndent = style.Alignment.RelativeIndent
 		alignment.ShrinkToFit = style.Alignment.ShrinkToFit
 		alignment.TextRotation = style.Alignment.TextRotation
+		if alignment.TextRotation < 0 {
+			alignment.TextRotation = 90 - alignment.TextRotation
+		}
 		alignment.Vertical = style.Alignment.Vertical
 		alignment.WrapText = style.Alignment.WrapText
 	}
@@ -2492,15 +2761,15 @@ func getBorderID(styleSheet *xlsxStyleSheet, style *Style) (borderID int) {
 	return getBorderIDImmediate(styleSheet, newBorders(style))
 }
 
-// getBorderIDImmediate provides a function to get border ID. If given border is not
-// exist, will return -1.
+// getBorderIDImmediate provides a function to get border ID, the borders are
+// compared by value. If given border is not exist, will return -1.
 func getBorderIDImmediate(styleSheet *xlsxStyleSheet, newBorder *xlsxBorder) (borderID int) {
 	borderID = -1
 	if styleSheet.Borders == nil || newBorder == nil {
 		return
 	}
 	for idx, border := range styleSheet.Borders.Border {
-		if reflect.DeepEqual(*border, newBorder) {
+		if reflect.DeepEqual(border, newBorder) {
 			borderID = idx
 			return
 		}
@@ -2508,50 +2777,52 @@ func getBorderIDImmediate(styleSheet *xlsxStyleSheet, newBorder *xlsxBorder) (bo
 	return
 }
 
+// styleBorders defined the line styles of the border by the index of the
+// style in the border format settings.
+var styleBorders = []string{
+	"none",
+	"thin",
+	"medium",
+	"dashed",
+	"dotted",
+	"thick",
+	"double",
+	"hair",
+	"mediumDashed",
+	"dashDot",
+	"mediumDashDot",
+	"dashDotDot",
+	"mediumDashDotDot",
+	"slantDashDot",
+}
+
 // newBorders provides a function to add border elements in the styles.xml by
 // given borders format settings.
 func newBorders(style *Style) *xlsxBorder {
-	styles := []string{
-		"none",
-		"thin",
-		"medium",
-		"dashed",
-		"dotted",
-		"thick",
-		"double",
-		"hair",
-		"mediumDashed",
-		"dashDot",
-		"mediumDashDot",
-		"dashDotDot",
-		"mediumDashDotDot",
-		"slantDashDot",
-	}
-
 	var border xlsxBorder
 	for _, v := range style.Border {
-		if 0 <= v.Style && v.Style < 14 {
+		if 0 <= v.Style && v.Style < len(styleBorders) {
 			var color xlsxColor
 			color.RGB = getPaletteColor(v.Color)
 			switch v.Type {
 			case "left":
-				border.Left.Style = styles[v.Style]
+				border.Left.Style = styleBorders[v.Style]
 				border.Left.Color = &color
 			case "right":
-				border.Right.Style = styles[v.Style]
+				border.Right.Style = styleBorders[v.Style]
 				border.Right.Color = &color
 			case "top":
-				border.Top.Style = styles[v.Style]
+				border.Top.Style = styleBorders[v.Style]
 				border.Top.Color = &color
 			case "bottom":
-				border.Bottom.Style = styles[v.Style]
+				border.Bottom.Style = styleBorders[v.Style]
 				border.Bottom.Color = &color
 			case "diagonalUp":
-				border.Diagonal.Style = styles[v.Style]
+				border.Diagonal.Style = styleBorders[v.Style]
 				border.Diagonal.Color = &color
 				border.DiagonalUp = true
 			case "diagonalDown":
-				border.Diagonal.Style = styles[v.Style]
+				border.Diagonal.Style = styleBorders[v.Style]
 				border.Diagonal.Color = &color
 				border.DiagonalDown = true
 			}
@@ -2560,6 +2831,29 @@ func newBorders(style *Style) *xlsxBorder {
 	return &border
 }
 
+// setCellXfsWith provides a function to get the cell style index which has
+// the same formatting as the given cell style index except the formatting
+// changed by the given function. A new cell style will be created if it
+// doesn't exist.
+func setCellXfsWith(style *xlsxStyleSheet, styleID int, fn func(xf *xlsxXf)) int {
+	var xf xlsxXf
+	if style.CellXfs == nil {
+		style.CellXfs = &xlsxCellXfs{}
+	}
+	if styleID < len(style.CellXfs.Xf) {
+		xf = style.CellXfs.Xf[styleID]
+	}
+	fn(&xf)
+	for idx, cellXf := range style.CellXfs.Xf {
+		if reflect.DeepEqual(cellXf, xf) {
+			return idx
+		}
+	}
+	style.CellXfs.Xf = append(style.CellXfs.Xf, xf)
+	style.CellXfs.Count = len(style.CellXfs.Xf)
+	return style.CellXfs.Count - 1
+}
+
 // setCellXfs provides a function to set describes all of the formatting for a
 // cell.
 func setCellXfs(style *xlsxStyleSheet, fontID, numFmtID, fillID, borderID int, applyAlignment, applyProtection bool, alignment *xlsxAlignment, protection *xlsxProtection, lang string) int {
@@ -2610,6 +2904,83 @@ func (f *File) GetCellStyle(sheet, axis string) (int, error) {
 	return f.prepareCellStyle(ws, col, row, cellData.S), err
 }
 
+// GetEffectiveCellStyle provides a function to get the effective style of
+// the cell by given worksheet name and cell reference. The style of the cell
+// which has no style will be resolved from the row or column default style,
+// and each part of the cell format, including the number format, font, fill,
+// border, alignment and protection, will be resolved from the referenced cell
+// style (named style) if the cell format doesn't apply it. For example, get
+// the effective style of the cell A1 on Sheet1:
+//
+//	style, err := f.GetEffectiveCellStyle("Sheet1", "A1")
+func (f *File) GetEffectiveCellStyle(sheet, cell string) (*Style, error) {
+	styleID, err := f.GetCellStyle(sheet, cell)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		numFmt     *xlsxNumFmt
+		font       *xlsxFont
+		fill       *xlsxFill
+		border     *xlsxBorder
+		alignment  *xlsxAlignment
+		protection *xlsxProtection
+		style      = &Style{}
+	)
+	s := f.stylesReader()
+	s.Lock()
+	if s.CellXfs == nil || styleID < 0 || styleID >= len(s.CellXfs.Xf) {
+		s.Unlock()
+		return style, err
+	}
+	xf := s.CellXfs.Xf[styleID]
+	styleXf := xf
+	if s.CellStyleXfs != nil && xf.XfID != nil && *xf.XfID >= 0 && *xf.XfID < len(s.CellStyleXfs.Xf) {
+		styleXf = s.CellStyleXfs.Xf[*xf.XfID]
+	}
+	// resolve returns the named style format if the cell format doesn't
+	// apply the part of the format.
+	resolve := func(apply *bool) xlsxXf {
+		if apply != nil && !*apply {
+			return styleXf
+		}
+		return xf
+	}
+	if numFmtID := resolve(xf.ApplyNumberFormat).NumFmtID; numFmtID != nil {
+		numFmt = &xlsxNumFmt{NumFmtID: *numFmtID}
+		if s.NumFmts != nil {
+			for _, nf := range s.NumFmts.NumFmt {
+				if nf.NumFmtID == *numFmtID {
+					numFmt.FormatCode = nf.FormatCode
+				}
+			}
+		}
+	}
+	if fontID := resolve(xf.ApplyFont).FontID; fontID != nil && s.Fonts != nil && *fontID >= 0 && *fontID < len(s.Fonts.Font) {
+		font = s.Fonts.Font[*fontID]
+	}
+	if fillID := resolve(xf.ApplyFill).FillID; fillID != nil && s.Fills != nil && *fillID >= 0 && *fillID < len(s.Fills.Fill) {
+		fill = s.Fills.Fill[*fillID]
+	}
+	if borderID := resolve(xf.ApplyBorder).BorderID; borderID != nil && s.Borders != nil && *borderID >= 0 && *borderID < len(s.Borders.Border) {
+		border = s.Borders.Border[*borderID]
+	}
+	alignment, protection = resolve(xf.ApplyAlignment).Alignment, resolve(xf.ApplyProtection).Protection
+	style.QuotePrefix = xf.QuotePrefix != nil && *xf.QuotePrefix
+	s.Unlock()
+	if numFmt != nil {
+		if style.NumFmt = numFmt.NumFmtID; numFmt.FormatCode != "" {
+			style.CustomNumFmt = stringPtr(numFmt.FormatCode)
+		}
+	}
+	if fill != nil {
+		style.Fill = f.extractFill(fill)
+	}
+	style.Font, style.Border = f.extractFont(font), f.extractBorders(border)
+	style.Alignment, style.Protection = extractAlignment(alignment), extractProtection(protection)
+	return style, err
+}
+
 // GetCellStyleJson provides a function to get cell style index by given worksheet
 // name and cell coordinates.
 func (f *File) GetCellStyleJson(sheet, axis string) (string, error) {
@@ -2652,14 +3023,15 @@ func (f *File) GetCellStyleJson(sheet, axis string) (string, error) {
 	if fi(xf.NumFmtID) > 0 {
 		so.NumFmt = s.NumFmts.NumFmt[fi(xf.NumFmtID)]
 	}
+	palette := s.getIndexedColors()
 	if fi(xf.FontID) > 0 {
-		so.Font = s.Fonts.Font[fi(xf.FontID)]
+		so.Font = f.resolveFontColor(palette, s.Fonts.Font[fi(xf.FontID)])
 	}
 	if fi(xf.BorderID) > 0 {
-		so.Border = s.Borders.Border[fi(xf.BorderID)]
+		so.Border = f.resolveBorderColor(palette, s.Borders.Border[fi(xf.BorderID)])
 	}
 	if fi(xf.FillID) > 0 {
-		so.Fill = s.Fills.Fill[fi(xf.FillID)]
+		so.Fill = f.resolveFillColor(palette, s.Fills.Fill[fi(xf.FillID)])
 	}
 
 	marshal, err := json.Marshal(so)
@@ -2670,149 +3042,1296 @@ func (f *File) GetCellStyleJson(sheet, axis string) (string, error) {
 	return string(marshal), nil
 }
 
-// SetCellStyle provides a function to add style attribute for cells by given
-// worksheet name, coordinate area and style ID. Note that diagonalDown and
-// diagonalUp type border should be use same color in the same coordinate
-// area. SetCellStyle will overwrite the existing styles for the cell, it
-// won't append or merge style with existing styles.
-//
-// For example create a borders of cell H9 on Sheet1:
-//
-//	style, err := f.NewStyle(&excelize.Style{
-//	    Border: []excelize.Border{
-//	        {Type: "left", Color: "0000FF", Style: 3},
-//	        {Type: "top", Color: "00FF00", Style: 4},
-//	        {Type: "bottom", Color: "FFFF00", Style: 5},
-//	        {Type: "right", Color: "FF0000", Style: 6},
-//	        {Type: "diagonalDown", Color: "A020F0", Style: 7},
-//	        {Type: "diagonalUp", Color: "A020F0", Style: 8},
-//	    },
-//	})
-//	if err != nil {
-//	    fmt.Println(err)
-//	}
-//	err = f.SetCellStyle("Sheet1", "H9", "H9", style)
-//
-// Set gradient fill with vertical variants shading styles for cell H9 on
-// Sheet1:
-//
-//	style, err := f.NewStyle(&excelize.Style{
-//	    Fill: excelize.Fill{Type: "gradient", Color: []string{"#FFFFFF", "#E0EBF5"}, Shading: 1},
-//	})
-//	if err != nil {
-//	    fmt.Println(err)
-//	}
-//	err = f.SetCellStyle("Sheet1", "H9", "H9", style)
-//
-// Set solid style pattern fill for cell H9 on Sheet1:
-//
-//	style, err := f.NewStyle(&excelize.Style{
-//	    Fill: excelize.Fill{Type: "pattern", Color: []string{"#E0EBF5"}, Pattern: 1},
-//	})
-//	if err != nil {
-//	    fmt.Println(err)
-//	}
-//	err = f.SetCellStyle("Sheet1", "H9", "H9", style)
-//
-// Set alignment style for cell H9 on Sheet1:
-//
-//	style, err := f.NewStyle(&excelize.Style{
-//	    Alignment: &excelize.Alignment{
-//	        Horizontal:      "center",
-//	        Indent:          1,
-//	        JustifyLastLine: true,
-//	        ReadingOrder:    0,
-//	        RelativeIndent:  1,
-//	        ShrinkToFit:     true,
-//	        TextRotation:    45,
-//	        Vertical:        "",
-//	        WrapText:        true,
-//	    },
-//	})
-//	if err != nil {
-//	    fmt.Println(err)
-//	}
-//	err = f.SetCellStyle("Sheet1", "H9", "H9", style)
-//
-// Dates and times in Excel are represented by real numbers, for example "Apr 7
-// 2017 12:00 PM" is represented by the number 42920.5. Set date and time format
-// for cell H9 on Sheet1:
-//
-//	f.SetCellValue("Sheet1", "H9", 42920.5)
-//	style, err := f.NewStyle(&excelize.Style{NumFmt: 22})
-//	if err != nil {
-//	    fmt.Println(err)
-//	}
-//	err = f.SetCellStyle("Sheet1", "H9", "H9", style)
-//
-// Set font style for cell H9 on Sheet1:
+// defaultIndexedColors defined the default legacy color palette, the RGB
+// values of the indexed colors 0 to 63.
+var defaultIndexedColors = []string{
+	"000000", "FFFFFF", "FF0000", "00FF00", "0000FF", "FFFF00", "FF00FF", "00FFFF",
+	"000000", "FFFFFF", "FF0000", "00FF00", "0000FF", "FFFF00", "FF00FF", "00FFFF",
+	"800000", "008000", "000080", "808000", "800080", "008080", "C0C0C0", "808080",
+	"9999FF", "993366", "FFFFCC", "CCFFFF", "660066", "FF8080", "0066CC", "CCCCFF",
+	"000080", "FF00FF", "FFFF00", "00FFFF", "800080", "800000", "008080", "0000FF",
+	"00CCFF", "CCFFFF", "CCFFCC", "FFFF99", "99CCFF", "FF99CC", "CC99FF", "FFCC99",
+	"3366FF", "33CCCC", "99CC00", "FFCC00", "FF9900", "FF6600", "666699", "969696",
+	"003366", "339966", "003300", "333300", "993300", "993366", "333399", "333333",
+}
+
+// GetStyleSheet provides a function to get the read-only snapshot of the
+// styles of the workbook, include the custom number formats, fonts, fills,
+// borders, cell style formats and cell formats. Changes to the snapshot will
+// not affect the workbook. For example, print the font family of each cell
+// format in the workbook:
 //
-//	style, err := f.NewStyle(&excelize.Style{
-//	    Font: &excelize.Font{
-//	        Bold:   true,
-//	        Italic: true,
-//	        Family: "Times New Roman",
-//	        Size:   36,
-//	        Color:  "#777777",
-//	    },
-//	})
+//	ss, err := f.GetStyleSheet()
 //	if err != nil {
 //	    fmt.Println(err)
+//	    return
 //	}
-//	err = f.SetCellStyle("Sheet1", "H9", "H9", style)
-//
-// Hide and lock for cell H9 on Sheet1:
-//
-//	style, err := f.NewStyle(&excelize.Style{
-//	    Protection: &excelize.Protection{
-//	        Hidden: true,
-//	        Locked: true,
-//	    },
-//	})
-//	if err != nil {
-//	    fmt.Println(err)
+//	for idx, xf := range ss.CellXfs {
+//	    fmt.Println(idx, ss.Fonts[xf.FontID].Family)
 //	}
-//	err = f.SetCellStyle("Sheet1", "H9", "H9", style)
-func (f *File) SetCellStyle(sheet, hCell, vCell string, styleID int) error {
-	hCol, hRow, err := CellNameToCoordinates(hCell)
-	if err != nil {
-		return err
+func (f *File) GetStyleSheet() (*StyleSheetSnapshot, error) {
+	s := f.stylesReader()
+	s.Lock()
+	defer s.Unlock()
+	ss := &StyleSheetSnapshot{}
+	if s.NumFmts != nil {
+		for _, numFmt := range s.NumFmts.NumFmt {
+			ss.NumFmts = append(ss.NumFmts, StyleNumFmt{ID: numFmt.NumFmtID, FormatCode: numFmt.FormatCode})
+		}
 	}
-
-	vCol, vRow, err := CellNameToCoordinates(vCell)
-	if err != nil {
-		return err
+	if s.Fonts != nil {
+		for _, fnt := range s.Fonts.Font {
+			var font Font
+			if extracted := f.extractFont(fnt); extracted != nil {
+				font = *extracted
+			}
+			ss.Fonts = append(ss.Fonts, font)
+		}
 	}
-
-	// Normalize the coordinate area, such correct C1:B3 to B1:C3.
-	if vCol < hCol {
-		vCol, hCol = hCol, vCol
+	if s.Fills != nil {
+		for _, fill := range s.Fills.Fill {
+			var fl Fill
+			if fill != nil {
+				fl = f.extractFill(fill)
+			}
+			ss.Fills = append(ss.Fills, fl)
+		}
 	}
-
-	if vRow < hRow {
-		vRow, hRow = hRow, vRow
+	if s.Borders != nil {
+		for _, border := range s.Borders.Border {
+			ss.Borders = append(ss.Borders, f.extractBorders(border))
+		}
+	}
+	if s.CellStyleXfs != nil {
+		ss.CellStyleXfs = extractStyleXfs(s.CellStyleXfs.Xf)
+	}
+	if s.CellXfs != nil {
+		ss.CellXfs = extractStyleXfs(s.CellXfs.Xf)
 	}
+	return ss, nil
+}
 
-	hColIdx := hCol - 1
-	hRowIdx := hRow - 1
+// extractStyleXfs provides a function to convert the cell formats to the
+// cell formats of the style sheet snapshot.
+func extractStyleXfs(xfs []xlsxXf) []StyleXf {
+	fi := func(id *int) int {
+		if id == nil {
+			return 0
+		}
+		return *id
+	}
+	styleXfs := make([]StyleXf, 0, len(xfs))
+	for _, xf := range xfs {
+		styleXfs = append(styleXfs, StyleXf{
+			NumFmtID:    fi(xf.NumFmtID),
+			FontID:      fi(xf.FontID),
+			FillID:      fi(xf.FillID),
+			BorderID:    fi(xf.BorderID),
+			XfID:        fi(xf.XfID),
+			QuotePrefix: xf.QuotePrefix != nil && *xf.QuotePrefix,
+			Alignment:   extractAlignment(xf.Alignment),
+			Protection:  extractProtection(xf.Protection),
+		})
+	}
+	return styleXfs
+}
 
-	vColIdx := vCol - 1
+// PruneStyles provides a function to remove the cell formats which are not
+// referenced by any cell, row or column of the worksheets, and remove the
+// fonts, fills, borders and custom number formats which are not referenced
+// by the remaining cell formats and cell style formats, the style indexes in
+// the worksheets will be remapped. The differential formats which are not
+// referenced by the conditional formats, sort states, auto filters, tables,
+// pivot tables or table styles will be removed too, and the references of
+// the kept differential formats will be remapped. This function returns the
+// number of the removed style components. Note that the style indexes which
+// are written by the stream writer will not be remapped, so please call this
+// function before using the stream writer. For example:
+//
+//	removed, err := f.PruneStyles()
+func (f *File) PruneStyles() (int, error) {
+	var worksheets []*xlsxWorksheet
+	for _, name := range f.GetSheetList() {
+		ws, err := f.workSheetReader(name)
+		if err != nil {
+			if err.Error() == fmt.Sprintf("sheet %s is not a worksheet", trimSheetName(name)) {
+				continue
+			}
+			return 0, err
+		}
+		worksheets = append(worksheets, ws)
+	}
+	s := f.stylesReader()
+	s.Lock()
+	defer s.Unlock()
+	if s.CellXfs == nil || len(s.CellXfs.Xf) == 0 {
+		return 0, nil
+	}
+	used := make([]bool, len(s.CellXfs.Xf))
+	used[0] = true
+	mark := func(styleID int) {
+		if styleID > 0 && styleID < len(used) {
+			used[styleID] = true
+		}
+	}
+	for _, ws := range worksheets {
+		if ws.Cols != nil {
+			for _, col := range ws.Cols.Col {
+				mark(col.Style)
+			}
+		}
+		for _, row := range ws.SheetData.Row {
+			mark(row.S)
+			for _, c := range row.C {
+				mark(c.S)
+			}
+		}
+	}
+	var removed int
+	xfs, xfMap := make([]xlsxXf, 0, len(used)), make([]int, len(used))
+	for i, xf := range s.CellXfs.Xf {
+		if !used[i] {
+			removed++
+			continue
+		}
+		xfMap[i] = len(xfs)
+		xfs = append(xfs, xf)
+	}
+	s.CellXfs.Xf, s.CellXfs.Count = xfs, len(xfs)
+	remap := func(styleID int) int {
+		if styleID > 0 && styleID < len(xfMap) {
+			return xfMap[styleID]
+		}
+		return styleID
+	}
+	for _, ws := range worksheets {
+		if ws.Cols != nil {
+			for i := range ws.Cols.Col {
+				ws.Cols.Col[i].Style = remap(ws.Cols.Col[i].Style)
+			}
+		}
+		for r := range ws.SheetData.Row {
+			row := &ws.SheetData.Row[r]
+			row.S = remap(row.S)
+			for c := range row.C {
+				row.C[c].S = remap(row.C[c].S)
+			}
+		}
+	}
+	xfLists := [][]xlsxXf{s.CellXfs.Xf}
+	if s.CellStyleXfs != nil {
+		xfLists = append(xfLists, s.CellStyleXfs.Xf)
+	}
+	if s.Fonts != nil {
+		keep := remapStyleComponentIDs(len(s.Fonts.Font), 1, xfLists, func(xf *xlsxXf) **int { return &xf.FontID })
+		var fonts []*xlsxFont
+		for i, font := range s.Fonts.Font {
+			if keep[i] {
+				fonts = append(fonts, font)
+			}
+		}
+		removed += len(s.Fonts.Font) - len(fonts)
+		s.Fonts.Font, s.Fonts.Count = fonts, len(fonts)
+	}
+	if s.Fills != nil {
+		keep := remapStyleComponentIDs(len(s.Fills.Fill), 2, xfLists, func(xf *xlsxXf) **int { return &xf.FillID })
+		var fills []*xlsxFill
+		for i, fill := range s.Fills.Fill {
+			if keep[i] {
+				fills = append(fills, fill)
+			}
+		}
+		removed += len(s.Fills.Fill) - len(fills)
+		s.Fills.Fill, s.Fills.Count = fills, len(fills)
+	}
+	if s.Borders != nil {
+		keep := remapStyleComponentIDs(len(s.Borders.Border), 1, xfLists, func(xf *xlsxXf) **int { return &xf.BorderID })
+		var borders []*xlsxBorder
+		for i, border := range s.Borders.Border {
+			if keep[i] {
+				borders = append(borders, border)
+			}
+		}
+		removed += len(s.Borders.Border) - len(borders)
+		s.Borders.Border, s.Borders.Count = borders, len(borders)
+	}
+	removed += f.pruneDxfs(worksheets, s)
+	if s.NumFmts != nil {
+		numFmtIDs := make(map[int]bool)
+		for _, xfs := range xfLists {
+			for _, xf := range xfs {
+				if xf.NumFmtID != nil {
+					numFmtIDs[*xf.NumFmtID] = true
+				}
+			}
+		}
+		var numFmts []*xlsxNumFmt
+		for _, numFmt := range s.NumFmts.NumFmt {
+			if numFmtIDs[numFmt.NumFmtID] || s.isDxfNumFmt(numFmt.NumFmtID) {
+				numFmts = append(numFmts, numFmt)
+			}
+		}
+		removed += len(s.NumFmts.NumFmt) - len(numFmts)
+		s.NumFmts.NumFmt, s.NumFmts.Count = numFmts, len(numFmts)
+		if len(numFmts) == 0 {
+			s.NumFmts = nil
+		}
+	}
+	return removed, nil
+}
+
+// dxfIDRegexp matches the differential format index attributes in the raw XML
+// content, such as dxfId="1" or headerRowDxfId="2".
+var dxfIDRegexp = regexp.MustCompile(`(\s[\w:]*[dD]xfId)="(\d+)"`)
+
+// remapRawDxfIDs provides a function to replace the differential format
+// indexes in the raw XML content by given callback.
+func remapRawDxfIDs(content string, fn func(id int) int) string {
+	return dxfIDRegexp.ReplaceAllStringFunc(content, func(attr string) string {
+		match := dxfIDRegexp.FindStringSubmatch(attr)
+		id, _ := strconv.Atoi(match[2])
+		return fmt.Sprintf(`%s="%d"`, match[1], fn(id))
+	})
+}
+
+// rangeDxfIDs provides a function to walk through the differential format
+// references of the workbook by given worksheets, style sheet and callback,
+// and replace each reference with the index returned by the callback. The
+// references in the worksheets loaded in memory will be replaced in the
+// structures, and the references in the table styles and the other parts,
+// such as the tables and the pivot tables, will be replaced in the raw XML.
+func (f *File) rangeDxfIDs(worksheets []*xlsxWorksheet, s *xlsxStyleSheet, fn func(id int) int) {
+	remapPtr := func(id *int) {
+		if id != nil {
+			*id = fn(*id)
+		}
+	}
+	remapAutoFilter := func(autoFilter *xlsxAutoFilter) {
+		if autoFilter == nil {
+			return
+		}
+		for _, filterColumn := range autoFilter.FilterColumn {
+			if filterColumn.ColorFilter != nil {
+				filterColumn.ColorFilter.DxfID = fn(filterColumn.ColorFilter.DxfID)
+			}
+		}
+	}
+	remapExtLst := func(extLst *xlsxExtLst) {
+		if extLst != nil {
+			extLst.Ext = remapRawDxfIDs(extLst.Ext, fn)
+		}
+	}
+	for _, ws := range worksheets {
+		for _, cf := range ws.ConditionalFormatting {
+			for _, rule := range cf.CfRule {
+				remapPtr(rule.DxfID)
+			}
+		}
+		remapAutoFilter(ws.AutoFilter)
+		if ws.SortState != nil {
+			for _, condition := range ws.SortState.SortCondition {
+				remapPtr(condition.DxfID)
+			}
+			remapExtLst(ws.SortState.ExtLst)
+		}
+		if ws.CustomSheetViews != nil {
+			for _, view := range ws.CustomSheetViews.CustomSheetView {
+				remapAutoFilter(view.AutoFilter)
+			}
+		}
+		remapExtLst(ws.ExtLst)
+	}
+	if s.TableStyles != nil {
+		for _, style := range s.TableStyles.TableStyles {
+			style.TableStyleElement = remapRawDxfIDs(style.TableStyleElement, fn)
+		}
+	}
+	f.Pkg.Range(func(k, v interface{}) bool {
+		path := k.(string)
+		if path == defaultXMLPathStyles || !strings.HasSuffix(path, ".xml") {
+			return true
+		}
+		if _, ok := f.Sheet.Load(path); ok {
+			return true
+		}
+		if content := string(v.([]byte)); dxfIDRegexp.MatchString(content) {
+			if remapped := remapRawDxfIDs(content, fn); remapped != content {
+				f.Pkg.Store(path, []byte(remapped))
+			}
+		}
+		return true
+	})
+}
+
+// pruneDxfs provides a function to remove the differential formats which are
+// not referenced by the workbook, and remap the references of the kept
+// differential formats by given worksheets and style sheet. The first
+// differential format will always be kept, and nothing will be removed if
+// the extension list of the style sheet references the differential
+// formats. This function returns the number of the removed differential
+// formats.
+func (f *File) pruneDxfs(worksheets []*xlsxWorksheet, s *xlsxStyleSheet) int {
+	if s.Dxfs == nil || len(s.Dxfs.Dxfs) < 2 ||
+		(s.ExtLst != nil && dxfIDRegexp.MatchString(s.ExtLst.Ext)) {
+		return 0
+	}
+	used := make([]bool, len(s.Dxfs.Dxfs))
+	used[0] = true
+	f.rangeDxfIDs(worksheets, s, func(id int) int {
+		if id >= 0 && id < len(used) {
+			used[id] = true
+		}
+		return id
+	})
+	dxfs, dxfMap := make([]*xlsxDxf, 0, len(used)), make([]int, len(used))
+	for i, dxf := range s.Dxfs.Dxfs {
+		if used[i] {
+			dxfMap[i] = len(dxfs)
+			dxfs = append(dxfs, dxf)
+		}
+	}
+	removed := len(s.Dxfs.Dxfs) - len(dxfs)
+	if removed == 0 {
+		return 0
+	}
+	f.rangeDxfIDs(worksheets, s, func(id int) int {
+		if id >= 0 && id < len(dxfMap) {
+			return dxfMap[id]
+		}
+		return id
+	})
+	s.Dxfs.Dxfs, s.Dxfs.Count = dxfs, len(dxfs)
+	return removed
+}
+
+// remapStyleComponentIDs provides a function to remap the font, fill or
+// border IDs of the cell formats to the compacted component list by given
+// component count, the number of leading components which should always be
+// kept, cell format lists and ID getter. This function returns the flags of
+// the components which should be kept.
+func remapStyleComponentIDs(count, reserved int, xfLists [][]xlsxXf, getID func(xf *xlsxXf) **int) []bool {
+	keep := make([]bool, count)
+	for i := 0; i < reserved && i < count; i++ {
+		keep[i] = true
+	}
+	for _, xfs := range xfLists {
+		for i := range xfs {
+			if id := *getID(&xfs[i]); id != nil && *id >= 0 && *id < count {
+				keep[*id] = true
+			}
+		}
+	}
+	idMap, idx := make([]int, count), 0
+	for i := range keep {
+		if keep[i] {
+			idMap[i] = idx
+			idx++
+		}
+	}
+	for _, xfs := range xfLists {
+		for i := range xfs {
+			if id := getID(&xfs[i]); *id != nil && **id >= 0 && **id < count {
+				*id = intPtr(idMap[**id])
+			}
+		}
+	}
+	return keep
+}
+
+// isDxfNumFmt provides a function to check if the number format is used by
+// the differential formats by given number format ID.
+func (s *xlsxStyleSheet) isDxfNumFmt(numFmtID int) bool {
+	if s.Dxfs != nil {
+		for _, dxf := range s.Dxfs.Dxfs {
+			if strings.Contains(dxf.Dxf, fmt.Sprintf(`numFmtId="%d"`, numFmtID)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// SetIndexedColors provides a function to set the legacy indexed color
+// palette of the workbook. The colors in the RRGGBB format (optionally
+// prefixed with #) override the palette starting from index 0, and the rest
+// indexes keep the default color. Set the nil or empty colors to restore the
+// default palette. For example, remap the indexed color 8 and 9 of the
+// palette:
+//
+//	colors := f.GetIndexedColors()
+//	colors[8], colors[9] = "1F4E78", "#C00000"
+//	err := f.SetIndexedColors(colors)
+func (f *File) SetIndexedColors(colors []string) error {
+	if len(colors) > len(defaultIndexedColors) {
+		return ErrIndexedColors
+	}
+	rgbColors := make([]xlsxRgbColor, len(defaultIndexedColors))
+	for idx, color := range defaultIndexedColors {
+		if idx < len(colors) {
+			if color = strings.TrimPrefix(colors[idx], "#"); !isHexColor(color) {
+				return ErrIndexedColors
+			}
+		}
+		rgbColors[idx].RGB = getPaletteColor(color)
+	}
+	s := f.stylesReader()
+	s.Lock()
+	defer s.Unlock()
+	if len(colors) == 0 {
+		if s.Colors != nil {
+			if s.Colors.IndexedColors = nil; s.Colors.MruColors == nil {
+				s.Colors = nil
+			}
+		}
+		return nil
+	}
+	if s.Colors == nil {
+		s.Colors = &xlsxStyleColors{}
+	}
+	s.Colors.IndexedColors = &xlsxIndexedColors{RgbColor: rgbColors}
+	return nil
+}
+
+// GetIndexedColors provides a function to get the legacy indexed color
+// palette of the workbook, the colors of index 0 to 63 in the RRGGBB format.
+// The default palette will be returned if the workbook doesn't customize it.
+func (f *File) GetIndexedColors() []string {
+	s := f.stylesReader()
+	s.Lock()
+	defer s.Unlock()
+	return s.getIndexedColors()
+}
+
+// getIndexedColors provides a function to get the indexed color palette of
+// the style sheet in the RRGGBB format.
+func (s *xlsxStyleSheet) getIndexedColors() []string {
+	colors := make([]string, len(defaultIndexedColors))
+	copy(colors, defaultIndexedColors)
+	if s.Colors == nil || s.Colors.IndexedColors == nil {
+		return colors
+	}
+	for idx, rgbColor := range s.Colors.IndexedColors.RgbColor {
+		if idx >= len(colors) {
+			break
+		}
+		color := strings.ToUpper(rgbColor.RGB)
+		if len(color) == 8 {
+			color = color[2:]
+		}
+		colors[idx] = color
+	}
+	return colors
+}
+
+// isHexColor checks if the given string is a color in the RRGGBB format.
+func isHexColor(color string) bool {
+	if len(color) != 6 {
+		return false
+	}
+	_, err := strconv.ParseUint(color, 16, 32)
+	return err == nil
+}
+
+// resolveColor returns a copy of the given color with the RGB value resolved
+// by the indexed color palette, the theme color scheme and the tint, the
+// color which has only the RGB value or can't be resolved will be returned
+// as is.
+func (f *File) resolveColor(palette []string, color *xlsxColor) *xlsxColor {
+	if color == nil || (color.RGB != "" && color.Tint == 0) {
+		return color
+	}
+	rgb := f.resolveColorRGB(palette, color)
+	if rgb == "" {
+		return color
+	}
+	return &xlsxColor{RGB: getPaletteColor(rgb)}
+}
+
+// resolveFontColor returns a copy of the given font with the color resolved
+// by the palette and the theme.
+func (f *File) resolveFontColor(palette []string, font *xlsxFont) *xlsxFont {
+	if font == nil {
+		return nil
+	}
+	resolved := *font
+	resolved.Color = f.resolveColor(palette, font.Color)
+	return &resolved
+}
+
+// resolveBorderColor returns a copy of the given border with the colors
+// resolved by the palette and the theme.
+func (f *File) resolveBorderColor(palette []string, border *xlsxBorder) *xlsxBorder {
+	if border == nil {
+		return nil
+	}
+	resolved := *border
+	for _, line := range []*xlsxLine{&resolved.Left, &resolved.Right, &resolved.Top, &resolved.Bottom, &resolved.Diagonal} {
+		line.Color = f.resolveColor(palette, line.Color)
+	}
+	return &resolved
+}
+
+// resolveFillColor returns a copy of the given fill with the colors resolved
+// by the palette and the theme.
+func (f *File) resolveFillColor(palette []string, fill *xlsxFill) *xlsxFill {
+	if fill == nil {
+		return nil
+	}
+	resolved := *fill
+	if fill.PatternFill != nil {
+		patternFill := *fill.PatternFill
+		patternFill.FgColor = f.resolveColor(palette, patternFill.FgColor)
+		patternFill.BgColor = f.resolveColor(palette, patternFill.BgColor)
+		resolved.PatternFill = &patternFill
+	}
+	if fill.GradientFill != nil {
+		gradientFill := *fill.GradientFill
+		gradientFill.Stop = make([]*xlsxGradientFillStop, len(fill.GradientFill.Stop))
+		for idx, stop := range fill.GradientFill.Stop {
+			resolvedStop := *stop
+			resolvedStop.Color = *f.resolveColor(palette, &stop.Color)
+			gradientFill.Stop[idx] = &resolvedStop
+		}
+		resolved.GradientFill = &gradientFill
+	}
+	return &resolved
+}
+
+// SetCellStyle provides a function to add style attribute for cells by given
+// worksheet name, coordinate area and style ID. Note that diagonalDown and
+// diagonalUp type border should be use same color in the same coordinate
+// area. SetCellStyle will overwrite the existing styles for the cell, it
+// won't append or merge style with existing styles.
+//
+// For example create a borders of cell H9 on Sheet1:
+//
+//	style, err := f.NewStyle(&excelize.Style{
+//	    Border: []excelize.Border{
+//	        {Type: "left", Color: "0000FF", Style: 3},
+//	        {Type: "top", Color: "00FF00", Style: 4},
+//	        {Type: "bottom", Color: "FFFF00", Style: 5},
+//	        {Type: "right", Color: "FF0000", Style: 6},
+//	        {Type: "diagonalDown", Color: "A020F0", Style: 7},
+//	        {Type: "diagonalUp", Color: "A020F0", Style: 8},
+//	    },
+//	})
+//	if err != nil {
+//	    fmt.Println(err)
+//	}
+//	err = f.SetCellStyle("Sheet1", "H9", "H9", style)
+//
+// Set gradient fill with vertical variants shading styles for cell H9 on
+// Sheet1:
+//
+//	style, err := f.NewStyle(&excelize.Style{
+//	    Fill: excelize.Fill{Type: "gradient", Color: []string{"#FFFFFF", "#E0EBF5"}, Shading: 1},
+//	})
+//	if err != nil {
+//	    fmt.Println(err)
+//	}
+//	err = f.SetCellStyle("Sheet1", "H9", "H9", style)
+//
+// Set solid style pattern fill for cell H9 on Sheet1:
+//
+//	style, err := f.NewStyle(&excelize.Style{
+//	    Fill: excelize.Fill{Type: "pattern", Color: []string{"#E0EBF5"}, Pattern: 1},
+//	})
+//	if err != nil {
+//	    fmt.Println(err)
+//	}
+//	err = f.SetCellStyle("Sheet1", "H9", "H9", style)
+//
+// Set alignment style for cell H9 on Sheet1:
+//
+//	style, err := f.NewStyle(&excelize.Style{
+//	    Alignment: &excelize.Alignment{
+//	        Horizontal:      "center",
+//	        Indent:          1,
+//	        JustifyLastLine: true,
+//	        ReadingOrder:    0,
+//	        RelativeIndent:  1,
+//	        ShrinkToFit:     true,
+//	        TextRotation:    45,
+//	        Vertical:        "",
+//	        WrapText:        true,
+//	    },
+//	})
+//	if err != nil {
+//	    fmt.Println(err)
+//	}
+//	err = f.SetCellStyle("Sheet1", "H9", "H9", style)
+//
+// The text rotation should be between -90 and 180 degrees, the negative value
+// rotates text downward and will be stored as 91 to 180. Set vertical stacked
+// text for cell H9 on Sheet1:
+//
+//	style, err := f.NewStyle(&excelize.Style{
+//	    Alignment: &excelize.Alignment{TextRotation: excelize.TextRotationVertical},
+//	})
+//	if err != nil {
+//	    fmt.Println(err)
+//	}
+//	err = f.SetCellStyle("Sheet1", "H9", "H9", style)
+//
+// Dates and times in Excel are represented by real numbers, for example "Apr 7
+// 2017 12:00 PM" is represented by the number 42920.5. Set date and time format
+// for cell H9 on Sheet1:
+//
+//	f.SetCellValue("Sheet1", "H9", 42920.5)
+//	style, err := f.NewStyle(&excelize.Style{NumFmt: 22})
+//	if err != nil {
+//	    fmt.Println(err)
+//	}
+//	err = f.SetCellStyle("Sheet1", "H9", "H9", style)
+//
+// Set font style for cell H9 on Sheet1:
+//
+//	style, err := f.NewStyle(&excelize.Style{
+//	    Font: &excelize.Font{
+//	        Bold:   true,
+//	        Italic: true,
+//	        Family: "Times New Roman",
+//	        Size:   36,
+//	        Color:  "#777777",
+//	    },
+//	})
+//	if err != nil {
+//	    fmt.Println(err)
+//	}
+//	err = f.SetCellStyle("Sheet1", "H9", "H9", style)
+//
+// Hide and lock for cell H9 on Sheet1:
+//
+//	style, err := f.NewStyle(&excelize.Style{
+//	    Protection: &excelize.Protection{
+//	        Hidden: true,
+//	        Locked: true,
+//	    },
+//	})
+//	if err != nil {
+//	    fmt.Println(err)
+//	}
+//	err = f.SetCellStyle("Sheet1", "H9", "H9", style)
+func (f *File) SetCellStyle(sheet, hCell, vCell string, styleID int) error {
+	hCol, hRow, err := CellNameToCoordinates(hCell)
+	if err != nil {
+		return err
+	}
+
+	vCol, vRow, err := CellNameToCoordinates(vCell)
+	if err != nil {
+		return err
+	}
+
+	// Normalize the coordinate area, such correct C1:B3 to B1:C3.
+	if vCol < hCol {
+		vCol, hCol = hCol, vCol
+	}
+
+	if vRow < hRow {
+		vRow, hRow = hRow, vRow
+	}
+
+	hColIdx := hCol - 1
+	hRowIdx := hRow - 1
+
+	vColIdx := vCol - 1
 	vRowIdx := vRow - 1
 
-	ws, err := f.workSheetReader(sheet)
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	prepareSheetXML(ws, vCol, vRow)
+	makeContiguousColumns(ws, hRow, vRow, vCol)
+	ws.Lock()
+	defer ws.Unlock()
+	for r := hRowIdx; r <= vRowIdx; r++ {
+		for k := hColIdx; k <= vColIdx; k++ {
+			ws.SheetData.Row[r].C[k].S = styleID
+		}
+	}
+	return err
+}
+
+// SetRowBanding provides a function to apply the alternating styles to the
+// rows of the range by given worksheet name, range reference and the style
+// indexes of the even and odd rows, which makes the range easier to read
+// without creating a table. The rows are counted from the first row of the
+// range, so the first row of the range is an odd row. The style of each cell
+// in the range will be replaced. For example, apply a light fill on every
+// second row of the range A2:F20 on Sheet1:
+//
+//	even, err := f.NewStyle(&excelize.Style{
+//	    Fill: excelize.Fill{Type: "pattern", Color: []string{"#DDEBF7"}, Pattern: 1},
+//	})
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	err = f.SetRowBanding("Sheet1", "A2:F20", even, 0)
+func (f *File) SetRowBanding(sheet, rangeRef string, evenStyleID, oddStyleID int) error {
+	for _, styleID := range []int{evenStyleID, oddStyleID} {
+		if styleID < 0 {
+			return newInvalidStyleID(styleID)
+		}
+	}
+	coordinates, err := rangeRefToCoordinates(rangeRef)
+	if err != nil {
+		return err
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	prepareSheetXML(ws, coordinates[2], coordinates[3])
+	makeContiguousColumns(ws, coordinates[1], coordinates[3], coordinates[2])
+	ws.Lock()
+	defer ws.Unlock()
+	for row := coordinates[1]; row <= coordinates[3]; row++ {
+		styleID := oddStyleID
+		if (row-coordinates[1])%2 == 1 {
+			styleID = evenStyleID
+		}
+		cells := ws.SheetData.Row[row-1].C[coordinates[0]-1 : coordinates[2]]
+		for idx := range cells {
+			cells[idx].S = styleID
+		}
+	}
+	return err
+}
+
+// setCellRangeXfs provides a function to update the cell style of each cell
+// in the range by given worksheet name, coordinates of the range and the
+// function which gets the new cell style index by the style sheet, the cell
+// coordinates and the cell style index. The optional key function gets the
+// key of the formatting to be applied to the cell, and the cell will be kept
+// if it returns false. The new cell style index will be reused for the cells
+// which have the same cell style index and the same key.
+func (f *File) setCellRangeXfs(sheet string, coordinates []int, key func(col, row int) (string, bool), fn func(s *xlsxStyleSheet, col, row, styleID int) int) error {
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	prepareSheetXML(ws, coordinates[2], coordinates[3])
+	makeContiguousColumns(ws, coordinates[1], coordinates[3], coordinates[2])
+	s := f.stylesReader()
+	s.Lock()
+	defer s.Unlock()
+	ws.Lock()
+	defer ws.Unlock()
+	type styleKey struct {
+		styleID int
+		key     string
+	}
+	styleIDs := make(map[styleKey]int)
+	for row := coordinates[1]; row <= coordinates[3]; row++ {
+		for col := coordinates[0]; col <= coordinates[2]; col++ {
+			var k styleKey
+			if key != nil {
+				var ok bool
+				if k.key, ok = key(col, row); !ok {
+					continue
+				}
+			}
+			cell := &ws.SheetData.Row[row-1].C[col-1]
+			k.styleID = f.prepareCellStyle(ws, col, row, cell.S)
+			if _, ok := styleIDs[k]; !ok {
+				styleIDs[k] = fn(s, col, row, k.styleID)
+			}
+			cell.S = styleIDs[k]
+		}
+	}
+	return nil
+}
+
+// SetCellIndent provides a function to set the indent level of the cells by
+// given worksheet name, range reference and indent level. Only the indent of
+// the alignment will be changed, the other formatting of each cell will be
+// kept. The range of the indent level is 0 - 250, and 0 removes the indent.
+// Because Excel only applies the indent to the left, right and distributed
+// horizontal alignment, the horizontal alignment of the cells will be set to
+// left if it is not one of those. For example, indent the cells in the range
+// A2:A5 on Sheet1 by 2 levels:
+//
+//	err := f.SetCellIndent("Sheet1", "A2:A5", 2)
+func (f *File) SetCellIndent(sheet, rangeRef string, level int) error {
+	if level < 0 || level > MaxCellIndent {
+		return ErrCellIndent
+	}
+	coordinates, err := rangeRefToCoordinates(rangeRef)
+	if err != nil {
+		return err
+	}
+	return f.setCellRangeXfs(sheet, coordinates, nil, func(s *xlsxStyleSheet, col, row, styleID int) int {
+		return setCellXfsIndent(s, styleID, level)
+	})
+}
+
+// setCellXfsIndent provides a function to get the cell style index which has
+// the same formatting as the given cell style index except the indent level of
+// the alignment. A new cell style will be created if it doesn't exist.
+func setCellXfsIndent(style *xlsxStyleSheet, styleID, level int) int {
+	return setCellXfsWith(style, styleID, func(xf *xlsxXf) {
+		var alignment xlsxAlignment
+		if xf.Alignment != nil {
+			alignment = *xf.Alignment
+		}
+		alignment.Indent = level
+		if level > 0 && inStrSlice([]string{"left", "right", "distributed"}, alignment.Horizontal, true) == -1 {
+			alignment.Horizontal = "left"
+		}
+		xf.Alignment, xf.ApplyAlignment = &alignment, boolPtr(true)
+		if alignment == (xlsxAlignment{}) {
+			xf.Alignment, xf.ApplyAlignment = nil, nil
+		}
+	})
+}
+
+// SetCellColor provides a function to set the solid background color of the
+// cells by given worksheet name, range reference and color in the #RRGGBB
+// format. Only the fill will be changed, the other formatting of each cell
+// will be kept, and the existing solid fill with the same color will be
+// reused. Set the empty color to remove the background color of the cells.
+// For example, highlight the cells in the range A1:B2 on Sheet1 with yellow:
+//
+//	err := f.SetCellColor("Sheet1", "A1:B2", "#FFFF00")
+func (f *File) SetCellColor(sheet, rangeRef, color string) error {
+	if color != "" && !isHexColor(strings.TrimPrefix(color, "#")) {
+		return ErrCellColor
+	}
+	coordinates, err := rangeRefToCoordinates(rangeRef)
+	if err != nil {
+		return err
+	}
+	fillID := -1
+	return f.setCellRangeXfs(sheet, coordinates, nil, func(s *xlsxStyleSheet, col, row, styleID int) int {
+		if fillID == -1 {
+			fillID = getSolidFillID(s, color)
+		}
+		return setCellXfsFill(s, styleID, fillID)
+	})
+}
+
+// getSolidFillID provides a function to get the fill index of the solid fill
+// by given color, the existing fill will be reused and a new fill will be
+// created if it doesn't exist. The empty color means no fill.
+func getSolidFillID(s *xlsxStyleSheet, color string) int {
+	if color == "" {
+		return 0
+	}
+	fill := &xlsxFill{PatternFill: &xlsxPatternFill{
+		PatternType: "solid",
+		FgColor:     &xlsxColor{RGB: getPaletteColor(color)},
+	}}
+	if s.Fills == nil {
+		s.Fills = &xlsxFills{}
+	}
+	fillID := getFillIDImmediate(s, fill)
+	if fillID == -1 {
+		s.Fills.Fill = append(s.Fills.Fill, fill)
+		s.Fills.Count = len(s.Fills.Fill)
+		fillID = s.Fills.Count - 1
+	}
+	return fillID
+}
+
+// setCellXfsFill provides a function to get the cell style index which has
+// the same formatting as the given cell style index except the fill. A new
+// cell style will be created if it doesn't exist.
+func setCellXfsFill(style *xlsxStyleSheet, styleID, fillID int) int {
+	return setCellXfsWith(style, styleID, func(xf *xlsxXf) {
+		xf.FillID, xf.ApplyFill = intPtr(fillID), boolPtr(true)
+		if fillID == 0 {
+			xf.ApplyFill = nil
+		}
+	})
+}
+
+// SetCellNumFmt provides a function to set the number format of the cells by
+// given worksheet name, range reference and number format code. Only the
+// number format will be changed, the other formatting of each cell will be
+// kept. The built-in number format will be used if the format code matches
+// one of them, otherwise a custom number format will be created or reused.
+// For example, set the number format of the cells in the range A1:B2 on
+// Sheet1 to show 2 decimal places with thousands separator:
+//
+//	err := f.SetCellNumFmt("Sheet1", "A1:B2", "#,##0.00")
+func (f *File) SetCellNumFmt(sheet, rangeRef, formatCode string) error {
+	if formatCode == "" {
+		return ErrCustomNumFmt
+	}
+	coordinates, err := rangeRefToCoordinates(rangeRef)
 	if err != nil {
 		return err
 	}
-	prepareSheetXML(ws, vCol, vRow)
-	makeContiguousColumns(ws, hRow, vRow, vCol)
-	ws.Lock()
-	defer ws.Unlock()
-	for r := hRowIdx; r <= vRowIdx; r++ {
-		for k := hColIdx; k <= vColIdx; k++ {
-			ws.SheetData.Row[r].C[k].S = styleID
+	numFmtID := -1
+	return f.setCellRangeXfs(sheet, coordinates, nil, func(s *xlsxStyleSheet, col, row, styleID int) int {
+		if numFmtID == -1 {
+			numFmtID = getNumFmtIDByCode(s, formatCode)
+		}
+		return setCellXfsNumFmt(s, styleID, numFmtID)
+	})
+}
+
+// getNumFmtIDByCode provides a function to get the number format ID by given
+// number format code. The custom number format will be created if the format
+// code is neither a built-in nor an existing custom number format. The format
+// codes are case-sensitive except the General keyword, such as "h:mm am/pm"
+// is a custom number format different from the built-in "h:mm AM/PM".
+func getNumFmtIDByCode(style *xlsxStyleSheet, formatCode string) int {
+	for numFmtID, code := range builtInNumFmt {
+		if excelCode, ok := builtInNumFmtCode[numFmtID]; ok {
+			code = excelCode
+		}
+		if code == formatCode || (numFmtID == 0 && strings.EqualFold(code, formatCode)) {
+			return numFmtID
 		}
 	}
-	return err
+	if numFmtID := getCustomNumFmtIDImmediate(style, formatCode); numFmtID != -1 {
+		return numFmtID
+	}
+	return setCustomNumFmtImmediate(style, &xlsxNumFmt{FormatCode: formatCode})
+}
+
+// setCellXfsNumFmt provides a function to get the cell style index which has
+// the same formatting as the given cell style index except the number format.
+// A new cell style will be created if it doesn't exist.
+func setCellXfsNumFmt(style *xlsxStyleSheet, styleID, numFmtID int) int {
+	return setCellXfsWith(style, styleID, func(xf *xlsxXf) {
+		xf.NumFmtID, xf.ApplyNumberFormat = intPtr(numFmtID), boolPtr(true)
+		if numFmtID == 0 {
+			xf.ApplyNumberFormat = nil
+		}
+	})
+}
+
+// SetCellSuperscript provides a function to set or remove the superscript
+// font formatting of the cells by given worksheet name, range reference and
+// the boolean flag. Only the vertical alignment of the font will be changed,
+// the other formatting of each cell will be kept. Use the rich text runs to
+// format the part of the text in a cell. For example, format the cell B1 on
+// Sheet1 as superscript to show the unit "m²" with the cells A1 and B1:
+//
+//	if err := f.SetSheetRow("Sheet1", "A1", &[]interface{}{"m", 2}); err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	err := f.SetCellSuperscript("Sheet1", "B1", true)
+func (f *File) SetCellSuperscript(sheet, rangeRef string, superscript bool) error {
+	var vertAlign string
+	if superscript {
+		vertAlign = "superscript"
+	}
+	return f.setCellVertAlign(sheet, rangeRef, vertAlign)
+}
+
+// SetCellSubscript provides a function to set or remove the subscript font
+// formatting of the cells by given worksheet name, range reference and the
+// boolean flag. Only the vertical alignment of the font will be changed, the
+// other formatting of each cell will be kept. Use the rich text runs to
+// format the part of the text in a cell. For example, format the cell B1 on
+// Sheet1 as subscript to show the chemical formula "H₂O" with the cells A1,
+// B1 and C1:
+//
+//	if err := f.SetSheetRow("Sheet1", "A1", &[]interface{}{"H", 2, "O"}); err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	err := f.SetCellSubscript("Sheet1", "B1", true)
+func (f *File) SetCellSubscript(sheet, rangeRef string, subscript bool) error {
+	var vertAlign string
+	if subscript {
+		vertAlign = "subscript"
+	}
+	return f.setCellVertAlign(sheet, rangeRef, vertAlign)
+}
+
+// setCellVertAlign provides a function to set the vertical alignment of the
+// font of the cells by given worksheet name, range reference and the vertical
+// alignment type. The empty vertical alignment type means the baseline.
+func (f *File) setCellVertAlign(sheet, rangeRef, vertAlign string) error {
+	coordinates, err := rangeRefToCoordinates(rangeRef)
+	if err != nil {
+		return err
+	}
+	return f.setCellRangeXfs(sheet, coordinates, nil, func(s *xlsxStyleSheet, col, row, styleID int) int {
+		return f.setCellXfsVertAlign(s, styleID, vertAlign)
+	})
+}
+
+// setCellXfsVertAlign provides a function to get the cell style index which
+// has the same formatting as the given cell style index except the vertical
+// alignment of the font. A new font and cell style will be created if it
+// doesn't exist.
+func (f *File) setCellXfsVertAlign(style *xlsxStyleSheet, styleID int, vertAlign string) int {
+	return setCellXfsWith(style, styleID, func(xf *xlsxXf) {
+		var fnt xlsxFont
+		if style.Fonts == nil {
+			style.Fonts = &xlsxFonts{}
+		}
+		fontID := 0
+		if xf.FontID != nil {
+			fontID = *xf.FontID
+		}
+		if fontID >= 0 && fontID < len(style.Fonts.Font) {
+			fnt = deepcopy.Copy(*style.Fonts.Font[fontID]).(xlsxFont)
+		}
+		if fnt.VertAlign = nil; vertAlign != "" {
+			fnt.VertAlign = &attrValString{Val: stringPtr(vertAlign)}
+		}
+		if fontID = f.getFontIDImmediate(style, &fnt); fontID == -1 {
+			style.Fonts.Font = append(style.Fonts.Font, &fnt)
+			style.Fonts.Count = len(style.Fonts.Font)
+			fontID = style.Fonts.Count - 1
+		}
+		xf.FontID, xf.ApplyFont = intPtr(fontID), boolPtr(true)
+		if fontID == 0 {
+			xf.ApplyFont = nil
+		}
+	})
+}
+
+// SetRangeOuterBorder provides a function to set the border around the
+// outside of the range by given worksheet name, range reference and the
+// border format settings. The Type of the border will be ignored, only the
+// edges of the cells on the perimeter of the range will be changed, each
+// corner cell gets both of the outer edges, and the inner cells will be
+// untouched. The other formatting of each cell, including the inner edges of
+// the perimeter cells, will be kept. Set the Style of the border to 0 to
+// remove the outer border of the range. For example, draw a thin black
+// border around the range B2:D5 on Sheet1:
+//
+//	err := f.SetRangeOuterBorder("Sheet1", "B2:D5", excelize.Border{Color: "#000000", Style: 1})
+func (f *File) SetRangeOuterBorder(sheet, rangeRef string, border Border) error {
+	if border.Style < 0 || border.Style >= len(styleBorders) {
+		return ErrBorderStyle
+	}
+	coordinates, err := rangeRefToCoordinates(rangeRef)
+	if err != nil {
+		return err
+	}
+	var line xlsxLine
+	if border.Style > 0 {
+		line = xlsxLine{Style: styleBorders[border.Style], Color: &xlsxColor{RGB: getPaletteColor(border.Color)}}
+	}
+	getEdges := func(col, row int) [4]bool {
+		return [4]bool{col == coordinates[0], col == coordinates[2], row == coordinates[1], row == coordinates[3]}
+	}
+	return f.setCellRangeXfs(sheet, coordinates, func(col, row int) (string, bool) {
+		edges := getEdges(col, row)
+		return fmt.Sprint(edges), edges != [4]bool{}
+	}, func(s *xlsxStyleSheet, col, row, styleID int) int {
+		return setCellXfsBorder(s, styleID, getEdges(col, row), line)
+	})
+}
+
+// setCellXfsBorder provides a function to get the cell style index which has
+// the same formatting as the given cell style index except the given left,
+// right, top and bottom edges of the border. A new border and cell style
+// will be created if it doesn't exist.
+func setCellXfsBorder(style *xlsxStyleSheet, styleID int, edges [4]bool, line xlsxLine) int {
+	return setCellXfsWith(style, styleID, func(xf *xlsxXf) {
+		var border xlsxBorder
+		if style.Borders == nil {
+			style.Borders = &xlsxBorders{}
+		}
+		borderID := 0
+		if xf.BorderID != nil {
+			borderID = *xf.BorderID
+		}
+		if borderID >= 0 && borderID < len(style.Borders.Border) {
+			border = *style.Borders.Border[borderID]
+		}
+		for idx, side := range []*xlsxLine{&border.Left, &border.Right, &border.Top, &border.Bottom} {
+			if edges[idx] {
+				*side = line
+			}
+		}
+		if borderID = getBorderIDImmediate(style, &border); borderID == -1 {
+			style.Borders.Border = append(style.Borders.Border, &border)
+			style.Borders.Count = len(style.Borders.Border)
+			borderID = style.Borders.Count - 1
+		}
+		xf.BorderID, xf.ApplyBorder = intPtr(borderID), boolPtr(true)
+		if borderID == 0 {
+			xf.ApplyBorder = nil
+		}
+	})
+}
+
+// ScientificFormat provides a function to get the number format code of the
+// scientific notation by given number of decimal places of the mantissa. If
+// the engineering is true, the code of the engineering notation will be
+// returned, which exponent is always a multiple of 3, such as "##0.0E+0".
+// The number of decimal places must be in the range 0 - 30, the out of range
+// value will be limited to this range. For example, set the number format of
+// the cells in column A on Sheet1 to the engineering notation with 2 decimal
+// places:
+//
+//	err := f.SetCellNumFmt("Sheet1", "A1:A100", excelize.ScientificFormat(2, true))
+func ScientificFormat(mantissaDecimals int, engineering bool) string {
+	if mantissaDecimals < 0 {
+		mantissaDecimals = 0
+	}
+	if mantissaDecimals > 30 {
+		mantissaDecimals = 30
+	}
+	mantissa, exponent := "0", "E+00"
+	if engineering {
+		mantissa, exponent = "##0", "E+0"
+	}
+	if mantissaDecimals > 0 {
+		mantissa += "." + strings.Repeat("0", mantissaDecimals)
+	}
+	return mantissa + exponent
+}
+
+// FormatBuilder directly maps the builder of the custom number format code
+// which contains multiple sections with conditions. A number format code can
+// have up to 4 sections separated by semicolons, and up to 2 of them can
+// have the conditions, such as [>=1000000]. The conditional sections must be
+// added before the sections without conditions.
+type FormatBuilder struct {
+	sections   []string
+	conditions int
+	err        error
+}
+
+// numFmtConditionOperators defined the comparison operators which can be used
+// in the condition of the number format section, the two characters
+// operators must be placed before the one character operators.
+var numFmtConditionOperators = []string{"<=", ">=", "<>", "<", ">", "="}
+
+// NewFormatBuilder provides a function to create a builder of the custom
+// number format code with conditional sections. For example, display the
+// values in the cells A1:A10 on Sheet1 with the abbreviation of the millions
+// and thousands:
+//
+//	numFmt, err := excelize.NewFormatBuilder().
+//	    AddSection(">=1000000", `0.0,,"M"`).
+//	    AddSection(">=1000", `0.0,"K"`).
+//	    AddSection("", "0").
+//	    Build()
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	err = f.SetCellNumFmt("Sheet1", "A1:A10", numFmt)
+//
+// The number format code of this example is:
+//
+//	[>=1000000]0.0,,"M";[>=1000]0.0,"K";0
+func NewFormatBuilder() *FormatBuilder {
+	return &FormatBuilder{}
+}
+
+// AddSection provides a function to append a section to the number format
+// code by given condition and format. The condition is a comparison operator
+// which is one of <, >, =, <=, >= and <> followed by a number, and the square
+// brackets around the condition are optional, such as ">=1000" or
+// "[>=1000]". Set the condition with an empty string to add the section
+// without condition. The format of the section can't contain the section
+// separator semicolon or condition outside the quoted text. The first error
+// will be returned by the Build function.
+func (b *FormatBuilder) AddSection(condition, format string) *FormatBuilder {
+	if b.err != nil {
+		return b
+	}
+	if len(b.sections) >= 4 {
+		b.err = ErrNumFmtSections
+		return b
+	}
+	if err := checkNumFmtSection(format); err != nil {
+		b.err = err
+		return b
+	}
+	if condition = strings.TrimSpace(condition); condition == "" {
+		b.sections = append(b.sections, format)
+		return b
+	}
+	if b.conditions != len(b.sections) {
+		b.err = ErrNumFmtCondition
+		return b
+	}
+	if b.conditions >= 2 {
+		b.err = ErrNumFmtSections
+		return b
+	}
+	cond, err := parseNumFmtCondition(condition)
+	if err != nil {
+		b.err = err
+		return b
+	}
+	b.sections = append(b.sections, cond+format)
+	b.conditions++
+	return b
+}
+
+// Build provides a function to get the number format code assembled by the
+// builder. It returns an error if any added section is invalid, no section
+// was added, or the length of the number format code exceeds 255 characters.
+func (b *FormatBuilder) Build() (string, error) {
+	if b.err != nil {
+		return "", b.err
+	}
+	if len(b.sections) == 0 {
+		return "", ErrCustomNumFmt
+	}
+	numFmt := strings.Join(b.sections, ";")
+	if len(numFmt) > MaxFieldLength {
+		return "", newFieldLengthError("number format")
+	}
+	return numFmt, nil
+}
+
+// parseNumFmtCondition provides a function to parse and normalize the
+// condition of the number format section, the normalized condition will be
+// enclosed in square brackets. The infinity and NaN aren't valid numbers of
+// the condition.
+func parseNumFmtCondition(condition string) (string, error) {
+	if strings.HasPrefix(condition, "[") {
+		if !strings.HasSuffix(condition, "]") {
+			return "", ErrNumFmtCondition
+		}
+		condition = strings.TrimSpace(condition[1 : len(condition)-1])
+	}
+	for _, operator := range numFmtConditionOperators {
+		if !strings.HasPrefix(condition, operator) {
+			continue
+		}
+		value := strings.TrimSpace(strings.TrimPrefix(condition, operator))
+		if val, err := strconv.ParseFloat(value, 64); err != nil || math.IsInf(val, 0) || math.IsNaN(val) {
+			return "", ErrNumFmtCondition
+		}
+		return "[" + operator + value + "]", nil
+	}
+	return "", ErrNumFmtCondition
+}
+
+// checkNumFmtSection provides a function to check the format of the number
+// format section doesn't contain the section separator or condition outside
+// the quoted text and escaped characters.
+func checkNumFmtSection(format string) error {
+	var quoted, escaped bool
+	for i, r := range format {
+		switch {
+		case escaped:
+			escaped = false
+		case r == '"':
+			quoted = !quoted
+		case quoted:
+		case r == '\\':
+			escaped = true
+		case r == ';':
+			return ErrNumFmtSection
+		case r == '[':
+			if strings.IndexAny(strings.TrimSpace(format[i+1:]), "<>=") == 0 {
+				return ErrNumFmtSection
+			}
+		}
+	}
+	return nil
 }
 
 // SetConditionalFormat provides a function to create conditional formatting
@@ -2945,15 +4464,43 @@ func (f *File) SetCellStyle(sheet, hCell, vCell string, styleID int) error {
 //	// Top/Bottom rules: Below Average...
 //	f.SetConditionalFormat("Sheet1", "B1:B10", fmt.Sprintf(`[{"type":"average","criteria":"=","format":%d, "above_average": false}]`, format2))
 //
-// type: duplicate - The duplicate type is used to highlight duplicate cells in a range:
+// type: duplicate - The duplicate type is used to highlight duplicate cells
+// in a range, the criteria parameter is optional for this type:
 //
 //	// Hightlight cells rules: Duplicate Values...
-//	f.SetConditionalFormat("Sheet1", "A1:A10", fmt.Sprintf(`[{"type":"duplicate","criteria":"=","format":%d}]`, format))
+//	f.SetConditionalFormat("Sheet1", "A1:A10", fmt.Sprintf(`[{"type":"duplicate","format":%d}]`, format))
+//
+// type: unique - The unique type is used to highlight unique cells in a
+// range, the criteria parameter is optional for this type:
+//
+//	// Hightlight cells rules: Unique Values...
+//	f.SetConditionalFormat("Sheet1", "A1:A10", fmt.Sprintf(`[{"type":"unique","format":%d}]`, format))
+//
+// The values are compared across all areas of the range, for example,
+// highlight the duplicate values in the columns A and C:
 //
-// type: unique - The unique type is used to highlight unique cells in a range:
+//	f.SetConditionalFormat("Sheet1", "A1:A10 C1:C10", fmt.Sprintf(`[{"type":"duplicate","format":%d}]`, format))
 //
-//	// Hightlight cells rules: Not Equal To...
-//	f.SetConditionalFormat("Sheet1", "A1:A10", fmt.Sprintf(`[{"type":"unique","criteria":"=","format":%d}]`, format))
+// type: time_period - The time_period type is used to specify Excel's "Dates
+// Occurring" style conditional format, which highlights the dates relative
+// to the current date. The criteria parameter is required for this type, and
+// it must be one of the available time periods listed below:
+//
+//	// Hightlight cells rules: A Date Occurring... Last 7 days.
+//	f.SetConditionalFormat("Sheet1", "A1:A10", fmt.Sprintf(`[{"type":"time_period","criteria":"last 7 days","format":%d}]`, format))
+//
+// The available time period criteria are:
+//
+//	yesterday
+//	today
+//	tomorrow
+//	last 7 days
+//	last week
+//	this week
+//	next week
+//	last month
+//	this month
+//	next month
 //
 // type: top - The top type is used to specify the top n values by number or percentage in a range:
 //
@@ -3025,12 +4572,54 @@ func (f *File) SetCellStyle(sheet, hCell, vCell string, styleID int) error {
 // max_color - Same as min_color, see above.
 //
 // bar_color - Used for data_bar. Same as min_color, see above.
+//
+// priority - The priority parameter is used to set the priority of the rule
+// when multiple conditional formatting rules are applied to the same cells,
+// the rule with the lower priority number will be evaluated first. By
+// default, the rules are evaluated in the order of they were added to the
+// worksheet. The priority numbers of the existing rules which are greater
+// than or equal to the given priority will be increased by one, so that the
+// priority numbers of the rules are kept unique.
+//
+// stop_if_true - The stop_if_true parameter is used to stop evaluating the
+// rules with lower priority on the cell when the condition of the rule is
+// met. For example, keep the blank cells unformatted by a formula rule with
+// higher priority than the color scale:
+//
+//	f.SetConditionalFormat("Sheet1", "A1:A10", `[{"type":"3_color_scale","criteria":"=","min_type":"min","mid_type":"percentile","max_type":"max","min_color":"#F8696B","mid_color":"#FFEB84","max_color":"#63BE7B","priority":2}]`)
+//	f.SetConditionalFormat("Sheet1", "A1:A10", fmt.Sprintf(`[{"type":"formula","criteria":"ISBLANK(A1)","format":%d,"priority":1,"stop_if_true":true}]`, format))
+//
+// type: formula - The formula type is used to specify a conditional format
+// based on a formula. The formula is written once, and the relative
+// references in the formula are relative to the top-left cell of the range,
+// Excel adjusts them for each cell of the range as the same as copying the
+// formula. The leading equal sign of the formula is optional. The cell ranges
+// will be normalized, such as correct A10:A1 to A1:A10, so that the formula is
+// anchored to the top-left cell. For example, highlight the cells in the
+// range A1:A100 whose value is greater than the cell in column B on the same
+// row, and use the absolute reference to compare with a fixed cell:
+//
+//	f.SetConditionalFormat("Sheet1", "A1:A100", fmt.Sprintf(`[{"type":"formula","criteria":"=A1>B1","format":%d}]`, format))
+//	f.SetConditionalFormat("Sheet1", "C1:C100", fmt.Sprintf(`[{"type":"formula","criteria":"C1>$E$1","format":%d}]`, format))
+//
+// The full column reference such as "A:A" and the full row reference such as
+// "1:1" are supported, the conditional format will be applied to the entire
+// columns or rows regardless of the number of the used rows or columns, and
+// the relative references in the formula are relative to the first cell of
+// the columns or rows. For example, highlight the cells of the entire column
+// A which value is greater than the cell in column B on the same row:
+//
+//	f.SetConditionalFormat("Sheet1", "A:A", fmt.Sprintf(`[{"type":"formula","criteria":"=A1>B1","format":%d}]`, format))
 func (f *File) SetConditionalFormat(sheet, area, formatSet string) error {
 	var format []*formatConditional
 	err := json.Unmarshal([]byte(formatSet), &format)
 	if err != nil {
 		return err
 	}
+	if area, err = f.prepareConditionalFormatRange(area); err != nil {
+		return err
+	}
+	topLeftCell := getConditionalFormatTopLeftCell(area)
 	drawContFmtFunc := map[string]func(p int, ct string, fmtCond *formatConditional) *xlsxCfRule{
 		"cellIs":          drawCondFmtCellIs,
 		"top10":           drawCondFmtTop10,
@@ -3041,6 +4630,9 @@ func (f *File) SetConditionalFormat(sheet, area, formatSet string) error {
 		"3_color_scale":   drawCondFmtColorScale,
 		"dataBar":         drawCondFmtDataBar,
 		"expression":      drawConfFmtExp,
+		"timePeriod": func(p int, ct string, fmtCond *formatConditional) *xlsxCfRule {
+			return drawCondFmtTimePeriod(p, ct, topLeftCell, fmtCond)
+		},
 	}
 
 	ws, err := f.workSheetReader(sheet)
@@ -3048,18 +4640,34 @@ func (f *File) SetConditionalFormat(sheet, area, formatSet string) error {
 		return err
 	}
 	var cfRule []*xlsxCfRule
-	for p, v := range format {
+	priority := ws.maxCfRulePriority()
+	for _, v := range format {
 		var vt, ct string
 		var ok bool
 		// "type" is a required parameter, check for valid validation types.
 		vt, ok = validType[v.Type]
+		if _, isTimePeriod := timePeriodFormulas[criteriaType[v.Criteria]]; vt == "timePeriod" && !isTimePeriod {
+			return ErrTimePeriodCriteria
+		}
 		if ok {
 			// Check for valid criteria types.
 			ct, ok = criteriaType[v.Criteria]
-			if ok || vt == "expression" {
+			if ok || vt == "expression" || vt == "duplicateValues" || vt == "uniqueValues" {
 				drawfunc, ok := drawContFmtFunc[vt]
 				if ok {
-					cfRule = append(cfRule, drawfunc(p, ct, v))
+					rule := drawfunc(priority, ct, v)
+					if v.Priority > 0 && v.Priority <= priority {
+						ws.shiftCfRulePriority(v.Priority, cfRule)
+						priority++
+					}
+					if v.Priority > 0 {
+						rule.Priority = v.Priority
+					}
+					if rule.Priority > priority {
+						priority = rule.Priority
+					}
+					rule.StopIfTrue = v.StopIfTrue
+					cfRule = append(cfRule, rule)
 				}
 			}
 		}
@@ -3072,6 +4680,145 @@ func (f *File) SetConditionalFormat(sheet, area, formatSet string) error {
 	return err
 }
 
+// prepareConditionalFormatRange provides a function to normalize the cell
+// ranges of the conditional format, such correct A10:A1 to A1:A10 and use
+// space to separate the cell ranges, so that the relative references in the
+// formula are anchored to the top-left cell of the first cell range. The full
+// column and full row references such as A:A and 1:1 are accepted as the
+// same as the cell ranges.
+func (f *File) prepareConditionalFormatRange(area string) (string, error) {
+	refs := strings.Fields(strings.ReplaceAll(area, ",", " "))
+	if len(refs) == 0 {
+		return area, ErrParameterInvalid
+	}
+	for i, ref := range refs {
+		if !strings.Contains(ref, ":") {
+			if _, _, err := CellNameToCoordinates(ref); err != nil {
+				return area, err
+			}
+			continue
+		}
+		fullRef, ok, err := prepareFullRangeRef(ref)
+		if err != nil {
+			return area, err
+		}
+		if ok {
+			refs[i] = fullRef
+			continue
+		}
+		coordinates, err := areaRefToCoordinates(ref)
+		if err != nil {
+			return area, err
+		}
+		_ = sortCoordinates(coordinates)
+		refs[i], _ = f.coordinatesToAreaRef(coordinates)
+	}
+	return strings.Join(refs, " "), nil
+}
+
+// prepareFullRangeRef provides a function to normalize the full column
+// reference such as "C:A" to "A:C" and the full row reference such as "3:1"
+// to "1:3", it returns false if the given reference isn't a full column or
+// full row reference.
+func prepareFullRangeRef(ref string) (string, bool, error) {
+	rng := strings.Split(strings.ReplaceAll(ref, "$", ""), ":")
+	if len(rng) != 2 {
+		return ref, false, nil
+	}
+	isLetters, isDigits := true, true
+	for _, part := range rng {
+		if part == "" {
+			isLetters, isDigits = false, false
+		}
+		for _, r := range part {
+			isLetters = isLetters && (('A' <= r && r <= 'Z') || ('a' <= r && r <= 'z'))
+			isDigits = isDigits && '0' <= r && r <= '9'
+		}
+	}
+	if isLetters {
+		first, err := ColumnNameToNumber(rng[0])
+		if err != nil {
+			return ref, true, err
+		}
+		last, err := ColumnNameToNumber(rng[1])
+		if err != nil {
+			return ref, true, err
+		}
+		if first > last {
+			first, last = last, first
+		}
+		firstName, _ := ColumnNumberToName(first)
+		lastName, _ := ColumnNumberToName(last)
+		return firstName + ":" + lastName, true, nil
+	}
+	if isDigits {
+		rows := make([]int, 2)
+		for i, part := range rng {
+			row, err := strconv.Atoi(part)
+			if err != nil || row > TotalRows {
+				return ref, true, ErrMaxRows
+			}
+			if row < 1 {
+				return ref, true, newInvalidRowNumberError(row)
+			}
+			rows[i] = row
+		}
+		if rows[0] > rows[1] {
+			rows[0], rows[1] = rows[1], rows[0]
+		}
+		return fmt.Sprintf("%d:%d", rows[0], rows[1]), true, nil
+	}
+	return ref, false, nil
+}
+
+// getConditionalFormatTopLeftCell provides a function to get the top-left
+// cell of the first cell range by given normalized cell ranges of the
+// conditional format, the first cell of the columns or rows will be returned
+// for the full column or full row reference.
+func getConditionalFormatTopLeftCell(area string) string {
+	rng := strings.Split(strings.Fields(area)[0], ":")
+	if len(rng) == 2 {
+		if col, err := ColumnNameToNumber(rng[0]); err == nil {
+			cell, _ := CoordinatesToCellName(col, 1)
+			return cell
+		}
+		if row, err := strconv.Atoi(rng[0]); err == nil {
+			cell, _ := CoordinatesToCellName(1, row)
+			return cell
+		}
+	}
+	return rng[0]
+}
+
+// maxCfRulePriority provides a function to get the maximum priority of the
+// conditional formatting rules in the worksheet.
+func (ws *xlsxWorksheet) maxCfRulePriority() int {
+	var priority int
+	for _, cf := range ws.ConditionalFormatting {
+		for _, rule := range cf.CfRule {
+			if rule.Priority > priority {
+				priority = rule.Priority
+			}
+		}
+	}
+	return priority
+}
+
+// shiftCfRulePriority provides a function to increase the priority numbers of
+// the conditional formatting rules in the worksheet and the given rules by
+// one, which priority number is greater than or equal to the given priority,
+// so that the priority numbers of the rules are kept unique.
+func (ws *xlsxWorksheet) shiftCfRulePriority(priority int, rules []*xlsxCfRule) {
+	for _, cf := range ws.ConditionalFormatting {
+		rules = append(rules, cf.CfRule...)
+	}
+	for _, rule := range rules {
+		if rule.Priority >= priority {
+			rule.Priority++
+		}
+	}
+}
+
 // UnsetConditionalFormat provides a function to unset the conditional format
 // by given worksheet name and range.
 func (f *File) UnsetConditionalFormat(sheet, area string) error {
@@ -3079,6 +4826,9 @@ func (f *File) UnsetConditionalFormat(sheet, area string) error {
 	if err != nil {
 		return err
 	}
+	if sqref, err := f.prepareConditionalFormatRange(area); err == nil {
+		area = sqref
+	}
 	for i, cf := range ws.ConditionalFormatting {
 		if cf.SQRef == area {
 			ws.ConditionalFormatting = append(ws.ConditionalFormatting[:i], ws.ConditionalFormatting[i+1:]...)
@@ -3088,6 +4838,148 @@ func (f *File) UnsetConditionalFormat(sheet, area string) error {
 	return nil
 }
 
+// GetConditionalFormats returns the conditional format settings of the
+// worksheet by given worksheet name, the map key is the cell ranges of the
+// conditional format, such as "A1:A10", the full column reference "A:A" or
+// the full row reference "1:1", and the map value is the format settings in
+// the same JSON format as the SetConditionalFormat function accepted. The
+// rules of the conditional formats with the same cell ranges will be merged
+// into the format settings of the cell ranges in the order of them. The
+// priority of the rules is omitted from the format settings, so the rules
+// will be added after the existing rules when they are applied to another
+// worksheet. The conditional formatting rules which are not supported by the
+// SetConditionalFormat function will be ignored. For example, copy the
+// conditional formats of Sheet1 to Sheet2:
+//
+//	formats, err := f.GetConditionalFormats("Sheet1")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	for area, format := range formats {
+//	    if err := f.SetConditionalFormat("Sheet2", area, format); err != nil {
+//	        fmt.Println(err)
+//	    }
+//	}
+func (f *File) GetConditionalFormats(sheet string) (map[string]string, error) {
+	formats := make(map[string]string)
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return formats, err
+	}
+	formatSets := make(map[string][]*formatConditional)
+	for _, cf := range ws.ConditionalFormatting {
+		for _, rule := range cf.CfRule {
+			if fc := f.extractCondFmt(rule); fc != nil {
+				formatSets[cf.SQRef] = append(formatSets[cf.SQRef], fc)
+			}
+		}
+	}
+	for area, format := range formatSets {
+		var buf bytes.Buffer
+		enc := json.NewEncoder(&buf)
+		enc.SetEscapeHTML(false)
+		if err = enc.Encode(format); err != nil {
+			return formats, err
+		}
+		formats[area] = strings.TrimSuffix(buf.String(), "\n")
+	}
+	return formats, err
+}
+
+// extractCondFmt provides a function to convert the conditional formatting
+// rule to the format settings, it returns nil if the type of the rule isn't
+// supported.
+func (f *File) extractCondFmt(rule *xlsxCfRule) *formatConditional {
+	format := &formatConditional{Criteria: "=", StopIfTrue: rule.StopIfTrue}
+	if rule.DxfID != nil {
+		format.Format = *rule.DxfID
+	}
+	switch rule.Type {
+	case "cellIs":
+		criteria, ok := criteriaNames[rule.Operator]
+		if !ok {
+			return nil
+		}
+		format.Type, format.Criteria = "cell", criteria
+		if rule.Operator == "between" || rule.Operator == "notBetween" {
+			if len(rule.Formula) == 2 {
+				format.Minimum, format.Maximum = rule.Formula[0], rule.Formula[1]
+			}
+		} else if len(rule.Formula) > 0 {
+			format.Value = rule.Formula[0]
+		}
+	case "top10":
+		if format.Type = "top"; rule.Bottom {
+			format.Type = "bottom"
+		}
+		format.Value, format.Percent = strconv.Itoa(rule.Rank), rule.Percent
+	case "aboveAverage":
+		format.Type = "average"
+		format.AboveAverage = rule.AboveAverage == nil || *rule.AboveAverage
+	case "duplicateValues", "uniqueValues":
+		if format.Type, format.Criteria = "duplicate", ""; rule.Type == "uniqueValues" {
+			format.Type = "unique"
+		}
+	case "timePeriod":
+		criteria, ok := criteriaNames[rule.TimePeriod]
+		if !ok {
+			return nil
+		}
+		format.Type, format.Criteria = "time_period", criteria
+	case "colorScale":
+		return f.extractCondFmtColorScale(rule, format)
+	case "dataBar":
+		if rule.DataBar == nil || len(rule.DataBar.Cfvo) < 2 {
+			return nil
+		}
+		format.Type = "data_bar"
+		format.MinType, format.MaxType = rule.DataBar.Cfvo[0].Type, rule.DataBar.Cfvo[1].Type
+		if len(rule.DataBar.Color) > 0 {
+			if color := f.getColorRGB(rule.DataBar.Color[0]); color != "" {
+				format.BarColor = "#" + color
+			}
+		}
+	case "expression":
+		if len(rule.Formula) == 0 {
+			return nil
+		}
+		format.Type, format.Criteria = "formula", rule.Formula[0]
+	default:
+		return nil
+	}
+	return format
+}
+
+// extractCondFmtColorScale provides a function to convert the color scale
+// conditional formatting rule to the format settings, it returns nil if the
+// rule isn't a 2 color scale or 3 color scale.
+func (f *File) extractCondFmtColorScale(rule *xlsxCfRule, format *formatConditional) *formatConditional {
+	if rule.ColorScale == nil || len(rule.ColorScale.Cfvo) != len(rule.ColorScale.Color) {
+		return nil
+	}
+	getColor := func(idx int) string {
+		if color := f.getColorRGB(rule.ColorScale.Color[idx]); color != "" {
+			return "#" + color
+		}
+		return ""
+	}
+	cfvo := rule.ColorScale.Cfvo
+	switch len(cfvo) {
+	case 2:
+		format.Type = "2_color_scale"
+	case 3:
+		format.Type = "3_color_scale"
+		format.MidType, format.MidValue, format.MidColor = cfvo[1].Type, cfvo[1].Val, getColor(1)
+	default:
+		return nil
+	}
+	last := len(cfvo) - 1
+	format.MinType, format.MinValue, format.MinColor = cfvo[0].Type, cfvo[0].Val, getColor(0)
+	format.MaxType, format.MaxValue, format.MaxColor = cfvo[last].Type, cfvo[last].Val, getColor(last)
+	return format
+}
+
 // drawCondFmtCellIs provides a function to create conditional formatting rule
 // for cell value (include between, not between, equal, not equal, greater
 // than and less than) by given priority, criteria type and format settings.
@@ -3153,6 +5045,19 @@ func drawCondFmtDuplicateUniqueValues(p int, ct string, format *formatConditiona
 	}
 }
 
+// drawCondFmtTimePeriod provides a function to create conditional formatting
+// rule for the dates occurring in the time period by given priority, criteria
+// type, top-left cell of the range and format settings.
+func drawCondFmtTimePeriod(p int, ct, cell string, format *formatConditional) *xlsxCfRule {
+	return &xlsxCfRule{
+		Priority:   p + 1,
+		Type:       validType[format.Type],
+		TimePeriod: ct,
+		Formula:    []string{fmt.Sprintf(timePeriodFormulas[ct], cell)},
+		DxfID:      &format.Format,
+	}
+}
+
 // drawCondFmtColorScale provides a function to create conditional formatting
 // rule for color scale (include 2 color scale and 3 color scale) by given
 // priority, criteria type and format settings.
@@ -3210,7 +5115,7 @@ func drawConfFmtExp(p int, ct string, format *formatConditional) *xlsxCfRule {
 	return &xlsxCfRule{
 		Priority: p + 1,
 		Type:     validType[format.Type],
-		Formula:  []string{format.Criteria},
+		Formula:  []string{strings.TrimPrefix(format.Criteria, "=")},
 		DxfID:    &format.Format,
 	}
 }
@@ -3235,6 +5140,127 @@ func (f *File) themeReader() *xlsxTheme {
 	return &theme
 }
 
+// GetTheme provides a function to get the color scheme and the Latin major
+// and minor fonts of the workbook theme. The colors are returned in the
+// RRGGBB format, and the system colors will be resolved by their last
+// computed values. This function returns ErrThemeNotExist if the workbook
+// doesn't contain a theme. For example, get the accent 1 color of the theme:
+//
+//	theme, err := f.GetTheme()
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	fmt.Println(theme.Colors.Accent1)
+func (f *File) GetTheme() (*Theme, error) {
+	if _, ok := f.Pkg.Load("xl/theme/theme1.xml"); !ok || f.Theme == nil {
+		return nil, ErrThemeNotExist
+	}
+	elements := f.Theme.ThemeElements
+	theme := &Theme{
+		ColorSchemeName: elements.ClrScheme.Name,
+		FontSchemeName:  elements.FontScheme.Name,
+		MajorFont:       getThemeLatinFont(elements.FontScheme.MajorFont.Children),
+		MinorFont:       getThemeLatinFont(elements.FontScheme.MinorFont.Children),
+	}
+	colors := map[string]*string{
+		"dk1": &theme.Colors.Dark1, "lt1": &theme.Colors.Light1,
+		"dk2": &theme.Colors.Dark2, "lt2": &theme.Colors.Light2,
+		"accent1": &theme.Colors.Accent1, "accent2": &theme.Colors.Accent2,
+		"accent3": &theme.Colors.Accent3, "accent4": &theme.Colors.Accent4,
+		"accent5": &theme.Colors.Accent5, "accent6": &theme.Colors.Accent6,
+		"hlink": &theme.Colors.Hyperlink, "folHlink": &theme.Colors.FollowedHyperlink,
+	}
+	for _, clr := range elements.ClrScheme.Children {
+		color, ok := colors[clr.XMLName.Local]
+		if !ok {
+			continue
+		}
+		if clr.SrgbClr != nil && clr.SrgbClr.Val != nil {
+			*color = strings.ToUpper(*clr.SrgbClr.Val)
+		} else if clr.SysClr != nil {
+			*color = strings.ToUpper(clr.SysClr.LastClr)
+		}
+	}
+	return theme, nil
+}
+
+// getThemeLatinFont provides a function to get the typeface of the Latin
+// font from the major or minor fonts of the theme font scheme.
+func getThemeLatinFont(fonts []xlsxFontSchemeEl) string {
+	for _, font := range fonts {
+		if font.XMLName.Local == "latin" {
+			return font.Typeface
+		}
+	}
+	return ""
+}
+
+// getThemeColor provides a function to get the RGB value of the theme color
+// in the RRGGBB format by given theme color index. The index 0 to 3 of the
+// theme color reference the light 1, dark 1, light 2 and dark 2 colors, which
+// stored in the reverse order of each pair in the color scheme.
+func (f *File) getThemeColor(index int) string {
+	if f.Theme == nil || index < 0 {
+		return ""
+	}
+	if index < 4 {
+		index ^= 1
+	}
+	children := f.Theme.ThemeElements.ClrScheme.Children
+	if index >= len(children) {
+		return ""
+	}
+	if clr := children[index]; clr.SrgbClr != nil && clr.SrgbClr.Val != nil {
+		return strings.ToUpper(*clr.SrgbClr.Val)
+	} else if clr.SysClr != nil {
+		return strings.ToUpper(clr.SysClr.LastClr)
+	}
+	return ""
+}
+
+// getColorRGB provides a function to resolve the color to the RRGGBB format
+// by the indexed color palette of the workbook. It returns empty string for
+// the automatic color or the color which can't be resolved.
+func (f *File) getColorRGB(clr *xlsxColor) string {
+	var palette []string
+	if clr != nil && clr.Indexed != nil {
+		palette = f.GetIndexedColors()
+	}
+	return f.resolveColorRGB(palette, clr)
+}
+
+// resolveColorRGB provides a function to resolve the color to the RRGGBB
+// format by given indexed color palette, through the RGB value, the indexed
+// color or the theme color scheme in order, and apply the tint of the color.
+// It returns empty string for the automatic color or the color which can't
+// be resolved.
+func (f *File) resolveColorRGB(palette []string, clr *xlsxColor) string {
+	if clr == nil || clr.Auto {
+		return ""
+	}
+	var color string
+	switch {
+	case clr.RGB != "":
+		if color = strings.ToUpper(clr.RGB); len(color) == 8 {
+			color = color[2:]
+		}
+	case clr.Indexed != nil:
+		if *clr.Indexed >= 0 && *clr.Indexed < len(palette) {
+			color = palette[*clr.Indexed]
+		}
+	case clr.Theme != nil:
+		color = f.getThemeColor(*clr.Theme)
+	}
+	if !isHexColor(color) {
+		return ""
+	}
+	if clr.Tint != 0 {
+		color = ThemeColor(color, clr.Tint)[2:]
+	}
+	return color
+}
+
 // ThemeColor applied the color with tint value.
 func ThemeColor(baseColor string, tint float64) string {
 	if tint == 0 {
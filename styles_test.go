@@ -1,6 +1,8 @@
 package excelize
 
 import (
+	"encoding/json"
+	"encoding/xml"
 	"fmt"
 	"math"
 	"path/filepath"
@@ -175,6 +177,218 @@ func TestSetConditionalFormat(t *testing.T) {
 	}
 }
 
+func TestSetConditionalFormatPriority(t *testing.T) {
+	f := NewFile()
+	format, err := f.NewConditionalStyle(`{"font":{"color":"#9A0511"}}`)
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "A1:A10", `[{"type":"3_color_scale","criteria":"=","min_type":"min","mid_type":"percentile","max_type":"max","min_color":"#F8696B","mid_color":"#FFEB84","max_color":"#63BE7B"}]`))
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "B1:B10", fmt.Sprintf(`[{"type":"cell","criteria":">","format":%[1]d,"value":"6"},{"type":"cell","criteria":"<","format":%[1]d,"value":"2"}]`, format)))
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "A1:A10", fmt.Sprintf(`[{"type":"formula","criteria":"ISBLANK(A1)","format":%d,"priority":1,"stop_if_true":true}]`, format)))
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	var priorities []int
+	var stopIfTrue []bool
+	for _, cf := range ws.ConditionalFormatting {
+		for _, rule := range cf.CfRule {
+			priorities, stopIfTrue = append(priorities, rule.Priority), append(stopIfTrue, rule.StopIfTrue)
+		}
+	}
+	assert.Equal(t, []int{2, 3, 4, 1}, priorities)
+	assert.Equal(t, []bool{false, false, false, true}, stopIfTrue)
+	// Test set the rules with the explicit priority collides with the existing rules
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "C1:C10", fmt.Sprintf(`[{"type":"cell","criteria":">","format":%[1]d,"value":"1","priority":3},{"type":"cell","criteria":">","format":%[1]d,"value":"2"},{"type":"cell","criteria":">","format":%[1]d,"value":"3","priority":3}]`, format)))
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "D1:D10", fmt.Sprintf(`[{"type":"cell","criteria":">","format":%d,"value":"4","priority":10}]`, format)))
+	priorities = nil
+	for _, cf := range ws.ConditionalFormatting {
+		for _, rule := range cf.CfRule {
+			priorities = append(priorities, rule.Priority)
+		}
+	}
+	assert.Equal(t, []int{2, 5, 6, 1, 4, 7, 3, 10}, priorities)
+	// Test the stopIfTrue attribute only be written for the rules stop if true
+	output, err := xml.Marshal(ws.ConditionalFormatting)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, strings.Count(string(output), "stopIfTrue"))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetConditionalFormatPriority.xlsx")))
+}
+
+func TestSetConditionalFormatDuplicateUnique(t *testing.T) {
+	f := NewFile()
+	format, err := f.NewConditionalStyle(`{"font":{"color":"#9A0511"},"fill":{"type":"pattern","color":["#FEC7CE"],"pattern":1}}`)
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "A1:A10 C1:C10", fmt.Sprintf(`[{"type":"duplicate","format":%d}]`, format)))
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "E1:E10", fmt.Sprintf(`[{"type":"unique","criteria":"=","format":%d}]`, format)))
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, ws.ConditionalFormatting, 2)
+	assert.Equal(t, "A1:A10 C1:C10", ws.ConditionalFormatting[0].SQRef)
+	assert.Equal(t, []*xlsxCfRule{{Priority: 1, Type: "duplicateValues", DxfID: &format}}, ws.ConditionalFormatting[0].CfRule)
+	assert.Equal(t, "E1:E10", ws.ConditionalFormatting[1].SQRef)
+	assert.Equal(t, []*xlsxCfRule{{Priority: 2, Type: "uniqueValues", DxfID: &format}}, ws.ConditionalFormatting[1].CfRule)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetConditionalFormatDuplicateUnique.xlsx")))
+}
+
+func TestSetConditionalFormatTimePeriod(t *testing.T) {
+	f := NewFile()
+	format, err := f.NewConditionalStyle(`{"font":{"color":"#9A0511"}}`)
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "B10:B1", fmt.Sprintf(`[{"type":"time_period","criteria":"last 7 days","format":%[1]d},{"type":"time_period","criteria":"next month","format":%[1]d}]`, format)))
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "C1", fmt.Sprintf(`[{"type":"time_period","criteria":"today","format":%d}]`, format)))
+	// Test set time period conditional format with invalid criteria
+	for _, criteria := range []string{">", "", "next year"} {
+		assert.Equal(t, ErrTimePeriodCriteria, f.SetConditionalFormat("Sheet1", "D1:D10", fmt.Sprintf(`[{"type":"time_period","criteria":"today","format":%[1]d},{"type":"time_period","criteria":"%[2]s","format":%[1]d}]`, format, criteria)), criteria)
+	}
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, ws.ConditionalFormatting, 2)
+	assert.Equal(t, []*xlsxCfRule{
+		{Priority: 1, Type: "timePeriod", TimePeriod: "last7Days", Formula: []string{"AND(TODAY()-FLOOR(B1,1)<=6,FLOOR(B1,1)<=TODAY())"}, DxfID: &format},
+		{Priority: 2, Type: "timePeriod", TimePeriod: "nextMonth", Formula: []string{"AND(MONTH(B1)=MONTH(EDATE(TODAY(),0+1)),YEAR(B1)=YEAR(EDATE(TODAY(),0+1)))"}, DxfID: &format},
+	}, ws.ConditionalFormatting[0].CfRule)
+	assert.Equal(t, []*xlsxCfRule{
+		{Priority: 3, Type: "timePeriod", TimePeriod: "today", Formula: []string{"FLOOR(C1,1)=TODAY()"}, DxfID: &format},
+	}, ws.ConditionalFormatting[1].CfRule)
+	for _, criteria := range []string{"yesterday", "tomorrow", "last week", "this week", "next week", "continue week", "last month", "this month", "continue month"} {
+		rule := drawCondFmtTimePeriod(0, criteriaType[criteria], "A1", &formatConditional{Type: "time_period", Criteria: criteria})
+		if assert.NotNil(t, rule, criteria) {
+			assert.Contains(t, rule.Formula[0], "A1", criteria)
+		}
+	}
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetConditionalFormatTimePeriod.xlsx")))
+}
+
+func TestSetConditionalFormatRelativeFormula(t *testing.T) {
+	f := NewFile()
+	format, err := f.NewConditionalStyle(`{"font":{"color":"#9A0511"}}`)
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "A100:A1", fmt.Sprintf(`[{"type":"formula","criteria":"=A1>B1","format":%d}]`, format)))
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "D10:C1,E1", fmt.Sprintf(`[{"type":"formula","criteria":"C1>$F$1","format":%d}]`, format)))
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, ws.ConditionalFormatting, 2)
+	assert.Equal(t, "A1:A100", ws.ConditionalFormatting[0].SQRef)
+	assert.Equal(t, []string{"A1>B1"}, ws.ConditionalFormatting[0].CfRule[0].Formula)
+	assert.Equal(t, "C1:D10 E1", ws.ConditionalFormatting[1].SQRef)
+	assert.Equal(t, []string{"C1>$F$1"}, ws.ConditionalFormatting[1].CfRule[0].Formula)
+	// Test unset the conditional format with the range before normalized
+	assert.NoError(t, f.UnsetConditionalFormat("Sheet1", "A100:A1"))
+	assert.Len(t, ws.ConditionalFormatting, 1)
+	// Test set conditional format with the full column and full row ranges
+	for area, expected := range map[string]string{"A:A": "A:A", "1:1": "1:1", "$C:$B": "B:C", "3:$2 A1": "2:3 A1"} {
+		assert.NoError(t, f.SetConditionalFormat("Sheet1", area, fmt.Sprintf(`[{"type":"formula","criteria":"=A1>B1","format":%d}]`, format)), area)
+		sqref, err := f.prepareConditionalFormatRange(area)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, sqref)
+	}
+	// Test set conditional format with invalid range
+	assert.EqualError(t, f.SetConditionalFormat("Sheet1", "", "[]"), ErrParameterInvalid.Error())
+	assert.EqualError(t, f.SetConditionalFormat("Sheet1", "A", "[]"), newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
+	assert.EqualError(t, f.SetConditionalFormat("Sheet1", "A1:B", "[]"), newCellNameToCoordinatesError("B", newInvalidCellNameError("B")).Error())
+}
+
+func TestConditionalFormatFullRange(t *testing.T) {
+	f := NewFile()
+	format, err := f.NewConditionalStyle(`{"font":{"color":"#9A0511"}}`)
+	assert.NoError(t, err)
+	for area, formatSet := range map[string]string{
+		"A:A":             fmt.Sprintf(`[{"type":"formula","criteria":"=A1>B1","format":%d}]`, format),
+		"$D:$c":           `[{"type":"2_color_scale","criteria":"=","min_type":"min","max_type":"max","min_color":"#F8696B","max_color":"#63BE7B"}]`,
+		"3:1":             fmt.Sprintf(`[{"type":"time_period","criteria":"last 7 days","format":%d}]`, format),
+		"E:E 5:5":         fmt.Sprintf(`[{"type":"cell","criteria":"between","format":%d,"minimum":"1","maximum":"10"}]`, format),
+		"F1:F10":          fmt.Sprintf(`[{"type":"top","criteria":"=","format":%d,"value":"6","percent":true,"priority":1,"stop_if_true":true}]`, format),
+		"G:G,H1":          fmt.Sprintf(`[{"type":"average","criteria":"=","format":%d,"above_average":false},{"type":"unique","format":%d}]`, format, format),
+		"XFD:XFD":         `[{"type":"3_color_scale","criteria":"=","min_type":"min","mid_type":"percentile","max_type":"max","min_color":"#F8696B","mid_color":"#FFEB84","max_color":"#63BE7B"}]`,
+		"1048576:1048576": `[{"type":"data_bar","criteria":"=","min_type":"min","max_type":"max","bar_color":"#638EC6"}]`,
+	} {
+		assert.NoError(t, f.SetConditionalFormat("Sheet1", area, formatSet), area)
+	}
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	sqref := map[string]*xlsxConditionalFormatting{}
+	for _, cf := range ws.ConditionalFormatting {
+		sqref[cf.SQRef] = cf
+	}
+	for _, area := range []string{"A:A", "C:D", "1:3", "E:E 5:5", "F1:F10", "G:G H1", "XFD:XFD", "1048576:1048576"} {
+		assert.Contains(t, sqref, area)
+	}
+	assert.Equal(t, []string{"A1>B1"}, sqref["A:A"].CfRule[0].Formula)
+	assert.Equal(t, []string{"AND(TODAY()-FLOOR(A1,1)<=6,FLOOR(A1,1)<=TODAY())"}, sqref["1:3"].CfRule[0].Formula)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestConditionalFormatFullRange.xlsx")))
+
+	// Test get the conditional formats and apply them to another worksheet
+	formats, err := f.GetConditionalFormats("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, formats, 8)
+	assert.Equal(t, fmt.Sprintf(`[{"type":"formula","above_average":false,"percent":false,"format":%d,"criteria":"A1>B1"}]`, format), formats["A:A"])
+	assert.NotContains(t, formats["F1:F10"], "priority")
+	f.NewSheet("Sheet2")
+	assert.NoError(t, f.SetConditionalFormat("Sheet2", "Z1:Z10", fmt.Sprintf(`[{"type":"cell","criteria":">","format":%[1]d,"value":"6"},{"type":"cell","criteria":"<","format":%[1]d,"value":"2","priority":1}]`, format)))
+	for area, formatSet := range formats {
+		assert.NoError(t, f.SetConditionalFormat("Sheet2", area, formatSet), area)
+	}
+	copied, err := f.GetConditionalFormats("Sheet2")
+	assert.NoError(t, err)
+	for area, formatSet := range formats {
+		var expected, actual []*formatConditional
+		assert.NoError(t, json.Unmarshal([]byte(formatSet), &expected))
+		assert.NoError(t, json.Unmarshal([]byte(copied[area]), &actual))
+		assert.Equal(t, expected, actual, area)
+	}
+	// Test the copied rules are added after the existing rules with the unique priority
+	ws, err = f.workSheetReader("Sheet2")
+	assert.NoError(t, err)
+	priorities := map[int]bool{}
+	for _, cf := range ws.ConditionalFormatting {
+		for _, rule := range cf.CfRule {
+			assert.False(t, priorities[rule.Priority], rule.Priority)
+			priorities[rule.Priority] = true
+		}
+	}
+	assert.Len(t, priorities, 11)
+	assert.Equal(t, 2, ws.ConditionalFormatting[0].CfRule[0].Priority)
+	assert.Equal(t, 1, ws.ConditionalFormatting[0].CfRule[1].Priority)
+	// Test unset the conditional format with the full range reference
+	assert.NoError(t, f.UnsetConditionalFormat("Sheet2", "$A:$A"))
+	assert.NoError(t, f.UnsetConditionalFormat("Sheet2", "3:1"))
+	copied, err = f.GetConditionalFormats("Sheet2")
+	assert.NoError(t, err)
+	assert.Len(t, copied, 7)
+	assert.NotContains(t, copied, "A:A")
+	assert.NotContains(t, copied, "1:3")
+
+	// Test set conditional format with invalid full range reference
+	assert.EqualError(t, f.SetConditionalFormat("Sheet1", "A:XFE", "[]"), ErrColumnNumber.Error())
+	assert.EqualError(t, f.SetConditionalFormat("Sheet1", "XFE:A", "[]"), ErrColumnNumber.Error())
+	assert.EqualError(t, f.SetConditionalFormat("Sheet1", "1:1048577", "[]"), ErrMaxRows.Error())
+	assert.EqualError(t, f.SetConditionalFormat("Sheet1", "0:1", "[]"), newInvalidRowNumberError(0).Error())
+	assert.EqualError(t, f.SetConditionalFormat("Sheet1", "A:1", "[]"), newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
+	assert.EqualError(t, f.SetConditionalFormat("Sheet1", "A:", "[]"), newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
+	// Test get conditional formats with unsupported and invalid rules, and the
+	// rules of the conditional formats with the same cell ranges
+	ws, err = f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	ws.ConditionalFormatting = []*xlsxConditionalFormatting{
+		{SQRef: "B1", CfRule: []*xlsxCfRule{
+			{Type: "cellIs", Operator: "greaterThan", Formula: []string{"1"}},
+			{Type: "aboveAverage"},
+		}},
+		{SQRef: "A1", CfRule: []*xlsxCfRule{
+			{Type: "iconSet"}, {Type: "cellIs", Operator: "unknown"}, {Type: "timePeriod", TimePeriod: "unknown"},
+			{Type: "colorScale"}, {Type: "colorScale", ColorScale: &xlsxColorScale{Cfvo: []*xlsxCfvo{{}}, Color: []*xlsxColor{{}}}},
+			{Type: "dataBar"}, {Type: "expression"},
+		}},
+		{SQRef: "B1", CfRule: []*xlsxCfRule{
+			{Type: "colorScale", ColorScale: &xlsxColorScale{Cfvo: []*xlsxCfvo{{Type: "min"}, {Type: "max"}}, Color: []*xlsxColor{{Theme: intPtr(4)}, {Auto: true}}}},
+			{Type: "dataBar", DataBar: &xlsxDataBar{Cfvo: []*xlsxCfvo{{Type: "min"}, {Type: "max"}}}},
+		}},
+	}
+	formats, err = f.GetConditionalFormats("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"B1": `[{"type":"cell","above_average":false,"percent":false,"format":0,"criteria":"greater than","value":"1"},{"type":"average","above_average":true,"percent":false,"format":0,"criteria":"="},{"type":"2_color_scale","above_average":false,"percent":false,"format":0,"criteria":"=","min_type":"min","max_type":"max","min_color":"#5B9BD5"},{"type":"data_bar","above_average":false,"percent":false,"format":0,"criteria":"=","min_type":"min","max_type":"max"}]`}, formats)
+	_, err = f.GetConditionalFormats("SheetN")
+	assert.EqualError(t, err, "sheet SheetN is not exist")
+}
+
 func TestUnsetConditionalFormat(t *testing.T) {
 	f := NewFile()
 	assert.NoError(t, f.SetCellValue("Sheet1", "A1", 7))
@@ -189,6 +403,54 @@ func TestUnsetConditionalFormat(t *testing.T) {
 	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestUnsetConditionalFormat.xlsx")))
 }
 
+func TestGetConditionalStyle(t *testing.T) {
+	f := NewFile()
+	idx, err := f.NewConditionalStyle(`{"font":{"color":"#9A0511","bold":true,"underline":"single","size":12},"fill":{"type":"pattern","color":["#FEC7CE"],"pattern":1},"alignment":{"wrap_text":true,"text_rotation":-45},"border":[{"type":"left","color":"#000000","style":1},{"type":"diagonalUp","color":"#FF0000","style":6}]}`)
+	assert.NoError(t, err)
+	style, err := f.GetConditionalStyle(idx)
+	assert.NoError(t, err)
+	assert.Equal(t, &Style{
+		Border:    []Border{{Type: "left", Color: "#000000", Style: 1}, {Type: "diagonalUp", Color: "#FF0000", Style: 6}},
+		Fill:      Fill{Type: "pattern", Pattern: 1, Color: []string{"#FEC7CE"}},
+		Font:      &Font{Bold: true, Underline: "single", Family: "Calibri", Size: 12, Color: "#9A0511"},
+		Alignment: &Alignment{WrapText: true, TextRotation: -45},
+	}, style)
+	idx, err = f.NewConditionalStyle(`{"fill":{"type":"gradient","color":["#FFFFFF","#E0EBF5"],"shading":2}}`)
+	assert.NoError(t, err)
+	style, err = f.GetConditionalStyle(idx)
+	assert.NoError(t, err)
+	assert.Equal(t, Fill{Type: "gradient", Color: []string{"#FFFFFF", "#E0EBF5"}, Shading: 2}, style.Fill)
+
+	// Test get conditional style with theme and indexed colors
+	s := f.stylesReader()
+	s.Dxfs.Dxfs = append(s.Dxfs.Dxfs, &xlsxDxf{Dxf: `<font><b/><i val="0"/><u/><color theme="4"/></font><numFmt numFmtId="164" formatCode="0.00%"/><fill><patternFill><bgColor indexed="10"/></patternFill></fill><border><bottom style="thick"><color auto="1"/></bottom></border><protection locked="0"/>`})
+	style, err = f.GetConditionalStyle(len(s.Dxfs.Dxfs) - 1)
+	assert.NoError(t, err)
+	assert.Equal(t, &Style{
+		Border:       []Border{{Type: "bottom", Style: 5}},
+		Fill:         Fill{Type: "pattern", Pattern: 1, Color: []string{"#FF0000"}},
+		Font:         &Font{Bold: true, Underline: "single", Color: "#5B9BD5"},
+		Protection:   &Protection{},
+		NumFmt:       164,
+		CustomNumFmt: stringPtr("0.00%"),
+	}, style)
+	// Test get conditional style with the style in the gradient path type
+	s.Dxfs.Dxfs = append(s.Dxfs.Dxfs, &xlsxDxf{Dxf: `<fill><gradientFill type="path" left="0.5" right="0.5" top="0.5" bottom="0.5"><stop position="0"><color rgb="FFFFFFFF"/></stop><stop position="1"><color theme="99"/></stop></gradientFill></fill>`})
+	style, err = f.GetConditionalStyle(len(s.Dxfs.Dxfs) - 1)
+	assert.NoError(t, err)
+	assert.Equal(t, Fill{Type: "gradient", Color: []string{"#FFFFFF"}, Shading: 5}, style.Fill)
+
+	// Test get conditional style with invalid style index
+	_, err = f.GetConditionalStyle(-1)
+	assert.EqualError(t, err, newInvalidStyleID(-1).Error())
+	_, err = f.GetConditionalStyle(len(s.Dxfs.Dxfs))
+	assert.EqualError(t, err, newInvalidStyleID(len(s.Dxfs.Dxfs)).Error())
+	// Test get conditional style with invalid differential format
+	s.Dxfs.Dxfs = append(s.Dxfs.Dxfs, &xlsxDxf{Dxf: `<font>`})
+	_, err = f.GetConditionalStyle(len(s.Dxfs.Dxfs) - 1)
+	assert.EqualError(t, err, "XML syntax error on line 1: element <font> closed by </dxf>")
+}
+
 func TestNewStyle(t *testing.T) {
 	f := NewFile()
 	styleID, err := f.NewStyle(`{"font":{"bold":true,"italic":true,"family":"Times New Roman","size":36,"color":"#777777"}}`)
@@ -281,6 +543,19 @@ func TestNewStyle(t *testing.T) {
 	assert.Equal(t, 0, style5)
 }
 
+func TestNewStyleTextRotation(t *testing.T) {
+	f := NewFile()
+	for _, rotation := range []int{-91, 181, 254, 256} {
+		_, err := f.NewStyle(&Style{Alignment: &Alignment{TextRotation: rotation}})
+		assert.EqualError(t, err, ErrTextRotation.Error())
+	}
+	for rotation, expected := range map[int]int{-90: 180, -45: 135, 0: 0, 45: 45, 135: 135, TextRotationVertical: 255} {
+		styleID, err := f.NewStyle(&Style{Alignment: &Alignment{TextRotation: rotation}})
+		assert.NoError(t, err)
+		assert.Equal(t, expected, f.Styles.CellXfs.Xf[styleID].Alignment.TextRotation)
+	}
+}
+
 func TestGetDefaultFont(t *testing.T) {
 	f := NewFile()
 	s := f.GetDefaultFont()
@@ -311,6 +586,34 @@ func TestThemeReader(t *testing.T) {
 	assert.EqualValues(t, new(xlsxTheme), f.themeReader())
 }
 
+func TestGetTheme(t *testing.T) {
+	f := NewFile()
+	theme, err := f.GetTheme()
+	assert.NoError(t, err)
+	assert.Equal(t, &Theme{
+		ColorSchemeName: "Office",
+		Colors: ThemeColors{
+			Dark1: "000000", Light1: "FFFFFF", Dark2: "44546A", Light2: "E7E6E6",
+			Accent1: "5B9BD5", Accent2: "ED7D31", Accent3: "A5A5A5",
+			Accent4: "FFC000", Accent5: "4472C4", Accent6: "70AD47",
+			Hyperlink: "0563C1", FollowedHyperlink: "954F72",
+		},
+		FontSchemeName: "Office",
+		MajorFont:      "Calibri Light",
+		MinorFont:      "Calibri",
+	}, theme)
+	// Test get theme without the font scheme
+	f.Theme.ThemeElements.FontScheme = xlsxFontScheme{}
+	theme, err = f.GetTheme()
+	assert.NoError(t, err)
+	assert.Empty(t, theme.MajorFont)
+	assert.Empty(t, theme.MinorFont)
+	// Test get theme on the workbook without theme
+	f.Pkg.Delete("xl/theme/theme1.xml")
+	_, err = f.GetTheme()
+	assert.EqualError(t, err, ErrThemeNotExist.Error())
+}
+
 func TestSetCellStyle(t *testing.T) {
 	f := NewFile()
 	// Test set cell style on not exists worksheet.
@@ -325,6 +628,42 @@ func TestGetFillID(t *testing.T) {
 	assert.Equal(t, -1, getFillID(NewFile().stylesReader(), &Style{Fill: Fill{Type: "unknown"}}))
 }
 
+func TestGetFontIDImmediate(t *testing.T) {
+	f := NewFile()
+	s := f.stylesReader()
+	font := f.newFont(&Style{Font: &Font{Bold: true, Color: "#FF0000"}})
+	assert.Equal(t, -1, f.getFontIDImmediate(s, font))
+	assert.Equal(t, -1, f.getFontIDImmediate(&xlsxStyleSheet{}, font))
+	// Test the font compared by value, the font in the stylesheet with the same
+	// settings will be reused
+	style1, err := f.NewStyle(&Style{Font: &Font{Bold: true, Color: "#FF0000"}})
+	assert.NoError(t, err)
+	fonts := len(s.Fonts.Font)
+	assert.Equal(t, fonts-1, f.getFontIDImmediate(s, font))
+	style2, err := f.NewStyle(&Style{Font: &Font{Bold: true, Color: "#FF0000"}})
+	assert.NoError(t, err)
+	assert.Equal(t, style1, style2)
+	assert.Len(t, s.Fonts.Font, fonts)
+}
+
+func TestGetBorderIDImmediate(t *testing.T) {
+	f := NewFile()
+	s := f.stylesReader()
+	border := newBorders(&Style{Border: []Border{{Type: "left", Color: "#0000FF", Style: 1}}})
+	assert.Equal(t, -1, getBorderIDImmediate(s, border))
+	assert.Equal(t, -1, getBorderIDImmediate(&xlsxStyleSheet{}, border))
+	// Test the border compared by value, the border in the stylesheet with the
+	// same settings will be reused
+	style1, err := f.NewStyle(&Style{Border: []Border{{Type: "left", Color: "#0000FF", Style: 1}}})
+	assert.NoError(t, err)
+	borders := len(s.Borders.Border)
+	assert.Equal(t, borders-1, getBorderIDImmediate(s, border))
+	style2, err := f.NewStyle(&Style{Border: []Border{{Type: "left", Color: "#0000FF", Style: 1}}})
+	assert.NoError(t, err)
+	assert.Equal(t, style1, style2)
+	assert.Len(t, s.Borders.Border, borders)
+}
+
 func TestThemeColor(t *testing.T) {
 	for _, clr := range [][]string{
 		{"FF000000", ThemeColor("000000", -0.1)},
@@ -352,3 +691,723 @@ func TestGetNumFmtID(t *testing.T) {
 	assert.NotEqual(t, id1, id2)
 	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestStyleNumFmt.xlsx")))
 }
+
+func TestIndexedColors(t *testing.T) {
+	f := NewFile()
+	assert.Equal(t, defaultIndexedColors, f.GetIndexedColors())
+	colors := f.GetIndexedColors()
+	colors[8], colors[10] = "1F4E78", "#c00000"
+	assert.NoError(t, f.SetIndexedColors(colors))
+	colors[10] = "C00000"
+	assert.Equal(t, colors, f.GetIndexedColors())
+	// Test resolve the indexed colors in the cell style
+	styleID, err := f.NewStyle(&Style{
+		Font:   &Font{Bold: true},
+		Fill:   Fill{Type: "pattern", Pattern: 1, Color: []string{"#FFFFFF"}},
+		Border: []Border{{Type: "left", Color: "000000", Style: 1}},
+	})
+	assert.NoError(t, err)
+	s := f.stylesReader()
+	xf := s.CellXfs.Xf[styleID]
+	s.Fonts.Font[*xf.FontID].Color = &xlsxColor{Indexed: intPtr(8)}
+	s.Fills.Fill[*xf.FillID].PatternFill.FgColor = &xlsxColor{Indexed: intPtr(10)}
+	s.Borders.Border[*xf.BorderID].Left.Color = &xlsxColor{Indexed: intPtr(64)}
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A1", "A1", styleID))
+	styleJSON, err := f.GetCellStyleJson("Sheet1", "A1")
+	assert.NoError(t, err)
+	var so StyleOutput
+	assert.NoError(t, json.Unmarshal([]byte(styleJSON), &so))
+	assert.Equal(t, "FF1F4E78", so.Font.Color.RGB)
+	assert.Equal(t, "FFC00000", so.Fill.PatternFill.FgColor.RGB)
+	assert.Equal(t, "", so.Border.Left.Color.RGB)
+	// Test resolve the black indexed color and the theme color with tint
+	s.Fonts.Font[*xf.FontID].Color = &xlsxColor{Indexed: intPtr(0)}
+	s.Fills.Fill[*xf.FillID].PatternFill.FgColor = &xlsxColor{Theme: intPtr(4), Tint: -0.5}
+	styleJSON, err = f.GetCellStyleJson("Sheet1", "A1")
+	assert.NoError(t, err)
+	so = StyleOutput{}
+	assert.NoError(t, json.Unmarshal([]byte(styleJSON), &so))
+	assert.Equal(t, "FF000000", so.Font.Color.RGB)
+	assert.Equal(t, ThemeColor("5B9BD5", -0.5), so.Fill.PatternFill.FgColor.RGB)
+	// Test the indexed colors in the style sheet was not changed
+	assert.Equal(t, "", s.Fonts.Font[*xf.FontID].Color.RGB)
+	assert.Equal(t, "", s.Fills.Fill[*xf.FillID].PatternFill.FgColor.RGB)
+	// Test set indexed colors with partial palette
+	assert.NoError(t, f.SetIndexedColors([]string{"111111"}))
+	colors = f.GetIndexedColors()
+	assert.Equal(t, "111111", colors[0])
+	assert.Equal(t, defaultIndexedColors[1:], colors[1:])
+	path := filepath.Join("test", "TestIndexedColors.xlsx")
+	assert.NoError(t, f.SaveAs(path))
+	f, err = OpenFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, colors, f.GetIndexedColors())
+	// Test restore the default palette
+	assert.NoError(t, f.SetIndexedColors(nil))
+	assert.Nil(t, f.stylesReader().Colors)
+	assert.Equal(t, defaultIndexedColors, f.GetIndexedColors())
+	assert.NoError(t, f.SetIndexedColors(nil))
+	// Test set indexed colors with invalid parameters
+	assert.EqualError(t, f.SetIndexedColors(make([]string, 65)), ErrIndexedColors.Error())
+	assert.EqualError(t, f.SetIndexedColors([]string{"FFF"}), ErrIndexedColors.Error())
+	assert.EqualError(t, f.SetIndexedColors([]string{"GGGGGG"}), ErrIndexedColors.Error())
+	assert.NoError(t, f.Close())
+}
+
+func TestPruneStyles(t *testing.T) {
+	f := NewFile()
+	f.NewSheet("Sheet2")
+	assert.NoError(t, f.AddChartSheet("Chart1", `{"type":"col","series":[{"name":"Sheet1!$A$1","categories":"Sheet1!$A$2","values":"Sheet1!$B$2"}]}`))
+	var styles []int
+	for _, style := range []*Style{
+		{Font: &Font{Bold: true, Color: "#FF0000"}},
+		{Fill: Fill{Type: "pattern", Color: []string{"#00FF00"}, Pattern: 1}},
+		{Border: []Border{{Type: "left", Color: "#0000FF", Style: 1}}},
+		{CustomNumFmt: stringPtr("0.000")},
+		{Font: &Font{Italic: true}, CustomNumFmt: stringPtr("0.0000")},
+		{Font: &Font{Underline: "single"}, Fill: Fill{Type: "pattern", Color: []string{"#FFFF00"}, Pattern: 1}},
+		{Alignment: &Alignment{Horizontal: "center"}},
+	} {
+		styleID, err := f.NewStyle(style)
+		assert.NoError(t, err)
+		styles = append(styles, styleID)
+	}
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A1", "A1", styles[1]))
+	assert.NoError(t, f.SetCellStyle("Sheet2", "B2", "B2", styles[4]))
+	assert.NoError(t, f.SetRowStyle("Sheet1", 3, 3, styles[6]))
+	assert.NoError(t, f.SetColStyle("Sheet2", "D", styles[4]))
+	getStyles := func() []string {
+		var result []string
+		for _, cell := range []struct{ sheet, axis string }{{"Sheet1", "A1"}, {"Sheet2", "B2"}, {"Sheet1", "C3"}, {"Sheet2", "D10"}} {
+			styleID, err := f.GetCellStyle(cell.sheet, cell.axis)
+			assert.NoError(t, err)
+			s, xf := f.stylesReader(), f.stylesReader().CellXfs.Xf[styleID]
+			style := struct {
+				Font      *xlsxFont
+				Fill      *xlsxFill
+				Border    *xlsxBorder
+				NumFmt    string
+				Alignment *xlsxAlignment
+			}{Alignment: xf.Alignment}
+			style.Font, style.Fill, style.Border = s.Fonts.Font[*xf.FontID], s.Fills.Fill[*xf.FillID], s.Borders.Border[*xf.BorderID]
+			if s.NumFmts != nil {
+				for _, numFmt := range s.NumFmts.NumFmt {
+					if numFmt.NumFmtID == *xf.NumFmtID {
+						style.NumFmt = numFmt.FormatCode
+					}
+				}
+			}
+			output, err := json.Marshal(style)
+			assert.NoError(t, err)
+			result = append(result, string(output))
+		}
+		return result
+	}
+	expected := getStyles()
+	removed, err := f.PruneStyles()
+	assert.NoError(t, err)
+	// Removed 4 cell formats, 2 fonts, 1 fill, 1 border and 1 number format
+	assert.Equal(t, 9, removed)
+	assert.Equal(t, expected, getStyles())
+	s := f.stylesReader()
+	assert.Len(t, s.CellXfs.Xf, 4)
+	assert.Equal(t, 4, s.CellXfs.Count)
+	assert.Len(t, s.Fonts.Font, 2)
+	assert.Len(t, s.Fills.Fill, 3)
+	assert.Len(t, s.Borders.Border, 1)
+	assert.Len(t, s.NumFmts.NumFmt, 1)
+	styleID, err := f.GetCellStyle("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, styleID)
+	// Test prune styles again without unused styles
+	removed, err = f.PruneStyles()
+	assert.NoError(t, err)
+	assert.Equal(t, 0, removed)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestPruneStyles.xlsx")))
+	// Test prune the unused differential formats
+	f = NewFile()
+	var dxfs []int
+	for _, color := range []string{"#FF0000", "#00FF00", "#0000FF", "#FFFF00"} {
+		dxf, err := f.NewConditionalStyle(fmt.Sprintf(`{"font":{"color":"%s"}}`, color))
+		assert.NoError(t, err)
+		dxfs = append(dxfs, dxf)
+	}
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "A1:A10", fmt.Sprintf(`[{"type":"cell","criteria":">","format":%d,"value":"6"}]`, dxfs[2])))
+	f.Pkg.Store("xl/tables/table1.xml", []byte(fmt.Sprintf(`<table id="1" ref="C1:D3" dataDxfId="%d"/>`, dxfs[3])))
+	removed, err = f.PruneStyles()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, removed)
+	assert.Len(t, f.Styles.Dxfs.Dxfs, 3)
+	assert.Equal(t, 3, f.Styles.Dxfs.Count)
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, *ws.ConditionalFormatting[0].CfRule[0].DxfID)
+	assert.Contains(t, f.Styles.Dxfs.Dxfs[1].Dxf, "FF0000FF")
+	content, ok := f.Pkg.Load("xl/tables/table1.xml")
+	assert.True(t, ok)
+	assert.Equal(t, `<table id="1" ref="C1:D3" dataDxfId="2"/>`, string(content.([]byte)))
+	// Test prune styles with the differential formats referenced by the style sheet extension list
+	f.Styles.ExtLst = &xlsxExtLst{Ext: `<ext><x14:slicerStyles><x14:slicerStyle name="Style"><x14:slicerStyleElements><x14:slicerStyleElement type="selectedItemWithData" dxfId="2"/></x14:slicerStyleElements></x14:slicerStyle></x14:slicerStyles></ext>`}
+	ws.ConditionalFormatting = nil
+	removed, err = f.PruneStyles()
+	assert.NoError(t, err)
+	assert.Equal(t, 0, removed)
+	assert.Len(t, f.Styles.Dxfs.Dxfs, 3)
+	// Test prune styles without cell formats
+	f = NewFile()
+	f.Styles.CellXfs = nil
+	removed, err = f.PruneStyles()
+	assert.NoError(t, err)
+	assert.Equal(t, 0, removed)
+	// Test prune styles with unsupported charset worksheet
+	f = NewFile()
+	f.Sheet.Delete("xl/worksheets/sheet1.xml")
+	f.Pkg.Store("xl/worksheets/sheet1.xml", MacintoshCyrillicCharset)
+	_, err = f.PruneStyles()
+	assert.EqualError(t, err, "xml decode error: XML syntax error on line 1: invalid UTF-8")
+}
+
+func TestSetRowBanding(t *testing.T) {
+	f := NewFile()
+	even, err := f.NewStyle(&Style{Fill: Fill{Type: "pattern", Color: []string{"#DDEBF7"}, Pattern: 1}})
+	assert.NoError(t, err)
+	odd, err := f.NewStyle(&Style{Font: &Font{Bold: true}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellValue("Sheet1", "B3", "B3"))
+	assert.NoError(t, f.SetRowBanding("Sheet1", "C6:B2", even, odd))
+	for row := 1; row <= 7; row++ {
+		for _, col := range []string{"A", "B", "C", "D"} {
+			cell, _ := JoinCellName(col, row)
+			expected := 0
+			if row >= 2 && row <= 6 && (col == "B" || col == "C") {
+				expected = odd
+				if row%2 == 1 {
+					expected = even
+				}
+			}
+			styleID, err := f.GetCellStyle("Sheet1", cell)
+			assert.NoError(t, err)
+			assert.Equal(t, expected, styleID, cell)
+		}
+	}
+	value, err := f.GetCellValue("Sheet1", "B3")
+	assert.NoError(t, err)
+	assert.Equal(t, "B3", value)
+	// Test apply row banding on a single cell
+	assert.NoError(t, f.SetRowBanding("Sheet1", "E1", even, odd))
+	styleID, err := f.GetCellStyle("Sheet1", "E1")
+	assert.NoError(t, err)
+	assert.Equal(t, odd, styleID)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetRowBanding.xlsx")))
+	// Test apply row banding with invalid parameters
+	assert.EqualError(t, f.SetRowBanding("Sheet1", "A1:B2", -1, odd), newInvalidStyleID(-1).Error())
+	assert.EqualError(t, f.SetRowBanding("Sheet1", "A1:B2", even, -2), newInvalidStyleID(-2).Error())
+	assert.EqualError(t, f.SetRowBanding("Sheet1", "A:B2", even, odd), newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
+	assert.EqualError(t, f.SetRowBanding("SheetN", "A1:B2", even, odd), "sheet SheetN is not exist")
+}
+
+func TestSetCellIndent(t *testing.T) {
+	f := NewFile()
+	style, err := f.NewStyle(&Style{Font: &Font{Bold: true}, Alignment: &Alignment{Horizontal: "center", WrapText: true}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A1", "A2", style))
+	rightStyle, err := f.NewStyle(&Style{Alignment: &Alignment{Horizontal: "right"}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "B1", "B1", rightStyle))
+	assert.NoError(t, f.SetCellIndent("Sheet1", "B2:A1", 2))
+
+	getAlignment := func(cell string) (int, *xlsxXf) {
+		styleID, err := f.GetCellStyle("Sheet1", cell)
+		assert.NoError(t, err)
+		return styleID, &f.Styles.CellXfs.Xf[styleID]
+	}
+	styleA1, xf := getAlignment("A1")
+	assert.Equal(t, &xlsxAlignment{Horizontal: "left", Indent: 2, WrapText: true}, xf.Alignment)
+	assert.Equal(t, f.Styles.CellXfs.Xf[style].FontID, xf.FontID)
+	styleA2, _ := getAlignment("A2")
+	assert.Equal(t, styleA1, styleA2)
+	_, xf = getAlignment("B1")
+	assert.Equal(t, &xlsxAlignment{Horizontal: "right", Indent: 2}, xf.Alignment)
+	_, xf = getAlignment("B2")
+	assert.Equal(t, &xlsxAlignment{Horizontal: "left", Indent: 2}, xf.Alignment)
+	// Test remove the indent
+	assert.NoError(t, f.SetCellIndent("Sheet1", "B2", 0))
+	styleB2, xf := getAlignment("B2")
+	assert.Equal(t, &xlsxAlignment{Horizontal: "left"}, xf.Alignment)
+	assert.NoError(t, f.SetCellIndent("Sheet1", "C3", 0))
+	styleC3, _ := getAlignment("C3")
+	assert.Equal(t, 0, styleC3)
+	assert.NoError(t, f.SetCellIndent("Sheet1", "B2", 0))
+	styleID, _ := getAlignment("B2")
+	assert.Equal(t, styleB2, styleID)
+
+	assert.EqualError(t, f.SetCellIndent("Sheet1", "A1", -1), ErrCellIndent.Error())
+	assert.EqualError(t, f.SetCellIndent("Sheet1", "A1", MaxCellIndent+1), ErrCellIndent.Error())
+	assert.EqualError(t, f.SetCellIndent("Sheet1", "A", 1), newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
+	assert.EqualError(t, f.SetCellIndent("SheetN", "A1", 1), "sheet SheetN is not exist")
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetCellIndent.xlsx")))
+}
+
+func TestNewStyleQuotePrefix(t *testing.T) {
+	f := NewFile()
+	style, err := f.NewStyle(&Style{QuotePrefix: true})
+	assert.NoError(t, err)
+	assert.Equal(t, boolPtr(true), f.Styles.CellXfs.Xf[style].QuotePrefix)
+	// Test get the exists style with the quote prefix
+	styleID, err := f.NewStyle(`{"quote_prefix":true}`)
+	assert.NoError(t, err)
+	assert.Equal(t, style, styleID)
+	// Test the quote prefix is not shared with the style without it
+	styleID, err = f.NewStyle(&Style{})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, styleID)
+	fontStyle, err := f.NewStyle(&Style{Font: &Font{Bold: true}})
+	assert.NoError(t, err)
+	styleID, err = f.NewStyle(&Style{Font: &Font{Bold: true}, QuotePrefix: true})
+	assert.NoError(t, err)
+	assert.NotEqual(t, fontStyle, styleID)
+	assert.Nil(t, f.Styles.CellXfs.Xf[fontStyle].QuotePrefix)
+
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A1", "A1", style))
+	assert.NoError(t, f.SetCellStr("Sheet1", "A1", "007"))
+	buf, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+	f, err = OpenReader(buf)
+	assert.NoError(t, err)
+	ss, err := f.GetStyleSheet()
+	assert.NoError(t, err)
+	assert.True(t, ss.CellXfs[style].QuotePrefix)
+	assert.False(t, ss.CellXfs[0].QuotePrefix)
+}
+
+func TestGetStyleSheet(t *testing.T) {
+	f := NewFile()
+	style, err := f.NewStyle(&Style{
+		Font:         &Font{Bold: true, Family: "Arial", Size: 12, Color: "#FF0000"},
+		Fill:         Fill{Type: "pattern", Pattern: 1, Color: []string{"#00FF00"}},
+		Border:       []Border{{Type: "left", Style: 1, Color: "#0000FF"}},
+		Alignment:    &Alignment{Horizontal: "center"},
+		Protection:   &Protection{Locked: true},
+		CustomNumFmt: stringPtr("0.000"),
+	})
+	assert.NoError(t, err)
+	ss, err := f.GetStyleSheet()
+	assert.NoError(t, err)
+	assert.Equal(t, []StyleNumFmt{{ID: 164, FormatCode: "0.000"}}, ss.NumFmts)
+	assert.Len(t, ss.CellXfs, style+1)
+	assert.Len(t, ss.CellStyleXfs, 1)
+	xf := ss.CellXfs[style]
+	assert.Equal(t, 164, xf.NumFmtID)
+	assert.Equal(t, &Alignment{Horizontal: "center"}, xf.Alignment)
+	assert.Equal(t, &Protection{Locked: true}, xf.Protection)
+	assert.Equal(t, Font{Bold: true, Family: "Arial", Size: 12, Color: "#FF0000"}, ss.Fonts[xf.FontID])
+	assert.Equal(t, Fill{Type: "pattern", Pattern: 1, Color: []string{"#00FF00"}}, ss.Fills[xf.FillID])
+	assert.Equal(t, []Border{{Type: "left", Style: 1, Color: "#0000FF"}}, ss.Borders[xf.BorderID])
+	assert.Equal(t, "Calibri", ss.Fonts[ss.CellXfs[0].FontID].Family)
+	// Test the snapshot doesn't affect the workbook
+	ss.Fonts[xf.FontID].Family = "Times"
+	ss, err = f.GetStyleSheet()
+	assert.NoError(t, err)
+	assert.Equal(t, "Arial", ss.Fonts[xf.FontID].Family)
+	// Test get style sheet with nil fonts and fills
+	f.Styles.Fonts.Font = append(f.Styles.Fonts.Font, nil)
+	f.Styles.Fills.Fill = append(f.Styles.Fills.Fill, nil)
+	f.Styles.CellXfs.Xf = append(f.Styles.CellXfs.Xf, xlsxXf{})
+	ss, err = f.GetStyleSheet()
+	assert.NoError(t, err)
+	assert.Equal(t, Font{}, ss.Fonts[len(ss.Fonts)-1])
+	assert.Equal(t, Fill{}, ss.Fills[len(ss.Fills)-1])
+	assert.Equal(t, StyleXf{}, ss.CellXfs[len(ss.CellXfs)-1])
+	// Test get style sheet without style components
+	f.Styles = &xlsxStyleSheet{}
+	ss, err = f.GetStyleSheet()
+	assert.NoError(t, err)
+	assert.Equal(t, &StyleSheetSnapshot{}, ss)
+}
+
+func TestScientificFormat(t *testing.T) {
+	for _, c := range []struct {
+		decimals    int
+		engineering bool
+		expected    string
+	}{
+		{2, false, "0.00E+00"},
+		{0, false, "0E+00"},
+		{-1, false, "0E+00"},
+		{1, true, "##0.0E+0"},
+		{0, true, "##0E+0"},
+		{3, true, "##0.000E+0"},
+		{31, true, "##0." + strings.Repeat("0", 30) + "E+0"},
+	} {
+		assert.Equal(t, c.expected, ScientificFormat(c.decimals, c.engineering))
+	}
+	f := NewFile()
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", 12345.678))
+	assert.NoError(t, f.SetCellNumFmt("Sheet1", "A1", ScientificFormat(2, false)))
+	styleID, err := f.GetCellStyle("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, 11, *f.Styles.CellXfs.Xf[styleID].NumFmtID)
+	assert.NoError(t, f.SetCellNumFmt("Sheet1", "A1", ScientificFormat(1, true)))
+	styleID, err = f.GetCellStyle("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, 48, *f.Styles.CellXfs.Xf[styleID].NumFmtID)
+}
+
+func TestFormatBuilder(t *testing.T) {
+	numFmt, err := NewFormatBuilder().
+		AddSection(">=1000000", `0.0,,"M"`).
+		AddSection("[>= 1000]", `0.0,"K"`).
+		AddSection("", "0").
+		Build()
+	assert.NoError(t, err)
+	assert.Equal(t, `[>=1000000]0.0,,"M";[>=1000]0.0,"K";0`, numFmt)
+	numFmt, err = NewFormatBuilder().
+		AddSection("<-0.5", `[Red]0.00`).
+		AddSection("", `"Low;[<1]" 0.00`).
+		AddSection("", `\;0`).
+		AddSection("", "@").
+		Build()
+	assert.NoError(t, err)
+	assert.Equal(t, `[<-0.5][Red]0.00;"Low;[<1]" 0.00;\;0;@`, numFmt)
+	for _, c := range []struct {
+		builder *FormatBuilder
+		err     error
+	}{
+		{NewFormatBuilder(), ErrCustomNumFmt},
+		{NewFormatBuilder().AddSection(">=A", "0"), ErrNumFmtCondition},
+		{NewFormatBuilder().AddSection("=>1", "0"), ErrNumFmtCondition},
+		{NewFormatBuilder().AddSection("[>1", "0"), ErrNumFmtCondition},
+		{NewFormatBuilder().AddSection(">Inf", "0"), ErrNumFmtCondition},
+		{NewFormatBuilder().AddSection("[<-infinity]", "0"), ErrNumFmtCondition},
+		{NewFormatBuilder().AddSection("=NaN", "0"), ErrNumFmtCondition},
+		{NewFormatBuilder().AddSection("", "[=NaN]0"), ErrNumFmtSection},
+		{NewFormatBuilder().AddSection("", "0["), nil},
+		{NewFormatBuilder().AddSection("", "0").AddSection(">1", "0"), ErrNumFmtCondition},
+		{NewFormatBuilder().AddSection(">1", "0").AddSection("<0", "0").AddSection("=0", "0"), ErrNumFmtSections},
+		{NewFormatBuilder().AddSection("", "0").AddSection("", "0").AddSection("", "0").AddSection("", "0").AddSection("", "0"), ErrNumFmtSections},
+		{NewFormatBuilder().AddSection("", "0;0"), ErrNumFmtSection},
+		{NewFormatBuilder().AddSection("", "[>1]0"), ErrNumFmtSection},
+		{NewFormatBuilder().AddSection("", strings.Repeat("0", MaxFieldLength+1)), newFieldLengthError("number format")},
+	} {
+		_, err = c.builder.Build()
+		assert.Equal(t, c.err, err)
+	}
+	f := NewFile()
+	numFmt, err = NewFormatBuilder().AddSection(">=1000", `0.0,"K"`).AddSection("", "0").Build()
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", 12345))
+	assert.NoError(t, f.SetCellNumFmt("Sheet1", "A1", numFmt))
+	styleID, err := f.GetCellStyle("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, numFmt, f.Styles.NumFmts.NumFmt[0].FormatCode)
+	assert.Equal(t, f.Styles.NumFmts.NumFmt[0].NumFmtID, *f.Styles.CellXfs.Xf[styleID].NumFmtID)
+}
+
+func TestSetCellNumFmt(t *testing.T) {
+	f := NewFile()
+	style, err := f.NewStyle(&Style{Font: &Font{Bold: true}, Alignment: &Alignment{Horizontal: "center"}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A1", "A2", style))
+	assert.NoError(t, f.SetCellNumFmt("Sheet1", "B2:A1", "0.000"))
+
+	getXf := func(cell string) (int, *xlsxXf) {
+		styleID, err := f.GetCellStyle("Sheet1", cell)
+		assert.NoError(t, err)
+		return styleID, &f.Styles.CellXfs.Xf[styleID]
+	}
+	styleA1, xf := getXf("A1")
+	assert.Equal(t, f.Styles.CellXfs.Xf[style].FontID, xf.FontID)
+	assert.Equal(t, f.Styles.CellXfs.Xf[style].Alignment, xf.Alignment)
+	numFmtID := *xf.NumFmtID
+	assert.Equal(t, 164, numFmtID)
+	styleA2, _ := getXf("A2")
+	assert.Equal(t, styleA1, styleA2)
+	_, xf = getXf("B1")
+	assert.Equal(t, numFmtID, *xf.NumFmtID)
+	assert.Equal(t, 0, *xf.FontID)
+	// Test reuse the custom number format and the cell style
+	assert.NoError(t, f.SetCellNumFmt("Sheet1", "C1", "0.000"))
+	styleC1, xf := getXf("C1")
+	assert.Equal(t, numFmtID, *xf.NumFmtID)
+	styleB1, _ := getXf("B1")
+	assert.Equal(t, styleB1, styleC1)
+	assert.Len(t, f.Styles.NumFmts.NumFmt, 1)
+	// Test set the built-in number format
+	assert.NoError(t, f.SetCellNumFmt("Sheet1", "A1", "0.00%"))
+	_, xf = getXf("A1")
+	assert.Equal(t, 10, *xf.NumFmtID)
+	assert.Equal(t, f.Styles.CellXfs.Xf[style].FontID, xf.FontID)
+	assert.NoError(t, f.SetCellNumFmt("Sheet1", "A1", "General"))
+	styleID, xf := getXf("A1")
+	assert.Equal(t, 0, *xf.NumFmtID)
+	assert.Nil(t, xf.ApplyNumberFormat)
+	assert.Equal(t, style, styleID)
+	assert.Len(t, f.Styles.NumFmts.NumFmt, 1)
+	// Test the number format code is case-sensitive
+	assert.NoError(t, f.SetCellNumFmt("Sheet1", "A1", "h:mm AM/PM"))
+	_, xf = getXf("A1")
+	assert.Equal(t, 18, *xf.NumFmtID)
+	assert.NoError(t, f.SetCellNumFmt("Sheet1", "A1", "h:mm am/pm"))
+	_, xf = getXf("A1")
+	assert.Equal(t, 165, *xf.NumFmtID)
+	assert.Len(t, f.Styles.NumFmts.NumFmt, 2)
+
+	assert.EqualError(t, f.SetCellNumFmt("Sheet1", "A1", ""), ErrCustomNumFmt.Error())
+	assert.EqualError(t, f.SetCellNumFmt("Sheet1", "A", "0.00"), newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
+	assert.EqualError(t, f.SetCellNumFmt("SheetN", "A1", "0.00"), "sheet SheetN is not exist")
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetCellNumFmt.xlsx")))
+}
+
+func TestSetCellColor(t *testing.T) {
+	f := NewFile()
+	style, err := f.NewStyle(&Style{Font: &Font{Bold: true}, CustomNumFmt: stringPtr("0.000")})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A1", "A2", style))
+	assert.NoError(t, f.SetCellColor("Sheet1", "B2:A1", "#FFFF00"))
+
+	getXf := func(cell string) (int, *xlsxXf) {
+		styleID, err := f.GetCellStyle("Sheet1", cell)
+		assert.NoError(t, err)
+		return styleID, &f.Styles.CellXfs.Xf[styleID]
+	}
+	styleA1, xf := getXf("A1")
+	assert.Equal(t, f.Styles.CellXfs.Xf[style].FontID, xf.FontID)
+	assert.Equal(t, f.Styles.CellXfs.Xf[style].NumFmtID, xf.NumFmtID)
+	fillID := *xf.FillID
+	assert.Equal(t, "solid", f.Styles.Fills.Fill[fillID].PatternFill.PatternType)
+	assert.Equal(t, "FFFFFF00", f.Styles.Fills.Fill[fillID].PatternFill.FgColor.RGB)
+	styleA2, _ := getXf("A2")
+	assert.Equal(t, styleA1, styleA2)
+	_, xf = getXf("B1")
+	assert.Equal(t, fillID, *xf.FillID)
+	assert.Equal(t, 0, *xf.FontID)
+	// Test reuse the solid fill and the cell style
+	fills := len(f.Styles.Fills.Fill)
+	assert.NoError(t, f.SetCellColor("Sheet1", "C1", "FFFF00"))
+	styleC1, xf := getXf("C1")
+	assert.Equal(t, fillID, *xf.FillID)
+	styleB1, _ := getXf("B1")
+	assert.Equal(t, styleB1, styleC1)
+	assert.Len(t, f.Styles.Fills.Fill, fills)
+	// Test remove the background color
+	assert.NoError(t, f.SetCellColor("Sheet1", "A1", ""))
+	styleID, xf := getXf("A1")
+	assert.Equal(t, 0, *xf.FillID)
+	assert.Nil(t, xf.ApplyFill)
+	assert.Equal(t, style, styleID)
+
+	assert.EqualError(t, f.SetCellColor("Sheet1", "A1", "#FFFF0"), ErrCellColor.Error())
+	assert.EqualError(t, f.SetCellColor("Sheet1", "A", "#FFFF00"), newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
+	assert.EqualError(t, f.SetCellColor("SheetN", "A1", "#FFFF00"), "sheet SheetN is not exist")
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetCellColor.xlsx")))
+}
+
+func TestSetCellSuperscript(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{"m", 2, "H", 2, "O"}))
+	style, err := f.NewStyle(&Style{Font: &Font{Bold: true, Color: "#FF0000"}, Fill: Fill{Type: "pattern", Color: []string{"#FFFF00"}, Pattern: 1}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "D1", "D1", style))
+	assert.NoError(t, f.SetCellSuperscript("Sheet1", "B1", true))
+	assert.NoError(t, f.SetCellSubscript("Sheet1", "D1", true))
+
+	getStyle := func(cell string) (int, *Style) {
+		styleID, err := f.GetCellStyle("Sheet1", cell)
+		assert.NoError(t, err)
+		style, err := f.GetEffectiveCellStyle("Sheet1", cell)
+		assert.NoError(t, err)
+		return styleID, style
+	}
+	_, styleB1 := getStyle("B1")
+	assert.Equal(t, "superscript", styleB1.Font.VertAlign)
+	assert.Equal(t, f.GetDefaultFont(), styleB1.Font.Family)
+	// Test the other formatting of the cell is kept
+	styleID, styleD1 := getStyle("D1")
+	assert.Equal(t, &Font{Bold: true, Color: "#FF0000", Family: "Calibri", Size: 11, VertAlign: "subscript"}, styleD1.Font)
+	assert.Equal(t, []string{"#FFFF00"}, styleD1.Fill.Color)
+	assert.Equal(t, f.Styles.CellXfs.Xf[style].FillID, f.Styles.CellXfs.Xf[styleID].FillID)
+	// Test reuse the font and the cell style
+	fonts := len(f.Styles.Fonts.Font)
+	assert.NoError(t, f.SetCellSuperscript("Sheet1", "C1:C2", true))
+	styleIDB1, _ := getStyle("B1")
+	styleIDC2, _ := getStyle("C2")
+	assert.Equal(t, styleIDB1, styleIDC2)
+	assert.Len(t, f.Styles.Fonts.Font, fonts)
+	// Test remove the superscript and subscript
+	assert.NoError(t, f.SetCellSuperscript("Sheet1", "C1", false))
+	styleID, styleC1 := getStyle("C1")
+	assert.Equal(t, 0, styleID)
+	assert.Empty(t, styleC1.Font.VertAlign)
+	assert.NoError(t, f.SetCellSubscript("Sheet1", "D1", false))
+	styleID, _ = getStyle("D1")
+	assert.Equal(t, style, styleID)
+	// Test set the vertical alignment of the font by the style definition
+	for _, vertAlign := range []string{"superscript", "subscript"} {
+		style, err = f.NewStyle(&Style{Font: &Font{VertAlign: vertAlign}})
+		assert.NoError(t, err)
+		assert.Equal(t, vertAlign, *f.Styles.Fonts.Font[*f.Styles.CellXfs.Xf[style].FontID].VertAlign.Val)
+		assert.NoError(t, f.SetCellStyle("Sheet1", "E1", "E1", style))
+		_, styleE1 := getStyle("E1")
+		assert.Equal(t, vertAlign, styleE1.Font.VertAlign)
+	}
+	style, err = f.NewStyle(&Style{Font: &Font{VertAlign: "baseline"}})
+	assert.NoError(t, err)
+	assert.Nil(t, f.Styles.Fonts.Font[*f.Styles.CellXfs.Xf[style].FontID].VertAlign)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetCellSuperscript.xlsx")))
+
+	assert.EqualError(t, f.SetCellSuperscript("Sheet1", "A", true), newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
+	assert.EqualError(t, f.SetCellSubscript("SheetN", "A1", true), "sheet SheetN is not exist")
+	// Test set the vertical alignment with the styles without fonts
+	f = NewFile()
+	f.Styles.Fonts, f.Styles.CellXfs = nil, nil
+	assert.NoError(t, f.SetCellSubscript("Sheet1", "A1", true))
+	assert.Equal(t, "subscript", *f.Styles.Fonts.Font[0].VertAlign.Val)
+}
+
+func TestSetRangeOuterBorder(t *testing.T) {
+	f := NewFile()
+	style, err := f.NewStyle(&Style{Border: []Border{{Type: "right", Color: "#FF0000", Style: 2}}, Fill: Fill{Type: "pattern", Color: []string{"#FFFF00"}, Pattern: 1}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "B2", "C3", style))
+	assert.NoError(t, f.SetRangeOuterBorder("Sheet1", "D5:B2", Border{Type: "left", Color: "#000000", Style: 1}))
+
+	getBorder := func(cell string) (*xlsxXf, *xlsxBorder) {
+		styleID, err := f.GetCellStyle("Sheet1", cell)
+		assert.NoError(t, err)
+		xf := &f.Styles.CellXfs.Xf[styleID]
+		if xf.BorderID == nil {
+			return xf, &xlsxBorder{}
+		}
+		return xf, f.Styles.Borders.Border[*xf.BorderID]
+	}
+	thin := xlsxLine{Style: "thin", Color: &xlsxColor{RGB: "FF000000"}}
+	medium := xlsxLine{Style: "medium", Color: &xlsxColor{RGB: "FFFF0000"}}
+	for cell, expected := range map[string]xlsxBorder{
+		"B2": {Left: thin, Top: thin, Right: medium},
+		"C2": {Top: thin, Right: medium},
+		"D2": {Top: thin, Right: thin},
+		"B3": {Left: thin, Right: medium},
+		"C3": {Right: medium},
+		"D3": {Right: thin},
+		"B5": {Left: thin, Bottom: thin},
+		"C5": {Bottom: thin},
+		"D5": {Right: thin, Bottom: thin},
+		"A1": {}, "E3": {}, "C6": {},
+	} {
+		_, border := getBorder(cell)
+		assert.Equal(t, expected, *border, cell)
+	}
+	// Test the other formatting of the cell is kept
+	xf, _ := getBorder("B2")
+	assert.Equal(t, f.Styles.CellXfs.Xf[style].FillID, xf.FillID)
+	// Test the inner cells are untouched
+	styleID, err := f.GetCellStyle("Sheet1", "C3")
+	assert.NoError(t, err)
+	assert.Equal(t, style, styleID)
+	styleID, err = f.GetCellStyle("Sheet1", "C4")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, styleID)
+	// Test reuse the border and the cell style
+	borders := len(f.Styles.Borders.Border)
+	assert.NoError(t, f.SetRangeOuterBorder("Sheet1", "D3", Border{Color: "#000000", Style: 1}))
+	assert.Len(t, f.Styles.Borders.Border, borders+1)
+	assert.NoError(t, f.SetRangeOuterBorder("Sheet1", "F1", Border{Color: "#000000", Style: 1}))
+	styleD3, err := f.GetCellStyle("Sheet1", "D3")
+	assert.NoError(t, err)
+	_, border := getBorder("F1")
+	assert.Equal(t, xlsxBorder{Left: thin, Right: thin, Top: thin, Bottom: thin}, *border)
+	assert.Len(t, f.Styles.Borders.Border, borders+1)
+	styleF1, err := f.GetCellStyle("Sheet1", "F1")
+	assert.NoError(t, err)
+	assert.Equal(t, styleD3, styleF1)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetRangeOuterBorder.xlsx")))
+	// Test remove the outer border of the range
+	assert.NoError(t, f.SetRangeOuterBorder("Sheet1", "B2:D5", Border{}))
+	for cell, expected := range map[string]xlsxBorder{"B2": {Right: medium}, "C3": {Right: medium}, "D2": {}, "C5": {}} {
+		_, border := getBorder(cell)
+		assert.Equal(t, expected, *border, cell)
+	}
+	styleID, err = f.GetCellStyle("Sheet1", "C5")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, styleID)
+
+	assert.Equal(t, ErrBorderStyle, f.SetRangeOuterBorder("Sheet1", "A1", Border{Style: 14}))
+	assert.Equal(t, ErrBorderStyle, f.SetRangeOuterBorder("Sheet1", "A1", Border{Style: -1}))
+	assert.EqualError(t, f.SetRangeOuterBorder("Sheet1", "A", Border{Style: 1}), newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
+	assert.EqualError(t, f.SetRangeOuterBorder("SheetN", "A1", Border{Style: 1}), "sheet SheetN is not exist")
+	// Test set the outer border with the styles without borders
+	f = NewFile()
+	f.Styles.Borders, f.Styles.CellXfs = nil, nil
+	assert.NoError(t, f.SetRangeOuterBorder("Sheet1", "A1", Border{Style: 1}))
+	assert.Equal(t, "thin", f.Styles.Borders.Border[0].Left.Style)
+}
+
+func TestGetEffectiveCellStyle(t *testing.T) {
+	f := NewFile()
+	styleID, err := f.NewStyle(&Style{
+		Font:         &Font{Bold: true, Color: "#FF0000"},
+		Fill:         Fill{Type: "pattern", Color: []string{"#FFFF00"}, Pattern: 1},
+		Border:       []Border{{Type: "left", Color: "#0000FF", Style: 1}},
+		Alignment:    &Alignment{Horizontal: "center"},
+		CustomNumFmt: stringPtr("0.000"),
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A1", "A1", styleID))
+	style, err := f.GetEffectiveCellStyle("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, &Font{Bold: true, Color: "#FF0000", Family: "Calibri", Size: 11}, style.Font)
+	assert.Equal(t, Fill{Type: "pattern", Color: []string{"#FFFF00"}, Pattern: 1}, style.Fill)
+	assert.Equal(t, []Border{{Type: "left", Color: "#0000FF", Style: 1}}, style.Border)
+	assert.Equal(t, "center", style.Alignment.Horizontal)
+	assert.Equal(t, "0.000", *style.CustomNumFmt)
+
+	// Test get effective style resolved from the column default style
+	assert.NoError(t, f.SetColStyle("Sheet1", "C", styleID))
+	style, err = f.GetEffectiveCellStyle("Sheet1", "C10")
+	assert.NoError(t, err)
+	assert.True(t, style.Font.Bold)
+	// Test get effective style resolved from the row default style
+	rowStyleID, err := f.NewStyle(&Style{Font: &Font{Italic: true}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetRowStyle("Sheet1", 5, 5, rowStyleID))
+	style, err = f.GetEffectiveCellStyle("Sheet1", "D5")
+	assert.NoError(t, err)
+	assert.True(t, style.Font.Italic)
+
+	// Test get effective style resolved from the named style
+	s := f.stylesReader()
+	s.CellStyleXfs.Xf = append(s.CellStyleXfs.Xf, xlsxXf{NumFmtID: intPtr(10), FontID: intPtr(*s.CellXfs.Xf[styleID].FontID), FillID: intPtr(0), BorderID: intPtr(0)})
+	s.CellStyleXfs.Count = len(s.CellStyleXfs.Xf)
+	s.CellXfs.Xf = append(s.CellXfs.Xf, xlsxXf{
+		NumFmtID: intPtr(0), FontID: intPtr(0), FillID: intPtr(*s.CellXfs.Xf[styleID].FillID), BorderID: intPtr(0),
+		XfID: intPtr(s.CellStyleXfs.Count - 1), ApplyNumberFormat: boolPtr(false), ApplyFont: boolPtr(false), ApplyFill: boolPtr(true),
+	})
+	s.CellXfs.Count = len(s.CellXfs.Xf)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "B1", "B1", s.CellXfs.Count-1))
+	style, err = f.GetEffectiveCellStyle("Sheet1", "B1")
+	assert.NoError(t, err)
+	assert.Equal(t, 10, style.NumFmt)
+	assert.Nil(t, style.CustomNumFmt)
+	assert.True(t, style.Font.Bold)
+	assert.Equal(t, []string{"#FFFF00"}, style.Fill.Color)
+	assert.Empty(t, style.Border)
+
+	// Test get effective style of the cell without style
+	style, err = f.GetEffectiveCellStyle("Sheet1", "Z100")
+	assert.NoError(t, err)
+	assert.False(t, style.Font.Bold)
+	assert.Equal(t, "Calibri", style.Font.Family)
+	// Test get effective style with invalid style ID
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	ws.SheetData.Row[0].C[0].S = 1000
+	style, err = f.GetEffectiveCellStyle("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, &Style{}, style)
+	// Test get effective style with not exist worksheet
+	_, err = f.GetEffectiveCellStyle("SheetN", "A1")
+	assert.EqualError(t, err, "sheet SheetN is not exist")
+	// Test get effective style with invalid cell reference
+	_, err = f.GetEffectiveCellStyle("Sheet1", "A")
+	assert.EqualError(t, err, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
+}
@@ -12,12 +12,17 @@
 package excelize
 
 import (
+	"bytes"
 	"encoding/json"
 	"encoding/xml"
 	"fmt"
+	"io"
+	"reflect"
 	"regexp"
 	"strconv"
 	"strings"
+
+	"github.com/mohae/deepcopy"
 )
 
 // parseFormatTableSet provides a function to parse the format settings of the
@@ -99,15 +104,23 @@ func (f *File) AddTable(sheet, hCell, vCell, format string) error {
 }
 
 // countTables provides a function to get table files count storage in the
-// folder xl/tables.
+// folder xl/tables. The largest index of the table parts will be used if the
+// table parts are not contiguous, such as some table has been removed.
 func (f *File) countTables() int {
-	count := 0
+	count, maxIdx := 0, 0
 	f.Pkg.Range(func(k, v interface{}) bool {
 		if strings.Contains(k.(string), "xl/tables/table") {
 			count++
+			idx, err := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(k.(string), "xl/tables/table"), ".xml"))
+			if err == nil && idx > maxIdx {
+				maxIdx = idx
+			}
 		}
 		return true
 	})
+	if maxIdx > count {
+		return maxIdx
+	}
 	return count
 }
 
@@ -202,6 +215,1179 @@ func (f *File) addTable(sheet, tableXML string, x1, y1, x2, y2, i int, formatSet
 	return nil
 }
 
+// GetTables provides the method to get all tables in a worksheet by given
+// worksheet name. The formula of the calculated column which fills the whole
+// column of the table could be read from the CalculatedColumnFormula of the
+// table columns. For example, get the tables on Sheet1 and print the
+// calculated column formulas:
+//
+//	tables, err := f.GetTables("Sheet1")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	for _, table := range tables {
+//	    for _, column := range table.Columns {
+//	        if column.CalculatedColumnFormula != "" {
+//	            fmt.Println(table.Name, column.Name, column.CalculatedColumnFormula)
+//	        }
+//	    }
+//	}
+func (f *File) GetTables(sheet string) ([]Table, error) {
+	var tables []Table
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return tables, err
+	}
+	if ws.TableParts == nil {
+		return tables, err
+	}
+	for _, tbl := range ws.TableParts.TableParts {
+		target := f.getSheetRelationshipsTargetByID(sheet, tbl.RID)
+		t, err := f.tableReader(strings.ReplaceAll(target, "..", "xl"))
+		if err != nil {
+			return tables, err
+		}
+		if t == nil {
+			continue
+		}
+		table := Table{Name: t.Name, Range: t.Ref}
+		if t.TableStyleInfo != nil {
+			table.StyleName = t.TableStyleInfo.Name
+			table.ShowFirstColumn = t.TableStyleInfo.ShowFirstColumn
+			table.ShowLastColumn = t.TableStyleInfo.ShowLastColumn
+			table.ShowRowStripes = t.TableStyleInfo.ShowRowStripes
+			table.ShowColumnStripes = t.TableStyleInfo.ShowColumnStripes
+		}
+		if t.TableColumns != nil {
+			for _, col := range t.TableColumns.TableColumn {
+				column := TableColumn{Name: col.Name, TotalsRowFunction: col.TotalsRowFunction}
+				if col.CalculatedColumnFormula != nil {
+					column.CalculatedColumnFormula = col.CalculatedColumnFormula.Content
+				}
+				if col.TotalsRowFormula != nil {
+					column.TotalsRowFormula = col.TotalsRowFormula.Content
+				}
+				table.Columns = append(table.Columns, column)
+			}
+		}
+		tables = append(tables, table)
+	}
+	return tables, err
+}
+
+// tableReader provides a function to get the pointer to the structure after
+// deserialization of the table part by given path. It returns nil if the
+// table part doesn't exist.
+func (f *File) tableReader(path string) (*xlsxTable, error) {
+	content, ok := f.Pkg.Load(path)
+	if !ok {
+		return nil, nil
+	}
+	t := xlsxTable{}
+	if err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(content.([]byte)))).
+		Decode(&t); err != nil && err != io.EOF {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// SetTableColumnFormula provides a function to set the formula of the
+// calculated column by given worksheet name, table name, column name and
+// formula. The formula will be saved as the calculated column formula of the
+// table and filled into each data cell of the column. The structured
+// references of the current row in the formula such as [@Price] or
+// [@[Unit Price]], and the column references without the table name such as
+// [Qty] will be converted to the fully qualified syntax which stored in the
+// workbook. Set the empty formula to remove the calculated column formula
+// and the formulas of the column cells. For example, calculate the amount
+// of each row in the table named Sales:
+//
+//	err := f.SetTableColumnFormula("Sheet1", "Sales", "Amount", "[@Price]*[@Qty]")
+//
+// The formula of the cells in the Amount column will be:
+//
+//	Sales[[#This Row],[Price]]*Sales[[#This Row],[Qty]]
+func (f *File) SetTableColumnFormula(sheet, tableName, columnName, formula string) error {
+	t, tableXML, err := f.getSheetTable(sheet, tableName)
+	if err != nil {
+		return err
+	}
+	col := -1
+	if t.TableColumns != nil {
+		for idx, column := range t.TableColumns.TableColumn {
+			if strings.EqualFold(column.Name, columnName) {
+				col = idx
+				break
+			}
+		}
+	}
+	if col == -1 {
+		return ErrTableColumnNotExist
+	}
+	coordinates, err := areaRefToCoordinates(t.Ref)
+	if err != nil {
+		return err
+	}
+	_ = sortCoordinates(coordinates)
+	formula = convertTableFormula(t, strings.TrimPrefix(formula, "="))
+	column := t.TableColumns.TableColumn[col]
+	if column.CalculatedColumnFormula = nil; formula != "" {
+		column.CalculatedColumnFormula = &xlsxTableFormula{Content: formula}
+	}
+	startRow, endRow := coordinates[1]+getTableHeaderRowCount(t), coordinates[3]-t.TotalsRowCount
+	for row := startRow; row <= endRow; row++ {
+		cell, err := CoordinatesToCellName(coordinates[0]+col, row)
+		if err != nil {
+			return err
+		}
+		if err = f.SetCellFormula(sheet, cell, formula); err != nil {
+			return err
+		}
+	}
+	table, _ := xml.Marshal(t)
+	f.saveFileList(tableXML, table)
+	return nil
+}
+
+// getTableHeaderRowCount provides a function to get the number of the header
+// rows of the table, the table has one header row if the header row count
+// is not specified.
+func getTableHeaderRowCount(t *xlsxTable) int {
+	if t.HeaderRowCount == nil {
+		return 1
+	}
+	return *t.HeaderRowCount
+}
+
+// tableTotalsRowFunctions defined the function number of the SUBTOTAL
+// function for each aggregation function of the table totals row.
+var tableTotalsRowFunctions = map[string]int{
+	"average":   101,
+	"countNums": 102,
+	"count":     103,
+	"max":       104,
+	"min":       105,
+	"stdDev":    107,
+	"sum":       109,
+	"var":       110,
+}
+
+// AddReportTable provides a function to create a formatted table by given
+// worksheet name, range reference of the header and data rows, and the
+// settings of the report table in one call. It sets the header names and
+// the header style, applies the number formats of the columns, adds a totals
+// row below the range with the aggregation of the columns, and freezes the
+// header row. The totals row will be added if any column has the totals row
+// function or label. For example, create a sales report table in the range
+// A1:C10 on Sheet1, with the totals of the amount column:
+//
+//	header, err := f.NewStyle(&excelize.Style{Font: &excelize.Font{Bold: true}})
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	err = f.AddReportTable("Sheet1", "A1:C10", excelize.ReportTableOptions{
+//	    TableName:     "Sales",
+//	    TableStyle:    "TableStyleMedium2",
+//	    Headers:       []string{"Region", "Date", "Amount"},
+//	    HeaderStyleID: header,
+//	    Columns: []excelize.ReportTableColumn{
+//	        {TotalsRowLabel: "Total"},
+//	        {NumFmt: "yyyy-mm-dd"},
+//	        {NumFmt: "#,##0.00", TotalsRowFunction: "sum"},
+//	    },
+//	    ShowRowStripes: true,
+//	    FreezeHeader:   true,
+//	})
+//
+// The total cell C11 will be the formula:
+//
+//	SUBTOTAL(109,Sales[Amount])
+func (f *File) AddReportTable(sheet, rangeRef string, opts ReportTableOptions) error {
+	coordinates, err := areaRefToCoordinates(rangeRef)
+	if err != nil {
+		return err
+	}
+	_ = sortCoordinates(coordinates)
+	cols := coordinates[2] - coordinates[0] + 1
+	if len(opts.Headers) > cols || len(opts.Columns) > cols {
+		return ErrParameterInvalid
+	}
+	var totalsRow bool
+	for _, column := range opts.Columns {
+		if _, ok := tableTotalsRowFunctions[column.TotalsRowFunction]; !ok && column.TotalsRowFunction != "" {
+			return ErrParameterInvalid
+		}
+		totalsRow = totalsRow || column.TotalsRowFunction != "" || column.TotalsRowLabel != ""
+	}
+	if _, ok := f.getSheetXMLPath(sheet); !ok {
+		return ErrSheetNotExist{sheet}
+	}
+	hCell, _ := CoordinatesToCellName(coordinates[0], coordinates[1])
+	if len(opts.Headers) > 0 {
+		headers := make([]interface{}, len(opts.Headers))
+		for idx, header := range opts.Headers {
+			headers[idx] = header
+		}
+		if err = f.SetSheetRow(sheet, hCell, &headers); err != nil {
+			return err
+		}
+	}
+	if opts.TableName == "" {
+		opts.TableName = "Table" + strconv.Itoa(f.countTables()+1)
+	}
+	lastRow := coordinates[3]
+	if lastRow == coordinates[1] {
+		lastRow++
+	}
+	if totalsRow {
+		lastRow++
+	}
+	vCell, err := CoordinatesToCellName(coordinates[2], lastRow)
+	if err != nil {
+		return err
+	}
+	format, _ := json.Marshal(formatTable{
+		TableName:         opts.TableName,
+		TableStyle:        opts.TableStyle,
+		ShowRowStripes:    opts.ShowRowStripes,
+		ShowColumnStripes: opts.ShowColumnStripes,
+	})
+	if err = f.AddTable(sheet, hCell, vCell, string(format)); err != nil {
+		return err
+	}
+	if opts.HeaderStyleID > 0 {
+		endCell, _ := CoordinatesToCellName(coordinates[2], coordinates[1])
+		if err = f.SetCellStyle(sheet, hCell, endCell, opts.HeaderStyleID); err != nil {
+			return err
+		}
+	}
+	if totalsRow {
+		if err = f.setReportTableTotalsRow(sheet, opts); err != nil {
+			return err
+		}
+	}
+	for idx, column := range opts.Columns {
+		if column.NumFmt == "" {
+			continue
+		}
+		ref, _ := f.coordinatesToAreaRef([]int{coordinates[0] + idx, coordinates[1] + 1, coordinates[0] + idx, lastRow})
+		if err = f.SetCellNumFmt(sheet, ref, column.NumFmt); err != nil {
+			return err
+		}
+	}
+	if opts.FreezeHeader {
+		topLeftCell, _ := CoordinatesToCellName(1, coordinates[1]+1)
+		return f.SetPanes(sheet, fmt.Sprintf(`{"freeze":true,"split":false,"y_split":%d,"top_left_cell":"%s","active_pane":"bottomLeft"}`, coordinates[1], topLeftCell))
+	}
+	return err
+}
+
+// setReportTableTotalsRow provides a function to set the totals row of the
+// table by given worksheet name and the settings of the report table, the
+// last row of the table will be used as the totals row.
+func (f *File) setReportTableTotalsRow(sheet string, opts ReportTableOptions) error {
+	t, tableXML, err := f.getSheetTable(sheet, opts.TableName)
+	if err != nil {
+		return err
+	}
+	coordinates, _ := areaRefToCoordinates(t.Ref)
+	t.TotalsRowCount = 1
+	t.AutoFilter.Ref, _ = f.coordinatesToAreaRef([]int{coordinates[0], coordinates[1], coordinates[2], coordinates[3] - 1})
+	for idx, column := range opts.Columns {
+		tableColumn := t.TableColumns.TableColumn[idx]
+		cell, _ := CoordinatesToCellName(coordinates[0]+idx, coordinates[3])
+		if column.TotalsRowFunction != "" {
+			tableColumn.TotalsRowFunction = column.TotalsRowFunction
+			formula := fmt.Sprintf("SUBTOTAL(%d,%s[%s])", tableTotalsRowFunctions[column.TotalsRowFunction], t.Name, escapeTableColumnName(tableColumn.Name))
+			if err = f.SetCellFormula(sheet, cell, formula); err != nil {
+				return err
+			}
+			continue
+		}
+		if column.TotalsRowLabel != "" {
+			tableColumn.TotalsRowLabel = column.TotalsRowLabel
+			if err = f.SetCellStr(sheet, cell, column.TotalsRowLabel); err != nil {
+				return err
+			}
+		}
+	}
+	table, _ := xml.Marshal(t)
+	f.saveFileList(tableXML, table)
+	return err
+}
+
+// escapeTableColumnName provides a function to escape the special characters
+// in the column name of the structured reference with the single quotation
+// mark.
+func escapeTableColumnName(name string) string {
+	var b strings.Builder
+	for _, r := range name {
+		if strings.ContainsRune("[]#'", r) {
+			b.WriteRune('\'')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// getSheetTable provides a function to get the table and the path of the
+// table part by given worksheet name and table name.
+func (f *File) getSheetTable(sheet, tableName string) (*xlsxTable, string, error) {
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return nil, "", err
+	}
+	if ws.TableParts != nil {
+		for _, tbl := range ws.TableParts.TableParts {
+			tableXML := strings.ReplaceAll(f.getSheetRelationshipsTargetByID(sheet, tbl.RID), "..", "xl")
+			t, err := f.tableReader(tableXML)
+			if err != nil {
+				return nil, "", err
+			}
+			if t != nil && strings.EqualFold(t.Name, tableName) {
+				return t, tableXML, err
+			}
+		}
+	}
+	return nil, "", ErrTableNotExist
+}
+
+// convertTableFormula provides a function to convert the structured
+// references in the formula of the calculated column to the fully qualified
+// syntax, such as convert [@Price] and [Price] to Table1[[#This Row],[Price]]
+// and Table1[Price]. The references with the table name and the contents of
+// the string literals will be kept, except for the references of the current
+// row.
+func convertTableFormula(t *xlsxTable, formula string) string {
+	isNameChar := func(r byte) bool {
+		return r == '_' || r == '.' || r == '\\' || r >= 0x80 ||
+			('0' <= r && r <= '9') || ('a' <= r && r <= 'z') || ('A' <= r && r <= 'Z')
+	}
+	isColumn := func(name string) bool {
+		if t.TableColumns != nil {
+			for _, column := range t.TableColumns.TableColumn {
+				if strings.EqualFold(column.Name, name) {
+					return true
+				}
+			}
+		}
+		return false
+	}
+	var (
+		b        strings.Builder
+		inString bool
+	)
+	for i := 0; i < len(formula); i++ {
+		c := formula[i]
+		if c == '"' {
+			inString = !inString
+		}
+		if inString || c != '[' {
+			b.WriteByte(c)
+			continue
+		}
+		// Find the closing bracket of the structured reference, the single
+		// quotation mark escapes the special characters in the column name
+		end, depth := -1, 0
+		for j := i; j < len(formula) && end == -1; j++ {
+			switch formula[j] {
+			case '\'':
+				j++
+			case '[':
+				depth++
+			case ']':
+				if depth--; depth == 0 {
+					end = j
+				}
+			}
+		}
+		if end == -1 {
+			b.WriteString(formula[i:])
+			break
+		}
+		inner, prefix := formula[i+1:end], ""
+		if i == 0 || !isNameChar(formula[i-1]) {
+			prefix = t.Name
+		}
+		switch {
+		case strings.HasPrefix(inner, "@"):
+			inner = "[#This Row]"
+			if ref := formula[i+2 : end]; strings.HasPrefix(ref, "[") {
+				inner = "[[#This Row]," + ref + "]"
+			} else if ref != "" {
+				inner = "[[#This Row],[" + ref + "]]"
+			}
+			b.WriteString(prefix + inner)
+		case prefix != "" && isColumn(inner):
+			b.WriteString(prefix + "[" + inner + "]")
+		default:
+			b.WriteString(formula[i : end+1])
+		}
+		i = end
+	}
+	return b.String()
+}
+
+// ConvertRangeToTable provides a function to convert the range of cells to a
+// table by given worksheet name, range reference and table name, like the
+// "Format as Table" of Excel. The first row of the range will be used as the
+// header row, the values and the formatting of the cells will be kept, and
+// the table will be formatted with the "TableStyleMedium2" style. The table
+// name must be unique in the workbook, the default table name will be used
+// if it's empty. For example, convert the range A1:D10 on Sheet1 to the table
+// named Sales:
+//
+//	err := f.ConvertRangeToTable("Sheet1", "A1:D10", "Sales")
+func (f *File) ConvertRangeToTable(sheet, rangeRef, tableName string) error {
+	coordinates, err := areaRefToCoordinates(rangeRef)
+	if err != nil {
+		return err
+	}
+	_ = sortCoordinates(coordinates)
+	tables, err := f.GetTables(sheet)
+	if err != nil {
+		return err
+	}
+	for _, table := range tables {
+		rect, err := areaRefToCoordinates(table.Range)
+		if err != nil {
+			return err
+		}
+		_ = sortCoordinates(rect)
+		if isOverlap(rect, coordinates) {
+			return ErrTableOverlap
+		}
+	}
+	if tableName != "" {
+		if err = f.checkTableName(tableName); err != nil {
+			return err
+		}
+	}
+	hCell, _ := CoordinatesToCellName(coordinates[0], coordinates[1])
+	vCell, _ := CoordinatesToCellName(coordinates[2], coordinates[3])
+	format, _ := json.Marshal(formatTable{
+		TableName:      tableName,
+		TableStyle:     "TableStyleMedium2",
+		ShowRowStripes: true,
+	})
+	return f.AddTable(sheet, hCell, vCell, string(format))
+}
+
+// checkTableName provides a function to check if the table name is valid
+// and unique in the workbook. The table name follows the same rules as the
+// defined name: it must begin with a letter, an underscore or a backslash,
+// contain only letters, numbers, periods and underscores, and can't be the
+// same as a cell reference in either the A1 or R1C1 reference style.
+func (f *File) checkTableName(name string) error {
+	if checkDefinedName(name) != nil {
+		return ErrTableName
+	}
+	for _, sheet := range f.GetSheetList() {
+		tables, err := f.GetTables(sheet)
+		if err != nil {
+			return err
+		}
+		for _, table := range tables {
+			if strings.EqualFold(table.Name, name) {
+				return ErrTableName
+			}
+		}
+	}
+	return nil
+}
+
+// ConvertTableToRange provides a function to convert the table to the normal
+// range of cells by given worksheet name, table name and if keep the
+// formatting of the table, like the "Convert to Range" of Excel. The values
+// of the cells will be kept, and the structured references of the table in
+// the formulas of the workbook will be converted to the cell references. If
+// keepFormatting is true, the formatting of the table style will be applied
+// to the cells as the direct cell formatting, so the range looks the same as
+// the table, the direct formatting of the cells has higher priority than the
+// table style. The built-in table styles are resolved by the theme colors of
+// the workbook. For example, convert the table named Sales on Sheet1 to the
+// range and keep the formatting:
+//
+//	err := f.ConvertTableToRange("Sheet1", "Sales", true)
+func (f *File) ConvertTableToRange(sheet, tableName string, keepFormatting bool) error {
+	t, tableXML, err := f.getSheetTable(sheet, tableName)
+	if err != nil {
+		return err
+	}
+	coordinates, err := areaRefToCoordinates(t.Ref)
+	if err != nil {
+		return err
+	}
+	_ = sortCoordinates(coordinates)
+	if keepFormatting && t.TableStyleInfo != nil {
+		if err = f.setTableStyleFormats(sheet, t, coordinates); err != nil {
+			return err
+		}
+	}
+	if err = f.convertTableFormulas(sheet, t, coordinates); err != nil {
+		return err
+	}
+	ws, _ := f.workSheetReader(sheet)
+	for idx, tbl := range ws.TableParts.TableParts {
+		if strings.ReplaceAll(f.getSheetRelationshipsTargetByID(sheet, tbl.RID), "..", "xl") == tableXML {
+			ws.TableParts.TableParts = append(ws.TableParts.TableParts[:idx], ws.TableParts.TableParts[idx+1:]...)
+			f.deleteSheetRelationships(sheet, tbl.RID)
+			break
+		}
+	}
+	if ws.TableParts.Count = len(ws.TableParts.TableParts); ws.TableParts.Count == 0 {
+		ws.TableParts = nil
+	}
+	f.Pkg.Delete(tableXML)
+	f.deleteSheetFromContentTypes("/" + tableXML)
+	return nil
+}
+
+// convertTableFormulas provides a function to convert the structured
+// references of the table in the formulas of all worksheets to the cell
+// references, the references in other worksheets will be qualified with the
+// worksheet name of the table.
+func (f *File) convertTableFormulas(sheet string, t *xlsxTable, coordinates []int) error {
+	for _, name := range f.GetSheetList() {
+		ws, err := f.workSheetReader(name)
+		if err != nil {
+			return err
+		}
+		var prefix string
+		if name != sheet {
+			prefix = "'" + strings.ReplaceAll(sheet, "'", "''") + "'!"
+		}
+		for r := range ws.SheetData.Row {
+			for c := range ws.SheetData.Row[r].C {
+				cell := &ws.SheetData.Row[r].C[c]
+				if cell.F == nil || !strings.Contains(strings.ToLower(cell.F.Content), strings.ToLower(t.Name)) {
+					continue
+				}
+				_, row, err := CellNameToCoordinates(cell.R)
+				if err != nil {
+					return err
+				}
+				cell.F.Content = convertTableRefs(t, coordinates, cell.F.Content, prefix, row)
+			}
+		}
+	}
+	return nil
+}
+
+// convertTableRefs provides a function to convert the structured references
+// of the table in the formula to the absolute cell references by given
+// table, the coordinates of the table range, formula, the worksheet name
+// prefix of the references and the row number of the formula cell. For
+// example, convert Table1[Price] to $B$2:$B$10, and convert Table1[@Price]
+// in the row 5 to $B5. The unrecognized references will be kept.
+func convertTableRefs(t *xlsxTable, coordinates []int, formula, prefix string, row int) string {
+	isNameChar := func(r byte) bool {
+		return r == '_' || r == '.' || r == '\\' || r >= 0x80 ||
+			('0' <= r && r <= '9') || ('a' <= r && r <= 'z') || ('A' <= r && r <= 'Z')
+	}
+	var (
+		b        strings.Builder
+		inString bool
+	)
+	for i := 0; i < len(formula); i++ {
+		c := formula[i]
+		if c == '"' {
+			inString = !inString
+		}
+		if inString || !isNameChar(c) || (i > 0 && (isNameChar(formula[i-1]) || formula[i-1] == '\'')) {
+			b.WriteByte(c)
+			continue
+		}
+		j := i
+		for j < len(formula) && isNameChar(formula[j]) {
+			j++
+		}
+		if !strings.EqualFold(formula[i:j], t.Name) || (j < len(formula) && (formula[j] == '!' || formula[j] == '(')) {
+			b.WriteString(formula[i:j])
+			i = j - 1
+			continue
+		}
+		if j == len(formula) || formula[j] != '[' {
+			ref, _ := structuredRefToRange(t, coordinates, "", row)
+			b.WriteString(prefix + ref)
+			i = j - 1
+			continue
+		}
+		end, depth := -1, 0
+		for k := j; k < len(formula) && end == -1; k++ {
+			switch formula[k] {
+			case '\'':
+				k++
+			case '[':
+				depth++
+			case ']':
+				if depth--; depth == 0 {
+					end = k
+				}
+			}
+		}
+		if end == -1 {
+			b.WriteString(formula[i:])
+			break
+		}
+		if ref, ok := structuredRefToRange(t, coordinates, formula[j+1:end], row); ok {
+			b.WriteString(prefix + ref)
+		} else {
+			b.WriteString(formula[i : end+1])
+		}
+		i = end
+	}
+	return b.String()
+}
+
+// structuredRefToRange provides a function to convert the specifier of the
+// structured reference, such as [#Headers],[Price]:[Qty] or @Price, to the
+// absolute cell reference by given table, the coordinates of the table
+// range and the row number of the formula cell. It returns false if the
+// specifier is invalid.
+func structuredRefToRange(t *xlsxTable, coordinates []int, specifier string, row int) (string, bool) {
+	unescape := func(s string) string {
+		var b strings.Builder
+		for i := 0; i < len(s); i++ {
+			if s[i] == '\'' && i+1 < len(s) {
+				i++
+			}
+			b.WriteByte(s[i])
+		}
+		return strings.TrimSpace(b.String())
+	}
+	var items []string
+	specifier = strings.TrimSpace(specifier)
+	if strings.HasPrefix(specifier, "@") {
+		items, specifier = append(items, "#This Row"), strings.TrimSpace(specifier[1:])
+	}
+	if strings.HasPrefix(specifier, "[") {
+		for i := 0; i < len(specifier); i++ {
+			if specifier[i] != '[' {
+				if !strings.ContainsRune(" ,:", rune(specifier[i])) {
+					return "", false
+				}
+				continue
+			}
+			end := -1
+			for k := i + 1; k < len(specifier) && end == -1; k++ {
+				if specifier[k] == '\'' {
+					k++
+				} else if specifier[k] == ']' {
+					end = k
+				}
+			}
+			if end == -1 {
+				return "", false
+			}
+			items, i = append(items, unescape(specifier[i+1:end])), end
+		}
+	} else if specifier != "" {
+		items = append(items, unescape(specifier))
+	}
+	headerRowCount := getTableHeaderRowCount(t)
+	dataStart, dataEnd := coordinates[1]+headerRowCount, coordinates[3]-t.TotalsRowCount
+	fromRow, toRow, fromCol, toCol, thisRow := 0, 0, 0, 0, false
+	setRows := func(from, to int) {
+		if fromRow == 0 || from < fromRow {
+			fromRow = from
+		}
+		if to > toRow {
+			toRow = to
+		}
+	}
+	for _, item := range items {
+		switch strings.ToLower(item) {
+		case "#all":
+			setRows(coordinates[1], coordinates[3])
+		case "#data":
+			setRows(dataStart, dataEnd)
+		case "#headers":
+			if headerRowCount == 0 {
+				return "", false
+			}
+			setRows(coordinates[1], coordinates[1])
+		case "#totals":
+			if t.TotalsRowCount == 0 {
+				return "", false
+			}
+			setRows(coordinates[3], coordinates[3])
+		case "#this row":
+			setRows(row, row)
+			thisRow = true
+		default:
+			col := -1
+			if t.TableColumns != nil {
+				for idx, column := range t.TableColumns.TableColumn {
+					if strings.EqualFold(column.Name, item) {
+						col = coordinates[0] + idx
+					}
+				}
+			}
+			if col == -1 {
+				return "", false
+			}
+			if fromCol == 0 || col < fromCol {
+				fromCol = col
+			}
+			if col > toCol {
+				toCol = col
+			}
+		}
+	}
+	if fromRow == 0 {
+		fromRow, toRow = dataStart, dataEnd
+	}
+	if fromCol == 0 {
+		fromCol, toCol = coordinates[0], coordinates[2]
+	}
+	cellRef := func(col, row int) string {
+		name, _ := ColumnNumberToName(col)
+		if thisRow {
+			return "$" + name + strconv.Itoa(row)
+		}
+		return "$" + name + "$" + strconv.Itoa(row)
+	}
+	ref := cellRef(fromCol, fromRow)
+	if fromCol != toCol || fromRow != toRow {
+		ref += ":" + cellRef(toCol, toRow)
+	}
+	return ref, true
+}
+
+// setTableStyleFormats provides a function to apply the formatting of the
+// table style to the cells of the table as the direct cell formatting. The
+// font, fill and borders of the table style elements will be applied in
+// the order of precedence, and the font, fill and each side of the borders
+// which has been set in the cell will be kept.
+func (f *File) setTableStyleFormats(sheet string, t *xlsxTable, coordinates []int) error {
+	elements, sizes, err := f.getTableStyleElements(t.TableStyleInfo.Name)
+	if err != nil || elements == nil {
+		return err
+	}
+	type region struct {
+		element string
+		rect    []int
+	}
+	x1, y1, x2, y2 := coordinates[0], coordinates[1], coordinates[2], coordinates[3]
+	headerRowCount := getTableHeaderRowCount(t)
+	dataStart, dataEnd := y1+headerRowCount, y2-t.TotalsRowCount
+	regions := []region{{"wholeTable", coordinates}}
+	stripes := func(stripe string, from, to int, rect func(from, to int) []int) {
+		for idx := 0; from <= to; idx++ {
+			element := []string{"first", "second"}[idx%2] + stripe
+			size := sizes[element]
+			if size < 1 {
+				size = 1
+			}
+			end := from + size - 1
+			if end > to {
+				end = to
+			}
+			regions = append(regions, region{element, rect(from, end)})
+			from = end + 1
+		}
+	}
+	if t.TableStyleInfo.ShowColumnStripes {
+		stripes("ColumnStripe", x1, x2, func(from, to int) []int { return []int{from, dataStart, to, dataEnd} })
+	}
+	if t.TableStyleInfo.ShowRowStripes {
+		stripes("RowStripe", dataStart, dataEnd, func(from, to int) []int { return []int{x1, from, x2, to} })
+	}
+	if t.TableStyleInfo.ShowLastColumn {
+		regions = append(regions, region{"lastColumn", []int{x2, y1, x2, y2}})
+	}
+	if t.TableStyleInfo.ShowFirstColumn {
+		regions = append(regions, region{"firstColumn", []int{x1, y1, x1, y2}})
+	}
+	if headerRowCount > 0 {
+		regions = append(regions, region{"headerRow", []int{x1, y1, x2, y1}})
+	}
+	if t.TotalsRowCount > 0 {
+		regions = append(regions, region{"totalRow", []int{x1, y2, x2, y2}})
+	}
+	if t.TableStyleInfo.ShowFirstColumn && headerRowCount > 0 {
+		regions = append(regions, region{"firstHeaderCell", []int{x1, y1, x1, y1}})
+	}
+	if t.TableStyleInfo.ShowLastColumn && headerRowCount > 0 {
+		regions = append(regions, region{"lastHeaderCell", []int{x2, y1, x2, y1}})
+	}
+	if t.TableStyleInfo.ShowFirstColumn && t.TotalsRowCount > 0 {
+		regions = append(regions, region{"firstTotalCell", []int{x1, y2, x1, y2}})
+	}
+	if t.TableStyleInfo.ShowLastColumn && t.TotalsRowCount > 0 {
+		regions = append(regions, region{"lastTotalCell", []int{x2, y2, x2, y2}})
+	}
+	formats := make([][]tableStyleDxf, y2-y1+1)
+	for row := range formats {
+		formats[row] = make([]tableStyleDxf, x2-x1+1)
+	}
+	for _, r := range regions {
+		element, ok := elements[r.element]
+		if !ok || r.rect[1] > r.rect[3] || r.rect[0] > r.rect[2] {
+			continue
+		}
+		for row := r.rect[1]; row <= r.rect[3]; row++ {
+			for col := r.rect[0]; col <= r.rect[2]; col++ {
+				mergeTableStyleDxf(&formats[row-y1][col-x1], element, col, row, r.rect)
+			}
+		}
+	}
+	return f.setCellRangeXfs(sheet, coordinates, func(col, row int) (string, bool) {
+		key, _ := xml.Marshal(formats[row-y1][col-x1])
+		return string(key), true
+	}, func(s *xlsxStyleSheet, col, row, styleID int) int {
+		return setCellXfsTableStyle(s, styleID, &formats[row-y1][col-x1])
+	})
+}
+
+// mergeTableStyleDxf provides a function to merge the formatting of the table
+// style element to the formatting of the cell by given the cell coordinates
+// and the range of the element. The outside borders of the element will be
+// applied to the cells on the edges of the range, and the inside borders will
+// be applied to the other sides of the cells.
+func mergeTableStyleDxf(format *tableStyleDxf, element *tableStyleDxf, col, row int, rect []int) {
+	if element.Font != nil {
+		if format.Font == nil {
+			format.Font = &xlsxFont{}
+		}
+		for _, attr := range []struct{ dst, src **attrValBool }{
+			{&format.Font.B, &element.Font.B}, {&format.Font.I, &element.Font.I}, {&format.Font.Strike, &element.Font.Strike},
+		} {
+			if *attr.src != nil {
+				*attr.dst = *attr.src
+			}
+		}
+		if element.Font.U != nil {
+			format.Font.U = element.Font.U
+		}
+		if element.Font.Color != nil {
+			format.Font.Color = element.Font.Color
+		}
+	}
+	if element.Fill != nil {
+		format.Fill = element.Fill
+	}
+	if element.Border == nil {
+		return
+	}
+	if format.Border == nil {
+		format.Border = &tableStyleBorder{}
+	}
+	pick := func(edge bool, outside, inside *xlsxLine) *xlsxLine {
+		if edge {
+			return outside
+		}
+		return inside
+	}
+	for _, side := range []struct {
+		dst  **xlsxLine
+		line *xlsxLine
+	}{
+		{&format.Border.Left, pick(col == rect[0], element.Border.Left, element.Border.Vertical)},
+		{&format.Border.Right, pick(col == rect[2], element.Border.Right, element.Border.Vertical)},
+		{&format.Border.Top, pick(row == rect[1], element.Border.Top, element.Border.Horizontal)},
+		{&format.Border.Bottom, pick(row == rect[3], element.Border.Bottom, element.Border.Horizontal)},
+	} {
+		if side.line != nil && side.line.Style != "" {
+			*side.dst = side.line
+		}
+	}
+}
+
+// setCellXfsTableStyle provides a function to get the cell style index which
+// has the same formatting as the given cell style index with the formatting
+// of the table style. The font and fill of the table style will be applied if
+// the cell style uses the default font and has no fill, and each side of the
+// borders will be applied if it's not set in the cell style. A new cell style
+// will be created if it doesn't exist.
+func setCellXfsTableStyle(style *xlsxStyleSheet, styleID int, format *tableStyleDxf) int {
+	return setCellXfsWith(style, styleID, func(xf *xlsxXf) {
+		if format.Font != nil && (xf.FontID == nil || *xf.FontID == 0) && style.Fonts != nil && len(style.Fonts.Font) > 0 {
+			font := deepcopy.Copy(*style.Fonts.Font[0]).(xlsxFont)
+			for _, attr := range []struct{ dst, src **attrValBool }{
+				{&font.B, &format.Font.B}, {&font.I, &format.Font.I}, {&font.Strike, &format.Font.Strike},
+			} {
+				if *attr.src != nil {
+					*attr.dst = *attr.src
+				}
+			}
+			if format.Font.U != nil {
+				font.U = format.Font.U
+			}
+			if format.Font.Color != nil {
+				font.Color = format.Font.Color
+			}
+			fontID := -1
+			for idx, fnt := range style.Fonts.Font {
+				if reflect.DeepEqual(*fnt, font) {
+					fontID = idx
+					break
+				}
+			}
+			if fontID == -1 {
+				style.Fonts.Font = append(style.Fonts.Font, &font)
+				style.Fonts.Count = len(style.Fonts.Font)
+				fontID = style.Fonts.Count - 1
+			}
+			if xf.FontID, xf.ApplyFont = intPtr(fontID), boolPtr(true); fontID == 0 {
+				xf.ApplyFont = nil
+			}
+		}
+		if format.Fill != nil && (xf.FillID == nil || *xf.FillID == 0) {
+			if style.Fills == nil {
+				style.Fills = &xlsxFills{}
+			}
+			fillID := getFillIDImmediate(style, format.Fill)
+			if fillID == -1 {
+				style.Fills.Fill = append(style.Fills.Fill, format.Fill)
+				style.Fills.Count = len(style.Fills.Fill)
+				fillID = style.Fills.Count - 1
+			}
+			xf.FillID, xf.ApplyFill = intPtr(fillID), boolPtr(true)
+		}
+		if format.Border != nil {
+			if style.Borders == nil {
+				style.Borders = &xlsxBorders{}
+			}
+			var border xlsxBorder
+			if xf.BorderID != nil && *xf.BorderID < len(style.Borders.Border) {
+				border = deepcopy.Copy(*style.Borders.Border[*xf.BorderID]).(xlsxBorder)
+			}
+			for _, side := range []struct {
+				dst  *xlsxLine
+				line *xlsxLine
+			}{
+				{&border.Left, format.Border.Left}, {&border.Right, format.Border.Right},
+				{&border.Top, format.Border.Top}, {&border.Bottom, format.Border.Bottom},
+			} {
+				if side.line != nil && side.dst.Style == "" {
+					*side.dst = *side.line
+				}
+			}
+			borderID := getBorderIDImmediate(style, &border)
+			if borderID == -1 {
+				style.Borders.Border = append(style.Borders.Border, &border)
+				style.Borders.Count = len(style.Borders.Border)
+				borderID = style.Borders.Count - 1
+			}
+			if xf.BorderID, xf.ApplyBorder = intPtr(borderID), boolPtr(true); borderID == 0 {
+				xf.ApplyBorder = nil
+			}
+		}
+	})
+}
+
+// getTableStyleElements provides a function to get the formatting and the
+// stripe size of the table style elements by given table style name. The
+// custom table style in the workbook will be used if it exists, otherwise
+// the built-in table style will be used. It returns nil if the table style
+// doesn't exist.
+func (f *File) getTableStyleElements(name string) (map[string]*tableStyleDxf, map[string]int, error) {
+	s := f.stylesReader()
+	if s.TableStyles != nil {
+		for _, tableStyle := range s.TableStyles.TableStyles {
+			if tableStyle.Name != name {
+				continue
+			}
+			var decode decodeTableStyleElements
+			if err := xml.Unmarshal([]byte("<tableStyle>"+tableStyle.TableStyleElement+"</tableStyle>"), &decode); err != nil {
+				return nil, nil, err
+			}
+			elements, sizes := make(map[string]*tableStyleDxf), make(map[string]int)
+			for _, element := range decode.Element {
+				sizes[element.Type] = element.Size
+				if element.DxfID == nil || s.Dxfs == nil || *element.DxfID < 0 || *element.DxfID >= len(s.Dxfs.Dxfs) {
+					continue
+				}
+				format := new(tableStyleDxf)
+				if err := xml.Unmarshal([]byte("<dxf>"+s.Dxfs.Dxfs[*element.DxfID].Dxf+"</dxf>"), format); err != nil {
+					return nil, nil, err
+				}
+				// The solid fill color of the differential formatting is
+				// specified by the background color
+				if fill := format.Fill; fill != nil && fill.PatternFill != nil &&
+					(fill.PatternFill.PatternType == "" || fill.PatternFill.PatternType == "solid") {
+					color := fill.PatternFill.BgColor
+					if color == nil {
+						color = fill.PatternFill.FgColor
+					}
+					format.Fill = &xlsxFill{PatternFill: &xlsxPatternFill{PatternType: "solid", FgColor: color}}
+				}
+				elements[element.Type] = format
+			}
+			return elements, sizes, nil
+		}
+	}
+	return getBuiltInTableStyle(name), nil, nil
+}
+
+// builtInTableStyleRegexp matches the name of the built-in table style, and
+// captures the group and the index of the style.
+var builtInTableStyleRegexp = regexp.MustCompile(`^TableStyle(Light|Medium|Dark)(\d+)$`)
+
+// getBuiltInTableStyle provides a function to get the formatting of the
+// elements of the built-in table style by given table style name, which is
+// resolved by the theme colors of the workbook. The light, medium and dark
+// table styles are grouped by every 7 styles, the styles in the same group
+// have the same layout with the text color or each accent color in order.
+// It returns nil if the table style doesn't exist.
+func getBuiltInTableStyle(name string) map[string]*tableStyleDxf {
+	matches := builtInTableStyleRegexp.FindStringSubmatch(name)
+	if len(matches) != 3 {
+		return nil
+	}
+	idx, _ := strconv.Atoi(matches[2])
+	if idx < 1 || idx > map[string]int{"Light": 21, "Medium": 28, "Dark": 11}[matches[1]] {
+		return nil
+	}
+	group, theme := (idx-1)/7, (idx-1)%7+3
+	if matches[1] == "Dark" && group == 1 {
+		theme = []int{3, 4, 6, 8}[idx-8]
+	}
+	light, medium, dark := 0.799982, 0.599994, -0.249977
+	if theme == 3 {
+		// Use the text color for the first style of each group
+		theme, light, medium, dark = 1, 0.849989, 0.649974, 0.249977
+	}
+	color := func(theme int, tint float64) *xlsxColor { return &xlsxColor{Theme: intPtr(theme), Tint: tint} }
+	fill := func(c *xlsxColor) *xlsxFill {
+		return &xlsxFill{PatternFill: &xlsxPatternFill{PatternType: "solid", FgColor: c}}
+	}
+	line := func(style string, c *xlsxColor) *xlsxLine { return &xlsxLine{Style: style, Color: c} }
+	font := func(bold bool, c *xlsxColor) *xlsxFont {
+		fnt := &xlsxFont{Color: c}
+		if bold {
+			fnt.B = &attrValBool{Val: boolPtr(true)}
+		}
+		return fnt
+	}
+	bold, white, accent := font(true, nil), color(0, 0), color(theme, 0)
+	switch matches[1] + strconv.Itoa(group) {
+	case "Light0":
+		stripe := &tableStyleDxf{Fill: fill(color(theme, light))}
+		textColor := color(theme, dark)
+		if theme == 1 {
+			textColor = color(1, 0)
+		}
+		return map[string]*tableStyleDxf{
+			"wholeTable":        {Font: font(false, textColor), Border: &tableStyleBorder{Top: line("thin", accent), Bottom: line("thin", accent)}},
+			"headerRow":         {Font: bold, Border: &tableStyleBorder{Bottom: line("thin", accent)}},
+			"totalRow":          {Font: bold, Border: &tableStyleBorder{Top: line("thin", accent)}},
+			"firstColumn":       {Font: bold},
+			"lastColumn":        {Font: bold},
+			"firstRowStripe":    stripe,
+			"firstColumnStripe": stripe,
+		}
+	case "Light1":
+		return map[string]*tableStyleDxf{
+			"wholeTable":        {Border: &tableStyleBorder{Left: line("thin", accent), Right: line("thin", accent), Top: line("thin", accent), Bottom: line("thin", accent)}},
+			"headerRow":         {Font: font(true, white), Fill: fill(accent)},
+			"totalRow":          {Font: bold, Border: &tableStyleBorder{Top: line("double", accent)}},
+			"firstColumn":       {Font: bold},
+			"lastColumn":        {Font: bold},
+			"firstRowStripe":    {Border: &tableStyleBorder{Top: line("thin", accent), Bottom: line("thin", accent)}},
+			"firstColumnStripe": {Border: &tableStyleBorder{Left: line("thin", accent), Right: line("thin", accent)}},
+		}
+	case "Light2":
+		stripe := &tableStyleDxf{Fill: fill(color(theme, light))}
+		return map[string]*tableStyleDxf{
+			"wholeTable": {Border: &tableStyleBorder{
+				Left: line("thin", accent), Right: line("thin", accent), Top: line("thin", accent),
+				Bottom: line("thin", accent), Vertical: line("thin", accent), Horizontal: line("thin", accent),
+			}},
+			"headerRow":         {Font: bold, Border: &tableStyleBorder{Bottom: line("medium", accent)}},
+			"totalRow":          {Font: bold, Border: &tableStyleBorder{Top: line("double", accent)}},
+			"firstColumn":       {Font: bold},
+			"lastColumn":        {Font: bold},
+			"firstRowStripe":    stripe,
+			"firstColumnStripe": stripe,
+		}
+	case "Medium0":
+		border := color(theme, 0.399975)
+		stripe := &tableStyleDxf{Fill: fill(color(theme, light))}
+		return map[string]*tableStyleDxf{
+			"wholeTable": {Border: &tableStyleBorder{
+				Left: line("thin", border), Right: line("thin", border), Top: line("thin", border),
+				Bottom: line("thin", border), Horizontal: line("thin", border),
+			}},
+			"headerRow":         {Font: font(true, white), Fill: fill(accent)},
+			"totalRow":          {Font: bold, Border: &tableStyleBorder{Top: line("double", accent)}},
+			"firstColumn":       {Font: bold},
+			"lastColumn":        {Font: bold},
+			"firstRowStripe":    stripe,
+			"firstColumnStripe": stripe,
+		}
+	case "Medium1":
+		stripe := &tableStyleDxf{Fill: fill(color(theme, medium))}
+		return map[string]*tableStyleDxf{
+			"wholeTable":        {Font: font(false, color(1, 0)), Fill: fill(color(theme, light)), Border: &tableStyleBorder{Vertical: line("thin", white), Horizontal: line("thin", white)}},
+			"headerRow":         {Font: font(true, white), Fill: fill(accent), Border: &tableStyleBorder{Bottom: line("medium", white)}},
+			"totalRow":          {Font: font(true, white), Fill: fill(accent), Border: &tableStyleBorder{Top: line("medium", white)}},
+			"firstColumn":       {Font: font(true, white), Fill: fill(accent)},
+			"lastColumn":        {Font: font(true, white), Fill: fill(accent)},
+			"firstRowStripe":    stripe,
+			"firstColumnStripe": stripe,
+		}
+	case "Medium2":
+		stripe := &tableStyleDxf{Fill: fill(color(1, 0.849989))}
+		return map[string]*tableStyleDxf{
+			"wholeTable": {Border: &tableStyleBorder{
+				Left: line("thin", accent), Right: line("thin", accent), Top: line("thin", accent),
+				Bottom: line("thin", accent), Horizontal: line("thin", accent),
+			}},
+			"headerRow":         {Font: font(true, white), Fill: fill(color(1, 0)), Border: &tableStyleBorder{Bottom: line("medium", color(1, 0))}},
+			"totalRow":          {Font: bold, Border: &tableStyleBorder{Top: line("double", color(1, 0))}},
+			"firstColumn":       {Font: bold},
+			"lastColumn":        {Font: bold},
+			"firstRowStripe":    stripe,
+			"firstColumnStripe": stripe,
+		}
+	case "Medium3":
+		border := color(theme, 0.399975)
+		stripe := &tableStyleDxf{Fill: fill(color(theme, medium))}
+		return map[string]*tableStyleDxf{
+			"wholeTable": {Fill: fill(color(theme, light)), Border: &tableStyleBorder{
+				Left: line("thin", border), Right: line("thin", border), Top: line("thin", border),
+				Bottom: line("thin", border), Vertical: line("thin", border), Horizontal: line("thin", border),
+			}},
+			"headerRow":         {Font: bold},
+			"totalRow":          {Font: bold, Border: &tableStyleBorder{Top: line("double", accent)}},
+			"firstColumn":       {Font: bold},
+			"lastColumn":        {Font: bold},
+			"firstRowStripe":    stripe,
+			"firstColumnStripe": stripe,
+		}
+	case "Dark0":
+		shade := -0.499984
+		if theme == 1 {
+			dark, shade = 0.249977, 0.149998
+		}
+		stripe := &tableStyleDxf{Fill: fill(color(theme, shade))}
+		return map[string]*tableStyleDxf{
+			"wholeTable":        {Font: font(false, white), Fill: fill(color(theme, dark))},
+			"headerRow":         {Font: font(true, white), Fill: fill(color(1, 0)), Border: &tableStyleBorder{Bottom: line("medium", white)}},
+			"totalRow":          {Font: font(true, white), Fill: fill(color(theme, shade)), Border: &tableStyleBorder{Top: line("medium", white)}},
+			"firstColumn":       {Font: font(true, white), Fill: fill(color(theme, shade)), Border: &tableStyleBorder{Right: line("medium", white)}},
+			"lastColumn":        {Font: font(true, white), Fill: fill(color(theme, shade)), Border: &tableStyleBorder{Left: line("medium", white)}},
+			"firstRowStripe":    stripe,
+			"firstColumnStripe": stripe,
+		}
+	}
+	stripe := &tableStyleDxf{Fill: fill(color(theme, medium))}
+	return map[string]*tableStyleDxf{
+		"wholeTable":        {Fill: fill(color(theme, light))},
+		"headerRow":         {Font: font(true, white), Fill: fill(color(1, 0))},
+		"totalRow":          {Font: bold, Border: &tableStyleBorder{Top: line("double", color(1, 0))}},
+		"firstColumn":       {Font: bold},
+		"lastColumn":        {Font: bold},
+		"firstRowStripe":    stripe,
+		"firstColumnStripe": stripe,
+	}
+}
+
 // parseAutoFilterSet provides a function to parse the settings of the auto
 // filter.
 func parseAutoFilterSet(formatSet string) (*formatAutoFilter, error) {
@@ -331,6 +1517,69 @@ func (f *File) AutoFilter(sheet, hCell, vCell, format string) error {
 	return f.autoFilter(sheet, ref, refRange, hCol, formatSet)
 }
 
+// GetAutoFilter provides a function to get the auto filter settings of the
+// worksheet by given worksheet name, include the range and the applied filter
+// criteria of the columns, such as the values, custom, top 10, dynamic, color
+// and icon filters. It returns nil if the worksheet has no auto filter.
+// For example, get the auto filter on Sheet1:
+//
+//	filter, err := f.GetAutoFilter("Sheet1")
+func (f *File) GetAutoFilter(sheet string) (*AutoFilter, error) {
+	ws, err := f.workSheetReader(sheet)
+	if err != nil || ws.AutoFilter == nil {
+		return nil, err
+	}
+	filter := &AutoFilter{Range: ws.AutoFilter.Ref}
+	coordinates, err := areaRefToCoordinates(ws.AutoFilter.Ref)
+	if err != nil {
+		return filter, err
+	}
+	_ = sortCoordinates(coordinates)
+	for _, filterColumn := range ws.AutoFilter.FilterColumn {
+		column := AutoFilterColumn{}
+		if column.Column, err = ColumnNumberToName(coordinates[0] + filterColumn.ColID); err != nil {
+			return filter, err
+		}
+		if filterColumn.Filters != nil {
+			column.Type, column.Blank = "filters", filterColumn.Filters.Blank
+			for _, item := range filterColumn.Filters.Filter {
+				column.Values = append(column.Values, item.Val)
+			}
+		}
+		if filterColumn.CustomFilters != nil {
+			column.Type, column.And = "customFilters", filterColumn.CustomFilters.And
+			for _, item := range filterColumn.CustomFilters.CustomFilter {
+				operator := item.Operator
+				if operator == "" {
+					operator = "equal"
+				}
+				column.CustomFilters = append(column.CustomFilters, AutoFilterCustomFilter{Operator: operator, Value: item.Val})
+			}
+		}
+		if top10 := filterColumn.Top10; top10 != nil {
+			column.Type = "top10"
+			column.Top10 = &AutoFilterTop10{Top: top10.Top, Percent: top10.Percent, Value: top10.Val, FilterValue: top10.FilterVal}
+		}
+		if dynamicFilter := filterColumn.DynamicFilter; dynamicFilter != nil {
+			column.Type = "dynamicFilter"
+			column.DynamicFilter = &AutoFilterDynamicFilter{
+				Type: dynamicFilter.Type, Value: dynamicFilter.Val, MaxValue: dynamicFilter.MaxVal,
+				ValueISO: dynamicFilter.ValISO, MaxValueISO: dynamicFilter.MaxValISO,
+			}
+		}
+		if colorFilter := filterColumn.ColorFilter; colorFilter != nil {
+			column.Type = "colorFilter"
+			column.ColorFilter = &AutoFilterColorFilter{CellColor: colorFilter.CellColor, DxfID: colorFilter.DxfID}
+		}
+		if iconFilter := filterColumn.IconFilter; iconFilter != nil {
+			column.Type = "iconFilter"
+			column.IconFilter = &AutoFilterIconFilter{IconSet: iconFilter.IconSet, IconID: iconFilter.IconID}
+		}
+		filter.Columns = append(filter.Columns, column)
+	}
+	return filter, err
+}
+
 // autoFilter provides a function to extract the tokens from the filter
 // expression. The tokens are mainly non-whitespace groups.
 func (f *File) autoFilter(sheet, ref string, refRange, col int, formatSet *formatAutoFilter) error {
@@ -376,6 +1625,57 @@ func (f *File) autoFilter(sheet, ref string, refRange, col int, formatSet *forma
 	return nil
 }
 
+// SetSortState provides a function to set the sort state of the worksheet by
+// given worksheet name, the reference of the sorted range without the header
+// row and the sort conditions, so that Excel remembers the last sort of the
+// range when the workbook is opened. It only records the sort state, the
+// cells will not be reordered. Up to 64 sort conditions are supported, and
+// the sort state will be removed if no sort condition is given. For example,
+// record that the range A2:D10 on Sheet1 was sorted by column B in
+// descending order and then by column C with a custom list:
+//
+//	err := f.SetSortState("Sheet1", "A2:D10", []excelize.SortCondition{
+//	    {Column: "B", Descending: true},
+//	    {Column: "C", CustomList: "Low,Medium,High"},
+//	})
+func (f *File) SetSortState(sheet, ref string, conditions []SortCondition) error {
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	if len(conditions) == 0 {
+		ws.SortState = nil
+		return err
+	}
+	if len(conditions) > 64 {
+		return ErrParameterInvalid
+	}
+	coordinates, err := areaRefToCoordinates(ref)
+	if err != nil {
+		return err
+	}
+	_ = sortCoordinates(coordinates)
+	sortState := &xlsxSortState{}
+	sortState.Ref, _ = f.coordinatesToAreaRef(coordinates)
+	for _, condition := range conditions {
+		col, err := ColumnNameToNumber(condition.Column)
+		if err != nil {
+			return err
+		}
+		if col < coordinates[0] || col > coordinates[2] {
+			return fmt.Errorf("incorrect index of column '%s'", condition.Column)
+		}
+		conditionRef, _ := f.coordinatesToAreaRef([]int{col, coordinates[1], col, coordinates[3]})
+		sortState.SortCondition = append(sortState.SortCondition, &xlsxSortCondition{
+			Descending: condition.Descending,
+			Ref:        conditionRef,
+			CustomList: condition.CustomList,
+		})
+	}
+	ws.SortState = sortState
+	return err
+}
+
 // writeAutoFilter provides a function to check for single or double custom
 // filters as default filters and handle them accordingly.
 func (f *File) writeAutoFilter(filter *xlsxAutoFilter, exp []int, tokens []string) {
@@ -1,8 +1,10 @@
 package excelize
 
 import (
+	"encoding/xml"
 	"fmt"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -45,6 +47,434 @@ func TestAddTable(t *testing.T) {
 	assert.EqualError(t, f.addTable("sheet1", "", 1, 1, 0, 0, 0, nil), "invalid cell coordinates [0, 0]")
 }
 
+func TestGetTables(t *testing.T) {
+	f := NewFile()
+	for idx, row := range [][]interface{}{{"Price", "Qty", "Amount"}, {10, 2}, {20, 3}} {
+		assert.NoError(t, f.SetSheetRow("Sheet1", fmt.Sprintf("A%d", idx+1), &row))
+	}
+	assert.NoError(t, f.AddTable("Sheet1", "A1", "C3", `{"table_name":"Sales","table_style":"TableStyleMedium2"}`))
+	tables, err := f.GetTables("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []Table{{Name: "Sales", Range: "A1:C3", StyleName: "TableStyleMedium2", ShowRowStripes: true,
+		Columns: []TableColumn{{Name: "Price"}, {Name: "Qty"}, {Name: "Amount"}}}}, tables)
+	// Test get tables with calculated column
+	formula := "Sales[[#This Row],[Price]]*Sales[[#This Row],[Qty]]"
+	f.Pkg.Store("xl/tables/table1.xml", []byte(`<table xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" id="1" name="Sales" displayName="Sales" ref="A1:C4" totalsRowCount="1"><autoFilter ref="A1:C3"/><tableColumns count="3"><tableColumn id="1" name="Price" totalsRowLabel="Total"/><tableColumn id="2" name="Qty" totalsRowFunction="sum"/><tableColumn id="3" name="Amount" totalsRowFunction="custom"><calculatedColumnFormula>`+formula+`</calculatedColumnFormula><totalsRowFormula>SUM(Sales[Amount])</totalsRowFormula></tableColumn></tableColumns></table>`))
+	tables, err = f.GetTables("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []TableColumn{{Name: "Price"}, {Name: "Qty", TotalsRowFunction: "sum"},
+		{Name: "Amount", CalculatedColumnFormula: formula, TotalsRowFunction: "custom", TotalsRowFormula: "SUM(Sales[Amount])"}}, tables[0].Columns)
+	// Test insert row inside the table extends the calculated column
+	assert.NoError(t, f.InsertRow("Sheet1", 3))
+	result, err := f.GetCellFormula("Sheet1", "C3")
+	assert.NoError(t, err)
+	assert.Equal(t, formula, result)
+	tables, err = f.GetTables("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "A1:C5", tables[0].Range)
+	assert.Equal(t, formula, tables[0].Columns[2].CalculatedColumnFormula)
+	// Test insert row after the table
+	assert.NoError(t, f.InsertRow("Sheet1", 6))
+	result, err = f.GetCellFormula("Sheet1", "C6")
+	assert.NoError(t, err)
+	assert.Empty(t, result)
+	// Test append rows to the table without totals row
+	f.Pkg.Store("xl/tables/table1.xml", []byte(`<table xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" id="1" name="Sales" displayName="Sales" ref="A1:C5"><autoFilter ref="A1:C5"/><tableColumns count="3"><tableColumn id="1" name="Price"/><tableColumn id="2" name="Qty"/><tableColumn id="3" name="Amount"><calculatedColumnFormula>`+formula+`</calculatedColumnFormula></tableColumn></tableColumns></table>`))
+	assert.NoError(t, f.InsertRow("Sheet1", 6))
+	result, err = f.GetCellFormula("Sheet1", "C6")
+	assert.NoError(t, err)
+	assert.Equal(t, formula, result)
+	tables, err = f.GetTables("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "A1:C6", tables[0].Range)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestGetTables.xlsx")))
+	// Test get tables on not exists worksheet
+	_, err = f.GetTables("SheetN")
+	assert.EqualError(t, err, "sheet SheetN is not exist")
+	// Test get tables with non-table part
+	f.Pkg.Delete("xl/tables/table1.xml")
+	tables, err = f.GetTables("Sheet1")
+	assert.NoError(t, err)
+	assert.Empty(t, tables)
+	// Test get tables with unsupported charset
+	f.Pkg.Store("xl/tables/table1.xml", MacintoshCyrillicCharset)
+	_, err = f.GetTables("Sheet1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+}
+
+func TestSetTableColumnFormula(t *testing.T) {
+	f := NewFile()
+	for idx, row := range [][]interface{}{{"Price", "Qty", "Unit Cost", "Amount"}, {10, 2, 6}, {20, 3, 15}, {30, 1, 25}} {
+		assert.NoError(t, f.SetSheetRow("Sheet1", fmt.Sprintf("A%d", idx+1), &row))
+	}
+	assert.NoError(t, f.AddTable("Sheet1", "A1", "D4", `{"table_name":"Sales"}`))
+	formula := "Sales[[#This Row],[Price]]*Sales[[#This Row],[Qty]]"
+	assert.NoError(t, f.SetTableColumnFormula("Sheet1", "sales", "Amount", "=[@Price]*[@Qty]"))
+	for _, cell := range []string{"D2", "D3", "D4"} {
+		result, err := f.GetCellFormula("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, formula, result)
+	}
+	result, err := f.GetCellValue("Sheet1", "D1")
+	assert.NoError(t, err)
+	assert.Equal(t, "Amount", result)
+	tables, err := f.GetTables("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, formula, tables[0].Columns[3].CalculatedColumnFormula)
+	// Test insert row inside the table extends the calculated column
+	assert.NoError(t, f.InsertRow("Sheet1", 3))
+	result, err = f.GetCellFormula("Sheet1", "D3")
+	assert.NoError(t, err)
+	assert.Equal(t, formula, result)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetTableColumnFormula.xlsx")))
+
+	// Test remove the calculated column formula
+	assert.NoError(t, f.SetTableColumnFormula("Sheet1", "Sales", "Amount", ""))
+	result, err = f.GetCellFormula("Sheet1", "D2")
+	assert.NoError(t, err)
+	assert.Empty(t, result)
+	tables, err = f.GetTables("Sheet1")
+	assert.NoError(t, err)
+	assert.Empty(t, tables[0].Columns[3].CalculatedColumnFormula)
+
+	// Test set the calculated column formula of the table with totals row
+	f.Pkg.Store("xl/tables/table1.xml", []byte(`<table xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" id="1" name="Sales" displayName="Sales" ref="A1:D5" totalsRowCount="1"><tableColumns count="4"><tableColumn id="1" name="Price"/><tableColumn id="2" name="Qty"/><tableColumn id="3" name="Unit Cost"/><tableColumn id="4" name="Amount"/></tableColumns></table>`))
+	assert.NoError(t, f.SetTableColumnFormula("Sheet1", "Sales", "Amount", "[@Qty]*[@[Unit Cost]]"))
+	result, err = f.GetCellFormula("Sheet1", "D4")
+	assert.NoError(t, err)
+	assert.Equal(t, "Sales[[#This Row],[Qty]]*Sales[[#This Row],[Unit Cost]]", result)
+	result, err = f.GetCellFormula("Sheet1", "D5")
+	assert.NoError(t, err)
+	assert.Empty(t, result)
+
+	// Test set the calculated column formula of the table without header row
+	f.Pkg.Store("xl/tables/table1.xml", []byte(`<table xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" id="1" name="Sales" displayName="Sales" ref="A1:D5" headerRowCount="0"><tableColumns count="4"><tableColumn id="1" name="Price"/><tableColumn id="2" name="Qty"/><tableColumn id="3" name="Unit Cost"/><tableColumn id="4" name="Amount"/></tableColumns></table>`))
+	assert.NoError(t, f.SetTableColumnFormula("Sheet1", "Sales", "Amount", "[@Price]"))
+	for _, cell := range []string{"D1", "D5"} {
+		result, err = f.GetCellFormula("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, "Sales[[#This Row],[Price]]", result, cell)
+	}
+	assert.Contains(t, string(f.readXML("xl/tables/table1.xml")), `headerRowCount="0"`)
+
+	// Test set the calculated column formula with not exist table or column
+	assert.EqualError(t, f.SetTableColumnFormula("SheetN", "Sales", "Amount", "[@Price]"), "sheet SheetN is not exist")
+	assert.EqualError(t, f.SetTableColumnFormula("Sheet1", "Table2", "Amount", "[@Price]"), ErrTableNotExist.Error())
+	assert.EqualError(t, f.SetTableColumnFormula("Sheet1", "Sales", "Cost", "[@Price]"), ErrTableColumnNotExist.Error())
+	// Test set the calculated column formula with invalid table reference
+	f.Pkg.Store("xl/tables/table1.xml", []byte(`<table xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" id="1" name="Sales" displayName="Sales" ref="A:D"><tableColumns count="1"><tableColumn id="1" name="Amount"/></tableColumns></table>`))
+	assert.EqualError(t, f.SetTableColumnFormula("Sheet1", "Sales", "Amount", "[@Price]"), newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
+	// Test set the calculated column formula with unsupported charset
+	f.Pkg.Store("xl/tables/table1.xml", MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetTableColumnFormula("Sheet1", "Sales", "Amount", "[@Price]"), "XML syntax error on line 1: invalid UTF-8")
+}
+
+func TestAddReportTable(t *testing.T) {
+	f := NewFile()
+	for row := 2; row <= 5; row++ {
+		assert.NoError(t, f.SetSheetRow("Sheet1", fmt.Sprintf("B%d", row), &[]interface{}{fmt.Sprintf("R%d", row), 44562 + row, row * 100}))
+	}
+	header, err := f.NewStyle(&Style{Font: &Font{Bold: true}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.AddReportTable("Sheet1", "D5:B1", ReportTableOptions{
+		TableStyle:    "TableStyleMedium2",
+		Headers:       []string{"Region", "Date", "Amount [USD]"},
+		HeaderStyleID: header,
+		Columns: []ReportTableColumn{
+			{TotalsRowLabel: "Total"},
+			{NumFmt: "yyyy-mm-dd", TotalsRowFunction: "count"},
+			{NumFmt: "#,##0.00", TotalsRowFunction: "sum"},
+		},
+		ShowRowStripes: true,
+		FreezeHeader:   true,
+	}))
+	tables, err := f.GetTables("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, tables, 1)
+	assert.Equal(t, "Table1", tables[0].Name)
+	assert.Equal(t, "B1:D6", tables[0].Range)
+	assert.Equal(t, []TableColumn{{Name: "Region"}, {Name: "Date", TotalsRowFunction: "count"}, {Name: "Amount [USD]", TotalsRowFunction: "sum"}}, tables[0].Columns)
+	table, _, err := f.getSheetTable("Sheet1", "Table1")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, table.TotalsRowCount)
+	assert.Equal(t, "B1:D5", table.AutoFilter.Ref)
+	assert.Equal(t, "Total", table.TableColumns.TableColumn[0].TotalsRowLabel)
+	for cell, expected := range map[string]string{"B1": "Region", "D1": "Amount [USD]", "B6": "Total", "C2": "2022-01-03", "D5": "500.00"} {
+		val, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, val, cell)
+	}
+	for cell, expected := range map[string]string{"C6": "SUBTOTAL(103,Table1[Date])", "D6": "SUBTOTAL(109,Table1[Amount '[USD']])"} {
+		formula, err := f.GetCellFormula("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, formula, cell)
+	}
+	styleID, err := f.GetCellStyle("Sheet1", "C1")
+	assert.NoError(t, err)
+	assert.Equal(t, header, styleID)
+	styleID, err = f.GetCellStyle("Sheet1", "D6")
+	assert.NoError(t, err)
+	assert.Equal(t, 4, *f.Styles.CellXfs.Xf[styleID].NumFmtID)
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "frozen", ws.SheetViews.SheetView[0].Pane.State)
+	assert.Equal(t, "A2", ws.SheetViews.SheetView[0].Pane.TopLeftCell)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddReportTable.xlsx")))
+
+	// Test add report table without totals row on the header row only
+	assert.NoError(t, f.AddReportTable("Sheet1", "F1:G1", ReportTableOptions{TableName: "Report"}))
+	tables, err = f.GetTables("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "F1:G2", tables[1].Range)
+	assert.Equal(t, []TableColumn{{Name: "Column1"}, {Name: "Column2"}}, tables[1].Columns)
+	// Test add report table with invalid parameters
+	assert.Equal(t, ErrParameterInvalid, f.AddReportTable("Sheet1", "A1:B2", ReportTableOptions{Headers: []string{"A", "B", "C"}}))
+	assert.Equal(t, ErrParameterInvalid, f.AddReportTable("Sheet1", "A1:B2", ReportTableOptions{Columns: make([]ReportTableColumn, 3)}))
+	assert.Equal(t, ErrParameterInvalid, f.AddReportTable("Sheet1", "A1:B2", ReportTableOptions{Columns: []ReportTableColumn{{TotalsRowFunction: "median"}}}))
+	assert.EqualError(t, f.AddReportTable("Sheet1", "A:B2", ReportTableOptions{}), newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
+	assert.EqualError(t, f.AddReportTable("SheetN", "A1:B2", ReportTableOptions{}), "sheet SheetN is not exist")
+	assert.Equal(t, ErrMaxRows, f.AddReportTable("Sheet1", "A1048576:B1048576", ReportTableOptions{}))
+}
+
+func TestEscapeTableColumnName(t *testing.T) {
+	assert.Equal(t, "Amount", escapeTableColumnName("Amount"))
+	assert.Equal(t, "'#'[A']''", escapeTableColumnName("#[A]'"))
+}
+
+func TestConvertTableFormula(t *testing.T) {
+	table := &xlsxTable{Name: "Sales", TableColumns: &xlsxTableColumns{TableColumn: []*xlsxTableColumn{{Name: "Price"}, {Name: "Qty"}, {Name: "Unit]Cost"}}}}
+	for formula, expected := range map[string]string{
+		"[@Price]*[@Qty]":            "Sales[[#This Row],[Price]]*Sales[[#This Row],[Qty]]",
+		"Sales[@Price]":              "Sales[[#This Row],[Price]]",
+		"Other[@Price]":              "Other[[#This Row],[Price]]",
+		"[@[Price]:[Qty]]":           "Sales[[#This Row],[Price]:[Qty]]",
+		"ROWS([@])":                  "ROWS(Sales[#This Row])",
+		"[@Price]/SUM([Price])":      "Sales[[#This Row],[Price]]/SUM(Sales[Price])",
+		"SUM(Sales[Price])":          "SUM(Sales[Price])",
+		"[@[Unit']Cost]]":            "Sales[[#This Row],[Unit']Cost]]",
+		`IF([@Qty]>1,"[@Qty]","")`:   `IF(Sales[[#This Row],[Qty]]>1,"[@Qty]","")`,
+		"[1]Sheet1!A1+[Amount]":      "[1]Sheet1!A1+[Amount]",
+		"Sales[[#This Row],[Price]]": "Sales[[#This Row],[Price]]",
+		"[@Price":                    "[@Price",
+	} {
+		assert.Equal(t, expected, convertTableFormula(table, formula), formula)
+	}
+}
+
+func TestConvertRangeToTable(t *testing.T) {
+	f := NewFile()
+	for row, values := range [][]interface{}{{"Region", "Price", "Qty"}, {"East", 10, 2}, {"West", 20, 3}} {
+		assert.NoError(t, f.SetSheetRow("Sheet1", fmt.Sprintf("A%d", row+1), &values))
+	}
+	assert.NoError(t, f.ConvertRangeToTable("Sheet1", "C3:A1", "Sales"))
+	tables, err := f.GetTables("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []Table{{
+		Name: "Sales", Range: "A1:C3", StyleName: "TableStyleMedium2", ShowRowStripes: true,
+		Columns: []TableColumn{{Name: "Region"}, {Name: "Price"}, {Name: "Qty"}},
+	}}, tables)
+	// Test convert range to table with default table name
+	assert.NoError(t, f.ConvertRangeToTable("Sheet1", "E1:F2", ""))
+	tables, err = f.GetTables("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "Table2", tables[1].Name)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestConvertRangeToTable.xlsx")))
+	// Test convert range to table with invalid parameters
+	assert.Equal(t, ErrTableOverlap, f.ConvertRangeToTable("Sheet1", "B2:D5", "Other"))
+	for _, name := range []string{"sales", "1Table", "Table Name", "AB12", "r", "C", "R1C1", "rc", "R2C", strings.Repeat("a", 256)} {
+		assert.Equal(t, ErrTableName, f.ConvertRangeToTable("Sheet1", "H1:I2", name), name)
+	}
+	assert.EqualError(t, f.ConvertRangeToTable("Sheet1", "A:B2", "Other"), newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
+	assert.EqualError(t, f.ConvertRangeToTable("SheetN", "A1:B2", "Other"), "sheet SheetN is not exist")
+	// Test convert range to table with unsupported charset table
+	f.Pkg.Store("xl/tables/table1.xml", MacintoshCyrillicCharset)
+	assert.EqualError(t, f.ConvertRangeToTable("Sheet1", "H1:I2", "Other"), "XML syntax error on line 1: invalid UTF-8")
+	f.NewSheet("Sheet2")
+	assert.EqualError(t, f.ConvertRangeToTable("Sheet2", "H1:I2", "Other"), "XML syntax error on line 1: invalid UTF-8")
+}
+
+func TestConvertTableToRange(t *testing.T) {
+	f := NewFile()
+	for row, values := range [][]interface{}{{"Region", "Price", "Qty", "Amount"}, {"East", 10, 2}, {"West", 20, 3}, {"North", 30, 4}} {
+		assert.NoError(t, f.SetSheetRow("Sheet1", fmt.Sprintf("A%d", row+1), &values))
+	}
+	fill, err := f.NewStyle(&Style{Fill: Fill{Type: "pattern", Color: []string{"#FFFF00"}, Pattern: 1}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "B3", "B3", fill))
+	assert.NoError(t, f.ConvertRangeToTable("Sheet1", "A1:D4", "Sales"))
+	assert.NoError(t, f.SetTableColumnFormula("Sheet1", "Sales", "Amount", "[@Price]*[@Qty]"))
+	f.NewSheet("Sheet2")
+	for cell, formula := range map[string]string{
+		"A1": "SUM(Sales[Price])",
+		"A2": "COUNTA(Sales)",
+		"A3": "SUM(Sales[[#Headers],[Price]:[Qty]])",
+		"A4": "ROWS(Sales[#All])+SUM(Sales[Unknown])",
+	} {
+		assert.NoError(t, f.SetCellFormula("Sheet2", cell, formula))
+	}
+	assert.NoError(t, f.ConvertTableToRange("Sheet1", "sales", true))
+	tables, err := f.GetTables("Sheet1")
+	assert.NoError(t, err)
+	assert.Empty(t, tables)
+	_, ok := f.Pkg.Load("xl/tables/table1.xml")
+	assert.False(t, ok)
+	for _, override := range f.ContentTypes.Overrides {
+		assert.NotEqual(t, "/xl/tables/table1.xml", override.PartName)
+	}
+	for sheet, expected := range map[string]map[string]string{
+		"Sheet1": {"D2": "$B2*$C2", "D4": "$B4*$C4"},
+		"Sheet2": {
+			"A1": "SUM('Sheet1'!$B$2:$B$4)",
+			"A2": "COUNTA('Sheet1'!$A$2:$D$4)",
+			"A3": "SUM('Sheet1'!$B$1:$C$1)",
+			"A4": "ROWS('Sheet1'!$A$1:$D$4)+SUM(Sales[Unknown])",
+		},
+	} {
+		for cell, formula := range expected {
+			result, err := f.GetCellFormula(sheet, cell)
+			assert.NoError(t, err)
+			assert.Equal(t, formula, result, cell)
+		}
+	}
+	s := f.stylesReader()
+	getXf := func(cell string) xlsxXf {
+		styleID, err := f.GetCellStyle("Sheet1", cell)
+		assert.NoError(t, err)
+		return s.CellXfs.Xf[styleID]
+	}
+	header := getXf("A1")
+	assert.Equal(t, &xlsxFill{PatternFill: &xlsxPatternFill{PatternType: "solid", FgColor: &xlsxColor{Theme: intPtr(4)}}}, s.Fills.Fill[*header.FillID])
+	assert.True(t, *s.Fonts.Font[*header.FontID].B.Val)
+	assert.Equal(t, 0, *s.Fonts.Font[*header.FontID].Color.Theme)
+	stripe := getXf("A2")
+	assert.Equal(t, 0.799982, s.Fills.Fill[*stripe.FillID].PatternFill.FgColor.Tint)
+	assert.Equal(t, "thin", s.Borders.Border[*stripe.BorderID].Top.Style)
+	assert.Equal(t, 0, *getXf("A3").FillID)
+	// Test the direct formatting of the cell has higher priority
+	assert.Equal(t, "FFFFFF00", s.Fills.Fill[*getXf("B3").FillID].PatternFill.FgColor.RGB)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestConvertTableToRange.xlsx")))
+
+	// Test the table parts are not contiguous after converting the table
+	assert.NoError(t, f.AddTable("Sheet2", "C1", "D2", `{"table_name":"First"}`))
+	assert.NoError(t, f.AddTable("Sheet2", "F1", "G2", `{"table_name":"Second","table_style":"TableStyleLight1"}`))
+	assert.NoError(t, f.ConvertTableToRange("Sheet2", "First", false))
+	assert.NoError(t, f.AddTable("Sheet2", "C1", "D2", `{"table_name":"Third"}`))
+	_, ok = f.Pkg.Load("xl/tables/table3.xml")
+	assert.True(t, ok)
+	styleID, err := f.GetCellStyle("Sheet2", "C1")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, styleID)
+
+	// Test convert table to range with custom table style
+	f = NewFile()
+	assert.NoError(t, f.AddTable("Sheet1", "A1", "B5", `{"table_name":"Custom","table_style":"CustomStyle","show_first_column":true}`))
+	s = f.stylesReader()
+	s.Dxfs = &xlsxDxfs{Dxfs: []*xlsxDxf{
+		{Dxf: `<font><i/></font><border><left style="thin"/><vertical style="dashed"/></border>`},
+		{Dxf: `<fill><patternFill><bgColor rgb="FFFF0000"/></patternFill></fill>`},
+	}}
+	s.TableStyles = &xlsxTableStyles{TableStyles: []*xlsxTableStyle{
+		{Name: "Other"},
+		{Name: "CustomStyle", TableStyleElement: `<tableStyleElement type="wholeTable" dxfId="0"/><tableStyleElement type="firstRowStripe" size="2" dxfId="1"/><tableStyleElement type="firstColumn" dxfId="2"/><tableStyleElement type="headerRow"/>`},
+	}}
+	assert.NoError(t, f.ConvertTableToRange("Sheet1", "Custom", true))
+	for cell, expected := range map[string]string{"A1": "thin", "B1": "dashed", "B2": "dashed"} {
+		styleID, err := f.GetCellStyle("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, s.Borders.Border[*s.CellXfs.Xf[styleID].BorderID].Left.Style, cell)
+		assert.True(t, *s.Fonts.Font[*s.CellXfs.Xf[styleID].FontID].I.Val, cell)
+	}
+	for cell, expected := range map[string]bool{"A1": false, "A2": true, "A3": true, "A4": false, "A5": true} {
+		styleID, err := f.GetCellStyle("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, *s.CellXfs.Xf[styleID].FillID != 0, cell)
+	}
+	styleID, err = f.GetCellStyle("Sheet1", "A2")
+	assert.NoError(t, err)
+	assert.Equal(t, "FFFF0000", s.Fills.Fill[*s.CellXfs.Xf[styleID].FillID].PatternFill.FgColor.RGB)
+
+	// Test convert table to range with invalid parameters
+	assert.Equal(t, ErrTableNotExist, f.ConvertTableToRange("Sheet1", "Custom", true))
+	assert.EqualError(t, f.ConvertTableToRange("SheetN", "Custom", true), "sheet SheetN is not exist")
+	// Test convert table to range with invalid table style
+	for _, element := range []string{`<tableStyleElement type="wholeTable" dxfId="0">`, `<tableStyleElement type="wholeTable" dxfId="3"/>`} {
+		assert.NoError(t, f.AddTable("Sheet1", "A1", "B5", `{"table_name":"Custom","table_style":"CustomStyle"}`))
+		s.TableStyles.TableStyles[1].TableStyleElement = element
+		s.Dxfs.Dxfs = append(s.Dxfs.Dxfs, &xlsxDxf{Dxf: "<font>"})
+		assert.Error(t, f.ConvertTableToRange("Sheet1", "Custom", true))
+		assert.NoError(t, f.ConvertTableToRange("Sheet1", "Custom", false))
+	}
+	// Test convert table to range with invalid table range
+	assert.NoError(t, f.AddTable("Sheet1", "A1", "B5", `{"table_name":"Custom"}`))
+	table, tableXML, err := f.getSheetTable("Sheet1", "Custom")
+	assert.NoError(t, err)
+	table.Ref = "A:B5"
+	content, _ := xml.Marshal(table)
+	f.saveFileList(tableXML, content)
+	assert.EqualError(t, f.ConvertTableToRange("Sheet1", "Custom", true), newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
+	// Test convert table to range with invalid formula cell reference
+	table.Ref = "A1:B5"
+	content, _ = xml.Marshal(table)
+	f.saveFileList(tableXML, content)
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	ws.SheetData.Row[0].C[0].F, ws.SheetData.Row[0].C[0].R = &xlsxF{Content: "Custom[Column1]"}, "A"
+	assert.EqualError(t, f.ConvertTableToRange("Sheet1", "Custom", false), newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
+}
+
+func TestStructuredRefToRange(t *testing.T) {
+	table := &xlsxTable{Name: "Sales", TotalsRowCount: 1, TableColumns: &xlsxTableColumns{TableColumn: []*xlsxTableColumn{{Name: "Price"}, {Name: "Qty"}, {Name: "Unit]Cost"}}}}
+	coordinates := []int{2, 3, 4, 10}
+	for specifier, expected := range map[string]string{
+		"":                           "$B$4:$D$9",
+		"Price":                      "$B$4:$B$9",
+		"@Qty":                       "$C5",
+		"@":                          "$B5:$D5",
+		"@[Price]:[Qty]":             "$B5:$C5",
+		"#Totals":                    "$B$10:$D$10",
+		"[#Data],[#Totals],[Price]":  "$B$4:$B$10",
+		"[#This Row],[Unit']Cost]":   "$D5",
+		"[#Headers],[Price]":         "$B$3",
+		" [#All] , [Qty] : [Price] ": "$B$3:$C$10",
+	} {
+		ref, ok := structuredRefToRange(table, coordinates, specifier, 5)
+		assert.True(t, ok, specifier)
+		assert.Equal(t, expected, ref, specifier)
+	}
+	for _, specifier := range []string{"Unknown", "[Price", "[Price]x", "[#Totals]"} {
+		_, ok := structuredRefToRange(&xlsxTable{Name: "Sales", TableColumns: table.TableColumns}, coordinates, specifier, 5)
+		assert.False(t, ok, specifier)
+	}
+	// Test the structured references of the table without header row
+	headerless := &xlsxTable{Name: "Sales", HeaderRowCount: intPtr(0), TableColumns: table.TableColumns}
+	ref, ok := structuredRefToRange(headerless, coordinates, "Price", 5)
+	assert.True(t, ok)
+	assert.Equal(t, "$B$3:$B$10", ref)
+	_, ok = structuredRefToRange(headerless, coordinates, "#Headers", 5)
+	assert.False(t, ok)
+	for formula, expected := range map[string]string{
+		"Sales[Price]*2":            "$B$4:$B$9*2",
+		`"Sales[Price]"&Sales`:      `"Sales[Price]"&$B$4:$D$9`,
+		"Sales!A1+SalesTotal":       "Sales!A1+SalesTotal",
+		"'Sales'!A1+SALES[@Qty]":    "'Sales'!A1+$C5",
+		"SUM(Sales[[#Totals]":       "SUM(Sales[[#Totals]",
+		"Sales[Unknown]+Sales[Qty]": "Sales[Unknown]+$C$4:$C$9",
+	} {
+		assert.Equal(t, expected, convertTableRefs(table, coordinates, formula, "", 5), formula)
+	}
+}
+
+func TestGetBuiltInTableStyle(t *testing.T) {
+	for style, count := range map[string]int{"Light": 21, "Medium": 28, "Dark": 11} {
+		for idx := 1; idx <= count; idx++ {
+			assert.NotNil(t, getBuiltInTableStyle(fmt.Sprintf("TableStyle%s%d", style, idx)))
+		}
+	}
+	for _, name := range []string{"", "TableStyleLight0", "TableStyleLight22", "TableStyleDark12", "TableStyleMedium"} {
+		assert.Nil(t, getBuiltInTableStyle(name), name)
+	}
+}
+
 func TestSetTableHeader(t *testing.T) {
 	f := NewFile()
 	_, err := f.setTableHeader("Sheet1", 1, 0, 1)
@@ -126,6 +556,109 @@ func TestAutoFilterError(t *testing.T) {
 	}), `incorrect number of tokens in criteria '-'`)
 }
 
+func TestGetAutoFilter(t *testing.T) {
+	f := NewFile()
+	filter, err := f.GetAutoFilter("Sheet1")
+	assert.NoError(t, err)
+	assert.Nil(t, filter)
+
+	assert.NoError(t, f.AutoFilter("Sheet1", "B1", "D10", `{"column":"C","expression":"x == 1 or x == 2"}`))
+	buf, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+	f, err = OpenReader(buf)
+	assert.NoError(t, err)
+	filter, err = f.GetAutoFilter("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, &AutoFilter{Range: "$B$1:$D$10", Columns: []AutoFilterColumn{{Column: "C", Type: "filters", Values: []string{"1", "2"}}}}, filter)
+
+	assert.NoError(t, f.AutoFilter("Sheet1", "B1", "D10", `{"column":"D","expression":"x >= 1 and x != 2"}`))
+	filter, err = f.GetAutoFilter("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, &AutoFilter{Range: "$B$1:$D$10", Columns: []AutoFilterColumn{{
+		Column: "D",
+		Type:   "customFilters",
+		CustomFilters: []AutoFilterCustomFilter{
+			{Operator: "greaterThanOrEqual", Value: "1"},
+			{Operator: "notEqual", Value: "2"},
+		},
+		And: true,
+	}}}, filter)
+
+	// Test get auto filter with blank and default operator
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).AutoFilter.FilterColumn = []*xlsxFilterColumn{
+		{ColID: 0, Filters: &xlsxFilters{Blank: true, Filter: []*xlsxFilter{{Val: "a"}}}},
+		{ColID: 2, CustomFilters: &xlsxCustomFilters{CustomFilter: []*xlsxCustomFilter{{Val: "b*"}}}},
+	}
+	filter, err = f.GetAutoFilter("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []AutoFilterColumn{
+		{Column: "B", Type: "filters", Values: []string{"a"}, Blank: true},
+		{Column: "D", Type: "customFilters", CustomFilters: []AutoFilterCustomFilter{{Operator: "equal", Value: "b*"}}},
+	}, filter.Columns)
+
+	// Test get auto filter with top 10, dynamic, color and icon filters
+	ws.(*xlsxWorksheet).AutoFilter.FilterColumn = []*xlsxFilterColumn{
+		{ColID: 0, Top10: &xlsxTop10{Top: true, Percent: true, Val: 10, FilterVal: 95}},
+		{ColID: 1, DynamicFilter: &xlsxDynamicFilter{Type: "aboveAverage", Val: 5.5}},
+		{ColID: 2, ColorFilter: &xlsxColorFilter{CellColor: true, DxfID: 1}},
+		{ColID: 3, IconFilter: &xlsxIconFilter{IconSet: "3Arrows", IconID: 2}},
+	}
+	ws.(*xlsxWorksheet).AutoFilter.Ref = "A1:D10"
+	filter, err = f.GetAutoFilter("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []AutoFilterColumn{
+		{Column: "A", Type: "top10", Top10: &AutoFilterTop10{Top: true, Percent: true, Value: 10, FilterValue: 95}},
+		{Column: "B", Type: "dynamicFilter", DynamicFilter: &AutoFilterDynamicFilter{Type: "aboveAverage", Value: 5.5}},
+		{Column: "C", Type: "colorFilter", ColorFilter: &AutoFilterColorFilter{CellColor: true, DxfID: 1}},
+		{Column: "D", Type: "iconFilter", IconFilter: &AutoFilterIconFilter{IconSet: "3Arrows", IconID: 2}},
+	}, filter.Columns)
+
+	// Test get auto filter with invalid range and column index
+	ws.(*xlsxWorksheet).AutoFilter.FilterColumn[0].ColID = MaxColumns
+	_, err = f.GetAutoFilter("Sheet1")
+	assert.EqualError(t, err, ErrColumnNumber.Error())
+	ws.(*xlsxWorksheet).AutoFilter.Ref = "B1"
+	_, err = f.GetAutoFilter("Sheet1")
+	assert.EqualError(t, err, ErrParameterInvalid.Error())
+	_, err = f.GetAutoFilter("SheetN")
+	assert.EqualError(t, err, "sheet SheetN is not exist")
+}
+
+func TestSetSortState(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.AutoFilter("Sheet1", "A1", "D10", ""))
+	assert.NoError(t, f.SetSortState("Sheet1", "D10:A2", []SortCondition{
+		{Column: "B", Descending: true},
+		{Column: "C", CustomList: "Low,Medium,High"},
+	}))
+	buf, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+
+	f, err = OpenReader(buf)
+	assert.NoError(t, err)
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, &xlsxSortState{
+		Ref: "A2:D10",
+		SortCondition: []*xlsxSortCondition{
+			{Descending: true, Ref: "B2:B10"},
+			{Ref: "C2:C10", CustomList: "Low,Medium,High"},
+		},
+	}, ws.SortState)
+	// Test remove the sort state
+	assert.NoError(t, f.SetSortState("Sheet1", "A2:D10", nil))
+	assert.Nil(t, ws.SortState)
+
+	// Test set sort state with invalid parameters
+	assert.EqualError(t, f.SetSortState("SheetN", "A2:D10", nil), "sheet SheetN is not exist")
+	assert.EqualError(t, f.SetSortState("Sheet1", "A2:D10", make([]SortCondition, 65)), ErrParameterInvalid.Error())
+	assert.EqualError(t, f.SetSortState("Sheet1", "A2", []SortCondition{{Column: "A"}}), ErrParameterInvalid.Error())
+	assert.EqualError(t, f.SetSortState("Sheet1", "A2:D10", []SortCondition{{Column: "-"}}), newInvalidColumnNameError("-").Error())
+	assert.EqualError(t, f.SetSortState("Sheet1", "A2:D10", []SortCondition{{Column: "E"}}), "incorrect index of column 'E'")
+}
+
 func TestParseFilterTokens(t *testing.T) {
 	f := NewFile()
 	// Test with unknown operator.
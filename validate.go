@@ -0,0 +1,398 @@
+// Copyright 2016 - 2022 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to and
+// read from XLAM / XLSM / XLSX / XLTM / XLTX files. Supports reading and
+// writing spreadsheet documents generated by Microsoft Excel™ 2007 and later.
+// Supports complex components by high compatibility, and provided streaming
+// API for generating or reading data from a worksheet with huge amounts of
+// data. This library needs Go version 1.15 or later.
+
+package excelize
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/xuri/efp"
+)
+
+// ValidationSeverity is the type of the severity of the workbook validation
+// issue.
+type ValidationSeverity int
+
+// This section defines the currently supported severity of the workbook
+// validation issue. The issues with ValidationSeverityError severity usually
+// cause the spreadsheet application to repair the workbook, and the issues
+// with ValidationSeverityWarning severity may produce incorrect results.
+const (
+	ValidationSeverityWarning ValidationSeverity = iota
+	ValidationSeverityError
+)
+
+// String returns the name of the validation issue severity.
+func (s ValidationSeverity) String() string {
+	if s == ValidationSeverityError {
+		return "error"
+	}
+	return "warning"
+}
+
+// ValidationIssue directly maps an issue found by the Validate function. The
+// Location is the cell reference (such as Sheet1!A1), the defined name or the
+// path of the part in the package where the issue was found.
+type ValidationIssue struct {
+	Severity ValidationSeverity
+	Location string
+	Message  string
+}
+
+// Validate provides a function to check the workbook against common
+// corruption issues, and returns the issues found. The checks include:
+//
+//	dangling cell, row, column and cell format style references
+//	overlapping merged cells
+//	formulas and defined names referencing worksheets that don't exist
+//	relationships of the worksheets and drawings referencing missing parts
+//	worksheet elements referencing missing relationships
+//	duplicate defined names on the same scope
+//
+// For example, check the workbook before saving it:
+//
+//	for _, issue := range f.Validate() {
+//	    fmt.Println(issue.Severity, issue.Location, issue.Message)
+//	}
+func (f *File) Validate() []ValidationIssue {
+	issues := new(validationIssues)
+	styleSheet := f.stylesReader()
+	var xfCount int
+	if styleSheet != nil && styleSheet.CellXfs != nil {
+		xfCount = len(styleSheet.CellXfs.Xf)
+	}
+	f.validateStyles(styleSheet, issues)
+	sheets := f.getValidateSheetNames()
+	for _, sheet := range f.GetSheetList() {
+		ws, err := f.workSheetReader(sheet)
+		if err != nil {
+			if err.Error() != fmt.Sprintf("sheet %s is not a worksheet", trimSheetName(sheet)) {
+				issues.add(ValidationSeverityError, sheet, "%s", err)
+			}
+			continue
+		}
+		f.validateSheetStyles(ws, sheet, xfCount, issues)
+		validateMergeCells(ws, sheet, issues)
+		for _, row := range ws.SheetData.Row {
+			for _, c := range row.C {
+				if c.F != nil && c.F.Content != "" {
+					validateFormulaSheetRefs(c.F.Content, sheet+"!"+c.R, sheets, issues)
+				}
+			}
+		}
+		sheetXMLPath, _ := f.getSheetXMLPath(sheet)
+		f.validateSheetRels(ws, sheetXMLPath, issues)
+	}
+	f.validateDefinedNames(sheets, issues)
+	return *issues
+}
+
+// validationIssues is the collection of the issues found by the Validate
+// function.
+type validationIssues []ValidationIssue
+
+// add provides a function to append an issue with the formatted message to
+// the collection.
+func (v *validationIssues) add(severity ValidationSeverity, location, format string, args ...interface{}) {
+	*v = append(*v, ValidationIssue{Severity: severity, Location: location, Message: fmt.Sprintf(format, args...)})
+}
+
+// getValidateSheetNames provides a function to get the lowercase names of
+// all sheets in the workbook.
+func (f *File) getValidateSheetNames() map[string]bool {
+	sheets := map[string]bool{}
+	for _, sheet := range f.GetSheetList() {
+		sheets[strings.ToLower(sheet)] = true
+	}
+	return sheets
+}
+
+// validateStyles provides a function to check the font, fill, border and
+// number format references of the cell formats.
+func (f *File) validateStyles(styleSheet *xlsxStyleSheet, issues *validationIssues) {
+	if styleSheet == nil || styleSheet.CellXfs == nil {
+		return
+	}
+	var fonts, fills, borders int
+	if styleSheet.Fonts != nil {
+		fonts = len(styleSheet.Fonts.Font)
+	}
+	if styleSheet.Fills != nil {
+		fills = len(styleSheet.Fills.Fill)
+	}
+	if styleSheet.Borders != nil {
+		borders = len(styleSheet.Borders.Border)
+	}
+	numFmts := map[int]bool{}
+	if styleSheet.NumFmts != nil {
+		for _, numFmt := range styleSheet.NumFmts.NumFmt {
+			numFmts[numFmt.NumFmtID] = true
+		}
+	}
+	location := "xl/styles.xml"
+	for idx, xf := range styleSheet.CellXfs.Xf {
+		if xf.FontID != nil && (*xf.FontID < 0 || *xf.FontID >= fonts) {
+			issues.add(ValidationSeverityError, location, "cell format %d references missing font %d", idx, *xf.FontID)
+		}
+		if xf.FillID != nil && (*xf.FillID < 0 || *xf.FillID >= fills) {
+			issues.add(ValidationSeverityError, location, "cell format %d references missing fill %d", idx, *xf.FillID)
+		}
+		if xf.BorderID != nil && (*xf.BorderID < 0 || *xf.BorderID >= borders) {
+			issues.add(ValidationSeverityError, location, "cell format %d references missing border %d", idx, *xf.BorderID)
+		}
+		if xf.NumFmtID != nil && *xf.NumFmtID >= 164 && !numFmts[*xf.NumFmtID] {
+			issues.add(ValidationSeverityError, location, "cell format %d references missing number format %d", idx, *xf.NumFmtID)
+		}
+	}
+}
+
+// validateSheetStyles provides a function to check the style references of
+// the cells, rows and columns in the worksheet.
+func (f *File) validateSheetStyles(ws *xlsxWorksheet, sheet string, xfCount int, issues *validationIssues) {
+	isInvalid := func(styleID int) bool {
+		return styleID < 0 || (styleID > 0 && styleID >= xfCount)
+	}
+	if ws.Cols != nil {
+		for _, col := range ws.Cols.Col {
+			if isInvalid(col.Style) {
+				minCol, _ := ColumnNumberToName(col.Min)
+				maxCol, _ := ColumnNumberToName(col.Max)
+				issues.add(ValidationSeverityError, fmt.Sprintf("%s!%s:%s", sheet, minCol, maxCol), "column references missing style %d", col.Style)
+			}
+		}
+	}
+	for _, row := range ws.SheetData.Row {
+		if isInvalid(row.S) {
+			issues.add(ValidationSeverityError, fmt.Sprintf("%s!%d:%d", sheet, row.R, row.R), "row references missing style %d", row.S)
+		}
+		for _, c := range row.C {
+			if isInvalid(c.S) {
+				issues.add(ValidationSeverityError, sheet+"!"+c.R, "cell references missing style %d", c.S)
+			}
+		}
+	}
+}
+
+// validateMergeCells provides a function to check the invalid and overlapping
+// merged cells in the worksheet. The merged cells are swept in the order of
+// the top row, and the merged cells which cover the current row are kept in
+// the order of the left column, so that each merged cell is only compared
+// with the merged cells in the same columns instead of all of them. The
+// merged cell which overlaps with others is reported and excluded from the
+// later checks.
+func validateMergeCells(ws *xlsxWorksheet, sheet string, issues *validationIssues) {
+	if ws.MergeCells == nil {
+		return
+	}
+	var (
+		refs   []string
+		rects  [][]int
+		active []int
+	)
+	for _, mergeCell := range ws.MergeCells.Cells {
+		if mergeCell == nil {
+			continue
+		}
+		rect, err := areaRefToCoordinates(mergeCell.Ref)
+		if err != nil {
+			issues.add(ValidationSeverityError, sheet+"!"+mergeCell.Ref, "invalid merged cell reference: %s", err)
+			continue
+		}
+		_ = sortCoordinates(rect)
+		refs, rects = append(refs, mergeCell.Ref), append(rects, rect)
+	}
+	order := make([]int, len(rects))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		a, b := rects[order[i]], rects[order[j]]
+		return a[1] < b[1] || (a[1] == b[1] && a[0] < b[0])
+	})
+	for _, i := range order {
+		rect, overlapped := rects[i], false
+		pos := sort.Search(len(active), func(j int) bool { return rects[active[j]][2] >= rect[0] })
+		for j := pos; j < len(active) && rects[active[j]][0] <= rect[2]; {
+			if rects[active[j]][3] < rect[1] {
+				active = append(active[:j], active[j+1:]...)
+				continue
+			}
+			first, second := active[j], i
+			if first > second {
+				first, second = second, first
+			}
+			issues.add(ValidationSeverityError, sheet+"!"+refs[first], "merged cell overlaps with merged cell %s", refs[second])
+			overlapped = true
+			j++
+		}
+		if !overlapped {
+			active = append(active, 0)
+			copy(active[pos+1:], active[pos:])
+			active[pos] = i
+		}
+	}
+}
+
+// validateFormulaSheetRefs provides a function to check if the formula
+// references worksheets that don't exist in the workbook.
+func validateFormulaSheetRefs(formula, location string, sheets map[string]bool, issues *validationIssues) {
+	for _, sheet := range getFormulaSheetRefs(formula) {
+		if !sheets[strings.ToLower(sheet)] {
+			issues.add(ValidationSeverityWarning, location, "formula references missing sheet %s", sheet)
+		}
+	}
+}
+
+// getFormulaSheetRefs provides a function to get the names of the sheets
+// referenced by the formula. External references will be ignored.
+func getFormulaSheetRefs(formula string) []string {
+	var sheets []string
+	ps := efp.ExcelParser()
+	for _, token := range ps.Parse(formula) {
+		if token.TType != efp.TokenTypeOperand || token.TSubType != efp.TokenSubTypeRange {
+			continue
+		}
+		idx := strings.LastIndex(token.TValue, "!")
+		if idx == -1 {
+			continue
+		}
+		ref := token.TValue[:idx]
+		if strings.HasPrefix(ref, "'") && strings.HasSuffix(ref, "'") && len(ref) > 1 {
+			ref = strings.ReplaceAll(ref[1:len(ref)-1], "''", "'")
+		}
+		if strings.HasPrefix(ref, "[") {
+			continue
+		}
+		sheets = append(sheets, strings.Split(ref, ":")...)
+	}
+	return sheets
+}
+
+// validateSheetRels provides a function to check the relationships of the
+// worksheet and its drawings, and the worksheet elements referencing the
+// relationships.
+func (f *File) validateSheetRels(ws *xlsxWorksheet, sheetXMLPath string, issues *validationIssues) {
+	relsPath := getPartRelsPath(sheetXMLPath)
+	rIDs := map[string]bool{}
+	if rels := f.relsReader(relsPath); rels != nil {
+		rels.Lock()
+		for _, rel := range rels.Relationships {
+			rIDs[rel.ID] = true
+			if rel.TargetMode == "External" {
+				continue
+			}
+			target := getRelsTargetPath(sheetXMLPath, rel.Target)
+			if !f.isPartExist(target) {
+				issues.add(ValidationSeverityError, relsPath, "relationship %s references missing part %s", rel.ID, target)
+				continue
+			}
+			if rel.Type == SourceRelationshipDrawingML {
+				f.validateDrawingRels(target, issues)
+			}
+		}
+		rels.Unlock()
+	}
+	var refs []string
+	if ws.Drawing != nil {
+		refs = append(refs, ws.Drawing.RID)
+	}
+	if ws.LegacyDrawing != nil {
+		refs = append(refs, ws.LegacyDrawing.RID)
+	}
+	if ws.Picture != nil {
+		refs = append(refs, ws.Picture.RID)
+	}
+	if ws.TableParts != nil {
+		for _, tablePart := range ws.TableParts.TableParts {
+			refs = append(refs, tablePart.RID)
+		}
+	}
+	if ws.Hyperlinks != nil {
+		for _, hyperlink := range ws.Hyperlinks.Hyperlink {
+			refs = append(refs, hyperlink.RID)
+		}
+	}
+	for _, rID := range refs {
+		if rID != "" && !rIDs[rID] {
+			issues.add(ValidationSeverityError, sheetXMLPath, "worksheet references missing relationship %s", rID)
+		}
+	}
+}
+
+// validateDrawingRels provides a function to check if the relationships of
+// the drawing reference missing parts.
+func (f *File) validateDrawingRels(drawingXML string, issues *validationIssues) {
+	relsPath := getPartRelsPath(drawingXML)
+	rels := f.relsReader(relsPath)
+	if rels == nil {
+		return
+	}
+	rels.Lock()
+	defer rels.Unlock()
+	for _, rel := range rels.Relationships {
+		if rel.TargetMode == "External" {
+			continue
+		}
+		if target := getRelsTargetPath(drawingXML, rel.Target); !f.isPartExist(target) {
+			issues.add(ValidationSeverityError, relsPath, "relationship %s references missing part %s", rel.ID, target)
+		}
+	}
+}
+
+// isPartExist provides a function to check if the part exists in the package
+// by given path.
+func (f *File) isPartExist(partPath string) bool {
+	if _, ok := f.Pkg.Load(partPath); ok {
+		return true
+	}
+	if _, ok := f.Sheet.Load(partPath); ok {
+		return true
+	}
+	if _, ok := f.Drawings.Load(partPath); ok {
+		return true
+	}
+	if _, ok := f.tempFiles.Load(partPath); ok {
+		return true
+	}
+	if _, ok := f.streams[partPath]; ok {
+		return true
+	}
+	return f.Comments[partPath] != nil || f.VMLDrawing[partPath] != nil
+}
+
+// validateDefinedNames provides a function to check the duplicate defined
+// names on the same scope, and the defined names referencing worksheets that
+// don't exist.
+func (f *File) validateDefinedNames(sheets map[string]bool, issues *validationIssues) {
+	wb := f.workbookReader()
+	if wb.DefinedNames == nil {
+		return
+	}
+	names := map[string]bool{}
+	for _, dn := range wb.DefinedNames.DefinedName {
+		scope := "Workbook"
+		if dn.LocalSheetID != nil {
+			if scope = f.GetSheetName(*dn.LocalSheetID); scope == "" {
+				issues.add(ValidationSeverityError, dn.Name, "defined name scoped to missing sheet index %d", *dn.LocalSheetID)
+				scope = strconv.Itoa(*dn.LocalSheetID)
+			}
+		}
+		key := strings.ToLower(scope) + "!" + strings.ToLower(dn.Name)
+		if names[key] {
+			issues.add(ValidationSeverityError, dn.Name, "duplicate defined name on the scope %s", scope)
+		}
+		names[key] = true
+		validateFormulaSheetRefs(dn.Data, dn.Name, sheets, issues)
+	}
+}
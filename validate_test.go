@@ -0,0 +1,99 @@
+package excelize
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	f := NewFile()
+	f.NewSheet("My Sheet")
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A1", "SUM('My Sheet'!A1:A2,Sheet1!B1)"))
+	assert.NoError(t, f.AddPicture("Sheet1", "D1", filepath.Join("test", "images", "excel.png"), ""))
+	assert.NoError(t, f.AddChart("Sheet1", "D10", `{"type":"col","series":[{"name":"Sheet1!$A$1","categories":"Sheet1!$A$2","values":"Sheet1!$B$2"}]}`))
+	assert.NoError(t, f.AddComment("Sheet1", "A3", `{"author":"Excelize: ","text":"This is a comment."}`))
+	assert.NoError(t, f.AddTable("Sheet1", "A5", "B7", ""))
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "A8", "https://github.com/xuri/excelize", "External"))
+	assert.NoError(t, f.MergeCell("Sheet1", "F1", "G2"))
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "Amount", RefersTo: "Sheet1!$A$1"}))
+	assert.Nil(t, f.Validate())
+
+	// Test validate workbook with corruption issues
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	ws.SheetData.Row[0].C[0].S = 100
+	ws.SheetData.Row[1].S = 100
+	ws.Cols = &xlsxCols{Col: []xlsxCol{{Min: 2, Max: 3, Style: 100}}}
+	ws.MergeCells.Cells = append(ws.MergeCells.Cells, &xlsxMergeCell{Ref: "G2:H3"}, &xlsxMergeCell{Ref: "A"})
+	ws.Drawing.RID = "rId100"
+	f.Styles.CellXfs.Xf = append(f.Styles.CellXfs.Xf, xlsxXf{FontID: intPtr(100), FillID: intPtr(100), BorderID: intPtr(100), NumFmtID: intPtr(200)})
+	assert.NoError(t, f.SetCellFormula("Sheet1", "B1", "Missing!A1+'Missing Sheet'!A1+[1]Sheet1!A1+\"Missing!A1\""))
+	wb := f.workbookReader()
+	wb.DefinedNames.DefinedName = append(wb.DefinedNames.DefinedName,
+		xlsxDefinedName{Name: "amount", Data: "Sheet1!$A$1"},
+		xlsxDefinedName{Name: "Total", Data: "Deleted!$A$1", LocalSheetID: intPtr(10)},
+	)
+	f.Pkg.Delete("xl/media/image1.png")
+	f.Pkg.Delete("xl/tables/table1.xml")
+	assert.Equal(t, []ValidationIssue{
+		{Severity: ValidationSeverityError, Location: "xl/styles.xml", Message: "cell format 1 references missing font 100"},
+		{Severity: ValidationSeverityError, Location: "xl/styles.xml", Message: "cell format 1 references missing fill 100"},
+		{Severity: ValidationSeverityError, Location: "xl/styles.xml", Message: "cell format 1 references missing border 100"},
+		{Severity: ValidationSeverityError, Location: "xl/styles.xml", Message: "cell format 1 references missing number format 200"},
+		{Severity: ValidationSeverityError, Location: "Sheet1!B:C", Message: "column references missing style 100"},
+		{Severity: ValidationSeverityError, Location: "Sheet1!A1", Message: "cell references missing style 100"},
+		{Severity: ValidationSeverityError, Location: "Sheet1!2:2", Message: "row references missing style 100"},
+		{Severity: ValidationSeverityError, Location: "Sheet1!A", Message: "invalid merged cell reference: " + ErrParameterInvalid.Error()},
+		{Severity: ValidationSeverityError, Location: "Sheet1!F1:G2", Message: "merged cell overlaps with merged cell G2:H3"},
+		{Severity: ValidationSeverityWarning, Location: "Sheet1!B1", Message: "formula references missing sheet Missing"},
+		{Severity: ValidationSeverityWarning, Location: "Sheet1!B1", Message: "formula references missing sheet Missing Sheet"},
+		{Severity: ValidationSeverityError, Location: "xl/drawings/_rels/drawing1.xml.rels", Message: "relationship rId1 references missing part xl/media/image1.png"},
+		{Severity: ValidationSeverityError, Location: "xl/worksheets/_rels/sheet1.xml.rels", Message: "relationship rId4 references missing part xl/tables/table1.xml"},
+		{Severity: ValidationSeverityError, Location: "xl/worksheets/sheet1.xml", Message: "worksheet references missing relationship rId100"},
+		{Severity: ValidationSeverityError, Location: "amount", Message: "duplicate defined name on the scope Workbook"},
+		{Severity: ValidationSeverityError, Location: "Total", Message: "defined name scoped to missing sheet index 10"},
+		{Severity: ValidationSeverityWarning, Location: "Total", Message: "formula references missing sheet Deleted"},
+	}, f.Validate())
+	assert.Equal(t, "error", ValidationSeverityError.String())
+	assert.Equal(t, "warning", ValidationSeverityWarning.String())
+
+	// Test validate workbook with invalid worksheet
+	f = NewFile()
+	f.Pkg.Store("xl/worksheets/sheet1.xml", MacintoshCyrillicCharset)
+	f.Sheet.Delete("xl/worksheets/sheet1.xml")
+	f.checked = nil
+	assert.Equal(t, []ValidationIssue{
+		{Severity: ValidationSeverityError, Location: "Sheet1", Message: "xml decode error: XML syntax error on line 1: invalid UTF-8"},
+	}, f.Validate())
+}
+
+func TestValidateMergeCells(t *testing.T) {
+	ws := &xlsxWorksheet{MergeCells: &xlsxMergeCells{}}
+	for row := 1; row <= 50000; row++ {
+		ws.MergeCells.Cells = append(ws.MergeCells.Cells, &xlsxMergeCell{Ref: fmt.Sprintf("A%d:B%d", row, row)})
+	}
+	for col := 4; col <= 10000; col++ {
+		name, err := ColumnNumberToName(col)
+		assert.NoError(t, err)
+		ws.MergeCells.Cells = append(ws.MergeCells.Cells, &xlsxMergeCell{Ref: name + "1:" + name + "50000"})
+	}
+	var issues validationIssues
+	validateMergeCells(ws, "Sheet1", &issues)
+	assert.Empty(t, issues)
+	// Test validate the overlapping merged cells
+	ws.MergeCells.Cells = append(ws.MergeCells.Cells, &xlsxMergeCell{Ref: "C100:B99"}, &xlsxMergeCell{Ref: "J60000:D50000"}, &xlsxMergeCell{Ref: "C1:C2"})
+	validateMergeCells(ws, "Sheet1", &issues)
+	assert.Equal(t, validationIssues{
+		{Severity: ValidationSeverityError, Location: "Sheet1!A99:B99", Message: "merged cell overlaps with merged cell C100:B99"},
+		{Severity: ValidationSeverityError, Location: "Sheet1!D1:D50000", Message: "merged cell overlaps with merged cell J60000:D50000"},
+		{Severity: ValidationSeverityError, Location: "Sheet1!E1:E50000", Message: "merged cell overlaps with merged cell J60000:D50000"},
+		{Severity: ValidationSeverityError, Location: "Sheet1!F1:F50000", Message: "merged cell overlaps with merged cell J60000:D50000"},
+		{Severity: ValidationSeverityError, Location: "Sheet1!G1:G50000", Message: "merged cell overlaps with merged cell J60000:D50000"},
+		{Severity: ValidationSeverityError, Location: "Sheet1!H1:H50000", Message: "merged cell overlaps with merged cell J60000:D50000"},
+		{Severity: ValidationSeverityError, Location: "Sheet1!I1:I50000", Message: "merged cell overlaps with merged cell J60000:D50000"},
+		{Severity: ValidationSeverityError, Location: "Sheet1!J1:J50000", Message: "merged cell overlaps with merged cell J60000:D50000"},
+	}, issues)
+}
@@ -81,6 +81,25 @@ func (f *File) getWorkbookRelsPath() (path string) {
 	return
 }
 
+// getWorkbookRelsTarget provides a function to get the path of the workbook
+// part by given relationship type, returns an empty string if the part
+// doesn't exist.
+func (f *File) getWorkbookRelsTarget(relType string) string {
+	if rels := f.relsReader(f.getWorkbookRelsPath()); rels != nil {
+		rels.Lock()
+		defer rels.Unlock()
+		for _, v := range rels.Relationships {
+			if v.Type == relType {
+				if strings.HasPrefix(v.Target, "/") {
+					return strings.TrimPrefix(v.Target, "/")
+				}
+				return "xl/" + v.Target
+			}
+		}
+	}
+	return ""
+}
+
 // workbookReader provides a function to get the pointer to the workbook.xml
 // structure after deserialization.
 func (f *File) workbookReader() *xlsxWorkbook {
@@ -101,6 +120,15 @@ func (f *File) workbookReader() *xlsxWorkbook {
 	return f.WorkBook
 }
 
+// date1904 provides a function to get whether the workbook uses the 1904 date
+// system when converting serial date-times to dates.
+func (f *File) date1904() bool {
+	if wb := f.workbookReader(); wb != nil && wb.WorkbookPr != nil {
+		return wb.WorkbookPr.Date1904
+	}
+	return false
+}
+
 // workBookWriter provides a function to save workbook.xml after serialize
 // structure.
 func (f *File) workBookWriter() {
@@ -124,6 +152,13 @@ func (f *File) workBookWriter() {
 //	Date1904(bool)
 //	FilterPrivacy(bool)
 //	CodeName(string)
+//
+// When Date1904 is enabled, date and time values set by SetCellValue, the
+// formatted values returned by GetCellValue, the date functions of the
+// formula calculation engine and newly added charts use the 1904 date system.
+// For example, switch a workbook to the 1904 date system:
+//
+//	err := f.SetWorkbookPrOptions(excelize.Date1904(true))
 func (f *File) SetWorkbookPrOptions(opts ...WorkbookPrOption) error {
 	wb := f.workbookReader()
 	pr := wb.WorkbookPr
@@ -197,3 +232,244 @@ func (o *CodeName) getWorkbookPrOption(pr *xlsxWorkbookPr) {
 	}
 	*o = CodeName(pr.CodeName)
 }
+
+// WorkbookWindow directly maps the settings of the workbook window. The
+// XWindow and YWindow specifies the position of the upper-left corner of the
+// window, the WindowWidth and WindowHeight specifies the size of the window,
+// all in twips (1/20 of a point). The TabRatio specifies the ratio of the
+// width of the sheet tabs to the width of the horizontal scroll bar, in
+// thousandths, the value 0 is the default ratio 600. The scroll bars and
+// sheet tabs are shown unless the HideHorizontalScroll, HideVerticalScroll or
+// HideSheetTabs is true, so the zero value keeps the Excel defaults.
+type WorkbookWindow struct {
+	XWindow              int
+	YWindow              int
+	WindowWidth          int
+	WindowHeight         int
+	TabRatio             int
+	Minimized            bool
+	HideHorizontalScroll bool
+	HideVerticalScroll   bool
+	HideSheetTabs        bool
+}
+
+// SetWorkbookWindow provides a function to set the position, size and the
+// display settings of the workbook window. This function returns
+// ErrParameterInvalid if the window size is negative or the tab ratio is out
+// of the range of 0 to 1000. For example, set the size of the window to
+// 1280 x 720 pixels (19200 x 10800 twips):
+//
+//	window, err := f.GetWorkbookWindow()
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	window.WindowWidth, window.WindowHeight = 19200, 10800
+//	err = f.SetWorkbookWindow(window)
+func (f *File) SetWorkbookWindow(window WorkbookWindow) error {
+	if window.WindowWidth < 0 || window.WindowHeight < 0 || window.TabRatio < 0 || window.TabRatio > 1000 {
+		return ErrParameterInvalid
+	}
+	wb := f.workbookReader()
+	if wb.BookViews == nil {
+		wb.BookViews = &xlsxBookViews{}
+	}
+	if len(wb.BookViews.WorkBookView) == 0 {
+		wb.BookViews.WorkBookView = append(wb.BookViews.WorkBookView, xlsxWorkBookView{})
+	}
+	view := &wb.BookViews.WorkBookView[0]
+	view.XWindow, view.YWindow = strconv.Itoa(window.XWindow), strconv.Itoa(window.YWindow)
+	view.WindowWidth, view.WindowHeight = window.WindowWidth, window.WindowHeight
+	view.TabRatio, view.Minimized = window.TabRatio, window.Minimized
+	view.ShowHorizontalScroll, view.ShowVerticalScroll, view.ShowSheetTabs = nil, nil, nil
+	if window.HideHorizontalScroll {
+		view.ShowHorizontalScroll = boolPtr(false)
+	}
+	if window.HideVerticalScroll {
+		view.ShowVerticalScroll = boolPtr(false)
+	}
+	if window.HideSheetTabs {
+		view.ShowSheetTabs = boolPtr(false)
+	}
+	return nil
+}
+
+// GetWorkbookWindow provides a function to get the position, size and the
+// display settings of the workbook window. The scroll bars and sheet tabs are
+// shown by default.
+func (f *File) GetWorkbookWindow() (WorkbookWindow, error) {
+	var window WorkbookWindow
+	wb := f.workbookReader()
+	if wb.BookViews == nil || len(wb.BookViews.WorkBookView) == 0 {
+		return window, nil
+	}
+	var err error
+	view := wb.BookViews.WorkBookView[0]
+	if view.XWindow != "" {
+		if window.XWindow, err = strconv.Atoi(view.XWindow); err != nil {
+			return window, err
+		}
+	}
+	if view.YWindow != "" {
+		if window.YWindow, err = strconv.Atoi(view.YWindow); err != nil {
+			return window, err
+		}
+	}
+	window.WindowWidth, window.WindowHeight = view.WindowWidth, view.WindowHeight
+	window.TabRatio, window.Minimized = view.TabRatio, view.Minimized
+	if view.ShowHorizontalScroll != nil {
+		window.HideHorizontalScroll = !*view.ShowHorizontalScroll
+	}
+	if view.ShowVerticalScroll != nil {
+		window.HideVerticalScroll = !*view.ShowVerticalScroll
+	}
+	if view.ShowSheetTabs != nil {
+		window.HideSheetTabs = !*view.ShowSheetTabs
+	}
+	return window, err
+}
+
+// WorkbookProtection directly maps the settings of the workbook protection.
+// The LockStructure prevents users from adding, deleting, moving, renaming,
+// hiding or unhiding the worksheets, the LockWindows prevents users from
+// moving, resizing or closing the workbook window, and the LockRevision
+// prevents users from removing the change tracking of the shared workbook.
+// The optional field AlgorithmName specified hash algorithm of the password,
+// support XOR, MD4, MD5, SHA-1, SHA-256, SHA-384, and SHA-512 currently, if
+// no hash algorithm specified, will be using the XOR algorithm as default.
+type WorkbookProtection struct {
+	AlgorithmName string
+	Password      string
+	LockStructure bool
+	LockWindows   bool
+	LockRevision  bool
+}
+
+// ProtectWorkbook provides a function to prevent other users from changing
+// the structure or the windows of the workbook, the contents of the cells
+// can still be edited unless the worksheets are protected. If the settings
+// is nil, the structure of the workbook will be locked without password. For
+// example, lock the structure of the workbook with password:
+//
+//	err := f.ProtectWorkbook(&excelize.WorkbookProtection{
+//	    AlgorithmName: "SHA-512",
+//	    Password:      "password",
+//	    LockStructure: true,
+//	})
+func (f *File) ProtectWorkbook(settings *WorkbookProtection) error {
+	if settings == nil {
+		settings = &WorkbookProtection{LockStructure: true}
+	}
+	protection := &xlsxWorkbookProtection{
+		LockStructure: settings.LockStructure,
+		LockWindows:   settings.LockWindows,
+		LockRevision:  settings.LockRevision,
+	}
+	if settings.Password != "" {
+		if settings.AlgorithmName == "" || settings.AlgorithmName == "XOR" {
+			protection.WorkbookPassword = genSheetPasswd(settings.Password)
+		} else {
+			hashValue, saltValue, err := genISOPasswdHash(settings.Password, settings.AlgorithmName, "", int(sheetProtectionSpinCount))
+			if err != nil {
+				return err
+			}
+			protection.WorkbookAlgorithmName = settings.AlgorithmName
+			protection.WorkbookSaltValue = saltValue
+			protection.WorkbookHashValue = hashValue
+			protection.WorkbookSpinCount = int(sheetProtectionSpinCount)
+		}
+	}
+	f.workbookReader().WorkbookProtection = protection
+	return nil
+}
+
+// UnprotectWorkbook provides a function to remove protection for the
+// workbook, specified the optional password parameter to remove workbook
+// protection with password verification.
+func (f *File) UnprotectWorkbook(password ...string) error {
+	wb := f.workbookReader()
+	// password verification
+	if len(password) > 0 {
+		if wb.WorkbookProtection == nil {
+			return ErrUnprotectWorkbook
+		}
+		if wb.WorkbookProtection.WorkbookAlgorithmName == "" && wb.WorkbookProtection.WorkbookPassword != genSheetPasswd(password[0]) {
+			return ErrUnprotectWorkbookPassword
+		}
+		if wb.WorkbookProtection.WorkbookAlgorithmName != "" {
+			// check with given salt value
+			hashValue, _, err := genISOPasswdHash(password[0], wb.WorkbookProtection.WorkbookAlgorithmName, wb.WorkbookProtection.WorkbookSaltValue, wb.WorkbookProtection.WorkbookSpinCount)
+			if err != nil {
+				return err
+			}
+			if wb.WorkbookProtection.WorkbookHashValue != hashValue {
+				return ErrUnprotectWorkbookPassword
+			}
+		}
+	}
+	wb.WorkbookProtection = nil
+	return nil
+}
+
+// GetWorkbookProtection provides a function to get the protection settings
+// of the workbook, returns nil if the workbook is not protected. The
+// password of the workbook protection is stored as the hash value, so the
+// Password of the returned settings is always empty, and the AlgorithmName
+// will be "XOR" if the password was hashed by the legacy XOR algorithm.
+func (f *File) GetWorkbookProtection() (*WorkbookProtection, error) {
+	wb := f.workbookReader()
+	if wb.WorkbookProtection == nil {
+		return nil, nil
+	}
+	settings := &WorkbookProtection{
+		AlgorithmName: wb.WorkbookProtection.WorkbookAlgorithmName,
+		LockStructure: wb.WorkbookProtection.LockStructure,
+		LockWindows:   wb.WorkbookProtection.LockWindows,
+		LockRevision:  wb.WorkbookProtection.LockRevision,
+	}
+	if settings.AlgorithmName == "" && wb.WorkbookProtection.WorkbookPassword != "" {
+		settings.AlgorithmName = "XOR"
+	}
+	return settings, nil
+}
+
+// GetConnections provides a function to get the external data connections
+// of the workbook, such as the Power Query connections. The connections part
+// and the query table parts of the workbook will be preserved when saving
+// the workbook. For example, print the names and commands of the
+// connections:
+//
+//	connections, err := f.GetConnections()
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	for _, conn := range connections {
+//	    fmt.Println(conn.Name, conn.Command)
+//	}
+func (f *File) GetConnections() ([]Connection, error) {
+	var connections []Connection
+	connectionsXML := f.getRelsTargetPath(f.getWorkbookPath(), SourceRelationshipConnections)
+	if connectionsXML == "" {
+		return connections, nil
+	}
+	var decodeConnections xlsxConnections
+	if err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(f.readXML(connectionsXML)))).
+		Decode(&decodeConnections); err != nil && err != io.EOF {
+		return connections, err
+	}
+	for _, conn := range decodeConnections.Connection {
+		connection := Connection{
+			ID:            conn.ID,
+			Name:          conn.Name,
+			Description:   conn.Description,
+			Type:          conn.Type,
+			RefreshOnLoad: conn.RefreshOnLoad,
+		}
+		if conn.DbPr != nil {
+			connection.Connection, connection.Command, connection.CommandType = conn.DbPr.Connection, conn.DbPr.Command, conn.DbPr.CommandType
+		}
+		connections = append(connections, connection)
+	}
+	return connections, nil
+}
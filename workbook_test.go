@@ -1,8 +1,12 @@
 package excelize
 
 import (
+	"encoding/xml"
 	"fmt"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -67,3 +71,190 @@ func TestWorkbookPr(t *testing.T) {
 	assert.NoError(t, f.GetWorkbookPrOptions(&filterPrivacy))
 	assert.Equal(t, false, bool(filterPrivacy))
 }
+
+func TestWorkbookDate1904(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetWorkbookPrOptions(Date1904(true)))
+	date := time.Date(2020, time.January, 15, 12, 0, 0, 0, time.UTC)
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", date))
+	raw, err := f.GetCellValue("Sheet1", "A1", Options{RawCellValue: true})
+	assert.NoError(t, err)
+	assert.Equal(t, "42383.5", raw)
+	assert.NoError(t, f.AddChart("Sheet1", "C1", `{"type":"col","series":[{"name":"Sheet1!$A$1","categories":"Sheet1!$A$1","values":"Sheet1!$A$1"}]}`))
+	path := filepath.Join("test", "TestWorkbookDate1904.xlsx")
+	assert.NoError(t, f.SaveAs(path))
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(path)
+	assert.NoError(t, err)
+	var date1904 Date1904
+	assert.NoError(t, f.GetWorkbookPrOptions(&date1904))
+	assert.True(t, bool(date1904))
+	val, err := f.GetCellValue("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "1/15/20 12:00", val)
+	assert.Contains(t, string(f.readXML("xl/charts/chart1.xml")), `<date1904 val="1"></date1904>`)
+	assert.NoError(t, f.Close())
+}
+
+func TestWorkbookWindow(t *testing.T) {
+	f := NewFile()
+	window, err := f.GetWorkbookWindow()
+	assert.NoError(t, err)
+	assert.Equal(t, WorkbookWindow{WindowWidth: 14805, WindowHeight: 8010}, window)
+	expected := WorkbookWindow{
+		XWindow: -120, YWindow: 240, WindowWidth: 19200, WindowHeight: 10800,
+		TabRatio: 750, Minimized: true, HideHorizontalScroll: true, HideSheetTabs: true,
+	}
+	assert.NoError(t, f.SetWorkbookWindow(expected))
+	window, err = f.GetWorkbookWindow()
+	assert.NoError(t, err)
+	assert.Equal(t, expected, window)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestWorkbookWindow.xlsx")))
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(filepath.Join("test", "TestWorkbookWindow.xlsx"))
+	assert.NoError(t, err)
+	window, err = f.GetWorkbookWindow()
+	assert.NoError(t, err)
+	assert.Equal(t, expected, window)
+	// Test set workbook window with invalid size and tab ratio
+	assert.EqualError(t, f.SetWorkbookWindow(WorkbookWindow{WindowWidth: -1}), ErrParameterInvalid.Error())
+	assert.EqualError(t, f.SetWorkbookWindow(WorkbookWindow{TabRatio: 1001}), ErrParameterInvalid.Error())
+	// Test get workbook window with invalid window position
+	f.WorkBook.BookViews.WorkBookView[0].XWindow = "x"
+	_, err = f.GetWorkbookWindow()
+	assert.EqualError(t, err, `strconv.Atoi: parsing "x": invalid syntax`)
+	f.WorkBook.BookViews.WorkBookView[0].XWindow, f.WorkBook.BookViews.WorkBookView[0].YWindow = "", "y"
+	_, err = f.GetWorkbookWindow()
+	assert.EqualError(t, err, `strconv.Atoi: parsing "y": invalid syntax`)
+	assert.NoError(t, f.Close())
+	// Test get and set workbook window without book views
+	f = NewFile()
+	f.WorkBook.BookViews = nil
+	window, err = f.GetWorkbookWindow()
+	assert.NoError(t, err)
+	assert.Equal(t, WorkbookWindow{}, window)
+	// Test set workbook window with the zero value keeps the scroll bars and sheet tabs shown
+	assert.NoError(t, f.SetWorkbookWindow(WorkbookWindow{WindowWidth: 100}))
+	view := f.WorkBook.BookViews.WorkBookView[0]
+	assert.Equal(t, 100, view.WindowWidth)
+	assert.Nil(t, view.ShowHorizontalScroll)
+	assert.Nil(t, view.ShowVerticalScroll)
+	assert.Nil(t, view.ShowSheetTabs)
+}
+
+func TestProtectWorkbook(t *testing.T) {
+	f := NewFile()
+	settings, err := f.GetWorkbookProtection()
+	assert.NoError(t, err)
+	assert.Nil(t, settings)
+	assert.NoError(t, f.ProtectWorkbook(nil))
+	settings, err = f.GetWorkbookProtection()
+	assert.NoError(t, err)
+	assert.Equal(t, &WorkbookProtection{LockStructure: true}, settings)
+	assert.NoError(t, f.UnprotectWorkbook())
+	settings, err = f.GetWorkbookProtection()
+	assert.NoError(t, err)
+	assert.Nil(t, settings)
+	// Test protect workbook with the XOR password
+	assert.NoError(t, f.ProtectWorkbook(&WorkbookProtection{Password: "password", LockStructure: true, LockWindows: true}))
+	assert.Equal(t, "83AF", f.WorkBook.WorkbookProtection.WorkbookPassword)
+	settings, err = f.GetWorkbookProtection()
+	assert.NoError(t, err)
+	assert.Equal(t, &WorkbookProtection{AlgorithmName: "XOR", LockStructure: true, LockWindows: true}, settings)
+	assert.Equal(t, ErrUnprotectWorkbookPassword, f.UnprotectWorkbook("wrong"))
+	assert.NoError(t, f.UnprotectWorkbook("password"))
+	assert.Equal(t, ErrUnprotectWorkbook, f.UnprotectWorkbook("password"))
+	// Test protect workbook with the hashed password
+	assert.NoError(t, f.ProtectWorkbook(&WorkbookProtection{AlgorithmName: "SHA-512", Password: "password", LockRevision: true}))
+	buf, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+	f, err = OpenReader(buf)
+	assert.NoError(t, err)
+	assert.Equal(t, int(sheetProtectionSpinCount), f.WorkBook.WorkbookProtection.WorkbookSpinCount)
+	settings, err = f.GetWorkbookProtection()
+	assert.NoError(t, err)
+	assert.Equal(t, &WorkbookProtection{AlgorithmName: "SHA-512", LockRevision: true}, settings)
+	assert.Equal(t, ErrUnprotectWorkbookPassword, f.UnprotectWorkbook("wrong"))
+	f.WorkBook.WorkbookProtection.WorkbookAlgorithmName = "RIPEMD-160"
+	assert.Equal(t, ErrUnsupportedHashAlgorithm, f.UnprotectWorkbook("password"))
+	f.WorkBook.WorkbookProtection.WorkbookAlgorithmName = "SHA-512"
+	assert.NoError(t, f.UnprotectWorkbook("password"))
+	// Test protect workbook with invalid parameters
+	assert.Equal(t, ErrUnsupportedHashAlgorithm, f.ProtectWorkbook(&WorkbookProtection{AlgorithmName: "RIPEMD-160", Password: "password"}))
+	assert.Equal(t, ErrPasswordLengthInvalid, f.ProtectWorkbook(&WorkbookProtection{AlgorithmName: "SHA-512", Password: strings.Repeat("s", MaxFieldLength+1)}))
+	assert.Nil(t, f.WorkBook.WorkbookProtection)
+}
+
+func TestGetConnections(t *testing.T) {
+	f := NewFile()
+	connections, err := f.GetConnections()
+	assert.NoError(t, err)
+	assert.Empty(t, connections)
+
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{"Name", "Qty"}))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A2", &[]interface{}{"Apple", 1}))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A3", &[]interface{}{"Banana", 2}))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A4", &[]interface{}{"Cherry", 3}))
+	assert.NoError(t, f.AddTable("Sheet1", "A1", "B4", `{"table_name":"Query1"}`))
+	table, err := f.tableReader("xl/tables/table1.xml")
+	assert.NoError(t, err)
+	table.TableType, table.ConnectionID = "queryTable", 1
+	for idx, col := range table.TableColumns.TableColumn {
+		col.QueryTableFieldID = idx + 1
+	}
+	output, err := xml.Marshal(table)
+	assert.NoError(t, err)
+	f.saveFileList("xl/tables/table1.xml", output)
+	f.addRels("xl/tables/_rels/table1.xml.rels", SourceRelationshipQueryTable, "../queryTables/queryTable1.xml", "")
+	f.saveFileList("xl/queryTables/queryTable1.xml", []byte(`<queryTable xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" name="Query1" connectionId="1" autoFormatId="16" applyNumberFormats="0" applyBorderFormats="0" applyFontFormats="0" applyPatternFormats="0" applyAlignmentFormats="0" applyWidthHeightFormats="0"><queryTableRefresh nextId="3"><queryTableFields count="2"><queryTableField id="1" name="Name" tableColumnId="1"/><queryTableField id="2" name="Qty" tableColumnId="2"/></queryTableFields></queryTableRefresh></queryTable>`))
+	f.addRels(f.getWorkbookRelsPath(), SourceRelationshipConnections, "connections.xml", "")
+	f.saveFileList("xl/connections.xml", []byte(`<connections xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><connection id="1" keepAlive="1" name="Query - Query1" description="Connection to the 'Query1' query in the workbook." type="5" refreshedVersion="7" background="1" saveData="1"><dbPr connection="Provider=Microsoft.Mashup.OleDb.1;Data Source=$Workbook$;Location=Query1;Extended Properties=&quot;&quot;" command="SELECT * FROM [Query1]"/></connection></connections>`))
+	content := f.contentTypesReader()
+	content.Overrides = append(content.Overrides,
+		xlsxOverride{PartName: "/xl/connections.xml", ContentType: "application/vnd.openxmlformats-officedocument.spreadsheetml.connections+xml"},
+		xlsxOverride{PartName: "/xl/queryTables/queryTable1.xml", ContentType: "application/vnd.openxmlformats-officedocument.spreadsheetml.queryTable+xml"},
+	)
+	path := filepath.Join("test", "TestGetConnections.xlsx")
+	assert.NoError(t, f.SaveAs(path))
+	assert.NoError(t, f.Close())
+
+	// Test the connections and query tables will be preserved on round-trip
+	f, err = OpenFile(path)
+	assert.NoError(t, err)
+	expected := []Connection{{
+		ID:          1,
+		Name:        "Query - Query1",
+		Description: "Connection to the 'Query1' query in the workbook.",
+		Type:        5,
+		Connection:  `Provider=Microsoft.Mashup.OleDb.1;Data Source=$Workbook$;Location=Query1;Extended Properties=""`,
+		Command:     "SELECT * FROM [Query1]",
+	}}
+	connections, err = f.GetConnections()
+	assert.NoError(t, err)
+	assert.Equal(t, expected, connections)
+	assert.NoError(t, f.InsertRow("Sheet1", 3))
+	assert.NoError(t, f.SaveAs(path))
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(path)
+	assert.NoError(t, err)
+	connections, err = f.GetConnections()
+	assert.NoError(t, err)
+	assert.Equal(t, expected, connections)
+	table, err = f.tableReader("xl/tables/table1.xml")
+	assert.NoError(t, err)
+	assert.Equal(t, "A1:B5", table.Ref)
+	assert.Equal(t, "queryTable", table.TableType)
+	assert.Equal(t, 1, table.ConnectionID)
+	assert.Equal(t, 2, table.TableColumns.TableColumn[1].QueryTableFieldID)
+	assert.Contains(t, string(f.readXML("xl/queryTables/queryTable1.xml")), `name="Query1"`)
+	assert.Contains(t, string(f.readXML("xl/tables/_rels/table1.xml.rels")), "../queryTables/queryTable1.xml")
+
+	// Test get connections with unsupported charset
+	f.Pkg.Store("xl/connections.xml", MacintoshCyrillicCharset)
+	_, err = f.GetConnections()
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
@@ -22,6 +22,17 @@ type AppProperties struct {
 	LinksUpToDate     bool
 	HyperlinksChanged bool
 	AppVersion        string
+	FileVersion       *FileVersion
+}
+
+// FileVersion directly maps the version properties of the application which
+// accessed the workbook, these properties are stored in the workbook part
+// instead of the document application properties part.
+type FileVersion struct {
+	AppName      string
+	LastEdited   string
+	LowestEdited string
+	RupBuild     string
 }
 
 // xlsxProperties specifies to an OOXML document properties such as the
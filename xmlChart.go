@@ -324,22 +324,23 @@ type cPlotArea struct {
 
 // cCharts specifies the common element of the chart.
 type cCharts struct {
-	BarDir       *attrValString `xml:"barDir"`
-	BubbleScale  *attrValFloat  `xml:"bubbleScale"`
-	Grouping     *attrValString `xml:"grouping"`
-	RadarStyle   *attrValString `xml:"radarStyle"`
-	ScatterStyle *attrValString `xml:"scatterStyle"`
-	OfPieType    *attrValString `xml:"ofPieType"`
-	VaryColors   *attrValBool   `xml:"varyColors"`
-	Wireframe    *attrValBool   `xml:"wireframe"`
-	Ser          *[]cSer        `xml:"ser"`
-	SerLines     *attrValString `xml:"serLines"`
-	DLbls        *cDLbls        `xml:"dLbls"`
-	Shape        *attrValString `xml:"shape"`
-	HoleSize     *attrValInt    `xml:"holeSize"`
-	Smooth       *attrValBool   `xml:"smooth"`
-	Overlap      *attrValInt    `xml:"overlap"`
-	AxID         []*attrValInt  `xml:"axId"`
+	BarDir        *attrValString `xml:"barDir"`
+	BubbleScale   *attrValFloat  `xml:"bubbleScale"`
+	Grouping      *attrValString `xml:"grouping"`
+	RadarStyle    *attrValString `xml:"radarStyle"`
+	ScatterStyle  *attrValString `xml:"scatterStyle"`
+	OfPieType     *attrValString `xml:"ofPieType"`
+	VaryColors    *attrValBool   `xml:"varyColors"`
+	Wireframe     *attrValBool   `xml:"wireframe"`
+	Ser           *[]cSer        `xml:"ser"`
+	SerLines      *attrValString `xml:"serLines"`
+	DLbls         *cDLbls        `xml:"dLbls"`
+	Shape         *attrValString `xml:"shape"`
+	FirstSliceAng *attrValInt    `xml:"firstSliceAng"`
+	HoleSize      *attrValInt    `xml:"holeSize"`
+	Smooth        *attrValBool   `xml:"smooth"`
+	Overlap       *attrValInt    `xml:"overlap"`
+	AxID          []*attrValInt  `xml:"axId"`
 }
 
 // cAxs directly maps the catAx and valAx element.
@@ -393,21 +394,46 @@ type cNumFmt struct {
 // cSer directly maps the ser element. This element specifies a series on a
 // chart.
 type cSer struct {
-	IDx              *attrValInt  `xml:"idx"`
-	Order            *attrValInt  `xml:"order"`
-	Tx               *cTx         `xml:"tx"`
-	SpPr             *cSpPr       `xml:"spPr"`
-	DPt              []*cDPt      `xml:"dPt"`
-	DLbls            *cDLbls      `xml:"dLbls"`
-	Marker           *cMarker     `xml:"marker"`
-	InvertIfNegative *attrValBool `xml:"invertIfNegative"`
-	Cat              *cCat        `xml:"cat"`
-	Val              *cVal        `xml:"val"`
-	XVal             *cCat        `xml:"xVal"`
-	YVal             *cVal        `xml:"yVal"`
-	Smooth           *attrValBool `xml:"smooth"`
-	BubbleSize       *cVal        `xml:"bubbleSize"`
-	Bubble3D         *attrValBool `xml:"bubble3D"`
+	IDx              *attrValInt   `xml:"idx"`
+	Order            *attrValInt   `xml:"order"`
+	Tx               *cTx          `xml:"tx"`
+	SpPr             *cSpPr        `xml:"spPr"`
+	Explosion        *attrValInt   `xml:"explosion"`
+	DPt              []*cDPt       `xml:"dPt"`
+	DLbls            *cDLbls       `xml:"dLbls"`
+	Marker           *cMarker      `xml:"marker"`
+	InvertIfNegative *attrValBool  `xml:"invertIfNegative"`
+	Trendline        []*cTrendline `xml:"trendline"`
+	ErrBars          *cErrBars     `xml:"errBars"`
+	Cat              *cCat         `xml:"cat"`
+	Val              *cVal         `xml:"val"`
+	XVal             *cCat         `xml:"xVal"`
+	YVal             *cVal         `xml:"yVal"`
+	Smooth           *attrValBool  `xml:"smooth"`
+	BubbleSize       *cVal         `xml:"bubbleSize"`
+	Bubble3D         *attrValBool  `xml:"bubble3D"`
+}
+
+// cTrendline (Trendline) directly maps the trendline element. This element
+// specifies a trendline of the series.
+type cTrendline struct {
+	Name          string         `xml:"name,omitempty"`
+	SpPr          *cSpPr         `xml:"spPr"`
+	TrendlineType *attrValString `xml:"trendlineType"`
+	Order         *attrValInt    `xml:"order"`
+	Period        *attrValInt    `xml:"period"`
+	DispRSqr      *attrValBool   `xml:"dispRSqr"`
+	DispEq        *attrValBool   `xml:"dispEq"`
+}
+
+// cErrBars (Error Bars) directly maps the errBars element. This element
+// specifies the error bars of the series.
+type cErrBars struct {
+	ErrDir     *attrValString `xml:"errDir"`
+	ErrBarType *attrValString `xml:"errBarType"`
+	ErrValType *attrValString `xml:"errValType"`
+	NoEndCap   *attrValBool   `xml:"noEndCap"`
+	Val        *attrValFloat  `xml:"val"`
 }
 
 // cMarker (Marker) directly maps the marker element. This element specifies a
@@ -421,9 +447,11 @@ type cMarker struct {
 // cDPt (Data Point) directly maps the dPt element. This element specifies a
 // single data point.
 type cDPt struct {
-	IDx      *attrValInt  `xml:"idx"`
-	Bubble3D *attrValBool `xml:"bubble3D"`
-	SpPr     *cSpPr       `xml:"spPr"`
+	IDx       *attrValInt  `xml:"idx"`
+	Marker    *cMarker     `xml:"marker"`
+	Bubble3D  *attrValBool `xml:"bubble3D"`
+	Explosion *attrValInt  `xml:"explosion"`
+	SpPr      *cSpPr       `xml:"spPr"`
 }
 
 // cCat (Category Axis Data) directly maps the cat element. This element
@@ -528,6 +556,7 @@ type formatChartAxis struct {
 	MinorTickMark       string   `json:"minor_tick_mark"`
 	MinorUnitType       string   `json:"minor_unit_type"`
 	MajorUnit           float64  `json:"major_unit"`
+	MinorUnit           float64  `json:"minor_unit"`
 	MajorUnitType       string   `json:"major_unit_type"`
 	TickLabelSkip       int      `json:"tick_label_skip"`
 	DisplayUnits        string   `json:"display_units"`
@@ -596,11 +625,12 @@ type formatChart struct {
 		} `json:"fill"`
 		Layout formatLayout `json:"layout"`
 	} `json:"plotarea"`
-	ShowBlanksAs   string `json:"show_blanks_as"`
-	ShowHiddenData bool   `json:"show_hidden_data"`
-	SetRotation    int    `json:"set_rotation"`
-	HoleSize       int    `json:"hole_size"`
-	order          int
+	ShowBlanksAs    string `json:"show_blanks_as"`
+	ShowHiddenData  bool   `json:"show_hidden_data"`
+	SetRotation     int    `json:"set_rotation"`
+	HoleSize        int    `json:"hole_size"`
+	FirstSliceAngle int    `json:"first_slice_angle"`
+	order           int
 }
 
 // formatChartLegend directly maps the format settings of the chart legend.
@@ -637,6 +667,38 @@ type formatChartSeries struct {
 			None  bool   `json:"none"`
 		} `json:"fill"`
 	} `json:"marker"`
+	Trendline  *formatChartTrendline  `json:"trendline"`
+	ErrorBars  *formatChartErrorBars  `json:"error_bars"`
+	DataPoints []formatChartDataPoint `json:"data_points"`
+	Explosion  int                    `json:"explosion"`
+}
+
+// formatChartDataPoint directly maps the format settings of the data point of
+// the chart series.
+type formatChartDataPoint struct {
+	Index     int    `json:"index"`
+	Color     string `json:"color"`
+	Explosion int    `json:"explosion"`
+}
+
+// formatChartTrendline directly maps the format settings of the trendline of
+// the chart series.
+type formatChartTrendline struct {
+	Type            string `json:"type"`
+	Name            string `json:"name"`
+	Order           int    `json:"order"`
+	Period          int    `json:"period"`
+	DisplayEquation bool   `json:"display_equation"`
+	DisplayRSquared bool   `json:"display_r_squared"`
+}
+
+// formatChartErrorBars directly maps the format settings of the error bars of
+// the chart series.
+type formatChartErrorBars struct {
+	Type      string  `json:"type"`
+	Direction string  `json:"direction"`
+	Value     float64 `json:"value"`
+	NoEndCap  bool    `json:"no_end_cap"`
 }
 
 // formatChartTitle directly maps the format settings of the chart title.
@@ -654,3 +716,84 @@ type formatLayout struct {
 	Width  float64 `json:"width"`
 	Height float64 `json:"height"`
 }
+
+// decodeChartGraphicFrame defined the structure used to parse the cell
+// anchor of the chart in the drawing part.
+type decodeChartGraphicFrame struct {
+	From         *decodeFrom `xml:"from"`
+	GraphicFrame struct {
+		Graphic struct {
+			GraphicData struct {
+				Chart *struct {
+					RID string `xml:"id,attr"`
+				} `xml:"chart"`
+			} `xml:"graphicData"`
+		} `xml:"graphic"`
+	} `xml:"graphicFrame"`
+}
+
+// decodeChartSpace defined the structure used to parse the chart groups and
+// the series of the chart part.
+type decodeChartSpace struct {
+	Chart struct {
+		PlotArea struct {
+			Charts []decodeChartGroup `xml:",any"`
+		} `xml:"plotArea"`
+	} `xml:"chart"`
+}
+
+// decodeChartGroup defined the structure used to parse the chart group in the
+// plot area, such as barChart and lineChart.
+type decodeChartGroup struct {
+	XMLName   xml.Name
+	BarDir    *attrValString   `xml:"barDir"`
+	Grouping  *attrValString   `xml:"grouping"`
+	OfPieType *attrValString   `xml:"ofPieType"`
+	Shape     *attrValString   `xml:"shape"`
+	Wireframe *attrValBool     `xml:"wireframe"`
+	Ser       []decodeChartSer `xml:"ser"`
+}
+
+// decodeChartSer defined the structure used to parse the series of the
+// chart.
+type decodeChartSer struct {
+	Tx *struct {
+		StrRef *decodeChartRef `xml:"strRef"`
+		V      string          `xml:"v"`
+	} `xml:"tx"`
+	Cat        *decodeChartDataSource `xml:"cat"`
+	Val        *decodeChartDataSource `xml:"val"`
+	XVal       *decodeChartDataSource `xml:"xVal"`
+	YVal       *decodeChartDataSource `xml:"yVal"`
+	BubbleSize *decodeChartDataSource `xml:"bubbleSize"`
+	Bubble3D   *attrValBool           `xml:"bubble3D"`
+}
+
+// decodeChartDataSource defined the structure used to parse the data source
+// of the chart series.
+type decodeChartDataSource struct {
+	StrRef         *decodeChartRef `xml:"strRef"`
+	NumRef         *decodeChartRef `xml:"numRef"`
+	MultiLvlStrRef *decodeChartRef `xml:"multiLvlStrRef"`
+}
+
+// decodeChartRef defined the structure used to parse the formula of the
+// reference of the chart data source.
+type decodeChartRef struct {
+	F string `xml:"f"`
+}
+
+// ChartSeries directly maps the series of the chart read from the worksheet.
+// ChartType specifies the type of the chart which the series belongs to, such
+// as "col" and "line". Name specifies the reference or the text of the
+// series name. Categories and Values specifies the references of the
+// categories and values of the series, which are the X and Y values for the
+// scatter and bubble chart. Sizes specifies the reference of the bubble sizes
+// of the bubble chart.
+type ChartSeries struct {
+	ChartType  string
+	Name       string
+	Categories string
+	Values     string
+	Sizes      string
+}
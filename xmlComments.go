@@ -72,10 +72,75 @@ type xlsxPhoneticRun struct {
 	T  string `xml:"t"`
 }
 
+// xlsxThreadedComments directly maps the ThreadedComments element from the
+// namespace http://schemas.microsoft.com/office/spreadsheetml/2018/threadedcomments.
+// This element contains a list of threaded comments of the worksheet. A
+// thread is a root comment with the replies, which are the comments that
+// reference the ID of the root comment as the parent ID.
+type xlsxThreadedComments struct {
+	XMLName         xml.Name              `xml:"http://schemas.microsoft.com/office/spreadsheetml/2018/threadedcomments ThreadedComments"`
+	ThreadedComment []xlsxThreadedComment `xml:"threadedComment"`
+	ExtLst          *xlsxInnerXML         `xml:"extLst"`
+}
+
+// xlsxThreadedComment directly maps the threadedComment element. This element
+// represents a single comment or reply in a thread of comments.
+type xlsxThreadedComment struct {
+	Ref      string        `xml:"ref,attr,omitempty"`
+	DT       string        `xml:"dT,attr,omitempty"`
+	PersonID string        `xml:"personId,attr"`
+	ID       string        `xml:"id,attr"`
+	ParentID string        `xml:"parentId,attr,omitempty"`
+	Done     *bool         `xml:"done,attr"`
+	Text     string        `xml:"text"`
+	Mentions *xlsxInnerXML `xml:"mentions"`
+	ExtLst   *xlsxInnerXML `xml:"extLst"`
+}
+
+// xlsxPersonList directly maps the personList element from the namespace
+// http://schemas.microsoft.com/office/spreadsheetml/2018/threadedcomments.
+// This element contains the list of persons who authored the threaded
+// comments of the workbook.
+type xlsxPersonList struct {
+	XMLName xml.Name      `xml:"http://schemas.microsoft.com/office/spreadsheetml/2018/threadedcomments personList"`
+	Person  []xlsxPerson  `xml:"person"`
+	ExtLst  *xlsxInnerXML `xml:"extLst"`
+}
+
+// xlsxPerson directly maps the person element. This element represents an
+// author of the threaded comments.
+type xlsxPerson struct {
+	DisplayName string        `xml:"displayName,attr"`
+	ID          string        `xml:"id,attr"`
+	UserID      string        `xml:"userId,attr,omitempty"`
+	ProviderID  string        `xml:"providerId,attr,omitempty"`
+	ExtLst      *xlsxInnerXML `xml:"extLst"`
+}
+
 // formatComment directly maps the format settings of the comment.
 type formatComment struct {
-	Author string `json:"author"`
-	Text   string `json:"text"`
+	Author          string         `json:"author"`
+	Text            string         `json:"text"`
+	NoLegacyDrawing bool           `json:"no_legacy_drawing"`
+	Anchor          *CommentAnchor `json:"anchor"`
+	AutoSize        bool           `json:"auto_size"`
+	MaxWidth        int            `json:"max_width"`
+	// threaded specifies the comment is the legacy comment of a threaded
+	// comment, which doesn't begin with the author.
+	threaded bool
+}
+
+// CommentAnchor directly maps the anchor of the comment box. The From and To
+// specifies the cells where the top left and bottom right corners of the
+// comment box are located, and the offsets specifies the distance in pixels
+// from the top left corner of the cells to the corners of the comment box.
+type CommentAnchor struct {
+	From        string `json:"from"`
+	FromOffsetX int    `json:"from_offset_x"`
+	FromOffsetY int    `json:"from_offset_y"`
+	To          string `json:"to"`
+	ToOffsetX   int    `json:"to_offset_x"`
+	ToOffsetY   int    `json:"to_offset_y"`
 }
 
 // Comment directly maps the comment information.
@@ -85,3 +150,15 @@ type Comment struct {
 	Ref      string `json:"ref"`
 	Text     string `json:"text"`
 }
+
+// ThreadedComment directly maps the threaded comment information. The
+// ParentID is empty for the comment which starts a thread, and is the ID of
+// that comment for the replies in the thread.
+type ThreadedComment struct {
+	ID       string `json:"id"`
+	ParentID string `json:"parent_id"`
+	Ref      string `json:"ref"`
+	Author   string `json:"author"`
+	Text     string `json:"text"`
+	Date     string `json:"date"`
+}
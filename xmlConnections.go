@@ -0,0 +1,57 @@
+// Copyright 2016 - 2022 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to and
+// read from XLAM / XLSM / XLSX / XLTM / XLTX files. Supports reading and
+// writing spreadsheet documents generated by Microsoft Excel™ 2007 and later.
+// Supports complex components by high compatibility, and provided streaming
+// API for generating or reading data from a worksheet with huge amounts of
+// data. This library needs Go version 1.15 or later.
+
+package excelize
+
+import "encoding/xml"
+
+// xlsxConnections directly maps the connections element. This element
+// specifies the collection of the external data connections of the
+// workbook.
+type xlsxConnections struct {
+	XMLName    xml.Name         `xml:"http://schemas.openxmlformats.org/spreadsheetml/2006/main connections"`
+	Connection []xlsxConnection `xml:"connection"`
+}
+
+// xlsxConnection directly maps the connection element. This element
+// specifies the properties of an external data connection, such as the
+// connection information and the command text for the database connection.
+type xlsxConnection struct {
+	ID            int       `xml:"id,attr"`
+	Name          string    `xml:"name,attr,omitempty"`
+	Description   string    `xml:"description,attr,omitempty"`
+	Type          int       `xml:"type,attr,omitempty"`
+	RefreshOnLoad bool      `xml:"refreshOnLoad,attr,omitempty"`
+	DbPr          *xlsxDbPr `xml:"dbPr"`
+}
+
+// xlsxDbPr directly maps the dbPr element. This element specifies the
+// properties of the database connection.
+type xlsxDbPr struct {
+	Connection  string `xml:"connection,attr"`
+	Command     string `xml:"command,attr,omitempty"`
+	CommandType int    `xml:"commandType,attr,omitempty"`
+}
+
+// Connection directly maps the settings of the external data connection of
+// the workbook, such as the Power Query connection. The Connection and
+// Command specifies the connection string and the command text of the
+// database connection.
+type Connection struct {
+	ID            int
+	Name          string
+	Description   string
+	Type          int
+	RefreshOnLoad bool
+	Connection    string
+	Command       string
+	CommandType   int
+}
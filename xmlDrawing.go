@@ -50,8 +50,20 @@ const (
 	SourceRelationshipDialogsheet                = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/dialogsheet"
 	SourceRelationshipPivotTable                 = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/pivotTable"
 	SourceRelationshipPivotCache                 = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/pivotCacheDefinition"
+	SourceRelationshipPivotCacheRecords          = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/pivotCacheRecords"
 	SourceRelationshipSharedStrings              = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/sharedStrings"
 	SourceRelationshipVBAProject                 = "http://schemas.microsoft.com/office/2006/relationships/vbaProject"
+	SourceRelationshipThreadedComment            = "http://schemas.microsoft.com/office/2017/10/relationships/threadedComment"
+	SourceRelationshipPerson                     = "http://schemas.microsoft.com/office/2017/10/relationships/person"
+	SourceRelationshipSheetMetadata              = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/sheetMetadata"
+	SourceRelationshipRichValue                  = "http://schemas.microsoft.com/office/2017/06/relationships/rdRichValue"
+	SourceRelationshipRichValueStructure         = "http://schemas.microsoft.com/office/2017/06/relationships/rdRichValueStructure"
+	SourceRelationshipConnections                = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/connections"
+	SourceRelationshipQueryTable                 = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/queryTable"
+	SourceRelationshipSlicer                     = "http://schemas.microsoft.com/office/2007/relationships/slicer"
+	SourceRelationshipSlicerCache                = "http://schemas.microsoft.com/office/2007/relationships/slicerCache"
+	NameSpaceDrawingMLSlicer                     = "http://schemas.microsoft.com/office/drawing/2010/slicer"
+	NameSpaceDrawingMLSlicerX15                  = "http://schemas.microsoft.com/office/drawing/2012/slicer"
 	NameSpaceXML                                 = "http://www.w3.org/XML/1998/namespace"
 	NameSpaceXMLSchemaInstance                   = "http://www.w3.org/2001/XMLSchema-instance"
 	StrictSourceRelationship                     = "http://purl.oclc.org/ooxml/officeDocument/relationships"
@@ -73,12 +85,17 @@ const (
 	ContentTypeSpreadSheetMLChartsheet           = "application/vnd.openxmlformats-officedocument.spreadsheetml.chartsheet+xml"
 	ContentTypeSpreadSheetMLComments             = "application/vnd.openxmlformats-officedocument.spreadsheetml.comments+xml"
 	ContentTypeSpreadSheetMLPivotCacheDefinition = "application/vnd.openxmlformats-officedocument.spreadsheetml.pivotCacheDefinition+xml"
+	ContentTypeSpreadSheetMLPivotCacheRecords    = "application/vnd.openxmlformats-officedocument.spreadsheetml.pivotCacheRecords+xml"
 	ContentTypeSpreadSheetMLPivotTable           = "application/vnd.openxmlformats-officedocument.spreadsheetml.pivotTable+xml"
 	ContentTypeSpreadSheetMLSharedStrings        = "application/vnd.openxmlformats-officedocument.spreadsheetml.sharedStrings+xml"
 	ContentTypeSpreadSheetMLTable                = "application/vnd.openxmlformats-officedocument.spreadsheetml.table+xml"
 	ContentTypeSpreadSheetMLWorksheet            = "application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"
 	ContentTypeVBA                               = "application/vnd.ms-office.vbaProject"
+	ContentTypeThreadedComments                  = "application/vnd.ms-excel.threadedcomments+xml"
+	ContentTypePerson                            = "application/vnd.ms-excel.person+xml"
 	ContentTypeVML                               = "application/vnd.openxmlformats-officedocument.vmlDrawing"
+	ContentTypeSlicer                            = "application/vnd.ms-excel.slicer+xml"
+	ContentTypeSlicerCache                       = "application/vnd.ms-excel.slicerCache+xml"
 	// ExtURIConditionalFormattings is the extLst child element
 	// ([ISO/IEC29500-1:2016] section 18.2.10) of the worksheet element
 	// ([ISO/IEC29500-1:2016] section 18.3.1.99) is extended by the addition of
@@ -89,6 +106,9 @@ const (
 	ExtURISlicerListX14          = "{A8765BA9-456A-4DAB-B4F3-ACF838C121DE}"
 	ExtURISlicerCachesListX14    = "{BBE1A952-AA13-448e-AADC-164F8A28A991}"
 	ExtURISlicerListX15          = "{3A4CF648-6AED-40f4-86FF-DC5316D8AED3}"
+	ExtURISlicerCachesListX15    = "{46BE6895-7355-4a93-B00E-2C351335B9C9}"
+	ExtURITableSlicerCache       = "{2F2917AC-EB37-4324-AD4E-5DD8C200BD13}"
+	ExtURIPivotCacheDefinition   = "{725AE2AE-9491-48be-B2B4-4EB974FC3084}"
 	ExtURIProtectedRanges        = "{FC87AEE6-9EDD-4A0A-B7FB-166176984837}"
 	ExtURIIgnoredErrors          = "{01252117-D84E-4E92-8308-4BE1C098FCBB}"
 	ExtURIWebExtensions          = "{F7C9EE02-42E1-4005-9D12-6889AFFD525C}"
@@ -104,16 +124,20 @@ const (
 	MaxFontFamilyLength  = 31
 	MaxFontSize          = 409
 	MaxFilePathLength    = 207
+	MaxSheetNameLength   = 31
 	MaxFieldLength       = 255
 	MaxColumnWidth       = 255
 	MaxRowHeight         = 409
 	MaxCellStyles        = 64000
+	MaxCellIndent        = 250
 	MinFontSize          = 1
 	TotalRows            = 1048576
 	MinColumns           = 1
 	MaxColumns           = 16384
 	TotalSheetHyperlinks = 65529
 	TotalCellChars       = 32767
+	MaxRenderPixels      = 1 << 26
+	MaxRenderCells       = 1 << 20
 	// pivotTableVersion should be greater than 3. One or more of the
 	// PivotTables chosen are created in a version of Excel earlier than
 	// Excel 2007 or in compatibility mode. Slicer can only be used with
@@ -392,8 +416,9 @@ type xlsxGraphic struct {
 // document. This graphic object is provided entirely by the document authors
 // who choose to persist this data within the document.
 type xlsxGraphicData struct {
-	URI   string     `xml:"uri,attr"`
-	Chart *xlsxChart `xml:"c:chart,omitempty"`
+	URI    string     `xml:"uri,attr"`
+	Chart  *xlsxChart `xml:"c:chart,omitempty"`
+	Slicer *xlsxSle   `xml:"sle:slicer,omitempty"`
 }
 
 // xlsxChart (Chart) directly maps the c:chart element.
@@ -403,6 +428,13 @@ type xlsxChart struct {
 	R   string `xml:"xmlns:r,attr"`
 }
 
+// xlsxSle directly maps the sle:slicer element, which references the slicer
+// by name.
+type xlsxSle struct {
+	Sle  string `xml:"xmlns:sle,attr"`
+	Name string `xml:"name,attr"`
+}
+
 // xdrSp (Shape) directly maps the xdr:sp element. This element specifies the
 // existence of a single shape. A shape can either be a preset or a custom
 // geometry, defined using the SpreadsheetDrawingML framework. In addition to a
@@ -419,6 +451,42 @@ type xdrSp struct {
 	TxBody   *xdrTxBody `xml:"xdr:txBody"`
 }
 
+// xdrGrpSp (Group Shape) directly maps the xdr:grpSp element. This element
+// specifies a group shape that represents many shapes grouped together. The
+// shapes in the group are positioned in the child coordinate space which
+// mapped to the position of the group shape.
+type xdrGrpSp struct {
+	XMLName   xml.Name     `xml:"xdr:grpSp"`
+	NvGrpSpPr xdrNvGrpSpPr `xml:"xdr:nvGrpSpPr"`
+	GrpSpPr   xdrGrpSpPr   `xml:"xdr:grpSpPr"`
+	Content   string       `xml:",innerxml"`
+}
+
+// xdrNvGrpSpPr (Non-Visual Properties for a Group Shape) directly maps the
+// xdr:nvGrpSpPr element. This element specifies all non-visual properties
+// for a group shape.
+type xdrNvGrpSpPr struct {
+	CNvPr      *xlsxCNvPr `xml:"xdr:cNvPr"`
+	CNvGrpSpPr string     `xml:"xdr:cNvGrpSpPr"`
+}
+
+// xdrGrpSpPr (Group Shape Properties) directly maps the xdr:grpSpPr element.
+// This element specifies the properties that are to be common across all of
+// the shapes within the group shape.
+type xdrGrpSpPr struct {
+	Xfrm xlsxGroupXfrm `xml:"a:xfrm"`
+}
+
+// xlsxGroupXfrm directly maps the xfrm (2D Transform for Grouped Objects).
+// This element specifies the position and size of the group shape, and the
+// position and size of the child coordinate space of the group.
+type xlsxGroupXfrm struct {
+	Off   xlsxOff `xml:"a:off"`
+	Ext   xlsxExt `xml:"a:ext"`
+	ChOff xlsxOff `xml:"a:chOff"`
+	ChExt xlsxExt `xml:"a:chExt"`
+}
+
 // xdrNvSpPr (Non-Visual Properties for a Shape) directly maps the xdr:nvSpPr
 // element. This element specifies all non-visual properties for a shape. This
 // element is a container for the non-visual identification properties, shape
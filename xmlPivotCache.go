@@ -120,26 +120,26 @@ type xlsxCacheField struct {
 // those values that are referenced in multiple places across all the
 // PivotTable parts.
 type xlsxSharedItems struct {
-	ContainsSemiMixedTypes bool          `xml:"containsSemiMixedTypes,attr,omitempty"`
-	ContainsNonDate        bool          `xml:"containsNonDate,attr,omitempty"`
-	ContainsDate           bool          `xml:"containsDate,attr,omitempty"`
-	ContainsString         bool          `xml:"containsString,attr,omitempty"`
-	ContainsBlank          bool          `xml:"containsBlank,attr,omitempty"`
-	ContainsMixedTypes     bool          `xml:"containsMixedTypes,attr,omitempty"`
-	ContainsNumber         bool          `xml:"containsNumber,attr,omitempty"`
-	ContainsInteger        bool          `xml:"containsInteger,attr,omitempty"`
-	MinValue               float64       `xml:"minValue,attr,omitempty"`
-	MaxValue               float64       `xml:"maxValue,attr,omitempty"`
-	MinDate                string        `xml:"minDate,attr,omitempty"`
-	MaxDate                string        `xml:"maxDate,attr,omitempty"`
-	Count                  int           `xml:"count,attr"`
-	LongText               bool          `xml:"longText,attr,omitempty"`
-	M                      *xlsxMissing  `xml:"m"`
-	N                      *xlsxNumber   `xml:"n"`
-	B                      *xlsxBoolean  `xml:"b"`
-	E                      *xlsxError    `xml:"e"`
-	S                      *xlsxString   `xml:"s"`
-	D                      *xlsxDateTime `xml:"d"`
+	ContainsSemiMixedTypes *bool          `xml:"containsSemiMixedTypes,attr"`
+	ContainsNonDate        *bool          `xml:"containsNonDate,attr"`
+	ContainsDate           bool           `xml:"containsDate,attr,omitempty"`
+	ContainsString         *bool          `xml:"containsString,attr"`
+	ContainsBlank          bool           `xml:"containsBlank,attr,omitempty"`
+	ContainsMixedTypes     bool           `xml:"containsMixedTypes,attr,omitempty"`
+	ContainsNumber         bool           `xml:"containsNumber,attr,omitempty"`
+	ContainsInteger        bool           `xml:"containsInteger,attr,omitempty"`
+	MinValue               float64        `xml:"minValue,attr,omitempty"`
+	MaxValue               float64        `xml:"maxValue,attr,omitempty"`
+	MinDate                string         `xml:"minDate,attr,omitempty"`
+	MaxDate                string         `xml:"maxDate,attr,omitempty"`
+	Count                  int            `xml:"count,attr"`
+	LongText               bool           `xml:"longText,attr,omitempty"`
+	M                      []xlsxMissing  `xml:"m"`
+	N                      []xlsxNumber   `xml:"n"`
+	B                      []xlsxBoolean  `xml:"b"`
+	E                      []xlsxError    `xml:"e"`
+	S                      []xlsxString   `xml:"s"`
+	D                      []xlsxDateTime `xml:"d"`
 }
 
 // xlsxMissing represents a value that was not specified.
@@ -226,3 +226,27 @@ type xlsxMeasureGroups struct{}
 
 // xlsxMaps represents the PivotTable OLAP measure group - Dimension maps.
 type xlsxMaps struct{}
+
+// xlsxPivotCacheRecords represents the pivotCacheRecords part. This part
+// contains the underlying data of the pivot cache, each record is a row of
+// the source data.
+type xlsxPivotCacheRecords struct {
+	XMLName xml.Name               `xml:"http://schemas.openxmlformats.org/spreadsheetml/2006/main pivotCacheRecords"`
+	Count   int                    `xml:"count,attr"`
+	R       []xlsxPivotCacheRecord `xml:"r"`
+	ExtLst  *xlsxExtLst            `xml:"extLst"`
+}
+
+// xlsxPivotCacheRecord represents a record in the pivot cache, the values of
+// the fields are stored in the order of the cache fields.
+type xlsxPivotCacheRecord struct {
+	Values []xlsxPivotCacheRecordValue `xml:",any"`
+}
+
+// xlsxPivotCacheRecordValue represents a value of the record in the pivot
+// cache, such as the index of the shared item (x), a number (n), a string
+// (s) or a missing value (m).
+type xlsxPivotCacheRecordValue struct {
+	XMLName xml.Name
+	V       string `xml:"v,attr,omitempty"`
+}
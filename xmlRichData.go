@@ -0,0 +1,162 @@
+// Copyright 2016 - 2022 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to and
+// read from XLAM / XLSM / XLSX / XLTM / XLTX files. Supports reading and
+// writing spreadsheet documents generated by Microsoft Excel™ 2007 and later.
+// Supports complex components by high compatibility, and provided streaming
+// API for generating or reading data from a worksheet with huge amounts of
+// data. This library needs Go version 1.15 or later.
+
+package excelize
+
+import "encoding/xml"
+
+// xlsxMetadata directly maps the metadata element. A cell in a spreadsheet
+// application can have metadata associated with it. Metadata is just a set of
+// additional properties about the particular cell, and this metadata is
+// stored in the metadata part. The value metadata of the cells which contain
+// the rich data types, such as stocks and geography, refer to the rich values
+// by the future metadata.
+type xlsxMetadata struct {
+	XMLName        xml.Name             `xml:"http://schemas.openxmlformats.org/spreadsheetml/2006/main metadata"`
+	MetadataTypes  *xlsxMetadataTypes   `xml:"metadataTypes"`
+	FutureMetadata []xlsxFutureMetadata `xml:"futureMetadata"`
+	CellMetadata   *xlsxMetadataBlocks  `xml:"cellMetadata"`
+	ValueMetadata  *xlsxMetadataBlocks  `xml:"valueMetadata"`
+}
+
+// xlsxMetadataTypes directly maps the metadataTypes element. This element
+// represents the list of the metadata types.
+type xlsxMetadataTypes struct {
+	Count        int                `xml:"count,attr"`
+	MetadataType []xlsxMetadataType `xml:"metadataType"`
+}
+
+// xlsxMetadataType directly maps the metadataType element. This element
+// represents a single metadata type.
+type xlsxMetadataType struct {
+	Name string `xml:"name,attr"`
+}
+
+// xlsxFutureMetadata directly maps the futureMetadata element. This element
+// represents future metadata information, the blocks of the future metadata
+// named "XLRICHVALUE" refer to the indexes of the rich values.
+type xlsxFutureMetadata struct {
+	Name  string                    `xml:"name,attr"`
+	Count int                       `xml:"count,attr"`
+	Bk    []xlsxFutureMetadataBlock `xml:"bk"`
+}
+
+// xlsxFutureMetadataBlock directly maps the bk element in the future
+// metadata.
+type xlsxFutureMetadataBlock struct {
+	ExtLst struct {
+		Ext []struct {
+			URI string `xml:"uri,attr"`
+			Rvb *struct {
+				I int `xml:"i,attr"`
+			} `xml:"rvb"`
+		} `xml:"ext"`
+	} `xml:"extLst"`
+}
+
+// xlsxMetadataBlocks directly maps the cellMetadata and valueMetadata
+// elements. This element represents the list of the metadata blocks, the
+// metadata index of the cell is the 1-based index of the block.
+type xlsxMetadataBlocks struct {
+	Count int                 `xml:"count,attr"`
+	Bk    []xlsxMetadataBlock `xml:"bk"`
+}
+
+// xlsxMetadataBlock directly maps the bk element. This element represents a
+// block of metadata records.
+type xlsxMetadataBlock struct {
+	Rc []xlsxMetadataRecord `xml:"rc"`
+}
+
+// xlsxMetadataRecord directly maps the rc element. This element represents a
+// reference to a metadata record, the T specifies the 1-based index of the
+// metadata type and the V specifies the 0-based index of the metadata
+// record of this type.
+type xlsxMetadataRecord struct {
+	T int `xml:"t,attr"`
+	V int `xml:"v,attr"`
+}
+
+// xlsxRichValueData directly maps the rvData element in the namespace
+// http://schemas.microsoft.com/office/spreadsheetml/2017/richdata. This
+// element specifies the rich values of the workbook.
+type xlsxRichValueData struct {
+	XMLName xml.Name        `xml:"rvData"`
+	Count   int             `xml:"count,attr"`
+	Rv      []xlsxRichValue `xml:"rv"`
+}
+
+// xlsxRichValue directly maps the rv element. This element specifies a rich
+// value, the S specifies the index of the rich value structure which defines
+// the keys of the values.
+type xlsxRichValue struct {
+	S int                  `xml:"s,attr"`
+	V []xlsxRichValueValue `xml:"v"`
+}
+
+// xlsxRichValueValue directly maps the v element in the rich value.
+type xlsxRichValueValue struct {
+	T   string `xml:"t,attr,omitempty"`
+	Val string `xml:",chardata"`
+}
+
+// xlsxRichValueStructures directly maps the rvStructures element in the
+// namespace http://schemas.microsoft.com/office/spreadsheetml/2017/richdata.
+// This element specifies the structures of the rich values.
+type xlsxRichValueStructures struct {
+	XMLName xml.Name                 `xml:"rvStructures"`
+	Count   int                      `xml:"count,attr"`
+	S       []xlsxRichValueStructure `xml:"s"`
+}
+
+// xlsxRichValueStructure directly maps the s element. This element specifies
+// the type and the keys of the rich value structure.
+type xlsxRichValueStructure struct {
+	T string                   `xml:"t,attr"`
+	K []xlsxRichValueStructKey `xml:"k"`
+}
+
+// xlsxRichValueStructKey directly maps the k element. This element specifies
+// the name and the type of a key in the rich value structure.
+type xlsxRichValueStructKey struct {
+	N string `xml:"n,attr"`
+	T string `xml:"t,attr,omitempty"`
+}
+
+// RichValue directly maps the rich value of the cell which contains the rich
+// data type, such as stocks and geography. Type specifies the type of the
+// rich value structure, such as "_linkedentity". Fields specifies the keys
+// and values of the rich value in order.
+type RichValue struct {
+	Type   string
+	Fields []RichValueField
+}
+
+// RichValueField directly maps a key and value of the rich value. Type
+// specifies the type of the value defined in the rich value structure, such
+// as "s" for string, "b" for boolean and "r" for the index of another rich
+// value, the number value has an empty type.
+type RichValueField struct {
+	Name  string
+	Type  string
+	Value string
+}
+
+// CellMetadata directly maps the metadata indices of the cell. CellMetadata
+// specifies the 1-based index of the cell metadata block in the metadata
+// part, such as the block which marks the formula of the cell as a dynamic
+// array formula. ValueMetadata specifies the 1-based index of the value
+// metadata block in the metadata part, such as the block which refers to the
+// rich value of the cell. The zero value means the cell has no metadata.
+type CellMetadata struct {
+	CellMetadata  int
+	ValueMetadata int
+}
@@ -25,6 +25,7 @@ type xlsxSST struct {
 	Count       int      `xml:"count,attr"`
 	UniqueCount int      `xml:"uniqueCount,attr"`
 	SI          []xlsxSI `xml:"si"`
+	values      []*string
 }
 
 // xlsxSI (String Item) is the representation of an individual string in the
@@ -85,3 +86,13 @@ type RichTextRun struct {
 	Font *Font
 	Text string
 }
+
+// PhoneticRun directly maps the settings of the phonetic run, which gives the
+// pronunciation hint (furigana) of the East Asian text in the cell. Start and
+// End specifies the zero-based start and end (exclusive) character position
+// of the base text which the phonetic run applies to.
+type PhoneticRun struct {
+	Start int
+	End   int
+	Text  string
+}
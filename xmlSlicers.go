@@ -0,0 +1,211 @@
+// Copyright 2016 - 2022 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to and
+// read from XLAM / XLSM / XLSX / XLTM / XLTX files. Supports reading and
+// writing spreadsheet documents generated by Microsoft Excel™ 2007 and later.
+// Supports complex components by high compatibility, and provided streaming
+// API for generating or reading data from a worksheet with huge amounts of
+// data. This library needs Go version 1.15 or later.
+
+package excelize
+
+import "encoding/xml"
+
+// xlsxSlicers directly maps the slicers element. This element specifies the
+// slicer views of the worksheet, each slicer view is associated with a slicer
+// cache.
+type xlsxSlicers struct {
+	XMLName  xml.Name     `xml:"http://schemas.microsoft.com/office/spreadsheetml/2009/9/main slicers"`
+	XMLNSXMC string       `xml:"xmlns:mc,attr"`
+	XMLNSX   string       `xml:"xmlns:x,attr"`
+	Slicer   []xlsxSlicer `xml:"slicer"`
+}
+
+// xlsxSlicer directly maps the slicer element. This element specifies the
+// caption, the style and the layout of a slicer view.
+type xlsxSlicer struct {
+	Name           string `xml:"name,attr"`
+	Cache          string `xml:"cache,attr"`
+	Caption        string `xml:"caption,attr,omitempty"`
+	StartItem      *int   `xml:"startItem,attr"`
+	ColumnCount    *int   `xml:"columnCount,attr"`
+	ShowCaption    *bool  `xml:"showCaption,attr"`
+	Level          int    `xml:"level,attr,omitempty"`
+	Style          string `xml:"style,attr,omitempty"`
+	LockedPosition bool   `xml:"lockedPosition,attr,omitempty"`
+	RowHeight      int    `xml:"rowHeight,attr"`
+}
+
+// xlsxSlicerCacheDefinition directly maps the slicerCacheDefinition element.
+// This element specifies the source of a slicer cache, which is a field of
+// the pivot tables or a column of a table.
+type xlsxSlicerCacheDefinition struct {
+	XMLName     xml.Name                    `xml:"http://schemas.microsoft.com/office/spreadsheetml/2009/9/main slicerCacheDefinition"`
+	XMLNSXMC    string                      `xml:"xmlns:mc,attr"`
+	XMLNSX      string                      `xml:"xmlns:x,attr"`
+	Name        string                      `xml:"name,attr"`
+	SourceName  string                      `xml:"sourceName,attr"`
+	PivotTables *xlsxSlicerCachePivotTables `xml:"pivotTables"`
+	Data        *xlsxSlicerCacheData        `xml:"data"`
+	ExtLst      *xlsxExtLst                 `xml:"extLst"`
+}
+
+// xlsxSlicerCachePivotTables directly maps the pivotTables element of the
+// slicer cache definition.
+type xlsxSlicerCachePivotTables struct {
+	PivotTable []xlsxSlicerCachePivotTable `xml:"pivotTable"`
+}
+
+// xlsxSlicerCachePivotTable directly maps the pivotTable element, which
+// specifies a pivot table associated with the slicer cache by the sheet ID
+// of the worksheet and the name of the pivot table.
+type xlsxSlicerCachePivotTable struct {
+	TabID int    `xml:"tabId,attr"`
+	Name  string `xml:"name,attr"`
+}
+
+// xlsxSlicerCacheData directly maps the data element of the slicer cache
+// definition.
+type xlsxSlicerCacheData struct {
+	Tabular *xlsxTabularSlicerCache `xml:"tabular"`
+}
+
+// xlsxTabularSlicerCache directly maps the tabular element, which specifies
+// the pivot cache of the slicer cache for the non-OLAP data source.
+type xlsxTabularSlicerCache struct {
+	PivotCacheID int                          `xml:"pivotCacheId,attr"`
+	SortOrder    string                       `xml:"sortOrder,attr,omitempty"`
+	Items        *xlsxTabularSlicerCacheItems `xml:"items"`
+}
+
+// xlsxTabularSlicerCacheItems directly maps the items element of the tabular
+// slicer cache.
+type xlsxTabularSlicerCacheItems struct {
+	Count int                          `xml:"count,attr"`
+	I     []xlsxTabularSlicerCacheItem `xml:"i"`
+}
+
+// xlsxTabularSlicerCacheItem directly maps the i element, which specifies an
+// item of the slicer cache by the index of the shared item of the cache
+// field.
+type xlsxTabularSlicerCacheItem struct {
+	X int  `xml:"x,attr"`
+	S bool `xml:"s,attr,omitempty"`
+}
+
+// xlsxTableSlicerCache directly maps the tableSlicerCache element, which
+// specifies the table and the column of the table slicer cache.
+type xlsxTableSlicerCache struct {
+	XMLName   xml.Name `xml:"x15:tableSlicerCache"`
+	TableID   int      `xml:"tableId,attr"`
+	Column    int      `xml:"column,attr"`
+	SortOrder string   `xml:"sortOrder,attr,omitempty"`
+}
+
+// xlsxSlicerList directly maps the list elements of the slicers in the
+// extension lists, such as the x14:slicerList and x15:slicerList in the
+// worksheet, and the x14:slicerCaches and x15:slicerCaches in the workbook.
+type xlsxSlicerList struct {
+	XMLName  xml.Name
+	XMLNSX14 string `xml:"xmlns:x14,attr"`
+	XMLNSX15 string `xml:"xmlns:x15,attr,omitempty"`
+	Content  string `xml:",innerxml"`
+}
+
+// xlsxSlicerListItem directly maps the x14:slicer and x14:slicerCache
+// elements, which reference the part by relationship ID.
+type xlsxSlicerListItem struct {
+	XMLName xml.Name
+	RID     string `xml:"r:id,attr"`
+}
+
+// xlsxX14PivotCacheDefinition directly maps the x14:pivotCacheDefinition
+// element in the extension list of the pivot cache definition.
+type xlsxX14PivotCacheDefinition struct {
+	XMLName      xml.Name `xml:"x14:pivotCacheDefinition"`
+	XMLNSX14     string   `xml:"xmlns:x14,attr"`
+	PivotCacheID int      `xml:"pivotCacheId,attr"`
+}
+
+// decodeX14PivotCacheDefinition defines the structure used to parse the
+// x14:pivotCacheDefinition element.
+type decodeX14PivotCacheDefinition struct {
+	PivotCacheID int `xml:"pivotCacheId,attr"`
+}
+
+// xdrTwoCellAnchor directly maps the twoCellAnchor element in the alternate
+// content of the drawing.
+type xdrTwoCellAnchor struct {
+	XMLName xml.Name `xml:"xdr:twoCellAnchor"`
+	xdrCellAnchor
+}
+
+// decodeTableSlicerCache defines the structure used to parse the
+// tableSlicerCache element of the slicer cache definition.
+type decodeTableSlicerCache struct {
+	TableID   int    `xml:"tableId,attr"`
+	Column    int    `xml:"column,attr"`
+	SortOrder string `xml:"sortOrder,attr"`
+}
+
+// decodeSlicerCacheDefinitionExt defines the structure used to parse the
+// extension list of the slicer cache definition.
+type decodeSlicerCacheDefinitionExt struct {
+	Ext []struct {
+		URI              string                  `xml:"uri,attr"`
+		TableSlicerCache *decodeTableSlicerCache `xml:"tableSlicerCache"`
+	} `xml:"ext"`
+}
+
+// decodeSlicerList defines the structure used to parse the x14:slicerList and
+// x15:slicerList elements in the extension list of the worksheet.
+type decodeSlicerList struct {
+	Slicer []struct {
+		RID string `xml:"id,attr"`
+	} `xml:"slicer"`
+}
+
+// decodeSlicerAnchor defines the structure used to parse the two cell anchor
+// of the slicer in the alternate content of the drawing.
+type decodeSlicerAnchor struct {
+	Choice struct {
+		TwoCellAnchor struct {
+			From         *decodeFrom `xml:"from"`
+			GraphicFrame struct {
+				Graphic struct {
+					GraphicData struct {
+						Slicer *struct {
+							Name string `xml:"name,attr"`
+						} `xml:"slicer"`
+					} `xml:"graphicData"`
+				} `xml:"graphic"`
+			} `xml:"graphicFrame"`
+		} `xml:"twoCellAnchor"`
+	} `xml:"Choice"`
+}
+
+// SlicerOptions directly maps the settings of the slicer. Name specifies the
+// name of the table column or the pivot table field to filter. Cell
+// specifies the top-left cell of the slicer. TableSheet and TableName
+// specify the worksheet name and the name of the table or the pivot table,
+// the default TableSheet is the worksheet of the slicer.
+// Caption specifies the header text of the slicer, the default value is the
+// same as the Name. Width and Height specify the size of the slicer in
+// pixels, the default size is 200 x 200 pixels. DisplayHeader specifies if
+// show the header of the slicer, the default value is true. ItemDesc
+// specifies if sort the items in descending order. Style specifies the
+// built-in style name of the slicer, such as "SlicerStyleLight1".
+type SlicerOptions struct {
+	Name          string
+	Cell          string
+	TableSheet    string
+	TableName     string
+	Caption       string
+	Width         int
+	Height        int
+	DisplayHeader *bool
+	ItemDesc      bool
+	Style         string
+}
@@ -72,7 +72,7 @@ type xlsxLine struct {
 type xlsxColor struct {
 	Auto    bool    `xml:"auto,attr,omitempty" json:"auto,omitempty"`
 	RGB     string  `xml:"rgb,attr,omitempty" json:"rgb,omitempty"`
-	Indexed int     `xml:"indexed,attr,omitempty" json:"indexed,omitempty"`
+	Indexed *int    `xml:"indexed,attr" json:"indexed,omitempty"`
 	Theme   *int    `xml:"theme,attr" json:"theme,omitempty"`
 	Tint    float64 `xml:"tint,attr,omitempty" json:"tint,omitempty"`
 }
@@ -87,20 +87,21 @@ type xlsxFonts struct {
 // xlsxFont directly maps the font element. This element defines the
 // properties for one of the fonts used in this workbook.
 type xlsxFont struct {
-	B        *attrValBool   `xml:"b" json:"b,omitempty"`
-	I        *attrValBool   `xml:"i" json:"i,omitempty"`
-	Strike   *attrValBool   `xml:"strike" json:"strike,omitempty"`
-	Outline  *attrValBool   `xml:"outline" json:"outline,omitempty"`
-	Shadow   *attrValBool   `xml:"shadow" json:"shadow,omitempty"`
-	Condense *attrValBool   `xml:"condense" json:"condense,omitempty"`
-	Extend   *attrValBool   `xml:"extend" json:"extend,omitempty"`
-	U        *attrValString `xml:"u" json:"u,omitempty"`
-	Sz       *attrValFloat  `xml:"sz" json:"sz,omitempty"`
-	Color    *xlsxColor     `xml:"color" json:"color,omitempty"`
-	Name     *attrValString `xml:"name" json:"name,omitempty"`
-	Family   *attrValInt    `xml:"family" json:"family,omitempty"`
-	Charset  *attrValInt    `xml:"charset" json:"charset,omitempty"`
-	Scheme   *attrValString `xml:"scheme" json:"scheme,omitempty"`
+	B         *attrValBool   `xml:"b" json:"b,omitempty"`
+	I         *attrValBool   `xml:"i" json:"i,omitempty"`
+	Strike    *attrValBool   `xml:"strike" json:"strike,omitempty"`
+	Outline   *attrValBool   `xml:"outline" json:"outline,omitempty"`
+	Shadow    *attrValBool   `xml:"shadow" json:"shadow,omitempty"`
+	Condense  *attrValBool   `xml:"condense" json:"condense,omitempty"`
+	Extend    *attrValBool   `xml:"extend" json:"extend,omitempty"`
+	U         *attrValString `xml:"u" json:"u,omitempty"`
+	VertAlign *attrValString `xml:"vertAlign" json:"vertAlign,omitempty"`
+	Sz        *attrValFloat  `xml:"sz" json:"sz,omitempty"`
+	Color     *xlsxColor     `xml:"color" json:"color,omitempty"`
+	Name      *attrValString `xml:"name" json:"name,omitempty"`
+	Family    *attrValInt    `xml:"family" json:"family,omitempty"`
+	Charset   *attrValInt    `xml:"charset" json:"charset,omitempty"`
+	Scheme    *attrValString `xml:"scheme" json:"scheme,omitempty"`
 }
 
 // xlsxFills directly maps the fills element. This element defines the cell
@@ -310,10 +311,33 @@ type xlsxNumFmt struct {
 // legacy color palette has been modified (backwards compatibility settings) or
 // a custom color has been selected while using this workbook.
 type xlsxStyleColors struct {
-	Color string `xml:",innerxml"`
+	IndexedColors *xlsxIndexedColors `xml:"indexedColors"`
+	MruColors     *xlsxInnerXML      `xml:"mruColors"`
 }
 
-// Alignment directly maps the alignment settings of the cells.
+// xlsxIndexedColors directly maps the indexedColors element. A deprecated
+// indexing scheme for colors that is still required for some records, and
+// for backwards compatibility with legacy formats. The element contains a
+// sequence of RGB color values that correspond to color indexes (zero-based).
+type xlsxIndexedColors struct {
+	RgbColor []xlsxRgbColor `xml:"rgbColor"`
+}
+
+// xlsxRgbColor directly maps the rgbColor element. This element specifies an
+// ARGB color value of the legacy color palette.
+type xlsxRgbColor struct {
+	RGB string `xml:"rgb,attr"`
+}
+
+// TextRotationVertical defined the text rotation of the alignment settings
+// for the vertical stacked text, the letters are stacked top to bottom.
+const TextRotationVertical = 255
+
+// Alignment directly maps the alignment settings of the cells. The
+// TextRotation specifies the rotation of the text in degrees, the value range
+// is from -90 (rotate downward) to 90 (rotate upward), the value of 91 to 180
+// also means the rotation downward by 1 to 90 degrees as the Office Open XML
+// did, and use TextRotationVertical for the vertical stacked text.
 type Alignment struct {
 	Horizontal      string `json:"horizontal"`
 	Indent          int    `json:"indent"`
@@ -371,6 +395,7 @@ type Style struct {
 	CustomNumFmt  *string     `json:"custom_number_format"`
 	Lang          string      `json:"lang"`
 	NegRed        bool        `json:"negred"`
+	QuotePrefix   bool        `json:"quote_prefix"`
 }
 
 type StyleOutput struct {
@@ -382,3 +407,35 @@ type StyleOutput struct {
 	Alignment  *xlsxAlignment  `json:"alignment,omitempty"`
 	Protection *xlsxProtection `json:"protection,omitempty"`
 }
+
+// StyleSheetSnapshot directly maps the read-only view of the styles of the
+// workbook. The indexes of the slices are the same as the indexes used by the
+// cell formats, for example the FontID of a cell format is the index of the
+// Fonts.
+type StyleSheetSnapshot struct {
+	NumFmts      []StyleNumFmt
+	Fonts        []Font
+	Fills        []Fill
+	Borders      [][]Border
+	CellStyleXfs []StyleXf
+	CellXfs      []StyleXf
+}
+
+// StyleNumFmt directly maps the custom number format of the workbook.
+type StyleNumFmt struct {
+	ID         int
+	FormatCode string
+}
+
+// StyleXf directly maps the cell format of the workbook. The XfID is the
+// index of the cell style format which the cell format is based on.
+type StyleXf struct {
+	NumFmtID    int
+	FontID      int
+	FillID      int
+	BorderID    int
+	XfID        int
+	QuotePrefix bool
+	Alignment   *Alignment
+	Protection  *Protection
+}
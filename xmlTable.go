@@ -21,12 +21,13 @@ import "encoding/xml"
 type xlsxTable struct {
 	XMLName              xml.Name            `xml:"table"`
 	XMLNS                string              `xml:"xmlns,attr"`
+	ConnectionID         int                 `xml:"connectionId,attr,omitempty"`
 	DataCellStyle        string              `xml:"dataCellStyle,attr,omitempty"`
 	DataDxfID            int                 `xml:"dataDxfId,attr,omitempty"`
 	DisplayName          string              `xml:"displayName,attr,omitempty"`
 	HeaderRowBorderDxfID int                 `xml:"headerRowBorderDxfId,attr,omitempty"`
 	HeaderRowCellStyle   string              `xml:"headerRowCellStyle,attr,omitempty"`
-	HeaderRowCount       int                 `xml:"headerRowCount,attr,omitempty"`
+	HeaderRowCount       *int                `xml:"headerRowCount,attr"`
 	HeaderRowDxfID       int                 `xml:"headerRowDxfId,attr,omitempty"`
 	ID                   int                 `xml:"id,attr"`
 	InsertRow            bool                `xml:"insertRow,attr,omitempty"`
@@ -34,12 +35,14 @@ type xlsxTable struct {
 	Name                 string              `xml:"name,attr"`
 	Published            bool                `xml:"published,attr,omitempty"`
 	Ref                  string              `xml:"ref,attr"`
+	TableType            string              `xml:"tableType,attr,omitempty"`
 	TotalsRowCount       int                 `xml:"totalsRowCount,attr,omitempty"`
 	TotalsRowDxfID       int                 `xml:"totalsRowDxfId,attr,omitempty"`
 	TotalsRowShown       bool                `xml:"totalsRowShown,attr"`
 	AutoFilter           *xlsxAutoFilter     `xml:"autoFilter"`
 	TableColumns         *xlsxTableColumns   `xml:"tableColumns"`
 	TableStyleInfo       *xlsxTableStyleInfo `xml:"tableStyleInfo"`
+	ExtLst               *xlsxExtLst         `xml:"extLst"`
 }
 
 // xlsxAutoFilter temporarily hides rows based on a filter criteria, which is
@@ -120,6 +123,7 @@ type xlsxColorFilter struct {
 // cells whose values do not meet the specified criteria, the corresponding rows
 // shall be hidden from view when the filter is applied.
 type xlsxDynamicFilter struct {
+	MaxVal    float64 `xml:"maxVal,attr,omitempty"`
 	MaxValISO string  `xml:"maxValIso,attr,omitempty"`
 	Type      string  `xml:"type,attr,omitempty"`
 	Val       float64 `xml:"val,attr,omitempty"`
@@ -171,18 +175,30 @@ type xlsxTableColumns struct {
 // xlsxTableColumn directly maps the element representing a single column for
 // this table.
 type xlsxTableColumn struct {
-	DataCellStyle      string `xml:"dataCellStyle,attr,omitempty"`
-	DataDxfID          int    `xml:"dataDxfId,attr,omitempty"`
-	HeaderRowCellStyle string `xml:"headerRowCellStyle,attr,omitempty"`
-	HeaderRowDxfID     int    `xml:"headerRowDxfId,attr,omitempty"`
-	ID                 int    `xml:"id,attr"`
-	Name               string `xml:"name,attr"`
-	QueryTableFieldID  int    `xml:"queryTableFieldId,attr,omitempty"`
-	TotalsRowCellStyle string `xml:"totalsRowCellStyle,attr,omitempty"`
-	TotalsRowDxfID     int    `xml:"totalsRowDxfId,attr,omitempty"`
-	TotalsRowFunction  string `xml:"totalsRowFunction,attr,omitempty"`
-	TotalsRowLabel     string `xml:"totalsRowLabel,attr,omitempty"`
-	UniqueName         string `xml:"uniqueName,attr,omitempty"`
+	DataCellStyle           string            `xml:"dataCellStyle,attr,omitempty"`
+	DataDxfID               int               `xml:"dataDxfId,attr,omitempty"`
+	HeaderRowCellStyle      string            `xml:"headerRowCellStyle,attr,omitempty"`
+	HeaderRowDxfID          int               `xml:"headerRowDxfId,attr,omitempty"`
+	ID                      int               `xml:"id,attr"`
+	Name                    string            `xml:"name,attr"`
+	QueryTableFieldID       int               `xml:"queryTableFieldId,attr,omitempty"`
+	TotalsRowCellStyle      string            `xml:"totalsRowCellStyle,attr,omitempty"`
+	TotalsRowDxfID          int               `xml:"totalsRowDxfId,attr,omitempty"`
+	TotalsRowFunction       string            `xml:"totalsRowFunction,attr,omitempty"`
+	TotalsRowLabel          string            `xml:"totalsRowLabel,attr,omitempty"`
+	UniqueName              string            `xml:"uniqueName,attr,omitempty"`
+	CalculatedColumnFormula *xlsxTableFormula `xml:"calculatedColumnFormula"`
+	TotalsRowFormula        *xlsxTableFormula `xml:"totalsRowFormula"`
+}
+
+// xlsxTableFormula directly maps the calculatedColumnFormula and
+// totalsRowFormula element. The calculatedColumnFormula element specifies
+// the formula that applied to all cells in the data area of the table
+// column, and the totalsRowFormula element specifies the formula of the
+// totals row cell of the table column.
+type xlsxTableFormula struct {
+	Content string `xml:",chardata"`
+	Array   bool   `xml:"array,attr,omitempty"`
 }
 
 // xlsxTableStyleInfo directly maps the tableStyleInfo element. This element
@@ -196,6 +212,36 @@ type xlsxTableStyleInfo struct {
 	ShowColumnStripes bool   `xml:"showColumnStripes,attr"`
 }
 
+// decodeTableStyleElements defines the structure used to parse the elements
+// of the custom table style, each element references the differential
+// formatting by index.
+type decodeTableStyleElements struct {
+	Element []struct {
+		Type  string `xml:"type,attr"`
+		Size  int    `xml:"size,attr"`
+		DxfID *int   `xml:"dxfId,attr"`
+	} `xml:"tableStyleElement"`
+}
+
+// tableStyleDxf defines the differential formatting of the element of the
+// table style, such as the header row, the totals row and the row stripes.
+type tableStyleDxf struct {
+	Font   *xlsxFont         `xml:"font"`
+	Fill   *xlsxFill         `xml:"fill"`
+	Border *tableStyleBorder `xml:"border"`
+}
+
+// tableStyleBorder defines the borders of the element of the table style,
+// the vertical and horizontal borders are the inside borders of the element.
+type tableStyleBorder struct {
+	Left       *xlsxLine `xml:"left"`
+	Right      *xlsxLine `xml:"right"`
+	Top        *xlsxLine `xml:"top"`
+	Bottom     *xlsxLine `xml:"bottom"`
+	Vertical   *xlsxLine `xml:"vertical"`
+	Horizontal *xlsxLine `xml:"horizontal"`
+}
+
 // formatTable directly maps the format settings of the table.
 type formatTable struct {
 	TableName         string `json:"table_name"`
@@ -206,6 +252,135 @@ type formatTable struct {
 	ShowColumnStripes bool   `json:"show_column_stripes"`
 }
 
+// Table directly maps the settings of the table read from the worksheet.
+type Table struct {
+	Name              string
+	Range             string
+	StyleName         string
+	ShowFirstColumn   bool
+	ShowLastColumn    bool
+	ShowRowStripes    bool
+	ShowColumnStripes bool
+	Columns           []TableColumn
+}
+
+// TableColumn directly maps the settings of the table column. The
+// CalculatedColumnFormula is the formula applied to all data cells of a
+// calculated column, and the TotalsRowFormula is the custom formula of the
+// totals row cell of the column.
+type TableColumn struct {
+	Name                    string
+	CalculatedColumnFormula string
+	TotalsRowFunction       string
+	TotalsRowFormula        string
+}
+
+// ReportTableOptions directly maps the settings of the report table created
+// by the AddReportTable function. TableName and TableStyle specifies the
+// name and the built-in style of the table. Headers specifies the header
+// names of the table columns, the existing header cells will be used if
+// it's empty. HeaderStyleID specifies the cell style index of the header row.
+// Columns specifies the settings of each table column in order. FreezeHeader
+// specifies if freeze the rows above and including the header row.
+type ReportTableOptions struct {
+	TableName         string
+	TableStyle        string
+	Headers           []string
+	HeaderStyleID     int
+	Columns           []ReportTableColumn
+	ShowRowStripes    bool
+	ShowColumnStripes bool
+	FreezeHeader      bool
+}
+
+// ReportTableColumn directly maps the settings of the column of the report
+// table. NumFmt specifies the number format code of the column data and
+// total cells. TotalsRowFunction specifies the aggregation function of the
+// column in the totals row, one of "average", "count", "countNums", "max",
+// "min", "stdDev", "sum" and "var". TotalsRowLabel specifies the text in the
+// totals row of the column, which is usually set for the first column.
+type ReportTableColumn struct {
+	NumFmt            string
+	TotalsRowFunction string
+	TotalsRowLabel    string
+}
+
+// AutoFilter directly maps the settings of the auto filter read from the
+// worksheet. The Columns are the columns of the range which have the filter
+// criteria applied.
+type AutoFilter struct {
+	Range   string
+	Columns []AutoFilterColumn
+}
+
+// AutoFilterColumn directly maps the filter criteria of a column in the auto
+// filter range. The Type is the type of the filter criteria, one of
+// "filters", "customFilters", "top10", "dynamicFilter", "colorFilter" and
+// "iconFilter". The Values are the cell values to be shown, and the Blank
+// specifies whether the blank cells are shown. The CustomFilters are the
+// custom filter criteria, which are joined by 'and' if the And is true,
+// otherwise by 'or'. The Top10, DynamicFilter, ColorFilter and IconFilter
+// are the settings of the other types of the filter criteria.
+type AutoFilterColumn struct {
+	Column        string
+	Type          string
+	Values        []string
+	Blank         bool
+	CustomFilters []AutoFilterCustomFilter
+	And           bool
+	Top10         *AutoFilterTop10
+	DynamicFilter *AutoFilterDynamicFilter
+	ColorFilter   *AutoFilterColorFilter
+	IconFilter    *AutoFilterIconFilter
+}
+
+// AutoFilterTop10 directly maps the top or bottom N filter criteria of the
+// auto filter column. The Value is the number of items, or the percent if
+// the Percent is true, and the FilterValue is the actual cell value which
+// is used as the threshold of the filter.
+type AutoFilterTop10 struct {
+	Top         bool
+	Percent     bool
+	Value       float64
+	FilterValue float64
+}
+
+// AutoFilterDynamicFilter directly maps the dynamic filter criteria of the
+// auto filter column, such as the type "aboveAverage" or "today". The Value
+// and MaxValue are the cached values of the criteria, and the ValueISO and
+// MaxValueISO are the cached date time values of the criteria in the ISO
+// 8601 format.
+type AutoFilterDynamicFilter struct {
+	Type        string
+	Value       float64
+	MaxValue    float64
+	ValueISO    string
+	MaxValueISO string
+}
+
+// AutoFilterColorFilter directly maps the color filter criteria of the auto
+// filter column. The DxfID is the index of the differential format which
+// specifies the color, and the CellColor specifies whether to filter by the
+// fill color of the cells, otherwise by the font color.
+type AutoFilterColorFilter struct {
+	CellColor bool
+	DxfID     int
+}
+
+// AutoFilterIconFilter directly maps the icon filter criteria of the auto
+// filter column by given icon set and the index of the icon in the set.
+type AutoFilterIconFilter struct {
+	IconSet string
+	IconID  int
+}
+
+// AutoFilterCustomFilter directly maps the custom filter criteria of the auto
+// filter column, such as the operator "greaterThan" with the value "2000".
+type AutoFilterCustomFilter struct {
+	Operator string
+	Value    string
+}
+
 // formatAutoFilter directly maps the auto filter settings.
 type formatAutoFilter struct {
 	Column     string `json:"column"`
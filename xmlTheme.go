@@ -149,3 +149,30 @@ type xlsxSysClr struct {
 	Val     string `xml:"val,attr"`
 	LastClr string `xml:"lastClr,attr"`
 }
+
+// ThemeColors directly maps the colors of the color scheme of the workbook
+// theme in the RRGGBB format.
+type ThemeColors struct {
+	Dark1             string
+	Light1            string
+	Dark2             string
+	Light2            string
+	Accent1           string
+	Accent2           string
+	Accent3           string
+	Accent4           string
+	Accent5           string
+	Accent6           string
+	Hyperlink         string
+	FollowedHyperlink string
+}
+
+// Theme directly maps the color scheme and the font scheme of the workbook
+// theme.
+type Theme struct {
+	ColorSchemeName string
+	Colors          ThemeColors
+	FontSchemeName  string
+	MajorFont       string
+	MinorFont       string
+}
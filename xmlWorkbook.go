@@ -92,6 +92,8 @@ type xlsxWorkbookProtection struct {
 	WorkbookHashValue      string `xml:"workbookHashValue,attr,omitempty"`
 	WorkbookSaltValue      string `xml:"workbookSaltValue,attr,omitempty"`
 	WorkbookSpinCount      int    `xml:"workbookSpinCount,attr,omitempty"`
+	RevisionsPassword      string `xml:"revisionsPassword,attr,omitempty"`
+	WorkbookPassword       string `xml:"workbookPassword,attr,omitempty"`
 }
 
 // xlsxFileVersion directly maps the fileVersion element. This element defines
@@ -48,7 +48,7 @@ type xlsxWorksheet struct {
 	ColBreaks              *xlsxBreaks                  `xml:"colBreaks"`
 	CustomProperties       *xlsxInnerXML                `xml:"customProperties"`
 	CellWatches            *xlsxInnerXML                `xml:"cellWatches"`
-	IgnoredErrors          *xlsxInnerXML                `xml:"ignoredErrors"`
+	IgnoredErrors          *xlsxIgnoredErrors           `xml:"ignoredErrors"`
 	SmartTags              *xlsxInnerXML                `xml:"smartTags"`
 	Drawing                *xlsxDrawing                 `xml:"drawing"`
 	LegacyDrawing          *xlsxLegacyDrawing           `xml:"legacyDrawing"`
@@ -62,7 +62,7 @@ type xlsxWorksheet struct {
 	TableParts             *xlsxTableParts              `xml:"tableParts"`
 	ExtLst                 *xlsxExtLst                  `xml:"extLst"`
 	DecodeAlternateContent *xlsxInnerXML                `xml:"http://schemas.openxmlformats.org/markup-compatibility/2006 AlternateContent"`
-	sharedFormulaCache     map[int]xlsxC                `xml:"shared_formula_cache"`
+	sharedFormulaCache     map[int]xlsxC                `xml:"-"`
 }
 
 // xlsxDrawing change r:id to rid in the namespace.
@@ -82,8 +82,8 @@ type xlsxHeaderFooter struct {
 	XMLName          xml.Name `xml:"headerFooter"`
 	DifferentOddEven bool     `xml:"differentOddEven,attr,omitempty"`
 	DifferentFirst   bool     `xml:"differentFirst,attr,omitempty"`
-	ScaleWithDoc     bool     `xml:"scaleWithDoc,attr,omitempty"`
-	AlignWithMargins bool     `xml:"alignWithMargins,attr,omitempty"`
+	ScaleWithDoc     *bool    `xml:"scaleWithDoc,attr,omitempty"`
+	AlignWithMargins *bool    `xml:"alignWithMargins,attr,omitempty"`
 	OddHeader        string   `xml:"oddHeader,omitempty"`
 	OddFooter        string   `xml:"oddFooter,omitempty"`
 	EvenHeader       string   `xml:"evenHeader,omitempty"`
@@ -265,7 +265,7 @@ type xlsxPageSetUpPr struct {
 // xlsxTabColor represents background color of the sheet tab.
 type xlsxTabColor struct {
 	Auto    bool    `xml:"auto,attr,omitempty"`
-	Indexed int     `xml:"indexed,attr,omitempty"`
+	Indexed *int    `xml:"indexed,attr"`
 	RGB     string  `xml:"rgb,attr,omitempty"`
 	Theme   int     `xml:"theme,attr,omitempty"`
 	Tint    float64 `xml:"tint,attr,omitempty"`
@@ -335,11 +335,35 @@ type xlsxRow struct {
 // xlsxSortState directly maps the sortState element. This collection
 // preserves the AutoFilter sort state.
 type xlsxSortState struct {
-	ColumnSort    bool   `xml:"columnSort,attr,omitempty"`
-	CaseSensitive bool   `xml:"caseSensitive,attr,omitempty"`
-	SortMethod    string `xml:"sortMethod,attr,omitempty"`
-	Ref           string `xml:"ref,attr"`
-	Content       string `xml:",innerxml"`
+	ColumnSort    bool                 `xml:"columnSort,attr,omitempty"`
+	CaseSensitive bool                 `xml:"caseSensitive,attr,omitempty"`
+	SortMethod    string               `xml:"sortMethod,attr,omitempty"`
+	Ref           string               `xml:"ref,attr"`
+	SortCondition []*xlsxSortCondition `xml:"sortCondition"`
+	ExtLst        *xlsxExtLst          `xml:"extLst"`
+}
+
+// xlsxSortCondition directly maps the sortCondition element. This element
+// specifies the column or row, the sort order and the custom list of a sort
+// level of the sort state.
+type xlsxSortCondition struct {
+	Descending bool   `xml:"descending,attr,omitempty"`
+	SortBy     string `xml:"sortBy,attr,omitempty"`
+	Ref        string `xml:"ref,attr"`
+	CustomList string `xml:"customList,attr,omitempty"`
+	DxfID      *int   `xml:"dxfId,attr"`
+	IconSet    string `xml:"iconSet,attr,omitempty"`
+	IconID     *int   `xml:"iconId,attr"`
+}
+
+// SortCondition directly maps the settings of a sort level of the sort state.
+// The Column is the column name of the sort key, which must be inside the
+// sorted range. The CustomList is the comma separated list of the values to
+// sort by, such as "Low,Medium,High".
+type SortCondition struct {
+	Column     string
+	Descending bool
+	CustomList string
 }
 
 // xlsxCustomSheetViews directly maps the customSheetViews element. This is a
@@ -366,6 +390,30 @@ type xlsxBreaks struct {
 	ManualBreakCount int        `xml:"manualBreakCount,attr,omitempty"`
 }
 
+// xlsxIgnoredErrors directly maps the ignoredErrors element. This collection
+// of ignored errors specifies the error conditions which shall be ignored for
+// the ranges of cells, so the application will not flag these cells with the
+// error indicator.
+type xlsxIgnoredErrors struct {
+	IgnoredError []*xlsxIgnoredError `xml:"ignoredError"`
+	ExtLst       *xlsxExtLst         `xml:"extLst"`
+}
+
+// xlsxIgnoredError directly maps the ignoredError element. This element
+// specifies a single ignored error for a range of cells.
+type xlsxIgnoredError struct {
+	Sqref              string `xml:"sqref,attr"`
+	EvalError          bool   `xml:"evalError,attr,omitempty"`
+	TwoDigitTextYear   bool   `xml:"twoDigitTextYear,attr,omitempty"`
+	NumberStoredAsText bool   `xml:"numberStoredAsText,attr,omitempty"`
+	Formula            bool   `xml:"formula,attr,omitempty"`
+	FormulaRange       bool   `xml:"formulaRange,attr,omitempty"`
+	UnlockedFormula    bool   `xml:"unlockedFormula,attr,omitempty"`
+	EmptyCellReference bool   `xml:"emptyCellReference,attr,omitempty"`
+	ListDataValidation bool   `xml:"listDataValidation,attr,omitempty"`
+	CalculatedColumn   bool   `xml:"calculatedColumn,attr,omitempty"`
+}
+
 // xlsxCustomSheetView directly maps the customSheetView element.
 type xlsxCustomSheetView struct {
 	Pane           *xlsxPane         `xml:"pane"`
@@ -383,10 +431,10 @@ type xlsxCustomSheetView struct {
 	ColorID        int               `xml:"colorId,attr,omitempty"`
 	ShowPageBreaks bool              `xml:"showPageBreaks,attr,omitempty"`
 	ShowFormulas   bool              `xml:"showFormulas,attr,omitempty"`
-	ShowGridLines  bool              `xml:"showGridLines,attr,omitempty"`
-	ShowRowCol     bool              `xml:"showRowCol,attr,omitempty"`
-	OutlineSymbols bool              `xml:"outlineSymbols,attr,omitempty"`
-	ZeroValues     bool              `xml:"zeroValues,attr,omitempty"`
+	ShowGridLines  *bool             `xml:"showGridLines,attr"`
+	ShowRowCol     *bool             `xml:"showRowCol,attr"`
+	OutlineSymbols *bool             `xml:"outlineSymbols,attr"`
+	ZeroValues     *bool             `xml:"zeroValues,attr"`
 	FitToPage      bool              `xml:"fitToPage,attr,omitempty"`
 	PrintArea      bool              `xml:"printArea,attr,omitempty"`
 	Filter         bool              `xml:"filter,attr,omitempty"`
@@ -396,7 +444,7 @@ type xlsxCustomSheetView struct {
 	State          string            `xml:"state,attr,omitempty"`
 	FilterUnique   bool              `xml:"filterUnique,attr,omitempty"`
 	View           string            `xml:"view,attr,omitempty"`
-	ShowRuler      bool              `xml:"showRuler,attr,omitempty"`
+	ShowRuler      *bool             `xml:"showRuler,attr"`
 	TopLeftCell    string            `xml:"topLeftCell,attr,omitempty"`
 }
 
@@ -847,6 +895,8 @@ type formatConditional struct {
 	MaxLength    string `json:"max_length,omitempty"`
 	MultiRange   string `json:"multi_range,omitempty"`
 	BarColor     string `json:"bar_color,omitempty"`
+	Priority     int    `json:"priority,omitempty"`
+	StopIfTrue   bool   `json:"stop_if_true,omitempty"`
 }
 
 // FormatSheetProtection directly maps the settings of worksheet protection.
@@ -872,16 +922,18 @@ type FormatSheetProtection struct {
 
 // FormatHeaderFooter directly maps the settings of header and footer.
 type FormatHeaderFooter struct {
-	AlignWithMargins bool
-	DifferentFirst   bool
-	DifferentOddEven bool
-	ScaleWithDoc     bool
-	OddHeader        string
-	OddFooter        string
-	EvenHeader       string
-	EvenFooter       string
-	FirstHeader      string
-	FirstFooter      string
+	AlignWithMargins   bool
+	DifferentFirst     bool
+	DifferentOddEven   bool
+	ScaleWithDoc       bool
+	OddHeader          string
+	OddFooter          string
+	EvenHeader         string
+	EvenFooter         string
+	FirstHeader        string
+	FirstFooter        string
+	NoAlignWithMargins bool
+	NoScaleWithDoc     bool
 }
 
 // FormatPageMargins directly maps the settings of page margins
@@ -893,3 +945,17 @@ type FormatPageMargins struct {
 	Right  string
 	Top    string
 }
+
+// IgnoredErrorTypes directly maps the types of the error conditions which
+// shall be ignored for a range of cells.
+type IgnoredErrorTypes struct {
+	EvalError          bool
+	TwoDigitTextYear   bool
+	NumberStoredAsText bool
+	Formula            bool
+	FormulaRange       bool
+	UnlockedFormula    bool
+	EmptyCellReference bool
+	ListDataValidation bool
+	CalculatedColumn   bool
+}